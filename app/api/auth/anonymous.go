@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+type anonymousAccessKey struct{}
+
+// WithAnonymousAccessDisabled returns a copy of parent in which anonymous access to public resources is disabled.
+// Public resources then require an authenticated session, same as any other resource.
+func WithAnonymousAccessDisabled(parent context.Context) context.Context {
+	return context.WithValue(parent, anonymousAccessKey{}, false)
+}
+
+// AnonymousAccessEnabled returns false iff anonymous access was disabled for the context.
+func AnonymousAccessEnabled(ctx context.Context) bool {
+	enabled, ok := ctx.Value(anonymousAccessKey{}).(bool)
+	return !ok || enabled
+}
+
+// publicAccessAllowed returns true if the session is allowed to access public resources without further checks.
+func publicAccessAllowed(ctx context.Context, session *auth.Session) bool {
+	return session != nil || AnonymousAccessEnabled(ctx)
+}
@@ -30,8 +30,11 @@ import (
 )
 
 var (
-	ErrNotAuthenticated          = errors.New("not authenticated")
-	ErrNotAuthorized             = errors.New("not authorized")
+	ErrNotAuthenticated = errors.New("not authenticated")
+	ErrNotAuthorized    = errors.New("not authorized")
+	// ErrNotVisible is returned if the principal isn't even allowed to know that the resource exists.
+	// It wraps ErrNotAuthorized, but is reported to users as not found to avoid leaking private resources.
+	ErrNotVisible                = fmt.Errorf("%w: resource not visible", ErrNotAuthorized)
 	ErrParentResourceTypeUnknown = errors.New("Unknown parent resource type")
 	ErrPrincipalTypeUnknown      = errors.New("Unknown principal type")
 )
@@ -63,6 +66,32 @@ func Check(ctx context.Context, authorizer authz.Authorizer, session *auth.Sessi
 	return nil
 }
 
+// CheckVisible checks if a resource specific permission is granted for the current auth session in the scope,
+// same as Check, but distinguishes between principals that can view the resource and those that can't.
+// If the permission isn't granted and the resource isn't public, the view permission is checked as well:
+// principals that can view the resource get NotAuthorized, all others get NotVisible.
+func CheckVisible(ctx context.Context, authorizer authz.Authorizer, session *auth.Session,
+	scope *types.Scope, resource *types.Resource, permission enum.Permission,
+	viewPermission enum.Permission, isPublic bool,
+) error {
+	err := Check(ctx, authorizer, session, scope, resource, permission)
+	if !errors.Is(err, ErrNotAuthorized) || isPublic {
+		return err
+	}
+
+	if permission != viewPermission {
+		errView := Check(ctx, authorizer, session, scope, resource, viewPermission)
+		if errView == nil {
+			return err
+		}
+		if !errors.Is(errView, ErrNotAuthorized) {
+			return errView
+		}
+	}
+
+	return ErrNotVisible
+}
+
 // CheckChild checks if a resource specific permission is granted for the current auth session
 // in the scope of a parent.
 // Returns nil if the permission is granted, otherwise returns an error.
@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+// ErrImpersonated is returned if an impersonated session tries to change the credentials of the user.
+// Impersonation is limited to troubleshooting, it must never allow to gain lasting access to the user.
+var ErrImpersonated = fmt.Errorf("%w: not allowed for impersonated sessions", ErrNotAuthorized)
+
+// CheckNotImpersonated returns ErrImpersonated if the session is impersonated by an admin.
+func CheckNotImpersonated(session *auth.Session) error {
+	if session != nil && session.Impersonator != nil {
+		return ErrImpersonated
+	}
+
+	return nil
+}
@@ -30,7 +30,7 @@ import (
 // CheckRepo checks if a repo specific permission is granted for the current auth session
 // in the scope of its parent.
 // Returns nil if the permission is granted, otherwise returns an error.
-// NotAuthenticated, NotAuthorized, or any underlying error.
+// NotAuthenticated, NotAuthorized, NotVisible (if the repo can't be viewed either), or any underlying error.
 func CheckRepo(
 	ctx context.Context,
 	authorizer authz.Authorizer,
@@ -39,13 +39,51 @@ func CheckRepo(
 	permission enum.Permission,
 	orPublic bool,
 ) error {
-	if orPublic && repo.IsPublic {
+	if orPublic && repo.IsPublic && publicAccessAllowed(ctx, session) {
 		return nil
 	}
 
+	scope, resource, err := getRepoScopeAndResource(repo)
+	if err != nil {
+		return err
+	}
+
+	return CheckVisible(ctx, authorizer, session, scope, resource, permission, enum.PermissionRepoView, repo.IsPublic)
+}
+
+// CheckRepoPrincipal checks if a repo specific permission is granted to the principal itself,
+// independent of the current auth session (e.g. to verify that a principal can be added as reviewer).
+// Public access isn't considered.
+// Returns nil if the permission is granted, otherwise returns an error.
+// NotAuthorized, or any underlying error.
+func CheckRepoPrincipal(
+	ctx context.Context,
+	authorizer authz.Authorizer,
+	principal *types.Principal,
+	repo *types.Repository,
+	permission enum.Permission,
+) error {
+	scope, resource, err := getRepoScopeAndResource(repo)
+	if err != nil {
+		return err
+	}
+
+	authorized, err := authorizer.CheckPrincipal(ctx, principal, scope, resource, permission)
+	if err != nil {
+		return err
+	}
+
+	if !authorized {
+		return ErrNotAuthorized
+	}
+
+	return nil
+}
+
+func getRepoScopeAndResource(repo *types.Repository) (*types.Scope, *types.Resource, error) {
 	parentSpace, name, err := paths.DisectLeaf(repo.Path)
 	if err != nil {
-		return errors.Wrapf(err, "Failed to disect path '%s'", repo.Path)
+		return nil, nil, errors.Wrapf(err, "Failed to disect path '%s'", repo.Path)
 	}
 
 	scope := &types.Scope{SpacePath: parentSpace}
@@ -54,7 +92,7 @@ func CheckRepo(
 		Identifier: name,
 	}
 
-	return Check(ctx, authorizer, session, scope, resource, permission)
+	return scope, resource, nil
 }
 
 func IsRepoOwner(
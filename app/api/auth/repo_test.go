@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// grantOnly denies every permission except for the provided ones.
+func grantOnly(permissions []enum.Permission) fake.DenyFunc {
+	return func(_ *types.Principal, _ *types.Scope, _ *types.Resource, permission enum.Permission) bool {
+		return !slices.Contains(permissions, permission)
+	}
+}
+
+func TestCheckRepo_Visibility(t *testing.T) {
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	tests := []struct {
+		name       string
+		granted    []enum.Permission
+		public     bool
+		permission enum.Permission
+		wantErr    error
+		wantHidden bool
+	}{
+		{
+			name:       "private-no-access",
+			permission: enum.PermissionRepoView,
+			wantErr:    ErrNotVisible,
+			wantHidden: true,
+		},
+		{
+			name:       "private-no-access-push",
+			permission: enum.PermissionRepoPush,
+			wantErr:    ErrNotVisible,
+			wantHidden: true,
+		},
+		{
+			name:       "readable-not-writable",
+			granted:    []enum.Permission{enum.PermissionRepoView},
+			permission: enum.PermissionRepoPush,
+			wantErr:    ErrNotAuthorized,
+		},
+		{
+			name:       "public-not-writable",
+			public:     true,
+			permission: enum.PermissionRepoPush,
+			wantErr:    ErrNotAuthorized,
+		},
+		{
+			name:       "granted",
+			granted:    []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush},
+			permission: enum.PermissionRepoPush,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &types.Repository{Path: "space/repo", IsPublic: test.public}
+
+			err := CheckRepo(context.Background(), &fake.Authorizer{Deny: grantOnly(test.granted)}, session,
+				repo, test.permission, false)
+
+			if test.wantErr == nil {
+				if err != nil {
+					t.Errorf("Want no error, got %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error %v, got %v", test.wantErr, err)
+			}
+			if hidden := errors.Is(err, ErrNotVisible); hidden != test.wantHidden {
+				t.Errorf("Want hidden=%t, got %v", test.wantHidden, err)
+			}
+		})
+	}
+}
+
+func TestCheckRepoPrincipal(t *testing.T) {
+	principal := &types.Principal{ID: 2}
+
+	tests := []struct {
+		name    string
+		granted []enum.Permission
+		public  bool
+		wantErr error
+	}{
+		{name: "no-access", wantErr: ErrNotAuthorized},
+		{name: "public-no-access", public: true, wantErr: ErrNotAuthorized},
+		{name: "granted", granted: []enum.Permission{enum.PermissionRepoView}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &types.Repository{Path: "space/repo", IsPublic: test.public}
+
+			err := CheckRepoPrincipal(context.Background(), &fake.Authorizer{Deny: grantOnly(test.granted)}, principal,
+				repo, enum.PermissionRepoView)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
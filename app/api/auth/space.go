@@ -28,7 +28,8 @@ import (
 
 // CheckSpace checks if a space specific permission is granted for the current auth session
 // in the scope of its parent.
-// Returns nil if permission is granted, otherwise returns NotAuthenticated, NotAuthorized, or the underlying error.
+// Returns nil if permission is granted, otherwise returns NotAuthenticated, NotAuthorized,
+// NotVisible (if the space can't be viewed either), or the underlying error.
 func CheckSpace(
 	ctx context.Context,
 	authorizer authz.Authorizer,
@@ -37,7 +38,7 @@ func CheckSpace(
 	permission enum.Permission,
 	orPublic bool,
 ) error {
-	if orPublic && space.IsPublic {
+	if orPublic && space.IsPublic && publicAccessAllowed(ctx, session) {
 		return nil
 	}
 
@@ -52,7 +53,7 @@ func CheckSpace(
 		Identifier: name,
 	}
 
-	return Check(ctx, authorizer, session, scope, resource, permission)
+	return CheckVisible(ctx, authorizer, session, scope, resource, permission, enum.PermissionSpaceView, space.IsPublic)
 }
 
 // CheckSpaceScope checks if a specific permission is granted for the current auth session
@@ -67,7 +68,7 @@ func CheckSpaceScope(
 	permission enum.Permission,
 	orPublic bool,
 ) error {
-	if orPublic && space.IsPublic {
+	if orPublic && space.IsPublic && publicAccessAllowed(ctx, session) {
 		return nil
 	}
 
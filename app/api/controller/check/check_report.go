@@ -65,8 +65,7 @@ func (in *ReportInput) Sanitize(
 		return usererror.BadRequestf("Identifier must match the regular expression: %s", regexpCheckIdentifier)
 	}
 
-	_, ok := in.Status.Sanitize()
-	if !ok {
+	if !in.Status.Valid() {
 		return usererror.BadRequest("Invalid value provided for status check status")
 	}
 
@@ -131,6 +130,18 @@ func (c *Controller) Report(
 		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
 	}
 
+	return c.report(ctx, session, repo, commitSHA, in, metadata)
+}
+
+// report validates the input and upserts the status check report for the commit in the provided repository.
+func (c *Controller) report(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	commitSHA string,
+	in *ReportInput,
+	metadata map[string]string,
+) (*types.Check, error) {
 	if errValidate := in.Sanitize(c.sanitizers, session); errValidate != nil {
 		return nil, errValidate
 	}
@@ -139,7 +150,7 @@ func (c *Controller) Report(
 		return nil, usererror.BadRequest("invalid commit SHA provided")
 	}
 
-	_, err = c.git.GetCommit(ctx, &git.GetCommitParams{
+	_, err := c.git.GetCommit(ctx, &git.GetCommitParams{
 		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
 		Revision:   commitSHA,
 	})
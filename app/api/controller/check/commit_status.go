@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SetCommitStatusInput is the input for setting the status of a context for a commit.
+// Context identifies the reporting system (e.g. "ci/build") - setting the status again for the same
+// context replaces the previous status.
+type SetCommitStatusInput struct {
+	Context     string                 `json:"context"`
+	State       enum.CommitStatusState `json:"state"`
+	TargetURL   string                 `json:"target_url"`
+	Description string                 `json:"description"`
+}
+
+func (in *SetCommitStatusInput) sanitize() error {
+	state, ok := in.State.Sanitize()
+	if !ok || state == "" {
+		return usererror.BadRequest("Invalid value provided for commit status state")
+	}
+
+	in.State = state
+
+	return nil
+}
+
+// SetCommitStatus sets the status of a context for a commit and returns the stored status.
+// Commit statuses are stored as status checks, with the context used as the check identifier,
+// which requires push permission on the repository.
+func (c *Controller) SetCommitStatus(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *SetCommitStatusInput,
+) (*types.CommitStatus, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	check, err := c.report(ctx, session, repo, commitSHA, &ReportInput{
+		Identifier: in.Context,
+		Status:     in.State.CheckStatus(),
+		Summary:    in.Description,
+		Link:       in.TargetURL,
+		Payload:    types.CheckPayload{Kind: enum.CheckPayloadKindRaw},
+	}, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+
+	status := commitStatusFromCheck(check)
+
+	return &status, nil
+}
+
+// GetCombinedCommitStatus returns the commit statuses of a commit together with their combined state.
+func (c *Controller) GetCombinedCommitStatus(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	opts types.CheckListOptions,
+) (*types.CombinedCommitStatus, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	var results []types.CheckResult
+	var checks []types.Check
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		results, err = c.checkStore.ListResults(ctx, repo.ID, commitSHA)
+		if err != nil {
+			return fmt.Errorf("failed to list status check results for repo=%s: %w", repo.Identifier, err)
+		}
+
+		checks, err = c.checkStore.List(ctx, repo.ID, commitSHA, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list status checks for repo=%s: %w", repo.Identifier, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]enum.CommitStatusState, len(results))
+	for i := range results {
+		states[i] = enum.CommitStatusStateFromCheckStatus(results[i].Status)
+	}
+
+	statuses := make([]types.CommitStatus, len(checks))
+	for i := range checks {
+		statuses[i] = commitStatusFromCheck(&checks[i])
+	}
+
+	return &types.CombinedCommitStatus{
+		SHA:        commitSHA,
+		State:      enum.RollupCommitStatusStates(states),
+		TotalCount: len(results),
+		Statuses:   statuses,
+	}, nil
+}
+
+func commitStatusFromCheck(check *types.Check) types.CommitStatus {
+	return types.CommitStatus{
+		Context:     check.Identifier,
+		State:       enum.CommitStatusStateFromCheckStatus(check.Status),
+		TargetURL:   check.Link,
+		Description: check.Summary,
+		Created:     check.Created,
+		Updated:     check.Updated,
+		ReportedBy:  check.ReportedBy,
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const testCommitSHA = "1d0e5a9461b843638ad6f5d2d4a2b1e3d5c2a7f0"
+
+func setupCommitStatusController() (*Controller, *fake.Authorizer) {
+	authorizer := &fake.Authorizer{}
+	ctrl := NewController(
+		txFake{},
+		authorizer,
+		fake.NewRepoStore(&types.Repository{ID: 1, Identifier: "repo", GitUID: "git-uid", Path: "space/repo"}),
+		&checkStoreFake{},
+		gitFake{},
+		ProvideCheckSanitizers(),
+	)
+	return ctrl, authorizer
+}
+
+func TestSetCommitStatus_MultipleContexts(t *testing.T) {
+	ctx := context.Background()
+	ctrl, authorizer := setupCommitStatusController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "ci"}}
+
+	inputs := []SetCommitStatusInput{
+		{Context: "build", State: enum.CommitStatusStateSuccess, TargetURL: "https://ci.example.com/1"},
+		{Context: "lint", State: enum.CommitStatusStatePending, Description: "linting"},
+		{Context: "build", State: enum.CommitStatusStateFailure, TargetURL: "https://ci.example.com/2"},
+	}
+	for i := range inputs {
+		if _, err := ctrl.SetCommitStatus(ctx, session, "space/repo", testCommitSHA, &inputs[i]); err != nil {
+			t.Fatalf("Want no error, got %v", err)
+		}
+	}
+
+	for _, check := range authorizer.Checks() {
+		if check.Permission != enum.PermissionRepoPush {
+			t.Errorf("Want permission %s, got %s", enum.PermissionRepoPush, check.Permission)
+		}
+	}
+
+	combined, err := ctrl.GetCombinedCommitStatus(ctx, session, "space/repo", testCommitSHA,
+		types.CheckListOptions{})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if combined.TotalCount != 2 || len(combined.Statuses) != 2 {
+		t.Fatalf("Want 2 statuses, got %d (%d listed)", combined.TotalCount, len(combined.Statuses))
+	}
+
+	byContext := map[string]types.CommitStatus{}
+	for _, s := range combined.Statuses {
+		byContext[s.Context] = s
+	}
+
+	if got := byContext["build"]; got.State != enum.CommitStatusStateFailure ||
+		got.TargetURL != "https://ci.example.com/2" {
+		t.Errorf("Want build context to be overridden with the latest status, got %+v", got)
+	}
+	if got := byContext["lint"]; got.State != enum.CommitStatusStatePending || got.Description != "linting" {
+		t.Errorf("Want lint context to be pending, got %+v", got)
+	}
+
+	if combined.State != enum.CommitStatusStateFailure {
+		t.Errorf("Want combined state %s, got %s", enum.CommitStatusStateFailure, combined.State)
+	}
+}
+
+func TestSetCommitStatus_InvalidState(t *testing.T) {
+	ctrl, _ := setupCommitStatusController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "ci"}}
+
+	for _, state := range []enum.CommitStatusState{"", "running", "unknown"} {
+		_, err := ctrl.SetCommitStatus(context.Background(), session, "space/repo", testCommitSHA,
+			&SetCommitStatusInput{Context: "build", State: state})
+		if err == nil {
+			t.Errorf("Want error for state %q, got none", state)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+)
+
+// txFake runs the transaction function without a database.
+type txFake struct {
+	dbtx.Transactor
+}
+
+func (txFake) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+// checkStoreFake is an in-memory check store keyed by the check identifier.
+type checkStoreFake struct {
+	store.CheckStore
+	checks []types.Check
+}
+
+func (f *checkStoreFake) FindByIdentifier(
+	_ context.Context,
+	repoID int64,
+	commitSHA string,
+	identifier string,
+) (types.Check, error) {
+	for _, c := range f.checks {
+		if c.RepoID == repoID && c.CommitSHA == commitSHA && c.Identifier == identifier {
+			return c, nil
+		}
+	}
+	return types.Check{}, gitnessstore.ErrResourceNotFound
+}
+
+func (f *checkStoreFake) Upsert(_ context.Context, check *types.Check) error {
+	for i, c := range f.checks {
+		if c.RepoID == check.RepoID && c.CommitSHA == check.CommitSHA && c.Identifier == check.Identifier {
+			f.checks[i] = *check
+			return nil
+		}
+	}
+	f.checks = append(f.checks, *check)
+	return nil
+}
+
+func (f *checkStoreFake) List(
+	_ context.Context,
+	repoID int64,
+	commitSHA string,
+	_ types.CheckListOptions,
+) ([]types.Check, error) {
+	var result []types.Check
+	for _, c := range f.checks {
+		if c.RepoID == repoID && c.CommitSHA == commitSHA {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (f *checkStoreFake) ListResults(
+	_ context.Context,
+	repoID int64,
+	commitSHA string,
+) ([]types.CheckResult, error) {
+	var result []types.CheckResult
+	for _, c := range f.checks {
+		if c.RepoID == repoID && c.CommitSHA == commitSHA {
+			result = append(result, types.CheckResult{Identifier: c.Identifier, Status: c.Status})
+		}
+	}
+	return result, nil
+}
+
+// gitFake resolves every commit.
+type gitFake struct {
+	git.Interface
+}
+
+func (gitFake) GetCommit(context.Context, *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	return &git.GetCommitOutput{}, nil
+}
@@ -41,6 +41,7 @@ type Controller struct {
 	authorizer          authz.Authorizer
 	principalStore      store.PrincipalStore
 	repoStore           store.RepoStore
+	mirrorStore         store.RepoMirrorStore
 	gitReporter         *eventsgit.Reporter
 	repoReporter        *eventsrepo.Reporter
 	git                 git.Interface
@@ -52,12 +53,14 @@ type Controller struct {
 	preReceiveExtender  PreReceiveExtender
 	updateExtender      UpdateExtender
 	postReceiveExtender PostReceiveExtender
+	scripts             *Scripts
 }
 
 func NewController(
 	authorizer authz.Authorizer,
 	principalStore store.PrincipalStore,
 	repoStore store.RepoStore,
+	mirrorStore store.RepoMirrorStore,
 	gitReporter *eventsgit.Reporter,
 	repoReporter *eventsrepo.Reporter,
 	git git.Interface,
@@ -69,11 +72,13 @@ func NewController(
 	preReceiveExtender PreReceiveExtender,
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
+	scripts *Scripts,
 ) *Controller {
 	return &Controller{
 		authorizer:          authorizer,
 		principalStore:      principalStore,
 		repoStore:           repoStore,
+		mirrorStore:         mirrorStore,
 		gitReporter:         gitReporter,
 		repoReporter:        repoReporter,
 		git:                 git,
@@ -85,6 +90,7 @@ func NewController(
 		preReceiveExtender:  preReceiveExtender,
 		updateExtender:      updateExtender,
 		postReceiveExtender: postReceiveExtender,
+		scripts:             scripts,
 	}
 }
 
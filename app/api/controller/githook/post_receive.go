@@ -68,6 +68,11 @@ func (c *Controller) PostReceive(
 	// handle branch updates related to PRs - best effort
 	c.handlePRMessaging(ctx, repo, in.PostReceiveInput, &out)
 
+	// run custom post-receive hook script for git pushes - best effort
+	if !in.Internal {
+		c.runPostReceiveScript(ctx, repo, in, &out)
+	}
+
 	err = c.postReceiveExtender.Extend(ctx, rgit, session, repo, in, &out)
 	if err != nil {
 		return hook.Output{}, fmt.Errorf("failed to extend post-receive hook: %w", err)
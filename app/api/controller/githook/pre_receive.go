@@ -16,6 +16,7 @@ package githook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git/hook"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -47,6 +49,22 @@ func (c *Controller) PreReceive(
 		return hook.Output{}, err
 	}
 
+	// Archived repositories are read-only - this covers pushes as well as writes through the API.
+	if repo.Archived {
+		output.Error = ptr.String(usererror.ErrRepositoryArchived.Error())
+		return output, nil
+	}
+
+	// Mirrored repositories are updated by syncing the remote repository only, which doesn't run the hooks.
+	isMirror, err := c.isMirror(ctx, repo.ID)
+	if err != nil {
+		return hook.Output{}, err
+	}
+	if isMirror {
+		output.Error = ptr.String(usererror.ErrRepositoryMirror.Error())
+		return output, nil
+	}
+
 	if err := c.limiter.RepoSize(ctx, in.RepoID); err != nil {
 		return hook.Output{}, fmt.Errorf(
 			"resource limit exceeded: %w",
@@ -81,6 +99,19 @@ func (c *Controller) PreReceive(
 		if err != nil {
 			return hook.Output{}, fmt.Errorf("failed to check protection rules: %w", err)
 		}
+
+		// custom hook scripts only apply to git pushes and aren't run for already rejected pushes.
+		if output.Error == nil {
+			err = c.runPreReceiveScript(ctx, repo, in, &output)
+			if err != nil {
+				return hook.Output{}, fmt.Errorf("failed to run pre-receive hook script: %w", err)
+			}
+		}
+	}
+
+	err = c.checkFileSizeLimit(ctx, rgit, repo, in, &output)
+	if err != nil {
+		return hook.Output{}, err
 	}
 
 	err = c.scanSecrets(ctx, rgit, repo, in, &output)
@@ -222,3 +253,16 @@ func loggingWithRefUpdate(refUpdate hook.ReferenceUpdate) func(c zerolog.Context
 		return c.Str("ref", refUpdate.Ref).Str("old_sha", refUpdate.Old.String()).Str("new_sha", refUpdate.New.String())
 	}
 }
+
+// isMirror returns true if the repository is mirrored from a remote repository.
+func (c *Controller) isMirror(ctx context.Context, repoID int64) (bool, error) {
+	_, err := c.mirrorStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repository mirror: %w", err)
+	}
+
+	return true, nil
+}
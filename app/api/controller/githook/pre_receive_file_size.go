@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+)
+
+// checkFileSizeLimit blocks the push in case any of the pushed files exceeds the max file size of the repo.
+func (c *Controller) checkFileSizeLimit(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+) error {
+	maxFileSize, err := settings.RepoGet(
+		ctx,
+		c.settings,
+		repo.ID,
+		settings.KeyMaxFileSize,
+		settings.DefaultMaxFileSize,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get max file size setting: %w", err)
+	}
+	if maxFileSize <= 0 {
+		return nil
+	}
+
+	revs := make([]string, 0, len(in.RefUpdates))
+	for _, refUpdate := range in.RefUpdates {
+		if refUpdate.New.IsNil() {
+			continue
+		}
+		revs = append(revs, refUpdate.New.String())
+	}
+	if len(revs) == 0 {
+		return nil
+	}
+
+	out, err := rgit.FindOversizeFiles(ctx, &git.FindOversizeFilesParams{
+		ReadParams: git.ReadParams{
+			RepoUID:             repo.GitUID,
+			AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+		},
+		Revs:      revs,
+		SizeLimit: maxFileSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find oversize files: %w", err)
+	}
+
+	if len(out.FileInfos) == 0 {
+		return nil
+	}
+
+	printOversizeFiles(output, out.FileInfos, maxFileSize)
+
+	output.Error = ptr.String("Changes blocked by files exceeding the file size limit")
+
+	return nil
+}
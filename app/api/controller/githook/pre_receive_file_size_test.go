@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// settingsStoreFake returns the configured values for all scopes.
+type settingsStoreFake struct {
+	store.SettingsStore
+
+	values map[string]json.RawMessage
+}
+
+func (s settingsStoreFake) Find(_ context.Context, _ enum.SettingsScope, _ int64, key string) (json.RawMessage, error) {
+	value, ok := s.values[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return value, nil
+}
+
+// restrictedGITFake finds oversize files in a local repository.
+type restrictedGITFake struct {
+	RestrictedGIT
+
+	repoPath string
+}
+
+func (f restrictedGITFake) FindOversizeFiles(
+	ctx context.Context,
+	params *git.FindOversizeFilesParams,
+) (*git.FindOversizeFilesOutput, error) {
+	files, err := (&api.Git{}).FindOversizeFiles(ctx, f.repoPath, nil, params.Revs, params.SizeLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &git.FindOversizeFilesOutput{}
+	for _, file := range files {
+		out.FileInfos = append(out.FileInfos, git.FileInfo{SHA: file.SHA, Path: file.Path, Size: file.Size})
+	}
+	return out, nil
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@gitness.io"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("failed to run git %v: %s: %s", args, err, out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+// setupPushedCommit creates a repo with a commit that adds a file of the given size.
+// The commit isn't referenced by any branch, same as a pushed commit during pre-receive.
+func setupPushedCommit(t *testing.T, fileSize int) (string, sha.SHA) {
+	t.Helper()
+
+	repoPath := t.TempDir()
+	runGit(t, repoPath, "init", "--quiet")
+	runGit(t, repoPath, "commit", "--quiet", "--allow-empty", "-m", "initial commit")
+	runGit(t, repoPath, "checkout", "--quiet", "-b", "pushed")
+
+	if err := os.MkdirAll(filepath.Join(repoPath, "assets"), 0o700); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	content := strings.Repeat("x", fileSize)
+	if err := os.WriteFile(filepath.Join(repoPath, "assets", "large.bin"), []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	runGit(t, repoPath, "add", ".")
+	runGit(t, repoPath, "commit", "--quiet", "-m", "add large file")
+	newSHA := sha.Must(runGit(t, repoPath, "rev-parse", "HEAD"))
+
+	runGit(t, repoPath, "checkout", "--quiet", "-")
+	runGit(t, repoPath, "branch", "--quiet", "-D", "pushed")
+
+	return repoPath, newSHA
+}
+
+func checkFileSizeLimit(t *testing.T, maxFileSize int64, fileSize int) hook.Output {
+	t.Helper()
+
+	repoPath, newSHA := setupPushedCommit(t, fileSize)
+
+	raw, _ := json.Marshal(maxFileSize)
+	c := &Controller{
+		settings: settings.NewService(settingsStoreFake{
+			values: map[string]json.RawMessage{string(settings.KeyMaxFileSize): raw},
+		}),
+	}
+
+	in := types.GithookPreReceiveInput{
+		PreReceiveInput: hook.PreReceiveInput{RefUpdates: []hook.ReferenceUpdate{
+			{Ref: "refs/heads/main", Old: sha.None, New: newSHA},
+		}},
+	}
+
+	output := hook.Output{}
+	err := c.checkFileSizeLimit(context.Background(), restrictedGITFake{repoPath: repoPath},
+		&types.Repository{ID: 1}, in, &output)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	return output
+}
+
+func TestCheckFileSizeLimit_OverLimit(t *testing.T) {
+	output := checkFileSizeLimit(t, 100, 101)
+
+	if output.Error == nil {
+		t.Fatalf("Want push to be rejected")
+	}
+
+	messages := strings.Join(output.Messages, "\n")
+	if !strings.Contains(messages, "assets/large.bin (101 bytes)") {
+		t.Errorf("Want messages to name the oversize file and its size, got %q", messages)
+	}
+}
+
+func TestCheckFileSizeLimit_AtLimit(t *testing.T) {
+	output := checkFileSizeLimit(t, 100, 100)
+
+	if output.Error != nil {
+		t.Errorf("Want push to be accepted, got error %q", *output.Error)
+	}
+	if len(output.Messages) != 0 {
+		t.Errorf("Want no messages, got %q", output.Messages)
+	}
+}
+
+func TestCheckFileSizeLimit_Unlimited(t *testing.T) {
+	output := checkFileSizeLimit(t, 0, 1000)
+
+	if output.Error != nil {
+		t.Errorf("Want push to be accepted, got error %q", *output.Error)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// mirrorStoreFake holds the mirrors of the listed repositories.
+type mirrorStoreFake struct {
+	store.RepoMirrorStore
+	repoIDs map[int64]bool
+}
+
+func (f mirrorStoreFake) Find(_ context.Context, repoID int64) (*types.RepositoryMirror, error) {
+	if !f.repoIDs[repoID] {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.RepositoryMirror{RepoID: repoID}, nil
+}
+
+func TestPreReceive_MirrorRejectsPush(t *testing.T) {
+	const repoID = 1
+
+	c := &Controller{
+		repoStore:   fake.NewRepoStore(&types.Repository{ID: repoID, Path: "space/repo"}),
+		mirrorStore: mirrorStoreFake{repoIDs: map[int64]bool{repoID: true}},
+	}
+
+	in := types.GithookPreReceiveInput{
+		GithookInputBase: types.GithookInputBase{RepoID: repoID},
+		PreReceiveInput: hook.PreReceiveInput{RefUpdates: []hook.ReferenceUpdate{
+			{Ref: "refs/heads/main", Old: sha.None, New: sha.Must("1111111111111111111111111111111111111111")},
+		}},
+	}
+
+	output, err := c.PreReceive(context.Background(), nil, &auth.Session{}, in)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if output.Error == nil || *output.Error != usererror.ErrRepositoryMirror.Error() {
+		t.Errorf("Want push to mirror rejected with %q, got %v", usererror.ErrRepositoryMirror.Error(), output.Error)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
 
 	"github.com/fatih/color"
@@ -87,6 +88,40 @@ func printScanSecretsFindings(
 	)
 }
 
+func printOversizeFiles(
+	output *hook.Output,
+	fileInfos []git.FileInfo,
+	sizeLimit int64,
+) {
+	output.Messages = append(
+		output.Messages,
+		colorScanHeader.Sprintf("Push contains files exceeding the file size limit of %d bytes:", sizeLimit),
+		"", // add empty line for making it visually more consumable
+	)
+
+	for _, fileInfo := range fileInfos {
+		output.Messages = append(
+			output.Messages,
+			fmt.Sprintf("  %s (%d bytes)", fileInfo.Path, fileInfo.Size),
+			fmt.Sprintf("      Blob: %s", fileInfo.SHA),
+		)
+	}
+
+	output.Messages = append(
+		output.Messages,
+		"", // add empty line for making it visually more consumable
+		colorScanSummary.Sprintf("%d oversize %s found", len(fileInfos), stringFileOrFiles(len(fileInfos) > 1)),
+		"", "", // add two empty lines for making it visually more consumable
+	)
+}
+
+func stringFileOrFiles(plural bool) string {
+	if plural {
+		return "files"
+	}
+	return "file"
+}
+
 func stringSecretOrSecrets(plural bool) string {
 	if plural {
 		return "secrets"
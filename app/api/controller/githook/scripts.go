@@ -0,0 +1,219 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	scriptPreReceive  = "pre-receive"
+	scriptPostReceive = "post-receive"
+
+	// scriptWaitDelay is the time given to processes spawned by a timed out script to release its output.
+	scriptWaitDelay = time.Second
+)
+
+// Scripts executes the custom server-side hook scripts configured by the admin.
+// The scripts get the ref updates via stdin in the same format as native git hooks ("<old> <new> <ref>" per line).
+// The environment of the scripts is restricted to PATH, HOME, the GIT_* variables and
+// GITNESS_REPO_ID, GITNESS_REPO_PATH and GITNESS_PRINCIPAL_ID - the remaining server environment isn't exposed.
+// GIT_DIR points to the repository and, during pre-receive, GIT_ALTERNATE_OBJECT_DIRECTORIES contains the
+// quarantine directory of the pushed objects, so scripts can inspect the pushed commits (e.g. "git log old..new").
+type Scripts struct {
+	dir     string
+	timeout time.Duration
+	gitRoot string
+}
+
+func NewScripts(dir string, timeout time.Duration, gitRoot string) *Scripts {
+	return &Scripts{
+		dir:     dir,
+		timeout: timeout,
+		gitRoot: gitRoot,
+	}
+}
+
+// scriptResult is the result of a hook script execution.
+type scriptResult struct {
+	exitCode int
+	stdout   string
+	stderr   string
+}
+
+// run executes the hook script with the provided name (if it exists).
+func (s *Scripts) run(
+	ctx context.Context,
+	name string,
+	repo *types.Repository,
+	principalID int64,
+	env hook.Environment,
+	refUpdates []hook.ReferenceUpdate,
+) (scriptResult, error) {
+	if s == nil || s.dir == "" {
+		return scriptResult{}, nil
+	}
+
+	path := filepath.Join(s.dir, name)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return scriptResult{}, nil
+	} else if err != nil {
+		return scriptResult{}, fmt.Errorf("failed to stat %s hook script: %w", name, err)
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	stdin := &strings.Builder{}
+	for _, refUpdate := range refUpdates {
+		fmt.Fprintf(stdin, "%s %s %s\n", refUpdate.Old, refUpdate.New, refUpdate.Ref)
+	}
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Dir = s.dir
+	cmd.Stdin = strings.NewReader(stdin.String())
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = scriptWaitDelay
+	cmd.Env = scriptEnv(repo, principalID, git.GetRepoPath(s.gitRoot, repo.GitUID), env.AlternateObjectDirs)
+
+	res := scriptResult{}
+
+	err := cmd.Run()
+	res.stdout = strings.TrimSpace(stdout.String())
+	res.stderr = strings.TrimSpace(stderr.String())
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		res.exitCode = -1
+		res.stderr = fmt.Sprintf("The %s hook timed out after %s.", name, s.timeout)
+	case errors.As(err, &exitErr) && ctx.Err() == nil:
+		res.exitCode = exitErr.ExitCode()
+	default:
+		return scriptResult{}, fmt.Errorf("failed to execute %s hook script: %w", name, err)
+	}
+
+	return res, nil
+}
+
+// scriptEnv returns the environment of a hook script.
+// NOTE: the server environment contains secrets (e.g. the database credentials), so only the required variables
+// are passed on.
+func scriptEnv(repo *types.Repository, principalID int64, gitDir string, alternateObjectDirs []string) []string {
+	var env []string
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		switch {
+		case key == command.GitDir || key == command.GitObjectDir || key == command.GitAlternateObjectDirs:
+			// the repository of the script is set explicitly.
+		case key == "PATH" || key == "HOME" || strings.HasPrefix(key, "GIT_"):
+			env = append(env, kv)
+		}
+	}
+
+	env = append(env,
+		command.GitDir+"="+gitDir,
+		"GITNESS_REPO_ID="+strconv.FormatInt(repo.ID, 10),
+		"GITNESS_REPO_PATH="+repo.Path,
+		"GITNESS_PRINCIPAL_ID="+strconv.FormatInt(principalID, 10),
+	)
+
+	// the pushed objects are still in quarantine until the pre-receive hook accepted the push.
+	if len(alternateObjectDirs) > 0 {
+		env = append(env, command.GitAlternateObjectDirs+"="+strings.Join(alternateObjectDirs, ":"))
+	}
+
+	return env
+}
+
+// runPreReceiveScript executes the pre-receive hook script and rejects the push in case it fails.
+// The stderr output of the script is used as error message.
+func (c *Controller) runPreReceiveScript(
+	ctx context.Context,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+) error {
+	res, err := c.scripts.run(ctx, scriptPreReceive, repo, in.PrincipalID, in.Environment, in.RefUpdates)
+	if err != nil {
+		return err
+	}
+
+	output.Messages = append(output.Messages, splitLines(res.stdout)...)
+
+	if res.exitCode != 0 {
+		message := res.stderr
+		if message == "" {
+			message = fmt.Sprintf("Rejected by pre-receive hook (exit code %d).", res.exitCode)
+		}
+		output.Error = ptr.String(message)
+	}
+
+	return nil
+}
+
+// runPostReceiveScript executes the post-receive hook script.
+// NOTE: keep best effort as the references are already updated and the push can't be rejected anymore.
+func (c *Controller) runPostReceiveScript(
+	ctx context.Context,
+	repo *types.Repository,
+	in types.GithookPostReceiveInput,
+	output *hook.Output,
+) {
+	res, err := c.scripts.run(ctx, scriptPostReceive, repo, in.PrincipalID, in.Environment, in.RefUpdates)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to run post-receive hook script")
+		return
+	}
+
+	output.Messages = append(output.Messages, splitLines(res.stdout)...)
+
+	if res.exitCode != 0 {
+		log.Ctx(ctx).Warn().Msgf("post-receive hook script failed with exit code %d: %s", res.exitCode, res.stderr)
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, "\n")
+}
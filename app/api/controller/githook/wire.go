@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -32,8 +33,13 @@ import (
 var WireSet = wire.NewSet(
 	ProvideController,
 	ProvideFactory,
+	ProvideScripts,
 )
 
+func ProvideScripts(config *types.Config) *Scripts {
+	return NewScripts(config.Git.HookScriptsDir, config.Git.HookScriptsTimeout, config.Git.Root)
+}
+
 func ProvideFactory() hook.ClientFactory {
 	return &ControllerClientFactory{
 		githookCtrl: nil,
@@ -44,6 +50,7 @@ func ProvideController(
 	authorizer authz.Authorizer,
 	principalStore store.PrincipalStore,
 	repoStore store.RepoStore,
+	mirrorStore store.RepoMirrorStore,
 	gitReporter *eventsgit.Reporter,
 	repoReporter *eventsrepo.Reporter,
 	git git.Interface,
@@ -56,11 +63,13 @@ func ProvideController(
 	preReceiveExtender PreReceiveExtender,
 	updateExtender UpdateExtender,
 	postReceiveExtender PostReceiveExtender,
+	scripts *Scripts,
 ) *Controller {
 	ctrl := NewController(
 		authorizer,
 		principalStore,
 		repoStore,
+		mirrorStore,
 		gitReporter,
 		repoReporter,
 		git,
@@ -72,6 +81,7 @@ func ProvideController(
 		preReceiveExtender,
 		updateExtender,
 		postReceiveExtender,
+		scripts,
 	)
 
 	// TODO: improve wiring if possible
@@ -0,0 +1,287 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// maxResults is the maximum number of accessible resources per type that are considered for a search.
+	// NOTE: Candidates are fetched shortest identifier first, which are the most relevant ones.
+	maxResults = 1000
+
+	// maxCandidates is the maximum number of candidates per type whose access is checked for a search.
+	// NOTE: Bounds the authorization checks in case most candidates aren't accessible to the caller.
+	maxCandidates = 2 * maxResults
+
+	// searchBatchSize is the number of candidates fetched at once. Candidates the caller can't access
+	// are skipped and the next batch is fetched until maxResults is reached, maxCandidates were checked
+	// or no candidates are left.
+	searchBatchSize = 100
+)
+
+const (
+	scoreContains = iota + 1
+	scorePrefix
+	scoreExact
+)
+
+type rankedResult struct {
+	result     *types.GlobalSearchResult
+	score      int
+	identifier string
+}
+
+// Search returns the repositories, spaces and users matching the query that the caller is allowed to see,
+// ranked by relevance.
+func (c *Controller) Search(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.GlobalSearchFilter,
+) ([]*types.GlobalSearchResult, int, error) {
+	query := strings.ToLower(strings.TrimSpace(filter.Query))
+	if query == "" {
+		return nil, 0, usererror.BadRequest("Query cannot be empty.")
+	}
+
+	searchTypes := filter.Types
+	if len(searchTypes) == 0 {
+		searchTypes, _ = enum.GetAllGlobalSearchTypes()
+	}
+
+	var results []rankedResult
+
+	if slices.Contains(searchTypes, enum.GlobalSearchTypeRepo) {
+		repoResults, err := c.searchRepos(ctx, session, query)
+		if err != nil {
+			return nil, 0, err
+		}
+		results = append(results, repoResults...)
+	}
+
+	if slices.Contains(searchTypes, enum.GlobalSearchTypeSpace) {
+		spaceResults, err := c.searchSpaces(ctx, session, query)
+		if err != nil {
+			return nil, 0, err
+		}
+		results = append(results, spaceResults...)
+	}
+
+	if slices.Contains(searchTypes, enum.GlobalSearchTypeUser) {
+		userResults, err := c.searchUsers(ctx, session, query)
+		if err != nil {
+			return nil, 0, err
+		}
+		results = append(results, userResults...)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].score != results[j].score {
+			return results[i].score > results[j].score
+		}
+		if len(results[i].identifier) != len(results[j].identifier) {
+			return len(results[i].identifier) < len(results[j].identifier)
+		}
+		return results[i].identifier < results[j].identifier
+	})
+
+	total := len(results)
+
+	page := filter.Page
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * filter.Size
+	if start >= total {
+		return []*types.GlobalSearchResult{}, total, nil
+	}
+	end := start + filter.Size
+	if end > total {
+		end = total
+	}
+
+	paged := make([]*types.GlobalSearchResult, 0, end-start)
+	for _, r := range results[start:end] {
+		paged = append(paged, r.result)
+	}
+
+	return paged, total, nil
+}
+
+func (c *Controller) searchRepos(
+	ctx context.Context,
+	session *auth.Session,
+	query string,
+) ([]rankedResult, error) {
+	var results []rankedResult
+	for offset := 0; offset < maxCandidates && len(results) < maxResults; offset += searchBatchSize {
+		repos, err := c.repoStore.Search(ctx, query, offset, searchBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search repositories: %w", err)
+		}
+
+		for _, repo := range repos {
+			err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true)
+			if isUnauthorized(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to check access to repository %q: %w", repo.Path, err)
+			}
+
+			results = append(results, rankedResult{
+				result: &types.GlobalSearchResult{
+					Type:       enum.GlobalSearchTypeRepo,
+					Repository: repo,
+				},
+				score:      score(query, repo.Identifier),
+				identifier: strings.ToLower(repo.Identifier),
+			})
+			if len(results) == maxResults {
+				break
+			}
+		}
+
+		if len(repos) < searchBatchSize {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+func (c *Controller) searchSpaces(
+	ctx context.Context,
+	session *auth.Session,
+	query string,
+) ([]rankedResult, error) {
+	var results []rankedResult
+	for offset := 0; offset < maxCandidates && len(results) < maxResults; offset += searchBatchSize {
+		spaces, err := c.spaceStore.Search(ctx, query, offset, searchBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search spaces: %w", err)
+		}
+
+		for _, space := range spaces {
+			err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, true)
+			if isUnauthorized(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to check access to space %q: %w", space.Path, err)
+			}
+
+			results = append(results, rankedResult{
+				result: &types.GlobalSearchResult{
+					Type:  enum.GlobalSearchTypeSpace,
+					Space: space,
+				},
+				score:      score(query, space.Identifier),
+				identifier: strings.ToLower(space.Identifier),
+			})
+			if len(results) == maxResults {
+				break
+			}
+		}
+
+		if len(spaces) < searchBatchSize {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// searchUsers returns the users matching the query.
+// NOTE: Users are visible to every authenticated principal (same as the principal list api).
+func (c *Controller) searchUsers(
+	ctx context.Context,
+	session *auth.Session,
+	query string,
+) ([]rankedResult, error) {
+	if session == nil {
+		return nil, nil
+	}
+
+	principals, err := c.principalStore.List(ctx, &types.PrincipalFilter{
+		Query: query,
+		Types: []enum.PrincipalType{enum.PrincipalTypeUser},
+		Page:  1,
+		Size:  maxResults,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	results := make([]rankedResult, len(principals))
+	for i, principal := range principals {
+		results[i] = rankedResult{
+			result: &types.GlobalSearchResult{
+				Type: enum.GlobalSearchTypeUser,
+				User: principal.ToPrincipalInfo(),
+			},
+			score: maxScore(
+				score(query, principal.UID),
+				score(query, principal.Email),
+				score(query, principal.DisplayName),
+			),
+			identifier: strings.ToLower(principal.UID),
+		}
+	}
+
+	return results, nil
+}
+
+// score returns the relevance of the value for the (lower case) query.
+func score(query string, value string) int {
+	value = strings.ToLower(value)
+	switch {
+	case value == query:
+		return scoreExact
+	case strings.HasPrefix(value, query):
+		return scorePrefix
+	case strings.Contains(value, query):
+		return scoreContains
+	default:
+		return 0
+	}
+}
+
+func isUnauthorized(err error) bool {
+	return errors.Is(err, apiauth.ErrNotAuthenticated) || errors.Is(err, apiauth.ErrNotAuthorized)
+}
+
+func maxScore(values ...int) int {
+	res := 0
+	for _, v := range values {
+		if v > res {
+			res = v
+		}
+	}
+	return res
+}
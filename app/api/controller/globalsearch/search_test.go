@@ -0,0 +1,235 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalsearch
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func setupController() *Controller {
+	return NewController(
+		&fake.Authorizer{Deny: permitSpaces("team")},
+		repoStoreFake{fake.NewRepoStore(
+			&types.Repository{ID: 1, Identifier: "app-secret", Path: "acme/app-secret"},
+			&types.Repository{ID: 2, Identifier: "app-public", Path: "acme/app-public", IsPublic: true},
+			&types.Repository{ID: 3, Identifier: "app", Path: "team/app"},
+			&types.Repository{ID: 4, Identifier: "my-app", Path: "team/nested/my-app"},
+			&types.Repository{ID: 5, Identifier: "other", Path: "team/other"},
+		)},
+		&spaceStoreFake{spaces: []*types.Space{
+			{ID: 1, Identifier: "acme", Path: "acme"},
+			{ID: 2, Identifier: "team", Path: "team"},
+			{ID: 3, Identifier: "apps", Path: "team/apps"},
+			{ID: 4, Identifier: "apps", Path: "acme/apps"},
+		}},
+		&principalStoreFake{principals: []*types.Principal{
+			{ID: 1, UID: "appleseed", Email: "john@example.com", Type: enum.PrincipalTypeUser},
+			{ID: 2, UID: "app-bot", Email: "bot@example.com", Type: enum.PrincipalTypeServiceAccount},
+		}},
+	)
+}
+
+func resultIDs(results []*types.GlobalSearchResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		switch r.Type {
+		case enum.GlobalSearchTypeRepo:
+			ids[i] = "repo:" + r.Repository.Path
+		case enum.GlobalSearchTypeSpace:
+			ids[i] = "space:" + r.Space.Path
+		case enum.GlobalSearchTypeUser:
+			ids[i] = "user:" + r.User.UID
+		}
+	}
+	return ids
+}
+
+func TestSearch_OnlyAuthorizedResults(t *testing.T) {
+	ctrl := setupController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+	results, total, err := ctrl.Search(context.Background(), session,
+		&types.GlobalSearchFilter{Query: "App", Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	// exact matches first, followed by prefix matches and substring matches (shorter identifiers first).
+	want := []string{
+		"repo:team/app",
+		"space:team/apps",
+		"user:appleseed",
+		"repo:acme/app-public",
+		"repo:team/nested/my-app",
+	}
+	if got := resultIDs(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want results %v, got %v", want, got)
+	}
+	if total != len(want) {
+		t.Errorf("Want total %d, got %d", len(want), total)
+	}
+}
+
+func TestSearch_TypeFilter(t *testing.T) {
+	ctrl := setupController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+	results, _, err := ctrl.Search(context.Background(), session, &types.GlobalSearchFilter{
+		Query: "app",
+		Types: []enum.GlobalSearchType{enum.GlobalSearchTypeSpace, enum.GlobalSearchTypeUser},
+		Page:  1,
+		Size:  10,
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	want := []string{"space:team/apps", "user:appleseed"}
+	if got := resultIDs(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want results %v, got %v", want, got)
+	}
+}
+
+func TestSearch_Pagination(t *testing.T) {
+	ctrl := setupController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+	results, total, err := ctrl.Search(context.Background(), session,
+		&types.GlobalSearchFilter{Query: "app", Page: 2, Size: 2})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	want := []string{"user:appleseed", "repo:acme/app-public"}
+	if got := resultIDs(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want results %v, got %v", want, got)
+	}
+	if total != 5 {
+		t.Errorf("Want total %d, got %d", 5, total)
+	}
+
+	results, _, err = ctrl.Search(context.Background(), session,
+		&types.GlobalSearchFilter{Query: "app", Page: 4, Size: 2})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Want no results beyond last page, got %v", resultIDs(results))
+	}
+}
+
+func TestSearch_SkipsInaccessibleCandidates(t *testing.T) {
+	ctrl := setupController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+	// more inaccessible candidates than the result limit are ranked before the accessible ones.
+	repoStore, _ := ctrl.repoStore.(repoStoreFake)
+	hidden := make([]*types.Repository, 0, maxResults+searchBatchSize)
+	for i := 0; i < maxResults+searchBatchSize; i++ {
+		hidden = append(hidden, &types.Repository{
+			ID:         int64(100 + i),
+			Identifier: "app-" + strconv.Itoa(i),
+			Path:       "acme/app-" + strconv.Itoa(i),
+		})
+	}
+	repoStore.Repos = append(hidden, repoStore.Repos...)
+
+	results, total, err := ctrl.Search(context.Background(), session, &types.GlobalSearchFilter{
+		Query: "app",
+		Types: []enum.GlobalSearchType{enum.GlobalSearchTypeRepo},
+		Page:  1,
+		Size:  10,
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	want := []string{"repo:team/app", "repo:acme/app-public", "repo:team/nested/my-app"}
+	if got := resultIDs(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want results %v, got %v", want, got)
+	}
+	if total != len(want) {
+		t.Errorf("Want total %d, got %d", len(want), total)
+	}
+}
+
+func TestSearch_LimitsCheckedCandidates(t *testing.T) {
+	ctrl := setupController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+	// none of the candidates are accessible, so all of them would be checked without a limit.
+	hidden := make([]*types.Repository, 0, maxCandidates+searchBatchSize)
+	for i := 0; i < maxCandidates+searchBatchSize; i++ {
+		hidden = append(hidden, &types.Repository{
+			ID:         int64(100 + i),
+			Identifier: "app-" + strconv.Itoa(i),
+			Path:       "acme/app-" + strconv.Itoa(i),
+		})
+	}
+	authorizer := &fake.Authorizer{Deny: permitSpaces("team")}
+	ctrl.authorizer = authorizer
+	ctrl.repoStore = repoStoreFake{fake.NewRepoStore(hidden...)}
+
+	results, _, err := ctrl.Search(context.Background(), session, &types.GlobalSearchFilter{
+		Query: "app",
+		Types: []enum.GlobalSearchType{enum.GlobalSearchTypeRepo},
+		Page:  1,
+		Size:  10,
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Want no results, got %v", resultIDs(results))
+	}
+	if checks := len(authorizer.Checks()); checks != maxCandidates {
+		t.Errorf("Want %d access checks, got %d", maxCandidates, checks)
+	}
+}
+
+func TestSearch_Anonymous(t *testing.T) {
+	ctrl := setupController()
+
+	results, _, err := ctrl.Search(context.Background(), nil,
+		&types.GlobalSearchFilter{Query: "app", Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	want := []string{"repo:acme/app-public"}
+	if got := resultIDs(results); !reflect.DeepEqual(got, want) {
+		t.Errorf("Want results %v, got %v", want, got)
+	}
+}
+
+func TestSearch_EmptyQuery(t *testing.T) {
+	ctrl := setupController()
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+	_, _, err := ctrl.Search(context.Background(), session, &types.GlobalSearchFilter{Query: " ", Page: 1, Size: 10})
+	if status := usererror.Translate(context.Background(), err).Status; status != http.StatusBadRequest {
+		t.Errorf("Want status %d, got %d (%v)", http.StatusBadRequest, status, err)
+	}
+}
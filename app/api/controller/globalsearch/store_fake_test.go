@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalsearch
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// permitSpaces permits access to the provided spaces and all their descendants.
+func permitSpaces(spaces ...string) fake.DenyFunc {
+	return func(_ *types.Principal, scope *types.Scope, resource *types.Resource, _ enum.Permission) bool {
+		path := resource.Identifier
+		if scope.SpacePath != "" {
+			path = scope.SpacePath + "/" + path
+		}
+		for _, space := range spaces {
+			if path == space || strings.HasPrefix(path, space+"/") {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// repoStoreFake extends the in-memory repo store with search.
+type repoStoreFake struct {
+	*fake.RepoStore
+}
+
+func (s repoStoreFake) Search(
+	_ context.Context,
+	query string,
+	offset int,
+	limit int,
+) ([]*types.Repository, error) {
+	var res []*types.Repository
+	for _, repo := range s.Repos {
+		if strings.Contains(strings.ToLower(repo.Identifier), strings.ToLower(query)) {
+			res = append(res, repo)
+		}
+	}
+	if offset >= len(res) {
+		return nil, nil
+	}
+	res = res[offset:]
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// spaceStoreFake is an in-memory space store that supports the operations used by the controller.
+type spaceStoreFake struct {
+	store.SpaceStore
+	spaces []*types.Space
+}
+
+func (s *spaceStoreFake) Search(
+	_ context.Context,
+	query string,
+	offset int,
+	limit int,
+) ([]*types.Space, error) {
+	var res []*types.Space
+	for _, space := range s.spaces {
+		if strings.Contains(strings.ToLower(space.Identifier), strings.ToLower(query)) {
+			res = append(res, space)
+		}
+	}
+	if offset >= len(res) {
+		return nil, nil
+	}
+	res = res[offset:]
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// principalStoreFake is an in-memory principal store that supports the operations used by the controller.
+type principalStoreFake struct {
+	store.PrincipalStore
+	principals []*types.Principal
+}
+
+func (s *principalStoreFake) List(_ context.Context, opts *types.PrincipalFilter) ([]*types.Principal, error) {
+	var res []*types.Principal
+	for _, p := range s.principals {
+		if len(opts.Types) > 0 && p.Type != opts.Types[0] {
+			continue
+		}
+		if strings.Contains(strings.ToLower(p.UID), opts.Query) ||
+			strings.Contains(strings.ToLower(p.Email), opts.Query) ||
+			strings.Contains(strings.ToLower(p.DisplayName), opts.Query) {
+			res = append(res, p)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// errIdempotencyKeyReused is returned in case an idempotency key is reused for a different request.
+var errIdempotencyKeyReused = usererror.UnprocessableEntityf(
+	"The idempotency key was already used for a different request.")
+
+// FindIdempotentResourceID returns the id of the resource that was created by an earlier request
+// of the principal with the same idempotency key, or 0 if there is none.
+// An error is returned in case the earlier request differs from the provided request.
+// Keys older than the ttl are expired and are deleted so they can be reused.
+func FindIdempotentResourceID(
+	ctx context.Context,
+	keyStore store.IdempotencyKeyStore,
+	ttl time.Duration,
+	principalID int64,
+	resourceType enum.ResourceType,
+	idempotency types.IdempotencyRequest,
+) (int64, error) {
+	if idempotency.Key == "" {
+		return 0, nil
+	}
+
+	existing, err := keyStore.Find(ctx, principalID, resourceType, idempotency.Key)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to find idempotency key: %w", err)
+	}
+
+	if time.Since(time.UnixMilli(existing.Created)) < ttl {
+		if existing.RequestHash != "" && existing.RequestHash != idempotency.Hash {
+			return 0, errIdempotencyKeyReused
+		}
+
+		return existing.ResourceID, nil
+	}
+
+	if err = keyStore.Delete(ctx, existing.ID); err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency key: %w", err)
+	}
+
+	return 0, nil
+}
+
+// StoreIdempotencyKey links the idempotency key of the principal to the created resource.
+// It's a no-op if no idempotency key was provided.
+func StoreIdempotencyKey(
+	ctx context.Context,
+	keyStore store.IdempotencyKeyStore,
+	principalID int64,
+	resourceType enum.ResourceType,
+	idempotency types.IdempotencyRequest,
+	resourceID int64,
+) error {
+	if idempotency.Key == "" {
+		return nil
+	}
+
+	err := keyStore.Create(ctx, &types.IdempotencyKey{
+		PrincipalID:  principalID,
+		ResourceType: resourceType,
+		Key:          idempotency.Key,
+		RequestHash:  idempotency.Hash,
+		ResourceID:   resourceID,
+		Created:      time.Now().UnixMilli(),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return usererror.Conflict("A request with the same idempotency key was processed concurrently.")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store idempotency key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+// Batch handles the LFS batch API request and negotiates the transfer actions for the requested objects.
+func (c *Controller) Batch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *BatchRequest,
+) (*BatchResponse, error) {
+	if in.Operation != OperationDownload && in.Operation != OperationUpload {
+		return nil, usererror.UnprocessableEntityf("Unsupported LFS operation %q.", in.Operation)
+	}
+
+	if in.HashAlgo != "" && in.HashAlgo != HashAlgorithmSHA256 {
+		return nil, usererror.Conflict("Only the sha256 hash algorithm is supported.")
+	}
+
+	if len(in.Transfers) > 0 && !slices.Contains(in.Transfers, TransferBasic) {
+		return nil, usererror.UnprocessableEntityf("Only the %q transfer adapter is supported.", TransferBasic)
+	}
+
+	repo, err := c.getRepoForOperation(ctx, session, repoRef, in.Operation)
+	if err != nil {
+		return nil, err
+	}
+
+	oids := make([]string, 0, len(in.Objects))
+	for _, obj := range in.Objects {
+		if regExpOID.MatchString(obj.OID) {
+			oids = append(oids, obj.OID)
+		}
+	}
+
+	existing := map[string]*types.LFSObject{}
+	if len(oids) > 0 {
+		objects, err := c.lfsObjectStore.FindMany(ctx, repo.ID, oids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find LFS objects: %w", err)
+		}
+
+		for _, obj := range objects {
+			existing[obj.OID] = obj
+		}
+	}
+
+	results := make([]ObjectResult, len(in.Objects))
+	for i, obj := range in.Objects {
+		results[i] = ObjectResult{Pointer: obj}
+
+		if !regExpOID.MatchString(obj.OID) || obj.Size < 0 {
+			results[i].Error = &ObjectError{
+				Code:    http.StatusUnprocessableEntity,
+				Message: "Invalid object oid or size.",
+			}
+			continue
+		}
+
+		stored, ok := existing[obj.OID]
+
+		switch in.Operation {
+		case OperationDownload:
+			if !ok {
+				results[i].Error = &ObjectError{
+					Code:    http.StatusNotFound,
+					Message: "Object not found.",
+				}
+				continue
+			}
+
+			results[i].Size = stored.Size
+			results[i].Actions = map[Operation]Action{
+				OperationDownload: {Href: c.objectURL(repo.Path, obj.OID)},
+			}
+		case OperationUpload:
+			// objects that are already stored don't have to be uploaded again.
+			if ok {
+				continue
+			}
+
+			results[i].Actions = map[Operation]Action{
+				OperationUpload: {Href: c.objectURL(repo.Path, obj.OID)},
+			}
+		}
+	}
+
+	return &BatchResponse{
+		Transfer: TransferBasic,
+		Objects:  results,
+		HashAlgo: HashAlgorithmSHA256,
+	}, nil
+}
+
+// objectURL returns the URL used by the basic transfer adapter to upload or download an object.
+func (c *Controller) objectURL(repoPath string, oid string) string {
+	return c.urlProvider.GenerateGITCloneURL(repoPath) + "/info/lfs/objects/" + oid
+}
@@ -0,0 +1,245 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+)
+
+func setupController(repo *types.Repository) (*Controller, *lfsObjectStoreFake) {
+	lfsObjectStore := &lfsObjectStoreFake{}
+	return &Controller{
+		authorizer:     &fake.Authorizer{},
+		repoStore:      fake.NewRepoStore(repo),
+		lfsObjectStore: lfsObjectStore,
+		blobStore:      &blobStoreFake{files: map[string][]byte{}},
+		urlProvider:    urlProviderFake{},
+	}, lfsObjectStore
+}
+
+func oidOf(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestBatch_RequestResponseShape(t *testing.T) {
+	c, lfsObjectStore := setupController(&types.Repository{ID: 1, Path: "space/repo"})
+
+	storedOID := oidOf([]byte("stored"))
+	missingOID := oidOf([]byte("missing"))
+	lfsObjectStore.objects = []*types.LFSObject{{ID: 1, RepoID: 1, OID: storedOID, Size: 6}}
+
+	in := new(BatchRequest)
+	err := json.Unmarshal([]byte(`{
+		"operation": "download",
+		"transfers": ["basic"],
+		"ref": {"name": "refs/heads/main"},
+		"objects": [
+			{"oid": "`+storedOID+`", "size": 6},
+			{"oid": "`+missingOID+`", "size": 7},
+			{"oid": "invalid", "size": 1}
+		],
+		"hash_algo": "sha256"
+	}`), in)
+	if err != nil {
+		t.Fatalf("failed to decode batch request: %v", err)
+	}
+
+	out, err := c.Batch(context.Background(), &auth.Session{}, "space/repo", in)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	raw, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("failed to encode batch response: %v", err)
+	}
+
+	want := `{"transfer":"basic","objects":[` +
+		`{"oid":"` + storedOID + `","size":6,"actions":{"download":` +
+		`{"href":"http://localhost/git/space/repo.git/info/lfs/objects/` + storedOID + `"}}},` +
+		`{"oid":"` + missingOID + `","size":7,"error":{"code":404,"message":"Object not found."}},` +
+		`{"oid":"invalid","size":1,"error":{"code":422,"message":"Invalid object oid or size."}}` +
+		`],"hash_algo":"sha256"}`
+	if string(raw) != want {
+		t.Errorf("Want response\n%s\ngot\n%s", want, raw)
+	}
+}
+
+func TestBatch_UploadSkipsStoredObjects(t *testing.T) {
+	c, lfsObjectStore := setupController(&types.Repository{ID: 1, Path: "space/repo"})
+
+	storedOID := oidOf([]byte("stored"))
+	newOID := oidOf([]byte("new"))
+	lfsObjectStore.objects = []*types.LFSObject{{ID: 1, RepoID: 1, OID: storedOID, Size: 6}}
+
+	out, err := c.Batch(context.Background(), &auth.Session{}, "space/repo", &BatchRequest{
+		Operation: OperationUpload,
+		Objects:   []Pointer{{OID: storedOID, Size: 6}, {OID: newOID, Size: 3}},
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if len(out.Objects) != 2 {
+		t.Fatalf("Want 2 objects, got %d", len(out.Objects))
+	}
+	if out.Objects[0].Actions != nil || out.Objects[0].Error != nil {
+		t.Errorf("Want no actions for stored object, got %+v", out.Objects[0])
+	}
+	if _, ok := out.Objects[1].Actions[OperationUpload]; !ok {
+		t.Errorf("Want upload action for new object, got %+v", out.Objects[1])
+	}
+}
+
+func TestBatch_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		repo       *types.Repository
+		in         *BatchRequest
+		wantStatus int
+	}{
+		{
+			name:       "unsupported operation",
+			repo:       &types.Repository{ID: 1},
+			in:         &BatchRequest{Operation: "verify"},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "unsupported hash algorithm",
+			repo:       &types.Repository{ID: 1},
+			in:         &BatchRequest{Operation: OperationDownload, HashAlgo: "sha512"},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "unsupported transfer",
+			repo:       &types.Repository{ID: 1},
+			in:         &BatchRequest{Operation: OperationDownload, Transfers: []string{"ssh"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "upload to archived repo",
+			repo:       &types.Repository{ID: 1, Path: "space/repo", Archived: true},
+			in:         &BatchRequest{Operation: OperationUpload},
+			wantStatus: usererror.ErrRepositoryArchived.Status,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, _ := setupController(test.repo)
+
+			_, err := c.Batch(context.Background(), &auth.Session{}, "space/repo", test.in)
+
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) || uErr.Status != test.wantStatus {
+				t.Errorf("Want error with status %d, got %v", test.wantStatus, err)
+			}
+		})
+	}
+}
+
+func TestUploadDownload_RoundTrip(t *testing.T) {
+	c, lfsObjectStore := setupController(&types.Repository{ID: 1, Path: "space/repo"})
+	ctx := context.Background()
+	session := &auth.Session{Principal: types.Principal{ID: 5}}
+
+	content := []byte("large file content")
+	oid := oidOf(content)
+
+	obj, err := c.Upload(ctx, session, "space/repo", oid, bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("Want no upload error, got %v", err)
+	}
+	if obj.Size != int64(len(content)) || obj.CreatedBy != 5 {
+		t.Errorf("Want size %d created by 5, got size %d created by %d", len(content), obj.Size, obj.CreatedBy)
+	}
+
+	out, err := c.Batch(ctx, session, "space/repo", &BatchRequest{
+		Operation: OperationDownload,
+		Objects:   []Pointer{{OID: oid, Size: int64(len(content))}},
+	})
+	if err != nil {
+		t.Fatalf("Want no batch error, got %v", err)
+	}
+	if _, ok := out.Objects[0].Actions[OperationDownload]; !ok {
+		t.Fatalf("Want download action for uploaded object, got %+v", out.Objects[0])
+	}
+
+	signedURL, file, err := c.Download(ctx, session, "space/repo", oid)
+	if err != nil {
+		t.Fatalf("Want no download error, got %v", err)
+	}
+	if signedURL != "" {
+		t.Errorf("Want no signed URL, got %q", signedURL)
+	}
+	defer file.Close()
+
+	downloaded, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("failed to read downloaded object: %v", err)
+	}
+	if !bytes.Equal(downloaded, content) {
+		t.Errorf("Want downloaded content %q, got %q", content, downloaded)
+	}
+
+	// content that doesn't match the oid is rejected and never becomes available.
+	badOID := oidOf([]byte("other content"))
+	_, err = c.Upload(ctx, session, "space/repo", badOID, strings.NewReader("tampered"))
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusUnprocessableEntity {
+		t.Errorf("Want unprocessable entity error for mismatching content, got %v", err)
+	}
+	if len(lfsObjectStore.objects) != 1 {
+		t.Errorf("Want 1 stored LFS object, got %d", len(lfsObjectStore.objects))
+	}
+}
+
+func TestUpload_MismatchDeletesBlob(t *testing.T) {
+	c, lfsObjectStore := setupController(&types.Repository{ID: 1, Path: "space/repo"})
+	blobStore, _ := c.blobStore.(*blobStoreFake)
+	session := &auth.Session{Principal: types.Principal{ID: 5}}
+
+	badOID := oidOf([]byte("other content"))
+	_, err := c.Upload(context.Background(), session, "space/repo", badOID, strings.NewReader("tampered"))
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusUnprocessableEntity {
+		t.Fatalf("Want unprocessable entity error for mismatching content, got %v", err)
+	}
+
+	if _, ok := blobStore.files[getLFSObjectBucketPath(1, badOID)]; ok {
+		t.Errorf("Want mismatching content to be deleted from the blobstore")
+	}
+	if len(blobStore.files) != 0 {
+		t.Errorf("Want no files in the blobstore, got %d", len(blobStore.files))
+	}
+	if len(lfsObjectStore.objects) != 0 {
+		t.Errorf("Want no stored LFS objects, got %d", len(lfsObjectStore.objects))
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// urlProviderFake generates static urls.
+type urlProviderFake struct {
+	url.Provider
+}
+
+func (urlProviderFake) GenerateGITCloneURL(repoPath string) string {
+	return "http://localhost/git/" + repoPath + ".git"
+}
+
+// lfsObjectStoreFake is an in-memory LFS object store.
+type lfsObjectStoreFake struct {
+	store.LFSObjectStore
+	objects []*types.LFSObject
+}
+
+func (f *lfsObjectStoreFake) Find(_ context.Context, repoID int64, oid string) (*types.LFSObject, error) {
+	for _, obj := range f.objects {
+		if obj.RepoID == repoID && obj.OID == oid {
+			return obj, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (f *lfsObjectStoreFake) FindMany(_ context.Context, repoID int64, oids []string) ([]*types.LFSObject, error) {
+	var res []*types.LFSObject
+	for _, oid := range oids {
+		if obj, err := f.Find(context.Background(), repoID, oid); err == nil {
+			res = append(res, obj)
+		}
+	}
+	return res, nil
+}
+
+func (f *lfsObjectStoreFake) Create(_ context.Context, obj *types.LFSObject) error {
+	if _, err := f.Find(context.Background(), obj.RepoID, obj.OID); err == nil {
+		return gitness_store.ErrDuplicate
+	}
+	obj.ID = int64(len(f.objects) + 1)
+	f.objects = append(f.objects, obj)
+	return nil
+}
+
+// blobStoreFake is an in-memory blob store.
+type blobStoreFake struct {
+	files map[string][]byte
+}
+
+func (f *blobStoreFake) Upload(_ context.Context, file io.Reader, filePath string) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	f.files[filePath] = data
+	return nil
+}
+
+func (f *blobStoreFake) GetSignedURL(context.Context, string) (string, error) {
+	return "", blob.ErrNotSupported
+}
+
+func (f *blobStoreFake) Download(_ context.Context, filePath string) (io.ReadCloser, error) {
+	data, ok := f.files[filePath]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (f *blobStoreFake) Stat(_ context.Context, filePath string) (*blob.FileInfo, error) {
+	data, ok := f.files[filePath]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return &blob.FileInfo{Size: int64(len(data))}, nil
+}
+
+func (f *blobStoreFake) Delete(_ context.Context, filePath string) error {
+	if _, ok := f.files[filePath]; !ok {
+		return blob.ErrNotFound
+	}
+	delete(f.files, filePath)
+	return nil
+}
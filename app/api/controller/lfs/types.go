@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"regexp"
+)
+
+// Operation is the operation requested by an LFS batch request.
+type Operation string
+
+const (
+	OperationDownload Operation = "download"
+	OperationUpload   Operation = "upload"
+)
+
+const (
+	// TransferBasic is the only transfer adapter supported by the server.
+	TransferBasic = "basic"
+
+	// HashAlgorithmSHA256 is the only hash algorithm supported by the server.
+	HashAlgorithmSHA256 = "sha256"
+)
+
+var regExpOID = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// BatchRequest is the request of the LFS batch API.
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md
+type BatchRequest struct {
+	Operation Operation  `json:"operation"`
+	Transfers []string   `json:"transfers,omitempty"`
+	Ref       *Reference `json:"ref,omitempty"`
+	Objects   []Pointer  `json:"objects"`
+	HashAlgo  string     `json:"hash_algo,omitempty"`
+}
+
+// BatchResponse is the response of the LFS batch API.
+type BatchResponse struct {
+	Transfer string         `json:"transfer"`
+	Objects  []ObjectResult `json:"objects"`
+	HashAlgo string         `json:"hash_algo"`
+}
+
+// Reference is the git reference an LFS batch request is made for.
+type Reference struct {
+	Name string `json:"name"`
+}
+
+// Pointer identifies an LFS object by its oid and size.
+type Pointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// ObjectResult is the result for a single object of an LFS batch request.
+// An object that already exists on the server for an upload has neither actions nor an error.
+type ObjectResult struct {
+	Pointer
+	Actions map[Operation]Action `json:"actions,omitempty"`
+	Error   *ObjectError         `json:"error,omitempty"`
+}
+
+// Action describes how the client transfers an object.
+type Action struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+// ObjectError describes why an object can't be transferred.
+type ObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Upload stores the content of an LFS object.
+// The content is verified against the oid before the object becomes available for download.
+func (c *Controller) Upload(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+	file io.Reader,
+) (*types.LFSObject, error) {
+	if !regExpOID.MatchString(oid) {
+		return nil, usererror.BadRequest("Invalid object oid.")
+	}
+
+	repo, err := c.getRepoForOperation(ctx, session, repoRef, OperationUpload)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := c.lfsObjectStore.Find(ctx, repo.ID, oid)
+	if err == nil {
+		return obj, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find LFS object: %w", err)
+	}
+
+	hasher := sha256.New()
+	counter := &countingWriter{}
+
+	bucketPath := getLFSObjectBucketPath(repo.ID, oid)
+
+	err = c.blobStore.Upload(ctx, io.TeeReader(file, io.MultiWriter(hasher, counter)), bucketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload LFS object to blobstore: %w", err)
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != oid {
+		// don't keep content that doesn't belong to the oid in the blobstore.
+		if errDelete := c.blobStore.Delete(ctx, bucketPath); errDelete != nil {
+			log.Ctx(ctx).Warn().Err(errDelete).Msgf("failed to delete mismatching LFS object %q from blobstore", oid)
+		}
+
+		return nil, usererror.UnprocessableEntityf("Object content doesn't match oid %q.", oid)
+	}
+
+	obj = &types.LFSObject{
+		OID:       oid,
+		Size:      counter.n,
+		Created:   time.Now().UnixMilli(),
+		CreatedBy: session.Principal.ID,
+		RepoID:    repo.ID,
+	}
+
+	err = c.lfsObjectStore.Create(ctx, obj)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the same object was uploaded concurrently.
+		return c.lfsObjectStore.Find(ctx, repo.ID, oid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LFS object: %w", err)
+	}
+
+	return obj, nil
+}
+
+// countingWriter counts the number of bytes written to it.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+// CheckPrincipalUnique verifies that no principal with the provided uid or email exists yet.
+// It mirrors the unique constraints of the principals table and is used to validate creations without writing.
+func CheckPrincipalUnique(ctx context.Context, principalStore store.PrincipalStore, uid string, email string) error {
+	_, err := principalStore.FindByUID(ctx, uid)
+	if err == nil {
+		return usererror.Conflict("A principal with the provided uid already exists.")
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find principal by uid: %w", err)
+	}
+
+	return CheckPrincipalEmailUnique(ctx, principalStore, email, 0)
+}
+
+// CheckPrincipalEmailUnique verifies that no principal other than the one with the provided id uses the email.
+// Emails are compared case-insensitively.
+func CheckPrincipalEmailUnique(
+	ctx context.Context,
+	principalStore store.PrincipalStore,
+	email string,
+	principalID int64,
+) error {
+	existing, err := principalStore.FindByEmail(ctx, email)
+	if err == nil && existing.ID != principalID {
+		return usererror.Conflict("A principal with the provided email already exists.")
+	}
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	return nil
+}
@@ -19,11 +19,19 @@ import (
 )
 
 type controller struct {
-	principalStore store.PrincipalStore
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+	maxBatchSize       int
 }
 
-func newController(principalStore store.PrincipalStore) *controller {
+func newController(
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	maxBatchSize int,
+) *controller {
 	return &controller{
-		principalStore: principalStore,
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+		maxBatchSize:       maxBatchSize,
 	}
 }
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package principal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type FindManyUsersInput struct {
+	IDs []int64 `json:"ids"`
+}
+
+func (c controller) FindManyUsers(ctx context.Context, in *FindManyUsersInput) ([]*types.PrincipalInfo, error) {
+	ids := make([]int64, 0, len(in.IDs))
+	seen := make(map[int64]struct{}, len(in.IDs))
+	for _, id := range in.IDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	if len(ids) > c.maxBatchSize {
+		return nil, usererror.BadRequestf("At most %d users can be requested at once.", c.maxBatchSize)
+	}
+
+	if len(ids) == 0 {
+		return []*types.PrincipalInfo{}, nil
+	}
+
+	infos, err := c.principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principals: %w", err)
+	}
+
+	// keep the order of the request and skip ids that don't exist or aren't users.
+	users := make([]*types.PrincipalInfo, 0, len(infos))
+	for _, id := range ids {
+		info, ok := infos[id]
+		if !ok || info.Type != enum.PrincipalTypeUser {
+			continue
+		}
+		users = append(users, info)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package principal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// principalInfoCacheFake serves principal infos from memory.
+type principalInfoCacheFake struct {
+	store.PrincipalInfoCache
+	infos map[int64]*types.PrincipalInfo
+	calls int
+}
+
+func (f *principalInfoCacheFake) Map(_ context.Context, ids []int64) (map[int64]*types.PrincipalInfo, error) {
+	f.calls++
+	m := make(map[int64]*types.PrincipalInfo)
+	for _, id := range ids {
+		if info, ok := f.infos[id]; ok {
+			m[id] = info
+		}
+	}
+	return m, nil
+}
+
+func TestFindManyUsers(t *testing.T) {
+	cache := &principalInfoCacheFake{
+		infos: map[int64]*types.PrincipalInfo{
+			1: {ID: 1, UID: "alice", Type: enum.PrincipalTypeUser},
+			2: {ID: 2, UID: "bob", Type: enum.PrincipalTypeUser},
+			3: {ID: 3, UID: "bot", Type: enum.PrincipalTypeServiceAccount},
+		},
+	}
+	c := newController(nil, cache, 10)
+
+	users, err := c.FindManyUsers(context.Background(), &FindManyUsersInput{IDs: []int64{2, 42, 3, 1, 2}})
+	if err != nil {
+		t.Fatalf("failed to find users: %s", err)
+	}
+
+	want := []int64{2, 1}
+	if len(users) != len(want) {
+		t.Fatalf("Want %d users, got %d", len(want), len(users))
+	}
+	for i := range want {
+		if users[i].ID != want[i] {
+			t.Errorf("Want user %d at position %d, got %d", want[i], i, users[i].ID)
+		}
+	}
+
+	if cache.calls != 1 {
+		t.Errorf("Want a single cache lookup, got %d", cache.calls)
+	}
+}
+
+func TestFindManyUsers_OverCap(t *testing.T) {
+	cache := &principalInfoCacheFake{}
+	c := newController(nil, cache, 2)
+
+	_, err := c.FindManyUsers(context.Background(), &FindManyUsersInput{IDs: []int64{1, 2, 3}})
+
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+		t.Fatalf("Want bad request error, got %v", err)
+	}
+	if cache.calls != 0 {
+		t.Errorf("Want no cache lookup, got %d", cache.calls)
+	}
+
+	// duplicates don't count against the cap.
+	if _, err = c.FindManyUsers(context.Background(), &FindManyUsersInput{IDs: []int64{1, 2, 1}}); err != nil {
+		t.Errorf("Want duplicate ids to be accepted, got %s", err)
+	}
+}
@@ -17,6 +17,7 @@ package principal
 import (
 	"context"
 
+	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 )
 
@@ -26,4 +27,8 @@ type Controller interface {
 	// List lists the principals based on the provided filter.
 	List(ctx context.Context, opts *types.PrincipalFilter) ([]*types.PrincipalInfo, error)
 	Find(ctx context.Context, principalID int64) (*types.PrincipalInfo, error)
+	// FindManyUsers returns the users with the provided ids, ids that don't belong to a user are omitted.
+	FindManyUsers(ctx context.Context, in *FindManyUsersInput) ([]*types.PrincipalInfo, error)
+	// Whoami returns the principal of the provided auth session, regardless of its type.
+	Whoami(ctx context.Context, session *auth.Session) (*types.Whoami, error)
 }
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package principal
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (c controller) Whoami(ctx context.Context, session *auth.Session) (*types.Whoami, error) {
+	if session == nil {
+		return nil, apiauth.ErrNotAuthenticated
+	}
+
+	principal := session.Principal
+	out := &types.Whoami{
+		ID:          principal.ID,
+		UID:         principal.UID,
+		DisplayName: principal.DisplayName,
+		Email:       principal.Email,
+		Type:        principal.Type,
+		Admin:       principal.Admin,
+	}
+
+	if principal.Type != enum.PrincipalTypeServiceAccount {
+		return out, nil
+	}
+
+	sa, err := c.principalStore.FindServiceAccount(ctx, principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find service account: %w", err)
+	}
+
+	out.Parent = &types.WhoamiParent{
+		Type: sa.ParentType,
+		ID:   sa.ParentID,
+	}
+
+	return out, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package principal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// principalStoreFake serves service accounts from memory.
+type principalStoreFake struct {
+	store.PrincipalStore
+	serviceAccounts map[int64]*types.ServiceAccount
+}
+
+func (f *principalStoreFake) FindServiceAccount(_ context.Context, id int64) (*types.ServiceAccount, error) {
+	sa, ok := f.serviceAccounts[id]
+	if !ok {
+		return nil, errors.New("service account not found")
+	}
+	return sa, nil
+}
+
+func TestWhoami_User(t *testing.T) {
+	c := newController(&principalStoreFake{}, nil, 10)
+	session := &auth.Session{Principal: types.Principal{
+		ID:          1,
+		UID:         "alice",
+		DisplayName: "Alice",
+		Email:       "alice@example.com",
+		Type:        enum.PrincipalTypeUser,
+		Admin:       true,
+	}}
+
+	whoami, err := c.Whoami(context.Background(), session)
+	if err != nil {
+		t.Fatalf("failed to get whoami: %s", err)
+	}
+
+	want := `{"id":1,"uid":"alice","display_name":"Alice","email":"alice@example.com","type":"user","admin":true}`
+	if got := marshalWhoami(t, whoami); got != want {
+		t.Errorf("Want %s, got %s", want, got)
+	}
+}
+
+func TestWhoami_ServiceAccount(t *testing.T) {
+	c := newController(&principalStoreFake{
+		serviceAccounts: map[int64]*types.ServiceAccount{
+			7: {ID: 7, UID: "bot", ParentType: enum.ParentResourceTypeSpace, ParentID: 3},
+		},
+	}, nil, 10)
+	session := &auth.Session{Principal: types.Principal{
+		ID:          7,
+		UID:         "bot",
+		DisplayName: "Bot",
+		Email:       "bot@example.com",
+		Type:        enum.PrincipalTypeServiceAccount,
+	}}
+
+	whoami, err := c.Whoami(context.Background(), session)
+	if err != nil {
+		t.Fatalf("failed to get whoami: %s", err)
+	}
+
+	want := `{"id":7,"uid":"bot","display_name":"Bot","email":"bot@example.com","type":"serviceaccount",` +
+		`"admin":false,"parent":{"type":"space","id":3}}`
+	if got := marshalWhoami(t, whoami); got != want {
+		t.Errorf("Want %s, got %s", want, got)
+	}
+}
+
+func TestWhoami_Anonymous(t *testing.T) {
+	c := newController(&principalStoreFake{}, nil, 10)
+
+	_, err := c.Whoami(context.Background(), nil)
+	if !errors.Is(err, apiauth.ErrNotAuthenticated) {
+		t.Errorf("Want error %v, got %v", apiauth.ErrNotAuthenticated, err)
+	}
+}
+
+func marshalWhoami(t *testing.T, whoami *types.Whoami) string {
+	t.Helper()
+
+	raw, err := json.Marshal(whoami)
+	if err != nil {
+		t.Fatalf("failed to marshal whoami: %s", err)
+	}
+	return string(raw)
+}
@@ -16,6 +16,7 @@ package principal
 
 import (
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -25,6 +26,10 @@ var WireSet = wire.NewSet(
 	ProvideController,
 )
 
-func ProvideController(principalStore store.PrincipalStore) Controller {
-	return newController(principalStore)
+func ProvideController(
+	config *types.Config,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+) Controller {
+	return newController(principalStore, principalInfoCache, config.Principal.MaxBatchSize)
 }
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testSourceSHA    = "1111111111111111111111111111111111111111"
+	testMergeBaseSHA = "2222222222222222222222222222222222222222"
+)
+
+// newCommentTestController returns a controller with a single open pull request
+// and an in-memory activity store.
+func newCommentTestController(t *testing.T) (*Controller, *types.PullReq) {
+	t.Helper()
+
+	repo := &types.Repository{ID: 1, ParentID: 10, Identifier: "repo", Path: "space/repo", GitUID: "git-uid"}
+	pr := &types.PullReq{
+		ID:           7,
+		Number:       1,
+		CreatedBy:    1,
+		TargetRepoID: repo.ID,
+		SourceSHA:    testSourceSHA,
+		MergeBaseSHA: testMergeBaseSHA,
+	}
+
+	c := &Controller{
+		tx:            txFake{},
+		authorizer:    &fake.Authorizer{},
+		pullreqStore:  &pullReqStoreFake{pullReqs: []*types.PullReq{pr}},
+		activityStore: &activityStoreFake{},
+		repoStore:     fake.NewRepoStore(repo),
+		git: &gitFake{
+			branchSHA:    sha.Must(testSourceSHA),
+			mergeBaseSHA: sha.Must(testMergeBaseSHA),
+		},
+		eventReporter: newEventReporter(t),
+		sseStreamer:   sseStreamerFake{},
+	}
+
+	return c, pr
+}
+
+func codeCommentInput(path string, line int) *CommentCreateInput {
+	return &CommentCreateInput{
+		Text:            "comment on " + path,
+		TargetCommitSHA: testMergeBaseSHA,
+		SourceCommitSHA: testSourceSHA,
+		Path:            path,
+		LineStart:       line,
+		LineStartNew:    true,
+		LineEnd:         line,
+		LineEndNew:      true,
+	}
+}
+
+func TestCommentCreate_CodeComment(t *testing.T) {
+	c, pr := newCommentTestController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 2, UID: "reviewer"}}
+
+	act, err := c.CommentCreate(context.Background(), session, "space/repo", pr.Number,
+		codeCommentInput("app/main.go", 12))
+	if err != nil {
+		t.Fatalf("failed to create code comment: %s", err)
+	}
+
+	if !act.IsValidCodeComment() {
+		t.Fatalf("Want a valid code comment, got type=%q kind=%q", act.Type, act.Kind)
+	}
+	if want, got := enum.PullReqActivityTypeCodeComment, act.Type; want != got {
+		t.Errorf("Want type %q, got %q", want, got)
+	}
+	if want, got := "app/main.go", act.CodeComment.Path; want != got {
+		t.Errorf("Want path %q, got %q", want, got)
+	}
+	if want, got := 12, act.CodeComment.LineNew; want != got {
+		t.Errorf("Want new line %d, got %d", want, got)
+	}
+	if want, got := testSourceSHA, act.CodeComment.SourceSHA; want != got {
+		t.Errorf("Want source SHA %q, got %q", want, got)
+	}
+	if pr.CommentCount != 1 {
+		t.Errorf("Want comment count 1, got %d", pr.CommentCount)
+	}
+}
+
+func TestCommentCreate_CodeCommentInvalid(t *testing.T) {
+	c, pr := newCommentTestController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 2, UID: "reviewer"}}
+
+	in := codeCommentInput("", 12)
+	if _, err := c.CommentCreate(context.Background(), session, "space/repo", pr.Number, in); err == nil {
+		t.Error("Want an error for a code comment without a path")
+	}
+
+	in = codeCommentInput("app/main.go", 0)
+	if _, err := c.CommentCreate(context.Background(), session, "space/repo", pr.Number, in); err == nil {
+		t.Error("Want an error for a code comment without a line")
+	}
+}
@@ -50,7 +50,7 @@ func (c *Controller) CommentDelete(
 			return fmt.Errorf("failed to find pull request by number: %w", err)
 		}
 
-		act, err := c.getCommentCheckEditAccess(ctx, session, pr, commentID)
+		act, err := c.getCommentCheckDeleteAccess(ctx, session, repo, pr, commentID)
 		if err != nil {
 			return fmt.Errorf("failed to get comment: %w", err)
 		}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+)
+
+func TestCommentDelete_Permissions(t *testing.T) {
+	const (
+		authorID = 2
+		otherID  = 3
+		adminID  = 4
+	)
+
+	tests := []struct {
+		name        string
+		principalID int64
+		wantStatus  int
+	}{
+		{name: "author", principalID: authorID},
+		{name: "repo-admin", principalID: adminID},
+		{name: "other-user", principalID: otherID, wantStatus: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			c, pr := newCommentTestController(t)
+
+			author := &auth.Session{Principal: types.Principal{ID: authorID, UID: "author"}}
+			act, err := c.CommentCreate(ctx, author, "space/repo", pr.Number, &CommentCreateInput{Text: "text"})
+			if err != nil {
+				t.Fatalf("failed to create comment: %s", err)
+			}
+
+			c.authorizer = &fake.Authorizer{Deny: viewOnly(otherID)}
+
+			session := &auth.Session{Principal: types.Principal{ID: test.principalID}}
+			err = c.CommentDelete(ctx, session, "space/repo", pr.Number, act.ID)
+
+			if test.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("Want comment deleted, got error: %s", err)
+				}
+				if act.Deleted == nil {
+					t.Error("Want comment marked as deleted")
+				}
+				return
+			}
+
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) || uErr.Status != test.wantStatus {
+				t.Errorf("Want error with status %d, got %v", test.wantStatus, err)
+			}
+			if act.Deleted != nil {
+				t.Error("Want comment not deleted")
+			}
+		})
+	}
+}
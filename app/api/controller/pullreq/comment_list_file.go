@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// CommentThread is a code comment followed by all its replies.
+type CommentThread struct {
+	Comments []*types.PullReqActivity `json:"comments"`
+}
+
+// CommentFile holds all code comment threads anchored to a single file of the pull request diff.
+type CommentFile struct {
+	Path    string          `json:"path"`
+	Threads []CommentThread `json:"threads"`
+}
+
+// CommentListByFile returns code comment threads of a pull request grouped by file path.
+func (c *Controller) CommentListByFile(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) ([]CommentFile, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	list, err := c.activityStore.List(ctx, pr.ID, &types.PullReqActivityFilter{
+		Kinds: []enum.PullReqActivityKind{enum.PullReqActivityKindChangeComment},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request code comments: %w", err)
+	}
+
+	list = removeDeletedComments(list)
+
+	return groupCommentsByFile(list), nil
+}
+
+// groupCommentsByFile groups a list of change comments, ordered by order and sub-order, into threads
+// and groups the threads by the file path of the code comment that started the thread.
+// The resulting list is sorted by the file path.
+func groupCommentsByFile(list []*types.PullReqActivity) []CommentFile {
+	files := make([]CommentFile, 0)
+	fileIdx := make(map[string]int)
+
+	var thread *CommentThread
+	for _, act := range list {
+		if act.SubOrder == 0 {
+			thread = nil
+			if !act.IsValidCodeComment() {
+				continue
+			}
+
+			path := act.CodeComment.Path
+			idx, ok := fileIdx[path]
+			if !ok {
+				idx = len(files)
+				fileIdx[path] = idx
+				files = append(files, CommentFile{Path: path})
+			}
+
+			files[idx].Threads = append(files[idx].Threads, CommentThread{})
+			thread = &files[idx].Threads[len(files[idx].Threads)-1]
+		}
+
+		if thread == nil || (thread.Comments != nil && thread.Comments[0].Order != act.Order) {
+			continue
+		}
+
+		thread.Comments = append(thread.Comments, act)
+	}
+
+	slices.SortStableFunc(files, func(a, b CommentFile) bool {
+		return a.Path < b.Path
+	})
+
+	return files
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestCommentListByFile(t *testing.T) {
+	ctx := context.Background()
+	c, pr := newCommentTestController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 2, UID: "reviewer"}}
+
+	create := func(in *CommentCreateInput) *types.PullReqActivity {
+		act, err := c.CommentCreate(ctx, session, "space/repo", pr.Number, in)
+		if err != nil {
+			t.Fatalf("failed to create comment: %s", err)
+		}
+		return act
+	}
+
+	mainFirst := create(codeCommentInput("main.go", 3))
+	create(&CommentCreateInput{Text: "general comment"})
+	readme := create(codeCommentInput("README.md", 1))
+	mainReply := create(&CommentCreateInput{Text: "reply", ParentID: mainFirst.ID})
+	mainSecond := create(codeCommentInput("main.go", 10))
+
+	files, err := c.CommentListByFile(ctx, session, "space/repo", pr.Number)
+	if err != nil {
+		t.Fatalf("failed to list comments: %s", err)
+	}
+
+	want := []struct {
+		path    string
+		threads [][]int64
+	}{
+		{path: "README.md", threads: [][]int64{{readme.ID}}},
+		{path: "main.go", threads: [][]int64{{mainFirst.ID, mainReply.ID}, {mainSecond.ID}}},
+	}
+
+	if len(files) != len(want) {
+		t.Fatalf("Want %d files, got %d", len(want), len(files))
+	}
+
+	for i, w := range want {
+		if files[i].Path != w.path {
+			t.Errorf("Want file %d path %q, got %q", i, w.path, files[i].Path)
+			continue
+		}
+
+		if len(files[i].Threads) != len(w.threads) {
+			t.Errorf("Want %d threads for %q, got %d", len(w.threads), w.path, len(files[i].Threads))
+			continue
+		}
+
+		for j, thread := range files[i].Threads {
+			got := make([]int64, len(thread.Comments))
+			for k, comment := range thread.Comments {
+				got[k] = comment.ID
+			}
+
+			if !slices.Equal(w.threads[j], got) {
+				t.Errorf("Want thread %d of %q to be %v, got %v", j, w.path, w.threads[j], got)
+			}
+		}
+	}
+}
@@ -33,8 +33,7 @@ type CommentStatusInput struct {
 }
 
 func (in *CommentStatusInput) Validate() error {
-	_, ok := in.Status.Sanitize()
-	if !ok {
+	if !in.Status.Valid() {
 		return usererror.BadRequest("Invalid value provided for comment status")
 	}
 
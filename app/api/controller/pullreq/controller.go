@@ -28,9 +28,11 @@ import (
 	locker "github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	gitenum "github.com/harness/gitness/git/enum"
@@ -61,6 +63,8 @@ type Controller struct {
 	sseStreamer         sse.Streamer
 	codeOwners          *codeowners.Service
 	locker              *locker.Locker
+	settings            *settings.Service
+	mergeabilityCache   cache.Cache[mergeabilityKey, *types.PullReqMergeability]
 }
 
 func NewController(
@@ -85,6 +89,7 @@ func NewController(
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
 	locker *locker.Locker,
+	settings *settings.Service,
 ) *Controller {
 	return &Controller{
 		tx:                  tx,
@@ -108,6 +113,8 @@ func NewController(
 		sseStreamer:         sseStreamer,
 		codeOwners:          codeowners,
 		locker:              locker,
+		settings:            settings,
+		mergeabilityCache:   newMergeabilityCache(git, systemIdentity),
 	}
 }
 
@@ -206,6 +213,31 @@ func (c *Controller) getCommentCheckEditAccess(ctx context.Context,
 	return comment, nil
 }
 
+// getCommentCheckDeleteAccess returns the comment if the principal is allowed to delete it.
+// Comments can be deleted by their author or by principals with edit access to the repository.
+func (c *Controller) getCommentCheckDeleteAccess(ctx context.Context,
+	session *auth.Session, repo *types.Repository, pr *types.PullReq, commentID int64,
+) (*types.PullReqActivity, error) {
+	comment, err := c.getCommentCheckModifyAccess(ctx, pr, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.CreatedBy == session.Principal.ID {
+		return comment, nil
+	}
+
+	err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return nil, usererror.Forbidden("Only own comments may be deleted.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check repo edit access: %w", err)
+	}
+
+	return comment, nil
+}
+
 func (c *Controller) getCommentCheckChangeStatusAccess(ctx context.Context,
 	pr *types.PullReq, commentID int64,
 ) (*types.PullReqActivity, error) {
@@ -50,10 +50,6 @@ type MergeInput struct {
 }
 
 func (in *MergeInput) sanitize() error {
-	if in.Method == "" && !in.DryRun {
-		return usererror.BadRequest("merge method must be provided if dry run is false")
-	}
-
 	if in.SourceSHA == "" {
 		return usererror.BadRequest("source SHA must be provided")
 	}
@@ -84,7 +80,8 @@ func (in *MergeInput) sanitize() error {
 // might block the merging. Dry running typically should be used with BypassRules=true.
 //
 // MergeMethod doesn't need to be provided for dry running. If no MergeMethod has been provided the function will
-// return allowed merge methods. Rules can limit allowed merge methods.
+// return allowed merge methods. Rules and repository settings can limit allowed merge methods.
+// Outside of dry running, an omitted MergeMethod falls back to the repository's default merge method.
 //
 // If the pull request has been successfully merged the function will return the SHA of the merge commit.
 //
@@ -110,6 +107,15 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
 
+	if targetRepo.Archived && !in.DryRun {
+		return nil, nil, usererror.ErrRepositoryArchived
+	}
+
+	allowedMethods, err := c.resolveMergeMethod(ctx, targetRepo, in)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// the max time we give a merge to succeed
 	const timeout = 3 * time.Minute
 
@@ -281,7 +287,7 @@ func (c *Controller) Merge(
 			// values only retured by dry run
 			DryRun:                              true,
 			ConflictFiles:                       pr.MergeConflicts,
-			AllowedMethods:                      ruleOut.AllowedMethods,
+			AllowedMethods:                      filterMergeMethods(ruleOut.AllowedMethods, allowedMethods),
 			RequiresCodeOwnersApproval:          ruleOut.RequiresCodeOwnersApproval,
 			RequiresCodeOwnersApprovalLatest:    ruleOut.RequiresCodeOwnersApprovalLatest,
 			RequiresCommentResolution:           ruleOut.RequiresCommentResolution,
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// resolveMergeMethod applies the repository's merge method settings to the merge input.
+// If the input doesn't specify a merge method, the repository's default merge method is used (unless dry running).
+// It returns the merge methods allowed by the repository settings (empty means all methods are allowed).
+func (c *Controller) resolveMergeMethod(
+	ctx context.Context,
+	repo *types.Repository,
+	in *MergeInput,
+) ([]enum.MergeMethod, error) {
+	defaultMethod := settings.DefaultDefaultMergeMethod
+	allowedMethods := settings.DefaultAllowedMergeMethods
+	err := c.settings.RepoMap(ctx, repo.ID,
+		settings.Mapping(settings.KeyDefaultMergeMethod, &defaultMethod),
+		settings.Mapping(settings.KeyAllowedMergeMethods, &allowedMethods),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map merge method settings: %w", err)
+	}
+
+	if in.Method == "" && !in.DryRun {
+		if defaultMethod == "" {
+			return nil, usererror.BadRequest("merge method must be provided if dry run is false")
+		}
+		in.Method = defaultMethod
+	}
+
+	if in.Method != "" && len(allowedMethods) > 0 && !slices.Contains(allowedMethods, in.Method) {
+		return nil, usererror.BadRequestf("merge method %s is not allowed for this repository", in.Method)
+	}
+
+	if in.Method == enum.MergeMethodRebase && (in.Title != "" || in.Message != "") {
+		return nil, usererror.BadRequest("rebase doesn't support customizing commit title and message")
+	}
+
+	return allowedMethods, nil
+}
+
+// filterMergeMethods returns the methods that are also in the allowed list (empty allowed list allows all).
+func filterMergeMethods(methods []enum.MergeMethod, allowed []enum.MergeMethod) []enum.MergeMethod {
+	if len(allowed) == 0 {
+		return methods
+	}
+
+	filtered := make([]enum.MergeMethod, 0, len(methods))
+	for _, method := range methods {
+		if slices.Contains(allowed, method) {
+			filtered = append(filtered, method)
+		}
+	}
+
+	return filtered
+}
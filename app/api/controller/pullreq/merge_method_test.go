@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestResolveMergeMethod(t *testing.T) {
+	repo := &types.Repository{ID: 1}
+
+	newController := func(values map[string]json.RawMessage) *Controller {
+		return &Controller{settings: settings.NewService(&settingsStoreFake{values: values})}
+	}
+
+	restricted := map[string]json.RawMessage{
+		string(settings.KeyDefaultMergeMethod):  json.RawMessage(`"squash"`),
+		string(settings.KeyAllowedMergeMethods): json.RawMessage(`["squash","rebase"]`),
+	}
+
+	t.Run("default applied", func(t *testing.T) {
+		in := &MergeInput{SourceSHA: "sha"}
+		allowed, err := newController(restricted).resolveMergeMethod(context.Background(), repo, in)
+		if err != nil {
+			t.Fatalf("Want no error, got %v", err)
+		}
+		if in.Method != enum.MergeMethodSquash {
+			t.Errorf("Want method %q, got %q", enum.MergeMethodSquash, in.Method)
+		}
+		if len(allowed) != 2 {
+			t.Errorf("Want 2 allowed methods, got %v", allowed)
+		}
+	})
+
+	t.Run("default not applied on dry run", func(t *testing.T) {
+		in := &MergeInput{SourceSHA: "sha", DryRun: true}
+		if _, err := newController(restricted).resolveMergeMethod(context.Background(), repo, in); err != nil {
+			t.Fatalf("Want no error, got %v", err)
+		}
+		if in.Method != "" {
+			t.Errorf("Want no method, got %q", in.Method)
+		}
+	})
+
+	t.Run("disallowed method rejected", func(t *testing.T) {
+		in := &MergeInput{SourceSHA: "sha", Method: enum.MergeMethodMerge}
+		_, err := newController(restricted).resolveMergeMethod(context.Background(), repo, in)
+		var uErr *usererror.Error
+		if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+			t.Errorf("Want bad request error, got %v", err)
+		}
+	})
+
+	t.Run("no default and no method", func(t *testing.T) {
+		in := &MergeInput{SourceSHA: "sha"}
+		_, err := newController(nil).resolveMergeMethod(context.Background(), repo, in)
+		var uErr *usererror.Error
+		if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+			t.Errorf("Want bad request error, got %v", err)
+		}
+	})
+
+	t.Run("all methods allowed by default", func(t *testing.T) {
+		in := &MergeInput{SourceSHA: "sha", Method: enum.MergeMethodRebase}
+		allowed, err := newController(nil).resolveMergeMethod(context.Background(), repo, in)
+		if err != nil {
+			t.Fatalf("Want no error, got %v", err)
+		}
+		if got := filterMergeMethods(enum.MergeMethods, allowed); len(got) != len(enum.MergeMethods) {
+			t.Errorf("Want all merge methods, got %v", got)
+		}
+	})
+}
+
+func TestFilterMergeMethods(t *testing.T) {
+	got := filterMergeMethods(enum.MergeMethods, []enum.MergeMethod{enum.MergeMethodSquash})
+	if len(got) != 1 || got[0] != enum.MergeMethodSquash {
+		t.Errorf("Want [squash], got %v", got)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// mergeabilityCacheDuration is the duration for which a mergeability result is kept.
+// The results never get stale, because the cache key includes the tips of both branches.
+const mergeabilityCacheDuration = 10 * time.Minute
+
+// MergeabilityInput holds the branches for which the mergeability is checked.
+// The source repository (if provided) has to be the target repository, as forks are not supported for now.
+type MergeabilityInput struct {
+	SourceRepoRef string
+	SourceBranch  string
+	TargetBranch  string
+}
+
+// mergeabilityKey identifies a mergeability result. It contains the tips of both branches,
+// so any push to either of the branches results in the mergeability being recomputed.
+type mergeabilityKey struct {
+	repoUID   string
+	sourceSHA string
+	targetSHA string
+}
+
+// mergeabilityGetter computes the mergeability of two commits by running a merge check.
+// The results are shared between all users, so the merge check is executed as the provided actor.
+type mergeabilityGetter struct {
+	git   git.Interface
+	actor func() git.Identity
+}
+
+func newMergeabilityCache(
+	gitInterface git.Interface,
+	actor func() git.Identity,
+) cache.Cache[mergeabilityKey, *types.PullReqMergeability] {
+	return cache.New[mergeabilityKey, *types.PullReqMergeability](
+		mergeabilityGetter{git: gitInterface, actor: actor}, mergeabilityCacheDuration)
+}
+
+// systemIdentity returns the git identity of the system principal.
+// It's resolved lazily, as the system principal is only available after the system got bootstrapped.
+func systemIdentity() git.Identity {
+	principal := bootstrap.NewSystemServiceSession().Principal
+	return git.Identity{
+		Name:  principal.DisplayName,
+		Email: principal.Email,
+	}
+}
+
+func (g mergeabilityGetter) Find(ctx context.Context, key mergeabilityKey) (*types.PullReqMergeability, error) {
+	// no reference is provided, so only a merge check is performed and no hooks are executed.
+	mergeOutput, err := g.git.Merge(ctx, &git.MergeParams{
+		WriteParams: git.WriteParams{
+			Actor:   g.actor(),
+			RepoUID: key.repoUID,
+		},
+		BaseBranch:  key.targetSHA,
+		HeadRepoUID: key.repoUID, // forks are not supported for now
+		HeadBranch:  key.sourceSHA,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.PullReqMergeability{
+		Status:        enum.MergeabilityStatusMergeable,
+		SourceSHA:     key.sourceSHA,
+		TargetSHA:     key.targetSHA,
+		MergeBaseSHA:  mergeOutput.MergeBaseSHA.String(),
+		ConflictFiles: mergeOutput.ConflictFiles,
+	}
+	if len(mergeOutput.ConflictFiles) > 0 {
+		result.Status = enum.MergeabilityStatusConflict
+	}
+
+	return result, nil
+}
+
+// Mergeability checks whether the source branch can be merged cleanly into the target branch,
+// without requiring a pull request to exist.
+func (c *Controller) Mergeability(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MergeabilityInput,
+) (*types.PullReqMergeability, error) {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	sourceRepo := targetRepo
+	if in.SourceRepoRef != "" {
+		sourceRepo, err = c.getRepoCheckAccess(ctx, session, in.SourceRepoRef, enum.PermissionRepoView)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire access to source repo: %w", err)
+		}
+	}
+
+	// the merge check runs in a single repository.
+	if sourceRepo.ID != targetRepo.ID {
+		return nil, usererror.BadRequest("source and target branch have to be in the same repository")
+	}
+
+	if in.TargetBranch == in.SourceBranch {
+		return nil, usererror.BadRequest("target and source branch can't be the same")
+	}
+
+	sourceSHA, err := c.verifyBranchExistence(ctx, sourceRepo, in.SourceBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	targetSHA, err := c.verifyBranchExistence(ctx, targetRepo, in.TargetBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	mergeability, err := c.mergeabilityCache.Get(ctx, mergeabilityKey{
+		repoUID:   sourceRepo.GitUID,
+		sourceSHA: sourceSHA,
+		targetSHA: targetSHA,
+	})
+	if errors.IsInvalidArgument(err) {
+		return nil, err
+	}
+	if err != nil {
+		// failed checks aren't cached, so the next request will retry the check.
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to compute mergeability")
+
+		return &types.PullReqMergeability{
+			Status:    enum.MergeabilityStatusUnknown,
+			SourceSHA: sourceSHA,
+			TargetSHA: targetSHA,
+		}, nil
+	}
+
+	return mergeability, nil
+}
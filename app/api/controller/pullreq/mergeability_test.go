@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	mergeabilityMainSHA    = "1111111111111111111111111111111111111111"
+	mergeabilityFeatureSHA = "2222222222222222222222222222222222222222"
+	mergeabilityPushedSHA  = "3333333333333333333333333333333333333333"
+	mergeabilityBaseSHA    = "4444444444444444444444444444444444444444"
+)
+
+// mergeabilityGitFake resolves branches from memory and reports the configured conflicts for merge checks.
+type mergeabilityGitFake struct {
+	git.Interface
+	branches map[string]string
+	// conflicts holds the conflicting files keyed by "<target sha>...<source sha>".
+	conflicts map[string][]string
+	merges    []*git.MergeParams
+}
+
+func (f *mergeabilityGitFake) GetRef(_ context.Context, params git.GetRefParams) (git.GetRefResponse, error) {
+	return git.GetRefResponse{SHA: sha.Must(f.branches[params.Name])}, nil
+}
+
+func (f *mergeabilityGitFake) Merge(_ context.Context, params *git.MergeParams) (git.MergeOutput, error) {
+	f.merges = append(f.merges, params)
+	return git.MergeOutput{
+		BaseSHA:       sha.Must(params.BaseBranch),
+		HeadSHA:       sha.Must(params.HeadBranch),
+		MergeBaseSHA:  sha.Must(mergeabilityBaseSHA),
+		ConflictFiles: f.conflicts[params.BaseBranch+"..."+params.HeadBranch],
+	}, nil
+}
+
+func setupMergeabilityController(conflicts map[string][]string) (*Controller, *mergeabilityGitFake) {
+	g := &mergeabilityGitFake{
+		branches: map[string]string{
+			"main":    mergeabilityMainSHA,
+			"feature": mergeabilityFeatureSHA,
+		},
+		conflicts: conflicts,
+	}
+
+	return &Controller{
+		authorizer: &fake.Authorizer{},
+		repoStore: fake.NewRepoStore(
+			&types.Repository{ID: 1, Path: "space/repo", GitUID: "repo-uid"},
+			&types.Repository{ID: 2, Path: "space/fork", GitUID: "fork-uid"},
+		),
+		git: g,
+		mergeabilityCache: newMergeabilityCache(g, func() git.Identity {
+			return git.Identity{Name: "system", Email: "system@gitness.io"}
+		}),
+	}, g
+}
+
+var mergeabilityInput = &MergeabilityInput{SourceBranch: "feature", TargetBranch: "main"}
+
+func TestMergeability_Mergeable(t *testing.T) {
+	c, g := setupMergeabilityController(nil)
+
+	out, err := c.Mergeability(context.Background(), &auth.Session{}, "space/repo", mergeabilityInput)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if out.Status != enum.MergeabilityStatusMergeable {
+		t.Errorf("Want status %s, got %s", enum.MergeabilityStatusMergeable, out.Status)
+	}
+	if out.SourceSHA != mergeabilityFeatureSHA || out.TargetSHA != mergeabilityMainSHA {
+		t.Errorf("Want source %s and target %s, got %s and %s",
+			mergeabilityFeatureSHA, mergeabilityMainSHA, out.SourceSHA, out.TargetSHA)
+	}
+	if out.MergeBaseSHA != mergeabilityBaseSHA {
+		t.Errorf("Want merge base %s, got %s", mergeabilityBaseSHA, out.MergeBaseSHA)
+	}
+	if len(out.ConflictFiles) != 0 {
+		t.Errorf("Want no conflicts, got %v", out.ConflictFiles)
+	}
+
+	if len(g.merges) != 1 {
+		t.Fatalf("Want one merge check, got %d", len(g.merges))
+	}
+	if g.merges[0].RefType != gitenum.RefTypeUndefined || g.merges[0].RefName != "" {
+		t.Errorf("Want merge check without reference update, got %s %q", g.merges[0].RefType, g.merges[0].RefName)
+	}
+}
+
+func TestMergeability_Conflict(t *testing.T) {
+	c, _ := setupMergeabilityController(map[string][]string{
+		mergeabilityMainSHA + "..." + mergeabilityFeatureSHA: {"a.txt", "b.txt"},
+	})
+
+	out, err := c.Mergeability(context.Background(), &auth.Session{}, "space/repo", mergeabilityInput)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if out.Status != enum.MergeabilityStatusConflict {
+		t.Errorf("Want status %s, got %s", enum.MergeabilityStatusConflict, out.Status)
+	}
+	if strings.Join(out.ConflictFiles, ",") != "a.txt,b.txt" {
+		t.Errorf("Want conflicts [a.txt b.txt], got %v", out.ConflictFiles)
+	}
+}
+
+func TestMergeability_CacheInvalidatedByPush(t *testing.T) {
+	c, g := setupMergeabilityController(map[string][]string{
+		mergeabilityMainSHA + "..." + mergeabilityFeatureSHA: {"a.txt"},
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		out, err := c.Mergeability(ctx, &auth.Session{}, "space/repo", mergeabilityInput)
+		if err != nil {
+			t.Fatalf("Want no error, got %v", err)
+		}
+		if out.Status != enum.MergeabilityStatusConflict {
+			t.Errorf("Want status %s, got %s", enum.MergeabilityStatusConflict, out.Status)
+		}
+	}
+	if len(g.merges) != 1 {
+		t.Fatalf("Want the cached result to be reused, got %d merge checks", len(g.merges))
+	}
+
+	// a new push to the source branch resolves the conflict.
+	g.branches["feature"] = mergeabilityPushedSHA
+
+	out, err := c.Mergeability(ctx, &auth.Session{}, "space/repo", mergeabilityInput)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if len(g.merges) != 2 {
+		t.Fatalf("Want mergeability to be recomputed after the push, got %d merge checks", len(g.merges))
+	}
+	if out.Status != enum.MergeabilityStatusMergeable || out.SourceSHA != mergeabilityPushedSHA {
+		t.Errorf("Want status %s for source %s, got %s for %s",
+			enum.MergeabilityStatusMergeable, mergeabilityPushedSHA, out.Status, out.SourceSHA)
+	}
+}
+
+func TestMergeability_SourceRepo(t *testing.T) {
+	c, g := setupMergeabilityController(nil)
+	ctx := context.Background()
+
+	in := &MergeabilityInput{SourceRepoRef: "space/repo", SourceBranch: "feature", TargetBranch: "main"}
+	if _, err := c.Mergeability(ctx, &auth.Session{}, "space/repo", in); err != nil {
+		t.Fatalf("Want target repo as source repo to be accepted, got %v", err)
+	}
+
+	// forks are not supported, the merge check would run in the wrong repository.
+	in = &MergeabilityInput{SourceRepoRef: "space/fork", SourceBranch: "feature", TargetBranch: "main"}
+	_, err := c.Mergeability(ctx, &auth.Session{}, "space/repo", in)
+	if status := usererror.Translate(ctx, err).Status; status != http.StatusBadRequest {
+		t.Errorf("Want status %d for another source repo, got %d (%v)", http.StatusBadRequest, status, err)
+	}
+
+	if len(g.merges) != 1 {
+		t.Errorf("Want one merge check, got %d", len(g.merges))
+	}
+}
@@ -121,6 +121,10 @@ func (c *Controller) Create(
 		SourceSHA:    sourceSHA,
 	})
 
+	if err = c.addDefaultReviewers(ctx, session, targetRepo, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to add default reviewers to pull request")
+	}
+
 	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
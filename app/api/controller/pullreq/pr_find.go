@@ -62,5 +62,31 @@ func (c *Controller) Find(
 		pr.Stats.DiffStats = types.NewDiffStats(output.Commits, output.FilesChanged)
 	}
 
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request reviewers: %w", err)
+	}
+
+	pr.Stats.Approvals, pr.Stats.ChangesRequested = aggregateReviews(reviewers)
+
 	return pr, nil
 }
+
+// aggregateReviews returns the number of reviewers whose latest review approved the pull request
+// and whether any reviewer's latest review requested changes.
+func aggregateReviews(reviewers []*types.PullReqReviewer) (int, bool) {
+	approvals := 0
+	changesRequested := false
+	for _, reviewer := range reviewers {
+		switch reviewer.ReviewDecision {
+		case enum.PullReqReviewDecisionApproved:
+			approvals++
+		case enum.PullReqReviewDecisionChangeReq:
+			changesRequested = true
+		case enum.PullReqReviewDecisionPending,
+			enum.PullReqReviewDecisionReviewed:
+		}
+	}
+
+	return approvals, changesRequested
+}
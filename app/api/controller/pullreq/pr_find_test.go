@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestAggregateReviews(t *testing.T) {
+	tests := []struct {
+		name                string
+		decisions           []enum.PullReqReviewDecision
+		wantApprovals       int
+		wantChangeRequested bool
+	}{
+		{
+			name: "no-reviewers",
+		},
+		{
+			name: "pending-and-commented",
+			decisions: []enum.PullReqReviewDecision{
+				enum.PullReqReviewDecisionPending,
+				enum.PullReqReviewDecisionReviewed,
+			},
+		},
+		{
+			name: "approved",
+			decisions: []enum.PullReqReviewDecision{
+				enum.PullReqReviewDecisionApproved,
+				enum.PullReqReviewDecisionApproved,
+				enum.PullReqReviewDecisionReviewed,
+			},
+			wantApprovals: 2,
+		},
+		{
+			name: "approved-and-changes-requested",
+			decisions: []enum.PullReqReviewDecision{
+				enum.PullReqReviewDecisionApproved,
+				enum.PullReqReviewDecisionChangeReq,
+			},
+			wantApprovals:       1,
+			wantChangeRequested: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reviewers := make([]*types.PullReqReviewer, len(test.decisions))
+			for i, decision := range test.decisions {
+				reviewers[i] = &types.PullReqReviewer{ReviewDecision: decision}
+			}
+
+			approvals, changesRequested := aggregateReviews(reviewers)
+			if approvals != test.wantApprovals {
+				t.Errorf("Want %d approvals, got %d", test.wantApprovals, approvals)
+			}
+			if changesRequested != test.wantChangeRequested {
+				t.Errorf("Want changes requested %t, got %t", test.wantChangeRequested, changesRequested)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestReviewSubmit(t *testing.T) {
+	const (
+		authorID   = 1
+		reviewerID = 2
+	)
+
+	commitSHA := sha.Must("1111111111111111111111111111111111111111")
+
+	tests := []struct {
+		name     string
+		decision enum.PullReqReviewDecision
+	}{
+		{name: "approved", decision: enum.PullReqReviewDecisionApproved},
+		{name: "changes-requested", decision: enum.PullReqReviewDecisionChangeReq},
+		{name: "commented", decision: enum.PullReqReviewDecisionReviewed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &types.Repository{ID: 1, ParentID: 10, Identifier: "repo", Path: "space/repo", GitUID: "git-uid"}
+			pr := &types.PullReq{ID: 7, Number: 1, CreatedBy: authorID, TargetRepoID: repo.ID}
+
+			reviewStore := &reviewStoreFake{}
+			reviewerStore := &reviewerStoreFake{}
+			activityStore := &activityStoreFake{}
+
+			c := &Controller{
+				tx:            txFake{},
+				authorizer:    &fake.Authorizer{},
+				pullreqStore:  &pullReqStoreFake{pullReqs: []*types.PullReq{pr}},
+				activityStore: activityStore,
+				reviewStore:   reviewStore,
+				reviewerStore: reviewerStore,
+				repoStore:     fake.NewRepoStore(repo),
+				git:           &gitFake{branchSHA: commitSHA},
+				eventReporter: newEventReporter(t),
+			}
+
+			session := &auth.Session{Principal: types.Principal{ID: reviewerID, UID: "reviewer"}}
+
+			review, err := c.ReviewSubmit(context.Background(), session, "space/repo", pr.Number,
+				&ReviewSubmitInput{CommitSHA: commitSHA.String(), Decision: test.decision})
+			if err != nil {
+				t.Fatalf("failed to submit review: %s", err)
+			}
+
+			if review.Decision != test.decision {
+				t.Errorf("Want decision %q, got %q", test.decision, review.Decision)
+			}
+			if review.SHA != commitSHA.String() {
+				t.Errorf("Want review SHA %q, got %q", commitSHA, review.SHA)
+			}
+			if len(reviewStore.reviews) != 1 {
+				t.Errorf("Want 1 stored review, got %d", len(reviewStore.reviews))
+			}
+
+			if len(reviewerStore.reviewers) != 1 {
+				t.Fatalf("Want 1 reviewer, got %d", len(reviewerStore.reviewers))
+			}
+			reviewer := reviewerStore.reviewers[0]
+			if reviewer.PrincipalID != reviewerID {
+				t.Errorf("Want reviewer %d, got %d", reviewerID, reviewer.PrincipalID)
+			}
+			if reviewer.ReviewDecision != test.decision {
+				t.Errorf("Want reviewer decision %q, got %q", test.decision, reviewer.ReviewDecision)
+			}
+			if reviewer.LatestReviewID == nil || *reviewer.LatestReviewID != review.ID {
+				t.Errorf("Want latest review ID %d, got %v", review.ID, reviewer.LatestReviewID)
+			}
+
+			if len(activityStore.activities) != 1 ||
+				activityStore.activities[0].Type != enum.PullReqActivityTypeReviewSubmit {
+				t.Errorf("Want a single review submit activity, got %v", activityStore.activities)
+			}
+		})
+	}
+}
+
+func TestReviewSubmit_Invalid(t *testing.T) {
+	const authorID = 1
+
+	commitSHA := sha.Must("1111111111111111111111111111111111111111")
+	repo := &types.Repository{ID: 1, ParentID: 10, Identifier: "repo", Path: "space/repo", GitUID: "git-uid"}
+	pr := &types.PullReq{ID: 7, Number: 1, CreatedBy: authorID, TargetRepoID: repo.ID}
+
+	c := &Controller{
+		tx:            txFake{},
+		authorizer:    &fake.Authorizer{},
+		pullreqStore:  &pullReqStoreFake{pullReqs: []*types.PullReq{pr}},
+		reviewStore:   &reviewStoreFake{},
+		reviewerStore: &reviewerStoreFake{},
+		repoStore:     fake.NewRepoStore(repo),
+		git:           &gitFake{branchSHA: commitSHA},
+		eventReporter: newEventReporter(t),
+	}
+
+	tests := []struct {
+		name        string
+		principalID int64
+		decision    enum.PullReqReviewDecision
+	}{
+		{name: "pending-decision", principalID: 2, decision: enum.PullReqReviewDecisionPending},
+		{name: "unknown-decision", principalID: 2, decision: "rejected"},
+		{name: "own-pull-request", principalID: authorID, decision: enum.PullReqReviewDecisionApproved},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session := &auth.Session{Principal: types.Principal{ID: test.principalID}}
+
+			_, err := c.ReviewSubmit(context.Background(), session, "space/repo", pr.Number,
+				&ReviewSubmitInput{CommitSHA: commitSHA.String(), Decision: test.decision})
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+				t.Errorf("Want bad request error, got %v", err)
+			}
+		})
+	}
+}
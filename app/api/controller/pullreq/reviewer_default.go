@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const defaultReviewersPageSize = 100
+
+// addDefaultReviewers adds the default reviewers configured for the target repository to a newly created
+// pull request. The pull request author and principals without access to the repository are skipped.
+func (c *Controller) addDefaultReviewers(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pr *types.PullReq,
+) error {
+	reviewerIDs, err := c.getDefaultReviewerIDs(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	addedByInfo := session.Principal.ToPrincipalInfo()
+
+	for _, reviewerID := range reviewerIDs {
+		if reviewerID == pr.CreatedBy {
+			continue
+		}
+
+		reviewerPrincipal, err := c.principalStore.Find(ctx, reviewerID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to find default reviewer principal %d", reviewerID)
+			continue
+		}
+
+		if err = apiauth.CheckRepoPrincipal(ctx, c.authorizer, reviewerPrincipal, repo,
+			enum.PermissionRepoView); err != nil {
+			log.Ctx(ctx).Info().Msgf("Default reviewer principal: %s access error: %s", reviewerPrincipal.UID, err)
+			continue
+		}
+
+		reviewer := newPullReqReviewer(session, pr, repo, reviewerPrincipal.ToPrincipalInfo(), addedByInfo,
+			enum.PullReqReviewerTypeRequested, &ReviewerAddInput{ReviewerID: reviewerID})
+
+		if err = c.reviewerStore.Create(ctx, reviewer); err != nil {
+			return fmt.Errorf("failed to create default reviewer: %w", err)
+		}
+
+		c.reportReviewerAddition(ctx, session, pr, reviewer)
+	}
+
+	return nil
+}
+
+// getDefaultReviewerIDs returns the deduplicated IDs of the repository's default reviewers:
+// the explicitly configured principals followed by the members of the parent space with the configured role.
+func (c *Controller) getDefaultReviewerIDs(ctx context.Context, repo *types.Repository) ([]int64, error) {
+	reviewerIDs := settings.DefaultDefaultReviewerIDs
+	reviewerRole := settings.DefaultDefaultReviewerRole
+	err := c.settings.RepoMap(ctx, repo.ID,
+		settings.Mapping(settings.KeyDefaultReviewerIDs, &reviewerIDs),
+		settings.Mapping(settings.KeyDefaultReviewerRole, &reviewerRole),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map default reviewer settings: %w", err)
+	}
+
+	ids := make([]int64, 0, len(reviewerIDs))
+	seen := make(map[int64]struct{}, len(reviewerIDs))
+	add := func(id int64) {
+		if _, ok := seen[id]; ok {
+			return
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	for _, id := range reviewerIDs {
+		add(id)
+	}
+
+	if reviewerRole == "" {
+		return ids, nil
+	}
+
+	for page := 1; ; page++ {
+		members, err := c.membershipStore.ListUsers(ctx, repo.ParentID, types.MembershipUserFilter{
+			ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: page, Size: defaultReviewersPageSize},
+			},
+			Sort:  enum.MembershipUserSortCreated,
+			Order: enum.OrderAsc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list space members: %w", err)
+		}
+
+		for _, member := range members {
+			if member.Role == reviewerRole {
+				add(member.PrincipalID)
+			}
+		}
+
+		if len(members) < defaultReviewersPageSize {
+			return ids, nil
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestCreate_DefaultReviewers(t *testing.T) {
+	const (
+		authorID = iota + 1
+		configuredID
+		noAccessID
+		contributorID
+		readerID
+	)
+
+	principals := []*types.Principal{
+		{ID: authorID, UID: "author", Type: enum.PrincipalTypeUser},
+		{ID: configuredID, UID: "configured", Type: enum.PrincipalTypeUser},
+		{ID: noAccessID, UID: "no-access", Type: enum.PrincipalTypeUser},
+		{ID: contributorID, UID: "contributor", Type: enum.PrincipalTypeUser},
+		{ID: readerID, UID: "reader", Type: enum.PrincipalTypeUser},
+	}
+
+	repo := &types.Repository{ID: 1, ParentID: 10, Identifier: "repo", Path: "space/repo", GitUID: "git-uid"}
+
+	member := func(principalID int64, role enum.MembershipRole) types.MembershipUser {
+		return types.MembershipUser{Membership: types.Membership{
+			MembershipKey: types.MembershipKey{SpaceID: repo.ParentID, PrincipalID: principalID},
+			Role:          role,
+		}}
+	}
+
+	settingsStore := &settingsStoreFake{values: map[string]json.RawMessage{
+		string(settings.KeyDefaultReviewerIDs):  json.RawMessage(`[1,2,3,2]`),
+		string(settings.KeyDefaultReviewerRole): json.RawMessage(`"contributor"`),
+	}}
+
+	reviewerStore := &reviewerStoreFake{}
+
+	c := &Controller{
+		authorizer:     &fake.Authorizer{Deny: denyPrincipals(noAccessID)},
+		pullreqStore:   &pullReqStoreFake{},
+		reviewerStore:  reviewerStore,
+		repoStore:      fake.NewRepoStore(repo),
+		principalStore: &principalStoreFake{principals: principals},
+		membershipStore: &membershipStoreFake{members: []types.MembershipUser{
+			member(authorID, enum.MembershipRoleContributor),
+			member(contributorID, enum.MembershipRoleContributor),
+			member(readerID, enum.MembershipRoleReader),
+		}},
+		git: &gitFake{
+			branchSHA:    sha.Must("1111111111111111111111111111111111111111"),
+			mergeBaseSHA: sha.Must("2222222222222222222222222222222222222222"),
+		},
+		eventReporter: newEventReporter(t),
+		sseStreamer:   sseStreamerFake{},
+		settings:      settings.NewService(settingsStore),
+	}
+
+	session := &auth.Session{Principal: *principals[0]}
+
+	pr, err := c.Create(context.Background(), session, "space/repo", &CreateInput{
+		Title:        "Feature",
+		SourceBranch: "feature",
+		TargetBranch: "main",
+	})
+	if err != nil {
+		t.Fatalf("failed to create pull request: %s", err)
+	}
+
+	var got []int64
+	for _, reviewer := range reviewerStore.reviewers {
+		if reviewer.PullReqID != pr.ID {
+			t.Errorf("Want reviewer for pull request %d, got %d", pr.ID, reviewer.PullReqID)
+		}
+		if reviewer.Type != enum.PullReqReviewerTypeRequested {
+			t.Errorf("Want reviewer type %q, got %q", enum.PullReqReviewerTypeRequested, reviewer.Type)
+		}
+		if reviewer.CreatedBy != authorID {
+			t.Errorf("Want reviewer added by %d, got %d", authorID, reviewer.CreatedBy)
+		}
+		got = append(got, reviewer.PrincipalID)
+	}
+
+	want := []int64{configuredID, contributorID}
+	if !slices.Equal(want, got) {
+		t.Errorf("Want reviewers %v, got %v", want, got)
+	}
+}
+
+func TestCreate_NoDefaultReviewers(t *testing.T) {
+	repo := &types.Repository{ID: 1, ParentID: 10, Identifier: "repo", Path: "space/repo", GitUID: "git-uid"}
+	author := &types.Principal{ID: 1, UID: "author", Type: enum.PrincipalTypeUser}
+
+	reviewerStore := &reviewerStoreFake{}
+
+	c := &Controller{
+		authorizer:      &fake.Authorizer{},
+		pullreqStore:    &pullReqStoreFake{},
+		reviewerStore:   reviewerStore,
+		repoStore:       fake.NewRepoStore(repo),
+		principalStore:  &principalStoreFake{principals: []*types.Principal{author}},
+		membershipStore: &membershipStoreFake{},
+		git: &gitFake{
+			branchSHA:    sha.Must("1111111111111111111111111111111111111111"),
+			mergeBaseSHA: sha.Must("2222222222222222222222222222222222222222"),
+		},
+		eventReporter: newEventReporter(t),
+		sseStreamer:   sseStreamerFake{},
+		settings:      settings.NewService(&settingsStoreFake{}),
+	}
+
+	_, err := c.Create(context.Background(), &auth.Session{Principal: *author}, "space/repo", &CreateInput{
+		Title:        "Feature",
+		SourceBranch: "feature",
+		TargetBranch: "main",
+	})
+	if err != nil {
+		t.Fatalf("failed to create pull request: %s", err)
+	}
+
+	if len(reviewerStore.reviewers) != 0 {
+		t.Errorf("Want no reviewers, got %d", len(reviewerStore.reviewers))
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// txFake runs the transaction function without an actual transaction.
+type txFake struct{}
+
+func (txFake) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+// denyPrincipals denies every permission to the provided principals.
+func denyPrincipals(ids ...int64) fake.DenyFunc {
+	return func(principal *types.Principal, _ *types.Scope, _ *types.Resource, _ enum.Permission) bool {
+		return slices.Contains(ids, principal.ID)
+	}
+}
+
+// viewOnly only permits the provided principals to view repositories.
+func viewOnly(ids ...int64) fake.DenyFunc {
+	return func(principal *types.Principal, _ *types.Scope, _ *types.Resource, permission enum.Permission) bool {
+		return slices.Contains(ids, principal.ID) && permission != enum.PermissionRepoView
+	}
+}
+
+// principalStoreFake is an in-memory principal store.
+type principalStoreFake struct {
+	store.PrincipalStore
+	principals []*types.Principal
+}
+
+func (f *principalStoreFake) Find(_ context.Context, id int64) (*types.Principal, error) {
+	for _, p := range f.principals {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// membershipStoreFake is an in-memory membership store.
+type membershipStoreFake struct {
+	store.MembershipStore
+	members []types.MembershipUser
+}
+
+func (f *membershipStoreFake) ListUsers(
+	_ context.Context,
+	spaceID int64,
+	_ types.MembershipUserFilter,
+) ([]types.MembershipUser, error) {
+	var res []types.MembershipUser
+	for _, m := range f.members {
+		if m.SpaceID == spaceID {
+			res = append(res, m)
+		}
+	}
+	return res, nil
+}
+
+// pullReqStoreFake is an in-memory pull request store.
+type pullReqStoreFake struct {
+	store.PullReqStore
+	pullReqs []*types.PullReq
+}
+
+func (f *pullReqStoreFake) List(context.Context, *types.PullReqFilter) ([]*types.PullReq, error) {
+	return nil, nil
+}
+
+func (f *pullReqStoreFake) FindByNumber(_ context.Context, repoID, number int64) (*types.PullReq, error) {
+	for _, pr := range f.pullReqs {
+		if pr.TargetRepoID == repoID && pr.Number == number {
+			return pr, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (f *pullReqStoreFake) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	pr.ActivitySeq++
+	return pr, nil
+}
+
+func (f *pullReqStoreFake) Update(context.Context, *types.PullReq) error {
+	return nil
+}
+
+func (f *pullReqStoreFake) Create(_ context.Context, pr *types.PullReq) error {
+	pr.ID = int64(len(f.pullReqs) + 1)
+	f.pullReqs = append(f.pullReqs, pr)
+	return nil
+}
+
+// reviewerStoreFake is an in-memory pull request reviewer store.
+type reviewerStoreFake struct {
+	store.PullReqReviewerStore
+	reviewers []*types.PullReqReviewer
+}
+
+func (f *reviewerStoreFake) Find(_ context.Context, prID, principalID int64) (*types.PullReqReviewer, error) {
+	for _, r := range f.reviewers {
+		if r.PullReqID == prID && r.PrincipalID == principalID {
+			return r, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (f *reviewerStoreFake) Create(_ context.Context, v *types.PullReqReviewer) error {
+	f.reviewers = append(f.reviewers, v)
+	return nil
+}
+
+func (f *reviewerStoreFake) Update(_ context.Context, v *types.PullReqReviewer) error {
+	for i, r := range f.reviewers {
+		if r.PullReqID == v.PullReqID && r.PrincipalID == v.PrincipalID {
+			f.reviewers[i] = v
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+// reviewStoreFake is an in-memory pull request review store.
+type reviewStoreFake struct {
+	store.PullReqReviewStore
+	reviews []*types.PullReqReview
+}
+
+func (f *reviewStoreFake) Create(_ context.Context, v *types.PullReqReview) error {
+	v.ID = int64(len(f.reviews) + 1)
+	f.reviews = append(f.reviews, v)
+	return nil
+}
+
+// activityStoreFake is an in-memory pull request activity store.
+type activityStoreFake struct {
+	store.PullReqActivityStore
+	activities []*types.PullReqActivity
+}
+
+func (f *activityStoreFake) Find(_ context.Context, id int64) (*types.PullReqActivity, error) {
+	for _, act := range f.activities {
+		if act.ID == id {
+			return act, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (f *activityStoreFake) Create(_ context.Context, act *types.PullReqActivity) error {
+	act.ID = int64(len(f.activities) + 1)
+	f.activities = append(f.activities, act)
+	return nil
+}
+
+func (f *activityStoreFake) Update(context.Context, *types.PullReqActivity) error {
+	return nil
+}
+
+func (f *activityStoreFake) UpdateOptLock(
+	_ context.Context,
+	act *types.PullReqActivity,
+	mutateFn func(act *types.PullReqActivity) error,
+) (*types.PullReqActivity, error) {
+	if err := mutateFn(act); err != nil {
+		return nil, err
+	}
+	return act, nil
+}
+
+// List returns the activities matching the filter's kinds ordered by order and sub-order.
+func (f *activityStoreFake) List(
+	_ context.Context,
+	prID int64,
+	opts *types.PullReqActivityFilter,
+) ([]*types.PullReqActivity, error) {
+	var res []*types.PullReqActivity
+	for _, act := range f.activities {
+		if act.PullReqID != prID || len(opts.Kinds) > 0 && !slices.Contains(opts.Kinds, act.Kind) {
+			continue
+		}
+		res = append(res, act)
+	}
+	slices.SortStableFunc(res, func(a, b *types.PullReqActivity) bool {
+		return a.Order < b.Order || a.Order == b.Order && a.SubOrder < b.SubOrder
+	})
+	return res, nil
+}
+
+func (f *activityStoreFake) CreateWithPayload(
+	_ context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+) (*types.PullReqActivity, error) {
+	act := &types.PullReqActivity{
+		ID:        int64(len(f.activities) + 1),
+		PullReqID: pr.ID,
+		CreatedBy: principalID,
+		Type:      payload.ActivityType(),
+	}
+	f.activities = append(f.activities, act)
+	return act, nil
+}
+
+// settingsStoreFake is an in-memory settings store.
+type settingsStoreFake struct {
+	store.SettingsStore
+	values map[string]json.RawMessage
+}
+
+func (f *settingsStoreFake) FindMany(
+	_ context.Context,
+	_ enum.SettingsScope,
+	_ int64,
+	keys ...string,
+) (map[string]json.RawMessage, error) {
+	res := make(map[string]json.RawMessage)
+	for _, key := range keys {
+		if v, ok := f.values[key]; ok {
+			res[key] = v
+		}
+	}
+	return res, nil
+}
+
+// gitFake resolves every branch to the same commit and returns a fixed merge base.
+type gitFake struct {
+	git.Interface
+	branchSHA    sha.SHA
+	mergeBaseSHA sha.SHA
+}
+
+func (f *gitFake) GetRef(context.Context, git.GetRefParams) (git.GetRefResponse, error) {
+	return git.GetRefResponse{SHA: f.branchSHA}, nil
+}
+
+func (f *gitFake) GetCommit(context.Context, *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	return &git.GetCommitOutput{Commit: git.Commit{SHA: f.branchSHA}}, nil
+}
+
+func (f *gitFake) DiffCut(_ context.Context, params *git.DiffCutParams) (git.DiffCutOutput, error) {
+	return git.DiffCutOutput{
+		Header: git.HunkHeader{
+			OldLine: params.LineStart,
+			OldSpan: params.LineEnd - params.LineStart + 1,
+			NewLine: params.LineStart,
+			NewSpan: params.LineEnd - params.LineStart + 1,
+		},
+		MergeBaseSHA: f.mergeBaseSHA,
+	}, nil
+}
+
+func (f *gitFake) MergeBase(context.Context, git.MergeBaseParams) (git.MergeBaseOutput, error) {
+	return git.MergeBaseOutput{MergeBaseSHA: f.mergeBaseSHA}, nil
+}
+
+// newEventReporter returns a pull request event reporter backed by an in-memory event system.
+func newEventReporter(t *testing.T) *pullreqevents.Reporter {
+	t.Helper()
+
+	eventsSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		Namespace:       "test",
+		MaxStreamLength: 100,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to create events system: %s", err)
+	}
+
+	eventReporter, err := pullreqevents.NewReporter(eventsSystem)
+	if err != nil {
+		t.Fatalf("failed to create event reporter: %s", err)
+	}
+
+	return eventReporter
+}
+
+// sseStreamerFake discards all events.
+type sseStreamerFake struct {
+	sse.Streamer
+}
+
+func (sseStreamerFake) Publish(context.Context, int64, enum.SSEType, any) error {
+	return nil
+}
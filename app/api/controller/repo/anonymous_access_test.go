@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func setupPublicRepo() (*Controller, *servicePackGitFake) {
+	gitFake := &servicePackGitFake{}
+	ctrl := &Controller{
+		authorizer: &fake.Authorizer{},
+		repoStore: fake.NewRepoStore(&types.Repository{
+			ID:       1,
+			Path:     "space/repo",
+			GitUID:   "repo-uid",
+			IsPublic: true,
+		}),
+		git: gitFake,
+	}
+
+	return ctrl, gitFake
+}
+
+func TestGitServicePack_AnonymousCloneEnabled(t *testing.T) {
+	ctrl, gitFake := setupPublicRepo()
+
+	err := ctrl.GitServicePack(context.Background(), nil, "space/repo",
+		enum.GitServiceTypeUploadPack, "", strings.NewReader(""), &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if len(gitFake.services) != 1 || gitFake.services[0] != string(enum.GitServiceTypeUploadPack) {
+		t.Errorf("Want service pack call %q, got %v", enum.GitServiceTypeUploadPack, gitFake.services)
+	}
+}
+
+func TestGitServicePack_AnonymousCloneDisabled(t *testing.T) {
+	ctrl, gitFake := setupPublicRepo()
+	ctx := apiauth.WithAnonymousAccessDisabled(context.Background())
+
+	// the git handler renders ErrNotAuthenticated as 401 with a basic auth challenge.
+	err := ctrl.GitServicePack(ctx, nil, "space/repo",
+		enum.GitServiceTypeUploadPack, "", strings.NewReader(""), &bytes.Buffer{})
+	if !errors.Is(err, apiauth.ErrNotAuthenticated) {
+		t.Fatalf("Want error %v, got %v", apiauth.ErrNotAuthenticated, err)
+	}
+
+	if len(gitFake.services) != 0 {
+		t.Errorf("Want no service pack call, got %v", gitFake.services)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Archive marks a repository as archived, blocking any further writes without deleting it.
+func (c *Controller) Archive(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.Repository, error) {
+	return c.setArchived(ctx, session, repoRef, true)
+}
+
+// Unarchive reverts the archiving of a repository, allowing writes again.
+func (c *Controller) Unarchive(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.Repository, error) {
+	return c.setArchived(ctx, session, repoRef, false)
+}
+
+func (c *Controller) setArchived(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	archived bool,
+) (*types.Repository, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.Archived == archived {
+		controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
+		return repo, nil
+	}
+
+	repoClone := repo.Clone()
+
+	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		repo.Archived = archived
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update repository archived state: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionUpdated,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(repoClone),
+		audit.WithNewObject(repo),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for repository archive operation: %s", err)
+	}
+
+	// backfill repo url
+	controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
+
+	return repo, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type servicePackGitFake struct {
+	git.Interface
+	services []string
+}
+
+func (f *servicePackGitFake) ServicePack(_ context.Context, _ io.Writer, params *git.ServicePackParams) error {
+	f.services = append(f.services, params.Service)
+	return nil
+}
+
+func setupArchivedRepo() (*Controller, *servicePackGitFake) {
+	gitFake := &servicePackGitFake{}
+	ctrl := &Controller{
+		authorizer: &fake.Authorizer{},
+		repoStore: fake.NewRepoStore(&types.Repository{
+			ID:       1,
+			Path:     "space/repo",
+			GitUID:   "repo-uid",
+			IsPublic: true,
+			Archived: true,
+		}),
+		git: gitFake,
+	}
+
+	return ctrl, gitFake
+}
+
+func TestGitServicePack_ArchivedRejectsPush(t *testing.T) {
+	ctrl, gitFake := setupArchivedRepo()
+
+	err := ctrl.GitServicePack(context.Background(), &auth.Session{}, "space/repo",
+		enum.GitServiceTypeReceivePack, "", strings.NewReader(""), &bytes.Buffer{})
+	if !errors.Is(err, usererror.ErrRepositoryArchived) {
+		t.Fatalf("Want error %v, got %v", usererror.ErrRepositoryArchived, err)
+	}
+
+	if len(gitFake.services) != 0 {
+		t.Errorf("Want no service pack call, got %v", gitFake.services)
+	}
+}
+
+func TestGitServicePack_ArchivedAllowsRead(t *testing.T) {
+	ctrl, gitFake := setupArchivedRepo()
+
+	err := ctrl.GitServicePack(context.Background(), &auth.Session{}, "space/repo",
+		enum.GitServiceTypeUploadPack, "", strings.NewReader(""), &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if len(gitFake.services) != 1 || gitFake.services[0] != string(enum.GitServiceTypeUploadPack) {
+		t.Errorf("Want service pack call %q, got %v", enum.GitServiceTypeUploadPack, gitFake.services)
+	}
+}
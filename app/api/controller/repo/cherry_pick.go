@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CherryPickInput holds the data for cherry-picking a commit onto a branch.
+type CherryPickInput struct {
+	Branch string `json:"branch"`
+
+	BypassRules bool `json:"bypass_rules"`
+}
+
+// CherryPickOutput holds the result of a successful cherry-pick.
+type CherryPickOutput struct {
+	CommitID       string                 `json:"commit_id"`
+	RuleViolations []types.RuleViolations `json:"rule_violations,omitempty"`
+}
+
+// CherryPick applies the changes of the provided commit on top of a branch, creating a new commit.
+func (c *Controller) CherryPick(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *CherryPickInput,
+) (*CherryPickOutput, []types.RuleViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if in.Branch == "" {
+		return nil, nil, usererror.BadRequest("Branch is required.")
+	}
+
+	commit, err := sha.New(commitSHA)
+	if err != nil {
+		return nil, nil, usererror.BadRequest("Invalid commit SHA provided.")
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   protection.RefActionUpdate,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{in.Branch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	now := time.Now()
+	out, err := c.git.CherryPick(ctx, &git.CherryPickParams{
+		WriteParams:   writeParams,
+		CommitSHA:     commit,
+		Branch:        in.Branch,
+		Committer:     identityFromPrincipal(session.Principal),
+		CommitterDate: &now,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(out.ConflictFiles) > 0 {
+		return nil, nil, usererror.ConflictWithPayload(
+			"The commit can't be cherry-picked cleanly onto the branch.",
+			map[string]any{"conflict_files": out.ConflictFiles},
+		)
+	}
+
+	return &CherryPickOutput{
+		CommitID:       out.CommitSHA.String(),
+		RuleViolations: violations,
+	}, nil, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	cherryPickCommitSHA = "6666666666666666666666666666666666666666"
+	cherryPickNewSHA    = "7777777777777777777777777777777777777777"
+)
+
+var cherryPickSession = &auth.Session{
+	Principal: types.Principal{ID: 42, DisplayName: "Release Manager", Email: "release@gitness.io"},
+}
+
+func TestCherryPick(t *testing.T) {
+	c, g := setupDeleteBranchController(t, nil)
+	g.cherryPickOutput = git.CherryPickOutput{CommitSHA: sha.Must(cherryPickNewSHA)}
+
+	out, violations, err := c.CherryPick(context.Background(), cherryPickSession, "space/repo",
+		cherryPickCommitSHA, &CherryPickInput{Branch: "release"})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Want no violations, got %v", violations)
+	}
+	if out.CommitID != cherryPickNewSHA {
+		t.Errorf("Want commit %s, got %s", cherryPickNewSHA, out.CommitID)
+	}
+
+	if len(g.cherryPicks) != 1 {
+		t.Fatalf("Want one cherry-pick, got %d", len(g.cherryPicks))
+	}
+	params := g.cherryPicks[0]
+	if params.CommitSHA.String() != cherryPickCommitSHA || params.Branch != "release" {
+		t.Errorf("Want commit %s on branch release, got %s on %s", cherryPickCommitSHA, params.CommitSHA, params.Branch)
+	}
+	if params.Committer == nil || params.Committer.Email != "release@gitness.io" {
+		t.Errorf("Want actor as committer, got %+v", params.Committer)
+	}
+}
+
+func TestCherryPick_Conflict(t *testing.T) {
+	c, g := setupDeleteBranchController(t, nil)
+	g.cherryPickOutput = git.CherryPickOutput{ConflictFiles: []string{"a.txt", "b.txt"}}
+
+	_, _, err := c.CherryPick(context.Background(), cherryPickSession, "space/repo",
+		cherryPickCommitSHA, &CherryPickInput{Branch: "release"})
+
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) {
+		t.Fatalf("Want user error, got %v", err)
+	}
+	if uErr.Status != http.StatusConflict {
+		t.Errorf("Want status %d, got %d", http.StatusConflict, uErr.Status)
+	}
+	files, ok := uErr.Values["conflict_files"].([]string)
+	if !ok || len(files) != 2 || files[0] != "a.txt" || files[1] != "b.txt" {
+		t.Errorf("Want conflict files [a.txt b.txt], got %v", uErr.Values["conflict_files"])
+	}
+}
+
+func TestCherryPick_InvalidInput(t *testing.T) {
+	c, g := setupDeleteBranchController(t, nil)
+
+	for _, tt := range []struct {
+		name      string
+		commitSHA string
+		branch    string
+	}{
+		{name: "missing branch", commitSHA: cherryPickCommitSHA, branch: ""},
+		{name: "invalid sha", commitSHA: "not-a-sha", branch: "release"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := c.CherryPick(context.Background(), cherryPickSession, "space/repo",
+				tt.commitSHA, &CherryPickInput{Branch: tt.branch})
+
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+				t.Errorf("Want bad request, got %v", err)
+			}
+		})
+	}
+
+	if len(g.cherryPicks) != 0 {
+		t.Errorf("Want no cherry-pick, got %d", len(g.cherryPicks))
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var errLastAdminCollaborator = usererror.BadRequest(
+	"The last admin collaborator of a repository without space owners can't be removed or downgraded.")
+
+type CollaboratorAddInput struct {
+	UserUID string              `json:"user_uid"`
+	Role    enum.MembershipRole `json:"role"`
+}
+
+func (in *CollaboratorAddInput) Validate() error {
+	if in.UserUID == "" {
+		return usererror.BadRequest("UserUID must be provided")
+	}
+
+	role, err := sanitizeCollaboratorRole(in.Role)
+	if err != nil {
+		return err
+	}
+
+	in.Role = role
+
+	return nil
+}
+
+type CollaboratorUpdateInput struct {
+	Role enum.MembershipRole `json:"role"`
+}
+
+func (in *CollaboratorUpdateInput) Validate() error {
+	role, err := sanitizeCollaboratorRole(in.Role)
+	if err != nil {
+		return err
+	}
+
+	in.Role = role
+
+	return nil
+}
+
+func sanitizeCollaboratorRole(role enum.MembershipRole) (enum.MembershipRole, error) {
+	if role == "" {
+		return "", usererror.BadRequest("Role must be provided")
+	}
+
+	sanitized, ok := role.Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Provided role '%s' is not suppored. Valid values are: %v",
+			role, enum.MembershipRoles)
+	}
+
+	return sanitized, nil
+}
+
+// CollaboratorAdd grants a user a role on the repository.
+func (c *Controller) CollaboratorAdd(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CollaboratorAddInput,
+) (*types.RepoCollaboratorUser, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, in.UserUID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("User '%s' not found", in.UserUID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the user: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+
+	collaborator := types.RepoCollaborator{
+		RepoCollaboratorKey: types.RepoCollaboratorKey{
+			RepoID:      repo.ID,
+			PrincipalID: user.ID,
+		},
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+		Role:      in.Role,
+	}
+
+	err = c.repoCollaboratorStore.Create(ctx, &collaborator)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict(
+			fmt.Sprintf("User '%s' is already a collaborator of the repository", in.UserUID))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository collaborator: %w", err)
+	}
+
+	return &types.RepoCollaboratorUser{
+		RepoCollaborator: collaborator,
+		Principal:        *user.ToPrincipalInfo(),
+		AddedBy:          *session.Principal.ToPrincipalInfo(),
+	}, nil
+}
+
+// CollaboratorList lists all collaborators of the repository.
+func (c *Controller) CollaboratorList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]types.RepoCollaboratorUser, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	collaborators, err := c.repoCollaboratorStore.ListUsers(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository collaborators: %w", err)
+	}
+
+	return collaborators, nil
+}
+
+// CollaboratorUpdate changes the role of an existing repository collaborator.
+func (c *Controller) CollaboratorUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	userUID string,
+	in *CollaboratorUpdateInput,
+) (*types.RepoCollaboratorUser, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user by uid: %w", err)
+	}
+
+	var collaborator *types.RepoCollaboratorUser
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		collaborator, err = c.repoCollaboratorStore.FindUser(ctx, types.RepoCollaboratorKey{
+			RepoID:      repo.ID,
+			PrincipalID: user.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find repository collaborator for update: %w", err)
+		}
+
+		if collaborator.Role == in.Role {
+			return nil
+		}
+
+		if collaborator.Role == enum.MembershipRoleSpaceOwner {
+			if err = c.checkNotLastAdmin(ctx, repo); err != nil {
+				return err
+			}
+		}
+
+		collaborator.Role = in.Role
+
+		if err = c.repoCollaboratorStore.Update(ctx, &collaborator.RepoCollaborator); err != nil {
+			return fmt.Errorf("failed to update repository collaborator: %w", err)
+		}
+
+		return nil
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+
+	return collaborator, nil
+}
+
+// CollaboratorRemove removes a collaborator from the repository.
+func (c *Controller) CollaboratorRemove(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	userUID string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.principalStore.FindUserByUID(ctx, userUID)
+	if err != nil {
+		return fmt.Errorf("failed to find user by uid: %w", err)
+	}
+
+	key := types.RepoCollaboratorKey{
+		RepoID:      repo.ID,
+		PrincipalID: user.ID,
+	}
+
+	return c.tx.WithTx(ctx, func(ctx context.Context) error {
+		collaborator, err := c.repoCollaboratorStore.Find(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to find repository collaborator: %w", err)
+		}
+
+		if collaborator.Role == enum.MembershipRoleSpaceOwner {
+			if err = c.checkNotLastAdmin(ctx, repo); err != nil {
+				return err
+			}
+		}
+
+		if err = c.repoCollaboratorStore.Delete(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete repository collaborator: %w", err)
+		}
+
+		return nil
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+}
+
+// checkNotLastAdmin returns an error if the repository would be left without any admin,
+// i.e. if it has a single admin collaborator and none of its ancestor spaces has a space owner.
+// NOTE: Has to run in a serializable transaction, otherwise concurrent removals of the
+// remaining admins could all pass the check.
+func (c *Controller) checkNotLastAdmin(ctx context.Context, repo *types.Repository) error {
+	count, err := c.repoCollaboratorStore.CountByRole(ctx, repo.ID, enum.MembershipRoleSpaceOwner)
+	if err != nil {
+		return fmt.Errorf("failed to count admin collaborators: %w", err)
+	}
+
+	if count > 1 {
+		return nil
+	}
+
+	filter := types.MembershipUserFilter{Role: enum.MembershipRoleSpaceOwner}
+
+	for spaceID := repo.ParentID; spaceID != 0; {
+		owners, err := c.membershipStore.CountUsers(ctx, spaceID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count space owners of space %d: %w", spaceID, err)
+		}
+
+		if owners > 0 {
+			return nil
+		}
+
+		space, err := c.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+
+		spaceID = space.ParentID
+	}
+
+	return errLastAdminCollaborator
+}
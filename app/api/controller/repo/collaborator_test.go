@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func setupCollaboratorController(
+	memberships []types.Membership,
+	collaborators ...types.RepoCollaborator,
+) (*Controller, *repoCollaboratorStoreFake, *auth.Session) {
+	collaboratorStore := &repoCollaboratorStoreFake{collaborators: collaborators}
+	ctrl := &Controller{
+		tx:         txFake{},
+		authorizer: &fake.Authorizer{},
+		repoStore: fake.NewRepoStore(&types.Repository{
+			ID:         1,
+			ParentID:   1,
+			Identifier: "repo",
+			Path:       "space/repo",
+		}),
+		spaceStore: &spaceStoreFake{space: &types.Space{ID: 1, Identifier: "space", Path: "space"}},
+		principalStore: &principalStoreFake{users: []*types.User{
+			{ID: 1, UID: "alice"},
+			{ID: 2, UID: "bob"},
+		}},
+		membershipStore:       &membershipStoreFake{memberships: memberships},
+		repoCollaboratorStore: collaboratorStore,
+	}
+
+	return ctrl, collaboratorStore, &auth.Session{Principal: types.Principal{ID: 5}}
+}
+
+// txOptionsRecorder runs the transaction functions without an actual transaction
+// and records the options of the transactions.
+type txOptionsRecorder struct {
+	opts []interface{}
+}
+
+func (r *txOptionsRecorder) WithTx(ctx context.Context, txFn func(ctx context.Context) error,
+	opts ...interface{},
+) error {
+	r.opts = append(r.opts, opts...)
+	return txFn(ctx)
+}
+
+func collaborator(principalID int64, role enum.MembershipRole) types.RepoCollaborator {
+	return types.RepoCollaborator{
+		RepoCollaboratorKey: types.RepoCollaboratorKey{RepoID: 1, PrincipalID: principalID},
+		Role:                role,
+	}
+}
+
+func TestCollaboratorRemove_LastAdmin(t *testing.T) {
+	spaceOwner := types.Membership{
+		MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 3},
+		Role:          enum.MembershipRoleSpaceOwner,
+	}
+	spaceReader := types.Membership{
+		MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 4},
+		Role:          enum.MembershipRoleReader,
+	}
+
+	tests := []struct {
+		name          string
+		memberships   []types.Membership
+		collaborators []types.RepoCollaborator
+		wantErr       error
+	}{
+		{
+			name:          "last admin without space owner",
+			memberships:   []types.Membership{spaceReader},
+			collaborators: []types.RepoCollaborator{collaborator(1, enum.MembershipRoleSpaceOwner)},
+			wantErr:       errLastAdminCollaborator,
+		},
+		{
+			name:          "last admin with space owner",
+			memberships:   []types.Membership{spaceOwner},
+			collaborators: []types.RepoCollaborator{collaborator(1, enum.MembershipRoleSpaceOwner)},
+		},
+		{
+			name: "another admin collaborator",
+			collaborators: []types.RepoCollaborator{
+				collaborator(1, enum.MembershipRoleSpaceOwner),
+				collaborator(2, enum.MembershipRoleSpaceOwner),
+			},
+		},
+		{
+			name: "non-admin collaborator",
+			collaborators: []types.RepoCollaborator{
+				collaborator(1, enum.MembershipRoleContributor),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl, collaboratorStore, session := setupCollaboratorController(test.memberships, test.collaborators...)
+
+			err := ctrl.CollaboratorRemove(context.Background(), session, "space/repo", "alice")
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Want error %v, got %v", test.wantErr, err)
+			}
+
+			wantCount := len(test.collaborators) - 1
+			if test.wantErr != nil {
+				wantCount = len(test.collaborators)
+			}
+			if got := len(collaboratorStore.collaborators); got != wantCount {
+				t.Errorf("Want %d collaborators, got %d", wantCount, got)
+			}
+		})
+	}
+}
+
+func TestCollaboratorUpdate_LastAdmin(t *testing.T) {
+	ctrl, collaboratorStore, session := setupCollaboratorController(nil,
+		collaborator(1, enum.MembershipRoleSpaceOwner))
+
+	_, err := ctrl.CollaboratorUpdate(context.Background(), session, "space/repo", "alice",
+		&CollaboratorUpdateInput{Role: enum.MembershipRoleContributor})
+	if !errors.Is(err, errLastAdminCollaborator) {
+		t.Fatalf("Want error %v, got %v", errLastAdminCollaborator, err)
+	}
+	if got := collaboratorStore.collaborators[0].Role; got != enum.MembershipRoleSpaceOwner {
+		t.Errorf("Want role '%s', got '%s'", enum.MembershipRoleSpaceOwner, got)
+	}
+
+	collaboratorStore.collaborators = append(collaboratorStore.collaborators,
+		collaborator(2, enum.MembershipRoleSpaceOwner))
+
+	updated, err := ctrl.CollaboratorUpdate(context.Background(), session, "space/repo", "alice",
+		&CollaboratorUpdateInput{Role: enum.MembershipRoleContributor})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if updated.Role != enum.MembershipRoleContributor {
+		t.Errorf("Want role '%s', got '%s'", enum.MembershipRoleContributor, updated.Role)
+	}
+}
+
+func TestCollaboratorChanges_Serializable(t *testing.T) {
+	ctrl, _, session := setupCollaboratorController(nil,
+		collaborator(1, enum.MembershipRoleSpaceOwner),
+		collaborator(2, enum.MembershipRoleSpaceOwner))
+	tx := &txOptionsRecorder{}
+	ctrl.tx = tx
+
+	_, err := ctrl.CollaboratorUpdate(context.Background(), session, "space/repo", "alice",
+		&CollaboratorUpdateInput{Role: enum.MembershipRoleContributor})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	err = ctrl.CollaboratorRemove(context.Background(), session, "space/repo", "bob")
+	if !errors.Is(err, errLastAdminCollaborator) {
+		t.Fatalf("Want error %v, got %v", errLastAdminCollaborator, err)
+	}
+
+	want := sql.TxOptions{Isolation: sql.LevelSerializable}
+	if len(tx.opts) != 2 {
+		t.Fatalf("Want 2 transactions with options, got %d", len(tx.opts))
+	}
+	for i, opts := range tx.opts {
+		if opts != want {
+			t.Errorf("Want transaction %d to run with %+v, got %+v", i, want, opts)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CommitChanges contains the changed files of a commit compared to one of its parents.
+type CommitChanges struct {
+	SHA        string   `json:"sha"`
+	ParentSHAs []string `json:"parent_shas"`
+	// BaseSHA is the parent the commit got diffed against, empty in case of a root commit.
+	BaseSHA   string         `json:"base_sha,omitempty"`
+	Additions int64          `json:"additions"`
+	Deletions int64          `json:"deletions"`
+	Files     []git.FileDiff `json:"files"`
+}
+
+// CommitChanges returns the patch and stats of the files changed by a commit.
+// Merge commits are diffed against their first parent, unless a different parent is requested.
+func (c *Controller) CommitChanges(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	parent int,
+) (*CommitChanges, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := c.git.CommitChanges(ctx, &git.CommitChangesParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   commitSHA,
+		Parent:     parent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit changes: %w", err)
+	}
+
+	changes := &CommitChanges{
+		SHA:        output.SHA.String(),
+		ParentSHAs: make([]string, len(output.ParentSHAs)),
+		Files:      output.Files,
+	}
+	for i := range output.ParentSHAs {
+		changes.ParentSHAs[i] = output.ParentSHAs[i].String()
+	}
+	if !output.BaseSHA.IsEmpty() {
+		changes.BaseSHA = output.BaseSHA.String()
+	}
+	for _, file := range output.Files {
+		changes.Additions += file.Additions
+		changes.Deletions += file.Deletions
+	}
+
+	return changes, nil
+}
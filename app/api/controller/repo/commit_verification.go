@@ -0,0 +1,289 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sshSignatureHeader  = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureFooter  = "-----END SSH SIGNATURE-----"
+	sshSignatureMagic   = "SSHSIG"
+	sshSignatureVersion = 1
+
+	// sshSignatureNamespace is the namespace git uses when signing commits and tags with ssh keys.
+	sshSignatureNamespace = "git"
+)
+
+// sshSignature is the binary representation of an ssh signature (without the magic preamble),
+// as documented in https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig.
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the data signed by an ssh signature.
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// fillCommitVerifications verifies the signatures of the commits against the public keys registered by the users.
+// Only ssh signatures can be verified, as users can't register gpg keys.
+// A commit is only verified if the owner of the signing key also owns the committer email
+// (either as primary email or as verified secondary email).
+func (c *Controller) fillCommitVerifications(
+	ctx context.Context,
+	repo *types.Repository,
+	commits []types.Commit,
+) error {
+	if len(commits) == 0 {
+		return nil
+	}
+
+	commitSHAs := make([]sha.SHA, len(commits))
+	for i := range commits {
+		commitSHA, err := sha.New(commits[i].SHA)
+		if err != nil {
+			return fmt.Errorf("failed to parse commit sha: %w", err)
+		}
+		commitSHAs[i] = commitSHA
+	}
+
+	out, err := c.git.GetCommitSignatures(ctx, &git.GetCommitSignaturesParams{
+		ReadParams: git.CreateReadParams(repo),
+		CommitSHAs: commitSHAs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get commit signatures: %w", err)
+	}
+
+	signatures := make(map[string]git.CommitSignature, len(out.Signatures))
+	for _, signature := range out.Signatures {
+		signatures[signature.CommitSHA.String()] = signature
+	}
+
+	signerIDs := make(map[string]int64)
+	committerEmails := make([]string, 0, len(commits))
+	for i := range commits {
+		signature, ok := signatures[commits[i].SHA]
+		if !ok {
+			commits[i].Verification = &types.CommitVerification{
+				Reason: enum.CommitVerificationReasonUnsigned,
+			}
+			continue
+		}
+
+		verification, signerID, err := c.verifyCommitSignature(ctx, signature)
+		if err != nil {
+			return err
+		}
+
+		commits[i].Verification = verification
+		if verification.Verified {
+			signerIDs[commits[i].SHA] = signerID
+			committerEmails = append(committerEmails, commits[i].Committer.Identity.Email)
+		}
+	}
+
+	if len(signerIDs) == 0 {
+		return nil
+	}
+
+	committerIDs, err := c.userEmailStore.MapPrincipalIDs(ctx, committerEmails)
+	if err != nil {
+		return fmt.Errorf("failed to map commit committer emails to users: %w", err)
+	}
+
+	for i := range commits {
+		signerID, ok := signerIDs[commits[i].SHA]
+		if !ok {
+			continue
+		}
+
+		committerID, ok := committerIDs[strings.ToLower(commits[i].Committer.Identity.Email)]
+		if !ok || committerID != signerID {
+			commits[i].Verification.Verified = false
+			commits[i].Verification.Reason = enum.CommitVerificationReasonEmailMismatch
+			delete(signerIDs, commits[i].SHA)
+		}
+	}
+
+	if len(signerIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(signerIDs))
+	for _, id := range signerIDs {
+		ids = append(ids, id)
+	}
+
+	signers, err := c.principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to fetch commit signer info from cache: %w", err)
+	}
+
+	for i := range commits {
+		if id, ok := signerIDs[commits[i].SHA]; ok {
+			commits[i].Verification.Signer = signers[id]
+		}
+	}
+
+	return nil
+}
+
+// verifyCommitSignature verifies a single commit signature and returns the ID of the principal
+// owning the signing key in case the signature is valid.
+// It doesn't check whether the principal owns the committer email, that's up to the caller.
+func (c *Controller) verifyCommitSignature(
+	ctx context.Context,
+	signature git.CommitSignature,
+) (*types.CommitVerification, int64, error) {
+	verification := &types.CommitVerification{
+		Signed: true,
+		Reason: enum.CommitVerificationReasonUnknownKey,
+	}
+
+	sig, publicKey, ok := parseSSHSigningKey(signature.Signature)
+	if !ok {
+		// gpg signatures and malformed ssh signatures can't be attributed to a registered key.
+		return verification, 0, nil
+	}
+
+	key, err := c.publicKeyStore.FindByFingerprint(ctx, ssh.FingerprintSHA256(publicKey))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return verification, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find public key by fingerprint: %w", err)
+	}
+
+	if valid := verifySSHSignature(publicKey, sig, []byte(signature.Payload)) == nil; !valid {
+		verification.Reason = enum.CommitVerificationReasonInvalid
+		return verification, 0, nil
+	}
+
+	verification.Verified = true
+	verification.Reason = enum.CommitVerificationReasonValid
+
+	return verification, key.PrincipalID, nil
+}
+
+// parseSSHSigningKey parses an armored ssh signature together with the public key it was created with.
+// It returns false in case the signature isn't a valid ssh signature.
+func parseSSHSigningKey(armored string) (*sshSignature, ssh.PublicKey, bool) {
+	sig, err := parseSSHSignature(armored)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	publicKey, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return sig, publicKey, true
+}
+
+// parseSSHSignature parses an armored ssh signature.
+func parseSSHSignature(armored string) (*sshSignature, error) {
+	armored = strings.TrimSpace(armored)
+
+	body, ok := strings.CutPrefix(armored, sshSignatureHeader)
+	if !ok {
+		return nil, errors.New("not an ssh signature")
+	}
+
+	body, ok = strings.CutSuffix(body, sshSignatureFooter)
+	if !ok {
+		return nil, errors.New("ssh signature footer is missing")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ssh signature: %w", err)
+	}
+
+	data, ok = bytes.CutPrefix(data, []byte(sshSignatureMagic))
+	if !ok {
+		return nil, errors.New("ssh signature magic preamble is missing")
+	}
+
+	sig := &sshSignature{}
+	if err = ssh.Unmarshal(data, sig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ssh signature: %w", err)
+	}
+
+	if sig.Version != sshSignatureVersion {
+		return nil, fmt.Errorf("unsupported ssh signature version %d", sig.Version)
+	}
+
+	return sig, nil
+}
+
+// verifySSHSignature verifies that the ssh signature has been created by the public key for the provided message.
+func verifySSHSignature(publicKey ssh.PublicKey, sig *sshSignature, message []byte) error {
+	if sig.Namespace != sshSignatureNamespace {
+		return fmt.Errorf("unexpected ssh signature namespace %q", sig.Namespace)
+	}
+
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported ssh signature hash algorithm %q", sig.HashAlgorithm)
+	}
+	h.Write(message)
+
+	signature := &ssh.Signature{}
+	if err := ssh.Unmarshal(sig.Signature, signature); err != nil {
+		return fmt.Errorf("failed to unmarshal ssh signature blob: %w", err)
+	}
+
+	signedData := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+
+	return publicKey.Verify(signedData, signature)
+}
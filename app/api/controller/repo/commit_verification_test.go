@@ -0,0 +1,263 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const signedCommitPayload = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+	"author alice <alice@example.com> 1700000000 +0000\n" +
+	"committer alice <alice@example.com> 1700000000 +0000\n\nsigned commit\n"
+
+func setupCommitVerificationController(
+	committerEmail string,
+	keys []*types.PublicKey,
+	signatures ...git.CommitSignature,
+) (*Controller, *auth.Session) {
+	signatureMap := make(map[string]git.CommitSignature, len(signatures))
+	for _, signature := range signatures {
+		signatureMap[signature.CommitSHA.String()] = signature
+	}
+
+	ctrl := &Controller{
+		authorizer: &fake.Authorizer{},
+		repoStore: fake.NewRepoStore(&types.Repository{
+			ID:         1,
+			Identifier: "repo",
+			Path:       "space/repo",
+		}),
+		git: &gitFake{
+			branch:     "main",
+			signatures: signatureMap,
+			committer:  git.Identity{Name: "alice", Email: committerEmail},
+		},
+		publicKeyStore: &publicKeyStoreFake{keys: keys},
+		userEmailStore: &userEmailStoreFake{principalIDs: map[string]int64{
+			"alice@example.com":      7,
+			"alice@users.gitness.io": 7,
+			"bob@example.com":        8,
+		}},
+		principalInfoCache: &principalInfoCacheFake{infos: map[int64]*types.PrincipalInfo{
+			7: {ID: 7, UID: "alice"},
+		}},
+	}
+
+	return ctrl, &auth.Session{Principal: types.Principal{ID: 5}}
+}
+
+// signSSH creates an armored ssh signature of the message in the namespace git uses for commits.
+func signSSH(t *testing.T, signer ssh.Signer, message string) string {
+	t.Helper()
+
+	h := sha512.Sum512([]byte(message))
+	signedData := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: "sha512",
+		Hash:          h[:],
+	})...)
+
+	signature, err := signer.Sign(rand.Reader, signedData)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	blob := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignature{
+		Version:       sshSignatureVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(signature),
+	})...)
+
+	return sshSignatureHeader + "\n" + base64.StdEncoding.EncodeToString(blob) + "\n" + sshSignatureFooter + "\n"
+}
+
+func generateSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+
+	return signer
+}
+
+func TestGetCommit_Verification(t *testing.T) {
+	signer := generateSigner(t)
+	registeredKeys := []*types.PublicKey{{
+		ID:          1,
+		PrincipalID: 7,
+		Fingerprint: ssh.FingerprintSHA256(signer.PublicKey()),
+	}}
+	signature := signSSH(t, signer, signedCommitPayload)
+
+	tests := []struct {
+		name         string
+		committer    string
+		keys         []*types.PublicKey
+		signatures   []git.CommitSignature
+		wantSigned   bool
+		wantVerified bool
+		wantSigner   string
+		wantReason   enum.CommitVerificationReason
+	}{
+		{
+			name: "verified",
+			keys: registeredKeys,
+			signatures: []git.CommitSignature{{
+				CommitSHA: sha.Must(templateReadmeSHA),
+				Signature: signature,
+				Payload:   signedCommitPayload,
+			}},
+			wantSigned:   true,
+			wantVerified: true,
+			wantSigner:   "alice",
+			wantReason:   enum.CommitVerificationReasonValid,
+		},
+		{
+			name:      "verified with secondary email",
+			committer: "Alice@Users.Gitness.io",
+			keys:      registeredKeys,
+			signatures: []git.CommitSignature{{
+				CommitSHA: sha.Must(templateReadmeSHA),
+				Signature: signature,
+				Payload:   signedCommitPayload,
+			}},
+			wantSigned:   true,
+			wantVerified: true,
+			wantSigner:   "alice",
+			wantReason:   enum.CommitVerificationReasonValid,
+		},
+		{
+			name:      "committer email of other user",
+			committer: "bob@example.com",
+			keys:      registeredKeys,
+			signatures: []git.CommitSignature{{
+				CommitSHA: sha.Must(templateReadmeSHA),
+				Signature: signature,
+				Payload:   signedCommitPayload,
+			}},
+			wantSigned: true,
+			wantReason: enum.CommitVerificationReasonEmailMismatch,
+		},
+		{
+			name:      "committer email of no user",
+			committer: "mallory@example.com",
+			keys:      registeredKeys,
+			signatures: []git.CommitSignature{{
+				CommitSHA: sha.Must(templateReadmeSHA),
+				Signature: signature,
+				Payload:   signedCommitPayload,
+			}},
+			wantSigned: true,
+			wantReason: enum.CommitVerificationReasonEmailMismatch,
+		},
+		{
+			name:       "unsigned",
+			keys:       registeredKeys,
+			wantReason: enum.CommitVerificationReasonUnsigned,
+		},
+		{
+			name: "signed by unknown key",
+			signatures: []git.CommitSignature{{
+				CommitSHA: sha.Must(templateReadmeSHA),
+				Signature: signature,
+				Payload:   signedCommitPayload,
+			}},
+			wantSigned: true,
+			wantReason: enum.CommitVerificationReasonUnknownKey,
+		},
+		{
+			name: "tampered payload",
+			keys: registeredKeys,
+			signatures: []git.CommitSignature{{
+				CommitSHA: sha.Must(templateReadmeSHA),
+				Signature: signature,
+				Payload:   signedCommitPayload + "tampered\n",
+			}},
+			wantSigned: true,
+			wantReason: enum.CommitVerificationReasonInvalid,
+		},
+		{
+			name: "gpg signature",
+			keys: registeredKeys,
+			signatures: []git.CommitSignature{{
+				CommitSHA: sha.Must(templateReadmeSHA),
+				Signature: "-----BEGIN PGP SIGNATURE-----\n\niQ==\n-----END PGP SIGNATURE-----\n",
+				Payload:   signedCommitPayload,
+			}},
+			wantSigned: true,
+			wantReason: enum.CommitVerificationReasonUnknownKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			committer := test.committer
+			if committer == "" {
+				committer = "alice@example.com"
+			}
+			ctrl, session := setupCommitVerificationController(committer, test.keys, test.signatures...)
+
+			commit, err := ctrl.GetCommit(context.Background(), session, "space/repo", "main")
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+
+			verification := commit.Verification
+			if verification == nil {
+				t.Fatal("Want verification, got nil")
+			}
+			if verification.Signed != test.wantSigned {
+				t.Errorf("Want signed %t, got %t", test.wantSigned, verification.Signed)
+			}
+			if verification.Verified != test.wantVerified {
+				t.Errorf("Want verified %t, got %t", test.wantVerified, verification.Verified)
+			}
+			if verification.Reason != test.wantReason {
+				t.Errorf("Want reason %q, got %q", test.wantReason, verification.Reason)
+			}
+
+			var signer string
+			if verification.Signer != nil {
+				signer = verification.Signer.UID
+			}
+			if signer != test.wantSigner {
+				t.Errorf("Want signer %q, got %q", test.wantSigner, signer)
+			}
+		})
+	}
+}
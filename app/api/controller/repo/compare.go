@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Compare contains the commit divergence and the changed files between two refs.
+type Compare struct {
+	BaseSHA string `json:"base_sha"`
+	HeadSHA string `json:"head_sha"`
+	// MergeBaseSHA is empty in case the refs don't have a common ancestor.
+	MergeBaseSHA string `json:"merge_base_sha,omitempty"`
+	// Ahead is the count of commits the head ref is ahead of the base ref.
+	Ahead int32 `json:"ahead"`
+	// Behind is the count of commits the head ref is behind the base ref.
+	Behind    int32          `json:"behind"`
+	Additions int64          `json:"additions"`
+	Deletions int64          `json:"deletions"`
+	Files     []git.FileDiff `json:"files"`
+}
+
+// Compare returns the commit divergence and the changed files between the base and head ref of the path.
+// The changed files are calculated from the merge base, or directly between the refs if they're unrelated.
+func (c *Controller) Compare(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	path string,
+) (*Compare, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := parseDiffPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := c.git.Compare(ctx, &git.CompareParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    info.BaseRef,
+		HeadRef:    info.HeadRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare refs: %w", err)
+	}
+
+	compare := &Compare{
+		BaseSHA: output.BaseSHA.String(),
+		HeadSHA: output.HeadSHA.String(),
+		Ahead:   output.Ahead,
+		Behind:  output.Behind,
+		Files:   output.Files,
+	}
+	if !output.MergeBaseSHA.IsEmpty() {
+		compare.MergeBaseSHA = output.MergeBaseSHA.String()
+	}
+	for _, file := range output.Files {
+		compare.Additions += file.Additions
+		compare.Deletions += file.Deletions
+	}
+
+	return compare, nil
+}
@@ -21,9 +21,11 @@ import (
 	"io"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/parser"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -32,10 +34,13 @@ import (
 
 const (
 	// maxGetContentFileSize specifies the maximum number of bytes a file content response contains.
-	// If a file is any larger, the content is truncated.
+	// If a file is any larger, an error is returned and the raw endpoint has to be used instead.
 	maxGetContentFileSize = 1 << 22 // 4 MB
 )
 
+var errFileTooLarge = usererror.BadRequestf(
+	"The file exceeds the maximum size of %d bytes, use the raw endpoint to download it.", maxGetContentFileSize)
+
 type ContentType string
 
 const (
@@ -68,6 +73,8 @@ type FileContent struct {
 	Data     string                   `json:"data"`
 	Size     int64                    `json:"size"`
 	DataSize int64                    `json:"data_size"`
+	// LFSObject is set in case the file is a git LFS pointer.
+	LFSObject *parser.LFSPointer `json:"lfs_object,omitempty"`
 }
 
 func (c *FileContent) isContent() {}
@@ -194,17 +201,27 @@ func (c *Controller) getFileContent(ctx context.Context,
 		}
 	}()
 
+	if output.Size > maxGetContentFileSize {
+		return nil, errFileTooLarge
+	}
+
 	content, err := io.ReadAll(output.Content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read blob content: %w", err)
 	}
 
-	return &FileContent{
+	fileContent := &FileContent{
 		Size:     output.Size,
 		DataSize: output.ContentSize,
 		Encoding: enum.ContentEncodingTypeBase64,
 		Data:     base64.StdEncoding.EncodeToString(content),
-	}, nil
+	}
+
+	if pointer, ok := parser.ParseLFSPointer(content); ok {
+		fileContent.LFSObject = &pointer
+	}
+
+	return fileContent, nil
 }
 
 func (c *Controller) getSymlinkContent(ctx context.Context,
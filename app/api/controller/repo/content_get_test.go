@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	contentLargeSHA = "4444444444444444444444444444444444444444"
+	contentDataSHA  = "5555555555555555555555555555555555555555"
+	contentHTMLSHA  = "6666666666666666666666666666666666666666"
+)
+
+func setupContentController() *Controller {
+	repo := &types.Repository{ID: 1, Path: "space/repo", DefaultBranch: "main"}
+	return &Controller{
+		authorizer: &fake.Authorizer{},
+		repoStore:  fake.NewRepoStore(repo),
+		git: &gitFake{
+			branch: "main",
+			nodes: map[string]git.TreeNode{
+				"README.md": {Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeFile, SHA: templateReadmeSHA,
+					Name: "README.md", Path: "README.md"},
+				"cmd": {Type: git.TreeNodeTypeTree, Mode: git.TreeNodeModeTree, SHA: templateMainSHA,
+					Name: "cmd", Path: "cmd"},
+				"cmd/main": {Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeExec, SHA: templateMainSHA,
+					Name: "main", Path: "cmd/main"},
+				"large.txt": {Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeFile, SHA: contentLargeSHA,
+					Name: "large.txt", Path: "large.txt"},
+				"config.json": {Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeFile, SHA: templateReadmeSHA,
+					Name: "config.json", Path: "config.json"},
+				"data": {Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeFile, SHA: contentDataSHA,
+					Name: "data", Path: "data"},
+				"index.html": {Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeFile, SHA: contentHTMLSHA,
+					Name: "index.html", Path: "index.html"},
+				"logo.svg": {Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeFile, SHA: contentHTMLSHA,
+					Name: "logo.svg", Path: "logo.svg"},
+				"app.js": {Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeFile, SHA: contentHTMLSHA,
+					Name: "app.js", Path: "app.js"},
+				"page": {Type: git.TreeNodeTypeBlob, Mode: git.TreeNodeModeFile, SHA: contentHTMLSHA,
+					Name: "page", Path: "page"},
+			},
+			blobs: map[string]string{
+				templateReadmeSHA: "# readme",
+				templateMainSHA:   "#!/bin/sh",
+				contentLargeSHA:   strings.Repeat("a", maxGetContentFileSize+1),
+				contentDataSHA:    "\x00\x01\x02",
+				contentHTMLSHA:    "<html><script>alert(1)</script></html>",
+			},
+		},
+	}
+}
+
+func TestGetContent_File(t *testing.T) {
+	c := setupContentController()
+
+	out, err := c.GetContent(context.Background(), &auth.Session{}, "space/repo", "", "README.md", false)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if out.Type != ContentTypeFile {
+		t.Errorf("Want content type %q, got %q", ContentTypeFile, out.Type)
+	}
+
+	file, ok := out.Content.(*FileContent)
+	if !ok {
+		t.Fatalf("Want file content, got %T", out.Content)
+	}
+	if want := base64.StdEncoding.EncodeToString([]byte("# readme")); file.Data != want {
+		t.Errorf("Want data %q, got %q", want, file.Data)
+	}
+	if file.Size != 8 || file.DataSize != 8 {
+		t.Errorf("Want size and data size 8, got %d and %d", file.Size, file.DataSize)
+	}
+}
+
+func TestGetContent_Dir(t *testing.T) {
+	c := setupContentController()
+
+	out, err := c.GetContent(context.Background(), &auth.Session{}, "space/repo", "", "cmd", false)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if out.Type != ContentTypeDir {
+		t.Errorf("Want content type %q, got %q", ContentTypeDir, out.Type)
+	}
+
+	dir, ok := out.Content.(*DirContent)
+	if !ok {
+		t.Fatalf("Want dir content, got %T", out.Content)
+	}
+	if len(dir.Entries) != 1 || dir.Entries[0].Path != "cmd/main" || dir.Entries[0].Type != ContentTypeFile {
+		t.Errorf("Want single file entry 'cmd/main', got %+v", dir.Entries)
+	}
+}
+
+func TestGetContent_FileTooLarge(t *testing.T) {
+	c := setupContentController()
+
+	_, err := c.GetContent(context.Background(), &auth.Session{}, "space/repo", "", "large.txt", false)
+	if !errors.Is(err, errFileTooLarge) {
+		t.Errorf("Want error %v, got %v", errFileTooLarge, err)
+	}
+}
+
+func TestRaw_ContentType(t *testing.T) {
+	c := setupContentController()
+
+	tests := map[string]struct {
+		contentType string
+		content     string
+	}{
+		"config.json": {"application/json", "# readme"},
+		"cmd/main":    {"text/plain; charset=utf-8", "#!/bin/sh"},
+		"data":        {"application/octet-stream", "\x00\x01\x02"},
+		// content that browsers would render or execute is served as plain text.
+		"index.html": {"text/plain; charset=utf-8", "<html><script>alert(1)</script></html>"},
+		"logo.svg":   {"text/plain; charset=utf-8", "<html><script>alert(1)</script></html>"},
+		"app.js":     {"text/plain; charset=utf-8", "<html><script>alert(1)</script></html>"},
+		"page":       {"text/plain; charset=utf-8", "<html><script>alert(1)</script></html>"},
+	}
+
+	for path, test := range tests {
+		reader, size, contentType, err := c.Raw(context.Background(), &auth.Session{}, "space/repo", "", path)
+		if err != nil {
+			t.Fatalf("Want no error for %q, got %v", path, err)
+		}
+
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("failed to read content of %q: %v", path, err)
+		}
+
+		if contentType != test.contentType {
+			t.Errorf("Want content type %q for %q, got %q", test.contentType, path, contentType)
+		}
+		if string(content) != test.content || size != int64(len(test.content)) {
+			t.Errorf("Want content %q for %q, got %q (size %d)", test.content, path, content, size)
+		}
+	}
+}
+
+func TestRaw_Dir(t *testing.T) {
+	c := setupContentController()
+
+	_, _, _, err := c.Raw(context.Background(), &auth.Session{}, "space/repo", "", "cmd")
+	if err == nil {
+		t.Errorf("Want raw of a directory to fail")
+	}
+}
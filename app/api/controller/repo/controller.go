@@ -19,18 +19,23 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/services/protection"
+	reposervice "github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -50,29 +55,45 @@ var (
 type Controller struct {
 	defaultBranch                 string
 	publicResourceCreationEnabled bool
+	idempotencyKeyTTL             time.Duration
+	renameRedirectTTL             time.Duration
 
-	tx                 dbtx.Transactor
-	urlProvider        url.Provider
-	authorizer         authz.Authorizer
-	repoStore          store.RepoStore
-	spaceStore         store.SpaceStore
-	pipelineStore      store.PipelineStore
-	principalStore     store.PrincipalStore
-	ruleStore          store.RuleStore
-	settings           *settings.Service
-	principalInfoCache store.PrincipalInfoCache
-	protectionManager  *protection.Manager
-	git                git.Interface
-	importer           *importer.Repository
-	codeOwners         *codeowners.Service
-	eventReporter      *repoevents.Reporter
-	indexer            keywordsearch.Indexer
-	resourceLimiter    limiter.ResourceLimiter
-	locker             *locker.Locker
-	auditService       audit.Service
-	mtxManager         lock.MutexManager
-	identifierCheck    check.RepoIdentifier
-	repoCheck          Check
+	tx                    dbtx.Transactor
+	urlProvider           url.Provider
+	authorizer            authz.Authorizer
+	repoStore             store.RepoStore
+	spaceStore            store.SpaceStore
+	pipelineStore         store.PipelineStore
+	principalStore        store.PrincipalStore
+	ruleStore             store.RuleStore
+	settings              *settings.Service
+	principalInfoCache    store.PrincipalInfoCache
+	protectionManager     *protection.Manager
+	git                   git.Interface
+	importer              *importer.Repository
+	codeOwners            *codeowners.Service
+	eventReporter         *repoevents.Reporter
+	indexer               keywordsearch.Indexer
+	resourceLimiter       limiter.ResourceLimiter
+	locker                *locker.Locker
+	auditService          audit.Service
+	mtxManager            lock.MutexManager
+	identifierCheck       check.RepoIdentifier
+	repoCheck             Check
+	statsReporter         *reposervice.StatsReporter
+	languageAnalyzer      *reposervice.LanguageAnalyzer
+	idempotencyKeyStore   store.IdempotencyKeyStore
+	deployKeyStore        store.DeployKeyStore
+	publicKeyStore        store.PublicKeyStore
+	deployKeyAuthn        *authn.DeployKeyAuthenticator
+	repoActivityStore     store.RepoActivityStore
+	userEmailStore        store.UserEmailStore
+	garbageCollector      *reposervice.GarbageCollector
+	mirror                *mirror.Service
+	operations            *operation.Registry
+	membershipStore       store.MembershipStore
+	repoCollaboratorStore store.RepoCollaboratorStore
+	repoRedirectStore     store.RepoRedirectStore
 }
 
 func NewController(
@@ -99,10 +120,26 @@ func NewController(
 	mtxManager lock.MutexManager,
 	identifierCheck check.RepoIdentifier,
 	repoCheck Check,
+	statsReporter *reposervice.StatsReporter,
+	languageAnalyzer *reposervice.LanguageAnalyzer,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	deployKeyStore store.DeployKeyStore,
+	publicKeyStore store.PublicKeyStore,
+	deployKeyAuthn *authn.DeployKeyAuthenticator,
+	repoActivityStore store.RepoActivityStore,
+	userEmailStore store.UserEmailStore,
+	garbageCollector *reposervice.GarbageCollector,
+	mirror *mirror.Service,
+	operations *operation.Registry,
+	membershipStore store.MembershipStore,
+	repoCollaboratorStore store.RepoCollaboratorStore,
+	repoRedirectStore store.RepoRedirectStore,
 ) *Controller {
 	return &Controller{
 		defaultBranch:                 config.Git.DefaultBranch,
 		publicResourceCreationEnabled: config.PublicResourceCreationEnabled,
+		idempotencyKeyTTL:             config.Idempotency.KeyTTL,
+		renameRedirectTTL:             config.Repos.RenameRedirectTTL,
 		tx:                            tx,
 		urlProvider:                   urlProvider,
 		authorizer:                    authorizer,
@@ -125,6 +162,20 @@ func NewController(
 		mtxManager:                    mtxManager,
 		identifierCheck:               identifierCheck,
 		repoCheck:                     repoCheck,
+		statsReporter:                 statsReporter,
+		languageAnalyzer:              languageAnalyzer,
+		idempotencyKeyStore:           idempotencyKeyStore,
+		deployKeyStore:                deployKeyStore,
+		publicKeyStore:                publicKeyStore,
+		deployKeyAuthn:                deployKeyAuthn,
+		repoActivityStore:             repoActivityStore,
+		userEmailStore:                userEmailStore,
+		garbageCollector:              garbageCollector,
+		mirror:                        mirror,
+		operations:                    operations,
+		membershipStore:               membershipStore,
+		repoCollaboratorStore:         repoCollaboratorStore,
+		repoRedirectStore:             repoRedirectStore,
 	}
 }
 
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
@@ -57,12 +58,23 @@ type CreateInput struct {
 	Readme        bool   `json:"readme"`
 	License       string `json:"license"`
 	GitIgnore     string `json:"git_ignore"`
+
+	// TemplateRepoID is the ID of the template repository the new repository is created from.
+	TemplateRepoID int64 `json:"template_repo_id"`
+	// CopyProtectionRules copies the protection rules of the template repository.
+	CopyProtectionRules bool `json:"copy_protection_rules"`
 }
 
 // Create creates a new repository.
+// If an idempotency key is provided, retries with the same key return the originally created repository.
 //
 //nolint:gocognit
-func (c *Controller) Create(ctx context.Context, session *auth.Session, in *CreateInput) (*types.Repository, error) {
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateInput,
+	idempotency types.IdempotencyRequest,
+) (*types.Repository, error) {
 	if err := c.sanitizeCreateInput(in); err != nil {
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
@@ -72,6 +84,39 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, err
 	}
 
+	repoID, err := controller.FindIdempotentResourceID(ctx, c.idempotencyKeyStore, c.idempotencyKeyTTL,
+		session.Principal.ID, enum.ResourceTypeRepo, idempotency)
+	if err != nil {
+		return nil, err
+	}
+	if repoID != 0 {
+		return c.findCreatedRepo(ctx, session, repoID)
+	}
+
+	var (
+		template      *types.Repository
+		templateFiles []git.File
+	)
+	if in.TemplateRepoID != 0 {
+		template, err = c.getTemplateRepoCheckAccess(ctx, session, in.TemplateRepoID)
+		if err != nil {
+			return nil, err
+		}
+
+		templateFiles, err = c.readTemplateFiles(ctx, template)
+		if err != nil {
+			return nil, err
+		}
+
+		if in.DefaultBranch == "" {
+			in.DefaultBranch = template.DefaultBranch
+		}
+	}
+
+	if in.DefaultBranch == "" {
+		in.DefaultBranch = c.spaceDefaultBranch(parentSpace)
+	}
+
 	err = c.repoCheck.Create(ctx, session, in)
 	if err != nil {
 		return nil, err
@@ -89,7 +134,7 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 			return fmt.Errorf("failed to find the parent space: %w", err)
 		}
 
-		gitResp, isEmpty, err := c.createGitRepository(ctx, session, in)
+		gitResp, isEmpty, err := c.createGitRepository(ctx, session, in, templateFiles)
 		if err != nil {
 			return fmt.Errorf("error creating repository on git: %w", err)
 		}
@@ -117,6 +162,25 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 			return fmt.Errorf("failed to create repository in storage: %w", err)
 		}
 
+		// only the protection rules are copied - collaborators and webhooks of the template are never carried over.
+		if template != nil && in.CopyProtectionRules {
+			if err = c.copyProtectionRules(ctx, session, template, repo); err != nil {
+				if dErr := c.DeleteGitRepository(ctx, session, repo); dErr != nil {
+					log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete repo for cleanup")
+				}
+				return err
+			}
+		}
+
+		err = controller.StoreIdempotencyKey(ctx, c.idempotencyKeyStore,
+			session.Principal.ID, enum.ResourceTypeRepo, idempotency, repo.ID)
+		if err != nil {
+			if dErr := c.DeleteGitRepository(ctx, session, repo); dErr != nil {
+				log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete repo for cleanup")
+			}
+			return err
+		}
+
 		return nil
 	}, sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
@@ -134,8 +198,8 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create repository operation: %s", err)
 	}
 
-	// backfil GitURL
-	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	// backfill clone urls
+	controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
 
 	// index repository if files are created
 	if !repo.IsEmpty {
@@ -148,6 +212,22 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 	return repo, nil
 }
 
+// findCreatedRepo returns the repository that was created by an earlier request with the same idempotency key.
+func (c *Controller) findCreatedRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoID int64,
+) (*types.Repository, error) {
+	repo, err := c.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository created for idempotency key: %w", err)
+	}
+
+	controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
+
+	return repo, nil
+}
+
 func (c *Controller) getSpaceCheckAuthRepoCreation(
 	ctx context.Context,
 	session *auth.Session,
@@ -175,6 +255,16 @@ func (c *Controller) getSpaceCheckAuthRepoCreation(
 	return space, nil
 }
 
+// spaceDefaultBranch returns the default branch for new repos in the space,
+// falling back to the globally configured default branch.
+func (c *Controller) spaceDefaultBranch(space *types.Space) string {
+	if space.DefaultBranch != "" {
+		return space.DefaultBranch
+	}
+
+	return c.defaultBranch
+}
+
 func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 	// TODO [CODE-1363]: remove after identifier migration.
 	if in.Identifier == "" {
@@ -198,46 +288,36 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return err
 	}
 
-	if in.DefaultBranch == "" {
-		in.DefaultBranch = c.defaultBranch
+	if in.DefaultBranch != "" {
+		if err := check.BranchName(in.DefaultBranch); err != nil {
+			return err
+		}
+	}
+
+	if in.TemplateRepoID != 0 && (in.Readme || in.GitIgnore != "" || (in.License != "" && in.License != "none")) {
+		return errTemplateWithFiles
+	}
+
+	if in.License != "" && in.License != "none" && !resources.LicenseExists(in.License) {
+		return usererror.BadRequestf("Unknown license %q.", in.License)
+	}
+
+	if in.GitIgnore != "" && !resources.GitIgnoreExists(in.GitIgnore) {
+		return usererror.BadRequestf("Unknown gitignore template %q.", in.GitIgnore)
+	}
+
+	if in.TemplateRepoID == 0 && in.CopyProtectionRules {
+		return usererror.BadRequest("Protection rules can only be copied from a template repository.")
 	}
 
 	return nil
 }
 
 func (c *Controller) createGitRepository(ctx context.Context, session *auth.Session,
-	in *CreateInput) (*git.CreateRepositoryOutput, bool, error) {
-	var (
-		err     error
-		content []byte
-	)
-	files := make([]git.File, 0, 3) // readme, gitignore, licence
-	if in.Readme {
-		content = createReadme(in.Identifier, in.Description)
-		files = append(files, git.File{
-			Path:    "README.md",
-			Content: content,
-		})
-	}
-	if in.License != "" && in.License != "none" {
-		content, err = resources.ReadLicense(in.License)
-		if err != nil {
-			return nil, false, fmt.Errorf("failed to read license '%s': %w", in.License, err)
-		}
-		files = append(files, git.File{
-			Path:    "LICENSE",
-			Content: content,
-		})
-	}
-	if in.GitIgnore != "" {
-		content, err = resources.ReadGitIgnore(in.GitIgnore)
-		if err != nil {
-			return nil, false, fmt.Errorf("failed to read git ignore '%s': %w", in.GitIgnore, err)
-		}
-		files = append(files, git.File{
-			Path:    ".gitignore",
-			Content: content,
-		})
+	in *CreateInput, templateFiles []git.File) (*git.CreateRepositoryOutput, bool, error) {
+	files, err := initialFiles(in, templateFiles)
+	if err != nil {
+		return nil, false, err
 	}
 
 	// generate envars (add everything githook CLI needs for execution)
@@ -273,6 +353,40 @@ func (c *Controller) createGitRepository(ctx context.Context, session *auth.Sess
 	return resp, len(files) == 0, nil
 }
 
+// initialFiles returns the files of the initial commit (template files, readme, license and gitignore).
+func initialFiles(in *CreateInput, templateFiles []git.File) ([]git.File, error) {
+	files := make([]git.File, 0, len(templateFiles)+3) // template files, readme, gitignore, licence
+	files = append(files, templateFiles...)
+	if in.Readme {
+		files = append(files, git.File{
+			Path:    "README.md",
+			Content: createReadme(in.Identifier, in.Description),
+		})
+	}
+	if in.License != "" && in.License != "none" {
+		content, err := resources.ReadLicense(in.License)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read license '%s': %w", in.License, err)
+		}
+		files = append(files, git.File{
+			Path:    "LICENSE",
+			Content: content,
+		})
+	}
+	if in.GitIgnore != "" {
+		content, err := resources.ReadGitIgnore(in.GitIgnore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read git ignore '%s': %w", in.GitIgnore, err)
+		}
+		files = append(files, git.File{
+			Path:    ".gitignore",
+			Content: content,
+		})
+	}
+
+	return files, nil
+}
+
 func createReadme(name, description string) []byte {
 	content := bytes.Buffer{}
 	content.WriteString("# " + name + "\n")
@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -47,6 +48,10 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 	repoRef string,
 	in *CreateCommitTagInput,
 ) (*CommitTag, []types.RuleViolations, error) {
+	if err := check.TagName(in.Name); err != nil {
+		return nil, nil, err
+	}
+
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
 	if err != nil {
 		return nil, nil, err
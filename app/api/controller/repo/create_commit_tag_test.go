@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/check"
+)
+
+func TestCreateCommitTag_InvalidName(t *testing.T) {
+	tests := []struct {
+		name    string
+		tagName string
+		wantErr error
+	}{
+		{name: "empty", tagName: "", wantErr: check.ErrTagNameLength},
+		{name: "double dot", tagName: "v1..0", wantErr: check.ErrTagNameInvalid},
+		{name: "lock suffix", tagName: "v1.lock", wantErr: check.ErrTagNameInvalid},
+		{name: "space", tagName: "v 1", wantErr: check.ErrTagNameInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Controller{}
+
+			_, _, err := c.CreateCommitTag(context.Background(), &auth.Session{}, "space/repo",
+				&CreateCommitTagInput{Name: tt.tagName})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Want error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
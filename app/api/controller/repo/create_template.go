@@ -0,0 +1,197 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxTemplateSize specifies the maximum total number of bytes of files that are copied from a template.
+	maxTemplateSize = 1 << 26 // 64 MB
+)
+
+var (
+	errRepositoryNotTemplate = usererror.BadRequest("The provided repository is not a template.")
+	errTemplateWithFiles     = usererror.BadRequest(
+		"Repositories created from a template can't be initialized with a readme, license or gitignore.")
+	errTemplateTooLarge = usererror.BadRequestf(
+		"The template repository exceeds the maximum size of %d bytes.", maxTemplateSize)
+)
+
+// getTemplateRepoCheckAccess fetches the template repository and checks
+// if the current user has permission to view it.
+func (c *Controller) getTemplateRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	templateRepoID int64,
+) (*types.Repository, error) {
+	template, err := c.getRepoCheckAccess(ctx, session,
+		strconv.FormatInt(templateRepoID, 10), enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template repository: %w", err)
+	}
+
+	if !template.IsTemplate {
+		return nil, errRepositoryNotTemplate
+	}
+
+	return template, nil
+}
+
+// readTemplateFiles returns all files of the default branch of the template repository.
+// Symlinks and submodules aren't copied.
+func (c *Controller) readTemplateFiles(
+	ctx context.Context,
+	template *types.Repository,
+) ([]git.File, error) {
+	if template.IsEmpty {
+		return nil, nil
+	}
+
+	readParams := git.CreateReadParams(template)
+	ref := template.DefaultBranch
+
+	paths, err := c.git.ListPaths(ctx, &git.ListPathsParams{
+		ReadParams: readParams,
+		GitREF:     ref,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list paths of template repository: %w", err)
+	}
+
+	files := make([]git.File, 0, len(paths.Files))
+	var totalSize int64
+	for _, path := range paths.Files {
+		node, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+			ReadParams: readParams,
+			GitREF:     ref,
+			Path:       path,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tree node '%s' of template repository: %w", path, err)
+		}
+
+		if node.Node.Mode != git.TreeNodeModeFile && node.Node.Mode != git.TreeNodeModeExec {
+			continue
+		}
+
+		content, err := c.readTemplateBlob(ctx, readParams, node.Node.SHA, maxTemplateSize-totalSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file '%s' of template repository: %w", path, err)
+		}
+
+		totalSize += int64(len(content))
+		files = append(files, git.File{
+			Path:    path,
+			Content: content,
+		})
+	}
+
+	return files, nil
+}
+
+func (c *Controller) readTemplateBlob(
+	ctx context.Context,
+	readParams git.ReadParams,
+	sha string,
+	sizeLimit int64,
+) ([]byte, error) {
+	output, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        sha,
+		SizeLimit:  sizeLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	if output.Size > sizeLimit {
+		return nil, errTemplateTooLarge
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	return content, nil
+}
+
+// copyProtectionRules copies the protection rules of the template repository to the new repository.
+// The copied rules are owned by the current user.
+func (c *Controller) copyProtectionRules(
+	ctx context.Context,
+	session *auth.Session,
+	template *types.Repository,
+	repo *types.Repository,
+) error {
+	filter := &types.RuleFilter{}
+
+	count, err := c.ruleStore.Count(ctx, nil, &template.ID, filter)
+	if err != nil {
+		return fmt.Errorf("failed to count protection rules of template repository: %w", err)
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	filter.Size = int(count)
+	rules, err := c.ruleStore.List(ctx, nil, &template.ID, filter)
+	if err != nil {
+		return fmt.Errorf("failed to list protection rules of template repository: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	for i := range rules {
+		rule := &types.Rule{
+			CreatedBy:   session.Principal.ID,
+			Created:     now,
+			Updated:     now,
+			RepoID:      &repo.ID,
+			Identifier:  rules[i].Identifier,
+			Description: rules[i].Description,
+			Type:        rules[i].Type,
+			State:       rules[i].State,
+			Pattern:     rules[i].Pattern,
+			Definition:  rules[i].Definition,
+		}
+
+		if err = c.ruleStore.Create(ctx, rule); err != nil {
+			return fmt.Errorf("failed to copy protection rule '%s': %w", rule.Identifier, err)
+		}
+	}
+
+	return nil
+}
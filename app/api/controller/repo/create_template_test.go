@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/operation"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	templateReadmeSHA = "1111111111111111111111111111111111111111"
+	templateMainSHA   = "2222222222222222222222222222222222222222"
+	templateLinkSHA   = "3333333333333333333333333333333333333333"
+)
+
+func setupGitFakeController(template *types.Repository, rules []types.Rule) *Controller {
+	return &Controller{
+		authorizer: &fake.Authorizer{},
+		repoStore:  fake.NewRepoStore(template),
+		ruleStore:  &ruleStoreFake{rules: rules},
+		git: &gitFake{
+			branch: "develop",
+			nodes: map[string]git.TreeNode{
+				"README.md": {Mode: git.TreeNodeModeFile, SHA: templateReadmeSHA, Path: "README.md"},
+				"cmd/main":  {Mode: git.TreeNodeModeExec, SHA: templateMainSHA, Path: "cmd/main"},
+				"link":      {Mode: git.TreeNodeModeSymlink, SHA: templateLinkSHA, Path: "link"},
+			},
+			blobs: map[string]string{
+				templateReadmeSHA: "# template",
+				templateMainSHA:   "#!/bin/sh",
+				templateLinkSHA:   "README.md",
+			},
+		},
+		operations: operation.NewRegistry(),
+	}
+}
+
+func TestCreate_TemplateFiles(t *testing.T) {
+	template := &types.Repository{ID: 1, Path: "space/template", DefaultBranch: "develop", IsTemplate: true}
+	c := setupGitFakeController(template, nil)
+
+	template, err := c.getTemplateRepoCheckAccess(context.Background(), &auth.Session{}, template.ID)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	files, err := c.readTemplateFiles(context.Background(), template)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	want := []git.File{
+		{Path: "README.md", Content: []byte("# template")},
+		{Path: "cmd/main", Content: []byte("#!/bin/sh")},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("Want %d files, got %d", len(want), len(files))
+	}
+	for i := range want {
+		if files[i].Path != want[i].Path || string(files[i].Content) != string(want[i].Content) {
+			t.Errorf("Want file %s with %q, got %s with %q",
+				want[i].Path, want[i].Content, files[i].Path, files[i].Content)
+		}
+	}
+}
+
+func TestCreate_TemplateNotMarked(t *testing.T) {
+	template := &types.Repository{ID: 1, Path: "space/template", DefaultBranch: "develop"}
+	c := setupGitFakeController(template, nil)
+
+	_, err := c.getTemplateRepoCheckAccess(context.Background(), &auth.Session{}, template.ID)
+	if !errors.Is(err, errRepositoryNotTemplate) {
+		t.Errorf("Want error %v, got %v", errRepositoryNotTemplate, err)
+	}
+}
+
+func TestCreate_TemplateWithFiles(t *testing.T) {
+	c := &Controller{identifierCheck: check.RepoIdentifierDefault}
+
+	err := c.sanitizeCreateInput(&CreateInput{
+		ParentRef:      "space",
+		Identifier:     "repo",
+		TemplateRepoID: 1,
+		Readme:         true,
+	})
+	if !errors.Is(err, errTemplateWithFiles) {
+		t.Errorf("Want error %v, got %v", errTemplateWithFiles, err)
+	}
+}
+
+func TestCreate_TemplateCopyProtectionRules(t *testing.T) {
+	templateID := int64(1)
+	otherRepoID := int64(3)
+	template := &types.Repository{ID: templateID, Path: "space/template", DefaultBranch: "develop", IsTemplate: true}
+	rules := []types.Rule{
+		{ID: 1, CreatedBy: 7, RepoID: &templateID, Identifier: "protect-main",
+			Type: "branch", State: enum.RuleStateActive},
+		{ID: 2, CreatedBy: 7, RepoID: &otherRepoID, Identifier: "other",
+			Type: "branch", State: enum.RuleStateActive},
+	}
+	c := setupGitFakeController(template, rules)
+
+	session := &auth.Session{Principal: types.Principal{ID: 42}}
+	repo := &types.Repository{ID: 2, DefaultBranch: "develop"}
+
+	err := c.copyProtectionRules(context.Background(), session, template, repo)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	copied, _ := c.ruleStore.List(context.Background(), nil, &repo.ID, &types.RuleFilter{})
+	if len(copied) != 1 {
+		t.Fatalf("Want 1 copied rule, got %d", len(copied))
+	}
+	if copied[0].Identifier != "protect-main" {
+		t.Errorf("Want rule %q, got %q", "protect-main", copied[0].Identifier)
+	}
+	if copied[0].CreatedBy != session.Principal.ID {
+		t.Errorf("Want rule created by %d, got %d", session.Principal.ID, copied[0].CreatedBy)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/resources"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+func TestCreate_SpaceDefaultBranch(t *testing.T) {
+	tests := []struct {
+		name               string
+		spaceDefaultBranch string
+		want               string
+	}{
+		{name: "global default", spaceDefaultBranch: "", want: "main"},
+		{name: "space default", spaceDefaultBranch: "develop", want: "develop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Controller{
+				defaultBranch: "main",
+				spaceStore: &spaceStoreFake{
+					space: &types.Space{ID: 1, Path: "space", DefaultBranch: tt.spaceDefaultBranch},
+				},
+				authorizer: &fake.Authorizer{},
+			}
+
+			space, err := c.getSpaceCheckAuthRepoCreation(context.Background(), &auth.Session{}, "space")
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+
+			if got := c.spaceDefaultBranch(space); got != tt.want {
+				t.Errorf("Want default branch %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCreate_InvalidDefaultBranch(t *testing.T) {
+	c := &Controller{identifierCheck: check.RepoIdentifierDefault}
+
+	err := c.sanitizeCreateInput(&CreateInput{
+		ParentRef:     "space",
+		Identifier:    "repo",
+		DefaultBranch: "main..dev",
+	})
+	if !errors.Is(err, check.ErrBranchNameInvalid) {
+		t.Errorf("Want error %v, got %v", check.ErrBranchNameInvalid, err)
+	}
+}
+
+func TestCreate_InitialFiles(t *testing.T) {
+	in := &CreateInput{
+		Identifier:  "repo",
+		Description: "some repo",
+		Readme:      true,
+		License:     "mit",
+		GitIgnore:   "Go",
+	}
+
+	files, err := initialFiles(in, nil)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	license, _ := resources.ReadLicense("mit")
+	gitIgnore, _ := resources.ReadGitIgnore("Go")
+	want := []git.File{
+		{Path: ".gitignore", Content: gitIgnore},
+		{Path: "LICENSE", Content: license},
+		{Path: "README.md", Content: []byte("# repo\nsome repo")},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("Want %d files, got %d", len(want), len(files))
+	}
+	for i := range want {
+		if files[i].Path != want[i].Path || string(files[i].Content) != string(want[i].Content) {
+			t.Errorf("Want file %s with %q, got %s with %q",
+				want[i].Path, want[i].Content, files[i].Path, files[i].Content)
+		}
+	}
+}
+
+func TestCreate_UnknownTemplates(t *testing.T) {
+	tests := []struct {
+		name string
+		in   CreateInput
+	}{
+		{name: "license", in: CreateInput{License: "no-such-license"}},
+		{name: "gitignore", in: CreateInput{GitIgnore: "NoSuchLanguage"}},
+		{name: "path traversal", in: CreateInput{GitIgnore: "../license/mit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Controller{identifierCheck: check.RepoIdentifierDefault}
+
+			in := tt.in
+			in.ParentRef = "space"
+			in.Identifier = "repo"
+
+			err := c.sanitizeCreateInput(&in)
+
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+				t.Errorf("Want bad request error, got %v", err)
+			}
+		})
+	}
+}
@@ -28,11 +28,13 @@ import (
 )
 
 // DeleteBranch deletes a repo branch.
+// If requireMerged is set, branches that aren't merged into the default branch can't be deleted.
 func (c *Controller) DeleteBranch(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	branchName string,
 	bypassRules bool,
+	requireMerged bool,
 ) ([]types.RuleViolations, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
 	if err != nil {
@@ -68,6 +70,12 @@ func (c *Controller) DeleteBranch(ctx context.Context,
 		return violations, nil
 	}
 
+	if requireMerged {
+		if err = c.checkBranchMerged(ctx, repo, branchName); err != nil {
+			return nil, err
+		}
+	}
+
 	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
@@ -83,3 +91,39 @@ func (c *Controller) DeleteBranch(ctx context.Context,
 
 	return nil, nil
 }
+
+// checkBranchMerged returns an error if the branch isn't merged into the default branch of the repo.
+func (c *Controller) checkBranchMerged(ctx context.Context, repo *types.Repository, branchName string) error {
+	readParams := git.CreateReadParams(repo)
+
+	branch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: branchName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get branch: %w", err)
+	}
+
+	defaultBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: repo.DefaultBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	out, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+		ReadParams:          readParams,
+		AncestorCommitSHA:   branch.Branch.SHA,
+		DescendantCommitSHA: defaultBranch.Branch.SHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check if branch is merged: %w", err)
+	}
+
+	if !out.Ancestor {
+		return usererror.ErrBranchNotMerged
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var deleteBranchSession = &auth.Session{Principal: types.Principal{ID: 42}}
+
+const (
+	mergedBranchSHA   = "4444444444444444444444444444444444444444"
+	unmergedBranchSHA = "5555555555555555555555555555555555555555"
+)
+
+func setupDeleteBranchController(t *testing.T, rules []types.Rule) (*Controller, *gitFake) {
+	t.Helper()
+
+	repo := &types.Repository{ID: 1, Path: "space/repo", GitUID: "repo-uid", DefaultBranch: "main"}
+	ruleStore := &ruleStoreFake{rules: rules}
+
+	protectionManager, err := protection.ProvideManager(ruleStore)
+	if err != nil {
+		t.Fatalf("failed to create protection manager: %s", err)
+	}
+
+	g := &gitFake{
+		branches: []git.Branch{
+			{Name: "main", SHA: sha.Must(templateReadmeSHA)},
+			{Name: "merged", SHA: sha.Must(mergedBranchSHA)},
+			{Name: "unmerged", SHA: sha.Must(unmergedBranchSHA)},
+		},
+		merged: map[string]bool{mergedBranchSHA: true},
+	}
+
+	return &Controller{
+		authorizer:        &fake.Authorizer{},
+		repoStore:         fake.NewRepoStore(repo),
+		ruleStore:         ruleStore,
+		protectionManager: protectionManager,
+		urlProvider:       urlProviderFake{},
+		git:               g,
+	}, g
+}
+
+func TestDeleteBranch(t *testing.T) {
+	c, g := setupDeleteBranchController(t, nil)
+
+	violations, err := c.DeleteBranch(context.Background(), deleteBranchSession, "space/repo", "merged", false, true)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Want no violations, got %v", violations)
+	}
+	if len(g.deleted) != 1 || g.deleted[0] != "merged" {
+		t.Errorf("Want branch %q to be deleted, got %v", "merged", g.deleted)
+	}
+}
+
+func TestDeleteBranch_DefaultBranch(t *testing.T) {
+	c, g := setupDeleteBranchController(t, nil)
+
+	_, err := c.DeleteBranch(context.Background(), deleteBranchSession, "space/repo", "main", false, false)
+	if !errors.Is(err, usererror.ErrDefaultBranchCantBeDeleted) {
+		t.Errorf("Want error %v, got %v", usererror.ErrDefaultBranchCantBeDeleted, err)
+	}
+	if len(g.deleted) != 0 {
+		t.Errorf("Want no branch to be deleted, got %v", g.deleted)
+	}
+}
+
+func TestDeleteBranch_Protected(t *testing.T) {
+	repoID := int64(1)
+	rules := []types.Rule{{
+		ID:         1,
+		RepoID:     &repoID,
+		Identifier: "no-delete",
+		Type:       protection.TypeBranch,
+		State:      enum.RuleStateActive,
+		Pattern:    []byte(`{"include":["merged"]}`),
+		Definition: []byte(`{"lifecycle":{"delete_forbidden":true}}`),
+	}}
+	c, g := setupDeleteBranchController(t, rules)
+
+	violations, err := c.DeleteBranch(context.Background(), deleteBranchSession, "space/repo", "merged", false, false)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if !protection.IsCritical(violations) {
+		t.Errorf("Want critical rule violations, got %v", violations)
+	}
+	if len(g.deleted) != 0 {
+		t.Errorf("Want no branch to be deleted, got %v", g.deleted)
+	}
+}
+
+func TestDeleteBranch_Unmerged(t *testing.T) {
+	c, g := setupDeleteBranchController(t, nil)
+
+	_, err := c.DeleteBranch(context.Background(), deleteBranchSession, "space/repo", "unmerged", false, true)
+	if !errors.Is(err, usererror.ErrBranchNotMerged) {
+		t.Errorf("Want error %v, got %v", usererror.ErrBranchNotMerged, err)
+	}
+	if len(g.deleted) != 0 {
+		t.Errorf("Want no branch to be deleted, got %v", g.deleted)
+	}
+
+	// unmerged branches are deleted by default.
+	_, err = c.DeleteBranch(context.Background(), deleteBranchSession, "space/repo", "unmerged", false, false)
+	if err != nil {
+		t.Fatalf("Want delete to succeed, got %v", err)
+	}
+	if len(g.deleted) != 1 || g.deleted[0] != "unmerged" {
+		t.Errorf("Want branch %q to be deleted, got %v", "unmerged", g.deleted)
+	}
+}
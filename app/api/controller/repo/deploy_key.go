@@ -0,0 +1,265 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+var errDeployKeyDuplicate = usererror.Conflict("A deploy key or public key with the same fingerprint already exists.")
+
+type CreateDeployKeyInput struct {
+	Content string `json:"content"`
+	Label   string `json:"label"`
+	Write   bool   `json:"write"`
+}
+
+// CreateDeployKey adds a new deploy key to the repository.
+func (c *Controller) CreateDeployKey(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateDeployKeyInput,
+) (*types.DeployKey, error) {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return nil, err
+	}
+
+	key, err := sanitizeCreateDeployKeyInput(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	// a key authenticates either a user or a repository, never both.
+	if err = c.checkDeployKeyFingerprintUnique(ctx, fingerprint); err != nil {
+		return nil, err
+	}
+
+	deployKey := &types.DeployKey{
+		RepoID:      repo.ID,
+		Fingerprint: fingerprint,
+		Content:     strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+		Label:       in.Label,
+		Write:       in.Write,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	err = c.deployKeyStore.Create(ctx, deployKey)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errDeployKeyDuplicate
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store deploy key: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeDeployKey, deployKey.Fingerprint),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(deployKey),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create deploy key operation: %s", err)
+	}
+
+	return deployKey, nil
+}
+
+// ListDeployKeys lists all deploy keys of the repository.
+func (c *Controller) ListDeployKeys(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.DeployKey, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.deployKeyStore.List(ctx, repo.ID)
+}
+
+// DeleteDeployKey revokes a deploy key of the repository.
+func (c *Controller) DeleteDeployKey(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	id int64,
+) error {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	deployKey, err := c.deployKeyStore.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Ensure deploy key belongs to repo.
+	if deployKey.RepoID != repo.ID {
+		log.Ctx(ctx).Warn().Msg("Principal tried to delete deploy key that doesn't belong to the repo")
+
+		// throw a not found error - no need for user to know about the key.
+		return usererror.ErrNotFound
+	}
+
+	if err = c.deployKeyStore.Delete(ctx, deployKey.ID); err != nil {
+		return fmt.Errorf("failed to delete deploy key: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeDeployKey, deployKey.Fingerprint),
+		audit.ActionDeleted,
+		paths.Parent(repo.Path),
+		audit.WithOldObject(deployKey),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for delete deploy key operation: %s", err)
+	}
+
+	return nil
+}
+
+type AuthorizeDeployKeyInput struct {
+	// Content is the public key presented by the ssh client in authorized_keys format.
+	Content string `json:"content"`
+	// Write requests push access (e.g. for git-receive-pack), otherwise read access is requested.
+	Write bool `json:"write"`
+}
+
+type AuthorizeDeployKeyOutput struct {
+	RepoID int64  `json:"repo_id"`
+	GitUID string `json:"git_uid"`
+}
+
+// AuthorizeDeployKey authorizes a git operation on the repository for a deploy key.
+// It's the entry point of the external ssh frontend, which forwards the public key of the ssh client
+// before serving the git operation. Only admins (e.g. the service account of the frontend) can call it.
+func (c *Controller) AuthorizeDeployKey(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *AuthorizeDeployKeyInput,
+) (*AuthorizeDeployKeyOutput, error) {
+	if !session.Principal.Admin {
+		return nil, usererror.ErrForbidden
+	}
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(in.Content)))
+	if err != nil {
+		return nil, check.ErrPublicKeyInvalid
+	}
+
+	keySession, err := c.deployKeyAuthn.Authenticate(ctx, key)
+	if errors.Is(err, authn.ErrInvalidDeployKey) {
+		return nil, usererror.ErrForbidden
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate deploy key: %w", err)
+	}
+
+	permission := enum.PermissionRepoView
+	if in.Write {
+		permission = enum.PermissionRepoPush
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, keySession, repoRef, permission, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthorizeDeployKeyOutput{
+		RepoID: repo.ID,
+		GitUID: repo.GitUID,
+	}, nil
+}
+
+// checkDeployKeyFingerprintUnique ensures the fingerprint isn't used by any deploy key or user public key.
+func (c *Controller) checkDeployKeyFingerprintUnique(ctx context.Context, fingerprint string) error {
+	_, err := c.deployKeyStore.FindByFingerprint(ctx, fingerprint)
+	if err == nil {
+		return errDeployKeyDuplicate
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find deploy key by fingerprint: %w", err)
+	}
+
+	_, err = c.publicKeyStore.FindByFingerprint(ctx, fingerprint)
+	if err == nil {
+		return errDeployKeyDuplicate
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find public key by fingerprint: %w", err)
+	}
+
+	return nil
+}
+
+// sanitizeCreateDeployKeyInput validates the input and returns the parsed public key.
+// If no label is provided, the comment of the key is used instead.
+func sanitizeCreateDeployKeyInput(in *CreateDeployKeyInput) (ssh.PublicKey, error) {
+	in.Content = strings.TrimSpace(in.Content)
+	if err := check.DeployKey(in.Content); err != nil {
+		return nil, err
+	}
+
+	key, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(in.Content))
+	if err != nil {
+		return nil, check.ErrPublicKeyInvalid
+	}
+
+	in.Label = strings.TrimSpace(in.Label)
+	if in.Label == "" {
+		in.Label = strings.TrimSpace(comment)
+	}
+	if err = check.Description(in.Label); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
@@ -0,0 +1,304 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"strings"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/exp/slices"
+)
+
+func setupDeployKeyController() (*Controller, *deployKeyStoreFake, *auth.Session) {
+	deployKeyStore := &deployKeyStoreFake{}
+	ctrl := &Controller{
+		authorizer: &fake.Authorizer{},
+		repoStore: fake.NewRepoStore(&types.Repository{
+			ID:         1,
+			Identifier: "repo",
+			Path:       "space/repo",
+		}),
+		auditService:   auditServiceFake{},
+		deployKeyStore: deployKeyStore,
+		publicKeyStore: &publicKeyStoreFake{},
+	}
+
+	return ctrl, deployKeyStore, &auth.Session{Principal: types.Principal{ID: 5}}
+}
+
+func generateDeployKey(t *testing.T) (string, ssh.PublicKey) {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to convert key: %v", err)
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))) + " ci@pipeline", key
+}
+
+func TestCreateDeployKey(t *testing.T) {
+	ctrl, _, session := setupDeployKeyController()
+	content, key := generateDeployKey(t)
+
+	deployKey, err := ctrl.CreateDeployKey(context.Background(), session, "space/repo",
+		&CreateDeployKeyInput{Content: content})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if got, want := deployKey.Fingerprint, ssh.FingerprintSHA256(key); got != want {
+		t.Errorf("Want fingerprint %q, got %q", want, got)
+	}
+	if got, want := deployKey.Label, "ci@pipeline"; got != want {
+		t.Errorf("Want label %q, got %q", want, got)
+	}
+	if deployKey.RepoID != 1 || deployKey.CreatedBy != 5 {
+		t.Errorf("Want key of repo 1 created by 5, got repo %d created by %d", deployKey.RepoID, deployKey.CreatedBy)
+	}
+	if deployKey.Write {
+		t.Errorf("Want deploy key to be read-only by default")
+	}
+
+	keys, err := ctrl.ListDeployKeys(context.Background(), session, "space/repo")
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if len(keys) != 1 {
+		t.Errorf("Want 1 deploy key, got %d", len(keys))
+	}
+}
+
+func TestCreateDeployKey_Duplicate(t *testing.T) {
+	ctrl, _, session := setupDeployKeyController()
+	content, _ := generateDeployKey(t)
+
+	_, err := ctrl.CreateDeployKey(context.Background(), session, "space/repo",
+		&CreateDeployKeyInput{Content: content})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	_, err = ctrl.CreateDeployKey(context.Background(), session, "space/repo",
+		&CreateDeployKeyInput{Content: content, Write: true})
+	if !errors.Is(err, errDeployKeyDuplicate) {
+		t.Errorf("Want error %v, got %v", errDeployKeyDuplicate, err)
+	}
+
+	// a key that's registered for a user can't be used as deploy key.
+	otherContent, otherKey := generateDeployKey(t)
+	ctrl.publicKeyStore = &publicKeyStoreFake{keys: []*types.PublicKey{
+		{ID: 1, PrincipalID: 5, Fingerprint: ssh.FingerprintSHA256(otherKey)},
+	}}
+	_, err = ctrl.CreateDeployKey(context.Background(), session, "space/repo",
+		&CreateDeployKeyInput{Content: otherContent})
+	if !errors.Is(err, errDeployKeyDuplicate) {
+		t.Errorf("Want error %v, got %v", errDeployKeyDuplicate, err)
+	}
+}
+
+func TestCreateDeployKey_Invalid(t *testing.T) {
+	ctrl, _, session := setupDeployKeyController()
+
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	weakSSHKey, err := ssh.NewPublicKey(&weakKey.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to convert key: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{name: "empty", content: "", wantErr: check.ErrPublicKeyLength},
+		{name: "malformed", content: "ssh-ed25519 not-a-key", wantErr: check.ErrPublicKeyInvalid},
+		{
+			name:    "weak rsa",
+			content: string(ssh.MarshalAuthorizedKey(weakSSHKey)),
+			wantErr: check.ErrDeployKeyRSATooShort,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ctrl.CreateDeployKey(context.Background(), session, "space/repo",
+				&CreateDeployKeyInput{Content: test.content})
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("Want error %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDeleteDeployKey(t *testing.T) {
+	ctrl, deployKeyStore, session := setupDeployKeyController()
+	content, _ := generateDeployKey(t)
+
+	deployKey, err := ctrl.CreateDeployKey(context.Background(), session, "space/repo",
+		&CreateDeployKeyInput{Content: content, Write: true})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if err = ctrl.DeleteDeployKey(context.Background(), session, "space/repo", deployKey.ID); err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if _, err = deployKeyStore.FindByFingerprint(context.Background(), deployKey.Fingerprint); err == nil {
+		t.Errorf("Want deploy key to be revoked")
+	}
+}
+
+func TestDeleteDeployKey_OtherRepo(t *testing.T) {
+	ctrl, deployKeyStore, session := setupDeployKeyController()
+	deployKeyStore.keys = []*types.DeployKey{{ID: 7, RepoID: 2, Fingerprint: "SHA256:other"}}
+
+	err := ctrl.DeleteDeployKey(context.Background(), session, "space/repo", 7)
+	if !errors.Is(err, usererror.ErrNotFound) {
+		t.Errorf("Want error %v, got %v", usererror.ErrNotFound, err)
+	}
+	if len(deployKeyStore.keys) != 1 {
+		t.Errorf("Want deploy key of other repo to be kept")
+	}
+}
+
+// permissionCacheFake grants all permissions of principals with access to the repo.
+type permissionCacheFake struct {
+	authz.PermissionCache
+	principalIDs []int64
+}
+
+func (c permissionCacheFake) Get(_ context.Context, key authz.PermissionCacheKey) (bool, error) {
+	return slices.Contains(c.principalIDs, key.PrincipalID), nil
+}
+
+func TestAuthorizeDeployKey(t *testing.T) {
+	ctrl, deployKeyStore, _ := setupDeployKeyController()
+	repoStore := fake.NewRepoStore(
+		&types.Repository{ID: 1, Identifier: "repo", Path: "space/repo", GitUID: "git-uid"},
+		&types.Repository{ID: 2, Identifier: "other", Path: "space/other"},
+	)
+	ctrl.repoStore = repoStore
+	ctrl.authorizer = authz.NewMembershipAuthorizer(permissionCacheFake{principalIDs: []int64{5}}, nil, repoStore)
+	ctrl.deployKeyAuthn = authn.NewDeployKeyAuthenticator(deployKeyStore,
+		&principalStoreFake{users: []*types.User{{ID: 5, UID: "user"}, {ID: 6, UID: "former"}}})
+
+	readContent, readKey := generateDeployKey(t)
+	writeContent, writeKey := generateDeployKey(t)
+	otherContent, otherKey := generateDeployKey(t)
+	formerContent, formerKey := generateDeployKey(t)
+	deployKeyStore.keys = []*types.DeployKey{
+		{ID: 1, RepoID: 1, Fingerprint: ssh.FingerprintSHA256(readKey), CreatedBy: 5},
+		{ID: 2, RepoID: 1, Fingerprint: ssh.FingerprintSHA256(writeKey), CreatedBy: 5, Write: true},
+		{ID: 3, RepoID: 2, Fingerprint: ssh.FingerprintSHA256(otherKey), CreatedBy: 5},
+		{ID: 4, RepoID: 1, Fingerprint: ssh.FingerprintSHA256(formerKey), CreatedBy: 6},
+	}
+
+	frontend := &auth.Session{Principal: types.Principal{ID: 9, Admin: true}}
+	unknownContent, _ := generateDeployKey(t)
+
+	tests := []struct {
+		name    string
+		session *auth.Session
+		in      *AuthorizeDeployKeyInput
+		wantErr error
+	}{
+		{name: "read", session: frontend, in: &AuthorizeDeployKeyInput{Content: readContent}},
+		{name: "read with write key", session: frontend, in: &AuthorizeDeployKeyInput{Content: writeContent}},
+		{name: "write", session: frontend, in: &AuthorizeDeployKeyInput{Content: writeContent, Write: true}},
+		{
+			name:    "write with read key",
+			session: frontend,
+			in:      &AuthorizeDeployKeyInput{Content: readContent, Write: true},
+			wantErr: apiauth.ErrNotAuthorized,
+		},
+		{
+			name:    "key of other repo",
+			session: frontend,
+			in:      &AuthorizeDeployKeyInput{Content: otherContent},
+			wantErr: apiauth.ErrNotAuthorized,
+		},
+		{
+			name:    "creator without repo access",
+			session: frontend,
+			in:      &AuthorizeDeployKeyInput{Content: formerContent},
+			wantErr: apiauth.ErrNotAuthorized,
+		},
+		{
+			name:    "unknown key",
+			session: frontend,
+			in:      &AuthorizeDeployKeyInput{Content: unknownContent},
+			wantErr: usererror.ErrForbidden,
+		},
+		{
+			name:    "caller isn't admin",
+			session: &auth.Session{Principal: types.Principal{ID: 5}},
+			in:      &AuthorizeDeployKeyInput{Content: readContent},
+			wantErr: usererror.ErrForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := ctrl.AuthorizeDeployKey(context.Background(), test.session, "space/repo", test.in)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Errorf("Want error %v, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+			if out.RepoID != 1 || out.GitUID != "git-uid" {
+				t.Errorf("Want repo 1 with git uid %q, got %+v", "git-uid", out)
+			}
+		})
+	}
+
+	// revoked keys are no longer authorized.
+	if err := ctrl.DeleteDeployKey(context.Background(), frontend, "space/repo", 1); err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	_, err := ctrl.AuthorizeDeployKey(context.Background(), frontend, "space/repo",
+		&AuthorizeDeployKeyInput{Content: readContent})
+	if !errors.Is(err, usererror.ErrForbidden) {
+		t.Errorf("Want revoked key to be rejected with %v, got %v", usererror.ErrForbidden, err)
+	}
+}
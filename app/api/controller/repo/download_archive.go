@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/operation"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DownloadArchive returns an archive of the repository at the given git ref together with its filename.
+// If no gitRef is provided, the archive is created from the default branch.
+func (c *Controller) DownloadArchive(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+	format api.ArchiveFormat,
+) (io.ReadCloser, string, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// set gitRef to default branch in case an empty reference was provided
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	// resolve the ref before streaming to fail early for unknown refs.
+	commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: readParams,
+		Revision:   gitRef,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	name := repo.Identifier + "-" + strings.ReplaceAll(gitRef, "/", "-")
+
+	// the operation is registered after the ref is resolved, it's done once the archive is fully written.
+	ctx, done := c.operations.Start(ctx, enum.OperationTypeArchive, operation.RepoTarget(repo.ID))
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		defer done()
+
+		err := c.git.Archive(ctx, pipeWriter, &git.ArchiveParams{
+			ReadParams: readParams,
+			GitREF:     commit.Commit.SHA.String(),
+			Format:     format,
+			Prefix:     name,
+		})
+
+		// If creating the archive fails, make the pipe reader also fail with the same error.
+		_ = pipeWriter.CloseWithError(err)
+	}()
+
+	return pipeReader, name + format.Extension(), nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
+)
+
+func TestDownloadArchive(t *testing.T) {
+	tests := []struct {
+		format       api.ArchiveFormat
+		wantFilename string
+	}{
+		{format: api.ArchiveFormatZip, wantFilename: "repo-develop.zip"},
+		{format: api.ArchiveFormatTarGz, wantFilename: "repo-develop.tar.gz"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			repo := &types.Repository{ID: 1, Identifier: "repo", Path: "space/repo", DefaultBranch: "develop"}
+			c := setupGitFakeController(repo, nil)
+
+			reader, filename, err := c.DownloadArchive(context.Background(), &auth.Session{}, "space/repo", "", tt.format)
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+			defer reader.Close()
+
+			if filename != tt.wantFilename {
+				t.Errorf("Want filename %q, got %q", tt.wantFilename, filename)
+			}
+
+			content, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+			if len(content) == 0 {
+				t.Errorf("Want non-empty archive")
+			}
+		})
+	}
+}
+
+func TestDownloadArchive_UnknownRef(t *testing.T) {
+	repo := &types.Repository{ID: 1, Identifier: "repo", Path: "space/repo", DefaultBranch: "develop"}
+	c := setupGitFakeController(repo, nil)
+
+	_, _, err := c.DownloadArchive(context.Background(), &auth.Session{}, "space/repo", "unknown", api.ArchiveFormatZip)
+	if status := usererror.Translate(context.Background(), err).Status; status != http.StatusNotFound {
+		t.Errorf("Want status %d, got %d (%v)", http.StatusNotFound, status, err)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -35,8 +36,8 @@ func (c *Controller) Find(ctx context.Context, session *auth.Session, repoRef st
 		return nil, err
 	}
 
-	// backfill clone url
-	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	// backfill clone urls
+	controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
 
 	return repo, nil
 }
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+)
+
+func TestFind_CloneURLs(t *testing.T) {
+	tests := []struct {
+		name        string
+		session     *auth.Session
+		sshFrontend bool
+		wantSSH     string
+	}{
+		{
+			name:        "ssh-frontend",
+			session:     &auth.Session{Principal: types.Principal{ID: 1}},
+			sshFrontend: true,
+			wantSSH:     "ssh://git@localhost/space/repo.git",
+		},
+		{
+			name:        "no-ssh-frontend",
+			session:     &auth.Session{Principal: types.Principal{ID: 1}},
+			sshFrontend: false,
+			wantSSH:     "",
+		},
+		{
+			name:        "anonymous",
+			session:     nil,
+			sshFrontend: true,
+			wantSSH:     "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{
+				authorizer:  &fake.Authorizer{},
+				repoStore:   fake.NewRepoStore(&types.Repository{ID: 1, Path: "space/repo", IsPublic: true}),
+				urlProvider: urlProviderFake{sshFrontend: test.sshFrontend},
+			}
+
+			repo, err := c.Find(context.Background(), test.session, "space/repo")
+			if err != nil {
+				t.Fatalf("failed to find repo: %s", err)
+			}
+
+			if want := "http://localhost/git/space/repo.git"; repo.GitURL != want {
+				t.Errorf("Want clone url %q, got %q", want, repo.GitURL)
+			}
+			if repo.GitSSHURL != test.wantSSH {
+				t.Errorf("Want ssh clone url %q, got %q", test.wantSSH, repo.GitSSHURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ForkInput is used for forking a repo.
+type ForkInput struct {
+	// ParentRef is the space the fork is created in.
+	ParentRef string `json:"parent_ref"`
+	// Identifier is the identifier of the fork (optional, default: identifier of the forked repository).
+	Identifier string `json:"identifier"`
+	IsPublic   bool   `json:"is_public"`
+}
+
+// Fork creates a copy of the repository in the provided space, including all branches and tags.
+// The webhooks, collaborators and protection rules of the forked repository aren't copied.
+func (c *Controller) Fork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ForkInput,
+) (*types.Repository, error) {
+	source, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeForkInput(in, source); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	// a public fork exposes the content of a private repository, which only its editors are allowed to do.
+	if in.IsPublic && !source.IsPublic {
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, source, enum.PermissionRepoEdit, false); err != nil {
+			return nil, fmt.Errorf("failed to authorize public fork of private repository: %w", err)
+		}
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.checkRepoIdentifierAvailable(ctx, parentSpace, in.Identifier); err != nil {
+		return nil, err
+	}
+
+	err = c.repoCheck.Create(ctx, session, &CreateInput{
+		ParentRef:     in.ParentRef,
+		Identifier:    in.Identifier,
+		DefaultBranch: source.DefaultBranch,
+		Description:   source.Description,
+		IsPublic:      in.IsPublic,
+		ForkID:        source.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var repo *types.Repository
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		// lock the space for update during repo creation to prevent racing conditions with space soft delete.
+		parentSpace, err = c.spaceStore.FindForUpdate(ctx, parentSpace.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find the parent space: %w", err)
+		}
+
+		gitUID, err := c.forkGitRepository(ctx, session, source)
+		if err != nil {
+			return fmt.Errorf("error creating repository on git: %w", err)
+		}
+
+		now := time.Now().UnixMilli()
+		repo = &types.Repository{
+			Version:       0,
+			ParentID:      parentSpace.ID,
+			Identifier:    in.Identifier,
+			GitUID:        gitUID,
+			Description:   source.Description,
+			IsPublic:      in.IsPublic,
+			CreatedBy:     session.Principal.ID,
+			Created:       now,
+			Updated:       now,
+			ForkID:        source.ID,
+			DefaultBranch: source.DefaultBranch,
+			IsEmpty:       source.IsEmpty,
+		}
+
+		err = c.repoStore.Create(ctx, repo)
+		if err != nil {
+			if dErr := c.DeleteGitRepository(ctx, session, repo); dErr != nil {
+				log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete repo for cleanup")
+			}
+			return fmt.Errorf("failed to create repository in storage: %w", err)
+		}
+
+		_, err = c.repoStore.UpdateOptLock(ctx, source, func(r *types.Repository) error {
+			r.NumForks++
+			return nil
+		})
+		if err != nil {
+			if dErr := c.DeleteGitRepository(ctx, session, repo); dErr != nil {
+				log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete repo for cleanup")
+			}
+			return fmt.Errorf("failed to update fork count of forked repository: %w", err)
+		}
+
+		return nil
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(repo),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for fork repository operation: %s", err)
+	}
+
+	// backfill clone urls
+	controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
+
+	if !repo.IsEmpty {
+		err = c.indexer.Index(ctx, repo)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repo")
+		}
+	}
+
+	return repo, nil
+}
+
+// forkGitRepository creates a new git repository containing all branches and tags of the forked repository.
+func (c *Controller) forkGitRepository(
+	ctx context.Context,
+	session *auth.Session,
+	source *types.Repository,
+) (string, error) {
+	// generate envars (add everything githook CLI needs for execution)
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(),
+		0,
+		session.Principal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	actor := identityFromPrincipal(session.Principal)
+	resp, err := c.git.CreateRepository(ctx, &git.CreateRepositoryParams{
+		Actor:         *actor,
+		EnvVars:       envVars,
+		DefaultBranch: source.DefaultBranch,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create repo: %w", err)
+	}
+
+	if source.IsEmpty {
+		return resp.UID, nil
+	}
+
+	_, err = c.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams: git.WriteParams{
+			RepoUID: resp.UID,
+			Actor:   *actor,
+			EnvVars: envVars,
+		},
+		SourceRepoUID: source.GitUID,
+		RefSpecs:      []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+	})
+	if err != nil {
+		if dErr := c.DeleteGitRepository(ctx, session, &types.Repository{GitUID: resp.UID}); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete repo for cleanup")
+		}
+		return "", fmt.Errorf("failed to copy git objects of forked repository: %w", err)
+	}
+
+	return resp.UID, nil
+}
+
+// checkRepoIdentifierAvailable returns a conflict error if the space already contains a repo with the identifier.
+func (c *Controller) checkRepoIdentifierAvailable(
+	ctx context.Context,
+	space *types.Space,
+	identifier string,
+) error {
+	existing, err := c.repoStore.FindByRef(ctx, paths.Concatenate(space.Path, identifier))
+	// the path could resolve through the redirect of a renamed repository, which doesn't block the identifier.
+	if err == nil && strings.EqualFold(existing.Identifier, identifier) {
+		return usererror.Conflict(fmt.Sprintf("A repository with identifier %q already exists in space %q.",
+			identifier, space.Path))
+	}
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find repository by path: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) sanitizeForkInput(in *ForkInput, source *types.Repository) error {
+	if in.Identifier == "" {
+		in.Identifier = source.Identifier
+	}
+
+	if in.IsPublic && !c.publicResourceCreationEnabled {
+		return errPublicRepoCreationDisabled
+	}
+
+	if err := c.validateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	return c.identifierCheck(in.Identifier)
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+func setupForkController(repos ...*types.Repository) (*Controller, *reposStoreFake, *gitFake) {
+	repoStore := &reposStoreFake{fake.NewRepoStore(repos...)}
+	gitFake := &gitFake{branch: "develop"}
+	ctrl := &Controller{
+		tx:              txFake{},
+		urlProvider:     urlProviderFake{},
+		authorizer:      &fake.Authorizer{},
+		repoStore:       repoStore,
+		spaceStore:      &spaceStoreFake{space: &types.Space{ID: 2, Path: "space2"}},
+		git:             gitFake,
+		indexer:         indexerFake{},
+		resourceLimiter: limiter.NewResourceLimiter(),
+		auditService:    auditServiceFake{},
+		identifierCheck: check.RepoIdentifierDefault,
+		repoCheck:       NewNoOpRepoChecks(),
+	}
+
+	return ctrl, repoStore, gitFake
+}
+
+func TestFork(t *testing.T) {
+	source := &types.Repository{
+		ID:            1,
+		ParentID:      1,
+		Identifier:    "repo",
+		Path:          "space1/repo",
+		GitUID:        "source-git-uid",
+		Description:   "source description",
+		DefaultBranch: "develop",
+	}
+	c, repoStore, gitFake := setupForkController(source)
+	session := &auth.Session{Principal: types.Principal{ID: 42}}
+
+	fork, err := c.Fork(context.Background(), session, "space1/repo", &ForkInput{ParentRef: "space2"})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if fork.ForkID != source.ID {
+		t.Errorf("Want fork id %d, got %d", source.ID, fork.ForkID)
+	}
+	if fork.Path != "space2/repo" {
+		t.Errorf("Want path %q, got %q", "space2/repo", fork.Path)
+	}
+	if fork.DefaultBranch != source.DefaultBranch || fork.Description != source.Description {
+		t.Errorf("Want default branch and description of source, got %q and %q",
+			fork.DefaultBranch, fork.Description)
+	}
+	if fork.CreatedBy != session.Principal.ID {
+		t.Errorf("Want fork created by %d, got %d", session.Principal.ID, fork.CreatedBy)
+	}
+
+	// the git objects are copied from the source repository.
+	if len(gitFake.syncs) != 1 {
+		t.Fatalf("Want 1 git sync, got %d", len(gitFake.syncs))
+	}
+	if got := gitFake.syncs[0]; got.SourceRepoUID != source.GitUID || got.RepoUID != fork.GitUID {
+		t.Errorf("Want sync from %q to %q, got sync from %q to %q",
+			source.GitUID, fork.GitUID, got.SourceRepoUID, got.RepoUID)
+	}
+
+	updatedSource, _ := repoStore.FindByRef(context.Background(), "space1/repo")
+	if updatedSource.NumForks != 1 {
+		t.Errorf("Want source to have 1 fork, got %d", updatedSource.NumForks)
+	}
+}
+
+func TestFork_Empty(t *testing.T) {
+	source := &types.Repository{ID: 1, Identifier: "repo", Path: "space1/repo", DefaultBranch: "main", IsEmpty: true}
+	c, _, gitFake := setupForkController(source)
+
+	fork, err := c.Fork(context.Background(), &auth.Session{}, "space1/repo",
+		&ForkInput{ParentRef: "space2", Identifier: "my-fork"})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if !fork.IsEmpty || fork.Identifier != "my-fork" {
+		t.Errorf("Want empty fork %q, got empty=%t %q", "my-fork", fork.IsEmpty, fork.Identifier)
+	}
+	if len(gitFake.syncs) != 0 {
+		t.Errorf("Want no git sync for empty repository, got %d", len(gitFake.syncs))
+	}
+}
+
+func TestFork_IdentifierCollision(t *testing.T) {
+	source := &types.Repository{ID: 1, Identifier: "repo", Path: "space1/repo", GitUID: "source-git-uid"}
+	existing := &types.Repository{ID: 2, Identifier: "repo", Path: "space2/repo"}
+	c, repoStore, gitFake := setupForkController(source, existing)
+
+	_, err := c.Fork(context.Background(), &auth.Session{}, "space1/repo", &ForkInput{ParentRef: "space2"})
+
+	var uErr *usererror.Error
+	if !errors.As(err, &uErr) || uErr.Status != 409 {
+		t.Fatalf("Want conflict error, got %v", err)
+	}
+	if len(repoStore.Repos) != 2 || len(gitFake.syncs) != 0 {
+		t.Errorf("Want no repository to be created")
+	}
+}
+
+// denyCreateRepo only permits viewing existing repositories.
+func denyCreateRepo(_ *types.Principal, _ *types.Scope, resource *types.Resource, permission enum.Permission) bool {
+	// repositories are created with the edit permission on the space.
+	return resource.Identifier != "" && permission == enum.PermissionRepoEdit
+}
+
+func TestFork_PublicForkOfPrivateRepo(t *testing.T) {
+	tests := []struct {
+		name       string
+		authorizer authz.Authorizer
+		isPublic   bool
+		wantStatus int
+	}{
+		{name: "private fork by viewer", authorizer: &fake.Authorizer{Deny: denyCreateRepo}, isPublic: false},
+		{name: "public fork by viewer", authorizer: &fake.Authorizer{Deny: denyCreateRepo}, isPublic: true,
+			wantStatus: http.StatusForbidden},
+		{name: "public fork by editor", authorizer: &fake.Authorizer{}, isPublic: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := &types.Repository{ID: 1, Identifier: "repo", Path: "space1/repo", GitUID: "source-git-uid"}
+			c, repoStore, _ := setupForkController(source)
+			c.authorizer = test.authorizer
+			c.publicResourceCreationEnabled = true
+
+			fork, err := c.Fork(context.Background(), &auth.Session{}, "space1/repo",
+				&ForkInput{ParentRef: "space2", IsPublic: test.isPublic})
+
+			if test.wantStatus != 0 {
+				if status := usererror.Translate(context.Background(), err).Status; status != test.wantStatus {
+					t.Errorf("Want status %d, got %d (%v)", test.wantStatus, status, err)
+				}
+				if len(repoStore.Repos) != 1 {
+					t.Errorf("Want no repository to be created")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+			if fork.IsPublic != test.isPublic {
+				t.Errorf("Want public %t, got %t", test.isPublic, fork.IsPublic)
+			}
+		})
+	}
+}
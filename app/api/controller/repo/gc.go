@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// GarbageCollect starts the git garbage collection of a repo in the background.
+func (c *Controller) GarbageCollect(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepositoryGCStatus, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := c.garbageCollector.Run(ctx, repo.ID, repo.GitUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start garbage collection: %w", err)
+	}
+
+	return &status, nil
+}
+
+// GarbageCollectStatus returns the status of the latest git garbage collection of a repo.
+func (c *Controller) GarbageCollectStatus(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepositoryGCStatus, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	status, ok := c.garbageCollector.Status(repo.ID)
+	if !ok {
+		return nil, usererror.NotFound("No garbage collection found for the repository.")
+	}
+
+	return &status, nil
+}
+
+type GarbageCollectAllOutput struct {
+	// Repos is the number of repos that are going to be garbage collected.
+	Repos int `json:"repos"`
+}
+
+// GarbageCollectAll starts the git garbage collection of all repos in the background.
+// NOTE: Access is restricted to admins by the router.
+func (c *Controller) GarbageCollectAll(ctx context.Context) (*GarbageCollectAllOutput, error) {
+	count, err := c.garbageCollector.RunAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start garbage collection: %w", err)
+	}
+
+	return &GarbageCollectAllOutput{Repos: count}, nil
+}
+
+// GarbageCollectStatuses returns the status of the latest git garbage collection of all repos.
+// NOTE: Access is restricted to admins by the router.
+func (c *Controller) GarbageCollectStatuses() []types.RepositoryGCStatus {
+	return c.garbageCollector.Statuses()
+}
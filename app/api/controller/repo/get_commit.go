@@ -50,5 +50,10 @@ func (c *Controller) GetCommit(ctx context.Context,
 		return nil, fmt.Errorf("failed to map commit: %w", err)
 	}
 
-	return commit, nil
+	commits := []types.Commit{*commit}
+	if err = c.fillCommitVerifications(ctx, repo, commits); err != nil {
+		return nil, err
+	}
+
+	return &commits[0], nil
 }
@@ -20,6 +20,7 @@ import (
 	"io"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types/enum"
@@ -48,6 +49,11 @@ func (c *Controller) GitServicePack(
 		return fmt.Errorf("failed to verify repo access: %w", err)
 	}
 
+	// archived repos can still be cloned and fetched, but not pushed to.
+	if isWriteOperation && repo.Archived {
+		return usererror.ErrRepositoryArchived
+	}
+
 	params := &git.ServicePackParams{
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
 		Service:     string(service),
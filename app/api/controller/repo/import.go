@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
@@ -97,7 +98,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		return nil, err
 	}
 
-	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
 
 	err = c.auditService.Log(ctx,
 		session.Principal,
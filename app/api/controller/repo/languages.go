@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Languages returns the breakdown of the files on the default branch of a repo by language.
+func (c *Controller) Languages(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepositoryLanguages, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.IsEmpty {
+		return &types.RepositoryLanguages{Languages: []types.RepositoryLanguage{}}, nil
+	}
+
+	commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   repo.DefaultBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default branch commit: %w", err)
+	}
+
+	languages, err := c.languageAnalyzer.Analyze(ctx, repo.GitUID, commit.Commit.SHA.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze repository languages: %w", err)
+	}
+
+	return languages, nil
+}
@@ -26,16 +26,26 @@ import (
 )
 
 type Branch struct {
-	Name   string        `json:"name"`
-	SHA    string        `json:"sha"`
-	Commit *types.Commit `json:"commit,omitempty"`
+	Name       string            `json:"name"`
+	SHA        string            `json:"sha"`
+	Commit     *types.Commit     `json:"commit,omitempty"`
+	Divergence *BranchDivergence `json:"divergence,omitempty"`
+}
+
+// BranchDivergence contains the divergence of a branch from the default branch of the repo.
+type BranchDivergence struct {
+	CommitDivergence
+	// Merged is true in case the branch doesn't contain any commits that aren't part of the default branch.
+	Merged bool `json:"merged"`
 }
 
 // ListBranches lists the branches of a repo.
+// If includeDivergence is set, each branch (except the default branch) contains its divergence from the default branch.
 func (c *Controller) ListBranches(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	includeCommit bool,
+	includeDivergence bool,
 	filter *types.BranchFilter,
 ) ([]Branch, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
@@ -64,9 +74,60 @@ func (c *Controller) ListBranches(ctx context.Context,
 		}
 	}
 
+	if includeDivergence {
+		if err = c.fillBranchDivergences(ctx, repo, branches); err != nil {
+			return nil, err
+		}
+	}
+
 	return branches, nil
 }
 
+// fillBranchDivergences computes the divergence of all provided branches from the default branch
+// of the repo using a single git call.
+func (c *Controller) fillBranchDivergences(ctx context.Context, repo *types.Repository, branches []Branch) error {
+	requests := make([]git.CommitDivergenceRequest, 0, len(branches))
+	indices := make([]int, 0, len(branches))
+	for i := range branches {
+		if branches[i].Name == repo.DefaultBranch {
+			continue
+		}
+		requests = append(requests, git.CommitDivergenceRequest{
+			From: branches[i].Name,
+			To:   repo.DefaultBranch,
+		})
+		indices = append(indices, i)
+	}
+
+	if len(requests) == 0 {
+		return nil
+	}
+
+	rpcOut, err := c.git.GetCommitDivergences(ctx, &git.GetCommitDivergencesParams{
+		ReadParams: git.CreateReadParams(repo),
+		Requests:   requests,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get divergences from default branch: %w", err)
+	}
+
+	if len(rpcOut.Divergences) != len(requests) {
+		return fmt.Errorf("expected %d divergences, got %d", len(requests), len(rpcOut.Divergences))
+	}
+
+	for i, div := range rpcOut.Divergences {
+		branches[indices[i]].Divergence = &BranchDivergence{
+			CommitDivergence: CommitDivergence{
+				Ahead:  div.Ahead,
+				Behind: div.Behind,
+			},
+			Merged: div.Ahead == 0,
+		}
+	}
+
+	return nil
+}
+
 func mapToRPCBranchSortOption(o enum.BranchSortOption) git.BranchSortOption {
 	switch o {
 	case enum.BranchSortOptionDate:
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+)
+
+func TestListBranches_Divergence(t *testing.T) {
+	c := &Controller{
+		authorizer: &fake.Authorizer{},
+		repoStore:  fake.NewRepoStore(&types.Repository{ID: 1, Path: "space/repo", DefaultBranch: "main"}),
+		git: &gitFake{
+			branches: []git.Branch{
+				{Name: "feature", SHA: sha.Must(templateMainSHA)},
+				{Name: "main", SHA: sha.Must(templateReadmeSHA)},
+				{Name: "stale", SHA: sha.Must(templateReadmeSHA)},
+			},
+			divergences: map[string]api.CommitDivergence{
+				"feature...main": {Ahead: 2, Behind: 1},
+				"stale...main":   {Ahead: 0, Behind: 3},
+			},
+		},
+	}
+
+	branches, err := c.ListBranches(context.Background(), &auth.Session{}, "space/repo",
+		false, true, &types.BranchFilter{})
+	if err != nil {
+		t.Fatalf("failed to list branches: %s", err)
+	}
+
+	want := map[string]*BranchDivergence{
+		"feature": {CommitDivergence: CommitDivergence{Ahead: 2, Behind: 1}, Merged: false},
+		"main":    nil,
+		"stale":   {CommitDivergence: CommitDivergence{Ahead: 0, Behind: 3}, Merged: true},
+	}
+	if len(branches) != len(want) {
+		t.Fatalf("Want %d branches, got %d", len(want), len(branches))
+	}
+	for _, branch := range branches {
+		wantDiv := want[branch.Name]
+		if wantDiv == nil || branch.Divergence == nil {
+			if wantDiv != branch.Divergence {
+				t.Errorf("Want divergence of %q to be %+v, got %+v", branch.Name, wantDiv, branch.Divergence)
+			}
+			continue
+		}
+		if *wantDiv != *branch.Divergence {
+			t.Errorf("Want divergence of %q to be %+v, got %+v", branch.Name, *wantDiv, *branch.Divergence)
+		}
+	}
+}
+
+func TestListBranches_NoDivergence(t *testing.T) {
+	c := &Controller{
+		authorizer: &fake.Authorizer{},
+		repoStore:  fake.NewRepoStore(&types.Repository{ID: 1, Path: "space/repo", DefaultBranch: "main"}),
+		// no divergences configured - the fake fails if they are requested.
+		git: &gitFake{
+			branches: []git.Branch{{Name: "feature", SHA: sha.Must(templateMainSHA)}},
+		},
+	}
+
+	branches, err := c.ListBranches(context.Background(), &auth.Session{}, "space/repo",
+		false, false, &types.BranchFilter{})
+	if err != nil {
+		t.Fatalf("failed to list branches: %s", err)
+	}
+	if len(branches) != 1 || branches[0].Divergence != nil {
+		t.Errorf("Want a single branch without divergence, got %+v", branches)
+	}
+}
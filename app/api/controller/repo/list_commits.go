@@ -17,6 +17,7 @@ package repo
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
@@ -52,6 +53,7 @@ func (c *Controller) ListCommits(ctx context.Context,
 		Since:        filter.Since,
 		Until:        filter.Until,
 		Committer:    filter.Committer,
+		Author:       filter.Author,
 		IncludeStats: filter.IncludeStats,
 	})
 	if err != nil {
@@ -68,6 +70,14 @@ func (c *Controller) ListCommits(ctx context.Context,
 		commits[i] = *commit
 	}
 
+	if err = c.fillCommitAuthors(ctx, commits); err != nil {
+		return types.ListCommitResponse{}, err
+	}
+
+	if err = c.fillCommitVerifications(ctx, repo, commits); err != nil {
+		return types.ListCommitResponse{}, err
+	}
+
 	renameDetailList := make([]types.RenameDetails, len(rpcOut.RenameDetails))
 	for i := range rpcOut.RenameDetails {
 		renameDetails := controller.MapRenameDetails(rpcOut.RenameDetails[i])
@@ -82,3 +92,45 @@ func (c *Controller) ListCommits(ctx context.Context,
 		TotalCommits:  rpcOut.TotalCommits,
 	}, nil
 }
+
+// fillCommitAuthors attributes the commits to the users owning the author emails.
+// Primary emails and verified secondary emails of users are taken into account.
+func (c *Controller) fillCommitAuthors(ctx context.Context, commits []types.Commit) error {
+	if len(commits) == 0 {
+		return nil
+	}
+
+	emails := make([]string, 0, len(commits))
+	for i := range commits {
+		emails = append(emails, commits[i].Author.Identity.Email)
+	}
+
+	principalIDs, err := c.userEmailStore.MapPrincipalIDs(ctx, emails)
+	if err != nil {
+		return fmt.Errorf("failed to map commit author emails to users: %w", err)
+	}
+
+	if len(principalIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(principalIDs))
+	for _, id := range principalIDs {
+		ids = append(ids, id)
+	}
+
+	principals, err := c.principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to fetch commit author info from cache: %w", err)
+	}
+
+	for i := range commits {
+		id, ok := principalIDs[strings.ToLower(commits[i].Author.Identity.Email)]
+		if !ok {
+			continue
+		}
+		commits[i].Author.Principal = principals[id]
+	}
+
+	return nil
+}
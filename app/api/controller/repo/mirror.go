@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CreateMirrorInput struct {
+	ParentRef   string `json:"parent_ref"`
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+
+	// RemoteURL is the http(s) URL of the mirrored git repository.
+	RemoteURL string `json:"remote_url"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+}
+
+// CreateMirror creates a new repository by cloning the remote repository. The repository is kept in sync
+// with the remote repository by a periodic job and can't be pushed to.
+func (c *Controller) CreateMirror(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateMirrorInput,
+) (*types.Repository, error) {
+	if err := c.sanitizeCreateMirrorInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := mirror.Credentials{Username: in.Username, Password: in.Password}
+
+	gitUID, defaultBranch, err := c.mirror.Clone(ctx, &session.Principal, in.RemoteURL, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	repo := &types.Repository{
+		Version:       0,
+		Identifier:    in.Identifier,
+		GitUID:        gitUID,
+		Description:   in.Description,
+		IsPublic:      in.IsPublic,
+		CreatedBy:     session.Principal.ID,
+		Created:       now,
+		Updated:       now,
+		DefaultBranch: defaultBranch,
+		IsEmpty:       defaultBranch == "",
+	}
+
+	if repo.DefaultBranch == "" {
+		repo.DefaultBranch = c.spaceDefaultBranch(parentSpace)
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.resourceLimiter.RepoCount(ctx, parentSpace.ID, 1); err != nil {
+			return fmt.Errorf("resource limit exceeded: %w", limiter.ErrMaxNumReposReached)
+		}
+
+		// lock the space for update during repo creation to prevent racing conditions with space soft delete.
+		parentSpace, err = c.spaceStore.FindForUpdate(ctx, parentSpace.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find the parent space: %w", err)
+		}
+
+		repo.ParentID = parentSpace.ID
+
+		if err = c.repoStore.Create(ctx, repo); err != nil {
+			return fmt.Errorf("failed to create repository in storage: %w", err)
+		}
+
+		_, err = c.mirror.Create(ctx, repo.ID, session.Principal.ID, in.RemoteURL, credentials)
+
+		return err
+	}, sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		if dErr := c.DeleteGitRepository(ctx, session, repo); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete repo for cleanup")
+		}
+		return nil, err
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeRepository, repo.Identifier),
+		audit.ActionCreated,
+		paths.Parent(repo.Path),
+		audit.WithNewObject(repo),
+	)
+	if err != nil {
+		log.Ctx(ctx).Warn().Msgf("failed to insert audit log for create mirror repository operation: %s", err)
+	}
+
+	controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
+
+	if !repo.IsEmpty {
+		if err = c.indexer.Index(ctx, repo); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repo")
+		}
+	}
+
+	return repo, nil
+}
+
+// MirrorFind returns the mirror configuration and sync state of a mirrored repository.
+func (c *Controller) MirrorFind(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepositoryMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.mirror.Find(ctx, repo.ID)
+}
+
+// MirrorSync fetches the updates of the remote repository into a mirrored repository.
+func (c *Controller) MirrorSync(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepositoryMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.mirror.Sync(ctx, &session.Principal, repo)
+}
+
+func (c *Controller) sanitizeCreateMirrorInput(in *CreateMirrorInput) error {
+	if in.IsPublic && !c.publicResourceCreationEnabled {
+		return errPublicRepoCreationDisabled
+	}
+
+	if err := c.validateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if err := c.identifierCheck(in.Identifier); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	in.RemoteURL = strings.TrimSpace(in.RemoteURL)
+	remoteURL, err := url.Parse(in.RemoteURL)
+	if err != nil || remoteURL.Host == "" || (remoteURL.Scheme != "http" && remoteURL.Scheme != "https") {
+		return usererror.BadRequest("Remote URL must be a valid http or https URL.")
+	}
+
+	if remoteURL.User != nil {
+		return usererror.BadRequest("Remote URL must not contain credentials, provide username and password instead.")
+	}
+
+	return nil
+}
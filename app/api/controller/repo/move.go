@@ -16,15 +16,23 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
+// movePathsLockExpiry is the expiry of the lock held on the parent space paths while moving.
+const movePathsLockExpiry = 30 * time.Second
+
 // MoveInput is used for moving a repo.
 type MoveInput struct {
 	// TODO [CODE-1363]: remove after identifier migration.
@@ -42,8 +50,6 @@ func (i *MoveInput) hasChanges(repo *types.Repository) bool {
 
 // Move moves a repository to a new identifier.
 // TODO: Add support for moving to other parents and aliases.
-//
-//nolint:gocognit // refactor if needed
 func (c *Controller) Move(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
@@ -70,17 +76,12 @@ func (c *Controller) Move(ctx context.Context,
 		return repo, nil
 	}
 
-	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
-		if in.Identifier != nil {
-			r.Identifier = *in.Identifier
-		}
-		return nil
-	})
+	repo, err = c.updateIdentifier(ctx, repo, *in.Identifier)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update repo: %w", err)
+		return nil, err
 	}
 
-	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
 
 	return repo, nil
 }
@@ -99,3 +100,62 @@ func (c *Controller) sanitizeMoveInput(in *MoveInput) error {
 
 	return nil
 }
+
+// updateIdentifier changes the identifier of the repository and keeps the old path resolving for a grace period.
+func (c *Controller) updateIdentifier(
+	ctx context.Context,
+	repo *types.Repository,
+	identifier string,
+) (*types.Repository, error) {
+	// serialize path changes within the parent space to avoid concurrent renames colliding.
+	unlock, err := c.locker.LockSpacePaths(ctx, repo.ParentID, movePathsLockExpiry)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	oldIdentifier := repo.Identifier
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+			r.Identifier = identifier
+			return nil
+		})
+		if errors.Is(err, gitness_store.ErrDuplicate) {
+			return usererror.Conflict(fmt.Sprintf("A repository with identifier %q already exists in the space.",
+				identifier))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update repo: %w", err)
+		}
+
+		// the new identifier takes precedence over any previous path that pointed somewhere else.
+		if err = c.repoRedirectStore.Delete(ctx, repo.ParentID, identifier); err != nil {
+			return fmt.Errorf("failed to delete repo redirect: %w", err)
+		}
+
+		// a change of case only doesn't need a redirect as paths are resolved case-insensitively.
+		if strings.EqualFold(oldIdentifier, identifier) {
+			return nil
+		}
+
+		now := time.Now()
+		err = c.repoRedirectStore.Upsert(ctx, &types.RepoRedirect{
+			SpaceID:    repo.ParentID,
+			Identifier: oldIdentifier,
+			RepoID:     repo.ID,
+			Created:    now.UnixMilli(),
+			Expires:    now.Add(c.renameRedirectTTL).UnixMilli(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create repo redirect: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
@@ -112,3 +113,12 @@ func (c *Controller) DeleteGitRepository(
 	}
 	return nil
 }
+
+// systemRepoPurger purges deleted repositories on behalf of the system service principal.
+type systemRepoPurger struct {
+	ctrl *Controller
+}
+
+func (p systemRepoPurger) Purge(ctx context.Context, repo *types.Repository) error {
+	return p.ctrl.PurgeNoAuth(ctx, bootstrap.NewSystemServiceSession(), repo)
+}
@@ -15,9 +15,14 @@
 package repo
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
@@ -25,17 +30,40 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
-// Raw finds the file of the repo at the given path and returns its raw content.
+// sniffLen is the number of bytes used to detect the content type of a file.
+const sniffLen = 512
+
+// rawContentTypePlain is served instead of content types browsers would render or execute.
+const rawContentTypePlain = "text/plain; charset=utf-8"
+
+// rawActiveContentTypes are the media types that browsers render as documents or execute as scripts.
+// Files of these types are served as plain text to prevent stored XSS on the API origin.
+var rawActiveContentTypes = map[string]struct{}{
+	"text/html":                {},
+	"application/xhtml+xml":    {},
+	"image/svg+xml":            {},
+	"text/xml":                 {},
+	"application/xml":          {},
+	"text/xsl":                 {},
+	"text/javascript":          {},
+	"application/javascript":   {},
+	"application/x-javascript": {},
+	"text/ecmascript":          {},
+	"application/ecmascript":   {},
+}
+
+// Raw finds the file of the repo at the given path and returns its raw content,
+// content size and guessed content type.
 // If no gitRef is provided, the content is retrieved from the default branch.
 func (c *Controller) Raw(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	gitRef string,
 	path string,
-) (io.ReadCloser, int64, error) {
+) (io.ReadCloser, int64, string, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
 
 	// set gitRef to default branch in case an empty reference was provided
@@ -52,12 +80,12 @@ func (c *Controller) Raw(ctx context.Context,
 		IncludeLatestCommit: false,
 	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to read tree node: %w", err)
+		return nil, 0, "", fmt.Errorf("failed to read tree node: %w", err)
 	}
 
 	// viewing Raw content is only supported for blob content
 	if treeNodeOutput.Node.Type != git.TreeNodeTypeBlob {
-		return nil, 0, usererror.BadRequestf(
+		return nil, 0, "", usererror.BadRequestf(
 			"Object in '%s' at '/%s' is of type '%s'. Only objects of type %s support raw viewing.",
 			gitRef, path, treeNodeOutput.Node.Type, git.TreeNodeTypeBlob)
 	}
@@ -68,8 +96,43 @@ func (c *Controller) Raw(ctx context.Context,
 		SizeLimit:  0, // no size limit, we stream whatever data there is
 	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to read blob: %w", err)
+		return nil, 0, "", fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	content, contentType := guessContentType(path, blobReader.Content)
+
+	return content, blobReader.ContentSize, contentType, nil
+}
+
+// guessContentType guesses the content type of a file based on its extension,
+// falling back to sniffing the beginning of its content.
+// Content types that browsers would render or execute are downgraded to plain text.
+// The returned reader has to be used instead of the provided one, as it includes the sniffed bytes.
+func guessContentType(path string, content io.ReadCloser) (io.ReadCloser, string) {
+	if contentType := mime.TypeByExtension(filepath.Ext(path)); contentType != "" {
+		return content, safeRawContentType(contentType)
+	}
+
+	buffered := bufio.NewReaderSize(content, sniffLen)
+	// errors (including EOF for small files) are surfaced again when reading the content.
+	head, _ := buffered.Peek(sniffLen)
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{buffered, content}, safeRawContentType(http.DetectContentType(head))
+}
+
+// safeRawContentType returns plain text for all html, xml and javascript content types.
+func safeRawContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return rawContentTypePlain
+	}
+
+	if _, ok := rawActiveContentTypes[mediaType]; ok || strings.HasSuffix(mediaType, "+xml") {
+		return rawContentTypePlain
 	}
 
-	return blobReader.Content, blobReader.ContentSize, nil
+	return contentType
 }
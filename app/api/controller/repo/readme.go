@@ -0,0 +1,222 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	gmparser "github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// readmeFileNames lists the supported README file names in the order of preference.
+// Names are matched case-insensitively.
+var readmeFileNames = []string{
+	"README.md",
+	"README.markdown",
+	"README.rst",
+	"README.txt",
+	"README",
+}
+
+type ReadmeOutput struct {
+	Path   string `json:"path"`
+	SHA    string `json:"sha"`
+	HTML   string `json:"html"`
+	Source string `json:"source"`
+}
+
+// Readme finds the README file in the given directory of the repo and returns its source
+// together with its sanitized HTML rendering.
+// If no gitRef is provided, the README is retrieved from the default branch.
+func (c *Controller) Readme(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+	dirPath string,
+) (*ReadmeOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// set gitRef to default branch in case an empty reference was provided
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	nodes, err := c.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       dirPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tree nodes: %w", err)
+	}
+
+	node, ok := findReadme(nodes.Nodes)
+	if !ok {
+		return nil, usererror.NotFound("README not found")
+	}
+
+	output, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.SHA,
+		SizeLimit:  maxGetContentFileSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get README content: %w", err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	if output.Size > maxGetContentFileSize {
+		return nil, errFileTooLarge
+	}
+
+	source, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read README content: %w", err)
+	}
+
+	rewriter := &readmeLinkRewriter{
+		generateURL: c.urlProvider.GenerateAPIRepoURL,
+		repoPath:    repo.Path,
+		gitRef:      gitRef,
+		dir:         path.Dir(node.Path),
+	}
+
+	renderedHTML, err := renderReadme(node.Name, source, rewriter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render README: %w", err)
+	}
+
+	return &ReadmeOutput{
+		Path:   node.Path,
+		SHA:    node.SHA,
+		HTML:   renderedHTML,
+		Source: string(source),
+	}, nil
+}
+
+// findReadme returns the preferred README file among the provided tree nodes.
+func findReadme(nodes []git.TreeNode) (git.TreeNode, bool) {
+	for _, name := range readmeFileNames {
+		for _, node := range nodes {
+			if node.Type == git.TreeNodeTypeBlob && strings.EqualFold(node.Name, name) {
+				return node, true
+			}
+		}
+	}
+
+	return git.TreeNode{}, false
+}
+
+// renderReadme renders markdown READMEs to HTML, all other READMEs are returned as preformatted text.
+// Raw HTML and dangerous links in markdown aren't rendered.
+func renderReadme(name string, source []byte, rewriter *readmeLinkRewriter) (string, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown":
+	default:
+		return "<pre>" + html.EscapeString(string(source)) + "</pre>", nil
+	}
+
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(
+			gmparser.WithAutoHeadingID(),
+			gmparser.WithASTTransformers(util.Prioritized(rewriter, 100)),
+		),
+	)
+
+	buf := &bytes.Buffer{}
+	if err := md.Convert(source, buf); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// readmeLinkRewriter rewrites relative link and image destinations of a markdown document
+// to API urls of the referenced repository content.
+type readmeLinkRewriter struct {
+	generateURL func(repoPath string, subPath string) string
+	repoPath    string
+	gitRef      string
+	dir         string
+}
+
+var _ gmparser.ASTTransformer = (*readmeLinkRewriter)(nil)
+
+func (r *readmeLinkRewriter) Transform(node *ast.Document, _ text.Reader, _ gmparser.Context) {
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch v := n.(type) {
+		case *ast.Link:
+			v.Destination = []byte(r.rewrite(string(v.Destination), "content"))
+		case *ast.Image:
+			v.Destination = []byte(r.rewrite(string(v.Destination), "raw"))
+		}
+
+		return ast.WalkContinue, nil
+	})
+}
+
+// rewrite returns the API url of the given API operation for a relative destination.
+// Absolute urls, fragments and paths leaving the repository are returned unchanged.
+func (r *readmeLinkRewriter) rewrite(dest string, operation string) string {
+	u, err := url.Parse(dest)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return dest
+	}
+
+	filePath := path.Join(r.dir, u.Path)
+	if strings.HasPrefix(u.Path, "/") {
+		// the destination is relative to the repository root
+		filePath = strings.TrimPrefix(path.Clean(u.Path), "/")
+	}
+
+	if filePath == "" || filePath == "." || filePath == ".." || strings.HasPrefix(filePath, "../") {
+		return dest
+	}
+
+	return r.generateURL(r.repoPath, operation+"/"+filePath) + "?git_ref=" + url.QueryEscape(r.gitRef)
+}
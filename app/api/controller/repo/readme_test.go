@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+)
+
+func TestFindReadme(t *testing.T) {
+	blob := func(name string) git.TreeNode {
+		return git.TreeNode{Type: git.TreeNodeTypeBlob, Name: name, Path: name}
+	}
+
+	tests := []struct {
+		name   string
+		nodes  []git.TreeNode
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "markdown-preferred",
+			nodes:  []git.TreeNode{blob("README"), blob("README.txt"), blob("README.md"), blob("main.go")},
+			want:   "README.md",
+			wantOK: true,
+		},
+		{
+			name:   "case-insensitive",
+			nodes:  []git.TreeNode{blob("Readme.Markdown"), blob("readme.txt")},
+			want:   "Readme.Markdown",
+			wantOK: true,
+		},
+		{
+			name:   "fallback-to-txt",
+			nodes:  []git.TreeNode{blob("README"), blob("README.txt")},
+			want:   "README.txt",
+			wantOK: true,
+		},
+		{
+			name:   "fallback-to-no-extension",
+			nodes:  []git.TreeNode{blob("main.go"), blob("README")},
+			want:   "README",
+			wantOK: true,
+		},
+		{
+			name: "directory-ignored",
+			nodes: []git.TreeNode{
+				{Type: git.TreeNodeTypeTree, Name: "README.md", Path: "README.md"},
+				blob("main.go"),
+			},
+			wantOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node, ok := findReadme(test.nodes)
+			if ok != test.wantOK {
+				t.Fatalf("Want found=%t, got %t", test.wantOK, ok)
+			}
+			if node.Name != test.want {
+				t.Errorf("Want %q, got %q", test.want, node.Name)
+			}
+		})
+	}
+}
+
+func newTestReadmeLinkRewriter(t *testing.T, dir string) *readmeLinkRewriter {
+	t.Helper()
+
+	provider, err := url.NewProvider("http://localhost:3000", "http://localhost:3000",
+		"https://gitness.example.com/api", "https://gitness.example.com/git", "", "https://gitness.example.com")
+	if err != nil {
+		t.Fatalf("failed to create url provider: %s", err)
+	}
+
+	return &readmeLinkRewriter{
+		generateURL: provider.GenerateAPIRepoURL,
+		repoPath:    "space/repo",
+		gitRef:      "main",
+		dir:         dir,
+	}
+}
+
+func TestRenderReadmeMarkdown(t *testing.T) {
+	source := strings.Join([]string{
+		"# Gitness",
+		"",
+		"Some **bold** text.",
+		"",
+		"| a | b |",
+		"|---|---|",
+		"| 1 | 2 |",
+		"",
+		"<script>alert('xss')</script>",
+		"",
+		"[click](javascript:alert(1))",
+	}, "\n")
+
+	got, err := renderReadme("README.md", []byte(source), newTestReadmeLinkRewriter(t, "."))
+	if err != nil {
+		t.Fatalf("failed to render README: %s", err)
+	}
+
+	for _, want := range []string{
+		`<h1 id="gitness">Gitness</h1>`,
+		"<strong>bold</strong>",
+		"<table>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Want rendered HTML to contain %q, got %q", want, got)
+		}
+	}
+
+	for _, unwanted := range []string{"<script>", "javascript:"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("Want rendered HTML to not contain %q, got %q", unwanted, got)
+		}
+	}
+}
+
+func TestRenderReadmePlainText(t *testing.T) {
+	got, err := renderReadme("README.txt", []byte("a <b> & c"), newTestReadmeLinkRewriter(t, "."))
+	if err != nil {
+		t.Fatalf("failed to render README: %s", err)
+	}
+
+	if want := "<pre>a &lt;b&gt; &amp; c</pre>"; got != want {
+		t.Errorf("Want %q, got %q", want, got)
+	}
+}
+
+func TestReadmeLinkRewriter(t *testing.T) {
+	const apiRepoURL = "https://gitness.example.com/api/v1/repos/space%2Frepo"
+
+	tests := []struct {
+		name   string
+		dir    string
+		source string
+		want   string
+	}{
+		{
+			name:   "relative-image",
+			dir:    ".",
+			source: "![logo](docs/logo.png)",
+			want:   `<img src="` + apiRepoURL + `/raw/docs/logo.png?git_ref=main" alt="logo">`,
+		},
+		{
+			name:   "relative-link-in-subdirectory",
+			dir:    "docs",
+			source: "[guide](../guide/setup.md)",
+			want:   `<a href="` + apiRepoURL + `/content/guide/setup.md?git_ref=main">guide</a>`,
+		},
+		{
+			name:   "root-relative-link",
+			dir:    "docs",
+			source: "[license](/LICENSE)",
+			want:   `<a href="` + apiRepoURL + `/content/LICENSE?git_ref=main">license</a>`,
+		},
+		{
+			name:   "escaped-path",
+			dir:    ".",
+			source: "![diagram](my%20docs/a.png)",
+			want:   `<img src="` + apiRepoURL + `/raw/my%20docs/a.png?git_ref=main" alt="diagram">`,
+		},
+		{
+			name:   "absolute-url-unchanged",
+			dir:    ".",
+			source: "[site](https://example.com/docs)",
+			want:   `<a href="https://example.com/docs">site</a>`,
+		},
+		{
+			name:   "fragment-unchanged",
+			dir:    ".",
+			source: "[usage](#usage)",
+			want:   `<a href="#usage">usage</a>`,
+		},
+		{
+			name:   "outside-repo-unchanged",
+			dir:    "docs",
+			source: "[up](../../secret)",
+			want:   `<a href="../../secret">up</a>`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := renderReadme("README.md", []byte(test.source), newTestReadmeLinkRewriter(t, test.dir))
+			if err != nil {
+				t.Fatalf("failed to render README: %s", err)
+			}
+
+			if !strings.Contains(got, test.want) {
+				t.Errorf("Want rendered HTML to contain %q, got %q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RenameInput is used for renaming a repo.
+type RenameInput struct {
+	Identifier string `json:"identifier"`
+}
+
+func (i *RenameInput) sanitize() error {
+	i.Identifier = strings.TrimSpace(i.Identifier)
+	if i.Identifier == "" {
+		return usererror.BadRequest("Identifier is required")
+	}
+
+	return nil
+}
+
+// Rename changes the identifier of a repository within its space.
+// Requests for the previous path keep resolving to the repository until the redirect expires.
+func (c *Controller) Rename(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *RenameInput,
+) (*types.Repository, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if err := c.identifierCheck(in.Identifier); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.Importing {
+		return nil, usererror.BadRequest("can't rename a repo that is being imported")
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false); err != nil {
+		return nil, err
+	}
+
+	if in.Identifier == repo.Identifier {
+		controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
+		return repo, nil
+	}
+
+	repo, err = c.updateIdentifier(ctx, repo, in.Identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename repo: %w", err)
+	}
+
+	controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
+
+	return repo, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/lock"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/xid"
+)
+
+// renameFixture holds the stores of an in-memory database containing the repos acme/app and acme/svc.
+type renameFixture struct {
+	ctrl      *Controller
+	repos     *database.RepoStore
+	repoAppID int64
+}
+
+func setupRenameFixture(t *testing.T, redirectTTL time.Duration) *renameFixture {
+	t.Helper()
+
+	db, err := sqlx.Connect("sqlite3", "file:"+xid.New().String()+".db?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %v", err)
+	}
+
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation)
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	spacePathCache := cache.New(spacePathStore, store.ToLowerSpacePathTransformation)
+	spaceStore := database.NewSpaceStore(db, spacePathCache, spacePathStore)
+	repoStore := database.NewRepoStore(db, spacePathCache, spacePathStore, spaceStore)
+
+	if err = principalStore.CreateUser(ctx, &types.User{ID: 1, UID: "user"}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	if err = spaceStore.Create(ctx, &types.Space{ID: 1, Identifier: "acme", CreatedBy: 1}); err != nil {
+		t.Fatalf("failed to create space: %v", err)
+	}
+	if err = spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+		Identifier: "acme", SpaceID: 1, IsPrimary: true, CreatedBy: 1,
+	}); err != nil {
+		t.Fatalf("failed to create path segment: %v", err)
+	}
+
+	createRepo := func(identifier string) int64 {
+		repo := &types.Repository{ParentID: 1, Identifier: identifier, GitUID: identifier}
+		if err := repoStore.Create(ctx, repo); err != nil {
+			t.Fatalf("failed to create repo %q: %v", identifier, err)
+		}
+		return repo.ID
+	}
+
+	repoAppID := createRepo("app")
+	createRepo("svc")
+
+	return &renameFixture{
+		ctrl: &Controller{
+			tx:                dbtx.New(db),
+			urlProvider:       urlProviderFake{},
+			authorizer:        &fake.Authorizer{},
+			identifierCheck:   check.RepoIdentifierDefault,
+			repoStore:         repoStore,
+			repoRedirectStore: database.NewRepoRedirectStore(db),
+			renameRedirectTTL: redirectTTL,
+			locker: locker.NewLocker(lock.NewInMemory(lock.Config{
+				App:        "gitness",
+				Expiry:     time.Minute,
+				Tries:      10,
+				RetryDelay: 10 * time.Millisecond,
+			})),
+		},
+		repos:     repoStore,
+		repoAppID: repoAppID,
+	}
+}
+
+func TestRename(t *testing.T) {
+	f := setupRenameFixture(t, time.Hour)
+	ctx := context.Background()
+
+	repo, err := f.ctrl.Rename(ctx, &auth.Session{}, "acme/app", &RenameInput{Identifier: "web"})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if repo.Identifier != "web" || repo.Path != "acme/web" {
+		t.Errorf("Want repo 'web' at 'acme/web', got %q at %q", repo.Identifier, repo.Path)
+	}
+
+	found, err := f.repos.FindByRef(ctx, "acme/web")
+	if err != nil {
+		t.Fatalf("failed to find repo by new path: %v", err)
+	}
+	if found.ID != f.repoAppID {
+		t.Errorf("Want new path to resolve to repo %d, got %d", f.repoAppID, found.ID)
+	}
+}
+
+func TestRename_OldPathRedirects(t *testing.T) {
+	f := setupRenameFixture(t, time.Hour)
+	ctx := context.Background()
+
+	if _, err := f.ctrl.Rename(ctx, &auth.Session{}, "acme/app", &RenameInput{Identifier: "web"}); err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	found, err := f.repos.FindByRef(ctx, "acme/APP")
+	if err != nil {
+		t.Fatalf("Want old path to resolve, got %v", err)
+	}
+	if found.ID != f.repoAppID || found.Path != "acme/web" {
+		t.Errorf("Want old path to resolve to repo %d at 'acme/web', got %d at %q", f.repoAppID, found.ID, found.Path)
+	}
+
+	// renaming back takes over the redirected path again.
+	if _, err = f.ctrl.Rename(ctx, &auth.Session{}, "acme/web", &RenameInput{Identifier: "app"}); err != nil {
+		t.Fatalf("Want renaming back to succeed, got %v", err)
+	}
+	found, err = f.repos.FindByRef(ctx, "acme/app")
+	if err != nil {
+		t.Fatalf("failed to find repo by path: %v", err)
+	}
+	if found.Identifier != "app" {
+		t.Errorf("Want identifier %q, got %q", "app", found.Identifier)
+	}
+}
+
+func TestRename_OldPathExpires(t *testing.T) {
+	f := setupRenameFixture(t, -time.Second)
+	ctx := context.Background()
+
+	if _, err := f.ctrl.Rename(ctx, &auth.Session{}, "acme/app", &RenameInput{Identifier: "web"}); err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	_, err := f.repos.FindByRef(ctx, "acme/app")
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("Want expired old path to not be found, got %v", err)
+	}
+}
+
+func TestRename_Collision(t *testing.T) {
+	f := setupRenameFixture(t, time.Hour)
+	ctx := context.Background()
+
+	_, err := f.ctrl.Rename(ctx, &auth.Session{}, "acme/app", &RenameInput{Identifier: "SVC"})
+	if status := usererror.Translate(ctx, err).Status; status != http.StatusConflict {
+		t.Errorf("Want status %d, got %d (%v)", http.StatusConflict, status, err)
+	}
+
+	repo, err := f.repos.Find(ctx, f.repoAppID)
+	if err != nil {
+		t.Fatalf("failed to find repo: %v", err)
+	}
+	if repo.Identifier != "app" {
+		t.Errorf("Want identifier to stay %q, got %q", "app", repo.Identifier)
+	}
+}
+
+func TestRename_InvalidIdentifier(t *testing.T) {
+	f := setupRenameFixture(t, time.Hour)
+
+	for _, identifier := range []string{"", "not valid"} {
+		_, err := f.ctrl.Rename(context.Background(), &auth.Session{}, "acme/app", &RenameInput{Identifier: identifier})
+		if status := usererror.Translate(context.Background(), err).Status; status != http.StatusBadRequest {
+			t.Errorf("Want status %d for %q, got %d (%v)", http.StatusBadRequest, identifier, status, err)
+		}
+	}
+}
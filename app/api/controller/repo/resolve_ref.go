@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ResolveRef resolves a branch, tag or (short) commit sha to the sha of the commit it points to.
+func (c *Controller) ResolveRef(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+) (*types.ResolvedRef, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.git.ResolveRef(ctx, git.ResolveRefParams{
+		ReadParams: git.CreateReadParams(repo),
+		Ref:        gitRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve ref: %w", err)
+	}
+
+	var refType enum.GitRefType
+	switch out.Type {
+	case gitenum.RefTypeBranch:
+		refType = enum.GitRefTypeBranch
+	case gitenum.RefTypeTag:
+		refType = enum.GitRefTypeTag
+	case gitenum.RefTypeUndefined, gitenum.RefTypeRaw, gitenum.RefTypePullReqHead, gitenum.RefTypePullReqMerge:
+		refType = enum.GitRefTypeCommit
+	}
+
+	return &types.ResolvedRef{
+		SHA:  out.SHA.String(),
+		Type: refType,
+		Name: out.Name,
+	}, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Stats returns the on-disk size, the object count and the largest blob of a repo.
+// NOTE: The stats are cached and might not reflect the latest state of the repository.
+func (c *Controller) Stats(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepositoryStats, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := c.statsReporter.Report(ctx, repo.GitUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to report repository stats: %w", err)
+	}
+
+	return stats, nil
+}
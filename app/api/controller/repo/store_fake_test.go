@@ -0,0 +1,532 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// spaceStoreFake is an in-memory space store holding a single space.
+type spaceStoreFake struct {
+	store.SpaceStore
+	space *types.Space
+}
+
+func (f *spaceStoreFake) FindByRef(context.Context, string) (*types.Space, error) {
+	space := *f.space
+	return &space, nil
+}
+
+func (f *spaceStoreFake) Find(_ context.Context, id int64) (*types.Space, error) {
+	if f.space.ID != id {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	space := *f.space
+	return &space, nil
+}
+
+func (f *spaceStoreFake) FindForUpdate(context.Context, int64) (*types.Space, error) {
+	space := *f.space
+	return &space, nil
+}
+
+// auditServiceFake discards all audit logs.
+type auditServiceFake struct {
+	audit.Service
+}
+
+func (auditServiceFake) Log(
+	context.Context,
+	types.Principal,
+	audit.Resource,
+	audit.Action,
+	string,
+	...audit.Option,
+) error {
+	return nil
+}
+
+// urlProviderFake generates static urls.
+// SSH clone urls are only generated if sshFrontend is set (as if an external SSH frontend is configured).
+type urlProviderFake struct {
+	url.Provider
+	sshFrontend bool
+}
+
+func (urlProviderFake) GenerateGITCloneURL(repoPath string) string {
+	return "http://localhost/git/" + repoPath + ".git"
+}
+
+func (f urlProviderFake) GenerateGITCloneSSHURL(repoPath string) string {
+	if !f.sshFrontend {
+		return ""
+	}
+	return "ssh://git@localhost/" + repoPath + ".git"
+}
+
+func (urlProviderFake) GetInternalAPIURL() string {
+	return "http://localhost/api"
+}
+
+// ruleStoreFake is an in-memory protection rule store.
+type ruleStoreFake struct {
+	store.RuleStore
+	rules []types.Rule
+}
+
+func (f *ruleStoreFake) Count(_ context.Context, _, repoID *int64, _ *types.RuleFilter) (int64, error) {
+	rules, _ := f.List(context.Background(), nil, repoID, &types.RuleFilter{})
+	return int64(len(rules)), nil
+}
+
+func (f *ruleStoreFake) List(_ context.Context, _, repoID *int64, _ *types.RuleFilter) ([]types.Rule, error) {
+	var rules []types.Rule
+	for _, rule := range f.rules {
+		if rule.RepoID != nil && *rule.RepoID == *repoID {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (f *ruleStoreFake) ListAllRepoRules(_ context.Context, repoID int64) ([]types.RuleInfoInternal, error) {
+	var rules []types.RuleInfoInternal
+	for _, rule := range f.rules {
+		if rule.RepoID != nil && *rule.RepoID == repoID {
+			rules = append(rules, types.RuleInfoInternal{
+				RuleInfo: types.RuleInfo{
+					ID:         rule.ID,
+					Identifier: rule.Identifier,
+					Type:       rule.Type,
+					State:      rule.State,
+				},
+				Pattern:    rule.Pattern,
+				Definition: rule.Definition,
+			})
+		}
+	}
+	return rules, nil
+}
+
+func (f *ruleStoreFake) Create(_ context.Context, rule *types.Rule) error {
+	rule.ID = int64(len(f.rules) + 1)
+	f.rules = append(f.rules, *rule)
+	return nil
+}
+
+// gitFake serves the tree of a single branch from memory.
+type gitFake struct {
+	git.Interface
+	branch string
+	nodes  map[string]git.TreeNode
+	blobs  map[string]string
+	syncs  []git.SyncRepositoryParams
+
+	// branches and divergences (keyed by "from...to") are served by ListBranches and GetCommitDivergences.
+	branches    []git.Branch
+	divergences map[string]api.CommitDivergence
+
+	// merged holds the SHAs of the commits reachable from the default branch, deleted the deleted branches.
+	merged  map[string]bool
+	deleted []string
+
+	// cherryPickOutput is returned by CherryPick, cherryPicks holds the received params.
+	cherryPickOutput git.CherryPickOutput
+	cherryPicks      []*git.CherryPickParams
+
+	// signatures holds the signatures of the signed commits, keyed by commit SHA.
+	signatures map[string]git.CommitSignature
+	// committer is the committer of the commit served by GetCommit.
+	committer git.Identity
+}
+
+func (f *gitFake) GetCommitSignatures(
+	_ context.Context,
+	params *git.GetCommitSignaturesParams,
+) (*git.GetCommitSignaturesOutput, error) {
+	out := &git.GetCommitSignaturesOutput{}
+	for _, commitSHA := range params.CommitSHAs {
+		if signature, ok := f.signatures[commitSHA.String()]; ok {
+			out.Signatures = append(out.Signatures, signature)
+		}
+	}
+	return out, nil
+}
+
+func (f *gitFake) GetBranch(_ context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	for _, branch := range f.branches {
+		if branch.Name == params.BranchName {
+			return &git.GetBranchOutput{Branch: branch}, nil
+		}
+	}
+	return nil, errors.NotFound("branch %q not found", params.BranchName)
+}
+
+func (f *gitFake) IsAncestor(_ context.Context, params git.IsAncestorParams) (git.IsAncestorOutput, error) {
+	return git.IsAncestorOutput{Ancestor: f.merged[params.AncestorCommitSHA.String()]}, nil
+}
+
+func (f *gitFake) DeleteBranch(_ context.Context, params *git.DeleteBranchParams) error {
+	f.deleted = append(f.deleted, params.BranchName)
+	return nil
+}
+
+func (f *gitFake) CherryPick(_ context.Context, params *git.CherryPickParams) (git.CherryPickOutput, error) {
+	f.cherryPicks = append(f.cherryPicks, params)
+	return f.cherryPickOutput, nil
+}
+
+func (f *gitFake) ListBranches(context.Context, *git.ListBranchesParams) (*git.ListBranchesOutput, error) {
+	return &git.ListBranchesOutput{Branches: f.branches}, nil
+}
+
+func (f *gitFake) GetCommitDivergences(
+	_ context.Context,
+	params *git.GetCommitDivergencesParams,
+) (*git.GetCommitDivergencesOutput, error) {
+	out := &git.GetCommitDivergencesOutput{}
+	for _, req := range params.Requests {
+		div, ok := f.divergences[req.From+"..."+req.To]
+		if !ok {
+			return nil, errors.NotFound("unknown divergence %s...%s", req.From, req.To)
+		}
+		out.Divergences = append(out.Divergences, div)
+	}
+	return out, nil
+}
+
+func (f *gitFake) ListPaths(_ context.Context, params *git.ListPathsParams) (*git.ListPathsOutput, error) {
+	out := &git.ListPathsOutput{}
+	if params.GitREF != f.branch {
+		return out, nil
+	}
+	for path := range f.nodes {
+		out.Files = append(out.Files, path)
+	}
+	return out, nil
+}
+
+func (f *gitFake) GetTreeNode(_ context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	return &git.GetTreeNodeOutput{Node: f.nodes[params.Path]}, nil
+}
+
+func (f *gitFake) ListTreeNodes(_ context.Context, params *git.ListTreeNodeParams) (*git.ListTreeNodeOutput, error) {
+	out := &git.ListTreeNodeOutput{}
+	for _, node := range f.nodes {
+		if path.Dir(node.Path) == params.Path {
+			out.Nodes = append(out.Nodes, node)
+		}
+	}
+	return out, nil
+}
+
+func (f *gitFake) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	content := f.blobs[params.SHA]
+	size := int64(len(content))
+	if params.SizeLimit > 0 && size > params.SizeLimit {
+		content = content[:params.SizeLimit]
+	}
+	return &git.GetBlobOutput{
+		SHA:         sha.Must(params.SHA),
+		Size:        size,
+		ContentSize: int64(len(content)),
+		Content:     io.NopCloser(strings.NewReader(content)),
+	}, nil
+}
+
+func (f *gitFake) GetCommit(_ context.Context, params *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	if params.Revision != f.branch {
+		return nil, errors.NotFound("revision %q not found", params.Revision)
+	}
+	return &git.GetCommitOutput{Commit: git.Commit{
+		SHA:       sha.Must(templateReadmeSHA),
+		Committer: git.Signature{Identity: f.committer},
+	}}, nil
+}
+
+func (f *gitFake) CreateRepository(
+	context.Context,
+	*git.CreateRepositoryParams,
+) (*git.CreateRepositoryOutput, error) {
+	return &git.CreateRepositoryOutput{UID: "fork-git-uid"}, nil
+}
+
+func (f *gitFake) SyncRepository(
+	_ context.Context,
+	params *git.SyncRepositoryParams,
+) (*git.SyncRepositoryOutput, error) {
+	f.syncs = append(f.syncs, *params)
+	return &git.SyncRepositoryOutput{DefaultBranch: f.branch}, nil
+}
+
+func (f *gitFake) Archive(_ context.Context, w io.Writer, params *git.ArchiveParams) error {
+	_, err := io.WriteString(w, params.Prefix+string(params.Format))
+	return err
+}
+
+// deployKeyStoreFake is an in-memory deploy key store.
+type deployKeyStoreFake struct {
+	store.DeployKeyStore
+
+	keys   []*types.DeployKey
+	lastID int64
+}
+
+func (s *deployKeyStoreFake) Find(_ context.Context, id int64) (*types.DeployKey, error) {
+	for _, k := range s.keys {
+		if k.ID == id {
+			return k, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *deployKeyStoreFake) FindByFingerprint(_ context.Context, fingerprint string) (*types.DeployKey, error) {
+	for _, k := range s.keys {
+		if k.Fingerprint == fingerprint {
+			return k, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *deployKeyStoreFake) Create(_ context.Context, key *types.DeployKey) error {
+	s.lastID++
+	key.ID = s.lastID
+	s.keys = append(s.keys, key)
+	return nil
+}
+
+func (s *deployKeyStoreFake) Delete(_ context.Context, id int64) error {
+	for i, k := range s.keys {
+		if k.ID == id {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *deployKeyStoreFake) List(_ context.Context, repoID int64) ([]*types.DeployKey, error) {
+	var keys []*types.DeployKey
+	for _, k := range s.keys {
+		if k.RepoID == repoID {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// publicKeyStoreFake is an in-memory public key store.
+type publicKeyStoreFake struct {
+	store.PublicKeyStore
+	keys []*types.PublicKey
+}
+
+func (s *publicKeyStoreFake) FindByFingerprint(_ context.Context, fingerprint string) (*types.PublicKey, error) {
+	for _, k := range s.keys {
+		if k.Fingerprint == fingerprint {
+			return k, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// userEmailStoreFake maps emails to the users owning them.
+type userEmailStoreFake struct {
+	store.UserEmailStore
+	principalIDs map[string]int64
+}
+
+func (s *userEmailStoreFake) MapPrincipalIDs(_ context.Context, emails []string) (map[string]int64, error) {
+	m := make(map[string]int64, len(emails))
+	for _, email := range emails {
+		email = strings.ToLower(email)
+		if id, ok := s.principalIDs[email]; ok {
+			m[email] = id
+		}
+	}
+	return m, nil
+}
+
+// principalInfoCacheFake serves principal info from memory.
+type principalInfoCacheFake struct {
+	store.PrincipalInfoCache
+	infos map[int64]*types.PrincipalInfo
+}
+
+func (c *principalInfoCacheFake) Map(_ context.Context, ids []int64) (map[int64]*types.PrincipalInfo, error) {
+	m := make(map[int64]*types.PrincipalInfo, len(ids))
+	for _, id := range ids {
+		if info, ok := c.infos[id]; ok {
+			m[id] = info
+		}
+	}
+	return m, nil
+}
+
+// reposStoreFake extends the in-memory repo store with creating repositories.
+type reposStoreFake struct {
+	*fake.RepoStore
+}
+
+func (f *reposStoreFake) Create(_ context.Context, repo *types.Repository) error {
+	repo.ID = int64(len(f.Repos) + 1)
+	repo.Path = fmt.Sprintf("space%d/%s", repo.ParentID, repo.Identifier)
+	stored := *repo
+	f.Repos = append(f.Repos, &stored)
+	return nil
+}
+
+// txFake runs the transaction function without a transaction.
+type txFake struct {
+	dbtx.Transactor
+}
+
+func (txFake) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+// indexerFake discards all index requests.
+type indexerFake struct{}
+
+func (indexerFake) Index(context.Context, *types.Repository) error {
+	return nil
+}
+
+// principalStoreFake is an in-memory principal store.
+type principalStoreFake struct {
+	store.PrincipalStore
+	users []*types.User
+}
+
+func (s *principalStoreFake) Find(_ context.Context, id int64) (*types.Principal, error) {
+	for _, u := range s.users {
+		if u.ID == id {
+			return u.ToPrincipal(), nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *principalStoreFake) FindUserByUID(_ context.Context, uid string) (*types.User, error) {
+	for _, u := range s.users {
+		if u.UID == uid {
+			return u, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// membershipStoreFake is an in-memory membership store.
+type membershipStoreFake struct {
+	store.MembershipStore
+	memberships []types.Membership
+}
+
+func (s *membershipStoreFake) CountUsers(
+	_ context.Context,
+	spaceID int64,
+	filter types.MembershipUserFilter,
+) (int64, error) {
+	var count int64
+	for _, m := range s.memberships {
+		if m.SpaceID == spaceID && (filter.Role == "" || m.Role == filter.Role) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// repoCollaboratorStoreFake is an in-memory repository collaborator store.
+type repoCollaboratorStoreFake struct {
+	store.RepoCollaboratorStore
+	collaborators []types.RepoCollaborator
+}
+
+func (s *repoCollaboratorStoreFake) Find(
+	_ context.Context,
+	key types.RepoCollaboratorKey,
+) (*types.RepoCollaborator, error) {
+	for _, c := range s.collaborators {
+		if c.RepoCollaboratorKey == key {
+			c := c
+			return &c, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *repoCollaboratorStoreFake) FindUser(
+	ctx context.Context,
+	key types.RepoCollaboratorKey,
+) (*types.RepoCollaboratorUser, error) {
+	c, err := s.Find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &types.RepoCollaboratorUser{RepoCollaborator: *c}, nil
+}
+
+func (s *repoCollaboratorStoreFake) Update(_ context.Context, collaborator *types.RepoCollaborator) error {
+	for i := range s.collaborators {
+		if s.collaborators[i].RepoCollaboratorKey == collaborator.RepoCollaboratorKey {
+			s.collaborators[i] = *collaborator
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *repoCollaboratorStoreFake) Delete(_ context.Context, key types.RepoCollaboratorKey) error {
+	for i := range s.collaborators {
+		if s.collaborators[i].RepoCollaboratorKey == key {
+			s.collaborators = append(s.collaborators[:i], s.collaborators[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *repoCollaboratorStoreFake) CountByRole(
+	_ context.Context,
+	repoID int64,
+	role enum.MembershipRole,
+) (int64, error) {
+	var count int64
+	for _, c := range s.collaborators {
+		if c.RepoID == repoID && c.Role == role {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/audit"
@@ -27,17 +28,25 @@ import (
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
 )
 
 // UpdateInput is used for updating a repo.
+// Empty values clear the description, homepage and topics of the repo.
 type UpdateInput struct {
-	Description *string `json:"description"`
-	IsPublic    *bool   `json:"is_public"`
+	Description *string   `json:"description"`
+	Homepage    *string   `json:"homepage"`
+	Topics      *[]string `json:"topics"`
+	IsPublic    *bool     `json:"is_public"`
+	IsTemplate  *bool     `json:"is_template"`
 }
 
 func (in *UpdateInput) hasChanges(repo *types.Repository) bool {
 	return (in.Description != nil && *in.Description != repo.Description) ||
-		(in.IsPublic != nil && *in.IsPublic != repo.IsPublic)
+		(in.Homepage != nil && *in.Homepage != repo.Homepage) ||
+		(in.Topics != nil && !slices.Equal(*in.Topics, repo.Topics)) ||
+		(in.IsPublic != nil && *in.IsPublic != repo.IsPublic) ||
+		(in.IsTemplate != nil && *in.IsTemplate != repo.IsTemplate)
 }
 
 // Update updates a repository.
@@ -66,9 +75,18 @@ func (c *Controller) Update(ctx context.Context,
 		if in.Description != nil {
 			repo.Description = *in.Description
 		}
+		if in.Homepage != nil {
+			repo.Homepage = *in.Homepage
+		}
+		if in.Topics != nil {
+			repo.Topics = *in.Topics
+		}
 		if in.IsPublic != nil {
 			repo.IsPublic = *in.IsPublic
 		}
+		if in.IsTemplate != nil {
+			repo.IsTemplate = *in.IsTemplate
+		}
 
 		return nil
 	})
@@ -89,7 +107,7 @@ func (c *Controller) Update(ctx context.Context,
 	}
 
 	// backfill repo url
-	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+	controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
 
 	return repo, nil
 }
@@ -108,5 +126,33 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.Homepage != nil {
+		*in.Homepage = strings.TrimSpace(*in.Homepage)
+		if err := check.RepoHomepage(*in.Homepage); err != nil {
+			return err
+		}
+	}
+
+	if in.Topics != nil {
+		*in.Topics = normalizeTopics(*in.Topics)
+		if err := check.RepoTopics(*in.Topics); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// normalizeTopics lowercases and trims the topics, and drops empty and duplicate entries.
+func normalizeTopics(topics []string) []string {
+	normalized := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		topic = strings.ToLower(strings.TrimSpace(topic))
+		if topic == "" || slices.Contains(normalized, topic) {
+			continue
+		}
+		normalized = append(normalized, topic)
+	}
+
+	return normalized
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/gotidy/ptr"
+	"golang.org/x/exp/slices"
+)
+
+func setupUpdateController() (*Controller, *fake.RepoStore) {
+	repoStore := fake.NewRepoStore(&types.Repository{
+		ID:          1,
+		Identifier:  "repo",
+		Path:        "space/repo",
+		Description: "old description",
+		Homepage:    "https://old.example.com",
+		Topics:      []string{"old"},
+	})
+	ctrl := &Controller{
+		authorizer:   &fake.Authorizer{},
+		repoStore:    repoStore,
+		auditService: auditServiceFake{},
+		urlProvider:  urlProviderFake{},
+	}
+
+	return ctrl, repoStore
+}
+
+func TestUpdate_Metadata(t *testing.T) {
+	ctrl, repoStore := setupUpdateController()
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	repo, err := ctrl.Update(context.Background(), session, "space/repo", &UpdateInput{
+		Description: ptr.String(" new description "),
+		Homepage:    ptr.String("https://gitness.example.com"),
+		Topics:      &[]string{"go", "ci-cd"},
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if repo.Description != "new description" {
+		t.Errorf("Want description %q, got %q", "new description", repo.Description)
+	}
+	if repo.Homepage != "https://gitness.example.com" {
+		t.Errorf("Want homepage %q, got %q", "https://gitness.example.com", repo.Homepage)
+	}
+	if !slices.Equal(repo.Topics, []string{"go", "ci-cd"}) {
+		t.Errorf("Want topics %v, got %v", []string{"go", "ci-cd"}, repo.Topics)
+	}
+	if repoStore.Repos[0].Homepage != repo.Homepage {
+		t.Errorf("Want updated repo to be stored")
+	}
+
+	// empty values clear the metadata
+	repo, err = ctrl.Update(context.Background(), session, "space/repo", &UpdateInput{
+		Description: ptr.String(""),
+		Homepage:    ptr.String(""),
+		Topics:      &[]string{},
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if repo.Description != "" || repo.Homepage != "" || len(repo.Topics) != 0 {
+		t.Errorf("Want metadata to be cleared, got %q, %q, %v", repo.Description, repo.Homepage, repo.Topics)
+	}
+}
+
+func TestUpdate_DescriptionTooLong(t *testing.T) {
+	ctrl, repoStore := setupUpdateController()
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	_, err := ctrl.Update(context.Background(), session, "space/repo", &UpdateInput{
+		Description: ptr.String(strings.Repeat("a", 1025)),
+	})
+	if !errors.Is(err, check.ErrDescriptionTooLong) {
+		t.Errorf("Want error %v, got %v", check.ErrDescriptionTooLong, err)
+	}
+
+	if repoStore.Repos[0].Description != "old description" {
+		t.Errorf("Want description to be unchanged, got %q", repoStore.Repos[0].Description)
+	}
+}
+
+func TestUpdate_TopicNormalization(t *testing.T) {
+	ctrl, _ := setupUpdateController()
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	repo, err := ctrl.Update(context.Background(), session, "space/repo", &UpdateInput{
+		Topics: &[]string{" Go ", "GO", "", "Machine-Learning"},
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	want := []string{"go", "machine-learning"}
+	if !slices.Equal(repo.Topics, want) {
+		t.Errorf("Want topics %v, got %v", want, repo.Topics)
+	}
+
+	_, err = ctrl.Update(context.Background(), session, "space/repo", &UpdateInput{
+		Topics: &[]string{"c++"},
+	})
+	if !errors.Is(err, check.ErrRepoTopicInvalid) {
+		t.Errorf("Want error %v, got %v", check.ErrRepoTopicInvalid, err)
+	}
+}
@@ -16,13 +16,18 @@ package repo
 
 import (
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/services/protection"
+	reposervice "github.com/harness/gitness/app/services/repo"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -39,6 +44,7 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideController,
+	ProvideRepoPurger,
 )
 
 func ProvideController(
@@ -65,12 +71,34 @@ func ProvideController(
 	mtxManager lock.MutexManager,
 	identifierCheck check.RepoIdentifier,
 	repoChecks Check,
+	statsReporter *reposervice.StatsReporter,
+	languageAnalyzer *reposervice.LanguageAnalyzer,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	deployKeyStore store.DeployKeyStore,
+	publicKeyStore store.PublicKeyStore,
+	deployKeyAuthn *authn.DeployKeyAuthenticator,
+	repoActivityStore store.RepoActivityStore,
+	userEmailStore store.UserEmailStore,
+	garbageCollector *reposervice.GarbageCollector,
+	mirror *mirror.Service,
+	operations *operation.Registry,
+	membershipStore store.MembershipStore,
+	repoCollaboratorStore store.RepoCollaboratorStore,
+	repoRedirectStore store.RepoRedirectStore,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer, repoStore,
 		spaceStore, pipelineStore,
 		principalStore, ruleStore, settings, principalInfoCache, protectionManager, rpcClient, importer,
-		codeOwners, reporeporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck, repoChecks)
+		codeOwners, reporeporter, indexer, limiter, locker, auditService, mtxManager, identifierCheck, repoChecks,
+		statsReporter, languageAnalyzer, idempotencyKeyStore, deployKeyStore, publicKeyStore, deployKeyAuthn,
+		repoActivityStore, userEmailStore, garbageCollector, mirror, operations, membershipStore,
+		repoCollaboratorStore, repoRedirectStore)
+}
+
+// ProvideRepoPurger provides the purger of deleted repositories used by the cleanup service.
+func ProvideRepoPurger(ctrl *Controller) cleanup.RepoPurger {
+	return systemRepoPurger{ctrl: ctrl}
 }
 
 func ProvideRepoCheck() Check {
@@ -27,20 +27,23 @@ import (
 )
 
 type Controller struct {
-	authorizer authz.Authorizer
-	repoStore  store.RepoStore
-	settings   *settings.Service
+	authorizer     authz.Authorizer
+	repoStore      store.RepoStore
+	principalStore store.PrincipalStore
+	settings       *settings.Service
 }
 
 func NewController(
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
 	settings *settings.Service,
 ) *Controller {
 	return &Controller{
-		authorizer: authorizer,
-		repoStore:  repoStore,
-		settings:   settings,
+		authorizer:     authorizer,
+		repoStore:      repoStore,
+		principalStore: principalStore,
+		settings:       settings,
 	}
 }
 
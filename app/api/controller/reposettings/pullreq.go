@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reposettings
+
+import (
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// PullReqSettings represents the pull request related part of repository settings as exposed externally.
+type PullReqSettings struct {
+	DefaultReviewerIDs  *[]int64             `json:"default_reviewer_ids"`
+	DefaultReviewerRole *enum.MembershipRole `json:"default_reviewer_role"`
+	DefaultMergeMethod  *enum.MergeMethod    `json:"default_merge_method"`
+	AllowedMergeMethods *[]enum.MergeMethod  `json:"allowed_merge_methods"`
+}
+
+func GetDefaultPullReqSettings() *PullReqSettings {
+	defaultReviewerIDs := slices.Clone(settings.DefaultDefaultReviewerIDs)
+	defaultReviewerRole := settings.DefaultDefaultReviewerRole
+	defaultMergeMethod := settings.DefaultDefaultMergeMethod
+	allowedMergeMethods := slices.Clone(settings.DefaultAllowedMergeMethods)
+	return &PullReqSettings{
+		DefaultReviewerIDs:  &defaultReviewerIDs,
+		DefaultReviewerRole: &defaultReviewerRole,
+		DefaultMergeMethod:  &defaultMergeMethod,
+		AllowedMergeMethods: &allowedMergeMethods,
+	}
+}
+
+func GetPullReqSettingsMappings(s *PullReqSettings) []settings.SettingHandler {
+	return []settings.SettingHandler{
+		settings.Mapping(settings.KeyDefaultReviewerIDs, s.DefaultReviewerIDs),
+		settings.Mapping(settings.KeyDefaultReviewerRole, s.DefaultReviewerRole),
+		settings.Mapping(settings.KeyDefaultMergeMethod, s.DefaultMergeMethod),
+		settings.Mapping(settings.KeyAllowedMergeMethods, s.AllowedMergeMethods),
+	}
+}
+
+func GetPullReqSettingsAsKeyValues(s *PullReqSettings) []settings.KeyValue {
+	kvs := make([]settings.KeyValue, 0, 4)
+	if s.DefaultReviewerIDs != nil {
+		kvs = append(kvs, settings.KeyValue{Key: settings.KeyDefaultReviewerIDs, Value: *s.DefaultReviewerIDs})
+	}
+	if s.DefaultReviewerRole != nil {
+		kvs = append(kvs, settings.KeyValue{Key: settings.KeyDefaultReviewerRole, Value: *s.DefaultReviewerRole})
+	}
+	if s.DefaultMergeMethod != nil {
+		kvs = append(kvs, settings.KeyValue{Key: settings.KeyDefaultMergeMethod, Value: *s.DefaultMergeMethod})
+	}
+	if s.AllowedMergeMethods != nil {
+		kvs = append(kvs, settings.KeyValue{Key: settings.KeyAllowedMergeMethods, Value: *s.AllowedMergeMethods})
+	}
+	return kvs
+}
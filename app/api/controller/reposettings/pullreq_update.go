@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reposettings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+const maxDefaultReviewers = 50
+
+// PullReqUpdate updates the pull request settings of the repo.
+func (c *Controller) PullReqUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *PullReqSettings,
+) (*PullReqSettings, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizePullReqSettings(ctx, repo, in); err != nil {
+		return nil, err
+	}
+
+	err = c.settings.RepoSetMany(ctx, repo.ID, GetPullReqSettingsAsKeyValues(in)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set settings: %w", err)
+	}
+
+	// read all settings and return complete config
+	out := GetDefaultPullReqSettings()
+	mappings := GetPullReqSettingsMappings(out)
+	err = c.settings.RepoMap(ctx, repo.ID, mappings...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map settings: %w", err)
+	}
+
+	return out, nil
+}
+
+func (c *Controller) sanitizePullReqSettings(
+	ctx context.Context,
+	repo *types.Repository,
+	in *PullReqSettings,
+) error {
+	if in.DefaultReviewerRole != nil && *in.DefaultReviewerRole != "" {
+		role, ok := in.DefaultReviewerRole.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid default reviewer role %q.", *in.DefaultReviewerRole)
+		}
+		in.DefaultReviewerRole = &role
+	}
+
+	if err := sanitizeMergeMethods(in); err != nil {
+		return err
+	}
+
+	if in.DefaultReviewerIDs == nil {
+		return nil
+	}
+
+	if len(*in.DefaultReviewerIDs) > maxDefaultReviewers {
+		return usererror.BadRequestf("A repository can have at most %d default reviewers.", maxDefaultReviewers)
+	}
+
+	reviewerIDs := make([]int64, 0, len(*in.DefaultReviewerIDs))
+	seen := make(map[int64]struct{}, len(*in.DefaultReviewerIDs))
+	for _, reviewerID := range *in.DefaultReviewerIDs {
+		if _, ok := seen[reviewerID]; ok {
+			continue
+		}
+		seen[reviewerID] = struct{}{}
+
+		principal, err := c.principalStore.Find(ctx, reviewerID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return usererror.BadRequestf("Default reviewer with ID %d doesn't exist.", reviewerID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find default reviewer principal: %w", err)
+		}
+
+		if err = apiauth.CheckRepoPrincipal(ctx, c.authorizer, principal, repo, enum.PermissionRepoView); err != nil {
+			return usererror.BadRequestf(
+				"Default reviewer %q doesn't have enough permissions for the repository.", principal.UID)
+		}
+
+		reviewerIDs = append(reviewerIDs, reviewerID)
+	}
+
+	in.DefaultReviewerIDs = &reviewerIDs
+
+	return nil
+}
+
+func sanitizeMergeMethods(in *PullReqSettings) error {
+	if in.DefaultMergeMethod != nil && *in.DefaultMergeMethod != "" {
+		method, ok := in.DefaultMergeMethod.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid default merge method %q.", *in.DefaultMergeMethod)
+		}
+		in.DefaultMergeMethod = &method
+	}
+
+	if in.AllowedMergeMethods == nil {
+		return nil
+	}
+
+	methods := make([]enum.MergeMethod, 0, len(*in.AllowedMergeMethods))
+	for _, m := range *in.AllowedMergeMethods {
+		method, ok := m.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Invalid allowed merge method %q.", m)
+		}
+		if !slices.Contains(methods, method) {
+			methods = append(methods, method)
+		}
+	}
+
+	in.AllowedMergeMethods = &methods
+
+	if len(methods) > 0 && in.DefaultMergeMethod != nil && *in.DefaultMergeMethod != "" &&
+		!slices.Contains(methods, *in.DefaultMergeMethod) {
+		return usererror.BadRequestf("Default merge method %q is not an allowed merge method.", *in.DefaultMergeMethod)
+	}
+
+	return nil
+}
@@ -30,7 +30,8 @@ var WireSet = wire.NewSet(
 func ProvideController(
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
 	settings *settings.Service,
 ) *Controller {
-	return NewController(authorizer, repoStore, settings)
+	return NewController(authorizer, repoStore, principalStore, settings)
 }
@@ -25,6 +25,7 @@ type Controller struct {
 	secretStore store.SecretStore
 	authorizer  authz.Authorizer
 	spaceStore  store.SpaceStore
+	repoStore   store.RepoStore
 }
 
 func NewController(
@@ -32,11 +33,13 @@ func NewController(
 	encrypter encrypt.Encrypter,
 	secretStore store.SecretStore,
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
 ) *Controller {
 	return &Controller{
 		encrypter:   encrypter,
 		secretStore: secretStore,
 		authorizer:  authorizer,
 		spaceStore:  spaceStore,
+		repoStore:   repoStore,
 	}
 }
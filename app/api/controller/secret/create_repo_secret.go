@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateRepoSecretInput struct {
+	Identifier  string `json:"identifier"`
+	Description string `json:"description"`
+	Data        string `json:"data"`
+}
+
+// CreateRepoSecret creates a new secret that is only available to the CI of the repository.
+func (c *Controller) CreateRepoSecret(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CreateRepoSecretInput,
+) (*types.Secret, error) {
+	if err := c.sanitizeCreateRepoSecretInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoEdit, false); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	secret := &types.Secret{
+		CreatedBy:   session.Principal.ID,
+		Description: in.Description,
+		Data:        in.Data,
+		SpaceID:     repo.ParentID,
+		RepoID:      &repo.ID,
+		Identifier:  in.Identifier,
+		Created:     now,
+		Updated:     now,
+		Version:     0,
+	}
+	secret, err = enc(c.encrypter, secret)
+	if err != nil {
+		return nil, fmt.Errorf("could not encrypt secret: %w", err)
+	}
+
+	err = c.secretStore.Create(ctx, secret)
+	if err != nil {
+		return nil, fmt.Errorf("secret creation failed: %w", err)
+	}
+
+	return secret.CopyWithoutData(), nil
+}
+
+func (c *Controller) sanitizeCreateRepoSecretInput(in *CreateRepoSecretInput) error {
+	if err := check.SecretName(in.Identifier); err != nil {
+		return err
+	}
+
+	if in.Data == "" {
+		return usererror.BadRequest("Secret data is required.")
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	return check.Description(in.Description)
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListRepoSecrets lists the secrets of a repository without their values.
+func (c *Controller) ListRepoSecrets(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter types.ListQueryFilter,
+) ([]*types.Secret, int64, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, false); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.secretStore.CountForRepo(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count secrets: %w", err)
+	}
+
+	secrets, err := c.secretStore.ListForRepo(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	for i := range secrets {
+		secrets[i] = secrets[i].CopyWithoutData()
+	}
+
+	return secrets, count, nil
+}
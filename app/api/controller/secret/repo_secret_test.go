@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+// secretStoreFake keeps the secrets in memory exactly as they are stored.
+type secretStoreFake struct {
+	store.SecretStore
+	secrets []*types.Secret
+}
+
+func (f *secretStoreFake) Create(_ context.Context, secret *types.Secret) error {
+	secret.ID = int64(len(f.secrets) + 1)
+	stored := *secret
+	f.secrets = append(f.secrets, &stored)
+	return nil
+}
+
+func (f *secretStoreFake) CountForRepo(ctx context.Context, repoID int64, filter types.ListQueryFilter) (int64, error) {
+	secrets, err := f.ListForRepo(ctx, repoID, filter)
+	return int64(len(secrets)), err
+}
+
+func (f *secretStoreFake) ListForRepo(
+	_ context.Context,
+	repoID int64,
+	_ types.ListQueryFilter,
+) ([]*types.Secret, error) {
+	var secrets []*types.Secret
+	for _, secret := range f.secrets {
+		if secret.RepoID != nil && *secret.RepoID == repoID {
+			stored := *secret
+			secrets = append(secrets, &stored)
+		}
+	}
+	return secrets, nil
+}
+
+func setupRepoSecretController(t *testing.T) (*Controller, *secretStoreFake, encrypt.Encrypter) {
+	t.Helper()
+
+	encrypter, err := encrypt.New("fb1e4ec5a4b3a59d74d2b4d9e94d0e0e", false)
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	secretStore := &secretStoreFake{}
+	repoStore := fake.NewRepoStore(&types.Repository{ID: 3, ParentID: 1, Path: "space/repo"})
+
+	return NewController(&fake.Authorizer{}, encrypter, secretStore, nil, repoStore), secretStore, encrypter
+}
+
+func TestCreateRepoSecret_Encrypted(t *testing.T) {
+	c, secretStore, encrypter := setupRepoSecretController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	out, err := c.CreateRepoSecret(context.Background(), session, "space/repo", &CreateRepoSecretInput{
+		Identifier: "DEPLOY_TOKEN",
+		Data:       "s3cr3t",
+	})
+	if err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+
+	if out.Data != "" {
+		t.Errorf("Want no data in the response, got %q", out.Data)
+	}
+
+	if len(secretStore.secrets) != 1 {
+		t.Fatalf("Want 1 stored secret, got %d", len(secretStore.secrets))
+	}
+	stored := secretStore.secrets[0]
+	if stored.RepoID == nil || *stored.RepoID != 3 || stored.SpaceID != 1 {
+		t.Errorf("Want secret of repo 3 in space 1, got %+v", stored)
+	}
+	if strings.Contains(stored.Data, "s3cr3t") {
+		t.Errorf("Want value to be encrypted at rest, got %q", stored.Data)
+	}
+
+	plaintext, err := encrypter.Decrypt([]byte(stored.Data))
+	if err != nil {
+		t.Fatalf("failed to decrypt stored secret: %v", err)
+	}
+	if plaintext != "s3cr3t" {
+		t.Errorf("Want decrypted value %q, got %q", "s3cr3t", plaintext)
+	}
+}
+
+func TestCreateRepoSecret_InvalidName(t *testing.T) {
+	c, secretStore, _ := setupRepoSecretController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	for _, name := range []string{"", "1TOKEN", "deploy-token", "deploy.token"} {
+		_, err := c.CreateRepoSecret(context.Background(), session, "space/repo", &CreateRepoSecretInput{
+			Identifier: name,
+			Data:       "s3cr3t",
+		})
+		if !errors.Is(err, check.ErrSecretNameInvalid) && !errors.Is(err, check.ErrSecretNameLength) {
+			t.Errorf("name %q: Want validation error, got %v", name, err)
+		}
+	}
+
+	if len(secretStore.secrets) != 0 {
+		t.Errorf("Want no stored secrets, got %d", len(secretStore.secrets))
+	}
+}
+
+func TestListRepoSecrets_OmitsValues(t *testing.T) {
+	c, _, _ := setupRepoSecretController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1}}
+
+	for _, name := range []string{"DEPLOY_TOKEN", "NPM_TOKEN"} {
+		if _, err := c.CreateRepoSecret(context.Background(), session, "space/repo", &CreateRepoSecretInput{
+			Identifier: name,
+			Data:       "s3cr3t",
+		}); err != nil {
+			t.Fatalf("failed to create secret: %v", err)
+		}
+	}
+
+	secrets, count, err := c.ListRepoSecrets(context.Background(), session, "space/repo", types.ListQueryFilter{})
+	if err != nil {
+		t.Fatalf("failed to list secrets: %v", err)
+	}
+
+	if count != 2 || len(secrets) != 2 {
+		t.Fatalf("Want 2 secrets, got %d (count %d)", len(secrets), count)
+	}
+	for _, secret := range secrets {
+		if secret.Data != "" {
+			t.Errorf("Want no data for secret %q, got %q", secret.Identifier, secret.Data)
+		}
+	}
+
+	raw, err := json.Marshal(secrets)
+	if err != nil {
+		t.Fatalf("failed to marshal secrets: %v", err)
+	}
+	if strings.Contains(string(raw), `"data"`) {
+		t.Errorf("Want no data in listing, got %s", raw)
+	}
+}
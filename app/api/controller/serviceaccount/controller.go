@@ -19,29 +19,34 @@ import (
 
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 )
 
 type Controller struct {
+	config            *types.Config
 	principalUIDCheck check.PrincipalUID
 	authorizer        authz.Authorizer
 	principalStore    store.PrincipalStore
 	spaceStore        store.SpaceStore
 	repoStore         store.RepoStore
 	tokenStore        store.TokenStore
+	tokenIssuer       *token.Issuer
 }
 
-func NewController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
+func NewController(config *types.Config, principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
 	principalStore store.PrincipalStore, spaceStore store.SpaceStore, repoStore store.RepoStore,
-	tokenStore store.TokenStore) *Controller {
+	tokenStore store.TokenStore, tokenIssuer *token.Issuer) *Controller {
 	return &Controller{
+		config:            config,
 		principalUIDCheck: principalUIDCheck,
 		authorizer:        authorizer,
 		principalStore:    principalStore,
 		spaceStore:        spaceStore,
 		repoStore:         repoStore,
 		tokenStore:        tokenStore,
+		tokenIssuer:       tokenIssuer,
 	}
 }
 
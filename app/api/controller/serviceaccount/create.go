@@ -21,6 +21,7 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -43,8 +44,9 @@ type CreateInput struct {
 }
 
 // Create creates a new service account.
+// In dry-run mode the input is validated and checked for uniqueness, but the service account isn't stored.
 func (c *Controller) Create(ctx context.Context, session *auth.Session,
-	in *CreateInput) (*types.ServiceAccount, error) {
+	in *CreateInput, dryRun bool) (*types.ServiceAccount, error) {
 	// Ensure principal has required permissions on parent (ensures that parent exists)
 	// since it's a create, we use don't pass a resource name.
 	if err := apiauth.CheckServiceAccount(ctx, c.authorizer, session, c.spaceStore, c.repoStore,
@@ -57,10 +59,38 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session,
 		return nil, fmt.Errorf("failed to generate service account UID: %w", err)
 	}
 
+	if dryRun {
+		return c.createDryRun(ctx, in, uid)
+	}
+
 	// TODO: There's a chance of duplicate error - we should retry?
 	return c.CreateNoAuth(ctx, in, uid)
 }
 
+// createDryRun returns the service account that would be created for the input, without storing it.
+func (c *Controller) createDryRun(ctx context.Context,
+	in *CreateInput, uid string) (*types.ServiceAccount, error) {
+	if err := c.sanitizeCreateInput(in, uid); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	if err := controller.CheckPrincipalUnique(ctx, c.principalStore, uid, in.Email); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	return &types.ServiceAccount{
+		UID:         uid,
+		Email:       in.Email,
+		DisplayName: in.DisplayName,
+		Created:     now,
+		Updated:     now,
+		ParentType:  in.ParentType,
+		ParentID:    in.ParentID,
+	}, nil
+}
+
 /*
  * CreateNoAuth creates a new service account without auth checks.
  * WARNING: Never call as part of user flow.
@@ -20,18 +20,21 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
-	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
 )
 
 type CreateTokenInput struct {
 	// TODO [CODE-1363]: remove after identifier migration.
-	UID        string         `json:"uid" deprecated:"true"`
-	Identifier string         `json:"identifier"`
-	Lifetime   *time.Duration `json:"lifetime"`
+	UID        string            `json:"uid" deprecated:"true"`
+	Identifier string            `json:"identifier"`
+	Lifetime   *time.Duration    `json:"lifetime"`
+	Scopes     []enum.TokenScope `json:"scopes"`
 }
 
 // CreateToken creates a new service account access token.
@@ -41,10 +44,19 @@ func (c *Controller) CreateToken(
 	saUID string,
 	in *CreateTokenInput,
 ) (*types.TokenResponse, error) {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return nil, err
+	}
+
 	if err := c.sanitizeCreateTokenInput(in); err != nil {
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
+	// scoped tokens must never be able to create tokens with more privileges than they have themselves.
+	if !auth.CanGrantScopes(session, in.Scopes) {
+		return nil, usererror.Forbidden("The token scopes have to be a subset of the scopes of the current token.")
+	}
+
 	sa, err := findServiceAccountFromUID(ctx, c.principalStore, saUID)
 	if err != nil {
 		return nil, err
@@ -56,13 +68,13 @@ func (c *Controller) CreateToken(
 		return nil, err
 	}
 
-	token, jwtToken, err := token.CreateSAT(
+	token, jwtToken, err := c.tokenIssuer.CreateSAT(
 		ctx,
-		c.tokenStore,
 		&session.Principal,
 		sa,
 		in.Identifier,
 		in.Lifetime,
+		in.Scopes,
 	)
 	if err != nil {
 		return nil, err
@@ -81,10 +93,19 @@ func (c *Controller) sanitizeCreateTokenInput(in *CreateTokenInput) error {
 		return err
 	}
 
-	//nolint:revive
 	if err := check.TokenLifetime(in.Lifetime, true); err != nil {
 		return err
 	}
 
+	// fallback to the configured default lifetime for service account tokens (if any)
+	if in.Lifetime == nil && c.config.Auth.ServiceAccountTokenLifetime > 0 {
+		in.Lifetime = ptr.Duration(c.config.Auth.ServiceAccountTokenLifetime)
+	}
+
+	//nolint:revive
+	if err := check.TokenScopes(in.Scopes); err != nil {
+		return err
+	}
+
 	return nil
 }
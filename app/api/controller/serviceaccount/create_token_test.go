@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccount
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+func setupCreateTokenController(t *testing.T, lifetime time.Duration) *Controller {
+	t.Helper()
+
+	config := &types.Config{}
+	config.Token.Expire = 24 * time.Hour
+	config.Auth.ServiceAccountTokenLifetime = lifetime
+
+	principalStore := &principalStoreFake{
+		sa: &types.ServiceAccount{
+			ID:         2,
+			UID:        "sa",
+			Salt:       "sa-salt",
+			ParentType: enum.ParentResourceTypeSpace,
+			ParentID:   1,
+		},
+	}
+
+	tokenStore := &tokenStoreFake{}
+
+	return NewController(config, nil, &fake.Authorizer{}, principalStore,
+		&spaceStoreFake{space: &types.Space{ID: 1, Path: "space"}}, nil, tokenStore,
+		token.NewIssuer(tokenStore, jwt.NewKeySet(0), jwt.GenerateForToken))
+}
+
+func tokenLifetime(tkn types.Token) *time.Duration {
+	if tkn.ExpiresAt == nil {
+		return nil
+	}
+	return ptr.Duration(time.Duration(*tkn.ExpiresAt-tkn.IssuedAt) * time.Millisecond)
+}
+
+func TestCreateToken_UsesServiceAccountTokenLifetime(t *testing.T) {
+	c := setupCreateTokenController(t, 48*time.Hour)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "admin"}}
+
+	res, err := c.CreateToken(context.Background(), session, "sa", &CreateTokenInput{Identifier: "token"})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	got := tokenLifetime(res.Token)
+	if got == nil || *got != 48*time.Hour {
+		t.Errorf("Want token lifetime %s, got %v", 48*time.Hour, got)
+	}
+	if res.Token.Type != enum.TokenTypeSAT {
+		t.Errorf("Want token type %q, got %q", enum.TokenTypeSAT, res.Token.Type)
+	}
+}
+
+func TestCreateToken_ExplicitLifetime(t *testing.T) {
+	c := setupCreateTokenController(t, 48*time.Hour)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "admin"}}
+
+	in := &CreateTokenInput{Identifier: "token", Lifetime: ptr.Duration(72 * time.Hour)}
+	res, err := c.CreateToken(context.Background(), session, "sa", in)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	got := tokenLifetime(res.Token)
+	if got == nil || *got != 72*time.Hour {
+		t.Errorf("Want token lifetime %s, got %v", 72*time.Hour, got)
+	}
+}
+
+func TestCreateToken_NoLifetimeConfigured(t *testing.T) {
+	c := setupCreateTokenController(t, 0)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "admin"}}
+
+	res, err := c.CreateToken(context.Background(), session, "sa", &CreateTokenInput{Identifier: "token"})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if res.Token.ExpiresAt != nil {
+		t.Errorf("Want token without expiration, got %d", *res.Token.ExpiresAt)
+	}
+}
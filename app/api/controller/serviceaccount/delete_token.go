@@ -32,6 +32,10 @@ func (c *Controller) DeleteToken(
 	saUID string,
 	identifier string,
 ) error {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return err
+	}
+
 	sa, err := findServiceAccountFromUID(ctx, c.principalStore, saUID)
 	if err != nil {
 		return err
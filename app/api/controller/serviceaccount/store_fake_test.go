@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccount
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// spaceStoreFake is an in-memory space store holding a single space.
+type spaceStoreFake struct {
+	store.SpaceStore
+	space *types.Space
+}
+
+func (f *spaceStoreFake) Find(context.Context, int64) (*types.Space, error) {
+	return f.space, nil
+}
+
+// principalStoreFake is an in-memory principal store holding a single service account.
+type principalStoreFake struct {
+	store.PrincipalStore
+	sa *types.ServiceAccount
+}
+
+func (f *principalStoreFake) FindServiceAccountByUID(context.Context, string) (*types.ServiceAccount, error) {
+	return f.sa, nil
+}
+
+// tokenStoreFake is an in-memory token store.
+type tokenStoreFake struct {
+	store.TokenStore
+	tokens []*types.Token
+}
+
+func (f *tokenStoreFake) Create(_ context.Context, token *types.Token) error {
+	token.ID = int64(len(f.tokens) + 1)
+	f.tokens = append(f.tokens, token)
+	return nil
+}
@@ -17,6 +17,8 @@ package serviceaccount
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 
 	"github.com/google/wire"
@@ -27,8 +29,9 @@ var WireSet = wire.NewSet(
 	NewController,
 )
 
-func ProvideController(principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
+func ProvideController(config *types.Config, principalUIDCheck check.PrincipalUID, authorizer authz.Authorizer,
 	principalStore store.PrincipalStore, spaceStore store.SpaceStore, repoStore store.RepoStore,
-	tokenStore store.TokenStore) *Controller {
-	return NewController(principalUIDCheck, authorizer, principalStore, spaceStore, repoStore, tokenStore)
+	tokenStore store.TokenStore, tokenIssuer *token.Issuer) *Controller {
+	return NewController(config, principalUIDCheck, authorizer, principalStore, spaceStore, repoStore,
+		tokenStore, tokenIssuer)
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -59,6 +60,7 @@ type Controller struct {
 	exporter        *exporter.Repository
 	resourceLimiter limiter.ResourceLimiter
 	auditService    audit.Service
+	locker          *locker.Locker
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -67,7 +69,7 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore, spaceStore store.SpaceStore,
 	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
 	membershipStore store.MembershipStore, importer *importer.Repository, exporter *exporter.Repository,
-	limiter limiter.ResourceLimiter, auditService audit.Service,
+	limiter limiter.ResourceLimiter, auditService audit.Service, locker *locker.Locker,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled:           config.NestedSpacesEnabled,
@@ -91,5 +93,6 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		exporter:                      exporter,
 		resourceLimiter:               limiter,
 		auditService:                  auditService,
+		locker:                        locker,
 	}
 }
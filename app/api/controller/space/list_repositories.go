@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -48,7 +49,17 @@ func (c *Controller) ListRepositories(
 		return nil, 0, err
 	}
 
-	return c.ListRepositoriesNoAuth(ctx, space.ID, filter)
+	repos, count, err := c.ListRepositoriesNoAuth(ctx, space.ID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// backfill clone urls for the caller
+	for _, repo := range repos {
+		controller.BackfillRepoCloneURLs(c.urlProvider, session, repo)
+	}
+
+	return repos, count, nil
 }
 
 // ListRepositoriesNoAuth list repositories WITHOUT checking for PermissionRepoView.
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// membershipStoreFake holds the members of a single space and applies the role filter.
+type membershipStoreFake struct {
+	store.MembershipStore
+	members []types.MembershipUser
+}
+
+func (f *membershipStoreFake) ListUsers(
+	_ context.Context,
+	_ int64,
+	filter types.MembershipUserFilter,
+) ([]types.MembershipUser, error) {
+	var list []types.MembershipUser
+	for _, m := range f.members {
+		if filter.Role == "" || m.Role == filter.Role {
+			list = append(list, m)
+		}
+	}
+	return list, nil
+}
+
+// txFake runs the transaction function without an actual transaction.
+type txFake struct{}
+
+func (txFake) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+func TestMembershipList(t *testing.T) {
+	member := func(id int64, role enum.MembershipRole) types.MembershipUser {
+		return types.MembershipUser{Membership: types.Membership{
+			MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: id},
+			Role:          role,
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		deny      fake.DenyFunc
+		role      enum.MembershipRole
+		wantIDs   []int64
+		wantError int
+	}{
+		{name: "all", wantIDs: []int64{1, 2, 3}},
+		{name: "admin-role", role: enum.MembershipRoleSpaceOwner, wantIDs: []int64{1, 3}},
+		{name: "non-member", deny: fake.DenyAll, wantError: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{
+				tx:         txFake{},
+				authorizer: &fake.Authorizer{Deny: test.deny},
+				spaceStore: &spaceStoreFake{space: &types.Space{ID: 1, Path: "space", Identifier: "space"}},
+				membershipStore: &membershipStoreFake{members: []types.MembershipUser{
+					member(1, enum.MembershipRoleSpaceOwner),
+					member(2, enum.MembershipRoleReader),
+					member(3, enum.MembershipRoleSpaceOwner),
+				}},
+			}
+
+			filter := types.MembershipUserFilter{
+				ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Page: 1, Size: 10}},
+				Role:            test.role,
+			}
+
+			list, count, err := c.MembershipList(context.Background(), &auth.Session{}, "space", filter)
+
+			if test.wantError != 0 {
+				if got := usererror.Translate(context.Background(), err); got.Status != test.wantError {
+					t.Errorf("Want status %d, got %v", test.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+
+			if count != int64(len(test.wantIDs)) {
+				t.Errorf("Want count %d, got %d", len(test.wantIDs), count)
+			}
+			if len(list) != len(test.wantIDs) {
+				t.Fatalf("Want %d members, got %d", len(test.wantIDs), len(list))
+			}
+			for i, m := range list {
+				if m.PrincipalID != test.wantIDs[i] {
+					t.Errorf("Want member %d at position %d, got %d", test.wantIDs[i], i, m.PrincipalID)
+				}
+			}
+		})
+	}
+}
@@ -16,11 +16,14 @@ package space
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -40,6 +43,9 @@ func (i *MoveInput) hasChanges(space *types.Space) bool {
 	return false
 }
 
+// movePathsLockExpiry is the expiry of the lock held on the parent space paths while moving.
+const movePathsLockExpiry = 30 * time.Second
+
 // Move moves a space to a new identifier.
 // TODO: Add support for moving to other parents and alias.
 //
@@ -68,6 +74,13 @@ func (c *Controller) Move(
 		return space, nil
 	}
 
+	// serialize path changes within the parent space to avoid concurrent renames colliding.
+	unlock, err := c.locker.LockSpacePaths(ctx, space.ParentID, movePathsLockExpiry)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	if err = c.moveInner(
 		ctx,
 		session,
@@ -124,6 +137,10 @@ func (c *Controller) moveInner(
 			Updated:    now,
 		}
 		err = c.spacePathStore.InsertSegment(ctx, newPrimarySegment)
+		if errors.Is(err, store.ErrDuplicate) {
+			return usererror.Conflict(fmt.Sprintf("A space or repository with identifier '%s' already exists.",
+				space.Identifier))
+		}
 		if err != nil {
 			return fmt.Errorf("failed to create new primary path segment: %w", err)
 		}
@@ -134,6 +151,14 @@ func (c *Controller) moveInner(
 			return fmt.Errorf("failed to update the space in the db: %w", err)
 		}
 
+		// paths of descendant spaces and repos are derived from the path segments and thus
+		// follow the move automatically - only the path of the space itself has to be refreshed.
+		spacePath, err := c.spacePathStore.FindPrimaryBySpaceID(ctx, space.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find new primary path of the space: %w", err)
+		}
+		space.Path = spacePath.Value
+
 		return nil
 	})
 }
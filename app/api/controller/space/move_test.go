@@ -0,0 +1,200 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/gotidy/ptr"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/xid"
+)
+
+// moveFixture holds the stores of an in-memory database containing the following tree:
+//
+//	acme/team/sub/app (repo)
+//	acme/team/svc (repo)
+//	acme/taken
+type moveFixture struct {
+	ctrl      *Controller
+	spaces    *database.SpaceStore
+	repos     *database.RepoStore
+	repoAppID int64
+	repoSvcID int64
+}
+
+func setupMoveFixture(t *testing.T) *moveFixture {
+	t.Helper()
+
+	db, err := sqlx.Connect("sqlite3", "file:"+xid.New().String()+".db?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	ctx := context.Background()
+	if err = migrate.Migrate(ctx, db); err != nil {
+		t.Fatalf("failed to migrate db: %v", err)
+	}
+
+	principalStore := database.NewPrincipalStore(db, store.ToLowerPrincipalUIDTransformation)
+	spacePathStore := database.NewSpacePathStore(db, store.ToLowerSpacePathTransformation)
+	spacePathCache := cache.New(spacePathStore, store.ToLowerSpacePathTransformation)
+	spaceStore := database.NewSpaceStore(db, spacePathCache, spacePathStore)
+	repoStore := database.NewRepoStore(db, spacePathCache, spacePathStore, spaceStore)
+
+	if err = principalStore.CreateUser(ctx, &types.User{ID: 1, UID: "user"}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	createSpace := func(id, parentID int64, identifier string) {
+		if err := spaceStore.Create(ctx, &types.Space{
+			ID: id, ParentID: parentID, Identifier: identifier, CreatedBy: 1,
+		}); err != nil {
+			t.Fatalf("failed to create space %q: %v", identifier, err)
+		}
+		if err := spacePathStore.InsertSegment(ctx, &types.SpacePathSegment{
+			ParentID: parentID, Identifier: identifier, SpaceID: id, IsPrimary: true, CreatedBy: 1,
+		}); err != nil {
+			t.Fatalf("failed to create path segment %q: %v", identifier, err)
+		}
+	}
+	createSpace(1, 0, "acme")
+	createSpace(2, 1, "team")
+	createSpace(3, 1, "taken")
+	createSpace(4, 2, "sub")
+
+	createRepo := func(parentID int64, identifier string) int64 {
+		repo := &types.Repository{ParentID: parentID, Identifier: identifier, GitUID: identifier}
+		if err := repoStore.Create(ctx, repo); err != nil {
+			t.Fatalf("failed to create repo %q: %v", identifier, err)
+		}
+		return repo.ID
+	}
+
+	return &moveFixture{
+		ctrl: &Controller{
+			tx:              dbtx.New(db),
+			identifierCheck: check.SpaceIdentifierDefault,
+			authorizer:      &fake.Authorizer{},
+			spacePathStore:  spacePathStore,
+			spaceStore:      spaceStore,
+			locker: locker.NewLocker(lock.NewInMemory(lock.Config{
+				App:        "gitness",
+				Expiry:     time.Minute,
+				Tries:      10,
+				RetryDelay: 10 * time.Millisecond,
+			})),
+		},
+		spaces:    spaceStore,
+		repos:     repoStore,
+		repoAppID: createRepo(4, "app"),
+		repoSvcID: createRepo(2, "svc"),
+	}
+}
+
+func (f *moveFixture) assertPaths(t *testing.T, spaces map[int64]string, repos map[int64]string) {
+	t.Helper()
+
+	ctx := context.Background()
+	for id, want := range spaces {
+		space, err := f.spaces.Find(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to find space %d: %v", id, err)
+		}
+		if space.Path != want {
+			t.Errorf("Want space %d to have path %q, got %q", id, want, space.Path)
+		}
+	}
+	for id, want := range repos {
+		repo, err := f.repos.Find(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to find repo %d: %v", id, err)
+		}
+		if repo.Path != want {
+			t.Errorf("Want repo %d to have path %q, got %q", id, want, repo.Path)
+		}
+	}
+}
+
+func TestMove_CascadesPaths(t *testing.T) {
+	f := setupMoveFixture(t)
+
+	space, err := f.ctrl.Move(context.Background(), &auth.Session{}, "acme/team",
+		&MoveInput{Identifier: ptr.String("platform")})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if space.Identifier != "platform" || space.Path != "acme/platform" {
+		t.Errorf("Want space 'platform' at 'acme/platform', got %q at %q", space.Identifier, space.Path)
+	}
+
+	f.assertPaths(t,
+		map[int64]string{2: "acme/platform", 3: "acme/taken", 4: "acme/platform/sub"},
+		map[int64]string{f.repoAppID: "acme/platform/sub/app", f.repoSvcID: "acme/platform/svc"},
+	)
+}
+
+func TestMove_InvalidIdentifier(t *testing.T) {
+	f := setupMoveFixture(t)
+
+	_, err := f.ctrl.Move(context.Background(), &auth.Session{}, "acme/team",
+		&MoveInput{Identifier: ptr.String("not valid")})
+	if err == nil {
+		t.Fatalf("Want invalid identifier to be rejected")
+	}
+
+	f.assertPaths(t, map[int64]string{2: "acme/team"}, nil)
+}
+
+func TestMove_CollisionRollsBack(t *testing.T) {
+	f := setupMoveFixture(t)
+
+	_, err := f.ctrl.Move(context.Background(), &auth.Session{}, "acme/team",
+		&MoveInput{Identifier: ptr.String("Taken")})
+	if status := usererror.Translate(context.Background(), err).Status; status != http.StatusConflict {
+		t.Errorf("Want status %d, got %d (%v)", http.StatusConflict, status, err)
+	}
+
+	space, err := f.spaces.Find(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("failed to find space: %v", err)
+	}
+	if space.Identifier != "team" {
+		t.Errorf("Want identifier to stay %q, got %q", "team", space.Identifier)
+	}
+
+	f.assertPaths(t,
+		map[int64]string{2: "acme/team", 3: "acme/taken", 4: "acme/team/sub"},
+		map[int64]string{f.repoAppID: "acme/team/sub/app", f.repoSvcID: "acme/team/svc"},
+	)
+}
@@ -28,13 +28,15 @@ import (
 
 // UpdateInput is used for updating a space.
 type UpdateInput struct {
-	Description *string `json:"description"`
-	IsPublic    *bool   `json:"is_public"`
+	Description   *string `json:"description"`
+	IsPublic      *bool   `json:"is_public"`
+	DefaultBranch *string `json:"default_branch"`
 }
 
 func (in *UpdateInput) hasChanges(space *types.Space) bool {
 	return (in.Description != nil && *in.Description != space.Description) ||
-		(in.IsPublic != nil && *in.IsPublic != space.IsPublic)
+		(in.IsPublic != nil && *in.IsPublic != space.IsPublic) ||
+		(in.DefaultBranch != nil && *in.DefaultBranch != space.DefaultBranch)
 }
 
 // Update updates a space.
@@ -65,6 +67,9 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		if in.IsPublic != nil {
 			space.IsPublic = *in.IsPublic
 		}
+		if in.DefaultBranch != nil {
+			space.DefaultBranch = *in.DefaultBranch
+		}
 
 		return nil
 	})
@@ -89,5 +94,15 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	// an empty default branch resets the space to the global default branch.
+	if in.DefaultBranch != nil {
+		*in.DefaultBranch = strings.TrimSpace(*in.DefaultBranch)
+		if *in.DefaultBranch != "" {
+			if err := check.BranchName(*in.DefaultBranch); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
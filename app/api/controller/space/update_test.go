@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/gotidy/ptr"
+)
+
+type spaceStoreFake struct {
+	store.SpaceStore
+	space *types.Space
+}
+
+func (f *spaceStoreFake) FindByRef(context.Context, string) (*types.Space, error) {
+	space := *f.space
+	return &space, nil
+}
+
+func (f *spaceStoreFake) UpdateOptLock(
+	_ context.Context,
+	space *types.Space,
+	mutateFn func(space *types.Space) error,
+) (*types.Space, error) {
+	if err := mutateFn(space); err != nil {
+		return nil, err
+	}
+	f.space = space
+	return space, nil
+}
+
+func setupUpdateController() (*Controller, *spaceStoreFake) {
+	spaceStore := &spaceStoreFake{space: &types.Space{ID: 1, Path: "space", Identifier: "space"}}
+	return &Controller{
+		authorizer: &fake.Authorizer{},
+		spaceStore: spaceStore,
+	}, spaceStore
+}
+
+func TestUpdate_DefaultBranch(t *testing.T) {
+	c, spaceStore := setupUpdateController()
+
+	space, err := c.Update(context.Background(), &auth.Session{}, "space",
+		&UpdateInput{DefaultBranch: ptr.String(" release/main ")})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if space.DefaultBranch != "release/main" {
+		t.Errorf("Want default branch %q, got %q", "release/main", space.DefaultBranch)
+	}
+	if spaceStore.space.DefaultBranch != "release/main" {
+		t.Errorf("Want stored default branch %q, got %q", "release/main", spaceStore.space.DefaultBranch)
+	}
+}
+
+func TestUpdate_DefaultBranchInvalid(t *testing.T) {
+	for _, name := range []string{"feature..x", "main.lock", "-main", "ma in", "main~1", "refs//main", "@"} {
+		t.Run(name, func(t *testing.T) {
+			c, spaceStore := setupUpdateController()
+
+			_, err := c.Update(context.Background(), &auth.Session{}, "space",
+				&UpdateInput{DefaultBranch: ptr.String(name)})
+			if !errors.Is(err, check.ErrBranchNameInvalid) {
+				t.Errorf("Want error %v, got %v", check.ErrBranchNameInvalid, err)
+			}
+
+			if spaceStore.space.DefaultBranch != "" {
+				t.Errorf("Want default branch to stay unset, got %q", spaceStore.space.DefaultBranch)
+			}
+		})
+	}
+}
@@ -17,19 +17,46 @@ package system
 import (
 	"context"
 
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 )
 
 type Controller struct {
-	principalStore store.PrincipalStore
-	config         *types.Config
+	principalStore    store.PrincipalStore
+	config            *types.Config
+	auditChainService *audit.ChainService
+	cleaner           cleanup.Cleaner
+	operations        *operation.Registry
+	migrations        MigrationReporter
+	signingKeys       *jwt.KeySet
 }
 
-func NewController(principalStore store.PrincipalStore, config *types.Config) *Controller {
+// MigrationReporter reports the migration status of the database.
+type MigrationReporter interface {
+	Status(ctx context.Context) (*types.MigrationStatus, error)
+}
+
+func NewController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	auditChainService *audit.ChainService,
+	cleaner cleanup.Cleaner,
+	operations *operation.Registry,
+	migrations MigrationReporter,
+	signingKeys *jwt.KeySet,
+) *Controller {
 	return &Controller{
-		principalStore: principalStore,
-		config:         config,
+		principalStore:    principalStore,
+		config:            config,
+		auditChainService: auditChainService,
+		cleaner:           cleaner,
+		operations:        operations,
+		migrations:        migrations,
+		signingKeys:       signingKeys,
 	}
 }
 
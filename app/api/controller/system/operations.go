@@ -0,0 +1,36 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+// ListOperations lists the long-running operations that are active on this instance.
+func (c *Controller) ListOperations(context.Context) []types.Operation {
+	return c.operations.List()
+}
+
+// KillOperation cancels the long-running operation with the provided ID.
+func (c *Controller) KillOperation(_ context.Context, id int64) error {
+	if !c.operations.Kill(id) {
+		return usererror.NotFound("Operation not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// RunCleanup runs the cleanup of expired tokens, webhook executions and audit entries on demand.
+func (c *Controller) RunCleanup(ctx context.Context) ([]types.CleanupJobResult, error) {
+	results, err := c.cleaner.RunNow(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run cleanup: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/audit"
+)
+
+// VerifyAuditChain verifies the hash chain of the audit entries of the provided space path.
+func (c *Controller) VerifyAuditChain(ctx context.Context, spacePath string) (*audit.ChainVerification, error) {
+	if spacePath == "" {
+		return nil, usererror.BadRequest("Space path is required.")
+	}
+
+	result, err := c.auditChainService.Verify(ctx, spacePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify audit chain: %w", err)
+	}
+
+	return result, nil
+}
@@ -15,7 +15,11 @@
 package system
 
 import (
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -26,6 +30,14 @@ var WireSet = wire.NewSet(
 	NewController,
 )
 
-func ProvideController(principalStore store.PrincipalStore, config *types.Config) *Controller {
-	return NewController(principalStore, config)
+func ProvideController(
+	principalStore store.PrincipalStore,
+	config *types.Config,
+	auditChainService *audit.ChainService,
+	cleaner cleanup.Cleaner,
+	operations *operation.Registry,
+	migrations MigrationReporter,
+	signingKeys *jwt.KeySet,
+) *Controller {
+	return NewController(principalStore, config, auditChainService, cleaner, operations, migrations, signingKeys)
 }
@@ -40,7 +40,7 @@ func checkSecret(secret string) error {
 func checkActions(actions []enum.TriggerAction) error {
 	// ignore duplicates here, should be deduplicated later
 	for _, action := range actions {
-		if _, ok := action.Sanitize(); !ok {
+		if !action.Valid() {
 			return check.NewValidationErrorf("The provided trigger action '%s' is invalid.", action)
 		}
 	}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListActiveTokens lists all tokens that can still be used to authenticate as the current principal.
+func (c *Controller) ListActiveTokens(ctx context.Context,
+	session *auth.Session,
+) ([]*types.ActiveToken, error) {
+	user, err := c.principalStore.FindUser(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	tokens, err := c.tokenStore.ListActive(ctx, user.ID, user.TokenGeneration, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active tokens: %w", err)
+	}
+
+	currentTokenID := sessionTokenID(session)
+
+	activeTokens := make([]*types.ActiveToken, len(tokens))
+	for i, tkn := range tokens {
+		activeTokens[i] = &types.ActiveToken{
+			ID:         tkn.ID,
+			Type:       tkn.Type,
+			Identifier: tkn.Identifier,
+			IssuedAt:   tkn.IssuedAt,
+			LastUsed:   tkn.LastUsed,
+			ExpiresAt:  tkn.ExpiresAt,
+			Current:    tkn.ID == currentTokenID,
+		}
+	}
+
+	return activeTokens, nil
+}
+
+// RevokeActiveToken revokes a token of the current principal.
+// The token used to authenticate the current request is only revoked if force is set.
+func (c *Controller) RevokeActiveToken(ctx context.Context,
+	session *auth.Session,
+	tokenID int64,
+	force bool,
+) error {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return err
+	}
+
+	user, err := c.principalStore.FindUser(ctx, session.Principal.ID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	tkn, err := c.tokenStore.Find(ctx, tokenID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return usererror.ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find token: %w", err)
+	}
+
+	// throw a not found error - no need for user to know about tokens of other principals.
+	if tkn.PrincipalID != user.ID || tkn.Type == enum.TokenTypePasswordReset {
+		return usererror.ErrNotFound
+	}
+
+	if tkn.ID == sessionTokenID(session) && !force {
+		return usererror.BadRequest("The token used for the current request can only be revoked if forced.")
+	}
+
+	if err = c.tokenStore.Delete(ctx, tkn.ID); err != nil {
+		return fmt.Errorf("failed to delete token: %w", err)
+	}
+
+	return nil
+}
+
+// sessionTokenID returns the id of the token used to authenticate the session (0 if none).
+func sessionTokenID(session *auth.Session) int64 {
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok {
+		return tokenMetadata.TokenID
+	}
+
+	return 0
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func setupActiveTokensController(t *testing.T) (*Controller, *tokenStoreFake, *auth.Session) {
+	t.Helper()
+
+	c := setupLoginController(t)
+	c.authorizer = &fake.Authorizer{}
+
+	tokenStore, ok := c.tokenStore.(*tokenStoreFake)
+	if !ok {
+		t.Fatalf("unexpected token store type %T", c.tokenStore)
+	}
+
+	principal, err := c.principalStore.FindByUID(context.Background(), "user")
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+
+	expired := time.Now().Add(-time.Hour).UnixMilli()
+	for _, tkn := range []*types.Token{
+		{PrincipalID: principal.ID, Type: enum.TokenTypeSession, Identifier: "current"},
+		{PrincipalID: principal.ID, Type: enum.TokenTypePAT, Identifier: "pat"},
+		{PrincipalID: principal.ID, Type: enum.TokenTypePAT, Identifier: "expired", ExpiresAt: &expired},
+		{PrincipalID: principal.ID, Type: enum.TokenTypePasswordReset, Identifier: "reset"},
+		{PrincipalID: principal.ID + 1, Type: enum.TokenTypePAT, Identifier: "other"},
+	} {
+		_ = tokenStore.Create(context.Background(), tkn)
+	}
+
+	session := &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.TokenMetadata{TokenType: enum.TokenTypeSession, TokenID: 1},
+	}
+
+	return c, tokenStore, session
+}
+
+func TestListActiveTokens(t *testing.T) {
+	c, _, session := setupActiveTokensController(t)
+
+	tokens, err := c.ListActiveTokens(context.Background(), session)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if len(tokens) != 2 {
+		t.Fatalf("Want 2 active tokens, got %d", len(tokens))
+	}
+
+	if tokens[0].Identifier != "current" || !tokens[0].Current {
+		t.Errorf("Want current session token to be marked as current, got %+v", tokens[0])
+	}
+	if tokens[1].Identifier != "pat" || tokens[1].Current {
+		t.Errorf("Want pat to not be marked as current, got %+v", tokens[1])
+	}
+}
+
+func TestRevokeActiveToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		tokenID     int64
+		force       bool
+		wantStatus  int
+		wantDeleted bool
+	}{
+		{name: "revoke other token", tokenID: 2, wantDeleted: true},
+		{name: "revoke current token without force", tokenID: 1, wantStatus: http.StatusBadRequest},
+		{name: "revoke current token with force", tokenID: 1, force: true, wantDeleted: true},
+		{name: "revoke password reset token", tokenID: 4, wantStatus: http.StatusNotFound},
+		{name: "revoke token of other principal", tokenID: 5, wantStatus: http.StatusNotFound},
+		{name: "revoke unknown token", tokenID: 42, wantStatus: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, tokenStore, session := setupActiveTokensController(t)
+
+			err := c.RevokeActiveToken(context.Background(), session, test.tokenID, test.force)
+
+			if test.wantStatus != 0 {
+				var userErr *usererror.Error
+				if !errors.As(err, &userErr) || userErr.Status != test.wantStatus {
+					t.Fatalf("Want error with status %d, got %v", test.wantStatus, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+
+			_, err = tokenStore.Find(context.Background(), test.tokenID)
+			if deleted := err != nil; deleted != test.wantDeleted && test.tokenID <= 5 {
+				t.Errorf("Want token deleted %t, got %t", test.wantDeleted, deleted)
+			}
+		})
+	}
+}
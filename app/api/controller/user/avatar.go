@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // md5 is mandated by gravatar and not used for security purposes.
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// MaxAvatarSize is the maximum size of an uploaded avatar.
+	MaxAvatarSize = 1 << 20 // 1 MB
+
+	avatarBucketPathFmt = "avatars/%d/%s"
+)
+
+// supportedAvatarTypes are the mime types accepted for avatars.
+// NOTE: svg is deliberately not supported as it can contain scripts.
+var supportedAvatarTypes = map[string]struct{}{
+	"image/png":  {},
+	"image/jpeg": {},
+	"image/gif":  {},
+	"image/webp": {},
+}
+
+// Avatar contains the information required to serve the avatar of a user.
+// Either RedirectURL or Data is set.
+type Avatar struct {
+	// RedirectURL is the url the avatar is served from (signed blob url or gravatar fallback).
+	RedirectURL string
+	// Data is the content of the avatar.
+	Data io.ReadCloser
+	// ContentType is the content type of the avatar (only set together with Data).
+	ContentType string
+	// ETag uniquely identifies the current avatar of the user.
+	ETag string
+}
+
+// UpdateAvatar uploads a new avatar for the provided user.
+func (c *Controller) UpdateAvatar(ctx context.Context, session *auth.Session,
+	userUID string, file io.Reader) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if file == nil {
+		return nil, usererror.BadRequest("No avatar provided.")
+	}
+
+	// read one byte more than allowed to detect oversized avatars.
+	data, err := io.ReadAll(io.LimitReader(file, MaxAvatarSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read avatar: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, usererror.BadRequest("No avatar provided.")
+	}
+	if len(data) > MaxAvatarSize {
+		return nil, usererror.BadRequestf("Avatar exceeds the maximum allowed size of %d bytes.", MaxAvatarSize)
+	}
+
+	mType := mimetype.Detect(data)
+	if _, ok := supportedAvatarTypes[mType.String()]; !ok {
+		return nil, usererror.BadRequestf("Avatar has unsupported type %q.", mType.String())
+	}
+
+	// a new file is used for every upload to avoid serving stale avatars from caches.
+	fileName := uuid.New().String() + mType.Extension()
+	if err = c.blobStore.Upload(ctx, bytes.NewReader(data), getAvatarBucketPath(user.ID, fileName)); err != nil {
+		return nil, fmt.Errorf("failed to upload avatar: %w", err)
+	}
+
+	oldFileName := user.Avatar
+
+	user.Avatar = fileName
+	user.Updated = time.Now().UnixMilli()
+
+	err = c.principalStore.UpdateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	// the previous avatar is no longer referenced - failing to delete it only leaves an orphaned file behind.
+	if oldFileName != "" {
+		err = c.blobStore.Delete(ctx, getAvatarBucketPath(user.ID, oldFileName))
+		if err != nil && !errors.Is(err, blob.ErrNotFound) {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete previous avatar of user %d", user.ID)
+		}
+	}
+
+	return user, nil
+}
+
+// GetAvatar returns the avatar of the provided user.
+// If the user didn't upload an avatar, the gravatar derived from the email of the user is returned (if enabled).
+func (c *Controller) GetAvatar(ctx context.Context, session *auth.Session,
+	userUID string) (*Avatar, error) {
+	// avatars are visible to every authenticated principal.
+	if session == nil {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Avatar == "" {
+		return c.getGravatar(user)
+	}
+
+	bucketPath := getAvatarBucketPath(user.ID, user.Avatar)
+
+	signedURL, err := c.blobStore.GetSignedURL(ctx, bucketPath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return &Avatar{
+			RedirectURL: signedURL,
+			ETag:        user.Avatar,
+		}, nil
+	}
+
+	data, err := c.blobStore.Download(ctx, bucketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download avatar from blobstore: %w", err)
+	}
+
+	return &Avatar{
+		Data:        data,
+		ContentType: mime.TypeByExtension(path.Ext(user.Avatar)),
+		ETag:        user.Avatar,
+	}, nil
+}
+
+func (c *Controller) getGravatar(user *types.User) (*Avatar, error) {
+	if c.config.Avatar.GravatarURL == "" {
+		return nil, usererror.ErrNotFound
+	}
+
+	hash := gravatarHash(user.Email)
+	return &Avatar{
+		RedirectURL: fmt.Sprintf("%s/%s?d=identicon", strings.TrimSuffix(c.config.Avatar.GravatarURL, "/"), hash),
+		ETag:        hash,
+	}, nil
+}
+
+// gravatarHash returns the hash of the email as expected by gravatar.
+func gravatarHash(email string) string {
+	//nolint:gosec // md5 is mandated by gravatar and not used for security purposes.
+	return fmt.Sprintf("%x", md5.Sum([]byte(strings.ToLower(strings.TrimSpace(email)))))
+}
+
+func getAvatarBucketPath(principalID int64, fileName string) string {
+	return fmt.Sprintf(avatarBucketPathFmt, principalID, fileName)
+}
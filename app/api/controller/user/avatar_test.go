@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+func setupAvatarController(t *testing.T) (*Controller, *blobStoreFake) {
+	t.Helper()
+
+	ctrl, _ := setupCreateController(t)
+	blobStore := &blobStoreFake{}
+	ctrl.blobStore = blobStore
+	ctrl.config.Avatar.GravatarURL = "https://gravatar.example.com/avatar/"
+
+	return ctrl, blobStore
+}
+
+func encodePNG(t *testing.T) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatalf("failed to encode png: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestUpdateAvatar(t *testing.T) {
+	ctrl, blobStore := setupAvatarController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+	data := encodePNG(t)
+
+	usr, err := ctrl.UpdateAvatar(context.Background(), session, "user", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if !strings.HasSuffix(usr.Avatar, ".png") {
+		t.Errorf("Want avatar file with .png extension, got %q", usr.Avatar)
+	}
+	if got := blobStore.files[getAvatarBucketPath(usr.ID, usr.Avatar)]; !bytes.Equal(got, data) {
+		t.Errorf("Want uploaded avatar to be stored in blob store")
+	}
+
+	avatar, err := ctrl.GetAvatar(context.Background(), session, "user")
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if avatar.Data == nil {
+		t.Fatalf("Want avatar data, got redirect to %q", avatar.RedirectURL)
+	}
+	defer avatar.Data.Close()
+
+	if avatar.ContentType != "image/png" {
+		t.Errorf("Want content type %q, got %q", "image/png", avatar.ContentType)
+	}
+	if avatar.ETag != usr.Avatar {
+		t.Errorf("Want etag %q, got %q", usr.Avatar, avatar.ETag)
+	}
+	if got, _ := io.ReadAll(avatar.Data); !bytes.Equal(got, data) {
+		t.Errorf("Want served avatar to match uploaded avatar")
+	}
+}
+
+func TestUpdateAvatar_DeletesPrevious(t *testing.T) {
+	ctrl, blobStore := setupAvatarController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+	first, err := ctrl.UpdateAvatar(context.Background(), session, "user", bytes.NewReader(encodePNG(t)))
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	firstPath := getAvatarBucketPath(first.ID, first.Avatar)
+
+	second, err := ctrl.UpdateAvatar(context.Background(), session, "user", bytes.NewReader(encodePNG(t)))
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if _, ok := blobStore.files[firstPath]; ok {
+		t.Errorf("Want previous avatar to be deleted from blob store")
+	}
+	if _, ok := blobStore.files[getAvatarBucketPath(second.ID, second.Avatar)]; !ok {
+		t.Errorf("Want new avatar to be stored in blob store")
+	}
+}
+
+func TestUpdateAvatar_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "too large", data: append(encodePNG(t), make([]byte, MaxAvatarSize)...)},
+		{name: "not an image", data: []byte("just some text")},
+		{name: "svg", data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`)},
+		{name: "empty", data: []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, blobStore := setupAvatarController(t)
+			session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+			_, err := ctrl.UpdateAvatar(context.Background(), session, "user", bytes.NewReader(tt.data))
+			if err == nil {
+				t.Fatalf("Want error, got none")
+			}
+			if status := usererror.Translate(context.Background(), err).Status; status != http.StatusBadRequest {
+				t.Errorf("Want status %d, got %d (%v)", http.StatusBadRequest, status, err)
+			}
+			if len(blobStore.files) != 0 {
+				t.Errorf("Want no file to be uploaded, got %d", len(blobStore.files))
+			}
+		})
+	}
+}
+
+func TestGetAvatar_GravatarFallback(t *testing.T) {
+	ctrl, _ := setupAvatarController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user"}}
+
+	avatar, err := ctrl.GetAvatar(context.Background(), session, "user")
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if avatar.Data != nil {
+		t.Fatalf("Want no avatar data for user without avatar")
+	}
+
+	// md5 of "user@example.com"
+	want := "https://gravatar.example.com/avatar/b58996c504c5638798eb6b511e6f49af?d=identicon"
+	if avatar.RedirectURL != want {
+		t.Errorf("Want redirect url %q, got %q", want, avatar.RedirectURL)
+	}
+
+	ctrl.config.Avatar.GravatarURL = ""
+	_, err = ctrl.GetAvatar(context.Background(), session, "user")
+	if status := usererror.Translate(context.Background(), err).Status; status != http.StatusNotFound {
+		t.Errorf("Want status %d with disabled fallback, got %d (%v)", http.StatusNotFound, status, err)
+	}
+}
+
+func TestGetAvatar_Unauthenticated(t *testing.T) {
+	ctrl, _ := setupAvatarController(t)
+
+	_, err := ctrl.GetAvatar(context.Background(), nil, "user")
+	if status := usererror.Translate(context.Background(), err).Status; status != http.StatusUnauthorized {
+		t.Errorf("Want status %d, got %d (%v)", http.StatusUnauthorized, status, err)
+	}
+}
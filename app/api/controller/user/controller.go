@@ -17,45 +17,104 @@ package user
 import (
 	"context"
 
+	"github.com/harness/gitness/app/api/middleware/ratelimit"
+	"github.com/harness/gitness/app/auth/authsource"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/auth/password"
+	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 type Controller struct {
+	config            *types.Config
 	tx                dbtx.Transactor
 	principalUIDCheck check.PrincipalUID
 	authorizer        authz.Authorizer
-	principalStore    store.PrincipalStore
-	tokenStore        store.TokenStore
-	membershipStore   store.MembershipStore
+	authSource        authsource.Source
+	passwordHasher    password.Hasher
+	// oidcProvider is the identity provider used for oidc login (nil if disabled).
+	oidcProvider    oidc.Provider
+	principalStore  store.PrincipalStore
+	tokenStore      store.TokenStore
+	tokenIssuer     *token.Issuer
+	publicKeyStore  store.PublicKeyStore
+	userEmailStore  store.UserEmailStore
+	deployKeyStore  store.DeployKeyStore
+	membershipStore store.MembershipStore
+	spaceStore      store.SpaceStore
+	repoStore       store.RepoStore
+	mailer          mailer.Mailer
+	auditService    audit.Service
+
+	idempotencyKeyStore store.IdempotencyKeyStore
+	blobStore           blob.Store
+
+	// passwordResetLimiter limits the password reset requests per account (nil if disabled).
+	passwordResetLimiter ratelimit.Limiter
 }
 
 func NewController(
+	config *types.Config,
 	tx dbtx.Transactor,
 	principalUIDCheck check.PrincipalUID,
 	authorizer authz.Authorizer,
+	authSource authsource.Source,
+	passwordHasher password.Hasher,
+	oidcProvider oidc.Provider,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	tokenIssuer *token.Issuer,
+	publicKeyStore store.PublicKeyStore,
+	userEmailStore store.UserEmailStore,
+	deployKeyStore store.DeployKeyStore,
 	membershipStore store.MembershipStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	blobStore blob.Store,
+	mailer mailer.Mailer,
+	auditService audit.Service,
 ) *Controller {
+	var passwordResetLimiter ratelimit.Limiter
+	if config.RateLimit.PasswordResetAccount > 0 {
+		passwordResetLimiter = ratelimit.NewFixedWindow(config.RateLimit.PasswordResetAccount, config.RateLimit.Window)
+	}
+
 	return &Controller{
+		config:            config,
 		tx:                tx,
 		principalUIDCheck: principalUIDCheck,
 		authorizer:        authorizer,
+		authSource:        authSource,
+		passwordHasher:    passwordHasher,
+		oidcProvider:      oidcProvider,
 		principalStore:    principalStore,
 		tokenStore:        tokenStore,
+		tokenIssuer:       tokenIssuer,
+		publicKeyStore:    publicKeyStore,
+		userEmailStore:    userEmailStore,
+		deployKeyStore:    deployKeyStore,
 		membershipStore:   membershipStore,
+		spaceStore:        spaceStore,
+		repoStore:         repoStore,
+		mailer:            mailer,
+		auditService:      auditService,
+
+		idempotencyKeyStore: idempotencyKeyStore,
+		blobStore:           blobStore,
+
+		passwordResetLimiter: passwordResetLimiter,
 	}
 }
 
-var hashPassword = bcrypt.GenerateFromPassword
-
 func findUserFromUID(ctx context.Context,
 	principalStore store.PrincipalStore, userUID string,
 ) (*types.User, error) {
@@ -21,13 +21,13 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/dchest/uniuri"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // CreateInput is the input used for create operations.
@@ -40,7 +40,15 @@ type CreateInput struct {
 }
 
 // Create creates a new user.
-func (c *Controller) Create(ctx context.Context, session *auth.Session, in *CreateInput) (*types.User, error) {
+// In dry-run mode the input is validated and checked for uniqueness, but the user isn't stored.
+// If an idempotency key is provided, retries with the same key return the originally created user.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	in *CreateInput,
+	dryRun bool,
+	idempotency types.IdempotencyRequest,
+) (*types.User, error) {
 	// Ensure principal has required permissions (user is global, no explicit resource)
 	scope := &types.Scope{}
 	resource := &types.Resource{
@@ -50,7 +58,65 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, err
 	}
 
-	return c.CreateNoAuth(ctx, in, false)
+	if dryRun {
+		return c.createDryRun(ctx, in)
+	}
+
+	userID, err := controller.FindIdempotentResourceID(ctx, c.idempotencyKeyStore, c.config.Idempotency.KeyTTL,
+		session.Principal.ID, enum.ResourceTypeUser, idempotency)
+	if err != nil {
+		return nil, err
+	}
+	if userID != 0 {
+		return c.principalStore.FindUser(ctx, userID)
+	}
+
+	var user *types.User
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		user, err = c.CreateNoAuth(ctx, in, false)
+		if err != nil {
+			return err
+		}
+
+		return controller.StoreIdempotencyKey(ctx, c.idempotencyKeyStore,
+			session.Principal.ID, enum.ResourceTypeUser, idempotency, user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// createDryRun returns the user that would be created for the input, without storing it.
+func (c *Controller) createDryRun(ctx context.Context, in *CreateInput) (*types.User, error) {
+	if err := c.sanitizeCreateInput(in); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	if err := controller.CheckPrincipalUnique(ctx, c.principalStore, in.UID, in.Email); err != nil {
+		return nil, err
+	}
+	if err := c.checkSecondaryEmailUnique(ctx, in.Email, 0); err != nil {
+		return nil, err
+	}
+
+	uCount, err := c.principalStore.CountUsers(ctx, &types.UserFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	return &types.User{
+		UID:         in.UID,
+		DisplayName: in.DisplayName,
+		Email:       in.Email,
+		Created:     now,
+		Updated:     now,
+		// first 'user' principal will be admin by default.
+		Admin: uCount == 0,
+	}, nil
 }
 
 /*
@@ -60,11 +126,29 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
  * Note: take admin separately to avoid potential vulnerabilities for user calls.
  */
 func (c *Controller) CreateNoAuth(ctx context.Context, in *CreateInput, admin bool) (*types.User, error) {
+	return c.createNoAuth(ctx, in, admin, nil)
+}
+
+// createNoAuth creates the user without any auth checks.
+// If provided, mutateFn is called before the user is stored, to store additional fields with the same insert.
+func (c *Controller) createNoAuth(
+	ctx context.Context,
+	in *CreateInput,
+	admin bool,
+	mutateFn func(user *types.User),
+) (*types.User, error) {
 	if err := c.sanitizeCreateInput(in); err != nil {
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
-	hash, err := hashPassword([]byte(in.Password), bcrypt.DefaultCost)
+	if err := controller.CheckPrincipalEmailUnique(ctx, c.principalStore, in.Email, 0); err != nil {
+		return nil, err
+	}
+	if err := c.checkSecondaryEmailUnique(ctx, in.Email, 0); err != nil {
+		return nil, err
+	}
+
+	hash, err := c.passwordHasher.Hash(in.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create hash: %w", err)
 	}
@@ -73,12 +157,15 @@ func (c *Controller) CreateNoAuth(ctx context.Context, in *CreateInput, admin bo
 		UID:         in.UID,
 		DisplayName: in.DisplayName,
 		Email:       in.Email,
-		Password:    string(hash),
+		Password:    hash,
 		Salt:        uniuri.NewLen(uniuri.UUIDLen),
 		Created:     time.Now().UnixMilli(),
 		Updated:     time.Now().UnixMilli(),
 		Admin:       admin,
 	}
+	if mutateFn != nil {
+		mutateFn(user)
+	}
 
 	err = c.principalStore.CreateUser(ctx, user)
 	if err != nil {
@@ -107,7 +194,8 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return err
 	}
 
-	in.Email = strings.TrimSpace(in.Email)
+	// emails are unique regardless of their case, so they're stored in lower case.
+	in.Email = strings.ToLower(strings.TrimSpace(in.Email))
 	if err := check.Email(in.Email); err != nil {
 		return err
 	}
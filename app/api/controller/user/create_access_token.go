@@ -20,8 +20,8 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
-	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -29,9 +29,10 @@ import (
 
 type CreateTokenInput struct {
 	// TODO [CODE-1363]: remove after identifier migration.
-	UID        string         `json:"uid" deprecated:"true"`
-	Identifier string         `json:"identifier"`
-	Lifetime   *time.Duration `json:"lifetime"`
+	UID        string            `json:"uid" deprecated:"true"`
+	Identifier string            `json:"identifier"`
+	Lifetime   *time.Duration    `json:"lifetime"`
+	Scopes     []enum.TokenScope `json:"scopes"`
 }
 
 /*
@@ -43,10 +44,19 @@ func (c *Controller) CreateAccessToken(
 	userUID string,
 	in *CreateTokenInput,
 ) (*types.TokenResponse, error) {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return nil, err
+	}
+
 	if err := c.sanitizeCreateTokenInput(in); err != nil {
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
+	// scoped tokens must never be able to create tokens with more privileges than they have themselves.
+	if !auth.CanGrantScopes(session, in.Scopes) {
+		return nil, usererror.Forbidden("The token scopes have to be a subset of the scopes of the current token.")
+	}
+
 	user, err := findUserFromUID(ctx, c.principalStore, userUID)
 	if err != nil {
 		return nil, err
@@ -57,13 +67,13 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
-	token, jwtToken, err := token.CreatePAT(
+	token, jwtToken, err := c.tokenIssuer.CreatePAT(
 		ctx,
-		c.tokenStore,
 		&session.Principal,
 		user,
 		in.Identifier,
 		in.Lifetime,
+		in.Scopes,
 	)
 	if err != nil {
 		return nil, err
@@ -82,10 +92,14 @@ func (c *Controller) sanitizeCreateTokenInput(in *CreateTokenInput) error {
 		return err
 	}
 
-	//nolint:revive
 	if err := check.TokenLifetime(in.Lifetime, true); err != nil {
 		return err
 	}
 
+	//nolint:revive
+	if err := check.TokenScopes(in.Scopes); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestSanitizeCreateTokenInput_Scopes(t *testing.T) {
+	c := &Controller{}
+
+	in := &CreateTokenInput{
+		Identifier: "token",
+		Scopes:     []enum.TokenScope{enum.TokenScopeRepoRead},
+	}
+	if err := c.sanitizeCreateTokenInput(in); err != nil {
+		t.Errorf("Want no error for known scope, got %v", err)
+	}
+
+	in = &CreateTokenInput{
+		Identifier: "token",
+		Scopes:     []enum.TokenScope{"repo:delete"},
+	}
+	err := c.sanitizeCreateTokenInput(in)
+
+	var validationErr *check.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Want validation error for unknown scope, got %v", err)
+	}
+}
+
+func TestCreateAccessToken_ScopesLimitedBySession(t *testing.T) {
+	c := setupLoginController(t)
+	session := &auth.Session{Metadata: &auth.TokenMetadata{
+		TokenType: enum.TokenTypePAT,
+		Scopes:    []enum.TokenScope{enum.TokenScopeRepoRead},
+	}}
+
+	tests := []struct {
+		name   string
+		scopes []enum.TokenScope
+	}{
+		{name: "unscoped token", scopes: nil},
+		{name: "wider scope", scopes: []enum.TokenScope{enum.TokenScopeRepoWrite}},
+		{name: "other scope", scopes: []enum.TokenScope{enum.TokenScopeRepoRead, enum.TokenScopeUserAdmin}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := c.CreateAccessToken(context.Background(), session, "user",
+				&CreateTokenInput{Identifier: "token", Scopes: test.scopes})
+
+			if status := usererror.Translate(context.Background(), err).Status; status != http.StatusForbidden {
+				t.Errorf("Want status %d, got %d (%v)", http.StatusForbidden, status, err)
+			}
+		})
+	}
+}
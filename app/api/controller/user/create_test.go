@@ -0,0 +1,292 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+func setupCreateController(t *testing.T) (*Controller, *principalStoreFake) {
+	t.Helper()
+
+	ctrl := setupLoginController(t)
+	ctrl.principalUIDCheck = check.PrincipalUIDDefault
+	ctrl.authorizer = &fake.Authorizer{}
+	ctrl.tx = txFake{}
+	ctrl.idempotencyKeyStore = &idempotencyKeyStoreFake{}
+	ctrl.userEmailStore = &userEmailStoreFake{}
+	ctrl.config.Idempotency.KeyTTL = time.Hour
+
+	principalStore, ok := ctrl.principalStore.(*principalStoreFake)
+	if !ok {
+		t.Fatalf("unexpected principal store type %T", ctrl.principalStore)
+	}
+
+	return ctrl, principalStore
+}
+
+func TestCreate_DryRun(t *testing.T) {
+	ctrl, principalStore := setupCreateController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+	usr, err := ctrl.Create(context.Background(), session, &CreateInput{
+		UID:         "new-user",
+		Email:       " new-user@example.com ",
+		DisplayName: "New User",
+		Password:    "password",
+	}, true, types.IdempotencyRequest{})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if principalStore.createUserCalls != 0 {
+		t.Errorf("Want no store create calls, got %d", principalStore.createUserCalls)
+	}
+
+	if usr.UID != "new-user" || usr.Email != "new-user@example.com" || usr.Admin {
+		t.Errorf("Want sanitized non-admin user to be returned, got %+v", usr)
+	}
+
+	if _, err = principalStore.FindByUID(context.Background(), "new-user"); err == nil {
+		t.Errorf("Want user to not exist after dry run")
+	}
+}
+
+func TestCreate_DryRunValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         *CreateInput
+		wantStatus int
+	}{
+		{
+			name:       "missing email",
+			in:         &CreateInput{UID: "new-user", Email: " ", DisplayName: "New User", Password: "password"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing display name",
+			in:         &CreateInput{UID: "new-user", Email: "new-user@example.com", Password: "password"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "duplicate uid",
+			in:         &CreateInput{UID: "USER", Email: "new-user@example.com", DisplayName: "New User", Password: "password"},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "duplicate email",
+			in:         &CreateInput{UID: "new-user", Email: "sa@example.com", DisplayName: "New User", Password: "password"},
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, principalStore := setupCreateController(t)
+			session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+			_, err := ctrl.Create(context.Background(), session, tt.in, true, types.IdempotencyRequest{})
+
+			if status := usererror.Translate(context.Background(), err).Status; status != tt.wantStatus {
+				t.Errorf("Want status %d, got %d (%v)", tt.wantStatus, status, err)
+			}
+
+			if principalStore.createUserCalls != 0 {
+				t.Errorf("Want no store create calls, got %d", principalStore.createUserCalls)
+			}
+		})
+	}
+}
+
+func TestCreate_NoDryRun(t *testing.T) {
+	ctrl, principalStore := setupCreateController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+	_, err := ctrl.Create(context.Background(), session, &CreateInput{
+		UID:         "new-user",
+		Email:       "new-user@example.com",
+		DisplayName: "New User",
+		Password:    "password",
+	}, false, types.IdempotencyRequest{})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if principalStore.createUserCalls != 1 {
+		t.Errorf("Want one store create call, got %d", principalStore.createUserCalls)
+	}
+
+	if _, err = principalStore.FindByUID(context.Background(), "new-user"); err != nil {
+		t.Errorf("Want created user to exist, got %v", err)
+	}
+}
+
+func TestCreate_EmailCaseInsensitive(t *testing.T) {
+	ctrl, principalStore := setupCreateController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+	_, err := ctrl.Create(context.Background(), session, &CreateInput{
+		UID:         "new-user",
+		Email:       "User@Example.com",
+		DisplayName: "New User",
+		Password:    "password",
+	}, false, types.IdempotencyRequest{})
+	if status := usererror.Translate(context.Background(), err).Status; status != http.StatusConflict {
+		t.Errorf("Want status %d, got %d (%v)", http.StatusConflict, status, err)
+	}
+
+	if principalStore.createUserCalls != 0 {
+		t.Errorf("Want no store create calls, got %d", principalStore.createUserCalls)
+	}
+
+	usr, err := ctrl.Create(context.Background(), session, &CreateInput{
+		UID:         "new-user",
+		Email:       "New-User@Example.com",
+		DisplayName: "New User",
+		Password:    "password",
+	}, false, types.IdempotencyRequest{})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if usr.Email != "new-user@example.com" {
+		t.Errorf("Want email to be stored in lower case, got %q", usr.Email)
+	}
+}
+
+func TestCreate_SecondaryEmailTaken(t *testing.T) {
+	for _, dryRun := range []bool{true, false} {
+		ctrl, principalStore := setupCreateController(t)
+		ctrl.userEmailStore = &userEmailStoreFake{emails: []*types.UserEmail{
+			{ID: 1, PrincipalID: 1, Email: "other@example.com", Verified: true},
+		}}
+		session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+		_, err := ctrl.Create(context.Background(), session, &CreateInput{
+			UID:         "new-user",
+			Email:       "Other@Example.com",
+			DisplayName: "New User",
+			Password:    "password",
+		}, dryRun, types.IdempotencyRequest{})
+		if status := usererror.Translate(context.Background(), err).Status; status != http.StatusConflict {
+			t.Errorf("Want status %d for dry run %t, got %d (%v)", http.StatusConflict, dryRun, status, err)
+		}
+
+		if principalStore.createUserCalls != 0 {
+			t.Errorf("Want no store create calls for dry run %t, got %d", dryRun, principalStore.createUserCalls)
+		}
+	}
+}
+
+func TestCreate_IdempotencyKey(t *testing.T) {
+	ctrl, principalStore := setupCreateController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+	in := func() *CreateInput {
+		return &CreateInput{
+			UID:         "new-user",
+			Email:       "new-user@example.com",
+			DisplayName: "New User",
+			Password:    "password",
+		}
+	}
+
+	idempotency := types.IdempotencyRequest{Key: "retry-key", Hash: "hash"}
+
+	first, err := ctrl.Create(context.Background(), session, in(), false, idempotency)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	retried, err := ctrl.Create(context.Background(), session, in(), false, idempotency)
+	if err != nil {
+		t.Fatalf("Want retried create to succeed, got %v", err)
+	}
+
+	if retried.ID != first.ID {
+		t.Errorf("Want retried create to return user %d, got %d", first.ID, retried.ID)
+	}
+	if principalStore.createUserCalls != 1 {
+		t.Errorf("Want exactly one store create call, got %d", principalStore.createUserCalls)
+	}
+}
+
+func TestCreate_IdempotencyKeyReused(t *testing.T) {
+	ctrl, principalStore := setupCreateController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+	in := &CreateInput{
+		UID:         "new-user",
+		Email:       "new-user@example.com",
+		DisplayName: "New User",
+		Password:    "password",
+	}
+
+	_, err := ctrl.Create(context.Background(), session, in, false,
+		types.IdempotencyRequest{Key: "retry-key", Hash: "hash"})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	_, err = ctrl.Create(context.Background(), session, in, false,
+		types.IdempotencyRequest{Key: "retry-key", Hash: "other-hash"})
+	if got, want := usererror.Translate(context.Background(), err).Status, http.StatusUnprocessableEntity; got != want {
+		t.Errorf("Want status %d for a reused key, got %d (%v)", want, got, err)
+	}
+	if principalStore.createUserCalls != 1 {
+		t.Errorf("Want exactly one store create call, got %d", principalStore.createUserCalls)
+	}
+}
+
+func TestCreate_IdempotencyKeyExpired(t *testing.T) {
+	ctrl, principalStore := setupCreateController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+	keyStore := &idempotencyKeyStoreFake{keys: []*types.IdempotencyKey{{
+		ID:           1,
+		PrincipalID:  session.Principal.ID,
+		ResourceType: enum.ResourceTypeUser,
+		Key:          "old-key",
+		ResourceID:   1,
+		Created:      time.Now().Add(-2 * time.Hour).UnixMilli(),
+	}}}
+	ctrl.idempotencyKeyStore = keyStore
+
+	usr, err := ctrl.Create(context.Background(), session, &CreateInput{
+		UID:         "new-user",
+		Email:       "new-user@example.com",
+		DisplayName: "New User",
+		Password:    "password",
+	}, false, types.IdempotencyRequest{Key: "old-key", Hash: "hash"})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if principalStore.createUserCalls != 1 {
+		t.Errorf("Want expired key to create a new user, got %d store create calls", principalStore.createUserCalls)
+	}
+	if len(keyStore.keys) != 1 || keyStore.keys[0].ResourceID != usr.ID {
+		t.Errorf("Want key to be linked to the new user %d, got %+v", usr.ID, keyStore.keys)
+	}
+}
@@ -34,6 +34,10 @@ func (c *Controller) DeleteToken(
 	userUID string,
 	tokenType enum.TokenType,
 	tokenIdentifier string) error {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return err
+	}
+
 	user, err := findUserFromUID(ctx, c.principalStore, userUID)
 	if err != nil {
 		return err
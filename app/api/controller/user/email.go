@@ -0,0 +1,451 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/notification/mailer"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const emailVerificationTokenLength = 32
+
+var (
+	errEmailDuplicate            = usererror.Conflict("The email is already used by a user.")
+	errEmailVerificationInvalid  = usererror.BadRequest("The email verification token is invalid.")
+	errEmailNotVerified          = usererror.BadRequest("Only verified emails can be made primary.")
+	errLastVerifiedEmail         = usererror.BadRequest("The last verified email of a user can't be removed.")
+	errEmailVerificationRequired = usererror.BadRequest("Verification token is required.")
+)
+
+// generateEmailVerificationToken is a package variable to allow overwriting it in tests.
+var generateEmailVerificationToken = func() (string, error) {
+	b := make([]byte, emailVerificationTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+type AddEmailInput struct {
+	Email string `json:"email"`
+}
+
+type VerifyEmailInput struct {
+	Token string `json:"token"`
+}
+
+/*
+ * ListEmails lists the secondary emails of a user.
+ */
+func (c *Controller) ListEmails(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) ([]*types.UserEmail, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.userEmailStore.List(ctx, user.ID)
+}
+
+/*
+ * AddEmail adds an unverified secondary email to a user and sends a verification link to it.
+ */
+func (c *Controller) AddEmail(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *AddEmailInput,
+) (*types.UserEmail, error) {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return nil, err
+	}
+
+	in.Email = strings.ToLower(strings.TrimSpace(in.Email))
+	if err := check.Email(in.Email); err != nil {
+		return nil, err
+	}
+
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(in.Email, user.Email) {
+		return nil, usererror.BadRequest("The email is already the primary email of the user.")
+	}
+
+	if err = controller.CheckPrincipalEmailUnique(ctx, c.principalStore, in.Email, 0); err != nil {
+		return nil, err
+	}
+
+	if err = c.checkSecondaryEmailUnique(ctx, in.Email, 0); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	email := &types.UserEmail{
+		PrincipalID: user.ID,
+		Email:       in.Email,
+		Created:     now,
+		Updated:     now,
+	}
+
+	token, err := c.resetEmailVerification(email)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.userEmailStore.Create(ctx, email)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errEmailDuplicate
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store user email: %w", err)
+	}
+
+	c.sendEmailVerification(ctx, user, email, token)
+
+	return email, nil
+}
+
+/*
+ * VerifyEmail verifies a secondary email of a user using the token sent to it.
+ */
+func (c *Controller) VerifyEmail(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	emailID int64,
+	in *VerifyEmailInput,
+) (*types.UserEmail, error) {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return nil, err
+	}
+
+	if in.Token == "" {
+		return nil, errEmailVerificationRequired
+	}
+
+	user, email, err := c.findUserEmail(ctx, session, userUID, emailID)
+	if err != nil {
+		return nil, err
+	}
+
+	if email.Verified {
+		return email, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashEmailVerificationToken(in.Token)), []byte(email.VerificationToken)) != 1 {
+		log.Ctx(ctx).Debug().Str("user_uid", user.UID).Msg("invalid email verification token.")
+		return nil, errEmailVerificationInvalid
+	}
+
+	email.Verified = true
+	email.VerificationToken = ""
+	email.Updated = time.Now().UnixMilli()
+
+	if err = c.userEmailStore.Update(ctx, email); err != nil {
+		return nil, fmt.Errorf("failed to update user email: %w", err)
+	}
+
+	return email, nil
+}
+
+/*
+ * SetPrimaryEmail makes a verified secondary email the primary email of a user.
+ * The previous primary email is kept as secondary email.
+ */
+func (c *Controller) SetPrimaryEmail(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	emailID int64,
+) (*types.User, error) {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return nil, err
+	}
+
+	user, email, err := c.findUserEmail(ctx, session, userUID, emailID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !email.Verified {
+		return nil, errEmailNotVerified
+	}
+
+	now := time.Now().UnixMilli()
+	previous := &types.UserEmail{
+		PrincipalID: user.ID,
+		Email:       user.Email,
+		Verified:    user.EmailVerified,
+		Created:     now,
+		Updated:     now,
+	}
+
+	var token string
+	if !previous.Verified {
+		// the previous primary email wasn't verified - allow the user to verify it as secondary email.
+		if token, err = c.resetEmailVerification(previous); err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.promoteEmail(ctx, user, email); err != nil {
+			return err
+		}
+
+		if err := c.userEmailStore.Create(ctx, previous); err != nil {
+			return fmt.Errorf("failed to store previous primary email: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		c.sendEmailVerification(ctx, user, previous, token)
+	}
+
+	return user, nil
+}
+
+/*
+ * RemoveEmail removes a secondary email of a user.
+ * The last verified email of a user can't be removed.
+ */
+func (c *Controller) RemoveEmail(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	emailID int64,
+) error {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return err
+	}
+
+	user, email, err := c.findUserEmail(ctx, session, userUID, emailID)
+	if err != nil {
+		return err
+	}
+
+	if email.Verified && !user.EmailVerified {
+		if _, err = c.findVerifiedEmail(ctx, user.ID, email.ID); err != nil {
+			return err
+		}
+	}
+
+	return c.userEmailStore.Delete(ctx, email.ID)
+}
+
+/*
+ * RemovePrimaryEmail removes the primary email of a user by promoting the oldest verified secondary email.
+ * In case the user has no verified secondary email, the primary email can't be removed.
+ */
+func (c *Controller) RemovePrimaryEmail(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.User, error) {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return nil, err
+	}
+
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	email, err := c.findVerifiedEmail(ctx, user.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.promoteEmail(ctx, user, email)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// findUserEmail finds the user and the secondary email with the provided id and ensures the email belongs to the user.
+func (c *Controller) findUserEmail(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	emailID int64,
+) (*types.User, *types.UserEmail, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, nil, err
+	}
+
+	email, err := c.userEmailStore.Find(ctx, emailID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Ensure email belongs to user.
+	if email.PrincipalID != user.ID {
+		log.Ctx(ctx).Warn().Msg("Principal tried to access email that doesn't belong to the user")
+
+		// throw a not found error - no need for user to know about the email.
+		return nil, nil, usererror.ErrNotFound
+	}
+
+	return user, email, nil
+}
+
+// findVerifiedEmail returns the oldest verified secondary email of the user other than the excluded one.
+// In case there is none, errLastVerifiedEmail is returned.
+func (c *Controller) findVerifiedEmail(
+	ctx context.Context,
+	principalID int64,
+	excludeID int64,
+) (*types.UserEmail, error) {
+	emails, err := c.userEmailStore.List(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user emails: %w", err)
+	}
+
+	for _, email := range emails {
+		if email.Verified && email.ID != excludeID {
+			return email, nil
+		}
+	}
+
+	return nil, errLastVerifiedEmail
+}
+
+// promoteEmail replaces the primary email of the user with the provided verified secondary email.
+// It has to be called within a transaction.
+func (c *Controller) promoteEmail(ctx context.Context, user *types.User, email *types.UserEmail) error {
+	if err := c.userEmailStore.Delete(ctx, email.ID); err != nil {
+		return fmt.Errorf("failed to delete promoted secondary email: %w", err)
+	}
+
+	user.Email = email.Email
+	user.EmailVerified = true
+	user.Updated = time.Now().UnixMilli()
+
+	if err := c.principalStore.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to update primary email of user: %w", err)
+	}
+
+	return nil
+}
+
+// checkSecondaryEmailUnique verifies that no user other than the one with the provided id
+// uses the email as secondary email.
+func (c *Controller) checkSecondaryEmailUnique(ctx context.Context, email string, principalID int64) error {
+	existing, err := c.userEmailStore.FindByEmail(ctx, email)
+	if err == nil && existing.PrincipalID != principalID {
+		return errEmailDuplicate
+	}
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find user email: %w", err)
+	}
+
+	return nil
+}
+
+// resetEmailVerification marks the email as unverified and returns a new verification token for it.
+func (c *Controller) resetEmailVerification(email *types.UserEmail) (string, error) {
+	token, err := generateEmailVerificationToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate email verification token: %w", err)
+	}
+
+	email.Verified = false
+	email.VerificationToken = hashEmailVerificationToken(token)
+
+	return token, nil
+}
+
+// sendEmailVerification sends the verification link of a secondary email.
+// Failures are only logged, the email can be removed and added again to resend the link.
+func (c *Controller) sendEmailVerification(ctx context.Context, user *types.User, email *types.UserEmail,
+	token string) {
+	verifyURL := fmt.Sprintf("%s/verify-email?email_id=%d&token=%s",
+		strings.TrimSuffix(c.config.URL.UI, "/"), email.ID, url.QueryEscape(token))
+
+	err := c.mailer.Send(ctx, mailer.Payload{
+		ToRecipients: []string{email.Email},
+		Subject:      "Verify your email",
+		Body: fmt.Sprintf(`<p>The email %s was added to the account %s.</p>`+
+			`<p><a href="%s">Verify your email</a></p>`+
+			`<p>If you didn't add the email, you can ignore this email.</p>`,
+			html.EscapeString(email.Email), html.EscapeString(user.UID), html.EscapeString(verifyURL)),
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("user_uid", user.UID).Msg("failed to send email verification.")
+	}
+}
+
+func hashEmailVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
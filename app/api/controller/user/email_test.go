@@ -0,0 +1,214 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+)
+
+func setupEmailController(t *testing.T, emailVerified bool) (*Controller, *mailerFake, *auth.Session) {
+	t.Helper()
+
+	c, m := setupPasswordResetController(t, emailVerified)
+	c.authorizer = &fake.Authorizer{}
+	c.tx = txFake{}
+	c.userEmailStore = &userEmailStoreFake{}
+
+	principal, err := c.principalStore.FindByUID(context.Background(), "user")
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+
+	return c, m, &auth.Session{Principal: *principal}
+}
+
+// addVerifiedEmail adds the email to the user and verifies it using the token sent via mail.
+func addVerifiedEmail(t *testing.T, c *Controller, m *mailerFake, session *auth.Session, address string,
+) *types.UserEmail {
+	t.Helper()
+
+	ctx := context.Background()
+	email, err := c.AddEmail(ctx, session, "user", &AddEmailInput{Email: address})
+	if err != nil {
+		t.Fatalf("Want email to be added, got %v", err)
+	}
+
+	match := resetTokenRegex.FindStringSubmatch(m.sent[len(m.sent)-1].Body)
+	if match == nil {
+		t.Fatalf("Want verification link in mail, got %q", m.sent[len(m.sent)-1].Body)
+	}
+
+	email, err = c.VerifyEmail(ctx, session, "user", email.ID, &VerifyEmailInput{Token: match[1]})
+	if err != nil {
+		t.Fatalf("Want email to be verified, got %v", err)
+	}
+
+	return email
+}
+
+func TestAddEmail_Verify(t *testing.T) {
+	c, m, session := setupEmailController(t, true)
+	ctx := context.Background()
+
+	email, err := c.AddEmail(ctx, session, "user", &AddEmailInput{Email: " Work@Example.com "})
+	if err != nil {
+		t.Fatalf("Want email to be added, got %v", err)
+	}
+	if email.Email != "work@example.com" || email.Verified {
+		t.Errorf("Want unverified email %q, got %q (verified: %t)", "work@example.com", email.Email, email.Verified)
+	}
+	if len(m.sent) != 1 || m.sent[0].ToRecipients[0] != "work@example.com" {
+		t.Fatalf("Want verification mail sent to the added email, got %+v", m.sent)
+	}
+
+	_, err = c.VerifyEmail(ctx, session, "user", email.ID, &VerifyEmailInput{Token: "invalid"})
+	if !errors.Is(err, errEmailVerificationInvalid) {
+		t.Errorf("Want invalid token to be rejected, got %v", err)
+	}
+
+	match := resetTokenRegex.FindStringSubmatch(m.sent[0].Body)
+	if match == nil {
+		t.Fatalf("Want verification link in mail, got %q", m.sent[0].Body)
+	}
+
+	email, err = c.VerifyEmail(ctx, session, "user", email.ID, &VerifyEmailInput{Token: match[1]})
+	if err != nil {
+		t.Fatalf("Want email to be verified, got %v", err)
+	}
+	if !email.Verified || email.VerificationToken != "" {
+		t.Errorf("Want email to be verified without token, got %+v", email)
+	}
+}
+
+func TestAddEmail_Duplicate(t *testing.T) {
+	c, _, session := setupEmailController(t, true)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		email string
+	}{
+		{name: "own primary", email: "User@example.com"},
+		{name: "other principal", email: "sa@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.AddEmail(ctx, session, "user", &AddEmailInput{Email: tt.email})
+			if err == nil {
+				t.Errorf("Want email %q to be rejected", tt.email)
+			}
+		})
+	}
+
+	if _, err := c.AddEmail(ctx, session, "user", &AddEmailInput{Email: "work@example.com"}); err != nil {
+		t.Fatalf("Want email to be added, got %v", err)
+	}
+	_, err := c.AddEmail(ctx, session, "user", &AddEmailInput{Email: "WORK@example.com"})
+	if !errors.Is(err, errEmailDuplicate) {
+		t.Errorf("Want duplicate secondary email to be rejected, got %v", err)
+	}
+}
+
+func TestSetPrimaryEmail(t *testing.T) {
+	c, m, session := setupEmailController(t, false)
+	ctx := context.Background()
+
+	unverified, err := c.AddEmail(ctx, session, "user", &AddEmailInput{Email: "unverified@example.com"})
+	if err != nil {
+		t.Fatalf("Want email to be added, got %v", err)
+	}
+	if _, err = c.SetPrimaryEmail(ctx, session, "user", unverified.ID); !errors.Is(err, errEmailNotVerified) {
+		t.Errorf("Want unverified email to be rejected as primary, got %v", err)
+	}
+
+	work := addVerifiedEmail(t, c, m, session, "work@example.com")
+
+	user, err := c.SetPrimaryEmail(ctx, session, "user", work.ID)
+	if err != nil {
+		t.Fatalf("Want email to be made primary, got %v", err)
+	}
+	if user.Email != "work@example.com" || !user.EmailVerified {
+		t.Errorf("Want verified primary email %q, got %q (verified: %t)", "work@example.com", user.Email, user.EmailVerified)
+	}
+
+	emails, err := c.ListEmails(ctx, session, "user")
+	if err != nil {
+		t.Fatalf("Want emails to be listed, got %v", err)
+	}
+	if len(emails) != 2 || emails[0].Email != "unverified@example.com" || emails[1].Email != "user@example.com" {
+		t.Fatalf("Want previous primary email to be kept as secondary email, got %+v", emails)
+	}
+	if emails[1].Verified {
+		t.Errorf("Want previous unverified primary email to stay unverified")
+	}
+	if last := m.sent[len(m.sent)-1]; last.ToRecipients[0] != "user@example.com" {
+		t.Errorf("Want verification mail sent to previous primary email, got %v", last.ToRecipients)
+	}
+}
+
+func TestRemoveEmail_LastVerified(t *testing.T) {
+	c, m, session := setupEmailController(t, false)
+	ctx := context.Background()
+
+	// the primary email isn't verified and there's no verified secondary email to promote.
+	if _, err := c.RemovePrimaryEmail(ctx, session, "user"); !errors.Is(err, errLastVerifiedEmail) {
+		t.Errorf("Want removal of primary email to be rejected, got %v", err)
+	}
+
+	work := addVerifiedEmail(t, c, m, session, "work@example.com")
+
+	err := c.RemoveEmail(ctx, session, "user", work.ID)
+	if !errors.Is(err, errLastVerifiedEmail) {
+		t.Errorf("Want removal of last verified email to be rejected, got %v", err)
+	}
+	if status := usererror.Translate(ctx, err).Status; status != 400 {
+		t.Errorf("Want status 400, got %d", status)
+	}
+
+	user, err := c.RemovePrimaryEmail(ctx, session, "user")
+	if err != nil {
+		t.Fatalf("Want primary email to be removed, got %v", err)
+	}
+	if user.Email != "work@example.com" || !user.EmailVerified {
+		t.Errorf("Want verified email %q to be promoted, got %q", "work@example.com", user.Email)
+	}
+
+	emails, err := c.ListEmails(ctx, session, "user")
+	if err != nil {
+		t.Fatalf("Want emails to be listed, got %v", err)
+	}
+	if len(emails) != 0 {
+		t.Errorf("Want no secondary emails left, got %+v", emails)
+	}
+}
+
+func TestRemoveEmail_NotOwned(t *testing.T) {
+	c, _, session := setupEmailController(t, true)
+	ctx := context.Background()
+
+	store, _ := c.userEmailStore.(*userEmailStoreFake)
+	store.emails = append(store.emails, &types.UserEmail{ID: 42, PrincipalID: 2, Email: "other@example.com"})
+
+	if err := c.RemoveEmail(ctx, session, "user", 42); !errors.Is(err, usererror.ErrNotFound) {
+		t.Errorf("Want email of other user to not be found, got %v", err)
+	}
+}
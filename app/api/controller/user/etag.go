@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// ETag returns the entity tag of the current version of the user.
+func ETag(user *types.User) string {
+	return fmt.Sprintf("%q", strconv.FormatInt(user.Version, 10))
+}
+
+// ListETag returns a weak entity tag of the provided list of users.
+func ListETag(users []*types.User) string {
+	h := sha256.New()
+	for _, user := range users {
+		_, _ = fmt.Fprintf(h, "%d:%d\n", user.ID, user.Version)
+	}
+
+	return fmt.Sprintf("W/%q", hex.EncodeToString(h.Sum(nil))[:32])
+}
+
+// checkIfMatch ensures the user wasn't modified since the client retrieved it.
+// Without provided entity tags the check passes, unless the If-Match header is required by the config.
+func (c *Controller) checkIfMatch(user *types.User, ifMatch []string) error {
+	if len(ifMatch) == 0 {
+		if c.config.Principal.RequireIfMatch {
+			return usererror.ErrIfMatchRequired
+		}
+		return nil
+	}
+
+	etag := ETag(user)
+	for _, tag := range ifMatch {
+		if tag == "*" || tag == etag {
+			return nil
+		}
+	}
+
+	return usererror.ErrResourceModified
+}
+
+// updateIfUnmodified stores the user in case it wasn't modified concurrently since it was read.
+// Together with checkIfMatch this ensures that updates based on an outdated version of the user are rejected.
+func (c *Controller) updateIfUnmodified(ctx context.Context, user *types.User, version int64) error {
+	err := c.principalStore.UpdateUserIfUnmodified(ctx, user, version)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return usererror.ErrResourceModified
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
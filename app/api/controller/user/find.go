@@ -16,6 +16,7 @@ package user
 
 import (
 	"context"
+	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
@@ -38,6 +39,14 @@ func (c *Controller) Find(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	// secondary emails are only exposed to the user itself.
+	if session != nil && session.Principal.ID == user.ID {
+		user.SecondaryEmails, err = c.userEmailStore.List(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secondary emails: %w", err)
+		}
+	}
+
 	return user, nil
 }
 
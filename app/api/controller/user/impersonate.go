@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+/*
+ * Impersonate creates a short-lived token that allows the admin to act as the user for troubleshooting.
+ * The token grants the rights of the user, admins can't be impersonated.
+ */
+func (c *Controller) Impersonate(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.TokenResponse, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+		return nil, err
+	}
+
+	if session.Impersonator != nil {
+		return nil, usererror.Forbidden("Impersonated sessions can't impersonate other users.")
+	}
+	if user.ID == session.Principal.ID {
+		return nil, usererror.BadRequest("Users can't impersonate themselves.")
+	}
+	if user.Admin {
+		return nil, usererror.Forbidden("Admins can't be impersonated.")
+	}
+
+	tokenIdentifier := fmt.Sprintf("impersonation-%s-%d", session.Principal.UID, time.Now().UnixMilli())
+	tkn, jwtToken, err := c.tokenIssuer.CreateImpersonation(
+		ctx,
+		&session.Principal,
+		user,
+		tokenIdentifier,
+		c.config.Auth.ImpersonationTokenLifetime,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create impersonation token: %w", err)
+	}
+
+	err = c.auditService.Log(ctx,
+		session.Principal,
+		audit.NewResource(audit.ResourceTypeUser, user.UID),
+		audit.ActionImpersonated,
+		audit.RootSpacePath,
+		audit.WithData("token_id", strconv.FormatInt(tkn.ID, 10)),
+	)
+	if err != nil {
+		// never hand out an impersonation token that isn't recorded in the audit log.
+		if errDelete := c.tokenStore.Delete(ctx, tkn.ID); errDelete != nil {
+			log.Ctx(ctx).Warn().Err(errDelete).Msg("failed to delete unaudited impersonation token")
+		}
+		return nil, fmt.Errorf("failed to insert audit log for impersonation: %w", err)
+	}
+
+	return &types.TokenResponse{Token: *tkn, AccessToken: jwtToken}, nil
+}
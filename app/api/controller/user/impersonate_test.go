@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+func setupImpersonationController(t *testing.T) (*Controller, *auth.Session) {
+	t.Helper()
+
+	c := setupLoginController(t)
+	c.config.Auth.ImpersonationTokenLifetime = 30 * time.Minute
+	c.authorizer = &fake.Authorizer{}
+	c.auditService = &auditServiceFake{}
+
+	principalStore, _ := c.principalStore.(*principalStoreFake)
+	admin := &types.Principal{
+		UID:   "admin",
+		Email: "admin@example.com",
+		Type:  enum.PrincipalTypeUser,
+		Admin: true,
+		Salt:  "admin-salt",
+	}
+	principalStore.addPrincipal(admin, "")
+
+	return c, &auth.Session{Principal: *admin}
+}
+
+func TestImpersonate(t *testing.T) {
+	c, session := setupImpersonationController(t)
+
+	res, err := c.Impersonate(context.Background(), session, "user")
+	if err != nil {
+		t.Fatalf("Want impersonation to succeed, got %v", err)
+	}
+	if got, want := res.Token.Type, enum.TokenTypeImpersonation; got != want {
+		t.Errorf("Want token type %q, got %q", want, got)
+	}
+	if res.Token.CreatedBy != session.Principal.ID {
+		t.Errorf("Want token to be created by admin %d, got %d", session.Principal.ID, res.Token.CreatedBy)
+	}
+	if res.Token.ExpiresAt == nil ||
+		time.Duration(*res.Token.ExpiresAt-res.Token.IssuedAt)*time.Millisecond != 30*time.Minute {
+		t.Errorf("Want token to expire after the impersonation token lifetime")
+	}
+
+	claims := &jwt.Claims{}
+	err = jwt.Parse(res.AccessToken, claims, nil, func(*jwt.Claims) (string, error) { return "user-salt", nil })
+	if err != nil {
+		t.Fatalf("failed to parse impersonation token: %v", err)
+	}
+	if claims.Token == nil || claims.Token.Type != enum.TokenTypeImpersonation {
+		t.Fatalf("Want token to carry the impersonation marker, got %+v", claims.Token)
+	}
+	if claims.Token.ImpersonatorID != session.Principal.ID {
+		t.Errorf("Want impersonator %d, got %d", session.Principal.ID, claims.Token.ImpersonatorID)
+	}
+
+	auditService, _ := c.auditService.(*auditServiceFake)
+	if len(auditService.events) != 1 {
+		t.Fatalf("Want impersonation to be recorded in the audit log, got %d events", len(auditService.events))
+	}
+	event := auditService.events[0]
+	if event.Action != audit.ActionImpersonated || event.User.UID != "admin" || event.Resource.Identifier != "user" {
+		t.Errorf("Want admin impersonating user to be recorded, got %+v", event)
+	}
+}
+
+func TestImpersonate_AuditFailure(t *testing.T) {
+	c, session := setupImpersonationController(t)
+	c.auditService = &auditServiceFake{err: errors.New("audit log unavailable")}
+
+	if _, err := c.Impersonate(context.Background(), session, "user"); err == nil {
+		t.Fatalf("Want impersonation to fail if it can't be audited")
+	}
+
+	tokenStore, _ := c.tokenStore.(*tokenStoreFake)
+	if len(tokenStore.tokens) != 0 {
+		t.Errorf("Want unaudited impersonation token to be deleted, got %d tokens", len(tokenStore.tokens))
+	}
+}
+
+func TestImpersonatedSession_CredentialChangesRejected(t *testing.T) {
+	c, admin := setupImpersonationController(t)
+
+	principalStore, _ := c.principalStore.(*principalStoreFake)
+	user, err := principalStore.FindByUID(context.Background(), "user")
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+	session := &auth.Session{Principal: *user, Impersonator: &admin.Principal}
+
+	tests := []struct {
+		name string
+		fn   func(ctx context.Context) error
+	}{
+		{name: "create token", fn: func(ctx context.Context) error {
+			_, err := c.CreateAccessToken(ctx, session, "user", &CreateTokenInput{Identifier: "token"})
+			return err
+		}},
+		{name: "change password", fn: func(ctx context.Context) error {
+			_, err := c.Update(ctx, session, "user", &UpdateInput{Password: ptr.String("new-password")})
+			return err
+		}},
+		{name: "change email", fn: func(ctx context.Context) error {
+			_, err := c.Update(ctx, session, "user", &UpdateInput{Email: ptr.String("new@example.com")})
+			return err
+		}},
+		{name: "add email", fn: func(ctx context.Context) error {
+			_, err := c.AddEmail(ctx, session, "user", &AddEmailInput{Email: "other@example.com"})
+			return err
+		}},
+		{name: "create public key", fn: func(ctx context.Context) error {
+			_, err := c.CreatePublicKey(ctx, session, "user", &CreatePublicKeyInput{})
+			return err
+		}},
+		{name: "revoke tokens", fn: func(ctx context.Context) error {
+			return c.RevokeAllTokens(ctx, session, "user")
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.fn(context.Background())
+			if status := usererror.Translate(context.Background(), err).Status; status != http.StatusForbidden {
+				t.Errorf("Want status %d, got %d (%v)", http.StatusForbidden, status, err)
+			}
+		})
+	}
+}
+
+func TestImpersonate_Rejected(t *testing.T) {
+	c, session := setupImpersonationController(t)
+
+	principalStore, _ := c.principalStore.(*principalStoreFake)
+	principalStore.addPrincipal(&types.Principal{
+		UID:   "other-admin",
+		Email: "other-admin@example.com",
+		Type:  enum.PrincipalTypeUser,
+		Admin: true,
+	}, "")
+
+	impersonated := &auth.Session{Principal: session.Principal, Impersonator: &session.Principal}
+
+	tests := []struct {
+		name    string
+		session *auth.Session
+		userUID string
+	}{
+		{name: "self", session: session, userUID: "admin"},
+		{name: "admin", session: session, userUID: "other-admin"},
+		{name: "impersonated session", session: impersonated, userUID: "user"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := c.Impersonate(context.Background(), test.session, test.userUID)
+
+			uErr := &usererror.Error{}
+			if !errors.As(err, &uErr) || uErr.Status < http.StatusBadRequest {
+				t.Errorf("Want impersonation to be rejected, got %v", err)
+			}
+		})
+	}
+}
@@ -23,14 +23,16 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/usererror"
-	"github.com/harness/gitness/app/token"
-	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/app/auth/authsource"
 	"github.com/harness/gitness/types"
 
 	"github.com/rs/zerolog/log"
-	"golang.org/x/crypto/bcrypt"
 )
 
+// errServiceAccountLogin is returned if a service account tries to login with its password.
+var errServiceAccountLogin = usererror.Forbidden(
+	"Service accounts can't login with a password, use a service account token instead.")
+
 type LoginInput struct {
 	LoginIdentifier string `json:"login_identifier"`
 	Password        string `json:"password"`
@@ -45,40 +47,60 @@ func (c *Controller) Login(
 ) (*types.TokenResponse, error) {
 	// no auth check required, password is used for it.
 
-	user, err := findUserFromUID(ctx, c.principalStore, in.LoginIdentifier)
-	if errors.Is(err, store.ErrResourceNotFound) {
-		user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
+	var lockoutUser *types.User
+	if c.lockoutEnabled() {
+		var err error
+		lockoutUser, err = c.findLoginUser(ctx, in.LoginIdentifier)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkLockout(lockoutUser, time.Now()); err != nil {
+			return nil, err
+		}
 	}
 
+	user, err := c.authSource.Authenticate(ctx, in.LoginIdentifier, in.Password)
+
 	// always return not found for security reasons.
-	if err != nil {
-		log.Ctx(ctx).Debug().Err(err).
-			Msgf("failed to retrieve user %q during login (returning ErrNotFound).", in.LoginIdentifier)
+	if errors.Is(err, authsource.ErrInvalidCredentials) {
+		log.Ctx(ctx).Debug().
+			Msgf("invalid credentials for %q during login (returning ErrNotFound).", in.LoginIdentifier)
+
+		if lockoutUser != nil {
+			if err = c.registerFailedLogin(ctx, lockoutUser); err != nil {
+				return nil, err
+			}
+		}
+
 		return nil, usererror.ErrNotFound
 	}
-
-	err = bcrypt.CompareHashAndPassword(
-		[]byte(user.Password),
-		[]byte(in.Password),
-	)
+	if errors.Is(err, authsource.ErrServiceAccountLogin) {
+		return nil, errServiceAccountLogin
+	}
 	if err != nil {
-		log.Debug().Err(err).
-			Str("user_uid", user.UID).
-			Msg("invalid password")
+		return nil, err
+	}
 
-		return nil, usererror.ErrNotFound
+	if err = c.resetFailedLogins(ctx, user); err != nil {
+		return nil, err
+	}
+
+	firstLogin, err := c.handleFirstLogin(ctx, user)
+	if err != nil {
+		return nil, err
 	}
 
 	tokenIdentifier, err := generateSessionTokenIdentifier()
 	if err != nil {
 		return nil, err
 	}
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenIdentifier)
+	token, jwtToken, err := c.tokenIssuer.CreateUserSession(ctx, user, tokenIdentifier,
+		c.config.Token.Expire)
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken, FirstLogin: firstLogin}, nil
 }
 
 func generateSessionTokenIdentifier() (string, error) {
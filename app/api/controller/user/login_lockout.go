@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// lockoutEnabled returns true if accounts get locked after repeated failed logins.
+func (c *Controller) lockoutEnabled() bool {
+	return c.config.Auth.Lockout.Threshold > 0
+}
+
+// findLoginUser returns the user the login identifier belongs to (nil if there's none).
+func (c *Controller) findLoginUser(ctx context.Context, loginIdentifier string) (*types.User, error) {
+	user, err := c.principalStore.FindUserByUID(ctx, loginIdentifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		user, err = c.principalStore.FindUserByEmail(ctx, loginIdentifier)
+	}
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, nil //nolint:nilnil // no user isn't an error for the lockout
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return user, nil
+}
+
+// checkLockout returns an error if the account is still locked.
+func checkLockout(user *types.User, now time.Time) error {
+	if user == nil || user.LockedUntil <= now.UnixMilli() {
+		return nil
+	}
+
+	return usererror.AccountLocked(time.UnixMilli(user.LockedUntil))
+}
+
+// registerFailedLogin counts the failed login of the user and locks the account once the threshold is reached.
+func (c *Controller) registerFailedLogin(ctx context.Context, user *types.User) error {
+	failedLogins, err := c.principalStore.IncrementFailedLogins(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to increment failed logins: %w", err)
+	}
+
+	if failedLogins < c.config.Auth.Lockout.Threshold {
+		return nil
+	}
+
+	lockedUntil := time.Now().Add(c.config.Auth.Lockout.Duration)
+	if err = c.principalStore.UpdateLockedUntil(ctx, user.ID, lockedUntil.UnixMilli()); err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+
+	log.Ctx(ctx).Warn().
+		Str("user_uid", user.UID).
+		Int("failed_logins", failedLogins).
+		Time("locked_until", lockedUntil).
+		Msg("locked user after too many failed logins")
+
+	return nil
+}
+
+// resetFailedLogins resets the failed logins and lockout of the user after a successful login.
+func (c *Controller) resetFailedLogins(ctx context.Context, user *types.User) error {
+	if user.FailedLogins == 0 && user.LockedUntil == 0 {
+		return nil
+	}
+
+	if err := c.principalStore.UpdateLockedUntil(ctx, user.ID, 0); err != nil {
+		return fmt.Errorf("failed to reset failed logins: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+)
+
+func setupLockoutController(t *testing.T) (*Controller, *principalStoreFake) {
+	t.Helper()
+
+	c := setupLoginController(t)
+	c.config.Auth.Lockout.Threshold = 3
+	c.config.Auth.Lockout.Duration = 15 * time.Minute
+	c.authorizer = &fake.Authorizer{}
+
+	principalStore, _ := c.principalStore.(*principalStoreFake)
+	return c, principalStore
+}
+
+func failLogins(t *testing.T, c *Controller, n int) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		_, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "wrong"})
+		if !errors.Is(err, usererror.ErrNotFound) {
+			t.Fatalf("Want failed login %d to return not found, got %v", i+1, err)
+		}
+	}
+}
+
+func assertAccountLocked(t *testing.T, err error) {
+	t.Helper()
+
+	uErr := &usererror.Error{}
+	if !errors.As(err, &uErr) {
+		t.Fatalf("Want user error, got %v", err)
+	}
+	if uErr.Status != http.StatusLocked {
+		t.Errorf("Want status %d, got %d", http.StatusLocked, uErr.Status)
+	}
+	if got := uErr.Values["code"]; got != usererror.ErrorCodeAccountLocked {
+		t.Errorf("Want code %q, got %v", usererror.ErrorCodeAccountLocked, got)
+	}
+}
+
+func TestLogin_LockoutAfterThreshold(t *testing.T) {
+	c, principalStore := setupLockoutController(t)
+
+	failLogins(t, c, 2)
+	if principalStore.lockedUntil[1] != 0 {
+		t.Fatalf("Want user not to be locked below the threshold")
+	}
+
+	failLogins(t, c, 1)
+	if principalStore.lockedUntil[1] <= time.Now().UnixMilli() {
+		t.Fatalf("Want user to be locked after reaching the threshold")
+	}
+
+	// the correct password doesn't help while the account is locked.
+	_, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"})
+	assertAccountLocked(t, err)
+
+	_, err = c.Login(context.Background(), &LoginInput{LoginIdentifier: "user@example.com", Password: "password"})
+	assertAccountLocked(t, err)
+}
+
+func TestLogin_LockoutExpires(t *testing.T) {
+	c, principalStore := setupLockoutController(t)
+
+	failLogins(t, c, 3)
+	principalStore.lockedUntil[1] = time.Now().Add(-time.Second).UnixMilli()
+
+	if _, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"}); err != nil {
+		t.Fatalf("Want login to succeed after the lockout expired, got %v", err)
+	}
+	if principalStore.lockedUntil[1] != 0 || principalStore.failedLogins[1] != 0 {
+		t.Errorf("Want lockout to be reset after successful login")
+	}
+}
+
+func TestLogin_SuccessResetsFailedLogins(t *testing.T) {
+	c, principalStore := setupLockoutController(t)
+
+	failLogins(t, c, 2)
+	if _, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"}); err != nil {
+		t.Fatalf("Want login to succeed, got %v", err)
+	}
+	if got := principalStore.failedLogins[1]; got != 0 {
+		t.Fatalf("Want failed logins to be reset, got %d", got)
+	}
+
+	// the earlier failures no longer count towards the threshold.
+	failLogins(t, c, 2)
+	if principalStore.lockedUntil[1] != 0 {
+		t.Errorf("Want user not to be locked")
+	}
+}
+
+func TestLogin_LockoutDisabled(t *testing.T) {
+	c, principalStore := setupLockoutController(t)
+	c.config.Auth.Lockout.Threshold = 0
+
+	failLogins(t, c, 5)
+	if principalStore.failedLogins[1] != 0 || principalStore.lockedUntil[1] != 0 {
+		t.Errorf("Want failed logins not to be tracked when lockout is disabled")
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	c, _ := setupLockoutController(t)
+
+	failLogins(t, c, 3)
+
+	session := &auth.Session{Principal: types.Principal{ID: 99, Admin: true}}
+	user, err := c.Unlock(context.Background(), session, "user")
+	if err != nil {
+		t.Fatalf("Want unlock to succeed, got %v", err)
+	}
+	if user.LockedUntil != 0 {
+		t.Errorf("Want unlocked user to be returned, got locked until %d", user.LockedUntil)
+	}
+
+	if _, err = c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"}); err != nil {
+		t.Errorf("Want login to succeed after unlock, got %v", err)
+	}
+}
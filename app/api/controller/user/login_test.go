@@ -0,0 +1,170 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/authsource"
+	"github.com/harness/gitness/app/auth/password"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func setupLoginController(t *testing.T) *Controller {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	principalStore := &principalStoreFake{}
+	principalStore.addPrincipal(&types.Principal{
+		UID:   "user",
+		Email: "user@example.com",
+		Type:  enum.PrincipalTypeUser,
+		Salt:  "user-salt",
+	}, string(hash))
+	principalStore.addPrincipal(&types.Principal{
+		UID:   "sa",
+		Email: "sa@example.com",
+		Type:  enum.PrincipalTypeServiceAccount,
+		Salt:  "sa-salt",
+	}, string(hash))
+
+	config := &types.Config{}
+	config.Auth.BlockServiceAccountLogin = true
+	config.Auth.CompleteOnboardingOnLogin = true
+	config.Token.Expire = 24 * time.Hour
+	config.Auth.ServiceAccountTokenLifetime = time.Hour
+
+	hasher := password.NewBcrypt(bcrypt.MinCost)
+	tokenStore := &tokenStoreFake{}
+
+	return &Controller{
+		config:         config,
+		authSource:     authsource.NewLocalSource(principalStore, hasher, config.Auth.BlockServiceAccountLogin),
+		passwordHasher: hasher,
+		principalStore: principalStore,
+		tokenStore:     tokenStore,
+		tokenIssuer:    token.NewIssuer(tokenStore, jwt.NewKeySet(0), jwt.GenerateForToken),
+	}
+}
+
+func TestLogin_User(t *testing.T) {
+	c := setupLoginController(t)
+
+	res, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"})
+	if err != nil {
+		t.Fatalf("Want user login to succeed, got %v", err)
+	}
+	if res.AccessToken == "" {
+		t.Errorf("Want access token to be returned")
+	}
+	if got, want := res.Token.Type, enum.TokenTypeSession; got != want {
+		t.Errorf("Want token type %q, got %q", want, got)
+	}
+}
+
+func TestLogin_UsesTokenExpire(t *testing.T) {
+	c := setupLoginController(t)
+
+	res, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"})
+	if err != nil {
+		t.Fatalf("Want user login to succeed, got %v", err)
+	}
+	if res.Token.ExpiresAt == nil {
+		t.Fatalf("Want session token to expire")
+	}
+
+	got := time.Duration(*res.Token.ExpiresAt-res.Token.IssuedAt) * time.Millisecond
+	if want := c.config.Token.Expire; got != want {
+		t.Errorf("Want session token lifetime %s, got %s", want, got)
+	}
+}
+
+func TestLogin_ServiceAccountRejected(t *testing.T) {
+	c := setupLoginController(t)
+
+	for _, identifier := range []string{"sa", "sa@example.com"} {
+		_, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: identifier, Password: "password"})
+		if !errors.Is(err, errServiceAccountLogin) {
+			t.Errorf("Want service account login with %q to be rejected, got %v", identifier, err)
+		}
+	}
+}
+
+func TestLogin_ServiceAccountInvalidPassword(t *testing.T) {
+	c := setupLoginController(t)
+
+	_, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "sa", Password: "wrong"})
+	if !errors.Is(err, usererror.ErrNotFound) {
+		t.Errorf("Want service account login with invalid password to be treated like an unknown user, got %v", err)
+	}
+}
+
+func TestLogin_EmailCaseInsensitive(t *testing.T) {
+	c := setupLoginController(t)
+
+	for _, identifier := range []string{"user@example.com", "User@Example.COM"} {
+		_, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: identifier, Password: "password"})
+		if err != nil {
+			t.Errorf("Want login with %q to succeed, got %v", identifier, err)
+		}
+	}
+}
+
+func TestLogin_UpgradesLegacyBcryptHash(t *testing.T) {
+	c := setupLoginController(t)
+
+	argon2id, err := password.NewArgon2id(password.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1})
+	if err != nil {
+		t.Fatalf("failed to create argon2id hasher: %v", err)
+	}
+	hasher, err := password.NewMultiHasher(password.AlgorithmArgon2id, password.NewBcrypt(bcrypt.MinCost), argon2id)
+	if err != nil {
+		t.Fatalf("failed to create hasher: %v", err)
+	}
+	principalStore, _ := c.principalStore.(*principalStoreFake)
+	c.authSource = authsource.NewLocalSource(principalStore, hasher, true)
+
+	if _, err = c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"}); err != nil {
+		t.Fatalf("Want login with legacy bcrypt hash to succeed, got %v", err)
+	}
+
+	user, err := principalStore.FindUserByUID(context.Background(), "user")
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+	if algorithm, _ := password.AlgorithmOf(user.Password); algorithm != password.AlgorithmArgon2id {
+		t.Fatalf("Want hash to be upgraded to %q, got %q", password.AlgorithmArgon2id, user.Password)
+	}
+
+	if _, err = c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"}); err != nil {
+		t.Errorf("Want login with upgraded hash to succeed, got %v", err)
+	}
+	if _, err = c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "wrong"}); err == nil {
+		t.Errorf("Want login with wrong password to fail after upgrade")
+	}
+}
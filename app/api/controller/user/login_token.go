@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type LoginWithTokenInput struct {
+	AccessToken string `json:"access_token"`
+}
+
+/*
+ * LoginWithToken exchanges a valid personal access token of a user for a new session token.
+ * The session inherits the scopes of the personal access token and doesn't outlive it.
+ */
+func (c *Controller) LoginWithToken(
+	ctx context.Context,
+	in *LoginWithTokenInput,
+) (*types.TokenResponse, error) {
+	// no auth check required, the token is used for it.
+
+	pat, user, err := c.findUserForToken(ctx, in.AccessToken, enum.TokenTypePAT)
+
+	// always return unauthorized for security reasons.
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to exchange personal access token (returning ErrUnauthorized).")
+		return nil, usererror.ErrUnauthorized
+	}
+
+	tokenIdentifier, err := generateSessionTokenIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	token, jwtToken, err := c.tokenIssuer.CreateUserSessionFromToken(ctx, user, tokenIdentifier,
+		c.config.Token.Expire, pat)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+}
+
+// findUserForToken validates the provided jwt of the expected token type against the token store
+// and returns the token and the user it belongs to.
+func (c *Controller) findUserForToken(
+	ctx context.Context,
+	accessToken string,
+	tokenType enum.TokenType,
+) (*types.Token, *types.User, error) {
+	if accessToken == "" {
+		return nil, nil, errors.New("access token is empty")
+	}
+
+	claims := &jwt.Claims{}
+	err := jwt.Parse(accessToken, claims, c.tokenIssuer.SigningKeys(), func(claims *jwt.Claims) (string, error) {
+		principal, err := c.principalStore.Find(ctx, claims.PrincipalID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get principal for token: %w", err)
+		}
+		return principal.Salt, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if claims.Token == nil || claims.Token.Type != tokenType {
+		return nil, nil, fmt.Errorf("JWT is not a token of type %s", tokenType)
+	}
+
+	// revoked tokens are removed from the db.
+	tkn, err := c.tokenStore.Find(ctx, claims.Token.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find token in db: %w", err)
+	}
+
+	if tkn.Type != tokenType {
+		return nil, nil, fmt.Errorf("JWT doesn't match the type of the db token %d", tkn.ID)
+	}
+
+	user, err := c.principalStore.FindUser(ctx, claims.PrincipalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find user for token: %w", err)
+	}
+
+	// apply the same checks as for authenticating with the token (revoked, expired, blocked).
+	if err = token.Validate(tkn, user.ToPrincipal()); err != nil {
+		return nil, nil, err
+	}
+
+	return tkn, user, nil
+}
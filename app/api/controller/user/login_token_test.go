@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+func createTestPAT(t *testing.T, c *Controller, lifetime time.Duration) (*types.Token, string) {
+	t.Helper()
+
+	user, err := c.principalStore.FindUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+
+	pat, jwt, err := c.tokenIssuer.CreatePAT(context.Background(), user.ToPrincipal(), user, "pat",
+		ptr.Duration(lifetime), []enum.TokenScope{enum.TokenScopeRepoRead})
+	if err != nil {
+		t.Fatalf("failed to create pat: %v", err)
+	}
+
+	return pat, jwt
+}
+
+func TestLoginWithToken_Valid(t *testing.T) {
+	c := setupLoginController(t)
+	pat, jwt := createTestPAT(t, c, time.Hour)
+
+	res, err := c.LoginWithToken(context.Background(), &LoginWithTokenInput{AccessToken: jwt})
+	if err != nil {
+		t.Fatalf("Want token login to succeed, got %v", err)
+	}
+	if res.AccessToken == "" {
+		t.Errorf("Want access token to be returned")
+	}
+	if got, want := res.Token.Type, enum.TokenTypeSession; got != want {
+		t.Errorf("Want token type %q, got %q", want, got)
+	}
+	if res.Token.ExpiresAt == nil || *res.Token.ExpiresAt > *pat.ExpiresAt {
+		t.Errorf("Want session to not outlive the personal access token")
+	}
+	if len(res.Token.Scopes) != 1 || res.Token.Scopes[0] != enum.TokenScopeRepoRead {
+		t.Errorf("Want session to inherit the token scopes, got %v", res.Token.Scopes)
+	}
+}
+
+func TestLoginWithToken_Expired(t *testing.T) {
+	c := setupLoginController(t)
+	_, jwt := createTestPAT(t, c, -time.Hour)
+
+	_, err := c.LoginWithToken(context.Background(), &LoginWithTokenInput{AccessToken: jwt})
+	if !errors.Is(err, usererror.ErrUnauthorized) {
+		t.Errorf("Want expired token to be rejected with %v, got %v", usererror.ErrUnauthorized, err)
+	}
+}
+
+func TestLoginWithToken_Revoked(t *testing.T) {
+	c := setupLoginController(t)
+	pat, jwt := createTestPAT(t, c, time.Hour)
+
+	if err := c.tokenStore.Delete(context.Background(), pat.ID); err != nil {
+		t.Fatalf("failed to revoke token: %v", err)
+	}
+
+	_, err := c.LoginWithToken(context.Background(), &LoginWithTokenInput{AccessToken: jwt})
+	if !errors.Is(err, usererror.ErrUnauthorized) {
+		t.Errorf("Want revoked token to be rejected with %v, got %v", usererror.ErrUnauthorized, err)
+	}
+}
+
+func TestLoginWithToken_SessionRejected(t *testing.T) {
+	c := setupLoginController(t)
+
+	res, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"})
+	if err != nil {
+		t.Fatalf("Want user login to succeed, got %v", err)
+	}
+
+	_, err = c.LoginWithToken(context.Background(), &LoginWithTokenInput{AccessToken: res.AccessToken})
+	if !errors.Is(err, usererror.ErrUnauthorized) {
+		t.Errorf("Want session token to be rejected with %v, got %v", usererror.ErrUnauthorized, err)
+	}
+}
+
+func TestLoginWithToken_RevokedGeneration(t *testing.T) {
+	c := setupLoginController(t)
+	_, jwt := createTestPAT(t, c, time.Hour)
+
+	// e.g. after a password reset
+	if _, err := c.principalStore.IncrementTokenGeneration(context.Background(), 1); err != nil {
+		t.Fatalf("failed to increment token generation: %v", err)
+	}
+
+	_, err := c.LoginWithToken(context.Background(), &LoginWithTokenInput{AccessToken: jwt})
+	if !errors.Is(err, usererror.ErrUnauthorized) {
+		t.Errorf("Want token of previous generation to be rejected with %v, got %v", usererror.ErrUnauthorized, err)
+	}
+}
+
+func TestLoginWithToken_Blocked(t *testing.T) {
+	c := setupLoginController(t)
+	_, jwt := createTestPAT(t, c, time.Hour)
+
+	principalStore, _ := c.principalStore.(*principalStoreFake)
+	principalStore.principals[0].Blocked = true
+
+	_, err := c.LoginWithToken(context.Background(), &LoginWithTokenInput{AccessToken: jwt})
+	if !errors.Is(err, usererror.ErrUnauthorized) {
+		t.Errorf("Want token of blocked user to be rejected with %v, got %v", usererror.ErrUnauthorized, err)
+	}
+}
+
+func TestLoginWithToken_RevokeAllTokens(t *testing.T) {
+	c := setupLoginController(t)
+	c.authorizer = &fake.Authorizer{}
+	_, oldJWT := createTestPAT(t, c, time.Hour)
+
+	principal, err := c.principalStore.Find(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+	session := &auth.Session{Principal: *principal}
+
+	if err = c.RevokeAllTokens(context.Background(), session, "user"); err != nil {
+		t.Fatalf("Want revoking all tokens to succeed, got %v", err)
+	}
+
+	_, err = c.LoginWithToken(context.Background(), &LoginWithTokenInput{AccessToken: oldJWT})
+	if !errors.Is(err, usererror.ErrUnauthorized) {
+		t.Errorf("Want token issued before revocation to be rejected with %v, got %v", usererror.ErrUnauthorized, err)
+	}
+
+	_, newJWT := createTestPAT(t, c, time.Hour)
+	if _, err = c.LoginWithToken(context.Background(), &LoginWithTokenInput{AccessToken: newJWT}); err != nil {
+		t.Errorf("Want token issued after revocation to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var (
+	meSpacePermissions = []enum.Permission{
+		enum.PermissionSpaceView,
+		enum.PermissionSpaceEdit,
+		enum.PermissionSpaceDelete,
+	}
+	meRepoPermissions = []enum.Permission{
+		enum.PermissionRepoView,
+		enum.PermissionRepoPush,
+		enum.PermissionRepoReportCommitCheck,
+		enum.PermissionRepoEdit,
+		enum.PermissionRepoDelete,
+	}
+)
+
+type MeInput struct {
+	SpaceRef string
+	RepoRef  string
+}
+
+// Me returns the current user together with its admin status and its effective
+// permissions in the (optionally) requested space and repository.
+func (c *Controller) Me(ctx context.Context,
+	session *auth.Session,
+	in *MeInput,
+) (*types.UserMe, error) {
+	user, err := c.principalStore.FindUser(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	out := &types.UserMe{
+		User:  user,
+		Admin: session.Principal.Admin,
+	}
+
+	if in.SpaceRef != "" {
+		out.Space, err = c.spaceAccess(ctx, session, in.SpaceRef)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if in.RepoRef != "" {
+		out.Repo, err = c.repoAccess(ctx, session, in.RepoRef)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+func (c *Controller) spaceAccess(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.UserAccess, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, true); err != nil {
+		return nil, err
+	}
+
+	role, err := c.resolveRole(ctx, session.Principal.ID, space)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]enum.Permission, 0, len(meSpacePermissions))
+	for _, permission := range meSpacePermissions {
+		err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission,
+			permission == enum.PermissionSpaceView)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return &types.UserAccess{
+		Path:        space.Path,
+		Role:        role,
+		Permissions: permissions,
+	}, nil
+}
+
+func (c *Controller) repoAccess(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.UserAccess, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true); err != nil {
+		return nil, err
+	}
+
+	space, err := c.spaceStore.Find(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find parent space of repo: %w", err)
+	}
+
+	role, err := c.resolveRole(ctx, session.Principal.ID, space)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]enum.Permission, 0, len(meRepoPermissions))
+	for _, permission := range meRepoPermissions {
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, permission,
+			permission == enum.PermissionRepoView)
+		if errors.Is(err, apiauth.ErrNotAuthorized) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return &types.UserAccess{
+		Path:        repo.Path,
+		Role:        role,
+		Permissions: permissions,
+	}, nil
+}
+
+// resolveRole returns the membership role of the principal in the provided space,
+// inherited from the closest ancestor space that has a membership (empty if there is none).
+func (c *Controller) resolveRole(ctx context.Context,
+	principalID int64,
+	space *types.Space,
+) (enum.MembershipRole, error) {
+	// limit the depth to be safe (e.g. root/space1/space2 => maxDepth of 3)
+	maxDepth := len(paths.Segments(space.Path))
+
+	for depth := 0; depth < maxDepth; depth++ {
+		membership, err := c.membershipStore.Find(ctx, types.MembershipKey{
+			SpaceID:     space.ID,
+			PrincipalID: principalID,
+		})
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return "", fmt.Errorf("failed to find membership: %w", err)
+		}
+
+		if membership != nil {
+			return membership.Role, nil
+		}
+
+		parentID := space.ParentID
+		if parentID == 0 {
+			return "", nil
+		}
+
+		space, err = c.spaceStore.Find(ctx, parentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to find parent space with id %d: %w", parentID, err)
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+func setupMeController(t *testing.T, memberships ...*types.Membership) (*Controller, *auth.Session) {
+	t.Helper()
+
+	c := setupLoginController(t)
+
+	spaceStore := &spaceStoreFake{spaces: []*types.Space{
+		{ID: 1, Identifier: "root", Path: "root"},
+		{ID: 2, ParentID: 1, Identifier: "child", Path: "root/child"},
+		{ID: 3, Identifier: "other", Path: "other"},
+	}}
+	membershipStore := &membershipStoreFake{memberships: memberships}
+
+	c.spaceStore = spaceStore
+	c.membershipStore = membershipStore
+	c.authorizer = authz.NewMembershipAuthorizer(
+		authz.NewPermissionCache(spaceStore, membershipStore, nil, nil, time.Minute),
+		spaceStore,
+		nil,
+	)
+
+	principal, err := c.principalStore.FindByUID(context.Background(), "user")
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+
+	return c, &auth.Session{Principal: *principal}
+}
+
+func TestMe_NonAdmin(t *testing.T) {
+	c, session := setupMeController(t, &types.Membership{
+		MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 1},
+		Role:          enum.MembershipRoleContributor,
+	})
+
+	me, err := c.Me(context.Background(), session, &MeInput{SpaceRef: "root/child"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if me.Admin {
+		t.Errorf("Want admin false, got true")
+	}
+	if me.User == nil || me.User.UID != "user" {
+		t.Fatalf("Want user 'user', got %+v", me.User)
+	}
+	if me.Space == nil {
+		t.Fatalf("Want space access, got nil")
+	}
+	if me.Space.Path != "root/child" {
+		t.Errorf("Want space path 'root/child', got '%s'", me.Space.Path)
+	}
+	if me.Space.Role != enum.MembershipRoleContributor {
+		t.Errorf("Want inherited role '%s', got '%s'", enum.MembershipRoleContributor, me.Space.Role)
+	}
+	if want := []enum.Permission{enum.PermissionSpaceView}; !slices.Equal(me.Space.Permissions, want) {
+		t.Errorf("Want permissions %v, got %v", want, me.Space.Permissions)
+	}
+	if me.Repo != nil {
+		t.Errorf("Want no repo access, got %+v", me.Repo)
+	}
+}
+
+func TestMe_ClosestRole(t *testing.T) {
+	c, session := setupMeController(t,
+		&types.Membership{
+			MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 1},
+			Role:          enum.MembershipRoleReader,
+		},
+		&types.Membership{
+			MembershipKey: types.MembershipKey{SpaceID: 2, PrincipalID: 1},
+			Role:          enum.MembershipRoleSpaceOwner,
+		},
+	)
+
+	me, err := c.Me(context.Background(), session, &MeInput{SpaceRef: "root/child"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if me.Space.Role != enum.MembershipRoleSpaceOwner {
+		t.Errorf("Want role '%s', got '%s'", enum.MembershipRoleSpaceOwner, me.Space.Role)
+	}
+	if !slices.Equal(me.Space.Permissions, meSpacePermissions) {
+		t.Errorf("Want permissions %v, got %v", meSpacePermissions, me.Space.Permissions)
+	}
+}
+
+func TestMe_Admin(t *testing.T) {
+	c, session := setupMeController(t)
+	session.Principal.Admin = true
+
+	me, err := c.Me(context.Background(), session, &MeInput{SpaceRef: "other"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !me.Admin {
+		t.Errorf("Want admin true, got false")
+	}
+	if me.Space.Role != "" {
+		t.Errorf("Want no role, got '%s'", me.Space.Role)
+	}
+	if !slices.Equal(me.Space.Permissions, meSpacePermissions) {
+		t.Errorf("Want permissions %v, got %v", meSpacePermissions, me.Space.Permissions)
+	}
+}
+
+func TestMe_SpaceNotAccessible(t *testing.T) {
+	c, session := setupMeController(t)
+
+	_, err := c.Me(context.Background(), session, &MeInput{SpaceRef: "other"})
+	if !errors.Is(err, apiauth.ErrNotAuthorized) {
+		t.Errorf("Want error %v, got %v", apiauth.ErrNotAuthorized, err)
+	}
+}
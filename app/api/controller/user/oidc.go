@@ -0,0 +1,292 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/oidc"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// oidcRandomLength is the number of random bytes used for the state and the nonce of an oidc login.
+	oidcRandomLength = 32
+
+	// oidcUIDSuffixLength is the length of the random suffix appended to provisioned uids that are taken already.
+	oidcUIDSuffixLength = 6
+)
+
+var errOIDCNotConfigured = usererror.NotFound("OIDC login is not configured")
+
+// OIDCLoginOutput contains the information required to redirect a user to the identity provider.
+// The state and nonce have to be stored by the client and passed back with the callback.
+type OIDCLoginOutput struct {
+	RedirectURL string
+	State       string
+	Nonce       string
+}
+
+// OIDCCallbackInput contains the values returned by the identity provider,
+// together with the state and nonce that were generated for the login.
+type OIDCCallbackInput struct {
+	Code          string
+	State         string
+	ExpectedState string
+	ExpectedNonce string
+}
+
+/*
+ * OIDCLogin starts a login via the configured OpenID Connect identity provider.
+ */
+func (c *Controller) OIDCLogin(ctx context.Context) (*OIDCLoginOutput, error) {
+	if c.oidcProvider == nil {
+		return nil, errOIDCNotConfigured
+	}
+
+	state, err := generateOIDCRandom()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := generateOIDCRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	redirectURL, err := c.oidcProvider.AuthCodeURL(ctx, state, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth code url: %w", err)
+	}
+
+	return &OIDCLoginOutput{
+		RedirectURL: redirectURL,
+		State:       state,
+		Nonce:       nonce,
+	}, nil
+}
+
+/*
+ * OIDCCallback completes a login via the configured OpenID Connect identity provider.
+ * The user is matched by the verified email of the ID token, and provisioned if it doesn't exist yet.
+ * Returns the session token if successful.
+ */
+func (c *Controller) OIDCCallback(
+	ctx context.Context,
+	in *OIDCCallbackInput,
+) (*types.TokenResponse, error) {
+	if c.oidcProvider == nil {
+		return nil, errOIDCNotConfigured
+	}
+
+	if in.ExpectedState == "" || subtle.ConstantTimeCompare([]byte(in.State), []byte(in.ExpectedState)) != 1 {
+		return nil, usererror.BadRequest("OIDC state is invalid")
+	}
+	if in.Code == "" {
+		return nil, usererror.BadRequest("OIDC authorization code is missing")
+	}
+
+	claims, err := c.oidcProvider.Exchange(ctx, in.Code)
+	if errors.Is(err, oidc.ErrInvalidToken) {
+		log.Ctx(ctx).Debug().Err(err).Msg("invalid id token during oidc login (returning ErrUnauthorized).")
+		return nil, usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange oidc authorization code: %w", err)
+	}
+
+	if in.ExpectedNonce == "" || subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(in.ExpectedNonce)) != 1 {
+		log.Ctx(ctx).Debug().Msg("nonce mismatch during oidc login (returning ErrUnauthorized).")
+		return nil, usererror.ErrUnauthorized
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, usererror.Forbidden("OIDC login requires a verified email")
+	}
+
+	user, err := c.findOrProvisionOIDCUser(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		return nil, usererror.Forbidden("User is blocked")
+	}
+
+	firstLogin, err := c.handleFirstLogin(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenIdentifier, err := generateSessionTokenIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	token, jwtToken, err := c.tokenIssuer.CreateUserSession(ctx, user, tokenIdentifier,
+		c.config.Token.Expire)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken, FirstLogin: firstLogin}, nil
+}
+
+// findOrProvisionOIDCUser returns the user linked to the identity (issuer and subject) of the claims.
+// Existing users with the email of the claims are only linked if enabled and they are local users.
+// If no such user exists and signup is allowed, a new user is created.
+func (c *Controller) findOrProvisionOIDCUser(ctx context.Context, claims *oidc.Claims) (*types.User, error) {
+	if claims.Subject == "" {
+		log.Ctx(ctx).Debug().Msg("missing subject during oidc login (returning ErrUnauthorized).")
+		return nil, usererror.ErrUnauthorized
+	}
+	externalID := claims.ExternalID()
+
+	user, err := c.principalStore.FindUserByExternalID(ctx, oidc.AuthSource, externalID)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user by external id: %w", err)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+
+	user, err = findUserFromEmail(ctx, c.principalStore, email)
+	if err == nil {
+		return c.linkOIDCUser(ctx, user, externalID)
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	if !c.config.OIDC.AllowSignup {
+		return nil, usererror.Forbidden("User registration is disabled")
+	}
+
+	uid, err := c.oidcUID(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := strings.TrimSpace(claims.Name)
+	if displayName == "" {
+		displayName = uid
+	}
+
+	// the user logs in via the identity provider, the random password is never handed out.
+	// the user is linked with the same insert, otherwise it could be left without its external id.
+	return c.createNoAuth(ctx, &CreateInput{
+		UID:         uid,
+		Email:       email,
+		DisplayName: displayName,
+		Password:    uniuri.NewLen(uniuri.UUIDLen),
+	}, false, func(user *types.User) {
+		// the email was verified by the identity provider.
+		user.EmailVerified = true
+		user.AuthSource = oidc.AuthSource
+		user.ExternalID = externalID
+	})
+}
+
+// linkOIDCUser links the existing user with the email of the claims to the identity of the claims.
+// NOTE: Linking is opt-in, as otherwise whoever controls the email at the identity provider
+// could take over the account.
+func (c *Controller) linkOIDCUser(ctx context.Context, user *types.User, externalID string) (*types.User, error) {
+	if !c.config.OIDC.LinkByEmail || user.AuthSource != "" {
+		log.Ctx(ctx).Warn().
+			Str("user_uid", user.UID).
+			Str("auth_source", user.AuthSource).
+			Msg("oidc user matches the email of a user that isn't linked to the identity provider")
+		return nil, usererror.Forbidden("A user with the same email exists already")
+	}
+
+	user.AuthSource = oidc.AuthSource
+	user.ExternalID = externalID
+	user.Updated = time.Now().UnixMilli()
+	if err := c.principalStore.UpdateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to link user to identity provider: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("user_uid", user.UID).
+		Msg("linked existing user to identity provider")
+
+	return user, nil
+}
+
+// oidcUID derives the uid of a provisioned user from the preferred username or the email of the claims.
+// A random suffix is appended in case the uid is taken already.
+func (c *Controller) oidcUID(ctx context.Context, claims *oidc.Claims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	uid := sanitizeOIDCUID(base)
+	if uid == "" {
+		uid = "user"
+	}
+
+	if err := c.principalUIDCheck(uid); err == nil {
+		_, err = c.principalStore.FindByUID(ctx, uid)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return uid, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to find principal by uid: %w", err)
+		}
+	}
+
+	return uid + "-" + strings.ToLower(uniuri.NewLen(oidcUIDSuffixLength)), nil
+}
+
+// sanitizeOIDCUID replaces all characters that aren't allowed in a uid.
+func sanitizeOIDCUID(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '-'
+		}
+	}, strings.TrimSpace(s))
+
+	// leave room for the random suffix.
+	if maxLength := check.MaxIdentifierLength - oidcUIDSuffixLength - 1; len(s) > maxLength {
+		s = s[:maxLength]
+	}
+
+	return s
+}
+
+func generateOIDCRandom() (string, error) {
+	b := make([]byte, oidcRandomLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,390 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/auth/password"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// oidcProviderFake returns the configured claims for any authorization code.
+type oidcProviderFake struct {
+	claims *oidc.Claims
+	err    error
+}
+
+func (p *oidcProviderFake) AuthCodeURL(_ context.Context, state string, nonce string) (string, error) {
+	return "https://idp.example.com/auth?state=" + state + "&nonce=" + nonce, nil
+}
+
+func (p *oidcProviderFake) Exchange(context.Context, string) (*oidc.Claims, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.claims, nil
+}
+
+func setupOIDCController(t *testing.T, claims *oidc.Claims) (*Controller, *principalStoreFake) {
+	t.Helper()
+
+	principalStore := &principalStoreFake{}
+	principalStore.addPrincipal(&types.Principal{
+		UID:   "admin",
+		Email: "admin@example.com",
+		Type:  enum.PrincipalTypeUser,
+		Admin: true,
+	}, "")
+	principalStore.addPrincipal(&types.Principal{
+		UID:   "existing",
+		Email: "existing@example.com",
+		Type:  enum.PrincipalTypeUser,
+	}, "")
+
+	config := &types.Config{}
+	config.Token.Expire = 24 * time.Hour
+	config.OIDC.AllowSignup = true
+
+	tokenStore := &tokenStoreFake{}
+
+	return &Controller{
+		config:            config,
+		principalUIDCheck: check.PrincipalUIDDefault,
+		oidcProvider:      &oidcProviderFake{claims: claims},
+		passwordHasher:    password.NewBcrypt(bcrypt.MinCost),
+		principalStore:    principalStore,
+		userEmailStore:    &userEmailStoreFake{},
+		tokenStore:        tokenStore,
+		tokenIssuer:       token.NewIssuer(tokenStore, jwt.NewKeySet(0), jwt.GenerateForToken),
+	}, principalStore
+}
+
+func oidcCallbackInput() *OIDCCallbackInput {
+	return &OIDCCallbackInput{
+		Code:          "code",
+		State:         "state",
+		ExpectedState: "state",
+		ExpectedNonce: "nonce",
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	c, _ := setupOIDCController(t, nil)
+
+	out, err := c.OIDCLogin(context.Background())
+	if err != nil {
+		t.Fatalf("Want oidc login to succeed, got %v", err)
+	}
+	if out.State == "" || out.Nonce == "" || out.State == out.Nonce {
+		t.Errorf("Want distinct random state and nonce, got %q and %q", out.State, out.Nonce)
+	}
+	if out.RedirectURL == "" {
+		t.Errorf("Want redirect url to be returned")
+	}
+}
+
+func TestOIDCLogin_NotConfigured(t *testing.T) {
+	c, _ := setupOIDCController(t, nil)
+	c.oidcProvider = nil
+
+	_, err := c.OIDCLogin(context.Background())
+	if got, want := usererror.Translate(context.Background(), err).Status, 404; got != want {
+		t.Errorf("Want status %d, got %d", want, got)
+	}
+}
+
+func TestOIDCCallback_ProvisionsUser(t *testing.T) {
+	c, principalStore := setupOIDCController(t, &oidc.Claims{
+		Issuer:            "https://idp.example.com",
+		Subject:           "subject",
+		Email:             "New.User@example.com",
+		EmailVerified:     true,
+		Name:              "New User",
+		PreferredUsername: "new user",
+		Nonce:             "nonce",
+	})
+
+	res, err := c.OIDCCallback(context.Background(), oidcCallbackInput())
+	if err != nil {
+		t.Fatalf("Want oidc callback to succeed, got %v", err)
+	}
+	if res.AccessToken == "" {
+		t.Errorf("Want access token to be returned")
+	}
+	if got, want := res.Token.Type, enum.TokenTypeSession; got != want {
+		t.Errorf("Want token type %q, got %q", want, got)
+	}
+
+	user, err := principalStore.FindUserByEmail(context.Background(), "new.user@example.com")
+	if err != nil {
+		t.Fatalf("Want user to be provisioned, got %v", err)
+	}
+	if user.AuthSource != oidc.AuthSource || user.ExternalID != "https://idp.example.com#subject" {
+		t.Errorf("Want user to be linked to the identity provider, got %q/%q", user.AuthSource, user.ExternalID)
+	}
+	if got, want := user.UID, "new-user"; got != want {
+		t.Errorf("Want uid %q, got %q", want, got)
+	}
+	if got, want := user.DisplayName, "New User"; got != want {
+		t.Errorf("Want display name %q, got %q", want, got)
+	}
+	if !user.EmailVerified {
+		t.Errorf("Want email of provisioned user to be verified")
+	}
+	if user.Admin {
+		t.Errorf("Want provisioned user not to be admin")
+	}
+}
+
+func TestOIDCCallback_ProvisionsLinkedUserWithSingleInsert(t *testing.T) {
+	c, principalStore := setupOIDCController(t, &oidc.Claims{
+		Issuer:        "https://idp.example.com",
+		Subject:       "subject",
+		Email:         "new@example.com",
+		EmailVerified: true,
+		Nonce:         "nonce",
+	})
+	// the user has to be linked when it's created, a failing update can't leave it unlinked.
+	principalStore.updateUserErr = errors.New("update failed")
+
+	if _, err := c.OIDCCallback(context.Background(), oidcCallbackInput()); err != nil {
+		t.Fatalf("Want oidc callback to succeed, got %v", err)
+	}
+
+	user, err := principalStore.FindUserByExternalID(context.Background(), oidc.AuthSource,
+		"https://idp.example.com#subject")
+	if err != nil {
+		t.Fatalf("Want provisioned user to be linked to the identity provider, got %v", err)
+	}
+	if !user.EmailVerified {
+		t.Errorf("Want email of provisioned user to be verified")
+	}
+}
+
+func TestOIDCCallback_MatchesLinkedUser(t *testing.T) {
+	claims := &oidc.Claims{
+		Issuer:        "https://idp.example.com",
+		Subject:       "subject",
+		Email:         "changed@example.com",
+		EmailVerified: true,
+		Nonce:         "nonce",
+	}
+	c, principalStore := setupOIDCController(t, claims)
+
+	// the identity is matched even if the email changed at the identity provider.
+	user, _ := principalStore.FindUser(context.Background(), 2)
+	user.AuthSource = oidc.AuthSource
+	user.ExternalID = claims.ExternalID()
+	_ = principalStore.UpdateUser(context.Background(), user)
+
+	res, err := c.OIDCCallback(context.Background(), oidcCallbackInput())
+	if err != nil {
+		t.Fatalf("Want oidc callback to succeed, got %v", err)
+	}
+	if got, want := res.Token.PrincipalID, int64(2); got != want {
+		t.Errorf("Want token for principal %d, got %d", want, got)
+	}
+	if principalStore.createUserCalls != 0 {
+		t.Errorf("Want no user to be created, got %d", principalStore.createUserCalls)
+	}
+}
+
+func TestOIDCCallback_LinkByEmail(t *testing.T) {
+	claims := &oidc.Claims{
+		Issuer:        "https://idp.example.com",
+		Subject:       "subject",
+		Email:         "EXISTING@example.com",
+		EmailVerified: true,
+		Nonce:         "nonce",
+	}
+
+	tests := []struct {
+		name        string
+		linkByEmail bool
+		authSource  string
+		wantStatus  int
+	}{
+		{
+			name:       "disabled",
+			wantStatus: 403,
+		},
+		{
+			name:        "enabled for local user",
+			linkByEmail: true,
+		},
+		{
+			name:        "enabled for user of other auth source",
+			linkByEmail: true,
+			authSource:  "ldap",
+			wantStatus:  403,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, principalStore := setupOIDCController(t, claims)
+			c.config.OIDC.LinkByEmail = test.linkByEmail
+
+			user, _ := principalStore.FindUser(context.Background(), 2)
+			user.AuthSource = test.authSource
+			_ = principalStore.UpdateUser(context.Background(), user)
+
+			res, err := c.OIDCCallback(context.Background(), oidcCallbackInput())
+			if test.wantStatus != 0 {
+				if got := usererror.Translate(context.Background(), err).Status; got != test.wantStatus {
+					t.Errorf("Want status %d, got %d (%v)", test.wantStatus, got, err)
+				}
+				if user, _ = principalStore.FindUser(context.Background(), 2); user.ExternalID != "" {
+					t.Errorf("Want user not to be linked, got external id %q", user.ExternalID)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Want oidc callback to succeed, got %v", err)
+			}
+			if got, want := res.Token.PrincipalID, int64(2); got != want {
+				t.Errorf("Want token for principal %d, got %d", want, got)
+			}
+			user, _ = principalStore.FindUser(context.Background(), 2)
+			if user.AuthSource != oidc.AuthSource || user.ExternalID != claims.ExternalID() {
+				t.Errorf("Want user to be linked to the identity provider, got %q/%q", user.AuthSource, user.ExternalID)
+			}
+			if principalStore.createUserCalls != 0 {
+				t.Errorf("Want no user to be created, got %d", principalStore.createUserCalls)
+			}
+		})
+	}
+}
+
+func TestOIDCCallback_UIDTaken(t *testing.T) {
+	c, principalStore := setupOIDCController(t, &oidc.Claims{
+		Subject:       "subject",
+		Email:         "existing@other.example.com",
+		EmailVerified: true,
+		Nonce:         "nonce",
+	})
+
+	if _, err := c.OIDCCallback(context.Background(), oidcCallbackInput()); err != nil {
+		t.Fatalf("Want oidc callback to succeed, got %v", err)
+	}
+
+	user, err := principalStore.FindUserByEmail(context.Background(), "existing@other.example.com")
+	if err != nil {
+		t.Fatalf("Want user to be provisioned, got %v", err)
+	}
+	if user.UID == "existing" || len(user.UID) != len("existing-")+oidcUIDSuffixLength {
+		t.Errorf("Want uid with random suffix, got %q", user.UID)
+	}
+}
+
+func TestOIDCCallback_Errors(t *testing.T) {
+	validClaims := oidc.Claims{Subject: "subject", Email: "user@example.com", EmailVerified: true, Nonce: "nonce"}
+
+	tests := []struct {
+		name        string
+		claims      oidc.Claims
+		exchangeErr error
+		in          func(in *OIDCCallbackInput)
+		allowSignup bool
+		wantStatus  int
+	}{
+		{
+			name:        "state mismatch",
+			claims:      validClaims,
+			in:          func(in *OIDCCallbackInput) { in.State = "other" },
+			allowSignup: true,
+			wantStatus:  400,
+		},
+		{
+			name:        "state missing",
+			claims:      validClaims,
+			in:          func(in *OIDCCallbackInput) { in.State, in.ExpectedState = "", "" },
+			allowSignup: true,
+			wantStatus:  400,
+		},
+		{
+			name:        "invalid token",
+			exchangeErr: oidc.ErrInvalidToken,
+			allowSignup: true,
+			wantStatus:  401,
+		},
+		{
+			name:        "nonce mismatch",
+			claims:      oidc.Claims{Email: "user@example.com", EmailVerified: true, Nonce: "other"},
+			allowSignup: true,
+			wantStatus:  401,
+		},
+		{
+			name:        "unverified email",
+			claims:      oidc.Claims{Email: "user@example.com", EmailVerified: false, Nonce: "nonce"},
+			allowSignup: true,
+			wantStatus:  403,
+		},
+		{
+			name:        "subject missing",
+			claims:      oidc.Claims{Email: "user@example.com", EmailVerified: true, Nonce: "nonce"},
+			allowSignup: true,
+			wantStatus:  401,
+		},
+		{
+			name:        "signup disabled",
+			claims:      validClaims,
+			allowSignup: false,
+			wantStatus:  403,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			claims := test.claims
+			c, principalStore := setupOIDCController(t, &claims)
+			c.oidcProvider = &oidcProviderFake{claims: &claims, err: test.exchangeErr}
+			c.config.OIDC.AllowSignup = test.allowSignup
+
+			in := oidcCallbackInput()
+			if test.in != nil {
+				test.in(in)
+			}
+
+			_, err := c.OIDCCallback(context.Background(), in)
+			if err == nil {
+				t.Fatalf("Want oidc callback to fail")
+			}
+			var uErr *usererror.Error
+			if !errors.As(err, &uErr) {
+				t.Fatalf("Want user error, got %v", err)
+			}
+			if got := uErr.Status; got != test.wantStatus {
+				t.Errorf("Want status %d, got %d", test.wantStatus, got)
+			}
+			if principalStore.createUserCalls != 0 {
+				t.Errorf("Want no user to be created, got %d", principalStore.createUserCalls)
+			}
+		})
+	}
+}
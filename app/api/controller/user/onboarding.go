@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CompleteOnboarding marks the onboarding of the user as completed.
+func (c *Controller) CompleteOnboarding(ctx context.Context, session *auth.Session,
+	userUID string) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if user.Onboarded {
+		return user, nil
+	}
+
+	if err = c.markOnboarded(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// handleFirstLogin returns true if the user wasn't onboarded yet.
+// If configured, the onboarding is completed with the first login.
+func (c *Controller) handleFirstLogin(ctx context.Context, user *types.User) (bool, error) {
+	if user.Onboarded {
+		return false, nil
+	}
+
+	if !c.config.Auth.CompleteOnboardingOnLogin {
+		return true, nil
+	}
+
+	if err := c.markOnboarded(ctx, user); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *Controller) markOnboarded(ctx context.Context, user *types.User) error {
+	user.Onboarded = true
+	user.Updated = time.Now().UnixMilli()
+
+	if err := c.principalStore.UpdateUser(ctx, user); err != nil {
+		return fmt.Errorf("failed to mark user as onboarded: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLogin_FirstLogin(t *testing.T) {
+	c := setupLoginController(t)
+
+	res, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"})
+	if err != nil {
+		t.Fatalf("Want user login to succeed, got %v", err)
+	}
+	if !res.FirstLogin {
+		t.Errorf("Want first login flag to be set on the first login")
+	}
+
+	for i := 0; i < 2; i++ {
+		res, err = c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"})
+		if err != nil {
+			t.Fatalf("Want user login to succeed, got %v", err)
+		}
+		if res.FirstLogin {
+			t.Errorf("Want first login flag to not be set on subsequent logins")
+		}
+	}
+
+	user, err := c.principalStore.FindUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+	if !user.Onboarded {
+		t.Errorf("Want user to be onboarded after the first login")
+	}
+}
+
+func TestLogin_FirstLoginExplicitOnboarding(t *testing.T) {
+	c := setupLoginController(t)
+	c.config.Auth.CompleteOnboardingOnLogin = false
+
+	for i := 0; i < 2; i++ {
+		res, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"})
+		if err != nil {
+			t.Fatalf("Want user login to succeed, got %v", err)
+		}
+		if !res.FirstLogin {
+			t.Errorf("Want first login flag to be set until the onboarding is completed")
+		}
+	}
+
+	user, err := c.principalStore.FindUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+	if err = c.markOnboarded(context.Background(), user); err != nil {
+		t.Fatalf("failed to complete onboarding: %v", err)
+	}
+
+	res, err := c.Login(context.Background(), &LoginInput{LoginIdentifier: "user", Password: "password"})
+	if err != nil {
+		t.Fatalf("Want user login to succeed, got %v", err)
+	}
+	if res.FirstLogin {
+		t.Errorf("Want first login flag to not be set after the onboarding was completed")
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/middleware/ratelimit"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/notification/mailer"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RequestPasswordResetInput struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+/*
+ * RequestPasswordReset sends a password reset link to the email of the user.
+ * To prevent enumeration of accounts, the call doesn't indicate whether a reset link was sent.
+ */
+func (c *Controller) RequestPasswordReset(
+	ctx context.Context,
+	in *RequestPasswordResetInput,
+) error {
+	// no auth check required, the reset link is sent to the email of the user.
+
+	email := strings.TrimSpace(in.Email)
+	if email == "" {
+		return usererror.BadRequest("Email is required.")
+	}
+
+	// limit by the provided email to not leak whether the account exists.
+	if c.passwordResetLimiter != nil {
+		state := c.passwordResetLimiter.Allow("account:" + strings.ToLower(email))
+		if !state.Allowed {
+			return &ratelimit.ExceededError{State: state}
+		}
+	}
+
+	user, err := findUserFromEmail(ctx, c.principalStore, email)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to find user for password reset (skipping).")
+		return nil
+	}
+
+	if user.Blocked {
+		log.Ctx(ctx).Debug().Str("user_uid", user.UID).Msg("password reset skipped for blocked user.")
+		return nil
+	}
+
+	if c.config.Auth.PasswordReset.RequireVerifiedEmail && !user.EmailVerified {
+		log.Ctx(ctx).Debug().Str("user_uid", user.UID).Msg("password reset skipped for unverified email.")
+		return nil
+	}
+
+	tokenIdentifier := fmt.Sprintf("password-reset-%d", time.Now().UnixMilli())
+	_, jwtToken, err := c.tokenIssuer.CreatePasswordReset(ctx, user, tokenIdentifier,
+		c.config.Auth.PasswordReset.TokenLifetime)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	if err = c.invalidateExcessResetTokens(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to invalidate excess password reset tokens: %w", err)
+	}
+
+	resetURL := fmt.Sprintf("%s/reset-password?token=%s",
+		strings.TrimSuffix(c.config.URL.UI, "/"), url.QueryEscape(jwtToken))
+
+	err = c.mailer.Send(ctx, mailer.Payload{
+		ToRecipients: []string{user.Email},
+		Subject:      "Reset your password",
+		Body: fmt.Sprintf(`<p>A password reset was requested for your account %s.</p>`+
+			`<p><a href="%s">Reset your password</a></p>`+
+			`<p>If you didn't request a password reset, you can ignore this email.</p>`,
+			html.EscapeString(user.UID), html.EscapeString(resetURL)),
+	})
+	if err != nil {
+		// don't leak the existence of the account via the response.
+		log.Ctx(ctx).Warn().Err(err).Str("user_uid", user.UID).Msg("failed to send password reset email.")
+	}
+
+	return nil
+}
+
+/*
+ * ResetPassword resets the password of the user the password reset token was issued for.
+ */
+func (c *Controller) ResetPassword(
+	ctx context.Context,
+	in *ResetPasswordInput,
+) error {
+	// no auth check required, the password reset token is used for it.
+
+	if err := check.Password(in.Password); err != nil {
+		return err
+	}
+
+	resetToken, user, err := c.findUserForToken(ctx, in.Token, enum.TokenTypePasswordReset)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("invalid password reset token (returning ErrUnauthorized).")
+		return usererror.ErrUnauthorized
+	}
+
+	hash, err := c.passwordHasher.Hash(in.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user.Password = hash
+	user.Updated = time.Now().UnixMilli()
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		// password reset tokens can only be used once - deleting the token first ensures that
+		// concurrent requests with the same token can't both reset the password.
+		if err := c.tokenStore.Delete(ctx, resetToken.ID); errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return usererror.ErrUnauthorized
+		} else if err != nil {
+			return fmt.Errorf("failed to delete password reset token: %w", err)
+		}
+
+		if err := c.principalStore.UpdateUser(ctx, user); err != nil {
+			return fmt.Errorf("failed to update user password: %w", err)
+		}
+
+		// revoke all existing sessions and tokens of the user, they might have been issued to an attacker.
+		if _, err := c.principalStore.IncrementTokenGeneration(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to revoke tokens after password reset: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// invalidateExcessResetTokens deletes the oldest outstanding password reset tokens of the principal
+// that exceed the configured maximum.
+func (c *Controller) invalidateExcessResetTokens(ctx context.Context, principalID int64) error {
+	maxTokens := c.config.Auth.PasswordReset.MaxOutstandingTokens
+	if maxTokens <= 0 {
+		return nil
+	}
+
+	tokens, err := c.tokenStore.List(ctx, principalID, enum.TokenTypePasswordReset)
+	if err != nil {
+		return fmt.Errorf("failed to list password reset tokens: %w", err)
+	}
+
+	if len(tokens) <= maxTokens {
+		return nil
+	}
+
+	// newest first
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].IssuedAt != tokens[j].IssuedAt {
+			return tokens[i].IssuedAt > tokens[j].IssuedAt
+		}
+		return tokens[i].ID > tokens[j].ID
+	})
+
+	for _, tkn := range tokens[maxTokens:] {
+		if err = c.tokenStore.Delete(ctx, tkn.ID); err != nil {
+			return fmt.Errorf("failed to delete password reset token %d: %w", tkn.ID, err)
+		}
+	}
+
+	return nil
+}
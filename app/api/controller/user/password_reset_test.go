@@ -0,0 +1,303 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/middleware/ratelimit"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// mailerFake records all sent mails.
+type mailerFake struct {
+	sent []mailer.Payload
+}
+
+func (m *mailerFake) Send(_ context.Context, payload mailer.Payload) error {
+	m.sent = append(m.sent, payload)
+	return nil
+}
+
+var resetTokenRegex = regexp.MustCompile(`token=([^"&]+)`)
+
+func setupPasswordResetController(t *testing.T, emailVerified bool) (*Controller, *mailerFake) {
+	t.Helper()
+
+	c := setupLoginController(t)
+	c.config.URL.UI = "https://gitness.example.com"
+	c.config.Auth.PasswordReset.RequireVerifiedEmail = true
+	c.config.Auth.PasswordReset.TokenLifetime = time.Hour
+
+	principalStore, _ := c.principalStore.(*principalStoreFake)
+	principalStore.emailVerified = map[int64]bool{1: emailVerified}
+
+	m := &mailerFake{}
+	c.mailer = m
+	c.tx = txFake{}
+
+	return c, m
+}
+
+func TestRequestPasswordReset_Verified(t *testing.T) {
+	c, m := setupPasswordResetController(t, true)
+
+	err := c.RequestPasswordReset(context.Background(), &RequestPasswordResetInput{Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("Want password reset request to succeed, got %v", err)
+	}
+
+	if len(m.sent) != 1 {
+		t.Fatalf("Want 1 password reset mail, got %d", len(m.sent))
+	}
+
+	resetToken := extractResetToken(t, m.sent[0])
+
+	err = c.ResetPassword(context.Background(), &ResetPasswordInput{Token: resetToken, Password: "new-password"})
+	if err != nil {
+		t.Fatalf("Want password reset to succeed, got %v", err)
+	}
+
+	user, _ := c.principalStore.FindUser(context.Background(), 1)
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("new-password")) != nil {
+		t.Errorf("Want password to be updated")
+	}
+
+	if user.TokenGeneration != 1 {
+		t.Errorf("Want existing tokens to be revoked after password reset, got token generation %d",
+			user.TokenGeneration)
+	}
+
+	// reset tokens can only be used once
+	err = c.ResetPassword(context.Background(), &ResetPasswordInput{Token: resetToken, Password: "other-password"})
+	if !errors.Is(err, usererror.ErrUnauthorized) {
+		t.Errorf("Want used reset token to be rejected with %v, got %v", usererror.ErrUnauthorized, err)
+	}
+}
+
+func TestRequestPasswordReset_Unverified(t *testing.T) {
+	c, m := setupPasswordResetController(t, false)
+
+	err := c.RequestPasswordReset(context.Background(), &RequestPasswordResetInput{Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("Want password reset request to silently succeed, got %v", err)
+	}
+
+	if len(m.sent) != 0 {
+		t.Errorf("Want no password reset mail for unverified email, got %d", len(m.sent))
+	}
+
+	tokenStore, _ := c.tokenStore.(*tokenStoreFake)
+	for _, tkn := range tokenStore.tokens {
+		if tkn.Type == enum.TokenTypePasswordReset {
+			t.Errorf("Want no password reset token for unverified email")
+		}
+	}
+}
+
+func TestRequestPasswordReset_UnverifiedWithoutPolicy(t *testing.T) {
+	c, m := setupPasswordResetController(t, false)
+	c.config.Auth.PasswordReset.RequireVerifiedEmail = false
+
+	err := c.RequestPasswordReset(context.Background(), &RequestPasswordResetInput{Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("Want password reset request to succeed, got %v", err)
+	}
+
+	if len(m.sent) != 1 {
+		t.Errorf("Want 1 password reset mail, got %d", len(m.sent))
+	}
+}
+
+func TestRequestPasswordReset_UnknownEmail(t *testing.T) {
+	c, m := setupPasswordResetController(t, true)
+
+	err := c.RequestPasswordReset(context.Background(), &RequestPasswordResetInput{Email: "unknown@example.com"})
+	if err != nil {
+		t.Fatalf("Want password reset request to silently succeed, got %v", err)
+	}
+
+	if len(m.sent) != 0 {
+		t.Errorf("Want no password reset mail for unknown email, got %d", len(m.sent))
+	}
+}
+
+func TestRequestPasswordReset_MaxOutstandingTokens(t *testing.T) {
+	c, m := setupPasswordResetController(t, true)
+	c.config.Auth.PasswordReset.MaxOutstandingTokens = 2
+
+	for i := 0; i < 3; i++ {
+		err := c.RequestPasswordReset(context.Background(), &RequestPasswordResetInput{Email: "user@example.com"})
+		if err != nil {
+			t.Fatalf("Want password reset request %d to succeed, got %v", i, err)
+		}
+	}
+
+	if len(m.sent) != 3 {
+		t.Fatalf("Want 3 password reset mails, got %d", len(m.sent))
+	}
+
+	tokenStore, _ := c.tokenStore.(*tokenStoreFake)
+	tokens, _ := tokenStore.List(context.Background(), 1, enum.TokenTypePasswordReset)
+	if len(tokens) != 2 {
+		t.Fatalf("Want 2 outstanding reset tokens, got %d", len(tokens))
+	}
+
+	// the oldest token got invalidated, the newest is still valid.
+	oldest := extractResetToken(t, m.sent[0])
+	err := c.ResetPassword(context.Background(), &ResetPasswordInput{Token: oldest, Password: "new-password"})
+	if !errors.Is(err, usererror.ErrUnauthorized) {
+		t.Errorf("Want oldest reset token to be rejected with %v, got %v", usererror.ErrUnauthorized, err)
+	}
+
+	newest := extractResetToken(t, m.sent[2])
+	err = c.ResetPassword(context.Background(), &ResetPasswordInput{Token: newest, Password: "new-password"})
+	if err != nil {
+		t.Errorf("Want newest reset token to succeed, got %v", err)
+	}
+}
+
+func TestRequestPasswordReset_RateLimitPerAccount(t *testing.T) {
+	c, m := setupPasswordResetController(t, true)
+	c.passwordResetLimiter = ratelimit.NewFixedWindow(2, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		err := c.RequestPasswordReset(context.Background(), &RequestPasswordResetInput{Email: "user@example.com"})
+		if err != nil {
+			t.Fatalf("Want password reset request %d to succeed, got %v", i, err)
+		}
+	}
+
+	err := c.RequestPasswordReset(context.Background(), &RequestPasswordResetInput{Email: "USER@example.com"})
+	uErr := &usererror.Error{}
+	if !errors.As(err, &uErr) || uErr.Status != http.StatusTooManyRequests {
+		t.Fatalf("Want status %d, got %v", http.StatusTooManyRequests, err)
+	}
+	limitErr := &ratelimit.ExceededError{}
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("Want limiter state to be surfaced, got %v", err)
+	}
+	if limitErr.State.Limit != 2 || limitErr.State.Remaining != 0 || limitErr.State.Reset.IsZero() {
+		t.Errorf("Want limit 2 with 0 remaining and a reset time, got %+v", limitErr.State)
+	}
+
+	if len(m.sent) != 2 {
+		t.Errorf("Want 2 password reset mails, got %d", len(m.sent))
+	}
+
+	// other accounts aren't affected.
+	err = c.RequestPasswordReset(context.Background(), &RequestPasswordResetInput{Email: "unknown@example.com"})
+	if err != nil {
+		t.Errorf("Want password reset request for other account to succeed, got %v", err)
+	}
+}
+
+func TestResetPassword_Blocked(t *testing.T) {
+	c, m := setupPasswordResetController(t, true)
+
+	err := c.RequestPasswordReset(context.Background(), &RequestPasswordResetInput{Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("Want password reset request to succeed, got %v", err)
+	}
+	if len(m.sent) != 1 {
+		t.Fatalf("Want 1 password reset mail, got %d", len(m.sent))
+	}
+	resetToken := extractResetToken(t, m.sent[0])
+
+	// the user gets blocked after the reset was requested.
+	principalStore, _ := c.principalStore.(*principalStoreFake)
+	principalStore.principals[0].Blocked = true
+
+	err = c.ResetPassword(context.Background(), &ResetPasswordInput{Token: resetToken, Password: "new-password"})
+	if !errors.Is(err, usererror.ErrUnauthorized) {
+		t.Errorf("Want password reset of blocked user to be rejected with %v, got %v", usererror.ErrUnauthorized, err)
+	}
+
+	user, _ := c.principalStore.FindUser(context.Background(), 1)
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("new-password")) == nil {
+		t.Errorf("Want password of blocked user to be unchanged")
+	}
+}
+
+// racingTokenStoreFake deletes the token on the first lookup, as if a concurrent request consumed it.
+type racingTokenStoreFake struct {
+	*tokenStoreFake
+}
+
+func (s racingTokenStoreFake) Find(ctx context.Context, id int64) (*types.Token, error) {
+	tkn, err := s.tokenStoreFake.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.tokenStoreFake.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return tkn, nil
+}
+
+func TestResetPassword_ConcurrentUse(t *testing.T) {
+	c, m := setupPasswordResetController(t, true)
+
+	err := c.RequestPasswordReset(context.Background(), &RequestPasswordResetInput{Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("Want password reset request to succeed, got %v", err)
+	}
+	if len(m.sent) != 1 {
+		t.Fatalf("Want 1 password reset mail, got %d", len(m.sent))
+	}
+	resetToken := extractResetToken(t, m.sent[0])
+
+	tokenStore, _ := c.tokenStore.(*tokenStoreFake)
+	c.tokenStore = racingTokenStoreFake{tokenStoreFake: tokenStore}
+
+	err = c.ResetPassword(context.Background(), &ResetPasswordInput{Token: resetToken, Password: "new-password"})
+	if !errors.Is(err, usererror.ErrUnauthorized) {
+		t.Errorf("Want reset token used concurrently to be rejected with %v, got %v", usererror.ErrUnauthorized, err)
+	}
+
+	user, _ := c.principalStore.FindUser(context.Background(), 1)
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("new-password")) == nil {
+		t.Errorf("Want password to be unchanged")
+	}
+	if user.TokenGeneration != 0 {
+		t.Errorf("Want token generation to be unchanged, got %d", user.TokenGeneration)
+	}
+}
+
+func extractResetToken(t *testing.T, payload mailer.Payload) string {
+	t.Helper()
+
+	match := resetTokenRegex.FindStringSubmatch(payload.Body)
+	if match == nil {
+		t.Fatalf("Want reset link in mail body, got %q", payload.Body)
+	}
+	resetToken, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatalf("failed to unescape reset token: %v", err)
+	}
+
+	return resetToken
+}
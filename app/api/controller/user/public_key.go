@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+var errPublicKeyDuplicate = usererror.Conflict("A public key or deploy key with the same fingerprint already exists.")
+
+type CreatePublicKeyInput struct {
+	Content string `json:"content"`
+	Label   string `json:"label"`
+}
+
+/*
+ * CreatePublicKey registers a new ssh public key for a user.
+ */
+func (c *Controller) CreatePublicKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *CreatePublicKeyInput,
+) (*types.PublicKey, error) {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return nil, err
+	}
+
+	key, err := sanitizeCreatePublicKeyInput(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	_, err = c.publicKeyStore.FindByFingerprint(ctx, fingerprint)
+	if err == nil {
+		return nil, errPublicKeyDuplicate
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find public key by fingerprint: %w", err)
+	}
+
+	// a key authenticates either a user or a repository, never both.
+	_, err = c.deployKeyStore.FindByFingerprint(ctx, fingerprint)
+	if err == nil {
+		return nil, errPublicKeyDuplicate
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find deploy key by fingerprint: %w", err)
+	}
+
+	publicKey := &types.PublicKey{
+		PrincipalID: user.ID,
+		Fingerprint: fingerprint,
+		Content:     strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+		Label:       in.Label,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	err = c.publicKeyStore.Create(ctx, publicKey)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, errPublicKeyDuplicate
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store public key: %w", err)
+	}
+
+	return publicKey, nil
+}
+
+/*
+ * ListPublicKeys lists all ssh public keys of a user.
+ */
+func (c *Controller) ListPublicKeys(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) ([]*types.PublicKey, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.publicKeyStore.List(ctx, user.ID)
+}
+
+/*
+ * DeletePublicKey deletes an ssh public key of a user.
+ */
+func (c *Controller) DeletePublicKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	id int64,
+) error {
+	if err := apiauth.CheckNotImpersonated(session); err != nil {
+		return err
+	}
+
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	publicKey, err := c.publicKeyStore.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Ensure public key belongs to user.
+	if publicKey.PrincipalID != user.ID {
+		log.Ctx(ctx).Warn().Msg("Principal tried to delete public key that doesn't belong to the user")
+
+		// throw a not found error - no need for user to know about the key.
+		return usererror.ErrNotFound
+	}
+
+	return c.publicKeyStore.Delete(ctx, publicKey.ID)
+}
+
+// sanitizeCreatePublicKeyInput validates the input and returns the parsed public key.
+// If no label is provided, the comment of the key is used instead.
+func sanitizeCreatePublicKeyInput(in *CreatePublicKeyInput) (ssh.PublicKey, error) {
+	in.Content = strings.TrimSpace(in.Content)
+	if err := check.PublicKey(in.Content); err != nil {
+		return nil, err
+	}
+
+	key, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(in.Content))
+	if err != nil {
+		return nil, check.ErrPublicKeyInvalid
+	}
+
+	in.Label = strings.TrimSpace(in.Label)
+	if in.Label == "" {
+		in.Label = strings.TrimSpace(comment)
+	}
+	if err = check.Description(in.Label); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func setupPublicKeyController(t *testing.T) (*Controller, *auth.Session) {
+	t.Helper()
+
+	c := setupLoginController(t)
+	c.authorizer = &fake.Authorizer{}
+	c.publicKeyStore = &publicKeyStoreFake{}
+	c.deployKeyStore = &deployKeyStoreFake{}
+
+	principal, err := c.principalStore.FindByUID(context.Background(), "user")
+	if err != nil {
+		t.Fatalf("failed to find user: %v", err)
+	}
+
+	return c, &auth.Session{Principal: *principal}
+}
+
+func generatePublicKey(t *testing.T) string {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to convert key: %v", err)
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub))) + " user@laptop"
+}
+
+func TestCreatePublicKey(t *testing.T) {
+	c, session := setupPublicKeyController(t)
+	content := generatePublicKey(t)
+
+	key, err := c.CreatePublicKey(context.Background(), session, "user", &CreatePublicKeyInput{Content: content})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if !strings.HasPrefix(key.Fingerprint, "SHA256:") {
+		t.Errorf("Want SHA256 fingerprint, got %q", key.Fingerprint)
+	}
+	if key.Label != "user@laptop" {
+		t.Errorf("Want label from key comment %q, got %q", "user@laptop", key.Label)
+	}
+	if key.PrincipalID != session.Principal.ID {
+		t.Errorf("Want principal %d, got %d", session.Principal.ID, key.PrincipalID)
+	}
+
+	keys, err := c.ListPublicKeys(context.Background(), session, "user")
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != key.ID {
+		t.Errorf("Want listed key %d, got %v", key.ID, keys)
+	}
+}
+
+func TestCreatePublicKey_Duplicate(t *testing.T) {
+	c, session := setupPublicKeyController(t)
+	content := generatePublicKey(t)
+
+	_, err := c.CreatePublicKey(context.Background(), session, "user", &CreatePublicKeyInput{Content: content})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	// same key with a different label has the same fingerprint
+	_, err = c.CreatePublicKey(context.Background(), session, "user",
+		&CreatePublicKeyInput{Content: content, Label: "other"})
+	if !errors.Is(err, errPublicKeyDuplicate) {
+		t.Errorf("Want error %v, got %v", errPublicKeyDuplicate, err)
+	}
+}
+
+func TestCreatePublicKey_UsedAsDeployKey(t *testing.T) {
+	c, session := setupPublicKeyController(t)
+	content := generatePublicKey(t)
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(content))
+	if err != nil {
+		t.Fatalf("failed to parse key: %v", err)
+	}
+	c.deployKeyStore = &deployKeyStoreFake{keys: []*types.DeployKey{
+		{ID: 1, RepoID: 1, Fingerprint: ssh.FingerprintSHA256(key)},
+	}}
+
+	_, err = c.CreatePublicKey(context.Background(), session, "user", &CreatePublicKeyInput{Content: content})
+	if !errors.Is(err, errPublicKeyDuplicate) {
+		t.Errorf("Want error %v, got %v", errPublicKeyDuplicate, err)
+	}
+}
+
+func TestCreatePublicKey_Malformed(t *testing.T) {
+	c, session := setupPublicKeyController(t)
+	valid := generatePublicKey(t)
+
+	tests := map[string]string{
+		"empty":        "",
+		"garbage":      "not a key",
+		"options":      `command="echo hi" ` + valid,
+		"multiple":     valid + "\n" + generatePublicKey(t),
+		"broken key":   "ssh-ed25519 AAAAinvalid",
+		"missing data": "ssh-ed25519",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.CreatePublicKey(context.Background(), session, "user",
+				&CreatePublicKeyInput{Content: content})
+
+			var validationErr *check.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("Want validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDeletePublicKey(t *testing.T) {
+	c, session := setupPublicKeyController(t)
+
+	key, err := c.CreatePublicKey(context.Background(), session, "user",
+		&CreatePublicKeyInput{Content: generatePublicKey(t)})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if err = c.DeletePublicKey(context.Background(), session, "user", key.ID); err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	keys, _ := c.ListPublicKeys(context.Background(), session, "user")
+	if len(keys) != 0 {
+		t.Errorf("Want no keys after delete, got %d", len(keys))
+	}
+
+	// the fingerprint can be registered again after the key got deleted.
+	_, err = c.CreatePublicKey(context.Background(), session, "user", &CreatePublicKeyInput{Content: key.Content})
+	if err != nil {
+		t.Errorf("Want re-adding deleted key to succeed, got %v", err)
+	}
+}
+
+func TestDeletePublicKey_OtherUser(t *testing.T) {
+	c, session := setupPublicKeyController(t)
+
+	sa, _ := c.principalStore.FindByUID(context.Background(), "sa")
+	saKey := &types.PublicKey{PrincipalID: sa.ID, Fingerprint: "SHA256:sa", Content: generatePublicKey(t)}
+	_ = c.publicKeyStore.Create(context.Background(), saKey)
+
+	err := c.DeletePublicKey(context.Background(), session, "user", saKey.ID)
+	if !errors.Is(err, usererror.ErrNotFound) {
+		t.Errorf("Want error %v, got %v", usererror.ErrNotFound, err)
+	}
+
+	if _, err = c.publicKeyStore.Find(context.Background(), saKey.ID); err != nil {
+		t.Errorf("Want key of other user to still exist, got %v", err)
+	}
+}
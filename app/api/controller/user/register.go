@@ -20,7 +20,6 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/usererror"
-	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 )
 
@@ -54,11 +53,17 @@ func (c *Controller) Register(ctx context.Context, sysCtrl *system.Controller,
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	firstLogin, err := c.handleFirstLogin(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: how should we name session tokens?
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, "register")
+	token, jwtToken, err := c.tokenIssuer.CreateUserSession(ctx, user, "register",
+		c.config.Token.Expire)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create token after successful user creation: %w", err)
 	}
 
-	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken, FirstLogin: firstLogin}, nil
 }
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+func TestRegister_SignupDisabled(t *testing.T) {
+	ctrl, principalStore := setupCreateController(t)
+	ctrl.config.UserSignupEnabled = false
+	sysCtrl := system.NewController(principalStore, ctrl.config, nil, nil, nil, nil, nil)
+
+	_, err := ctrl.Register(context.Background(), sysCtrl, &RegisterInput{
+		UID:         "new-user",
+		Email:       "new-user@example.com",
+		DisplayName: "New User",
+		Password:    "password",
+	})
+
+	var userErr *usererror.Error
+	if !errors.As(err, &userErr) || userErr.Status != http.StatusForbidden {
+		t.Fatalf("Want forbidden error, got %v", err)
+	}
+
+	if principalStore.createUserCalls != 0 {
+		t.Errorf("Want no user to be created, got %d create calls", principalStore.createUserCalls)
+	}
+}
+
+func TestRegister_SignupToggledAtRuntime(t *testing.T) {
+	ctrl, principalStore := setupCreateController(t)
+	ctrl.config.UserSignupEnabled = false
+	sysCtrl := system.NewController(principalStore, ctrl.config, nil, nil, nil, nil, nil)
+
+	// the flag is read on every request, so changing the config takes effect without restart.
+	ctrl.config.UserSignupEnabled = true
+
+	resp, err := ctrl.Register(context.Background(), sysCtrl, &RegisterInput{
+		UID:         "new-user",
+		Email:       "new-user@example.com",
+		DisplayName: "New User",
+		Password:    "password",
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if resp.AccessToken == "" {
+		t.Errorf("Want access token to be returned")
+	}
+}
+
+func TestCreate_SignupDisabled(t *testing.T) {
+	ctrl, principalStore := setupCreateController(t)
+	ctrl.config.UserSignupEnabled = false
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+	usr, err := ctrl.Create(context.Background(), session, &CreateInput{
+		UID:         "new-user",
+		Email:       "new-user@example.com",
+		DisplayName: "New User",
+		Password:    "password",
+	}, false, types.IdempotencyRequest{})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if usr.UID != "new-user" {
+		t.Errorf("Want user 'new-user' to be created, got '%s'", usr.UID)
+	}
+
+	if principalStore.createUserCalls != 1 {
+		t.Errorf("Want 1 create call, got %d", principalStore.createUserCalls)
+	}
+}
@@ -0,0 +1,640 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// principalStoreFake is an in-memory principal store that supports the operations used by the controller.
+type principalStoreFake struct {
+	store.PrincipalStore
+
+	principals    []*types.Principal
+	passwords     map[int64]string
+	emailVerified map[int64]bool
+	onboarded     map[int64]bool
+	avatars       map[int64]string
+	failedLogins  map[int64]int
+	lockedUntil   map[int64]int64
+	authSources   map[int64]string
+	externalIDs   map[int64]string
+	versions      map[int64]int64
+
+	createUserCalls int
+	// updateUserErr is returned by UpdateUser if set.
+	updateUserErr error
+
+	// beforeConditionalUpdate is called before a conditional update is applied (to simulate concurrent updates).
+	beforeConditionalUpdate func()
+}
+
+func (s *principalStoreFake) addPrincipal(p *types.Principal, password string) {
+	if s.passwords == nil {
+		s.passwords = map[int64]string{}
+	}
+	p.ID = int64(len(s.principals) + 1)
+	s.principals = append(s.principals, p)
+	s.passwords[p.ID] = password
+}
+
+func (s *principalStoreFake) Find(_ context.Context, id int64) (*types.Principal, error) {
+	for _, p := range s.principals {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *principalStoreFake) FindByUID(_ context.Context, uid string) (*types.Principal, error) {
+	for _, p := range s.principals {
+		if strings.EqualFold(p.UID, uid) {
+			return p, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *principalStoreFake) FindByEmail(_ context.Context, email string) (*types.Principal, error) {
+	for _, p := range s.principals {
+		if strings.EqualFold(p.Email, email) {
+			return p, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *principalStoreFake) FindPassword(ctx context.Context, id int64) (string, error) {
+	if _, err := s.Find(ctx, id); err != nil {
+		return "", err
+	}
+	return s.passwords[id], nil
+}
+
+func (s *principalStoreFake) FindUser(ctx context.Context, id int64) (*types.User, error) {
+	p, err := s.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Type != enum.PrincipalTypeUser {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	return &types.User{
+		ID:            p.ID,
+		UID:           p.UID,
+		Email:         p.Email,
+		DisplayName:   p.DisplayName,
+		Admin:         p.Admin,
+		Blocked:       p.Blocked,
+		Salt:          p.Salt,
+		Password:      s.passwords[p.ID],
+		EmailVerified: s.emailVerified[p.ID],
+		Onboarded:     s.onboarded[p.ID],
+		Avatar:        s.avatars[p.ID],
+		FailedLogins:  s.failedLogins[p.ID],
+		LockedUntil:   s.lockedUntil[p.ID],
+		AuthSource:    s.authSources[p.ID],
+		ExternalID:    s.externalIDs[p.ID],
+		Updated:       p.Updated,
+		Version:       s.versions[p.ID],
+
+		TokenGeneration: p.TokenGeneration,
+	}, nil
+}
+
+func (s *principalStoreFake) IncrementFailedLogins(ctx context.Context, id int64) (int, error) {
+	if _, err := s.Find(ctx, id); err != nil {
+		return 0, err
+	}
+	if s.failedLogins == nil {
+		s.failedLogins = map[int64]int{}
+	}
+	s.failedLogins[id]++
+	return s.failedLogins[id], nil
+}
+
+func (s *principalStoreFake) UpdateLockedUntil(ctx context.Context, id int64, lockedUntil int64) error {
+	if _, err := s.Find(ctx, id); err != nil {
+		return err
+	}
+	if s.lockedUntil == nil {
+		s.lockedUntil = map[int64]int64{}
+	}
+	delete(s.failedLogins, id)
+	s.lockedUntil[id] = lockedUntil
+	return nil
+}
+
+func (s *principalStoreFake) FindUserByEmail(ctx context.Context, email string) (*types.User, error) {
+	p, err := s.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return s.FindUser(ctx, p.ID)
+}
+
+func (s *principalStoreFake) FindUserByUID(ctx context.Context, uid string) (*types.User, error) {
+	p, err := s.FindByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return s.FindUser(ctx, p.ID)
+}
+
+func (s *principalStoreFake) FindUserByExternalID(
+	ctx context.Context,
+	authSource string,
+	externalID string,
+) (*types.User, error) {
+	for id, source := range s.authSources {
+		if source == authSource && externalID != "" && s.externalIDs[id] == externalID {
+			return s.FindUser(ctx, id)
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *principalStoreFake) CreateUser(_ context.Context, user *types.User) error {
+	s.createUserCalls++
+	p := &types.Principal{
+		UID:         user.UID,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Type:        enum.PrincipalTypeUser,
+		Admin:       user.Admin,
+		Salt:        user.Salt,
+	}
+	s.addPrincipal(p, user.Password)
+	user.ID = p.ID
+	s.setUserFields(user)
+	return nil
+}
+
+func (s *principalStoreFake) CountUsers(context.Context, *types.UserFilter) (int64, error) {
+	var count int64
+	for _, p := range s.principals {
+		if p.Type == enum.PrincipalTypeUser {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *principalStoreFake) UpdateUser(ctx context.Context, user *types.User) error {
+	if s.updateUserErr != nil {
+		return s.updateUserErr
+	}
+	p, err := s.Find(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	p.Email = user.Email
+	p.DisplayName = user.DisplayName
+	p.Updated = user.Updated
+	s.passwords[user.ID] = user.Password
+	s.setUserFields(user)
+
+	if s.versions == nil {
+		s.versions = map[int64]int64{}
+	}
+	s.versions[user.ID]++
+	user.Version = s.versions[user.ID]
+	return nil
+}
+
+// setUserFields stores the user specific fields of the user.
+func (s *principalStoreFake) setUserFields(user *types.User) {
+	if s.emailVerified == nil {
+		s.emailVerified = map[int64]bool{}
+	}
+	if s.onboarded == nil {
+		s.onboarded = map[int64]bool{}
+	}
+	if s.avatars == nil {
+		s.avatars = map[int64]string{}
+	}
+	if s.authSources == nil {
+		s.authSources = map[int64]string{}
+		s.externalIDs = map[int64]string{}
+	}
+	s.emailVerified[user.ID] = user.EmailVerified
+	s.onboarded[user.ID] = user.Onboarded
+	s.avatars[user.ID] = user.Avatar
+	s.authSources[user.ID] = user.AuthSource
+	s.externalIDs[user.ID] = user.ExternalID
+}
+
+func (s *principalStoreFake) UpdateUserIfUnmodified(ctx context.Context, user *types.User, version int64) error {
+	if s.beforeConditionalUpdate != nil {
+		fn := s.beforeConditionalUpdate
+		s.beforeConditionalUpdate = nil
+		fn()
+	}
+
+	if _, err := s.Find(ctx, user.ID); err != nil {
+		return err
+	}
+	if s.versions[user.ID] != version {
+		return gitness_store.ErrVersionConflict
+	}
+
+	return s.UpdateUser(ctx, user)
+}
+
+func (s *principalStoreFake) UpdatePassword(ctx context.Context, id int64, currentHash string, hash string) error {
+	if _, err := s.Find(ctx, id); err != nil {
+		return err
+	}
+	if s.passwords[id] != currentHash {
+		return gitness_store.ErrVersionConflict
+	}
+	s.passwords[id] = hash
+	return nil
+}
+
+// tokenStoreFake is an in-memory token store that supports the operations used by the controller.
+type tokenStoreFake struct {
+	store.TokenStore
+
+	tokens []*types.Token
+	lastID int64
+}
+
+func (s *tokenStoreFake) Create(_ context.Context, token *types.Token) error {
+	s.lastID++
+	token.ID = s.lastID
+	s.tokens = append(s.tokens, token)
+	return nil
+}
+
+func (s *tokenStoreFake) Find(_ context.Context, id int64) (*types.Token, error) {
+	for _, t := range s.tokens {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *tokenStoreFake) Delete(_ context.Context, id int64) error {
+	for i, t := range s.tokens {
+		if t.ID == id {
+			s.tokens = append(s.tokens[:i], s.tokens[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *tokenStoreFake) List(
+	_ context.Context,
+	principalID int64,
+	tokenType enum.TokenType,
+) ([]*types.Token, error) {
+	var tokens []*types.Token
+	for _, t := range s.tokens {
+		if t.PrincipalID == principalID && t.Type == tokenType {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens, nil
+}
+
+func (s *tokenStoreFake) ListActive(
+	_ context.Context,
+	principalID int64,
+	generation int64,
+	now time.Time,
+) ([]*types.Token, error) {
+	var tokens []*types.Token
+	for _, t := range s.tokens {
+		if t.PrincipalID != principalID || t.Generation < generation || t.Type == enum.TokenTypePasswordReset {
+			continue
+		}
+		if t.ExpiresAt != nil && *t.ExpiresAt < now.UnixMilli() {
+			continue
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, nil
+}
+
+// publicKeyStoreFake is an in-memory public key store.
+type publicKeyStoreFake struct {
+	store.PublicKeyStore
+
+	keys   []*types.PublicKey
+	lastID int64
+}
+
+func (s *publicKeyStoreFake) Find(_ context.Context, id int64) (*types.PublicKey, error) {
+	for _, k := range s.keys {
+		if k.ID == id {
+			return k, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *publicKeyStoreFake) FindByFingerprint(_ context.Context, fingerprint string) (*types.PublicKey, error) {
+	for _, k := range s.keys {
+		if k.Fingerprint == fingerprint {
+			return k, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *publicKeyStoreFake) Create(_ context.Context, key *types.PublicKey) error {
+	s.lastID++
+	key.ID = s.lastID
+	s.keys = append(s.keys, key)
+	return nil
+}
+
+func (s *publicKeyStoreFake) Delete(_ context.Context, id int64) error {
+	for i, k := range s.keys {
+		if k.ID == id {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *publicKeyStoreFake) List(_ context.Context, principalID int64) ([]*types.PublicKey, error) {
+	var keys []*types.PublicKey
+	for _, k := range s.keys {
+		if k.PrincipalID == principalID {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// userEmailStoreFake is an in-memory user email store.
+type userEmailStoreFake struct {
+	store.UserEmailStore
+
+	emails []*types.UserEmail
+	lastID int64
+}
+
+func (s *userEmailStoreFake) Find(_ context.Context, id int64) (*types.UserEmail, error) {
+	for _, e := range s.emails {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *userEmailStoreFake) FindByEmail(_ context.Context, email string) (*types.UserEmail, error) {
+	for _, e := range s.emails {
+		if strings.EqualFold(e.Email, email) {
+			return e, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *userEmailStoreFake) Create(ctx context.Context, email *types.UserEmail) error {
+	if _, err := s.FindByEmail(ctx, email.Email); err == nil {
+		return gitness_store.ErrDuplicate
+	}
+	s.lastID++
+	email.ID = s.lastID
+	s.emails = append(s.emails, email)
+	return nil
+}
+
+func (s *userEmailStoreFake) Update(context.Context, *types.UserEmail) error {
+	// emails are stored by reference.
+	return nil
+}
+
+func (s *userEmailStoreFake) Delete(_ context.Context, id int64) error {
+	for i, e := range s.emails {
+		if e.ID == id {
+			s.emails = append(s.emails[:i], s.emails[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+func (s *userEmailStoreFake) List(_ context.Context, principalID int64) ([]*types.UserEmail, error) {
+	var emails []*types.UserEmail
+	for _, e := range s.emails {
+		if e.PrincipalID == principalID {
+			emails = append(emails, e)
+		}
+	}
+	return emails, nil
+}
+
+// deployKeyStoreFake is an in-memory deploy key store.
+type deployKeyStoreFake struct {
+	store.DeployKeyStore
+
+	keys []*types.DeployKey
+}
+
+func (s *deployKeyStoreFake) FindByFingerprint(_ context.Context, fingerprint string) (*types.DeployKey, error) {
+	for _, k := range s.keys {
+		if k.Fingerprint == fingerprint {
+			return k, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// spaceStoreFake is an in-memory space store.
+type spaceStoreFake struct {
+	store.SpaceStore
+
+	spaces []*types.Space
+}
+
+func (s *spaceStoreFake) Find(_ context.Context, id int64) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.ID == id {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *spaceStoreFake) FindByRef(_ context.Context, spaceRef string) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.Path == spaceRef {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// membershipStoreFake is an in-memory membership store.
+type membershipStoreFake struct {
+	store.MembershipStore
+
+	memberships []*types.Membership
+}
+
+func (s *membershipStoreFake) Find(_ context.Context, key types.MembershipKey) (*types.Membership, error) {
+	for _, m := range s.memberships {
+		if m.MembershipKey == key {
+			return m, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// txFake runs the transaction function without a database.
+type txFake struct {
+	dbtx.Transactor
+}
+
+func (txFake) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+// idempotencyKeyStoreFake is an in-memory idempotency key store.
+type idempotencyKeyStoreFake struct {
+	store.IdempotencyKeyStore
+	keys []*types.IdempotencyKey
+}
+
+func (s *idempotencyKeyStoreFake) Find(
+	_ context.Context,
+	principalID int64,
+	resourceType enum.ResourceType,
+	key string,
+) (*types.IdempotencyKey, error) {
+	for _, k := range s.keys {
+		if k.PrincipalID == principalID && k.ResourceType == resourceType && k.Key == key {
+			return k, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *idempotencyKeyStoreFake) Create(_ context.Context, key *types.IdempotencyKey) error {
+	if _, err := s.Find(context.Background(), key.PrincipalID, key.ResourceType, key.Key); err == nil {
+		return gitness_store.ErrDuplicate
+	}
+	key.ID = int64(len(s.keys) + 1)
+	s.keys = append(s.keys, key)
+	return nil
+}
+
+func (s *idempotencyKeyStoreFake) Delete(_ context.Context, id int64) error {
+	for i, k := range s.keys {
+		if k.ID == id {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+// blobStoreFake is an in-memory blob store without signed url support.
+type blobStoreFake struct {
+	files map[string][]byte
+}
+
+func (s *blobStoreFake) Upload(_ context.Context, file io.Reader, filePath string) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	if s.files == nil {
+		s.files = map[string][]byte{}
+	}
+	s.files[filePath] = data
+	return nil
+}
+
+func (s *blobStoreFake) GetSignedURL(context.Context, string) (string, error) {
+	return "", blob.ErrNotSupported
+}
+
+func (s *blobStoreFake) Download(_ context.Context, filePath string) (io.ReadCloser, error) {
+	data, ok := s.files[filePath]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *blobStoreFake) Stat(_ context.Context, filePath string) (*blob.FileInfo, error) {
+	data, ok := s.files[filePath]
+	if !ok {
+		return nil, blob.ErrNotFound
+	}
+	return &blob.FileInfo{Size: int64(len(data))}, nil
+}
+
+func (s *blobStoreFake) Delete(_ context.Context, filePath string) error {
+	if _, ok := s.files[filePath]; !ok {
+		return blob.ErrNotFound
+	}
+	delete(s.files, filePath)
+	return nil
+}
+
+// auditServiceFake records the logged audit events.
+type auditServiceFake struct {
+	events []audit.Event
+	err    error
+}
+
+func (s *auditServiceFake) Log(
+	_ context.Context,
+	user types.Principal,
+	resource audit.Resource,
+	action audit.Action,
+	spacePath string,
+	_ ...audit.Option,
+) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, audit.Event{User: user, Resource: resource, Action: action, SpacePath: spacePath})
+	return nil
+}
+
+func (s *principalStoreFake) IncrementTokenGeneration(ctx context.Context, id int64) (int64, error) {
+	p, err := s.Find(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	p.TokenGeneration++
+	return p.TokenGeneration, nil
+}
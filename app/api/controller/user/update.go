@@ -21,12 +21,12 @@ import (
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 // UpdateInput store infos to update an existing user.
@@ -34,11 +34,23 @@ type UpdateInput struct {
 	Email       *string `json:"email"`
 	Password    *string `json:"password"`
 	DisplayName *string `json:"display_name"`
+
+	// EmailVerified can only be updated by admins.
+	EmailVerified *bool `json:"email_verified"`
+
+	// IfMatch contains the entity tags of the If-Match header (if provided).
+	IfMatch []string `json:"-"`
 }
 
 // Update updates the provided user.
 func (c *Controller) Update(ctx context.Context, session *auth.Session,
 	userUID string, in *UpdateInput) (*types.User, error) {
+	if in.Password != nil || in.Email != nil {
+		if err := apiauth.CheckNotImpersonated(session); err != nil {
+			return nil, err
+		}
+	}
+
 	user, err := findUserFromUID(ctx, c.principalStore, userUID)
 	if err != nil {
 		return nil, err
@@ -49,28 +61,48 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	if err = c.checkIfMatch(user, in.IfMatch); err != nil {
+		return nil, err
+	}
+	version := user.Version
+
 	if err = c.sanitizeUpdateInput(in); err != nil {
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
+	if in.EmailVerified != nil && !session.Principal.Admin {
+		return nil, usererror.Forbidden("Only admins can update the email verification status.")
+	}
+
 	if in.DisplayName != nil {
 		user.DisplayName = *in.DisplayName
 	}
-	if in.Email != nil {
+	if in.Email != nil && *in.Email != user.Email {
+		if err = controller.CheckPrincipalEmailUnique(ctx, c.principalStore, *in.Email, user.ID); err != nil {
+			return nil, err
+		}
+		if err = c.checkSecondaryEmailUnique(ctx, *in.Email, 0); err != nil {
+			return nil, err
+		}
+
 		user.Email = *in.Email
+		// a changed email has to be verified again.
+		user.EmailVerified = false
+	}
+	if in.EmailVerified != nil {
+		user.EmailVerified = *in.EmailVerified
 	}
 	if in.Password != nil {
-		var hash []byte
-		hash, err = hashPassword([]byte(*in.Password), bcrypt.DefaultCost)
+		var hash string
+		hash, err = c.passwordHasher.Hash(*in.Password)
 		if err != nil {
 			return nil, fmt.Errorf("failed to hash password: %w", err)
 		}
-		user.Password = string(hash)
+		user.Password = hash
 	}
 	user.Updated = time.Now().UnixMilli()
 
-	err = c.principalStore.UpdateUser(ctx, user)
-	if err != nil {
+	if err = c.updateIfUnmodified(ctx, user, version); err != nil {
 		return nil, err
 	}
 
@@ -79,7 +111,7 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 
 func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 	if in.Email != nil {
-		*in.Email = strings.TrimSpace(*in.Email)
+		*in.Email = strings.ToLower(strings.TrimSpace(*in.Email))
 		if err := check.Email(*in.Email); err != nil {
 			return err
 		}
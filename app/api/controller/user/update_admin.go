@@ -28,6 +28,9 @@ import (
 
 type UpdateAdminInput struct {
 	Admin bool `json:"admin"`
+
+	// IfMatch contains the entity tags of the If-Match header (if provided).
+	IfMatch []string `json:"-"`
 }
 
 // UpdateAdmin updates the admin state of a user.
@@ -43,6 +46,11 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	if err = c.checkIfMatch(user, request.IfMatch); err != nil {
+		return nil, err
+	}
+	version := user.Version
+
 	// Fail if the user being updated is the only admin in DB.
 	if user.Admin && !request.Admin {
 		admUsrCount, err := c.principalStore.CountUsers(ctx, &types.UserFilter{Admin: true})
@@ -58,8 +66,7 @@ func (c *Controller) UpdateAdmin(ctx context.Context, session *auth.Session,
 	user.Admin = request.Admin
 	user.Updated = time.Now().UnixMilli()
 
-	err = c.principalStore.UpdateUser(ctx, user)
-	if err != nil {
+	if err = c.updateIfUnmodified(ctx, user, version); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+func TestUpdate_EmailCaseInsensitive(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		wantStatus int
+		wantEmail  string
+	}{
+		{name: "other principal", email: "SA@example.com", wantStatus: http.StatusConflict, wantEmail: "user@example.com"},
+		{name: "own email", email: "User@Example.com", wantStatus: http.StatusOK, wantEmail: "user@example.com"},
+		{name: "new email", email: "New@Example.com", wantStatus: http.StatusOK, wantEmail: "new@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, principalStore := setupCreateController(t)
+			session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+			email := tt.email
+			_, err := ctrl.Update(context.Background(), session, "user", &UpdateInput{Email: &email})
+
+			status := http.StatusOK
+			if err != nil {
+				status = usererror.Translate(context.Background(), err).Status
+			}
+			if status != tt.wantStatus {
+				t.Errorf("Want status %d, got %d (%v)", tt.wantStatus, status, err)
+			}
+
+			usr, err := principalStore.FindUserByUID(context.Background(), "user")
+			if err != nil {
+				t.Fatalf("Want user to exist, got %v", err)
+			}
+			if usr.Email != tt.wantEmail {
+				t.Errorf("Want email %q, got %q", tt.wantEmail, usr.Email)
+			}
+		})
+	}
+}
+
+func TestUpdate_IfMatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		ifMatch        func(usr *types.User) []string
+		requireIfMatch bool
+		wantStatus     int
+	}{
+		{name: "matching etag", ifMatch: func(usr *types.User) []string { return []string{ETag(usr)} },
+			wantStatus: http.StatusOK},
+		{name: "any etag", ifMatch: func(*types.User) []string { return []string{"*"} },
+			wantStatus: http.StatusOK},
+		{name: "stale etag", ifMatch: func(usr *types.User) []string { return []string{`"1"`, `W/` + ETag(usr)} },
+			wantStatus: http.StatusPreconditionFailed},
+		{name: "no etag", ifMatch: func(*types.User) []string { return nil },
+			wantStatus: http.StatusOK},
+		{name: "no etag but required", ifMatch: func(*types.User) []string { return nil }, requireIfMatch: true,
+			wantStatus: http.StatusPreconditionRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, principalStore := setupCreateController(t)
+			ctrl.config.Principal.RequireIfMatch = tt.requireIfMatch
+			session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+			usr, err := principalStore.FindUserByUID(context.Background(), "user")
+			if err != nil {
+				t.Fatalf("Want user to exist, got %v", err)
+			}
+			ifMatch := tt.ifMatch(usr)
+
+			displayName := "Updated User"
+			_, err = ctrl.Update(context.Background(), session, "user",
+				&UpdateInput{DisplayName: &displayName, IfMatch: ifMatch})
+
+			status := http.StatusOK
+			if err != nil {
+				status = usererror.Translate(context.Background(), err).Status
+			}
+			if status != tt.wantStatus {
+				t.Fatalf("Want status %d, got %d (%v)", tt.wantStatus, status, err)
+			}
+
+			usr, err = principalStore.FindUserByUID(context.Background(), "user")
+			if err != nil {
+				t.Fatalf("Want user to exist, got %v", err)
+			}
+			if (usr.DisplayName == displayName) != (status == http.StatusOK) {
+				t.Errorf("Want user to be updated only on success, got display name %q", usr.DisplayName)
+			}
+		})
+	}
+}
+
+func TestUpdate_IfMatchConcurrent(t *testing.T) {
+	ctrl, principalStore := setupCreateController(t)
+	session := &auth.Session{Principal: types.Principal{ID: 1, UID: "user", Admin: true}}
+
+	usr, err := principalStore.FindUserByUID(context.Background(), "user")
+	if err != nil {
+		t.Fatalf("Want user to exist, got %v", err)
+	}
+	etag := ETag(usr)
+
+	// both updates carry the same etag and pass the If-Match check, the other one is stored first.
+	first, second := "First Update", "Second Update"
+	var otherErr error
+	principalStore.beforeConditionalUpdate = func() {
+		_, otherErr = ctrl.Update(context.Background(), session, "user",
+			&UpdateInput{DisplayName: &first, IfMatch: []string{etag}})
+	}
+
+	_, err = ctrl.Update(context.Background(), session, "user",
+		&UpdateInput{DisplayName: &second, IfMatch: []string{etag}})
+	if otherErr != nil {
+		t.Fatalf("Want concurrent update to succeed, got %v", otherErr)
+	}
+	if status := usererror.Translate(context.Background(), err).Status; status != http.StatusPreconditionFailed {
+		t.Errorf("Want status %d, got %d (%v)", http.StatusPreconditionFailed, status, err)
+	}
+
+	usr, err = principalStore.FindUserByUID(context.Background(), "user")
+	if err != nil {
+		t.Fatalf("Want user to exist, got %v", err)
+	}
+	if usr.DisplayName != first {
+		t.Errorf("Want display name %q, got %q", first, usr.DisplayName)
+	}
+}
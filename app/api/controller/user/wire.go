@@ -15,9 +15,17 @@
 package user
 
 import (
+	"github.com/harness/gitness/app/auth/authsource"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/auth/password"
+	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 
 	"github.com/google/wire"
@@ -29,18 +37,46 @@ var WireSet = wire.NewSet(
 )
 
 func ProvideController(
+	config *types.Config,
 	tx dbtx.Transactor,
 	principalUIDCheck check.PrincipalUID,
 	authorizer authz.Authorizer,
+	authSource authsource.Source,
+	passwordHasher password.Hasher,
+	oidcProvider oidc.Provider,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	tokenIssuer *token.Issuer,
+	publicKeyStore store.PublicKeyStore,
+	userEmailStore store.UserEmailStore,
+	deployKeyStore store.DeployKeyStore,
 	membershipStore store.MembershipStore,
+	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	blobStore blob.Store,
+	mailer mailer.Mailer,
+	auditService audit.Service,
 ) *Controller {
 	return NewController(
+		config,
 		tx,
 		principalUIDCheck,
 		authorizer,
+		authSource,
+		passwordHasher,
+		oidcProvider,
 		principalStore,
 		tokenStore,
-		membershipStore)
+		tokenIssuer,
+		publicKeyStore,
+		userEmailStore,
+		deployKeyStore,
+		membershipStore,
+		spaceStore,
+		repoStore,
+		idempotencyKeyStore,
+		blobStore,
+		mailer,
+		auditService)
 }
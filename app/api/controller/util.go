@@ -168,3 +168,13 @@ func MapSignature(s *git.Signature) (*types.Signature, error) {
 		When: s.When,
 	}, nil
 }
+
+// BackfillRepoCloneURLs sets the clone URLs of the repository.
+// The SSH clone URL is omitted for anonymous callers, as SSH requires a key of a principal.
+func BackfillRepoCloneURLs(urlProvider url.Provider, session *auth.Session, repo *types.Repository) {
+	repo.GitURL = urlProvider.GenerateGITCloneURL(repo.Path)
+	repo.GitSSHURL = ""
+	if session != nil {
+		repo.GitSSHURL = urlProvider.GenerateGITCloneSSHURL(repo.Path)
+	}
+}
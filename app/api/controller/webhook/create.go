@@ -43,6 +43,8 @@ type CreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	// BranchPattern restricts branch triggers to branches matching the glob (empty => all branches).
+	BranchPattern string `json:"branch_pattern"`
 }
 
 // Create creates a new webhook.
@@ -93,6 +95,7 @@ func (c *Controller) Create(
 		Enabled:               in.Enabled,
 		Insecure:              in.Insecure,
 		Triggers:              deduplicateTriggers(in.Triggers),
+		BranchPattern:         in.BranchPattern,
 		LatestExecutionResult: nil,
 	}
 
@@ -155,7 +158,10 @@ func sanitizeCreateInput(in *CreateInput, allowLoopback bool, allowPrivateNetwor
 	if err := checkSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := checkTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := checkTriggers(in.Triggers); err != nil {
+		return err
+	}
+	if err := check.BranchPattern(in.BranchPattern); err != nil { //nolint:revive
 		return err
 	}
 
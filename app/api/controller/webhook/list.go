@@ -17,12 +17,16 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
+// recentDeliveriesWindow is the duration for which the executions of webhooks are considered recent.
+const recentDeliveriesWindow = 7 * 24 * time.Hour
+
 // List returns the webhooks from the provided repository.
 func (c *Controller) List(
 	ctx context.Context,
@@ -45,5 +49,33 @@ func (c *Controller) List(
 		return nil, 0, fmt.Errorf("failed to list webhooks for repo with id %d: %w", repo.ID, err)
 	}
 
+	if err = c.fillRecentDeliveries(ctx, webhooks); err != nil {
+		return nil, 0, err
+	}
+
 	return webhooks, count, nil
 }
+
+// fillRecentDeliveries sets the statistics of the executions of the last week for the webhooks.
+func (c *Controller) fillRecentDeliveries(ctx context.Context, webhooks []*types.Webhook) error {
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(webhooks))
+	for i, hook := range webhooks {
+		ids[i] = hook.ID
+	}
+
+	stats, err := c.webhookExecutionStore.MapDeliveryStats(ctx, ids, time.Now().Add(-recentDeliveriesWindow))
+	if err != nil {
+		return fmt.Errorf("failed to get webhook delivery stats: %w", err)
+	}
+
+	for _, hook := range webhooks {
+		hookStats := stats[hook.ID]
+		hook.RecentDeliveries = &hookStats
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Ping sends a synthetic ping event to the webhook and returns the outcome of the call.
+// The execution isn't stored and doesn't affect the latest execution result of the webhook.
+func (c *Controller) Ping(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	webhookIdentifier string,
+) (*types.WebhookExecution, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	// get the webhook and ensure it belongs to us
+	webhook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	// errors of the call itself are reported to the user as part of the execution.
+	execution, err := c.webhookService.PingWebhook(ctx, webhook, repo, &session.Principal)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msgf("ping of webhook %d had an error", webhook.ID)
+	}
+
+	return execution, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SecretOutput contains the webhook together with its newly generated secret.
+type SecretOutput struct {
+	Webhook *types.Webhook `json:"webhook"`
+	// Secret is the plain secret of the webhook. It's only returned once and can't be retrieved later.
+	Secret string `json:"secret"`
+}
+
+// RegenerateSecret replaces the secret of an existing webhook with a newly generated one.
+func (c *Controller) RegenerateSecret(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	webhookIdentifier string,
+) (*SecretOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the hook and ensure it belongs to us
+	hook, err := c.getWebhookVerifyOwnership(ctx, repo.ID, webhookIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if hook.Internal {
+		return nil, ErrInternalWebhookOperationNotAllowed
+	}
+
+	hook, secret, err := c.webhookService.RegenerateSecret(ctx, hook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to regenerate webhook secret: %w", err)
+	}
+
+	return &SecretOutput{
+		Webhook: hook,
+		Secret:  secret,
+	}, nil
+}
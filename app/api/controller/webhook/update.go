@@ -36,6 +36,8 @@ type UpdateInput struct {
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+	// BranchPattern restricts branch triggers to branches matching the glob (empty => all branches).
+	BranchPattern *string `json:"branch_pattern"`
 }
 
 // Update updates an existing webhook.
@@ -95,6 +97,9 @@ func (c *Controller) Update(
 	if in.Triggers != nil {
 		hook.Triggers = deduplicateTriggers(in.Triggers)
 	}
+	if in.BranchPattern != nil {
+		hook.BranchPattern = *in.BranchPattern
+	}
 
 	if err = c.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
@@ -139,6 +144,11 @@ func sanitizeUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwor
 			return err
 		}
 	}
+	if in.BranchPattern != nil {
+		if err := check.BranchPattern(*in.BranchPattern); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
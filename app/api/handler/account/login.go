@@ -15,11 +15,11 @@
 package account
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
 )
 
 // HandleLogin returns an http.HandlerFunc that authenticates
@@ -29,9 +29,9 @@ func HandleLogin(userCtrl *user.Controller, cookieName string) http.HandlerFunc
 		ctx := r.Context()
 
 		in := new(user.LoginInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err := request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
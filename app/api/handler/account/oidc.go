@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+)
+
+const (
+	oidcStateCookieName = "gitness_oidc_state"
+	oidcNonceCookieName = "gitness_oidc_nonce"
+
+	// oidcCookieLifetime is the time a user has to complete the login with the identity provider.
+	oidcCookieLifetime = 10 * time.Minute
+)
+
+// HandleOIDCLogin returns an http.HandlerFunc that redirects the user to the identity provider.
+func HandleOIDCLogin(userCtrl *user.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		out, err := userCtrl.OIDCLogin(ctx)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		expires := time.Now().Add(oidcCookieLifetime)
+		http.SetCookie(w, newOIDCCookie(r, oidcStateCookieName, out.State, expires))
+		http.SetCookie(w, newOIDCCookie(r, oidcNonceCookieName, out.Nonce, expires))
+
+		http.Redirect(w, r, out.RedirectURL, http.StatusFound)
+	}
+}
+
+// HandleOIDCCallback returns an http.HandlerFunc that completes the login with the identity provider
+// and redirects the user to the UI. If no token cookie is configured, the token is returned
+// in the response body instead (as for the password login).
+func HandleOIDCCallback(userCtrl *user.Controller, cookieName string, uiURL string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		in := &user.OIDCCallbackInput{
+			Code:  r.URL.Query().Get("code"),
+			State: r.URL.Query().Get("state"),
+		}
+		if cookie, err := r.Cookie(oidcStateCookieName); err == nil {
+			in.ExpectedState = cookie.Value
+		}
+		if cookie, err := r.Cookie(oidcNonceCookieName); err == nil {
+			in.ExpectedNonce = cookie.Value
+		}
+
+		// state and nonce are single use.
+		http.SetCookie(w, newOIDCCookie(r, oidcStateCookieName, "", time.UnixMilli(0)))
+		http.SetCookie(w, newOIDCCookie(r, oidcNonceCookieName, "", time.UnixMilli(0)))
+
+		if errMsg := r.URL.Query().Get("error"); errMsg != "" {
+			render.BadRequestf(ctx, w, "OIDC login failed: %s.", errMsg)
+			return
+		}
+
+		tokenResponse, err := userCtrl.OIDCCallback(ctx, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if cookieName == "" {
+			render.JSON(w, http.StatusOK, tokenResponse)
+			return
+		}
+
+		includeTokenCookie(r, w, tokenResponse, cookieName)
+
+		http.Redirect(w, r, uiURL, http.StatusFound)
+	}
+}
+
+// newOIDCCookie returns a cookie used during the oidc login.
+// The cookie has to be sent with the redirect from the identity provider, hence SameSite=Lax is required.
+func newOIDCCookie(r *http.Request, name string, value string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Expires:  expires,
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+	}
+}
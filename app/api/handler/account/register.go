@@ -15,7 +15,6 @@
 package account
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/system"
@@ -37,9 +36,9 @@ func HandleRegister(userCtrl *user.Controller, sysCtrl *system.Controller, cooki
 		}
 
 		in := new(user.RegisterInput)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
@@ -48,7 +48,6 @@ func HandleCheckList(checkCtrl *check.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, opts.Page, opts.Size, count)
-		render.JSON(w, http.StatusOK, checks)
+		render.PaginatedJSON(r, w, opts.Page, opts.Size, count, checks)
 	}
 }
@@ -15,7 +15,6 @@
 package pullreq
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/check"
@@ -42,9 +41,9 @@ func HandleCheckReport(checkCtrl *check.Controller) http.HandlerFunc {
 		}
 
 		in := new(check.ReportInput)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
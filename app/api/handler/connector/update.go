@@ -15,7 +15,6 @@
 package connector
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/connector"
@@ -30,9 +29,9 @@ func HandleUpdate(connectorCtrl *connector.Controller) http.HandlerFunc {
 		session, _ := request.AuthSessionFrom(ctx)
 
 		in := new(connector.UpdateInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err := request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
@@ -45,7 +45,6 @@ func HandleList(executionCtrl *execution.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, pagination.Page, pagination.Size, int(totalCount))
-		render.JSON(w, http.StatusOK, repos)
+		render.PaginatedJSON(r, w, pagination.Page, pagination.Size, int(totalCount), repos)
 	}
 }
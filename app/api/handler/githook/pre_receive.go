@@ -15,7 +15,6 @@
 package githook
 
 import (
-	"encoding/json"
 	"net/http"
 
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
@@ -35,9 +34,9 @@ func HandlePreReceive(
 		session, _ := request.AuthSessionFrom(ctx)
 
 		in := types.GithookPreReceiveInput{}
-		err := json.NewDecoder(r.Body).Decode(&in)
+		err := request.ReadJSON(r, &in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
@@ -15,7 +15,6 @@
 package pipeline
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/pipeline"
@@ -35,9 +34,9 @@ func HandleCreate(pipelineCtrl *pipeline.Controller) http.HandlerFunc {
 		}
 
 		in := new(pipeline.CreateInput)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
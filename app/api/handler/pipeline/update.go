@@ -15,7 +15,6 @@
 package pipeline
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/pipeline"
@@ -29,9 +28,9 @@ func HandleUpdate(pipelineCtrl *pipeline.Controller) http.HandlerFunc {
 		session, _ := request.AuthSessionFrom(ctx)
 
 		in := new(pipeline.UpdateInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err := request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
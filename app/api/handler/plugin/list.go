@@ -32,7 +32,6 @@ func HandleList(pluginCtrl *plugin.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
-		render.JSON(w, http.StatusOK, ret)
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(totalCount), ret)
 	}
 }
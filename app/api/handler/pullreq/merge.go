@@ -15,9 +15,6 @@
 package pullreq
 
 import (
-	"encoding/json"
-	"errors"
-	"io"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/pullreq"
@@ -38,9 +35,9 @@ func HandleMerge(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
 		}
 
 		in := new(pullreq.MergeInput)
-		err = json.NewDecoder(r.Body).Decode(in)
-		if err != nil && !errors.Is(err, io.EOF) { // allow empty body
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+		err = request.ReadOptionalJSON(r, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
@@ -51,7 +51,6 @@ func HandleList(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(total))
-		render.JSON(w, http.StatusOK, list)
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(total), list)
 	}
 }
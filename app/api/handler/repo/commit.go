@@ -15,7 +15,6 @@
 package repo
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/repo"
@@ -35,9 +34,9 @@ func HandleCommitFiles(repoCtrl *repo.Controller) http.HandlerFunc {
 		}
 
 		in := new(repo.CommitFilesOptions)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 		response, violations, err := repoCtrl.CommitFiles(ctx, session, repoRef, in)
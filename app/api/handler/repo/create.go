@@ -15,7 +15,6 @@
 package repo
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/repo"
@@ -29,14 +28,20 @@ func HandleCreate(repoCtrl *repo.Controller) http.HandlerFunc {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
+		idempotency, err := request.GetIdempotencyRequest(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		in := new(repo.CreateInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err = request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
-		repo, err := repoCtrl.Create(ctx, session, in)
+		repo, err := repoCtrl.Create(ctx, session, in, idempotency)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -46,9 +46,16 @@ func HandleDeleteBranch(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		violations, err := repoCtrl.DeleteBranch(ctx, session, repoRef, branchName, bypassRules)
+		requireMerged, err := request.ParseRequireMergedFromQuery(r)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		violations, err := repoCtrl.DeleteBranch(ctx, session, repoRef, branchName, bypassRules, requireMerged)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
 		}
 		if violations != nil {
 			render.Violations(w, violations)
@@ -41,9 +41,15 @@ func HandleListBranches(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
+		includeDivergence, err := request.GetIncludeDivergenceFromQueryOrDefault(r, false)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		filter := request.ParseBranchFilter(r)
 
-		branches, err := repoCtrl.ListBranches(ctx, session, repoRef, includeCommit, filter)
+		branches, err := repoCtrl.ListBranches(ctx, session, repoRef, includeCommit, includeDivergence, filter)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -40,7 +40,6 @@ func HandleListPipelines(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
-		render.JSON(w, http.StatusOK, repos)
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(totalCount), repos)
 	}
 }
@@ -41,7 +41,7 @@ func HandleRaw(repoCtrl *repo.Controller) http.HandlerFunc {
 		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
 		path := request.GetOptionalRemainderFromPath(r)
 
-		dataReader, dataLength, err := repoCtrl.Raw(ctx, session, repoRef, gitRef, path)
+		dataReader, dataLength, contentType, err := repoCtrl.Raw(ctx, session, repoRef, gitRef, path)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
@@ -54,6 +54,10 @@ func HandleRaw(repoCtrl *repo.Controller) http.HandlerFunc {
 		}()
 
 		w.Header().Add("Content-Length", fmt.Sprint(dataLength))
+		w.Header().Set("Content-Type", contentType)
+		// never let browsers sniff, render or run the content with the privileges of the api origin.
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Security-Policy", "sandbox")
 
 		render.Reader(ctx, w, http.StatusOK, dataReader)
 	}
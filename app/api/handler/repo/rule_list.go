@@ -42,7 +42,6 @@ func HandleRuleList(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(rulesCount))
-		render.JSON(w, http.StatusOK, rules)
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(rulesCount), rules)
 	}
 }
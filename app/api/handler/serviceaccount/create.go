@@ -15,7 +15,6 @@
 package serviceaccount
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -31,19 +30,30 @@ func HandleCreate(saCtrl *serviceaccount.Controller) http.HandlerFunc {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
+		dryRun, err := request.ParseDryRunFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		in := new(serviceaccount.CreateInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err = request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
-		sa, err := saCtrl.Create(ctx, session, in)
+		sa, err := saCtrl.Create(ctx, session, in, dryRun)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
+		if dryRun {
+			render.JSON(w, http.StatusOK, sa)
+			return
+		}
+
 		render.JSON(w, http.StatusCreated, sa)
 	}
 }
@@ -15,7 +15,6 @@
 package serviceaccount
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -36,9 +35,9 @@ func HandleCreateToken(saCrl *serviceaccount.Controller) http.HandlerFunc {
 		}
 
 		in := new(serviceaccount.CreateTokenInput)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
@@ -50,7 +50,6 @@ func HandleListSpaces(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, spaceFilter.Page, spaceFilter.Size, int(totalCount))
-		render.JSON(w, http.StatusOK, spaces)
+		render.PaginatedJSON(r, w, spaceFilter.Page, spaceFilter.Size, int(totalCount), spaces)
 	}
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -51,7 +52,24 @@ func HandleListRepos(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(count))
-		render.JSON(w, http.StatusOK, repos)
+		if filter.Cursor != nil {
+			render.PaginationCursor(r, w, filter.Size, nextCursor(repos, filter.Size))
+			render.PaginationLimit(r, w, int(count))
+			render.JSON(w, http.StatusOK, repos)
+			return
+		}
+
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(count), repos)
+	}
+}
+
+// nextCursor returns the encoded cursor of the next page, or an empty string in case of the last page.
+func nextCursor(repos []*types.Repository, size int) string {
+	if len(repos) == 0 || len(repos) < size {
+		return ""
 	}
+
+	last := repos[len(repos)-1]
+
+	return types.Cursor{Created: last.Created, ID: last.ID}.Encode()
 }
@@ -46,7 +46,6 @@ func HandleListSecrets(spaceCtrl *space.Controller) http.HandlerFunc {
 			secrets = append(secrets, *s.CopyWithoutData())
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
-		render.JSON(w, http.StatusOK, secrets)
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(totalCount), secrets)
 	}
 }
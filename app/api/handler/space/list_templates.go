@@ -39,7 +39,6 @@ func HandleListTemplates(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
-		render.JSON(w, http.StatusOK, ret)
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(totalCount), ret)
 	}
 }
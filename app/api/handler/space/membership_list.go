@@ -34,7 +34,11 @@ func HandleMembershipList(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		filter := request.ParseMembershipUserFilter(r)
+		filter, err := request.ParseMembershipUserFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 
 		memberships, membershipsCount, err := spaceCtrl.MembershipList(ctx, session, spaceRef, filter)
 		if err != nil {
@@ -42,7 +46,6 @@ func HandleMembershipList(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(membershipsCount))
-		render.JSON(w, http.StatusOK, memberships)
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(membershipsCount), memberships)
 	}
 }
@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/services/featureflag"
+)
+
+// HandleListFeatureFlags returns an http.HandlerFunc that writes the state of all feature flags
+// for the principal of the request, allowing clients to hide features that aren't available.
+func HandleListFeatureFlags(flags *featureflag.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session, _ := request.AuthSessionFrom(r.Context())
+
+		render.JSON(w, http.StatusOK, flags.List(session))
+	}
+}
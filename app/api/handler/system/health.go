@@ -14,10 +14,21 @@
 
 package system
 
-import "net/http"
+import (
+	"net/http"
 
-// HandleHealth writes a 200 OK status to the http.Response
-// if the server is healthy.
-func HandleHealth(w http.ResponseWriter, _ *http.Request) {
-	w.WriteHeader(http.StatusOK)
+	gitnesshttp "github.com/harness/gitness/http"
+)
+
+// HandleHealth returns an http.HandlerFunc that writes a 200 OK status to the http.Response
+// if the server is healthy, and 503 Service Unavailable once the server started shutting down.
+func HandleHealth(readiness *gitnesshttp.Readiness) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if !readiness.Ready() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
 }
@@ -0,0 +1,30 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/render"
+)
+
+// HandleJWKS writes the public keys used for verifying issued tokens
+// as json web key set to the http.Response body.
+func HandleJWKS(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		render.JSON(w, http.StatusOK, sysCtrl.JWKS())
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/types"
+)
+
+// HandleGetMaintenance returns an http.HandlerFunc that writes the state of the maintenance mode.
+func HandleGetMaintenance(maintenanceSvc *maintenance.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, http.StatusOK, maintenanceSvc.State(r.Context()))
+	}
+}
+
+// HandleUpdateMaintenance returns an http.HandlerFunc that enables or disables the maintenance mode.
+func HandleUpdateMaintenance(maintenanceSvc *maintenance.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		in := new(types.MaintenanceModeInput)
+		err := request.ReadJSON(r, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		state, err := maintenanceSvc.SetEnabled(ctx, in.Enabled)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, state)
+	}
+}
@@ -45,7 +45,6 @@ func HandleList(triggerCtrl *trigger.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
-		render.JSON(w, http.StatusOK, repos)
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(totalCount), repos)
 	}
 }
@@ -30,12 +30,13 @@ func HandleFind(userCtrl *user.Controller) http.HandlerFunc {
 		session, _ := request.AuthSessionFrom(ctx)
 		userUID := session.Principal.UID
 
-		user, err := userCtrl.Find(ctx, session, userUID)
+		usr, err := userCtrl.Find(ctx, session, userUID)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
-		render.JSON(w, http.StatusOK, user)
+		w.Header().Set(request.HeaderETag, user.ETag(usr))
+		render.JSON(w, http.StatusOK, usr)
 	}
 }
@@ -36,7 +36,6 @@ func HandleMembershipSpaces(userCtrl *user.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(membershipSpaceCount))
-		render.JSON(w, http.StatusOK, membershipSpaces)
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(membershipSpaceCount), membershipSpaces)
 	}
 }
@@ -15,7 +15,6 @@
 package user
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/user"
@@ -32,18 +31,20 @@ func HandleUpdate(userCtrl *user.Controller) http.HandlerFunc {
 		userUID := session.Principal.UID
 
 		in := new(user.UpdateInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err := request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
+		in.IfMatch = request.GetIfMatchFromHeaders(r)
 
-		user, err := userCtrl.Update(ctx, session, userUID, in)
+		usr, err := userCtrl.Update(ctx, session, userUID, in)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
-		render.JSON(w, http.StatusOK, user)
+		w.Header().Set(request.HeaderETag, user.ETag(usr))
+		render.JSON(w, http.StatusOK, usr)
 	}
 }
@@ -15,7 +15,6 @@
 package user
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/user"
@@ -37,18 +36,20 @@ func HandleUpdateAdmin(userCtrl *user.Controller) http.HandlerFunc {
 		}
 
 		in := new(user.UpdateAdminInput)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
+		in.IfMatch = request.GetIfMatchFromHeaders(r)
 
-		user, err := userCtrl.UpdateAdmin(ctx, session, userUID, in)
+		usr, err := userCtrl.UpdateAdmin(ctx, session, userUID, in)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
-		render.JSON(w, http.StatusOK, user)
+		w.Header().Set(request.HeaderETag, user.ETag(usr))
+		render.JSON(w, http.StatusOK, usr)
 	}
 }
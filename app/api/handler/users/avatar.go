@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package users
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// avatarMaxAge is the duration in seconds clients are allowed to cache an avatar.
+const avatarMaxAge = 3600
+
+// HandleAvatar returns an http.HandlerFunc that serves the avatar of a user.
+func HandleAvatar(userCtrl *user.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		userUID, err := request.GetUserUIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		avatar, err := userCtrl.GetAvatar(ctx, session, userUID)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		if avatar.Data != nil {
+			defer func() {
+				if err := avatar.Data.Close(); err != nil {
+					log.Ctx(ctx).Warn().Err(err).Msg("failed to close avatar reader")
+				}
+			}()
+		}
+
+		etag := fmt.Sprintf("%q", avatar.ETag)
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", avatarMaxAge))
+		w.Header().Set("ETag", etag)
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		if avatar.Data == nil {
+			http.Redirect(w, r, avatar.RedirectURL, http.StatusTemporaryRedirect)
+			return
+		}
+
+		if avatar.ContentType != "" {
+			w.Header().Set("Content-Type", avatar.ContentType)
+		}
+		render.Reader(ctx, w, http.StatusOK, avatar.Data)
+	}
+}
@@ -15,7 +15,6 @@
 package users
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/user"
@@ -30,19 +29,36 @@ func HandleCreate(userCtrl *user.Controller) http.HandlerFunc {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
+		dryRun, err := request.ParseDryRunFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		idempotency, err := request.GetIdempotencyRequest(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		in := new(user.CreateInput)
-		err := json.NewDecoder(r.Body).Decode(in)
+		err = request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
-		usr, err := userCtrl.Create(ctx, session, in)
+		usr, err := userCtrl.Create(ctx, session, in, dryRun, idempotency)
 		if err != nil {
 			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
+		if dryRun {
+			render.JSON(w, http.StatusOK, usr)
+			return
+		}
+
 		render.JSON(w, http.StatusCreated, usr)
 	}
 }
@@ -40,6 +40,7 @@ func HandleFind(userCtrl *user.Controller) http.HandlerFunc {
 			return
 		}
 
+		w.Header().Set(request.HeaderETag, user.ETag(usr))
 		render.JSON(w, http.StatusOK, usr)
 	}
 }
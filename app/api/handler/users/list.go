@@ -30,7 +30,12 @@ func HandleList(userCtrl *user.Controller) http.HandlerFunc {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
-		filter := request.ParseUserFilter(r)
+		filter, err := request.ParseUserFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
 		if filter.Order == enum.OrderDefault {
 			filter.Order = enum.OrderAsc
 		}
@@ -41,7 +46,7 @@ func HandleList(userCtrl *user.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
-		render.JSON(w, http.StatusOK, list)
+		w.Header().Set(request.HeaderETag, user.ListETag(list))
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(totalCount), list)
 	}
 }
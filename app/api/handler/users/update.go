@@ -15,7 +15,6 @@
 package users
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/user"
@@ -36,10 +35,11 @@ func HandleUpdate(userCtrl *user.Controller) http.HandlerFunc {
 		}
 
 		in := new(user.UpdateInput)
-		if err = json.NewDecoder(r.Body).Decode(in); err != nil {
-			render.BadRequestf(ctx, w, "Invalid request body: %s.", err)
+		if err = request.ReadJSON(r, in); err != nil {
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
+		in.IfMatch = request.GetIfMatchFromHeaders(r)
 
 		usr, err := userCtrl.Update(ctx, session, userUID, in)
 		if err != nil {
@@ -47,6 +47,7 @@ func HandleUpdate(userCtrl *user.Controller) http.HandlerFunc {
 			return
 		}
 
+		w.Header().Set(request.HeaderETag, user.ETag(usr))
 		render.JSON(w, http.StatusOK, usr)
 	}
 }
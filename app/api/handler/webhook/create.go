@@ -15,7 +15,6 @@
 package webhook
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/webhook"
@@ -36,9 +35,9 @@ func HandleCreate(webhookCtrl *webhook.Controller) http.HandlerFunc {
 		}
 
 		in := new(webhook.CreateInput)
-		err = json.NewDecoder(r.Body).Decode(in)
+		err = request.ReadJSON(r, in)
 		if err != nil {
-			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			render.TranslatedUserError(ctx, w, err)
 			return
 		}
 
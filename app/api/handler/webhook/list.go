@@ -35,7 +35,11 @@ func HandleList(webhookCtrl *webhook.Controller) http.HandlerFunc {
 			return
 		}
 
-		filter := request.ParseWebhookFilter(r)
+		filter, err := request.ParseWebhookFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
 		if filter.Order == enum.OrderDefault {
 			filter.Order = enum.OrderAsc
 		}
@@ -49,7 +53,6 @@ func HandleList(webhookCtrl *webhook.Controller) http.HandlerFunc {
 			return
 		}
 
-		render.Pagination(r, w, filter.Page, filter.Size, int(totalCount))
-		render.JSON(w, http.StatusOK, webhooks)
+		render.PaginatedJSON(r, w, filter.Page, filter.Size, int(totalCount), webhooks)
 	}
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/audit"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/hlog"
@@ -28,14 +29,14 @@ import (
 
 // Attempt returns an http.HandlerFunc middleware that authenticates
 // the http.Request if authentication payload is available.
-func Attempt(authenticator authn.Authenticator) func(http.Handler) http.Handler {
-	return performAuthentication(authenticator, false)
+func Attempt(authenticator authn.Authenticator, auditService audit.Service) func(http.Handler) http.Handler {
+	return performAuthentication(authenticator, auditService, false)
 }
 
 // Required returns an http.HandlerFunc middleware that authenticates
 // the http.Request and fails the request if no auth data was available.
-func Required(authenticator authn.Authenticator) func(http.Handler) http.Handler {
-	return performAuthentication(authenticator, true)
+func Required(authenticator authn.Authenticator, auditService audit.Service) func(http.Handler) http.Handler {
+	return performAuthentication(authenticator, auditService, true)
 }
 
 // performAuthentication returns an http.HandlerFunc middleware that authenticates
@@ -43,6 +44,7 @@ func Required(authenticator authn.Authenticator) func(http.Handler) http.Handler
 // Depending on whether it is required or not, the request will be failed.
 func performAuthentication(
 	authenticator authn.Authenticator,
+	auditService audit.Service,
 	required bool,
 ) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -78,11 +80,37 @@ func performAuthentication(
 			// Update the logging context and inject principal in context
 			log.UpdateContext(func(c zerolog.Context) zerolog.Context {
 				return c.
+					Int64("principal_id", session.Principal.ID).
 					Str("principal_uid", session.Principal.UID).
 					Str("principal_type", string(session.Principal.Type)).
 					Bool("principal_admin", session.Principal.Admin)
 			})
 
+			// tag impersonated requests to record the acting admin (e.g. in the audit log)
+			if session.Impersonator != nil {
+				log.UpdateContext(func(c zerolog.Context) zerolog.Context {
+					return c.Int64("impersonator_id", session.Impersonator.ID)
+				})
+				ctx = audit.ContextWithImpersonator(ctx, *session.Impersonator)
+
+				// never serve an impersonated request without an audit entry of it.
+				err = auditService.Log(ctx,
+					session.Principal,
+					audit.NewResource(audit.ResourceTypeUser, session.Principal.UID),
+					audit.ActionActedAs,
+					audit.RootSpacePath,
+					audit.WithRequestMethod(r.Method),
+					audit.WithClientIP(audit.GetRealIP(ctx)),
+					audit.WithData("path", r.URL.Path),
+				)
+				if err != nil {
+					log.Error().Err(err).Msg("failed to audit impersonated request")
+
+					render.InternalError(ctx, w)
+					return
+				}
+			}
+
 			next.ServeHTTP(w, r.WithContext(
 				request.WithAuthSession(ctx, session),
 			))
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
+)
+
+type authenticatorFake struct {
+	session *auth.Session
+}
+
+func (a authenticatorFake) Authenticate(*http.Request) (*auth.Session, error) {
+	return a.session, nil
+}
+
+type auditEvent struct {
+	user         types.Principal
+	impersonator *types.Principal
+	action       audit.Action
+}
+
+type auditServiceFake struct {
+	events []auditEvent
+	err    error
+}
+
+func (s *auditServiceFake) Log(
+	ctx context.Context,
+	user types.Principal,
+	_ audit.Resource,
+	action audit.Action,
+	_ string,
+	_ ...audit.Option,
+) error {
+	if s.err != nil {
+		return s.err
+	}
+	event := auditEvent{user: user, action: action}
+	if impersonator, ok := audit.GetImpersonator(ctx); ok {
+		event.impersonator = &impersonator
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestAttempt_AuditsImpersonatedRequests(t *testing.T) {
+	admin := types.Principal{ID: 1, UID: "admin", Admin: true}
+	user := types.Principal{ID: 2, UID: "user"}
+
+	tests := []struct {
+		name       string
+		session    *auth.Session
+		auditErr   error
+		wantCode   int
+		wantEvents int
+	}{
+		{name: "regular", session: &auth.Session{Principal: user}, wantCode: http.StatusOK},
+		{name: "impersonated", session: &auth.Session{Principal: user, Impersonator: &admin},
+			wantCode: http.StatusOK, wantEvents: 1},
+		{name: "impersonated audit failure", session: &auth.Session{Principal: user, Impersonator: &admin},
+			auditErr: errors.New("audit store is down"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			auditService := &auditServiceFake{err: test.auditErr}
+			handler := Attempt(authenticatorFake{session: test.session}, auditService)(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/user", nil))
+
+			if w.Code != test.wantCode {
+				t.Errorf("Want response code %d, got %d", test.wantCode, w.Code)
+			}
+			if len(auditService.events) != test.wantEvents {
+				t.Fatalf("Want %d audit events, got %d", test.wantEvents, len(auditService.events))
+			}
+			for _, event := range auditService.events {
+				if event.action != audit.ActionActedAs || event.user.UID != user.UID ||
+					event.impersonator == nil || event.impersonator.UID != admin.UID {
+					t.Errorf("Want %q acting as %q, got %+v", admin.UID, user.UID, event)
+				}
+			}
+		})
+	}
+}
@@ -46,3 +46,71 @@ func BlockSessionToken(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// ScopeResolver returns the token scope required for the request.
+// It returns false if the request isn't mapped to any scope.
+type ScopeResolver func(r *http.Request) (enum.TokenScope, bool)
+
+// RequireScope returns an http.HandlerFunc middleware that ensures the token used for authentication
+// was granted the provided scope. Requests that aren't authenticated via a scoped token aren't restricted.
+func RequireScope(scope enum.TokenScope) func(http.Handler) http.Handler {
+	return RequireScopeForMethod(scope, scope)
+}
+
+// RequireScopeForMethod returns an http.HandlerFunc middleware that ensures the token used for authentication
+// was granted the read scope for safe http methods, and the write scope for all other http methods.
+func RequireScopeForMethod(readScope enum.TokenScope, writeScope enum.TokenScope) func(http.Handler) http.Handler {
+	return RequireScopes(func(r *http.Request) (enum.TokenScope, bool) {
+		return ScopeForMethod(r.Method, readScope, writeScope), true
+	})
+}
+
+// ScopeForMethod returns the read scope for safe http methods, and the write scope for all other http methods.
+func ScopeForMethod(method string, readScope enum.TokenScope, writeScope enum.TokenScope) enum.TokenScope {
+	if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+		return readScope
+	}
+
+	return writeScope
+}
+
+// RequireScopes returns an http.HandlerFunc middleware that ensures the token used for authentication
+// was granted the scope the resolver requires for the request.
+// Scoped tokens are denied access to requests the resolver doesn't map to any scope,
+// requests that aren't authenticated via a scoped token aren't restricted.
+func RequireScopes(resolve ScopeResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			session, oks := request.AuthSessionFrom(ctx)
+			if !oks {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tokenMetadata, okt := session.Metadata.(*auth.TokenMetadata)
+			if !okt || !tokenMetadata.IsScoped() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scope, ok := resolve(r)
+			if !ok {
+				log.Ctx(ctx).Debug().Msg("scoped token isn't allowed to access a route without a required scope")
+
+				render.Forbidden(ctx, w)
+				return
+			}
+
+			if !tokenMetadata.HasScope(scope) {
+				log.Ctx(ctx).Debug().Msgf("token is missing required scope '%s'", scope)
+
+				render.Forbidden(ctx, w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
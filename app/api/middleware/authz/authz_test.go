@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRequireScopeForMethod(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		metadata auth.Metadata
+		want     int
+	}{
+		{
+			name:     "allowed scope",
+			method:   http.MethodPost,
+			metadata: &auth.TokenMetadata{TokenType: enum.TokenTypePAT, Scopes: []enum.TokenScope{enum.TokenScopeRepoWrite}},
+			want:     http.StatusOK,
+		},
+		{
+			name:     "write scope includes read scope",
+			method:   http.MethodGet,
+			metadata: &auth.TokenMetadata{TokenType: enum.TokenTypePAT, Scopes: []enum.TokenScope{enum.TokenScopeRepoWrite}},
+			want:     http.StatusOK,
+		},
+		{
+			name:     "missing scope",
+			method:   http.MethodPost,
+			metadata: &auth.TokenMetadata{TokenType: enum.TokenTypePAT, Scopes: []enum.TokenScope{enum.TokenScopeRepoRead}},
+			want:     http.StatusForbidden,
+		},
+		{
+			name:     "unscoped token",
+			method:   http.MethodDelete,
+			metadata: &auth.TokenMetadata{TokenType: enum.TokenTypePAT},
+			want:     http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := RequireScopeForMethod(enum.TokenScopeRepoRead, enum.TokenScopeRepoWrite)(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))
+
+			ctx := request.WithAuthSession(context.Background(), &auth.Session{Metadata: test.metadata})
+			r := httptest.NewRequest(test.method, "/", nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, test.want; want != got {
+				t.Errorf("Want response code %d, got %d", want, got)
+			}
+		})
+	}
+}
+
+func TestRequireScopes_DeniesUnmappedRoutes(t *testing.T) {
+	h := RequireScopes(func(*http.Request) (enum.TokenScope, bool) { return "", false })(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	tests := []struct {
+		name     string
+		metadata auth.Metadata
+		want     int
+	}{
+		{
+			name:     "scoped token",
+			metadata: &auth.TokenMetadata{TokenType: enum.TokenTypePAT, Scopes: []enum.TokenScope{enum.TokenScopeRepoWrite}},
+			want:     http.StatusForbidden,
+		},
+		{
+			name:     "unscoped token",
+			metadata: &auth.TokenMetadata{TokenType: enum.TokenTypePAT},
+			want:     http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := request.WithAuthSession(context.Background(), &auth.Session{Metadata: test.metadata})
+			r := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, test.want; want != got {
+				t.Errorf("Want response code %d, got %d", want, got)
+			}
+		})
+	}
+}
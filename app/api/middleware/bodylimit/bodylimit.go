@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bodylimit
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+type originalBodyKey struct{}
+
+// Limit returns an http.HandlerFunc middleware that limits the size of the request body.
+// Reading beyond the limit fails with an *http.MaxBytesError, which is rendered as 413.
+// A limit of 0 disables the limit.
+func Limit(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// keep the original body to allow overriding the limit for a route group.
+			r = r.WithContext(context.WithValue(r.Context(), originalBodyKey{}, r.Body))
+			r.Body = limitBody(w, r.Body, limit)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Override returns an http.HandlerFunc middleware that replaces the body limit of the request
+// attached by Limit (e.g. to allow larger bodies for upload routes). A limit of 0 removes the limit.
+func Override(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if body, ok := r.Context().Value(originalBodyKey{}).(io.ReadCloser); ok {
+				r.Body = limitBody(w, body, limit)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func limitBody(w http.ResponseWriter, body io.ReadCloser, limit int64) io.ReadCloser {
+	if body == nil || limit <= 0 {
+		return body
+	}
+
+	return http.MaxBytesReader(w, body, limit)
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bodylimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/go-chi/chi"
+)
+
+// decodeHandler decodes the json body of the request the same way the api handlers do.
+func decodeHandler(w http.ResponseWriter, r *http.Request) {
+	in := map[string]string{}
+	if err := request.ReadJSON(r, &in); err != nil {
+		render.TranslatedUserError(r.Context(), w, err)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, in)
+}
+
+func serve(h http.Handler, path string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return w
+}
+
+func TestLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int64
+		body     string
+		wantCode int
+	}{
+		{name: "within limit", limit: 64, body: `{"name":"gitness"}`, wantCode: http.StatusOK},
+		{name: "over limit", limit: 8, body: `{"name":"gitness"}`, wantCode: http.StatusRequestEntityTooLarge},
+		{name: "malformed", limit: 64, body: `{"name":`, wantCode: http.StatusBadRequest},
+		{name: "disabled", limit: 0, body: `{"name":"` + strings.Repeat("a", 1024) + `"}`, wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(Limit(tt.limit)(http.HandlerFunc(decodeHandler)), "/", tt.body)
+
+			if got, want := w.Code, tt.wantCode; want != got {
+				t.Errorf("Want response code %d, got %d (%s)", want, got, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestOverride(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(Limit(8))
+	r.Post("/", decodeHandler)
+	r.With(Override(64)).Post("/upload", decodeHandler)
+
+	body := `{"name":"gitness"}`
+
+	w := serve(r, "/", body)
+	if got, want := w.Code, http.StatusRequestEntityTooLarge; want != got {
+		t.Errorf("Want response code %d for default route, got %d", want, got)
+	}
+
+	w = serve(r, "/upload", body)
+	if got, want := w.Code, http.StatusOK; want != got {
+		t.Errorf("Want response code %d for overridden route, got %d (%s)", want, got, w.Body.String())
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflag
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/services/featureflag"
+)
+
+// Require returns an http.HandlerFunc middleware that responds with 404 Not Found
+// if the feature flag is disabled for the principal of the request.
+// The route is indistinguishable from a route that doesn't exist.
+func Require(flags *featureflag.Service, flag featureflag.Flag) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			session, _ := request.AuthSessionFrom(ctx)
+
+			if !flags.IsEnabled(flag, session) {
+				render.NotFound(ctx, w)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/featureflag"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-chi/chi"
+)
+
+func TestRequire(t *testing.T) {
+	tests := []struct {
+		name     string
+		disabled []string
+		users    map[string]string
+		want     int
+	}{
+		{name: "on", want: http.StatusOK},
+		{name: "off", disabled: []string{string(featureflag.FlagRepoCherryPick)}, want: http.StatusNotFound},
+		{name: "on for user", disabled: []string{string(featureflag.FlagRepoCherryPick)},
+			users: map[string]string{string(featureflag.FlagRepoCherryPick): "user"}, want: http.StatusOK},
+		{name: "off for user", users: map[string]string{string(featureflag.FlagRepoCherryPick): "!user"},
+			want: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags, err := featureflag.NewService(nil, test.disabled, test.users)
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+
+			r := chi.NewRouter()
+			r.With(Require(flags, featureflag.FlagRepoCherryPick)).
+				Post("/cherry-pick", func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})
+
+			req := httptest.NewRequest(http.MethodPost, "/cherry-pick", nil)
+			req = req.WithContext(request.WithAuthSession(req.Context(), &auth.Session{
+				Principal: types.Principal{ID: 1, UID: "user"},
+			}))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != test.want {
+				t.Errorf("Want response code %d, got %d", test.want, w.Code)
+			}
+		})
+	}
+}
@@ -16,22 +16,71 @@ package logging
 
 import (
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/logging"
 
+	"github.com/go-chi/chi"
 	"github.com/rs/xid"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/hlog"
 )
 
 const (
 	requestIDHeader = "X-Request-Id"
+
+	// maxRequestIDLength is the max length of a request id supplied by the client.
+	maxRequestIDLength = 128
+
+	// redactedValue replaces the values of sensitive query parameters in logs.
+	redactedValue = "REDACTED"
 )
 
+// sensitiveQueryParams are the query parameters whose values must never be logged.
+var sensitiveQueryParams = []string{request.QueryParamAccessToken, "token"}
+
+// RedactURL returns the string representation of the url with the values of sensitive query parameters redacted.
+func RedactURL(u *url.URL) string {
+	query := u.Query()
+
+	redacted := false
+	for _, param := range sensitiveQueryParams {
+		if query.Has(param) {
+			query.Set(param, redactedValue)
+			redacted = true
+		}
+	}
+
+	if !redacted {
+		return u.String()
+	}
+
+	c := *u
+	c.RawQuery = query.Encode()
+	return c.String()
+}
+
+// HLogURLHandler provides a middleware that adds the url of the request with sensitive query parameters
+// redacted to the logging context.
+func HLogURLHandler(fieldKey string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			zerolog.Ctx(r.Context()).UpdateContext(func(c zerolog.Context) zerolog.Context {
+				return c.Str(fieldKey, RedactURL(r.URL))
+			})
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
 // HLogRequestIDHandler provides a middleware that injects request_id into the logging and execution context.
-// It prefers the X-Request-Id header, if that doesn't exist it creates a new request id similar to zerolog.
+// It prefers the X-Request-Id header, if that doesn't exist (or isn't a valid request id)
+// it creates a new request id similar to zerolog.
 func HLogRequestIDHandler() func(http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -39,7 +88,7 @@ func HLogRequestIDHandler() func(http.Handler) http.Handler {
 
 			// read requestID from header (or create new one if none exists)
 			var reqID string
-			if reqIDs, ok := r.Header[requestIDHeader]; ok && len(reqIDs) > 0 && len(reqIDs[0]) > 0 {
+			if reqIDs, ok := r.Header[requestIDHeader]; ok && len(reqIDs) > 0 && isValidRequestID(reqIDs[0]) {
 				reqID = reqIDs[0]
 			} else {
 				// similar to zerolog requestID generation
@@ -62,11 +111,37 @@ func HLogRequestIDHandler() func(http.Handler) http.Handler {
 	}
 }
 
+// isValidRequestID returns true if the request id supplied by the client is safe to be logged and returned.
+func isValidRequestID(reqID string) bool {
+	if reqID == "" || len(reqID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range reqID {
+		isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanumeric && !strings.ContainsRune("-_.:", c) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // HLogAccessLogHandler provides an hlog based middleware that logs access logs.
+// Request bodies are never logged, as they could contain sensitive data like passwords.
 func HLogAccessLogHandler() func(http.Handler) http.Handler {
 	return hlog.AccessHandler(
 		func(r *http.Request, status, size int, duration time.Duration) {
-			hlog.FromRequest(r).Info().
+			event := hlog.FromRequest(r).Info()
+
+			// the route pattern is only known once the request was routed by chi.
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					event = event.Str("http.route", pattern)
+				}
+			}
+
+			event.
 				Int("http.status_code", status).
 				Int("http.response_size_bytes", size).
 				Dur("http.elapsed_ms", duration).
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/hlog"
+)
+
+func setupRouter(buf *bytes.Buffer) http.Handler {
+	log := zerolog.New(buf)
+
+	r := chi.NewRouter()
+	r.Use(func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// use a copy of the logger per request, same as the main router.
+			l := log.With().Logger()
+			h.ServeHTTP(w, r.WithContext(l.WithContext(r.Context())))
+		})
+	})
+	r.Use(HLogURLHandler("http.url"))
+	r.Use(hlog.MethodHandler("http.method"))
+	r.Use(HLogRequestIDHandler())
+	r.Use(HLogAccessLogHandler())
+
+	r.Post("/v1/login", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Get("/v1/fail", func(w http.ResponseWriter, r *http.Request) {
+		render.InternalError(r.Context(), w)
+	})
+
+	return r
+}
+
+func readLogEntry(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	entry := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log entry %q: %s", buf.String(), err)
+	}
+	return entry
+}
+
+func TestAccessLog_RequestIDHeader(t *testing.T) {
+	buf := &bytes.Buffer{}
+	router := setupRouter(buf)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/login", strings.NewReader("{}"))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	reqID := w.Header().Get(requestIDHeader)
+	if reqID == "" {
+		t.Fatalf("Want %s header to be set", requestIDHeader)
+	}
+
+	entry := readLogEntry(t, buf)
+	if got := entry["request_id"]; got != reqID {
+		t.Errorf("Want request id %q, got %v", reqID, got)
+	}
+	if got := entry["http.method"]; got != http.MethodPost {
+		t.Errorf("Want method %q, got %v", http.MethodPost, got)
+	}
+	if got := entry["http.route"]; got != "/v1/login" {
+		t.Errorf("Want route %q, got %v", "/v1/login", got)
+	}
+	if got := entry["http.status_code"]; got != float64(http.StatusOK) {
+		t.Errorf("Want status code %d, got %v", http.StatusOK, got)
+	}
+	if _, ok := entry["http.elapsed_ms"]; !ok {
+		t.Errorf("Want elapsed time to be logged")
+	}
+}
+
+func TestAccessLog_RequestIDHeaderProvided(t *testing.T) {
+	buf := &bytes.Buffer{}
+	router := setupRouter(buf)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/login", strings.NewReader("{}"))
+	req.Header.Set(requestIDHeader, "client-request-id")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get(requestIDHeader); got != "client-request-id" {
+		t.Errorf("Want request id %q, got %q", "client-request-id", got)
+	}
+}
+
+func TestAccessLog_NoSensitiveData(t *testing.T) {
+	buf := &bytes.Buffer{}
+	router := setupRouter(buf)
+
+	body := `{"login_identifier":"admin","password":"s3cr3t-passw0rd"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/login?access_token=s3cr3t-t0ken", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	logs := buf.String()
+	if strings.Contains(logs, "s3cr3t-passw0rd") {
+		t.Errorf("Want password to not be logged, got %q", logs)
+	}
+	if strings.Contains(logs, "s3cr3t-t0ken") {
+		t.Errorf("Want access token to not be logged, got %q", logs)
+	}
+
+	entry := readLogEntry(t, buf)
+	if got, want := entry["http.url"], "/v1/login?access_token="+redactedValue; got != want {
+		t.Errorf("Want url %q, got %v", want, got)
+	}
+}
+
+func TestRequestID_ErrorBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantSame bool
+	}{
+		{name: "generated"},
+		{name: "provided", header: "client-request-id", wantSame: true},
+		{name: "invalid provided", header: "bad id\nwith newline"},
+		{name: "too long provided", header: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			router := setupRouter(&bytes.Buffer{})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/fail", nil)
+			if test.header != "" {
+				req.Header.Set(requestIDHeader, test.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusInternalServerError {
+				t.Fatalf("Want status %d, got %d", http.StatusInternalServerError, w.Code)
+			}
+
+			reqID := w.Header().Get(requestIDHeader)
+			if reqID == "" {
+				t.Fatalf("Want %s header to be set", requestIDHeader)
+			}
+			if test.wantSame != (reqID == test.header) {
+				t.Errorf("Want client request id preserved to be %t, got header %q", test.wantSame, reqID)
+			}
+
+			errjson := &usererror.Error{}
+			if err := json.NewDecoder(w.Body).Decode(errjson); err != nil {
+				t.Fatal(err)
+			}
+			if errjson.RequestID != reqID {
+				t.Errorf("Want request id %q in error body, got %q", reqID, errjson.RequestID)
+			}
+		})
+	}
+}
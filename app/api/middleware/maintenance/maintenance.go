@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/services/maintenance"
+)
+
+// WriteDetector returns whether the request writes data.
+type WriteDetector func(r *http.Request) bool
+
+// IsWriteMethod returns whether the request has a method that isn't safe and therefore writes data.
+func IsWriteMethod(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// BlockWrites returns an http.HandlerFunc middleware that responds with 503 Service Unavailable
+// to write requests while the maintenance mode is enabled. Reads are always served.
+func BlockWrites(
+	maintenanceSvc *maintenance.Service,
+	isWrite WriteDetector,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if isWrite(r) {
+				session, _ := request.AuthSessionFrom(ctx)
+				if !maintenanceSvc.AllowsWrite(ctx, session) {
+					render.MaintenanceMode(ctx, w, maintenanceSvc.RetryAfter())
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
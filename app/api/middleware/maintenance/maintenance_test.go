@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/maintenance"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/go-chi/chi"
+)
+
+// settingsStoreFake is an in-memory settings store.
+type settingsStoreFake struct {
+	store.SettingsStore
+	values  map[string]json.RawMessage
+	findErr error
+	finds   int
+}
+
+func (s *settingsStoreFake) Find(
+	_ context.Context,
+	_ enum.SettingsScope,
+	_ int64,
+	key string,
+) (json.RawMessage, error) {
+	s.finds++
+	if s.findErr != nil {
+		return nil, s.findErr
+	}
+	value, ok := s.values[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return value, nil
+}
+
+func (s *settingsStoreFake) Upsert(
+	_ context.Context,
+	_ enum.SettingsScope,
+	_ int64,
+	key string,
+	value json.RawMessage,
+) error {
+	if s.values == nil {
+		s.values = map[string]json.RawMessage{}
+	}
+	s.values[key] = value
+	return nil
+}
+
+func TestBlockWrites(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		adminBypass bool
+		method      string
+		admin       bool
+		want        int
+	}{
+		{name: "write outside maintenance", method: http.MethodPatch, want: http.StatusOK},
+		{name: "write in maintenance", enabled: true, method: http.MethodPatch, want: http.StatusServiceUnavailable},
+		{name: "read in maintenance", enabled: true, method: http.MethodGet, want: http.StatusOK},
+		{name: "admin write with bypass", enabled: true, adminBypass: true, method: http.MethodPatch, admin: true,
+			want: http.StatusOK},
+		{name: "admin write without bypass", enabled: true, method: http.MethodPatch, admin: true,
+			want: http.StatusServiceUnavailable},
+		{name: "user write with bypass", enabled: true, adminBypass: true, method: http.MethodPatch,
+			want: http.StatusServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maintenanceSvc := maintenance.NewService(settings.NewService(&settingsStoreFake{}),
+				test.enabled, 2*time.Minute, test.adminBypass)
+
+			r := chi.NewRouter()
+			r.Use(BlockWrites(maintenanceSvc, IsWriteMethod))
+			r.HandleFunc("/repos", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(test.method, "/repos", nil)
+			req = req.WithContext(request.WithAuthSession(req.Context(), &auth.Session{
+				Principal: types.Principal{ID: 1, UID: "user", Admin: test.admin},
+			}))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != test.want {
+				t.Errorf("Want response code %d, got %d", test.want, w.Code)
+			}
+
+			wantRetryAfter := ""
+			if test.want == http.StatusServiceUnavailable {
+				wantRetryAfter = "120"
+			}
+			if got := w.Header().Get("Retry-After"); got != wantRetryAfter {
+				t.Errorf("Want Retry-After %q, got %q", wantRetryAfter, got)
+			}
+		})
+	}
+}
+
+func TestBlockWrites_Toggle(t *testing.T) {
+	maintenanceSvc := maintenance.NewService(settings.NewService(&settingsStoreFake{}), false, time.Minute, false)
+
+	r := chi.NewRouter()
+	r.Use(BlockWrites(maintenanceSvc, IsWriteMethod))
+	r.Patch("/repos", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, enabled := range []bool{true, false} {
+		req := httptest.NewRequest(http.MethodPatch, "/repos", nil)
+		state, err := maintenanceSvc.SetEnabled(req.Context(), enabled)
+		if err != nil {
+			t.Fatalf("failed to toggle maintenance mode: %v", err)
+		}
+		if state.Enabled != enabled {
+			t.Errorf("Want maintenance mode enabled %t, got %t", enabled, state.Enabled)
+		}
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		want := http.StatusOK
+		if enabled {
+			want = http.StatusServiceUnavailable
+		}
+		if w.Code != want {
+			t.Errorf("Want response code %d, got %d", want, w.Code)
+		}
+	}
+}
+
+func TestBlockWrites_PersistedState(t *testing.T) {
+	settingsStore := &settingsStoreFake{}
+
+	// the toggled state is shared with other (or restarted) instances through the settings.
+	toggled := maintenance.NewService(settings.NewService(settingsStore), false, time.Minute, false)
+	if _, err := toggled.SetEnabled(context.Background(), true); err != nil {
+		t.Fatalf("failed to enable maintenance mode: %v", err)
+	}
+
+	other := maintenance.NewService(settings.NewService(settingsStore), false, time.Minute, false)
+
+	r := chi.NewRouter()
+	r.Use(BlockWrites(other, IsWriteMethod))
+	r.Post("/repos", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/repos", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Want response code %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestEnabled_CachesLoadFailure(t *testing.T) {
+	settingsStore := &settingsStoreFake{findErr: errors.New("database is down")}
+	maintenanceSvc := maintenance.NewService(settings.NewService(settingsStore), false, time.Minute, false)
+
+	for i := 0; i < 3; i++ {
+		if maintenanceSvc.Enabled(context.Background()) {
+			t.Errorf("Want last known state to be used if loading fails")
+		}
+	}
+
+	if settingsStore.finds != 1 {
+		t.Errorf("Want failing settings store to be queried once within the refresh interval, got %d",
+			settingsStore.finds)
+	}
+}
+
+func TestSetEnabled_EnabledByConfig(t *testing.T) {
+	maintenanceSvc := maintenance.NewService(settings.NewService(&settingsStoreFake{}), true, time.Minute, false)
+
+	if _, err := maintenanceSvc.SetEnabled(context.Background(), false); err == nil {
+		t.Errorf("Want maintenance mode enabled by config to not be disabled")
+	}
+	if !maintenanceSvc.Enabled(context.Background()) {
+		t.Errorf("Want maintenance mode to stay enabled")
+	}
+}
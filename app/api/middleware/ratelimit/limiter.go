@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+// Limiter limits the number of requests allowed per key.
+type Limiter interface {
+	// Allow consumes one request for the provided key and returns the current state of the limit.
+	Allow(key string) State
+}
+
+// State describes the state of a limiter for a single key.
+type State struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// ExceededError is returned by callers that enforce a limit outside of the middleware.
+// It carries the state of the limiter so the response can be rendered with the rate limit headers.
+type ExceededError struct {
+	State State
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("rate limit of %d exceeded", e.State.Limit)
+}
+
+// Unwrap returns the user facing error for the exceeded limit.
+func (e *ExceededError) Unwrap() error {
+	return usererror.TooManyRequests(e.State.Limit, e.State.Remaining, e.State.Reset)
+}
+
+var _ Limiter = (*FixedWindow)(nil)
+
+// FixedWindow is an in-memory limiter that allows up to limit requests per key within each window.
+type FixedWindow struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mx        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+type window struct {
+	count int
+	reset time.Time
+}
+
+func NewFixedWindow(limit int, duration time.Duration) *FixedWindow {
+	return &FixedWindow{
+		limit:   limit,
+		window:  duration,
+		now:     time.Now,
+		windows: make(map[string]*window),
+	}
+}
+
+func (l *FixedWindow) Allow(key string) State {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.reset) {
+		w = &window{reset: now.Add(l.window)}
+		l.windows[key] = w
+	}
+
+	if w.count >= l.limit {
+		return State{
+			Allowed:   false,
+			Limit:     l.limit,
+			Remaining: 0,
+			Reset:     w.reset,
+		}
+	}
+
+	w.count++
+
+	return State{
+		Allowed:   true,
+		Limit:     l.limit,
+		Remaining: l.limit - w.count,
+		Reset:     w.reset,
+	}
+}
+
+// sweep removes all expired windows - at most once per window duration.
+func (l *FixedWindow) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+
+	for key, w := range l.windows {
+		if !now.Before(w.reset) {
+			delete(l.windows, key)
+		}
+	}
+
+	l.lastSweep = now
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// KeyFunc returns the key used to limit the provided request.
+type KeyFunc func(r *http.Request) string
+
+// Limit returns an http.HandlerFunc middleware that limits requests based on the key returned by keyFn.
+// In case the limit is exceeded, a uniform 429 response is rendered.
+func Limit(limiter Limiter, keyFn KeyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			serveLimited(w, r, next, limiter, keyFn(r))
+		})
+	}
+}
+
+// serveLimited serves the request in case the limit of the key isn't exceeded yet.
+func serveLimited(w http.ResponseWriter, r *http.Request, next http.Handler, limiter Limiter, key string) {
+	ctx := r.Context()
+
+	state := limiter.Allow(key)
+	if !state.Allowed {
+		log.Ctx(ctx).Debug().Msgf("Rate limit exceeded for key '%s'.", key)
+
+		render.TooManyRequests(ctx, w, state.Limit, state.Remaining, state.Reset)
+		return
+	}
+
+	render.RateLimitHeaders(w, state.Limit, state.Remaining, state.Reset)
+
+	next.ServeHTTP(w, r)
+}
+
+// Global returns an http.HandlerFunc middleware that limits all requests using a single key.
+func Global(limiter Limiter) func(http.Handler) http.Handler {
+	return Limit(limiter, func(*http.Request) string {
+		return "global"
+	})
+}
+
+// PerPrincipal returns an http.HandlerFunc middleware that limits requests per authenticated principal.
+// Anonymous requests are limited per client IP (see PerClientIP).
+func PerPrincipal(limiter Limiter, trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	keyIP := keyClientIP(trustedProxies)
+	return Limit(limiter, func(r *http.Request) string {
+		if p, ok := request.PrincipalFrom(r.Context()); ok {
+			return "principal:" + strconv.FormatInt(p.ID, 10)
+		}
+
+		return keyIP(r)
+	})
+}
+
+// ScopeResolver returns the token scope required for the request.
+// It returns false if the request isn't mapped to any scope.
+type ScopeResolver func(r *http.Request) (enum.TokenScope, bool)
+
+// PerScope returns an http.HandlerFunc middleware that limits the requests of scoped tokens
+// per principal and token scope, using the limiter of the scope the resolver requires for the request.
+// Requests that aren't authenticated via a scoped token or require a scope without limiter aren't limited.
+func PerScope(limiters map[enum.TokenScope]Limiter, resolve ScopeResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session, ok := request.AuthSessionFrom(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata)
+			if !ok || !tokenMetadata.IsScoped() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scope, ok := resolve(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			limiter, ok := limiters[scope]
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := "scope:" + string(scope) + ":principal:" + strconv.FormatInt(session.Principal.ID, 10)
+			serveLimited(w, r, next, limiter, key)
+		})
+	}
+}
+
+// PerClientIP returns an http.HandlerFunc middleware that limits requests per client IP.
+// The client IP is the address of the connected peer, the X-Forwarded-For header is only
+// honoured for requests forwarded by one of the trusted proxies.
+func PerClientIP(limiter Limiter, trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return Limit(limiter, keyClientIP(trustedProxies))
+}
+
+func keyClientIP(trustedProxies []netip.Prefix) KeyFunc {
+	isTrusted := func(addr netip.Addr) bool {
+		for _, prefix := range trustedProxies {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(r *http.Request) string {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+
+		peer, err := netip.ParseAddr(host)
+		if err != nil || !isTrusted(peer.Unmap()) {
+			return "ip:" + host
+		}
+
+		// the closest address that isn't a trusted proxy is the client, everything before could be spoofed.
+		client := peer
+		hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+			if err != nil {
+				break
+			}
+			client = addr
+			if !isTrusted(addr.Unmap()) {
+				break
+			}
+		}
+
+		return "ip:" + client.String()
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// scopeForMethod requires the repo read scope for GET requests, and the repo write scope for all other requests.
+func scopeForMethod(r *http.Request) (enum.TokenScope, bool) {
+	if r.Method == http.MethodGet {
+		return enum.TokenScopeRepoRead, true
+	}
+	return enum.TokenScopeRepoWrite, true
+}
+
+// withScopedToken returns a context of a session authenticated via a token with the provided scopes.
+func withScopedToken(principalID int64, scopes ...enum.TokenScope) context.Context {
+	return request.WithAuthSession(context.Background(), &auth.Session{
+		Principal: types.Principal{ID: principalID},
+		Metadata:  &auth.TokenMetadata{TokenType: enum.TokenTypePAT, Scopes: scopes},
+	})
+}
+
+func TestLimit_ConsistentResponse(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	reset := now.Add(time.Minute)
+
+	tests := []struct {
+		name       string
+		middleware func(Limiter, []netip.Prefix) func(http.Handler) http.Handler
+		ctx        context.Context
+	}{
+		{
+			name:       "login",
+			middleware: PerClientIP,
+			ctx:        context.Background(),
+		},
+		{
+			name:       "principal",
+			middleware: PerPrincipal,
+			ctx: request.WithAuthSession(context.Background(), &auth.Session{
+				Principal: types.Principal{ID: 1},
+			}),
+		},
+		{
+			name: "scope",
+			middleware: func(limiter Limiter, _ []netip.Prefix) func(http.Handler) http.Handler {
+				return PerScope(map[enum.TokenScope]Limiter{enum.TokenScopeRepoRead: limiter}, scopeForMethod)
+			},
+			ctx: withScopedToken(1, enum.TokenScopeRepoRead),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := NewFixedWindow(1, time.Minute)
+			limiter.now = func() time.Time { return now }
+
+			h := test.middleware(limiter, nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(test.ctx)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if got, want := w.Code, http.StatusOK; want != got {
+				t.Errorf("Want response code %d, got %d", want, got)
+			}
+			if got, want := w.Header().Get(render.HeaderRateLimitRemaining), "0"; want != got {
+				t.Errorf("Want remaining header %q, got %q", want, got)
+			}
+
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if got, want := w.Code, http.StatusTooManyRequests; want != got {
+				t.Fatalf("Want response code %d, got %d", want, got)
+			}
+
+			headers := map[string]string{
+				render.HeaderRateLimitLimit:     "1",
+				render.HeaderRateLimitRemaining: "0",
+				render.HeaderRateLimitReset:     strconv.FormatInt(reset.Unix(), 10),
+			}
+			for header, want := range headers {
+				if got := w.Header().Get(header); want != got {
+					t.Errorf("Want header %s %q, got %q", header, want, got)
+				}
+			}
+
+			errjson := &usererror.Error{}
+			if err := json.NewDecoder(w.Body).Decode(errjson); err != nil {
+				t.Fatal(err)
+			}
+
+			values := map[string]any{
+				"code":      usererror.ErrorCodeRateLimitExceeded,
+				"limit":     float64(1),
+				"remaining": float64(0),
+				"reset":     float64(reset.Unix()),
+			}
+			for key, want := range values {
+				if got := errjson.Values[key]; want != got {
+					t.Errorf("Want body value %s %v, got %v", key, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPerScope(t *testing.T) {
+	h := PerScope(map[enum.TokenScope]Limiter{
+		enum.TokenScopeRepoWrite: NewFixedWindow(1, time.Minute),
+	}, scopeForMethod)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(ctx context.Context, method string) int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, "/", nil).WithContext(ctx))
+		return w.Code
+	}
+
+	scoped := withScopedToken(1, enum.TokenScopeRepoWrite)
+	if got := serve(scoped, http.MethodPost); got != http.StatusOK {
+		t.Errorf("Want first write to pass, got %d", got)
+	}
+	if got := serve(scoped, http.MethodPost); got != http.StatusTooManyRequests {
+		t.Errorf("Want second write to exceed the scope limit, got %d", got)
+	}
+	if got := serve(scoped, http.MethodGet); got != http.StatusOK {
+		t.Errorf("Want read of scope without limit to pass, got %d", got)
+	}
+
+	if got := serve(withScopedToken(2, enum.TokenScopeRepoWrite), http.MethodPost); got != http.StatusOK {
+		t.Errorf("Want write of other principal to pass, got %d", got)
+	}
+
+	unscoped := withScopedToken(1)
+	for i := 0; i < 2; i++ {
+		if got := serve(unscoped, http.MethodPost); got != http.StatusOK {
+			t.Errorf("Want write of unscoped token to pass, got %d", got)
+		}
+	}
+}
+
+func TestPerClientIP_ForwardedFor(t *testing.T) {
+	trustedProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		want         string
+	}{
+		{
+			name:       "no header",
+			remoteAddr: "192.0.2.1:1234",
+			want:       "ip:192.0.2.1",
+		},
+		{
+			name:         "spoofed header",
+			remoteAddr:   "192.0.2.1:1234",
+			forwardedFor: "198.51.100.1",
+			want:         "ip:192.0.2.1",
+		},
+		{
+			name:         "trusted proxy",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "198.51.100.1",
+			want:         "ip:198.51.100.1",
+		},
+		{
+			name:         "trusted proxy chain with spoofed client",
+			remoteAddr:   "10.0.0.1:1234",
+			forwardedFor: "203.0.113.1, 198.51.100.1, 10.0.0.2",
+			want:         "ip:198.51.100.1",
+		},
+		{
+			name:       "trusted proxy without header",
+			remoteAddr: "10.0.0.1:1234",
+			want:       "ip:10.0.0.1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = test.remoteAddr
+			if test.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", test.forwardedFor)
+			}
+
+			if got := keyClientIP(trustedProxies)(r); got != test.want {
+				t.Errorf("Want key %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFixedWindow_Reset(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewFixedWindow(1, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	if state := limiter.Allow("key"); !state.Allowed {
+		t.Errorf("Want first request to be allowed")
+	}
+	if state := limiter.Allow("key"); state.Allowed {
+		t.Errorf("Want second request to be rejected")
+	}
+	if state := limiter.Allow("other"); !state.Allowed {
+		t.Errorf("Want request for other key to be allowed")
+	}
+
+	now = now.Add(time.Minute)
+	if state := limiter.Allow("key"); !state.Allowed {
+		t.Errorf("Want request to be allowed after window reset")
+	}
+}
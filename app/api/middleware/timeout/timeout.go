@@ -0,0 +1,225 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+type deadlineKey struct{}
+
+// Timeout returns an http.HandlerFunc middleware that attaches a deadline to the request context,
+// causing downstream store and git calls to get cancelled once it's exceeded.
+// In case the handler didn't write a response before the deadline, a 504 is rendered.
+// A timeout of 0 disables the deadline.
+func Timeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, d := withDeadline(r.Context(), timeout)
+			defer d.stop()
+
+			tw := &timeoutWriter{ResponseWriter: w, d: d}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+
+			if !d.isExceeded() {
+				return
+			}
+
+			tw.mx.Lock()
+			defer tw.mx.Unlock()
+
+			if !tw.wroteHeader {
+				render.UserError(ctx, w, usererror.ErrRequestTimeout)
+			}
+		})
+	}
+}
+
+// Override returns an http.HandlerFunc middleware that replaces the timeout of the request
+// attached by Timeout (e.g. to allow longer running requests for a route group).
+// The new timeout is measured from the start of the request, a timeout of 0 removes the deadline.
+func Override(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if d, ok := r.Context().Value(deadlineKey{}).(*deadline); ok {
+				d.reset(timeout)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// deadline is a resettable deadline of a request.
+type deadline struct {
+	mx       sync.Mutex
+	start    time.Time
+	timeout  time.Duration
+	timer    *time.Timer
+	exceeded bool
+	cancel   context.CancelFunc
+}
+
+func withDeadline(parent context.Context, timeout time.Duration) (context.Context, *deadline) {
+	ctx, cancel := context.WithCancel(parent)
+	d := &deadline{
+		start:  time.Now(),
+		cancel: cancel,
+	}
+	d.reset(timeout)
+
+	ctx = &deadlineContext{
+		Context: context.WithValue(ctx, deadlineKey{}, d),
+		d:       d,
+	}
+
+	return ctx, d
+}
+
+func (d *deadline) reset(timeout time.Duration) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.exceeded {
+		return
+	}
+
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+
+	d.timeout = timeout
+	if timeout <= 0 {
+		return
+	}
+
+	d.timer = time.AfterFunc(time.Until(d.start.Add(timeout)), d.expire)
+}
+
+func (d *deadline) expire() {
+	d.mx.Lock()
+	d.exceeded = true
+	d.mx.Unlock()
+
+	d.cancel()
+}
+
+func (d *deadline) stop() {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.cancel()
+}
+
+func (d *deadline) isExceeded() bool {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.exceeded
+}
+
+func (d *deadline) get() (time.Time, bool) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.timeout <= 0 {
+		return time.Time{}, false
+	}
+
+	return d.start.Add(d.timeout), true
+}
+
+// deadlineContext reports context.DeadlineExceeded in case the request got cancelled by its deadline.
+type deadlineContext struct {
+	context.Context
+	d *deadline
+}
+
+func (c *deadlineContext) Deadline() (time.Time, bool) {
+	deadline, ok := c.d.get()
+	if parentDeadline, parentOK := c.Context.Deadline(); parentOK && (!ok || parentDeadline.Before(deadline)) {
+		return parentDeadline, true
+	}
+
+	return deadline, ok
+}
+
+func (c *deadlineContext) Err() error {
+	err := c.Context.Err()
+	if errors.Is(err, context.Canceled) && c.d.isExceeded() {
+		return context.DeadlineExceeded
+	}
+
+	return err
+}
+
+// timeoutWriter discards everything the handler writes after the deadline was exceeded,
+// unless the handler already started writing the response before.
+type timeoutWriter struct {
+	http.ResponseWriter
+	d *deadline
+
+	mx          sync.Mutex
+	wroteHeader bool
+}
+
+func (w *timeoutWriter) allowWrite() bool {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	if w.wroteHeader {
+		return true
+	}
+	if w.d.isExceeded() {
+		return false
+	}
+
+	w.wroteHeader = true
+	return true
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	if !w.allowWrite() {
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	if !w.allowWrite() {
+		return 0, context.DeadlineExceeded
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher to support streaming responses.
+func (w *timeoutWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok && w.allowWrite() {
+		f.Flush()
+	}
+}
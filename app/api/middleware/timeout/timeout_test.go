@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+)
+
+// sleepHandler sleeps for the provided duration (or until the request got cancelled) and
+// writes a 200 afterwards - ignoring the cancellation on purpose.
+func sleepHandler(d time.Duration, ctxErr *error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(d):
+		case <-r.Context().Done():
+		}
+		if ctxErr != nil {
+			*ctxErr = r.Context().Err()
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func TestTimeout_Exceeded(t *testing.T) {
+	var ctxErr error
+	h := Timeout(20 * time.Millisecond)(sleepHandler(time.Second, &ctxErr))
+
+	w := httptest.NewRecorder()
+	start := time.Now()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got, want := w.Code, http.StatusGatewayTimeout; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+	if !errors.Is(ctxErr, context.DeadlineExceeded) {
+		t.Errorf("Want request context to be cancelled with deadline exceeded, got %v", ctxErr)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Want request to be cancelled at the deadline, took %s", elapsed)
+	}
+}
+
+func TestTimeout_IgnoredCancellation(t *testing.T) {
+	// the handler sleeps past the deadline without observing the context.
+	h := Timeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got, want := w.Code, http.StatusGatewayTimeout; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
+
+func TestTimeout_NotExceeded(t *testing.T) {
+	h := Timeout(time.Second)(sleepHandler(0, nil))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got, want := w.Code, http.StatusOK; want != got {
+		t.Errorf("Want response code %d, got %d", want, got)
+	}
+}
+
+func TestOverride(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(Timeout(20 * time.Millisecond))
+	r.Get("/default", sleepHandler(100*time.Millisecond, nil))
+	r.With(Override(time.Second)).Get("/archive", sleepHandler(100*time.Millisecond, nil))
+	r.With(Override(0)).Get("/stream", sleepHandler(100*time.Millisecond, nil))
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{path: "/default", want: http.StatusGatewayTimeout},
+		{path: "/archive", want: http.StatusOK},
+		{path: "/stream", want: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+
+			if got := w.Code; got != test.want {
+				t.Errorf("Want response code %d, got %d", test.want, got)
+			}
+		})
+	}
+}
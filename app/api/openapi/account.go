@@ -46,6 +46,27 @@ type loginRequest struct {
 	user.LoginInput
 }
 
+// request to exchange a personal access token for a session.
+type loginWithTokenRequest struct {
+	user.LoginWithTokenInput
+}
+
+// request to send a password reset link.
+type requestPasswordResetRequest struct {
+	user.RequestPasswordResetInput
+}
+
+// request to reset the password using a password reset token.
+type resetPasswordRequest struct {
+	user.ResetPasswordInput
+}
+
+// request to complete a login with the identity provider.
+type oidcCallbackRequest struct {
+	Code  string `query:"code"`
+	State string `query:"state"`
+}
+
 // request to register an account.
 type registerRequest struct {
 	user.RegisterInput
@@ -63,8 +84,39 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusLocked)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/login", onLogin)
 
+	onLoginWithToken := openapi3.Operation{}
+	onLoginWithToken.WithTags("account")
+	onLoginWithToken.WithParameters(queryParameterIncludeCookie)
+	onLoginWithToken.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginWithToken"})
+	_ = reflector.SetRequest(&onLoginWithToken, new(loginWithTokenRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&onLoginWithToken, new(types.TokenResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onLoginWithToken, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onLoginWithToken, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLoginWithToken, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/login/token", onLoginWithToken)
+
+	opRequestPasswordReset := openapi3.Operation{}
+	opRequestPasswordReset.WithTags("account")
+	opRequestPasswordReset.WithMapOfAnything(map[string]interface{}{"operationId": "requestPasswordReset"})
+	_ = reflector.SetRequest(&opRequestPasswordReset, new(requestPasswordResetRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRequestPasswordReset, nil, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRequestPasswordReset, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRequestPasswordReset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/password-reset", opRequestPasswordReset)
+
+	opResetPassword := openapi3.Operation{}
+	opResetPassword.WithTags("account")
+	opResetPassword.WithMapOfAnything(map[string]interface{}{"operationId": "resetPassword"})
+	_ = reflector.SetRequest(&opResetPassword, new(resetPasswordRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opResetPassword, nil, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opResetPassword, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opResetPassword, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opResetPassword, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/password-reset/confirm", opResetPassword)
+
 	opLogout := openapi3.Operation{}
 	opLogout.WithTags("account")
 	opLogout.WithMapOfAnything(map[string]interface{}{"operationId": "opLogout"})
@@ -84,4 +136,25 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/register", onRegister)
+
+	onOIDCLogin := openapi3.Operation{}
+	onOIDCLogin.WithTags("account")
+	onOIDCLogin.WithMapOfAnything(map[string]interface{}{"operationId": "onOIDCLogin"})
+	_ = reflector.SetRequest(&onOIDCLogin, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&onOIDCLogin, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onOIDCLogin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onOIDCLogin, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/login", onOIDCLogin)
+
+	onOIDCCallback := openapi3.Operation{}
+	onOIDCCallback.WithTags("account")
+	onOIDCCallback.WithMapOfAnything(map[string]interface{}{"operationId": "onOIDCCallback"})
+	_ = reflector.SetRequest(&onOIDCCallback, new(oidcCallbackRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/callback", onOIDCCallback)
 }
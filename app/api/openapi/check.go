@@ -106,4 +106,37 @@ func checkOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listStatusCheckRecent, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/recent",
 		listStatusCheckRecent)
+
+	setCommitStatus := openapi3.Operation{}
+	setCommitStatus.WithTags(tag)
+	setCommitStatus.WithMapOfAnything(map[string]interface{}{"operationId": "setCommitStatus"})
+	_ = reflector.SetRequest(&setCommitStatus, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+		check.SetCommitStatusInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&setCommitStatus, new(types.CommitStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&setCommitStatus, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&setCommitStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&setCommitStatus, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&setCommitStatus, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/statuses/{commit_sha}",
+		setCommitStatus)
+
+	getCombinedCommitStatus := openapi3.Operation{}
+	getCombinedCommitStatus.WithTags(tag)
+	getCombinedCommitStatus.WithParameters(
+		queryParameterPage, queryParameterLimit, queryParameterStatusCheckQuery)
+	getCombinedCommitStatus.WithMapOfAnything(map[string]interface{}{"operationId": "getCombinedCommitStatus"})
+	_ = reflector.SetRequest(&getCombinedCommitStatus, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&getCombinedCommitStatus, new(types.CombinedCommitStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getCombinedCommitStatus, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getCombinedCommitStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getCombinedCommitStatus, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getCombinedCommitStatus, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/statuses/{commit_sha}",
+		getCombinedCommitStatus)
 }
@@ -46,6 +46,21 @@ var queryParameterPage = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterCursor = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamCursor,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The cursor returned by the previous page (via x-next-cursor header). " +
+			"Provide an empty cursor to request the first page using keyset pagination."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterOrder = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamOrder,
@@ -112,6 +127,36 @@ var queryParameterCreatedLt = openapi3.ParameterOrRef{
 	},
 }
 
+var headerParameterIdempotencyKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.HeaderIdempotencyKey,
+		In:   openapi3.ParameterInHeader,
+		Description: ptr.String("Unique key of the request. Retries with the same key return the originally " +
+			"created resource instead of creating a new one. Reusing the key for a different request fails with 422."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterDryRun = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamDryRun,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Validate the request without persisting any changes."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterCreatedGt = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamCreatedGt,
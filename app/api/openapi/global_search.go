@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type globalSearchRequest struct {
+}
+
+var queryParameterQueryGlobalSearch = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring by which the resources are searched."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterGlobalSearchTypes = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The types of resources to include (all types if not provided)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.GlobalSearchType("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
+// buildGlobalSearch function that constructs the openapi specification
+// for the global search.
+func buildGlobalSearch(reflector *openapi3.Reflector) {
+	opSearch := openapi3.Operation{}
+	opSearch.WithTags("search")
+	opSearch.WithMapOfAnything(map[string]interface{}{"operationId": "globalSearch"})
+	opSearch.WithParameters(queryParameterQueryGlobalSearch, queryParameterGlobalSearchTypes,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opSearch, new(globalSearchRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSearch, new([]types.GlobalSearchResult), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSearch, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSearch, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/search/global", opSearch)
+}
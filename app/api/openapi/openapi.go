@@ -58,6 +58,7 @@ func (*OpenAPI) Generate() *openapi3.Spec {
 	buildUser(&reflector)
 	buildAdmin(&reflector)
 	buildPrincipals(&reflector)
+	buildGlobalSearch(&reflector)
 	spaceOperations(&reflector)
 	pluginOperations(&reflector)
 	repoOperations(&reflector)
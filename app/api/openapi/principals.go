@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
@@ -29,6 +30,10 @@ import (
 type principalRequest struct {
 }
 
+type findManyUsersRequest struct {
+	principal.FindManyUsersInput
+}
+
 var queryParameterQueryPrincipals = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -77,4 +82,22 @@ func buildPrincipals(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/principals", opList)
+
+	opFindManyUsers := openapi3.Operation{}
+	opFindManyUsers.WithTags("principals")
+	opFindManyUsers.WithMapOfAnything(map[string]interface{}{"operationId": "findManyUsers"})
+	_ = reflector.SetRequest(&opFindManyUsers, new(findManyUsersRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFindManyUsers, new([]types.PrincipalInfo), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindManyUsers, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindManyUsers, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/principals/users/batch", opFindManyUsers)
+
+	opWhoami := openapi3.Operation{}
+	opWhoami.WithTags("principals")
+	opWhoami.WithMapOfAnything(map[string]interface{}{"operationId": "whoami"})
+	_ = reflector.SetRequest(&opWhoami, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opWhoami, new(types.Whoami), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opWhoami, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opWhoami, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/principals/whoami", opWhoami)
 }
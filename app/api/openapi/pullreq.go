@@ -66,6 +66,10 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type commentListByFilePullReqRequest struct {
+	pullReqRequest
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -340,6 +344,19 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq", listPullReq)
 
+	getPullReqMergeability := openapi3.Operation{}
+	getPullReqMergeability.WithTags("pullreq")
+	getPullReqMergeability.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReqMergeability"})
+	getPullReqMergeability.WithParameters(queryParameterSourceRepoRefPullRequest,
+		queryParameterSourceBranchPullRequest, queryParameterTargetBranchPullRequest)
+	_ = reflector.SetRequest(&getPullReqMergeability, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&getPullReqMergeability, new(types.PullReqMergeability), http.StatusOK)
+	_ = reflector.SetJSONResponse(&getPullReqMergeability, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&getPullReqMergeability, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&getPullReqMergeability, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&getPullReqMergeability, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/mergeability", getPullReqMergeability)
+
 	getPullReq := openapi3.Operation{}
 	getPullReq.WithTags("pullreq")
 	getPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReq"})
@@ -400,6 +417,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments", commentCreatePullReq)
 
+	commentListByFilePullReq := openapi3.Operation{}
+	commentListByFilePullReq.WithTags("pullreq")
+	commentListByFilePullReq.WithMapOfAnything(map[string]interface{}{"operationId": "commentListByFilePullReq"})
+	_ = reflector.SetRequest(&commentListByFilePullReq, new(commentListByFilePullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&commentListByFilePullReq, new([]pullreq.CommentFile), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentListByFilePullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentListByFilePullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentListByFilePullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentListByFilePullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/files", commentListByFilePullReq)
+
 	commentUpdatePullReq := openapi3.Operation{}
 	commentUpdatePullReq.WithTags("pullreq")
 	commentUpdatePullReq.WithMapOfAnything(map[string]interface{}{"operationId": "commentUpdatePullReq"})
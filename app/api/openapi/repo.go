@@ -54,11 +54,26 @@ type moveRepoRequest struct {
 	repo.MoveInput
 }
 
+type renameRepoRequest struct {
+	repoRequest
+	repo.RenameInput
+}
+
+type forkRepoRequest struct {
+	repoRequest
+	repo.ForkInput
+}
+
 type getContentRequest struct {
 	repoRequest
 	Path string `path:"path"`
 }
 
+type downloadArchiveRequest struct {
+	repoRequest
+	GitRef string `path:"git_ref" example:"main.zip"`
+}
+
 type pathsDetailsRequest struct {
 	repoRequest
 	repo.PathsDetailsInput
@@ -116,6 +131,12 @@ type GetCommitRequest struct {
 	CommitSHA string `path:"commit_sha"`
 }
 
+type cherryPickRequest struct {
+	repoRequest
+	CommitSHA string `path:"commit_sha"`
+	repo.CherryPickInput
+}
+
 type calculateCommitDivergenceRequest struct {
 	repoRequest
 	repo.GetCommitDivergencesInput
@@ -159,6 +180,11 @@ type getRawDiffRequest struct {
 	Path  []string `query:"path" description:"provide path for diff operation"`
 }
 
+type compareRequest struct {
+	repoRequest
+	Range string `path:"range" example:"main...dev"`
+}
+
 type postRawDiffRequest struct {
 	repoRequest
 	gittypes.FileDiffRequests
@@ -199,6 +225,39 @@ type restoreRequest struct {
 	repo.RestoreInput
 }
 
+var queryParameterArchiveFormat = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamArchiveFormat,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The format of the archive. " +
+			"If no value is provided the format is taken from the extension of the git ref."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: []interface{}{
+					gittypes.ArchiveFormatZip,
+					gittypes.ArchiveFormatTarGz,
+				},
+			},
+		},
+	},
+}
+
+var queryParameterGitRefRequired = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamGitRef,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The git reference (branch / tag / commit sha) to resolve."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterGitRef = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name: request.QueryParamGitRef,
@@ -258,6 +317,21 @@ var queryParameterUntil = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterIncludeDivergence = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeDivergence,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the divergence from the default branch should be included."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterIncludeCommit = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamIncludeCommit,
@@ -443,6 +517,36 @@ var queryParameterCommitter = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterAuthor = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuthor,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Author pattern (name or email) for which commit information should be retrieved."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCommitParent = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamParent,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The 1-based number of the parent the commit is compared to (e.g. 2 for the merged branch)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(1),
+				Minimum: ptr.Float64(1),
+			},
+		},
+	},
+}
+
 var queryParameterQueryRuleList = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -488,6 +592,21 @@ var queryParameterBypassRules = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterRequireMerged = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRequireMerged,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only delete the branch if it's merged into the default branch."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 var queryParameterDeletedAt = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamDeletedAt,
@@ -502,18 +621,39 @@ var queryParameterDeletedAt = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterTypeRepoActivity = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The type of the repository activity to include in the result."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.RepoActivityType("").Enum(),
+					},
+				},
+			},
+		},
+	},
+}
+
 //nolint:funlen
 func repoOperations(reflector *openapi3.Reflector) {
 	createRepository := openapi3.Operation{}
 	createRepository.WithTags("repository")
 	createRepository.WithMapOfAnything(map[string]interface{}{"operationId": "createRepository"})
-	createRepository.WithParameters(queryParameterSpacePath)
+	createRepository.WithParameters(queryParameterSpacePath, headerParameterIdempotencyKey)
 	_ = reflector.SetRequest(&createRepository, new(createRepositoryRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&createRepository, new(types.Repository), http.StatusCreated)
 	_ = reflector.SetJSONResponse(&createRepository, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&createRepository, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&createRepository, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&createRepository, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&createRepository, new(usererror.Error), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos", createRepository)
 
 	importRepository := openapi3.Operation{}
@@ -528,6 +668,41 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&importRepository, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/import", importRepository)
 
+	opCreateMirror := openapi3.Operation{}
+	opCreateMirror.WithTags("repository")
+	opCreateMirror.WithMapOfAnything(map[string]interface{}{"operationId": "createMirrorRepository"})
+	opCreateMirror.WithParameters(queryParameterSpacePath)
+	_ = reflector.SetRequest(&opCreateMirror, &struct{ repo.CreateMirrorInput }{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateMirror, new(types.Repository), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateMirror, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateMirror, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateMirror, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateMirror, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/mirror", opCreateMirror)
+
+	opMirrorFind := openapi3.Operation{}
+	opMirrorFind.WithTags("repository")
+	opMirrorFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepositoryMirror"})
+	_ = reflector.SetRequest(&opMirrorFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(types.RepositoryMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/mirror", opMirrorFind)
+
+	opMirrorSync := openapi3.Operation{}
+	opMirrorSync.WithTags("repository")
+	opMirrorSync.WithMapOfAnything(map[string]interface{}{"operationId": "syncRepositoryMirror"})
+	_ = reflector.SetRequest(&opMirrorSync, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(types.RepositoryMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusLocked)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/mirror/sync", opMirrorSync)
+
 	opFind := openapi3.Operation{}
 	opFind.WithTags("repository")
 	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepository"})
@@ -539,6 +714,50 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}", opFind)
 
+	opStats := openapi3.Operation{}
+	opStats.WithTags("repository")
+	opStats.WithMapOfAnything(map[string]interface{}{"operationId": "repositoryStats"})
+	_ = reflector.SetRequest(&opStats, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opStats, new(types.RepositoryStats), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/stats", opStats)
+
+	opLanguages := openapi3.Operation{}
+	opLanguages.WithTags("repository")
+	opLanguages.WithMapOfAnything(map[string]interface{}{"operationId": "repositoryLanguages"})
+	_ = reflector.SetRequest(&opLanguages, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLanguages, new(types.RepositoryLanguages), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLanguages, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLanguages, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLanguages, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLanguages, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/languages", opLanguages)
+
+	opArchive := openapi3.Operation{}
+	opArchive.WithTags("repository")
+	opArchive.WithMapOfAnything(map[string]interface{}{"operationId": "archiveRepository"})
+	_ = reflector.SetRequest(&opArchive, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opArchive, new(types.Repository), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/archive", opArchive)
+
+	opUnarchive := openapi3.Operation{}
+	opUnarchive.WithTags("repository")
+	opUnarchive.WithMapOfAnything(map[string]interface{}{"operationId": "unarchiveRepository"})
+	_ = reflector.SetRequest(&opUnarchive, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUnarchive, new(types.Repository), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUnarchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUnarchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUnarchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUnarchive, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/unarchive", opUnarchive)
+
 	opUpdate := openapi3.Operation{}
 	opUpdate.WithTags("repository")
 	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepository"})
@@ -598,6 +817,31 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMove, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/move", opMove)
 
+	opRename := openapi3.Operation{}
+	opRename.WithTags("repository")
+	opRename.WithMapOfAnything(map[string]interface{}{"operationId": "renameRepository"})
+	_ = reflector.SetRequest(&opRename, new(renameRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRename, new(types.Repository), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRename, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRename, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRename, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRename, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRename, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/rename", opRename)
+
+	opFork := openapi3.Operation{}
+	opFork.WithTags("repository")
+	opFork.WithMapOfAnything(map[string]interface{}{"operationId": "forkRepository"})
+	_ = reflector.SetRequest(&opFork, new(forkRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFork, new(types.Repository), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork", opFork)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("repository")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryServiceAccounts"})
@@ -621,6 +865,18 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetContent, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/content/{path}", opGetContent)
 
+	opReadme := openapi3.Operation{}
+	opReadme.WithTags("repository")
+	opReadme.WithMapOfAnything(map[string]interface{}{"operationId": "getReadme"})
+	opReadme.WithParameters(queryParameterGitRef)
+	_ = reflector.SetRequest(&opReadme, new(getContentRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opReadme, new(repo.ReadmeOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReadme, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReadme, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReadme, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReadme, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/readme/{path}", opReadme)
+
 	opListPaths := openapi3.Operation{}
 	opListPaths.WithTags("repository")
 	opListPaths.WithMapOfAnything(map[string]interface{}{"operationId": "listPaths"})
@@ -658,6 +914,19 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/raw/{path}", opGetRaw)
 
+	opDownloadArchive := openapi3.Operation{}
+	opDownloadArchive.WithTags("repository")
+	opDownloadArchive.WithMapOfAnything(map[string]interface{}{"operationId": "downloadArchive"})
+	opDownloadArchive.WithParameters(queryParameterArchiveFormat)
+	_ = reflector.SetRequest(&opDownloadArchive, new(downloadArchiveRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opDownloadArchive, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&opDownloadArchive, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDownloadArchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDownloadArchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDownloadArchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDownloadArchive, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/archive/{git_ref}", opDownloadArchive)
+
 	opGetBlame := openapi3.Operation{}
 	opGetBlame.WithTags("repository")
 	opGetBlame.WithMapOfAnything(map[string]interface{}{"operationId": "getBlame"})
@@ -675,7 +944,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opListCommits.WithTags("repository")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listCommits"})
 	opListCommits.WithParameters(queryParameterGitRef, queryParameterAfterCommits, queryParameterPath,
-		queryParameterSince, queryParameterUntil, queryParameterCommitter,
+		queryParameterSince, queryParameterUntil, queryParameterCommitter, queryParameterAuthor,
 		queryParameterPage, queryParameterLimit, QueryParamIncludeStats)
 	_ = reflector.SetRequest(&opListCommits, new(listCommitsRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opListCommits, []types.ListCommitResponse{}, http.StatusOK)
@@ -696,6 +965,19 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetCommit, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}", opGetCommit)
 
+	opResolveRef := openapi3.Operation{}
+	opResolveRef.WithTags("repository")
+	opResolveRef.WithMapOfAnything(map[string]interface{}{"operationId": "resolveRef"})
+	opResolveRef.WithParameters(queryParameterGitRefRequired)
+	_ = reflector.SetRequest(&opResolveRef, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opResolveRef, new(types.ResolvedRef), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opResolveRef, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opResolveRef, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opResolveRef, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opResolveRef, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opResolveRef, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/resolve", opResolveRef)
+
 	opCalulateCommitDivergence := openapi3.Operation{}
 	opCalulateCommitDivergence.WithTags("repository")
 	opCalulateCommitDivergence.WithMapOfAnything(map[string]interface{}{"operationId": "calculateCommitDivergence"})
@@ -734,9 +1016,10 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opDeleteBranch := openapi3.Operation{}
 	opDeleteBranch.WithTags("repository")
 	opDeleteBranch.WithMapOfAnything(map[string]interface{}{"operationId": "deleteBranch"})
-	opDeleteBranch.WithParameters(queryParameterBypassRules)
+	opDeleteBranch.WithParameters(queryParameterBypassRules, queryParameterRequireMerged)
 	_ = reflector.SetRequest(&opDeleteBranch, new(deleteBranchRequest), http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDeleteBranch, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteBranch, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opDeleteBranch, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDeleteBranch, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opDeleteBranch, new(usererror.Error), http.StatusForbidden)
@@ -747,7 +1030,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opListBranches := openapi3.Operation{}
 	opListBranches.WithTags("repository")
 	opListBranches.WithMapOfAnything(map[string]interface{}{"operationId": "listBranches"})
-	opListBranches.WithParameters(queryParameterIncludeCommit,
+	opListBranches.WithParameters(queryParameterIncludeCommit, queryParameterIncludeDivergence,
 		queryParameterQueryBranches, queryParameterOrder, queryParameterSortBranch,
 		queryParameterPage, queryParameterLimit)
 	_ = reflector.SetRequest(&opListBranches, new(listBranchesRequest), http.MethodGet)
@@ -845,6 +1128,33 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCommitDiff, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}/diff", opCommitDiff)
 
+	opCommitChanges := openapi3.Operation{}
+	opCommitChanges.WithTags("repository")
+	opCommitChanges.WithMapOfAnything(map[string]interface{}{"operationId": "getCommitChanges"})
+	opCommitChanges.WithParameters(queryParameterCommitParent)
+	_ = reflector.SetRequest(&opCommitChanges, new(GetCommitRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCommitChanges, new(repo.CommitChanges), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommitChanges, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommitChanges, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommitChanges, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitChanges, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitChanges, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}/changes", opCommitChanges)
+
+	opCherryPick := openapi3.Operation{}
+	opCherryPick.WithTags("repository")
+	opCherryPick.WithMapOfAnything(map[string]interface{}{"operationId": "cherryPick"})
+	_ = reflector.SetRequest(&opCherryPick, new(cherryPickRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(repo.CherryPickOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits/{commit_sha}/cherry-pick", opCherryPick)
+
 	opDiffStats := openapi3.Operation{}
 	opDiffStats.WithTags("repository")
 	opDiffStats.WithMapOfAnything(map[string]interface{}{"operationId": "diffStats"})
@@ -855,6 +1165,18 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDiffStats, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/diff-stats/{range}", opDiffStats)
 
+	opCompare := openapi3.Operation{}
+	opCompare.WithTags("repository")
+	opCompare.WithMapOfAnything(map[string]interface{}{"operationId": "compare"})
+	_ = reflector.SetRequest(&opCompare, new(compareRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCompare, new(repo.Compare), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/compare/{range}", opCompare)
+
 	opMergeCheck := openapi3.Operation{}
 	opMergeCheck.WithTags("repository")
 	opMergeCheck.WithMapOfAnything(map[string]interface{}{"operationId": "mergeCheck"})
@@ -959,4 +1281,134 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/codeowners/validate", opCodeOwnerValidate)
+
+	opDeployKeyCreate := openapi3.Operation{}
+	opDeployKeyCreate.WithTags("repository")
+	opDeployKeyCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createDeployKey"})
+	_ = reflector.SetRequest(&opDeployKeyCreate, &struct {
+		repoRequest
+		repo.CreateDeployKeyInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opDeployKeyCreate, new(types.DeployKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opDeployKeyCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeployKeyCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeployKeyCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeployKeyCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeployKeyCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/deploy-keys", opDeployKeyCreate)
+
+	opDeployKeyList := openapi3.Operation{}
+	opDeployKeyList.WithTags("repository")
+	opDeployKeyList.WithMapOfAnything(map[string]interface{}{"operationId": "listDeployKeys"})
+	_ = reflector.SetRequest(&opDeployKeyList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDeployKeyList, []types.DeployKey{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDeployKeyList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeployKeyList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeployKeyList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeployKeyList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/deploy-keys", opDeployKeyList)
+
+	opDeployKeyDelete := openapi3.Operation{}
+	opDeployKeyDelete.WithTags("repository")
+	opDeployKeyDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteDeployKey"})
+	_ = reflector.SetRequest(&opDeployKeyDelete, struct {
+		repoRequest
+		DeployKeyID int64 `path:"deploy_key_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeployKeyDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeployKeyDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeployKeyDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeployKeyDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeployKeyDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/deploy-keys/{deploy_key_id}", opDeployKeyDelete)
+
+	opDeployKeyAuthorize := openapi3.Operation{}
+	opDeployKeyAuthorize.WithTags("repository")
+	opDeployKeyAuthorize.WithMapOfAnything(map[string]interface{}{"operationId": "authorizeDeployKey"})
+	_ = reflector.SetRequest(&opDeployKeyAuthorize, &struct {
+		repoRequest
+		repo.AuthorizeDeployKeyInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opDeployKeyAuthorize, new(repo.AuthorizeDeployKeyOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDeployKeyAuthorize, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeployKeyAuthorize, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeployKeyAuthorize, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeployKeyAuthorize, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeployKeyAuthorize, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/deploy-keys/authorize", opDeployKeyAuthorize)
+
+	opCollaboratorAdd := openapi3.Operation{}
+	opCollaboratorAdd.WithTags("repository")
+	opCollaboratorAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addRepoCollaborator"})
+	_ = reflector.SetRequest(&opCollaboratorAdd, &struct {
+		repoRequest
+		repo.CollaboratorAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCollaboratorAdd, new(types.RepoCollaboratorUser), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCollaboratorAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCollaboratorAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCollaboratorAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCollaboratorAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCollaboratorAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/collaborators", opCollaboratorAdd)
+
+	opCollaboratorList := openapi3.Operation{}
+	opCollaboratorList.WithTags("repository")
+	opCollaboratorList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoCollaborators"})
+	_ = reflector.SetRequest(&opCollaboratorList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCollaboratorList, []types.RepoCollaboratorUser{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCollaboratorList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCollaboratorList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCollaboratorList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCollaboratorList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/collaborators", opCollaboratorList)
+
+	opCollaboratorUpdate := openapi3.Operation{}
+	opCollaboratorUpdate.WithTags("repository")
+	opCollaboratorUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoCollaborator"})
+	_ = reflector.SetRequest(&opCollaboratorUpdate, &struct {
+		repoRequest
+		UserUID string `path:"user_uid"`
+		repo.CollaboratorUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opCollaboratorUpdate, new(types.RepoCollaboratorUser), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCollaboratorUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCollaboratorUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCollaboratorUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCollaboratorUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCollaboratorUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/collaborators/{user_uid}", opCollaboratorUpdate)
+
+	opCollaboratorRemove := openapi3.Operation{}
+	opCollaboratorRemove.WithTags("repository")
+	opCollaboratorRemove.WithMapOfAnything(map[string]interface{}{"operationId": "removeRepoCollaborator"})
+	_ = reflector.SetRequest(&opCollaboratorRemove, struct {
+		repoRequest
+		UserUID string `path:"user_uid"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opCollaboratorRemove, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opCollaboratorRemove, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCollaboratorRemove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCollaboratorRemove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCollaboratorRemove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCollaboratorRemove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/collaborators/{user_uid}", opCollaboratorRemove)
+
+	opActivityList := openapi3.Operation{}
+	opActivityList.WithTags("repository")
+	opActivityList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoActivities"})
+	opActivityList.WithParameters(queryParameterTypeRepoActivity,
+		queryParameterCreatedLt, queryParameterCreatedGt, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opActivityList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opActivityList, []types.RepoActivity{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opActivityList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opActivityList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opActivityList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opActivityList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opActivityList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/activities", opActivityList)
 }
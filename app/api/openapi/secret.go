@@ -41,6 +41,17 @@ type updateSecretRequest struct {
 	secret.UpdateInput
 }
 
+type createRepoSecretRequest struct {
+	repoRequest
+	secret.CreateRepoSecretInput
+}
+
+type repoSecretRequest struct {
+	repoRequest
+	Identifier string `path:"secret_identifier"`
+}
+
+//nolint:funlen
 func secretOperations(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
 	opCreate.WithTags("secret")
@@ -86,4 +97,39 @@ func secretOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/secrets/{secret_ref}", opUpdate)
+
+	opCreateRepo := openapi3.Operation{}
+	opCreateRepo.WithTags("secret")
+	opCreateRepo.WithMapOfAnything(map[string]interface{}{"operationId": "createRepoSecret"})
+	_ = reflector.SetRequest(&opCreateRepo, new(createRepoSecretRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateRepo, new(types.Secret), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateRepo, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/secrets", opCreateRepo)
+
+	opListRepo := openapi3.Operation{}
+	opListRepo.WithTags("secret")
+	opListRepo.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoSecrets"})
+	opListRepo.WithParameters(queryParameterQueryRepo, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListRepo, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListRepo, []types.Secret{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/secrets", opListRepo)
+
+	opDeleteRepo := openapi3.Operation{}
+	opDeleteRepo.WithTags("secret")
+	opDeleteRepo.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoSecret"})
+	_ = reflector.SetRequest(&opDeleteRepo, new(repoSecretRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteRepo, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteRepo, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteRepo, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteRepo, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteRepo, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/secrets/{secret_identifier}", opDeleteRepo)
 }
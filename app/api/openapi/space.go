@@ -103,6 +103,55 @@ var queryParameterRecursive = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterTopic = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTopic,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only return repositories tagged with the provided topics."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterTopicMode = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTopicMode,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Whether repositories have to be tagged with all or any of the provided topics."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(request.TopicModeAll),
+				Enum:    []interface{}{request.TopicModeAll, request.TopicModeAny},
+			},
+		},
+	},
+}
+
+var queryParameterArchived = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamArchived,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only return repositories with the provided archived state."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeBoolean),
+			},
+		},
+	},
+}
+
 var queryParameterSortSpace = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -141,11 +190,26 @@ var queryParameterMembershipUsers = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
 		In:          openapi3.ParameterInQuery,
-		Description: ptr.String("The substring by which the space members are filtered."),
+		Description: ptr.String("The substring of the display name or email by which the space members are filtered."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterMembershipRole = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRole,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The role by which the space members are filtered."),
 		Required:    ptr.Bool(false),
 		Schema: &openapi3.SchemaOrRef{
 			Schema: &openapi3.Schema{
 				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.MembershipRole("").Enum(),
 			},
 		},
 	},
@@ -312,7 +376,8 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opRepos.WithTags("space")
 	opRepos.WithMapOfAnything(map[string]interface{}{"operationId": "listRepos"})
 	opRepos.WithParameters(queryParameterQueryRepo, queryParameterSortRepo, queryParameterOrder,
-		queryParameterPage, queryParameterLimit, queryParameterRecursive)
+		queryParameterPage, queryParameterLimit, queryParameterRecursive, queryParameterArchived,
+		queryParameterTopic, queryParameterTopicMode, queryParameterCursor)
 	_ = reflector.SetRequest(&opRepos, new(spaceRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opRepos, []types.Repository{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opRepos, new(usererror.Error), http.StatusInternalServerError)
@@ -415,7 +480,7 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opMembershipList.WithTags("space")
 	opMembershipList.WithMapOfAnything(map[string]interface{}{"operationId": "membershipList"})
 	opMembershipList.WithParameters(
-		queryParameterMembershipUsers,
+		queryParameterMembershipUsers, queryParameterMembershipRole,
 		queryParameterOrder, queryParameterSortMembershipUsers,
 		queryParameterPage, queryParameterLimit)
 	_ = reflector.SetRequest(&opMembershipList, &struct {
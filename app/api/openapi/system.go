@@ -19,6 +19,8 @@ import (
 
 	"github.com/harness/gitness/app/api/handler/system"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types"
 
 	"github.com/swaggest/openapi-go/openapi3"
 )
@@ -34,4 +36,19 @@ func buildSystem(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/config", opGetConfig)
+
+	opListFeatureFlags := openapi3.Operation{}
+	opListFeatureFlags.WithTags("system")
+	opListFeatureFlags.WithMapOfAnything(map[string]interface{}{"operationId": "listFeatureFlags"})
+	_ = reflector.SetRequest(&opListFeatureFlags, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListFeatureFlags, []types.FeatureFlag{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListFeatureFlags, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/feature-flags", opListFeatureFlags)
+
+	opGetJWKS := openapi3.Operation{}
+	opGetJWKS.WithTags("system")
+	opGetJWKS.WithMapOfAnything(map[string]interface{}{"operationId": "getSystemJWKS"})
+	_ = reflector.SetRequest(&opGetJWKS, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetJWKS, new(jwt.JWKS), http.StatusOK)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/jwks", opGetJWKS)
 }
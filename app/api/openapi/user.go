@@ -27,10 +27,59 @@ import (
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
+// ifMatchRequest is the optional If-Match header of update requests.
+type ifMatchRequest struct {
+	IfMatchHeader string `header:"If-Match" description:"ETag of the resource the update is based on."`
+}
+
+type updateUserRequest struct {
+	ifMatchRequest
+	user.UpdateInput
+}
+
 type createTokenRequest struct {
 	user.CreateTokenInput
 }
 
+type updateAvatarRequest struct {
+	Content string `json:"-" format:"binary" description:"Binary image to upload"`
+}
+
+type avatarRequest struct {
+	UserUID string `path:"user_uid"`
+}
+
+type createPublicKeyRequest struct {
+	user.CreatePublicKeyInput
+}
+
+type activeTokenRequest struct {
+	ID    int64 `path:"token_id"`
+	Force bool  `query:"force" description:"Revoke the token even if it's used for the current request."`
+}
+
+type meRequest struct {
+	SpaceRef string `query:"space_ref" description:"Space for which the effective access is returned."`
+	RepoRef  string `query:"repo_ref"  description:"Repository for which the effective access is returned."`
+}
+
+type publicKeyRequest struct {
+	ID int64 `path:"public_key_id"`
+}
+
+type addEmailRequest struct {
+	user.AddEmailInput
+}
+
+type userEmailRequest struct {
+	ID int64 `path:"email_id"`
+}
+
+type verifyEmailRequest struct {
+	userEmailRequest
+	user.VerifyEmailInput
+}
+
 var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -72,12 +121,26 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user", opFind)
 
+	opMe := openapi3.Operation{}
+	opMe.WithTags("user")
+	opMe.WithMapOfAnything(map[string]interface{}{"operationId": "getUserMe"})
+	_ = reflector.SetRequest(&opMe, new(meRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMe, new(types.UserMe), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMe, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMe, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMe, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMe, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMe, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/me", opMe)
+
 	opUpdate := openapi3.Operation{}
 	opUpdate.WithTags("user")
 	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateUser"})
-	_ = reflector.SetRequest(&opUpdate, new(user.UpdateInput), http.MethodPatch)
+	_ = reflector.SetRequest(&opUpdate, new(updateUserRequest), http.MethodPatch)
 	_ = reflector.SetJSONResponse(&opUpdate, new(types.User), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusPreconditionRequired)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user", opUpdate)
 
 	opToken := openapi3.Operation{}
@@ -99,4 +162,142 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new([]types.MembershipSpace), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
+
+	opCompleteOnboarding := openapi3.Operation{}
+	opCompleteOnboarding.WithTags("user")
+	opCompleteOnboarding.WithMapOfAnything(map[string]interface{}{"operationId": "completeOnboarding"})
+	_ = reflector.SetRequest(&opCompleteOnboarding, struct{}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCompleteOnboarding, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCompleteOnboarding, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/onboarding/complete", opCompleteOnboarding)
+
+	opUpdateAvatar := openapi3.Operation{}
+	opUpdateAvatar.WithTags("user")
+	opUpdateAvatar.WithMapOfAnything(map[string]interface{}{"operationId": "updateUserAvatar"})
+	_ = reflector.SetRequest(&opUpdateAvatar, new(updateAvatarRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateAvatar, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateAvatar, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateAvatar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/avatar", opUpdateAvatar)
+
+	opAvatar := openapi3.Operation{}
+	opAvatar.WithTags("user")
+	opAvatar.WithMapOfAnything(map[string]interface{}{"operationId": "getUserAvatar"})
+	_ = reflector.SetRequest(&opAvatar, new(avatarRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAvatar, nil, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAvatar, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&opAvatar, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAvatar, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opAvatar, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/users/{user_uid}/avatar", opAvatar)
+
+	opListPublicKeys := openapi3.Operation{}
+	opListPublicKeys.WithTags("user")
+	opListPublicKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listPublicKeys"})
+	_ = reflector.SetRequest(&opListPublicKeys, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new([]types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opListPublicKeys)
+
+	opCreatePublicKey := openapi3.Operation{}
+	opCreatePublicKey.WithTags("user")
+	opCreatePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "createPublicKey"})
+	_ = reflector.SetRequest(&opCreatePublicKey, new(createPublicKeyRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(types.PublicKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/keys", opCreatePublicKey)
+
+	opDeletePublicKey := openapi3.Operation{}
+	opDeletePublicKey.WithTags("user")
+	opDeletePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "deletePublicKey"})
+	_ = reflector.SetRequest(&opDeletePublicKey, new(publicKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/keys/{public_key_id}", opDeletePublicKey)
+
+	opListEmails := openapi3.Operation{}
+	opListEmails.WithTags("user")
+	opListEmails.WithMapOfAnything(map[string]interface{}{"operationId": "listEmails"})
+	_ = reflector.SetRequest(&opListEmails, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListEmails, new([]types.UserEmail), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListEmails, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/emails", opListEmails)
+
+	opAddEmail := openapi3.Operation{}
+	opAddEmail.WithTags("user")
+	opAddEmail.WithMapOfAnything(map[string]interface{}{"operationId": "addEmail"})
+	_ = reflector.SetRequest(&opAddEmail, new(addEmailRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAddEmail, new(types.UserEmail), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opAddEmail, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAddEmail, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opAddEmail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails", opAddEmail)
+
+	opVerifyEmail := openapi3.Operation{}
+	opVerifyEmail.WithTags("user")
+	opVerifyEmail.WithMapOfAnything(map[string]interface{}{"operationId": "verifyEmail"})
+	_ = reflector.SetRequest(&opVerifyEmail, new(verifyEmailRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(types.UserEmail), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails/{email_id}/verify", opVerifyEmail)
+
+	opSetPrimaryEmail := openapi3.Operation{}
+	opSetPrimaryEmail.WithTags("user")
+	opSetPrimaryEmail.WithMapOfAnything(map[string]interface{}{"operationId": "setPrimaryEmail"})
+	_ = reflector.SetRequest(&opSetPrimaryEmail, new(userEmailRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSetPrimaryEmail, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetPrimaryEmail, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetPrimaryEmail, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSetPrimaryEmail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/emails/{email_id}/primary", opSetPrimaryEmail)
+
+	opRemoveEmail := openapi3.Operation{}
+	opRemoveEmail.WithTags("user")
+	opRemoveEmail.WithMapOfAnything(map[string]interface{}{"operationId": "removeEmail"})
+	_ = reflector.SetRequest(&opRemoveEmail, new(userEmailRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRemoveEmail, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRemoveEmail, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRemoveEmail, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRemoveEmail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/emails/{email_id}", opRemoveEmail)
+
+	opRemovePrimaryEmail := openapi3.Operation{}
+	opRemovePrimaryEmail.WithTags("user")
+	opRemovePrimaryEmail.WithMapOfAnything(map[string]interface{}{"operationId": "removePrimaryEmail"})
+	_ = reflector.SetRequest(&opRemovePrimaryEmail, struct{}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRemovePrimaryEmail, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRemovePrimaryEmail, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRemovePrimaryEmail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/emails/primary", opRemovePrimaryEmail)
+
+	opRevokeAllTokens := openapi3.Operation{}
+	opRevokeAllTokens.WithTags("user")
+	opRevokeAllTokens.WithMapOfAnything(map[string]interface{}{"operationId": "revokeAllTokens"})
+	_ = reflector.SetRequest(&opRevokeAllTokens, struct{}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRevokeAllTokens, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRevokeAllTokens, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/sessions/revoke-all", opRevokeAllTokens)
+
+	opListActiveTokens := openapi3.Operation{}
+	opListActiveTokens.WithTags("user")
+	opListActiveTokens.WithMapOfAnything(map[string]interface{}{"operationId": "listActiveTokens"})
+	_ = reflector.SetRequest(&opListActiveTokens, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListActiveTokens, new([]types.ActiveToken), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListActiveTokens, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/active-tokens", opListActiveTokens)
+
+	opRevokeActiveToken := openapi3.Operation{}
+	opRevokeActiveToken.WithTags("user")
+	opRevokeActiveToken.WithMapOfAnything(map[string]interface{}{"operationId": "revokeActiveToken"})
+	_ = reflector.SetRequest(&opRevokeActiveToken, new(activeTokenRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRevokeActiveToken, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRevokeActiveToken, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRevokeActiveToken, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRevokeActiveToken, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/active-tokens/{token_id}", opRevokeActiveToken)
 }
@@ -17,8 +17,10 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/types"
 
 	"github.com/swaggest/openapi-go/openapi3"
@@ -38,9 +40,15 @@ type (
 	// adminUsersUpdateRequest is the request for the admin user update operation.
 	adminUsersUpdateRequest struct {
 		adminUsersRequest
+		ifMatchRequest
 		user.UpdateInput
 	}
 
+	// adminOperationRequest is the request for operation specific admin operations.
+	adminOperationRequest struct {
+		OperationID int64 `path:"operation_id"`
+	}
+
 	// adminUserListRequest is the request for listing users.
 	adminUserListRequest struct {
 		Sort  string `query:"sort"      enum:"id,email,created,updated"`
@@ -53,8 +61,14 @@ type (
 	// updateAdminRequest is the request for updating the admin attribute for the user.
 	updateAdminRequest struct {
 		adminUsersRequest
+		ifMatchRequest
 		user.UpdateAdminInput
 	}
+
+	// adminVerifyAuditChainRequest is the request for verifying the audit log hash chain.
+	adminVerifyAuditChainRequest struct {
+		SpacePath string `query:"space_path"`
+	}
 )
 
 // helper function that constructs the openapi specification
@@ -73,6 +87,7 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	opList := openapi3.Operation{}
 	opList.WithTags("admin")
 	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListUsers"})
+	opList.WithParameters(queryParameterCreatedLt, queryParameterCreatedGt)
 	_ = reflector.SetRequest(&opList, new(adminUserListRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opList, new([]*types.User), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusBadRequest)
@@ -83,11 +98,14 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
 	opCreate.WithTags("admin")
 	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "adminCreateUser"})
+	opCreate.WithParameters(queryParameterDryRun, headerParameterIdempotencyKey)
 	_ = reflector.SetRequest(&opCreate, new(adminUsersCreateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.User), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opCreate, new(types.User), http.StatusCreated)
 	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/users", opCreate)
 
 	opUpdate := openapi3.Operation{}
@@ -98,6 +116,8 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusPreconditionRequired)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/users/{user_uid}", opUpdate)
 
 	opUpdateAdmin := openapi3.Operation{}
@@ -107,8 +127,30 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUpdateAdmin, new(types.User), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opUpdateAdmin, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.SetJSONResponse(&opUpdateAdmin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateAdmin, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opUpdateAdmin, new(usererror.Error), http.StatusPreconditionRequired)
 	_ = reflector.Spec.AddOperation(http.MethodPatch, "/admin/users/{user_uid}/admin", opUpdateAdmin)
 
+	opUnlock := openapi3.Operation{}
+	opUnlock.WithTags("admin")
+	opUnlock.WithMapOfAnything(map[string]interface{}{"operationId": "adminUnlockUser"})
+	_ = reflector.SetRequest(&opUnlock, new(adminUsersRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opUnlock, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUnlock, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUnlock, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/users/{user_uid}/unlock", opUnlock)
+
+	opImpersonate := openapi3.Operation{}
+	opImpersonate.WithTags("admin")
+	opImpersonate.WithMapOfAnything(map[string]interface{}{"operationId": "adminImpersonateUser"})
+	_ = reflector.SetRequest(&opImpersonate, new(adminUsersRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opImpersonate, new(types.TokenResponse), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opImpersonate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opImpersonate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opImpersonate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opImpersonate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/users/{user_uid}/impersonate", opImpersonate)
+
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("admin")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteUser"})
@@ -117,4 +159,103 @@ func buildAdmin(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/users/{user_uid}", opDelete)
+
+	opVerifyAuditChain := openapi3.Operation{}
+	opVerifyAuditChain.WithTags("admin")
+	opVerifyAuditChain.WithMapOfAnything(map[string]interface{}{"operationId": "adminVerifyAuditChain"})
+	_ = reflector.SetRequest(&opVerifyAuditChain, new(adminVerifyAuditChainRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opVerifyAuditChain, new(audit.ChainVerification), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opVerifyAuditChain, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opVerifyAuditChain, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/audit/verify", opVerifyAuditChain)
+
+	opRunCleanup := openapi3.Operation{}
+	opRunCleanup.WithTags("admin")
+	opRunCleanup.WithMapOfAnything(map[string]interface{}{"operationId": "adminRunCleanup"})
+	_ = reflector.SetRequest(&opRunCleanup, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRunCleanup, new([]types.CleanupJobResult), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRunCleanup, new(usererror.Error), http.StatusLocked)
+	_ = reflector.SetJSONResponse(&opRunCleanup, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/cleanup", opRunCleanup)
+
+	opGetMigrationStatus := openapi3.Operation{}
+	opGetMigrationStatus.WithTags("admin")
+	opGetMigrationStatus.WithMapOfAnything(map[string]interface{}{"operationId": "adminGetMigrationStatus"})
+	_ = reflector.SetRequest(&opGetMigrationStatus, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetMigrationStatus, new(types.MigrationStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetMigrationStatus, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetMigrationStatus, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGetMigrationStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/migrations", opGetMigrationStatus)
+
+	opGetMaintenance := openapi3.Operation{}
+	opGetMaintenance.WithTags("admin")
+	opGetMaintenance.WithMapOfAnything(map[string]interface{}{"operationId": "adminGetMaintenance"})
+	_ = reflector.SetRequest(&opGetMaintenance, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetMaintenance, new(types.MaintenanceMode), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetMaintenance, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetMaintenance, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/maintenance", opGetMaintenance)
+
+	opUpdateMaintenance := openapi3.Operation{}
+	opUpdateMaintenance.WithTags("admin")
+	opUpdateMaintenance.WithMapOfAnything(map[string]interface{}{"operationId": "adminUpdateMaintenance"})
+	_ = reflector.SetRequest(&opUpdateMaintenance, new(types.MaintenanceModeInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdateMaintenance, new(types.MaintenanceMode), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateMaintenance, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateMaintenance, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateMaintenance, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/admin/maintenance", opUpdateMaintenance)
+
+	opListOperations := openapi3.Operation{}
+	opListOperations.WithTags("admin")
+	opListOperations.WithMapOfAnything(map[string]interface{}{"operationId": "adminListOperations"})
+	_ = reflector.SetRequest(&opListOperations, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListOperations, new([]types.Operation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListOperations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/operations", opListOperations)
+
+	opKillOperation := openapi3.Operation{}
+	opKillOperation.WithTags("admin")
+	opKillOperation.WithMapOfAnything(map[string]interface{}{"operationId": "adminKillOperation"})
+	_ = reflector.SetRequest(&opKillOperation, new(adminOperationRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opKillOperation, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opKillOperation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opKillOperation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/operations/{operation_id}/kill", opKillOperation)
+
+	opGCAll := openapi3.Operation{}
+	opGCAll.WithTags("admin")
+	opGCAll.WithMapOfAnything(map[string]interface{}{"operationId": "adminGarbageCollectRepos"})
+	_ = reflector.SetRequest(&opGCAll, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opGCAll, new(repo.GarbageCollectAllOutput), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opGCAll, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/repos/gc", opGCAll)
+
+	opGCStatuses := openapi3.Operation{}
+	opGCStatuses.WithTags("admin")
+	opGCStatuses.WithMapOfAnything(map[string]interface{}{"operationId": "adminListReposGarbageCollection"})
+	_ = reflector.SetRequest(&opGCStatuses, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGCStatuses, new([]types.RepositoryGCStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGCStatuses, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/repos/gc", opGCStatuses)
+
+	opGC := openapi3.Operation{}
+	opGC.WithTags("admin")
+	opGC.WithMapOfAnything(map[string]interface{}{"operationId": "adminGarbageCollectRepo"})
+	_ = reflector.SetRequest(&opGC, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opGC, new(types.RepositoryGCStatus), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opGC, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opGC, new(usererror.Error), http.StatusLocked)
+	_ = reflector.SetJSONResponse(&opGC, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/repos/{repo_ref}/gc", opGC)
+
+	opGCStatus := openapi3.Operation{}
+	opGCStatus.WithTags("admin")
+	opGCStatus.WithMapOfAnything(map[string]interface{}{"operationId": "adminGetRepoGarbageCollection"})
+	_ = reflector.SetRequest(&opGCStatus, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGCStatus, new(types.RepositoryGCStatus), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGCStatus, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opGCStatus, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/repos/{repo_ref}/gc", opGCStatus)
 }
@@ -97,6 +97,35 @@ var queryParameterSortWebhook = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterEnabledWebhook = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamEnabled,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only return webhooks with the provided enabled state."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeBoolean),
+			},
+		},
+	},
+}
+
+var queryParameterTriggerWebhook = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamTrigger,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only return webhooks subscribed to the provided trigger."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.WebhookTrigger("").Enum(),
+			},
+		},
+	},
+}
+
 var queryParameterQueryWebhook = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -128,7 +157,7 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	listWebhooks.WithTags("webhook")
 	listWebhooks.WithMapOfAnything(map[string]interface{}{"operationId": "listWebhooks"})
 	listWebhooks.WithParameters(queryParameterQueryWebhook, queryParameterSortWebhook, queryParameterOrder,
-		queryParameterPage, queryParameterLimit)
+		queryParameterPage, queryParameterLimit, queryParameterEnabledWebhook, queryParameterTriggerWebhook)
 	_ = reflector.SetRequest(&listWebhooks, new(listWebhooksRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&listWebhooks, new([]webhookType), http.StatusOK)
 	_ = reflector.SetJSONResponse(&listWebhooks, new(usererror.Error), http.StatusBadRequest)
@@ -170,6 +199,29 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&deleteWebhook, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/webhooks/{webhook_identifier}", deleteWebhook)
 
+	pingWebhook := openapi3.Operation{}
+	pingWebhook.WithTags("webhook")
+	pingWebhook.WithMapOfAnything(map[string]interface{}{"operationId": "pingWebhook"})
+	_ = reflector.SetRequest(&pingWebhook, new(webhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&pingWebhook, new(types.WebhookExecution), http.StatusOK)
+	_ = reflector.SetJSONResponse(&pingWebhook, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&pingWebhook, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&pingWebhook, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&pingWebhook, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/webhooks/{webhook_identifier}/ping", pingWebhook)
+
+	regenerateWebhookSecret := openapi3.Operation{}
+	regenerateWebhookSecret.WithTags("webhook")
+	regenerateWebhookSecret.WithMapOfAnything(map[string]interface{}{"operationId": "regenerateWebhookSecret"})
+	_ = reflector.SetRequest(&regenerateWebhookSecret, new(webhookRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&regenerateWebhookSecret, new(webhook.SecretOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&regenerateWebhookSecret, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&regenerateWebhookSecret, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&regenerateWebhookSecret, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&regenerateWebhookSecret, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/webhooks/{webhook_identifier}/regenerate-secret", regenerateWebhookSecret)
+
 	listWebhookExecutions := openapi3.Operation{}
 	listWebhookExecutions.WithTags("webhook")
 	listWebhookExecutions.WithMapOfAnything(map[string]interface{}{"operationId": "listWebhookExecutions"})
@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// queryParamFormat is the query parameter used by clients to request a specific response format.
+	queryParamFormat = "format"
+
+	formatCSV    = "csv"
+	mediaTypeCSV = "text/csv"
+
+	// csvValueColumn is the column used for list elements that aren't objects.
+	csvValueColumn = "value"
+)
+
+// wantsCSV returns true if the client requested a CSV response,
+// either via "format=csv" or with an Accept header containing "text/csv".
+func wantsCSV(r *http.Request) bool {
+	if format := r.URL.Query().Get(queryParamFormat); format != "" {
+		return strings.EqualFold(format, formatCSV)
+	}
+
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err == nil && mediaType == mediaTypeCSV {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// CSV writes the list as CSV with a header row to the response with the provided status.
+// The records are the json-encoded list elements: the columns are the fields of the records
+// in order of their first appearance, nested objects and arrays are json-encoded per cell.
+func CSV(w http.ResponseWriter, code int, list any) {
+	header, rows, err := csvRecords(list)
+	if err != nil {
+		log.Err(err).Msg("failed to convert list to csv, falling back to json")
+		JSON(w, code, list)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write(header)
+	for _, row := range rows {
+		_ = writer.Write(row)
+	}
+
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		log.Err(err).Msg("failed to write csv to response body")
+	}
+}
+
+// csvRecords converts the list into the csv header and rows using its json representation.
+func csvRecords(list any) ([]string, [][]string, error) {
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to json-encode list: %w", err)
+	}
+
+	var elements []json.RawMessage
+	if err = json.Unmarshal(data, &elements); err != nil {
+		return nil, nil, fmt.Errorf("list isn't a json array: %w", err)
+	}
+
+	var header []string
+	columns := map[string]int{}
+	records := make([]map[string]string, len(elements))
+
+	for i, element := range elements {
+		keys, values, err := csvFields(element)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		records[i] = values
+		for _, key := range keys {
+			if _, ok := columns[key]; !ok {
+				columns[key] = len(header)
+				header = append(header, key)
+			}
+		}
+	}
+
+	rows := make([][]string, len(records))
+	for i, record := range records {
+		row := make([]string, len(header))
+		for key, value := range record {
+			row[columns[key]] = value
+		}
+		rows[i] = row
+	}
+
+	return header, rows, nil
+}
+
+// csvFields returns the keys (in order of appearance) and cell values of a json-encoded list element.
+func csvFields(element json.RawMessage) ([]string, map[string]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(element))
+
+	token, err := dec.Token()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read list element: %w", err)
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return []string{csvValueColumn}, map[string]string{csvValueColumn: csvCell(element)}, nil
+	}
+
+	var keys []string
+	values := map[string]string{}
+
+	for dec.More() {
+		token, err = dec.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read field name: %w", err)
+		}
+
+		key, _ := token.(string)
+
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, nil, fmt.Errorf("failed to read field %q: %w", key, err)
+		}
+
+		keys = append(keys, key)
+		values[key] = csvCell(value)
+	}
+
+	return keys, values, nil
+}
+
+// csvCell returns the cell of a json value - strings are unquoted, null is empty
+// and all other values (numbers, booleans, objects and arrays) are kept json-encoded.
+func csvCell(value json.RawMessage) string {
+	value = bytes.TrimSpace(value)
+
+	switch {
+	case bytes.Equal(value, []byte("null")):
+		return ""
+	case len(value) > 0 && value[0] == '"':
+		var s string
+		if err := json.Unmarshal(value, &s); err == nil {
+			return csvEscapeFormula(s)
+		}
+	}
+
+	return string(value)
+}
+
+// csvEscapeFormula prefixes strings that spreadsheet applications would evaluate as formula with a single quote.
+// Strings are user controlled (e.g. repo names or pull request titles), they must not be executed when opened.
+func csvEscapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
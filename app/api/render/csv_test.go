@@ -0,0 +1,197 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestPaginatedJSON_CSVMatchesJSON(t *testing.T) {
+	users := []*types.User{
+		{UID: "admin", Email: "admin@example.com", DisplayName: "Admin, The", Admin: true, Created: 1, Updated: 2},
+		{UID: "jane", Email: "jane@example.com", DisplayName: "Jane \"JD\" Doe", Created: 3, Updated: 4},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	w := httptest.NewRecorder()
+	PaginatedJSON(r, w, 1, 10, len(users), users)
+
+	dec := json.NewDecoder(w.Body)
+	dec.UseNumber()
+	var want []map[string]any
+	if err := dec.Decode(&want); err != nil {
+		t.Fatalf("failed to decode json: %s", err)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	r.Header.Set("Accept", "text/csv")
+	w = httptest.NewRecorder()
+	PaginatedJSON(r, w, 1, 10, len(users), users)
+
+	if got, want := w.Header().Get("Content-Type"), "text/csv; charset=utf-8"; got != want {
+		t.Errorf("Want content type %q, got %q", want, got)
+	}
+	if got, want := w.Header().Get("x-total"), "2"; got != want {
+		t.Errorf("Want x-total header %q, got %q", want, got)
+	}
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read csv: %s", err)
+	}
+
+	wantHeader := []string{"uid", "email", "display_name", "admin", "blocked", "created", "updated",
+		"email_verified", "onboarded"}
+	if len(records) == 0 || !slices.Equal(records[0], wantHeader) {
+		t.Fatalf("Want csv header %v, got %v", wantHeader, records)
+	}
+
+	rows := records[1:]
+	if len(rows) != len(want) {
+		t.Fatalf("Want %d csv rows, got %d", len(want), len(rows))
+	}
+
+	for i, row := range rows {
+		if len(row) != len(want[i]) {
+			t.Errorf("Want %d cells in row %d, got %d", len(want[i]), i, len(row))
+		}
+		for j, column := range records[0] {
+			if got, want := row[j], jsonCell(want[i][column]); got != want {
+				t.Errorf("Want %q in row %d column %q, got %q", want, i, column, got)
+			}
+		}
+	}
+}
+
+func TestPaginatedJSON_CSVFormatParam(t *testing.T) {
+	type item struct {
+		Name   string            `json:"name"`
+		Labels []string          `json:"labels"`
+		Meta   map[string]string `json:"meta,omitempty"`
+		Parent *item             `json:"parent"`
+	}
+
+	list := []item{
+		{Name: "a", Labels: []string{"x", "y"}},
+		{Name: "b", Meta: map[string]string{"k": "v"}, Parent: &item{Name: "a"}},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/items?format=csv", nil)
+	w := httptest.NewRecorder()
+	PaginatedJSON(r, w, 1, 10, len(list), list)
+
+	want := "name,labels,parent,meta\n" +
+		"a,\"[\"\"x\"\",\"\"y\"\"]\",,\n" +
+		"b,,\"{\"\"name\"\":\"\"a\"\",\"\"labels\"\":null,\"\"parent\"\":null}\",\"{\"\"k\"\":\"\"v\"\"}\"\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("Want csv body %q, got %q", want, got)
+	}
+}
+
+func TestCSV_EscapesFormulas(t *testing.T) {
+	type item struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "plain", value: "repo", want: "repo"},
+		{name: "equals", value: "=HYPERLINK(\"http://evil\")", want: "'=HYPERLINK(\"http://evil\")"},
+		{name: "plus", value: "+1+1", want: "'+1+1"},
+		{name: "minus", value: "-1+1", want: "'-1+1"},
+		{name: "at", value: "@SUM(A1)", want: "'@SUM(A1)"},
+		{name: "tab", value: "\t=1", want: "'\t=1"},
+		{name: "carriage return", value: "\r=1", want: "'\r=1"},
+		{name: "not leading", value: "a=1", want: "a=1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			CSV(w, http.StatusOK, []item{{Name: test.value, Count: -1}})
+
+			records, err := csv.NewReader(w.Body).ReadAll()
+			if err != nil {
+				t.Fatalf("failed to read csv: %s", err)
+			}
+			if len(records) != 2 {
+				t.Fatalf("Want header and one row, got %v", records)
+			}
+			if got := records[1][0]; got != test.want {
+				t.Errorf("Want cell %q, got %q", test.want, got)
+			}
+			// numbers aren't user controlled text, they're kept as they are.
+			if got, want := records[1][1], "-1"; got != want {
+				t.Errorf("Want number cell %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestWantsCSV(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		accept string
+		want   bool
+	}{
+		{name: "default", target: "/items", want: false},
+		{name: "json", target: "/items", accept: "application/json", want: false},
+		{name: "accept", target: "/items", accept: "application/json;q=0.5, text/csv", want: true},
+		{name: "param", target: "/items?format=CSV", want: true},
+		{name: "param overrides accept", target: "/items?format=json", accept: "text/csv", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, test.target, nil)
+			if test.accept != "" {
+				r.Header.Set("Accept", test.accept)
+			}
+			if got := wantsCSV(r); got != test.want {
+				t.Errorf("Want %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+// jsonCell returns the expected csv cell of a decoded json value.
+func jsonCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(bytes.TrimSpace(data))
+	}
+}
@@ -83,6 +83,28 @@ func PaginationNoTotal(r *http.Request, w http.ResponseWriter, page int, size in
 	}
 }
 
+// PaginationCursor writes the cursor pagination and link headers to the http.Response.
+// An empty next cursor indicates that the last page was reached.
+func PaginationCursor(r *http.Request, w http.ResponseWriter, size int, next string) {
+	w.Header().Set("x-per-page", strconv.Itoa(size))
+
+	if next == "" {
+		return
+	}
+
+	uri := *r.URL
+	params := uri.Query()
+	params.Del("access_token")
+	params.Del("token")
+	params.Del("page")
+	params.Set("limit", strconv.Itoa(size))
+	params.Set("cursor", next)
+	uri.RawQuery = params.Encode()
+
+	w.Header().Set("x-next-cursor", next)
+	w.Header().Add("Link", fmt.Sprintf(linkf, uri.String(), "next"))
+}
+
 // PaginationLimit writes the x-total header.
 func PaginationLimit(_ *http.Request, w http.ResponseWriter, total int) {
 	w.Header().Set("x-total", strconv.Itoa(total))
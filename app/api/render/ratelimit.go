@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
+)
+
+// RateLimitHeaders writes the rate limit headers to the http.Response.
+func RateLimitHeaders(w http.ResponseWriter, limit int, remaining int, reset time.Time) {
+	w.Header().Set(HeaderRateLimitLimit, strconv.Itoa(limit))
+	w.Header().Set(HeaderRateLimitRemaining, strconv.Itoa(remaining))
+	w.Header().Set(HeaderRateLimitReset, strconv.FormatInt(reset.Unix(), 10))
+}
+
+// TooManyRequests writes the rate limit headers and the json-encoded message for a too many requests error.
+// All limiters are expected to use this method to ensure a uniform response.
+func TooManyRequests(ctx context.Context, w http.ResponseWriter, limit int, remaining int, reset time.Time) {
+	RateLimitHeaders(w, limit, remaining, reset)
+
+	retryAfter := int(time.Until(reset).Round(time.Second).Seconds())
+	w.Header().Set(headerRetryAfter, strconv.Itoa(max(retryAfter, 0)))
+
+	UserError(ctx, w, usererror.TooManyRequests(limit, remaining, reset))
+}
@@ -50,6 +50,42 @@ func JSON(w http.ResponseWriter, code int, v interface{}) {
 	writeJSON(w, v)
 }
 
+// queryParamEnvelope is the query parameter used by clients to request an enveloped list response.
+const queryParamEnvelope = "envelope"
+
+// envelope wraps a list response together with its pagination metadata.
+type envelope struct {
+	Data  any `json:"data"`
+	Page  int `json:"page"`
+	Size  int `json:"size"`
+	Total int `json:"total"`
+}
+
+// PaginatedJSON writes the pagination headers and the json-encoded list to the response.
+// If the request contains "envelope=true", the list is wrapped together with the pagination metadata
+// as {data, page, size, total} instead of being written as bare array.
+// If the client requested CSV (see wantsCSV), the list is written as CSV instead.
+func PaginatedJSON(r *http.Request, w http.ResponseWriter, page, size, total int, v any) {
+	Pagination(r, w, page, size, total)
+
+	if wantsCSV(r) {
+		CSV(w, http.StatusOK, v)
+		return
+	}
+
+	if wrap, _ := strconv.ParseBool(r.URL.Query().Get(queryParamEnvelope)); wrap {
+		JSON(w, http.StatusOK, &envelope{
+			Data:  v,
+			Page:  page,
+			Size:  size,
+			Total: total,
+		})
+		return
+	}
+
+	JSON(w, http.StatusOK, v)
+}
+
 // Reader reads the content from the provided reader and writes it as is to the response body.
 // NOTE: If no content-type header is added beforehand, the content-type will be deduced
 // automatically by `http.DetectContentType` (https://pkg.go.dev/net/http#DetectContentType).
@@ -17,7 +17,10 @@ package render
 import (
 	"context"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 
 	"github.com/rs/zerolog/log"
@@ -58,9 +61,25 @@ func InternalError(ctx context.Context, w http.ResponseWriter) {
 	UserError(ctx, w, usererror.ErrInternal)
 }
 
+// MaintenanceMode writes the Retry-After header and the json-encoded message for a write request
+// that got blocked by the maintenance mode.
+func MaintenanceMode(ctx context.Context, w http.ResponseWriter, retryAfter time.Duration) {
+	w.Header().Set(headerRetryAfter, strconv.Itoa(max(int(retryAfter.Round(time.Second).Seconds()), 0)))
+
+	UserError(ctx, w, usererror.ErrMaintenanceMode)
+}
+
 // UserError writes the json-encoded user error.
+// All user errors are written by it, ensuring the category and code consistently map to the status
+// and the request id is included to correlate the error with the logs.
 func UserError(ctx context.Context, w http.ResponseWriter, err *usererror.Error) {
-	log.Ctx(ctx).Debug().Err(err).Msgf("operation resulted in user facing error")
+	err = err.Classified()
+	if requestID, ok := request.RequestIDFrom(ctx); ok {
+		err = err.WithRequestID(requestID)
+	}
+
+	log.Ctx(ctx).Debug().Err(err).Str("error.category", string(err.Category)).
+		Msgf("operation resulted in user facing error")
 
 	JSON(w, err.Status, err)
 }
@@ -21,9 +21,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/check"
 )
 
 func TestWriteErrorf(t *testing.T) {
@@ -280,3 +285,113 @@ func TestJSONArrayDynamic(t *testing.T) {
 		})
 	}
 }
+
+func TestPaginatedJSON(t *testing.T) {
+	list := []string{"a", "b"}
+
+	// bare array by default
+	{
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/items?page=2&limit=2", nil)
+		w := httptest.NewRecorder()
+		PaginatedJSON(r, w, 2, 2, 5, list)
+		if got, want := w.Body.String(), "[\"a\",\"b\"]\n"; got != want {
+			t.Errorf("Want JSON body %q, got %q", want, got)
+		}
+		if got, want := w.Header().Get("x-total"), "5"; got != want {
+			t.Errorf("Want x-total header %q, got %q", want, got)
+		}
+	}
+	// envelope on request
+	{
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/items?page=2&limit=2&envelope=true", nil)
+		w := httptest.NewRecorder()
+		PaginatedJSON(r, w, 2, 2, 5, list)
+		if got, want := w.Body.String(), "{\"data\":[\"a\",\"b\"],\"page\":2,\"size\":2,\"total\":5}\n"; got != want {
+			t.Errorf("Want JSON body %q, got %q", want, got)
+		}
+		if got, want := w.Header().Get("x-total"), "5"; got != want {
+			t.Errorf("Want x-total header %q, got %q", want, got)
+		}
+		if got, want := w.Code, http.StatusOK; got != want {
+			t.Errorf("Want status code %d, got %d", want, got)
+		}
+	}
+}
+
+func TestUserError_Category(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantStatus   int
+		wantCategory usererror.Category
+		wantCode     string
+	}{
+		{name: "validation", err: check.NewValidationError("invalid identifier"),
+			wantStatus: http.StatusBadRequest, wantCategory: usererror.CategoryValidation, wantCode: "validation"},
+		{name: "unauthorized", err: apiauth.ErrNotAuthenticated,
+			wantStatus: http.StatusUnauthorized, wantCategory: usererror.CategoryUnauthorized, wantCode: "unauthorized"},
+		{name: "forbidden", err: apiauth.ErrNotAuthorized,
+			wantStatus: http.StatusForbidden, wantCategory: usererror.CategoryForbidden, wantCode: "forbidden"},
+		{name: "not found", err: store.ErrResourceNotFound,
+			wantStatus: http.StatusNotFound, wantCategory: usererror.CategoryNotFound, wantCode: "not_found"},
+		{name: "conflict", err: store.ErrDuplicate,
+			wantStatus: http.StatusConflict, wantCategory: usererror.CategoryConflict, wantCode: "conflict"},
+		{name: "internal", err: errors.New("unexpected"),
+			wantStatus: http.StatusInternalServerError, wantCategory: usererror.CategoryInternal, wantCode: "internal"},
+		{name: "custom code", err: usererror.TooManyRequests(10, 0, time.Now()),
+			wantStatus: http.StatusTooManyRequests, wantCategory: usererror.CategoryRateLimited,
+			wantCode: usererror.ErrorCodeRateLimitExceeded},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			TranslatedUserError(context.Background(), w, test.err)
+
+			if got := w.Code; got != test.wantStatus {
+				t.Errorf("Want response code %d, got %d", test.wantStatus, got)
+			}
+
+			errjson := &usererror.Error{}
+			if err := json.NewDecoder(w.Body).Decode(errjson); err != nil {
+				t.Fatal(err)
+			}
+			if errjson.Category != test.wantCategory {
+				t.Errorf("Want category %q, got %q", test.wantCategory, errjson.Category)
+			}
+			if errjson.Code != test.wantCode {
+				t.Errorf("Want code %q, got %q", test.wantCode, errjson.Code)
+			}
+		})
+	}
+}
+
+func TestUserError_RequestID(t *testing.T) {
+	ctx := request.WithRequestID(context.Background(), "req-1")
+
+	w := httptest.NewRecorder()
+	InternalError(ctx, w)
+
+	errjson := &usererror.Error{}
+	if err := json.NewDecoder(w.Body).Decode(errjson); err != nil {
+		t.Fatal(err)
+	}
+	if errjson.RequestID != "req-1" {
+		t.Errorf("Want request id %q, got %q", "req-1", errjson.RequestID)
+	}
+
+	// the shared error must not be modified.
+	if usererror.ErrInternal.RequestID != "" {
+		t.Errorf("Want shared error to have no request id, got %q", usererror.ErrInternal.RequestID)
+	}
+
+	w = httptest.NewRecorder()
+	InternalError(context.Background(), w)
+	var body map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := body["request_id"]; ok {
+		t.Errorf("Want no request id without one in the context, got %v", body["request_id"])
+	}
+}
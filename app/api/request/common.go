@@ -15,10 +15,17 @@
 package request
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -35,6 +42,7 @@ const (
 	QueryParamState = "state"
 	QueryParamKind  = "kind"
 	QueryParamType  = "type"
+	QueryParamRole  = "role"
 
 	QueryParamAfter  = "after"
 	QueryParamBefore = "before"
@@ -45,16 +53,25 @@ const (
 	QueryParamCreatedLt = "created_lt"
 	QueryParamCreatedGt = "created_gt"
 
-	QueryParamPage  = "page"
-	QueryParamLimit = "limit"
-	PerPageDefault  = 30
-	PerPageMax      = 100
+	QueryParamDryRun = "dry_run"
+
+	QueryParamPage   = "page"
+	QueryParamLimit  = "limit"
+	QueryParamCursor = "cursor"
+	PerPageDefault   = 30
+	PerPageMax       = 100
 
 	// TODO: have shared constants across all services?
 	HeaderRequestID       = "X-Request-Id"
 	HeaderUserAgent       = "User-Agent"
 	HeaderAuthorization   = "Authorization"
 	HeaderContentEncoding = "Content-Encoding"
+	HeaderIdempotencyKey  = "Idempotency-Key"
+	HeaderIfMatch         = "If-Match"
+	HeaderETag            = "ETag"
+
+	// maxIdempotencyKeyLength is the maximum length of an idempotency key provided by the client.
+	maxIdempotencyKeyLength = 255
 )
 
 // GetOptionalRemainderFromPath returns the remainder ("*") from the path or an empty string if it doesn't exist.
@@ -114,6 +131,21 @@ func ParsePaginationFromRequest(r *http.Request) types.Pagination {
 	}
 }
 
+// ParseCursor extracts the optional cursor parameter from the url.
+// An empty cursor parameter requests the first page using keyset pagination.
+func ParseCursor(r *http.Request) (types.Cursor, bool, error) {
+	if !r.URL.Query().Has(QueryParamCursor) {
+		return types.Cursor{}, false, nil
+	}
+
+	cursor, err := types.DecodeCursor(r.URL.Query().Get(QueryParamCursor))
+	if err != nil {
+		return types.Cursor{}, false, usererror.BadRequest("Invalid value provided for the cursor.")
+	}
+
+	return cursor, true, nil
+}
+
 // ParseListQueryFilterFromRequest parses pagination and query related info from the url.
 func ParseListQueryFilterFromRequest(r *http.Request) types.ListQueryFilter {
 	return types.ListQueryFilter{
@@ -136,6 +168,11 @@ func ParseCreated(r *http.Request) (types.CreatedFilter, error) {
 		return filter, fmt.Errorf("encountered error parsing created gt: %w", err)
 	}
 
+	if createdGt > 0 && createdLt > 0 && createdGt >= createdLt {
+		return filter, usererror.BadRequestf("Parameter '%s' must be less than parameter '%s'.",
+			QueryParamCreatedGt, QueryParamCreatedLt)
+	}
+
 	filter.CreatedGt = createdGt
 	filter.CreatedLt = createdLt
 
@@ -152,6 +189,63 @@ func ParseRecursiveFromQuery(r *http.Request) (bool, error) {
 	return QueryParamAsBoolOrDefault(r, QueryParamRecursive, false)
 }
 
+// ParseDryRunFromQuery extracts the dry run option from the URL query.
+func ParseDryRunFromQuery(r *http.Request) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamDryRun, false)
+}
+
+// GetIdempotencyRequest extracts the optional idempotency key from the request headers together with
+// the hash of the request (method, url and body), which is used to detect a reuse of the key for another request.
+// NOTE: The body is read completely and replaced, so it can still be decoded afterwards.
+func GetIdempotencyRequest(r *http.Request) (types.IdempotencyRequest, error) {
+	key := strings.TrimSpace(GetHeaderOrDefault(r, HeaderIdempotencyKey, ""))
+	if key == "" {
+		return types.IdempotencyRequest{}, nil
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return types.IdempotencyRequest{}, usererror.BadRequestf("Header '%s' can't be longer than %d characters.",
+			HeaderIdempotencyKey, maxIdempotencyKeyLength)
+	}
+
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return types.IdempotencyRequest{}, usererror.RequestTooLargef(
+				"The request body is too large, the maximum allowed size is %d bytes.", maxBytesErr.Limit)
+		}
+		if err != nil {
+			return types.IdempotencyRequest{}, fmt.Errorf("failed to read request body: %w", err)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + "\n" + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+
+	return types.IdempotencyRequest{
+		Key:  key,
+		Hash: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// GetIfMatchFromHeaders returns the entity tags of the If-Match header (empty if the header wasn't provided).
+func GetIfMatchFromHeaders(r *http.Request) []string {
+	var etags []string
+	for _, val := range r.Header.Values(HeaderIfMatch) {
+		for _, etag := range strings.Split(val, ",") {
+			if etag = strings.TrimSpace(etag); etag != "" {
+				etags = append(etags, etag)
+			}
+		}
+	}
+
+	return etags
+}
+
 // GetDeletedAtFromQueryOrError gets the exact resource deletion timestamp from the query.
 func GetDeletedAtFromQueryOrError(r *http.Request) (int64, error) {
 	return QueryParamAsPositiveInt64OrError(r, QueryParamDeletedAt)
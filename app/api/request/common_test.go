@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+func TestParseCreated(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantGt  int64
+		wantLt  int64
+		wantErr bool
+	}{
+		{
+			name:  "no range",
+			query: "",
+		},
+		{
+			name:   "bounded range",
+			query:  "created_gt=1000&created_lt=5000",
+			wantGt: 1000,
+			wantLt: 5000,
+		},
+		{
+			name:   "open ended range",
+			query:  "created_gt=1000",
+			wantGt: 1000,
+		},
+		{
+			name:    "inverted range",
+			query:   "created_gt=5000&created_lt=1000",
+			wantErr: true,
+		},
+		{
+			name:    "empty range",
+			query:   "created_gt=1000&created_lt=1000",
+			wantErr: true,
+		},
+		{
+			name:    "invalid timestamp",
+			query:   "created_lt=yesterday",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{URL: &url.URL{Path: "/admin/users", RawQuery: tt.query}}
+
+			filter, err := ParseCreated(r)
+			if tt.wantErr {
+				var uErr *usererror.Error
+				if !errors.As(err, &uErr) || uErr.Status != http.StatusBadRequest {
+					t.Errorf("Want bad request error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+
+			if filter.CreatedGt != tt.wantGt || filter.CreatedLt != tt.wantLt {
+				t.Errorf("Want range (%d, %d), got (%d, %d)",
+					tt.wantGt, tt.wantLt, filter.CreatedGt, filter.CreatedLt)
+			}
+		})
+	}
+}
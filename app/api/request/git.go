@@ -29,6 +29,7 @@ import (
 const (
 	QueryParamGitRef             = "git_ref"
 	QueryParamIncludeCommit      = "include_commit"
+	QueryParamIncludeDivergence  = "include_divergence"
 	QueryParamIncludeDirectories = "include_directories"
 	PathParamCommitSHA           = "commit_sha"
 	QueryParamLineFrom           = "line_from"
@@ -37,10 +38,14 @@ const (
 	QueryParamSince              = "since"
 	QueryParamUntil              = "until"
 	QueryParamCommitter          = "committer"
+	QueryParamAuthor             = "author"
+	QueryParamParent             = "parent"
 	QueryParamIncludeStats       = "include_stats"
 	QueryParamInternal           = "internal"
 	QueryParamService            = "service"
 	HeaderParamGitProtocol       = "Git-Protocol"
+	QueryParamArchiveFormat      = "format"
+	QueryParamRequireMerged      = "require_merged"
 )
 
 func GetGitRefFromQueryOrDefault(r *http.Request, deflt string) string {
@@ -51,14 +56,46 @@ func GetIncludeCommitFromQueryOrDefault(r *http.Request, deflt bool) (bool, erro
 	return QueryParamAsBoolOrDefault(r, QueryParamIncludeCommit, deflt)
 }
 
+func GetIncludeDivergenceFromQueryOrDefault(r *http.Request, deflt bool) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamIncludeDivergence, deflt)
+}
+
 func GetIncludeDirectoriesFromQueryOrDefault(r *http.Request, deflt bool) (bool, error) {
 	return QueryParamAsBoolOrDefault(r, QueryParamIncludeDirectories, deflt)
 }
 
+// ParseRequireMergedFromQuery extracts the require merged parameter from the url.
+func ParseRequireMergedFromQuery(r *http.Request) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamRequireMerged, false)
+}
+
 func GetCommitSHAFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamCommitSHA)
 }
 
+// ParseArchiveParams extracts the git ref and the archive format from the url.
+// The format is taken from the format query parameter if provided, otherwise from the extension of the path.
+func ParseArchiveParams(r *http.Request) (string, gittypes.ArchiveFormat, error) {
+	gitRef := GetOptionalRemainderFromPath(r)
+
+	if formatStr := r.URL.Query().Get(QueryParamArchiveFormat); formatStr != "" {
+		format, ok := gittypes.ParseArchiveFormat(formatStr)
+		if !ok {
+			return "", "", usererror.BadRequestf("Archive format %q is not supported.", formatStr)
+		}
+
+		return strings.TrimSuffix(gitRef, format.Extension()), format, nil
+	}
+
+	for _, format := range gittypes.ArchiveFormats {
+		if strings.HasSuffix(gitRef, format.Extension()) {
+			return strings.TrimSuffix(gitRef, format.Extension()), format, nil
+		}
+	}
+
+	return "", "", usererror.BadRequest("Archive format has to be provided via the url extension or the format parameter.")
+}
+
 // ParseSortBranch extracts the branch sort parameter from the url.
 func ParseSortBranch(r *http.Request) enum.BranchSortOption {
 	return enum.ParseBranchSortOption(
@@ -122,6 +159,7 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 		Since:        since,
 		Until:        until,
 		Committer:    QueryParamOrDefault(r, QueryParamCommitter, ""),
+		Author:       QueryParamOrDefault(r, QueryParamAuthor, ""),
 		IncludeStats: includeStats,
 	}, nil
 }
@@ -172,3 +210,7 @@ func GetFileDiffFromQuery(r *http.Request) (files gittypes.FileDiffRequests) {
 	}
 	return
 }
+
+func GetGitRefFromQuery(r *http.Request) (string, error) {
+	return QueryParamOrError(r, QueryParamGitRef)
+}
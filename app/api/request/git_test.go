@@ -15,12 +15,15 @@
 package request
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"reflect"
 	"testing"
 
 	"github.com/harness/gitness/git/api"
+
+	"github.com/go-chi/chi"
 )
 
 func TestGetFileDiffRequestsFromQuery(t *testing.T) {
@@ -143,3 +146,37 @@ func TestGetFileDiffRequestsFromQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestParseArchiveParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		remainder  string
+		query      string
+		wantRef    string
+		wantFormat api.ArchiveFormat
+		wantErr    bool
+	}{
+		{name: "zip extension", remainder: "main.zip", wantRef: "main", wantFormat: api.ArchiveFormatZip},
+		{name: "tar.gz extension", remainder: "feature/x.tar.gz", wantRef: "feature/x", wantFormat: api.ArchiveFormatTarGz},
+		{name: "query param", remainder: "v1.0", query: "format=zip", wantRef: "v1.0", wantFormat: api.ArchiveFormatZip},
+		{name: "default branch", remainder: "", query: "format=tar.gz", wantRef: "", wantFormat: api.ArchiveFormatTarGz},
+		{name: "unsupported format", remainder: "main", query: "format=rar", wantErr: true},
+		{name: "missing format", remainder: "main", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{URL: &url.URL{RawQuery: tt.query}}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add(PathParamRemainder, tt.remainder)
+			r = r.WithContext(context.WithValue(context.Background(), chi.RouteCtxKey, rctx))
+
+			gitRef, format, err := ParseArchiveParams(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Want error %t, got %v", tt.wantErr, err)
+			}
+			if gitRef != tt.wantRef || format != tt.wantFormat {
+				t.Errorf("Want %q and %q, got %q and %q", tt.wantRef, tt.wantFormat, gitRef, format)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ParseGlobalSearchTypes extracts the global search types from the url.
+func ParseGlobalSearchTypes(r *http.Request) []enum.GlobalSearchType {
+	typesRaw := r.URL.Query()[QueryParamType]
+	m := make(map[enum.GlobalSearchType]struct{}) // use map to eliminate duplicates
+	for _, typeRaw := range typesRaw {
+		if t, ok := enum.GlobalSearchType(typeRaw).Sanitize(); ok {
+			m[t] = struct{}{}
+		}
+	}
+
+	res := make([]enum.GlobalSearchType, 0, len(m))
+	for t := range m {
+		res = append(res, t)
+	}
+
+	return res
+}
+
+// ParseGlobalSearchFilter extracts the global search filter from the url.
+func ParseGlobalSearchFilter(r *http.Request) *types.GlobalSearchFilter {
+	return &types.GlobalSearchFilter{
+		Query: ParseQuery(r),
+		Types: ParseGlobalSearchTypes(r),
+		Page:  ParsePage(r),
+		Size:  ParseLimit(r),
+	}
+}
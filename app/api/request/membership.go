@@ -17,6 +17,7 @@ package request
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -28,13 +29,34 @@ func ParseMembershipUserSort(r *http.Request) enum.MembershipUserSort {
 	)
 }
 
+// ParseMembershipRole extracts the optional membership role parameter from the url.
+func ParseMembershipRole(r *http.Request) (enum.MembershipRole, error) {
+	s := r.URL.Query().Get(QueryParamRole)
+	if s == "" {
+		return "", nil
+	}
+
+	role, ok := enum.MembershipRole(s).Sanitize()
+	if !ok {
+		return "", usererror.BadRequestf("Invalid membership role %q.", s)
+	}
+
+	return role, nil
+}
+
 // ParseMembershipUserFilter extracts the membership filter from the url.
-func ParseMembershipUserFilter(r *http.Request) types.MembershipUserFilter {
+func ParseMembershipUserFilter(r *http.Request) (types.MembershipUserFilter, error) {
+	role, err := ParseMembershipRole(r)
+	if err != nil {
+		return types.MembershipUserFilter{}, err
+	}
+
 	return types.MembershipUserFilter{
 		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		Role:            role,
 		Sort:            ParseMembershipUserSort(r),
 		Order:           ParseOrder(r),
-	}
+	}, nil
 }
 
 // ParseMembershipSpaceSort extracts the membership space sort parameter from the url.
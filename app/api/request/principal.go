@@ -55,13 +55,19 @@ func ParseSortUser(r *http.Request) enum.UserAttr {
 }
 
 // ParseUserFilter extracts the user filter from the url.
-func ParseUserFilter(r *http.Request) *types.UserFilter {
-	return &types.UserFilter{
-		Order: ParseOrder(r),
-		Page:  ParsePage(r),
-		Sort:  ParseSortUser(r),
-		Size:  ParseLimit(r),
+func ParseUserFilter(r *http.Request) (*types.UserFilter, error) {
+	createdFilter, err := ParseCreated(r)
+	if err != nil {
+		return nil, err
 	}
+
+	return &types.UserFilter{
+		Order:         ParseOrder(r),
+		Page:          ParsePage(r),
+		Sort:          ParseSortUser(r),
+		Size:          ParseLimit(r),
+		CreatedFilter: createdFilter,
+	}, nil
 }
 
 // ParsePrincipalTypes extracts the principal types from the url.
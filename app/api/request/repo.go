@@ -17,14 +17,27 @@ package request
 import (
 	"net/http"
 	"net/url"
+	"strings"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
 
 const (
-	PathParamRepoRef = "repo_ref"
-	QueryParamRepoID = "repo_id"
+	PathParamRepoRef  = "repo_ref"
+	QueryParamRepoID  = "repo_id"
+	QueryParamRepoRef = "repo_ref"
+
+	QueryParamArchived  = "archived"
+	QueryParamTopic     = "topic"
+	QueryParamTopicMode = "topic_mode"
+
+	// TopicModeAll returns repositories tagged with all of the provided topics.
+	TopicModeAll = "all"
+	// TopicModeAny returns repositories tagged with any of the provided topics.
+	TopicModeAny = "any"
 )
 
 func GetRepoRefFromPath(r *http.Request) (string, error) {
@@ -44,6 +57,45 @@ func ParseSortRepo(r *http.Request) enum.RepoAttr {
 	)
 }
 
+// ParseArchivedFromQuery extracts the optional archived filter from the url.
+func ParseArchivedFromQuery(r *http.Request) (*bool, error) {
+	if _, ok := QueryParam(r, QueryParamArchived); !ok {
+		return nil, nil //nolint:nilnil // no filter requested
+	}
+
+	archived, err := QueryParamAsBoolOrDefault(r, QueryParamArchived, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &archived, nil
+}
+
+// ParseTopicsFromQuery extracts the optional topics filter and whether any of the topics has to match from the url.
+func ParseTopicsFromQuery(r *http.Request) ([]string, bool, error) {
+	var topics []string
+	for _, topic := range r.URL.Query()[QueryParamTopic] {
+		topic = strings.ToLower(strings.TrimSpace(topic))
+		if topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+
+	if err := check.RepoTopics(topics); err != nil {
+		return nil, false, err
+	}
+
+	switch mode := QueryParamOrDefault(r, QueryParamTopicMode, TopicModeAll); mode {
+	case TopicModeAll:
+		return topics, false, nil
+	case TopicModeAny:
+		return topics, true, nil
+	default:
+		return nil, false, usererror.BadRequestf("Parameter '%s' has to be either '%s' or '%s'.",
+			QueryParamTopicMode, TopicModeAll, TopicModeAny)
+	}
+}
+
 // ParseRepoFilter extracts the repository filter from the url.
 func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 	// recursive is optional to get all repos in a sapce and its subsapces recursively.
@@ -72,6 +124,28 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		deletedAt = &deletedAtVal
 	}
 
+	// archived is optional to filter repos by their archived state.
+	archived, err := ParseArchivedFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// topics are optional to filter repos by their topics.
+	topics, topicsMatchAny, err := ParseTopicsFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// cursor is optional to use keyset pagination instead of offset pagination.
+	var cursor *types.Cursor
+	cursorVal, ok, err := ParseCursor(r)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		cursor = &cursorVal
+	}
+
 	return &types.RepoFilter{
 		Query:             ParseQuery(r),
 		Order:             ParseOrder(r),
@@ -81,5 +155,9 @@ func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
 		Recursive:         recursive,
 		DeletedAt:         deletedAt,
 		DeletedBeforeOrAt: deletedBeforeOrAt,
+		Archived:          archived,
+		Topics:            topics,
+		TopicsMatchAny:    topicsMatchAny,
+		Cursor:            cursor,
 	}, nil
 }
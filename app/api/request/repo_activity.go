@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ParseRepoActivityFilter extracts the repository activity filter from the url.
+func ParseRepoActivityFilter(r *http.Request) (*types.RepoActivityFilter, error) {
+	created, err := ParseCreated(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.RepoActivityFilter{
+		Page:          ParsePage(r),
+		Size:          ParseLimit(r),
+		Types:         parseRepoActivityTypes(r),
+		CreatedFilter: created,
+	}, nil
+}
+
+// parseRepoActivityTypes extracts the repository activity types from the url.
+func parseRepoActivityTypes(r *http.Request) []enum.RepoActivityType {
+	strTypes, _ := QueryParamList(r, QueryParamType)
+	m := make(map[enum.RepoActivityType]struct{}) // use map to eliminate duplicates
+	for _, s := range strTypes {
+		if t, ok := enum.RepoActivityType(s).Sanitize(); ok {
+			m[t] = struct{}{}
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	activityTypes := make([]enum.RepoActivityType, 0, len(m))
+	for t := range m {
+		activityTypes = append(activityTypes, t)
+	}
+
+	return activityTypes
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseTopicsFromQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		wantTopics   []string
+		wantMatchAny bool
+		wantErr      bool
+	}{
+		{name: "no topics", query: "", wantTopics: nil},
+		{name: "single topic", query: "topic=Go", wantTopics: []string{"go"}},
+		{name: "multiple topics", query: "topic=go&topic=api", wantTopics: []string{"go", "api"}},
+		{name: "any mode", query: "topic=go&topic=api&topic_mode=any", wantTopics: []string{"go", "api"}, wantMatchAny: true},
+		{name: "invalid mode", query: "topic=go&topic_mode=some", wantErr: true},
+		{name: "invalid topic", query: "topic=go%25", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{URL: &url.URL{Path: "/repos", RawQuery: tt.query}}
+
+			topics, matchAny, err := ParseTopicsFromQuery(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTopicsFromQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(topics, tt.wantTopics) {
+				t.Errorf("ParseTopicsFromQuery() topics = %v, want %v", topics, tt.wantTopics)
+			}
+			if matchAny != tt.wantMatchAny {
+				t.Errorf("ParseTopicsFromQuery() matchAny = %v, want %v", matchAny, tt.wantMatchAny)
+			}
+		})
+	}
+}
@@ -20,7 +20,8 @@ import (
 )
 
 const (
-	PathParamSecretRef = "secret_ref"
+	PathParamSecretRef        = "secret_ref"
+	PathParamSecretIdentifier = "secret_identifier"
 )
 
 func GetSecretRefFromPath(r *http.Request) (string, error) {
@@ -32,3 +33,7 @@ func GetSecretRefFromPath(r *http.Request) (string, error) {
 	// paths are unescaped
 	return url.PathUnescape(rawRef)
 }
+
+func GetSecretIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamSecretIdentifier)
+}
@@ -23,7 +23,8 @@ import (
 )
 
 const (
-	PathParamSpaceRef = "space_ref"
+	PathParamSpaceRef  = "space_ref"
+	QueryParamSpaceRef = "space_ref"
 )
 
 func GetSpaceRefFromPath(r *http.Request) (string, error) {
@@ -20,8 +20,19 @@ import (
 
 const (
 	PathParamTokenIdentifier = "token_identifier"
+	PathParamTokenID         = "token_id"
+	QueryParamForce          = "force"
 )
 
 func GetTokenIdentifierFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamTokenIdentifier)
 }
+
+func GetTokenIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamTokenID)
+}
+
+// ParseForceFromQuery extracts the force parameter from the url.
+func ParseForceFromQuery(r *http.Request) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamForce, false)
+}
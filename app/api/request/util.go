@@ -15,8 +15,10 @@
 package request
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -25,6 +27,35 @@ import (
 	"github.com/go-chi/chi"
 )
 
+// ReadJSON decodes the json encoded request body into the provided object.
+// The returned error is a user facing error: a body exceeding the configured size limit results in
+// a request too large error, any other decoding failure in a bad request error.
+func ReadJSON(r *http.Request, in interface{}) error {
+	return readJSON(r, in, false)
+}
+
+// ReadOptionalJSON is the same as ReadJSON, but leaves the provided object untouched in case the body is empty.
+func ReadOptionalJSON(r *http.Request, in interface{}) error {
+	return readJSON(r, in, true)
+}
+
+func readJSON(r *http.Request, in interface{}, optional bool) error {
+	err := json.NewDecoder(r.Body).Decode(in)
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case err == nil:
+		return nil
+	case optional && errors.Is(err, io.EOF):
+		return nil
+	case errors.As(err, &maxBytesErr):
+		return usererror.RequestTooLargef("The request body is too large, the maximum allowed size is %d bytes.",
+			maxBytesErr.Limit)
+	default:
+		return usererror.BadRequestf("Invalid Request Body: %s.", err)
+	}
+}
+
 // GetCookie tries to retrieve the cookie from the request or returns false if it doesn't exist.
 func GetCookie(r *http.Request, cookieName string) (string, bool) {
 	cookie, err := r.Cookie(cookieName)
@@ -13,3 +13,52 @@
 // limitations under the License.
 
 package request
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+func TestReadJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		optional   bool
+		wantStatus int
+	}{
+		{name: "valid", body: `{"name":"gitness"}`},
+		{name: "malformed", body: `{"name":`, wantStatus: http.StatusBadRequest},
+		{name: "empty", body: ``, wantStatus: http.StatusBadRequest},
+		{name: "empty optional", body: ``, optional: true},
+		{name: "too large", body: `{"name":"` + strings.Repeat("a", 64) + `"}`, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			r.Body = http.MaxBytesReader(httptest.NewRecorder(), r.Body, 32)
+
+			in := map[string]string{}
+			var err error
+			if tt.optional {
+				err = ReadOptionalJSON(r, &in)
+			} else {
+				err = ReadJSON(r, &in)
+			}
+
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("Want no error, got %v", err)
+				}
+				return
+			}
+			if got := usererror.Translate(context.Background(), err).Status; got != tt.wantStatus {
+				t.Errorf("Want status %d, got %d (%v)", tt.wantStatus, got, err)
+			}
+		})
+	}
+}
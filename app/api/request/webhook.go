@@ -17,6 +17,7 @@ package request
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -24,6 +25,9 @@ import (
 const (
 	PathParamWebhookIdentifier  = "webhook_identifier"
 	PathParamWebhookExecutionID = "webhook_execution_id"
+
+	QueryParamEnabled = "enabled"
+	QueryParamTrigger = "trigger"
 )
 
 func GetWebhookIdentifierFromPath(r *http.Request) (string, error) {
@@ -35,14 +39,55 @@ func GetWebhookExecutionIDFromPath(r *http.Request) (int64, error) {
 }
 
 // ParseWebhookFilter extracts the Webhook query parameters for listing from the url.
-func ParseWebhookFilter(r *http.Request) *types.WebhookFilter {
+func ParseWebhookFilter(r *http.Request) (*types.WebhookFilter, error) {
+	enabled, err := ParseEnabledFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	trigger, err := ParseWebhookTriggerFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.WebhookFilter{
-		Query: ParseQuery(r),
-		Page:  ParsePage(r),
-		Size:  ParseLimit(r),
-		Sort:  ParseSortWebhook(r),
-		Order: ParseOrder(r),
+		Query:   ParseQuery(r),
+		Page:    ParsePage(r),
+		Size:    ParseLimit(r),
+		Sort:    ParseSortWebhook(r),
+		Order:   ParseOrder(r),
+		Enabled: enabled,
+		Trigger: trigger,
+	}, nil
+}
+
+// ParseEnabledFromQuery extracts the optional enabled filter from the url.
+func ParseEnabledFromQuery(r *http.Request) (*bool, error) {
+	if _, ok := QueryParam(r, QueryParamEnabled); !ok {
+		return nil, nil //nolint:nilnil // no filter requested
+	}
+
+	enabled, err := QueryParamAsBoolOrDefault(r, QueryParamEnabled, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &enabled, nil
+}
+
+// ParseWebhookTriggerFromQuery extracts the optional webhook trigger filter from the url.
+func ParseWebhookTriggerFromQuery(r *http.Request) (enum.WebhookTrigger, error) {
+	rawTrigger, ok := QueryParam(r, QueryParamTrigger)
+	if !ok || rawTrigger == "" {
+		return "", nil
+	}
+
+	trigger, ok := enum.ParseWebhookTrigger(rawTrigger)
+	if !ok {
+		return "", usererror.BadRequestf("Parameter '%s' must be a webhook trigger.", QueryParamTrigger)
 	}
+
+	return trigger, nil
 }
 
 // ParseWebhookExecutionFilter extracts the WebhookExecution query parameters for listing from the url.
@@ -0,0 +1,66 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usererror
+
+import "net/http"
+
+// Category is the machine-readable category of a user facing error,
+// allowing clients to handle errors programmatically.
+type Category string
+
+const (
+	// CategoryValidation is used for errors caused by invalid input.
+	CategoryValidation Category = "validation"
+	// CategoryUnauthorized is used for errors caused by missing or invalid authentication.
+	CategoryUnauthorized Category = "unauthorized"
+	// CategoryForbidden is used for errors caused by the principal lacking the required permissions.
+	CategoryForbidden Category = "forbidden"
+	// CategoryNotFound is used for errors caused by resources that don't exist or aren't visible.
+	CategoryNotFound Category = "not_found"
+	// CategoryConflict is used for errors caused by the current state of a resource (e.g. duplicates or locks).
+	CategoryConflict Category = "conflict"
+	// CategoryRateLimited is used for errors caused by exceeding a rate limit.
+	CategoryRateLimited Category = "rate_limited"
+	// CategoryUnavailable is used for errors caused by the system being temporarily unavailable.
+	CategoryUnavailable Category = "unavailable"
+	// CategoryInternal is used for errors caused by internal failures.
+	CategoryInternal Category = "internal"
+)
+
+// CategoryFromStatus returns the category of errors with the provided http status code.
+// It's the single mapping between status codes and categories, client errors without
+// a dedicated category are considered validation errors.
+func CategoryFromStatus(status int) Category {
+	switch status {
+	case http.StatusUnauthorized:
+		return CategoryUnauthorized
+	case http.StatusForbidden:
+		return CategoryForbidden
+	case http.StatusNotFound:
+		return CategoryNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed, http.StatusLocked:
+		return CategoryConflict
+	case http.StatusTooManyRequests:
+		return CategoryRateLimited
+	case http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return CategoryUnavailable
+	}
+
+	if status >= http.StatusBadRequest && status < http.StatusInternalServerError {
+		return CategoryValidation
+	}
+
+	return CategoryInternal
+}
@@ -51,9 +51,15 @@ func Translate(ctx context.Context, err error) *Error {
 	case errors.As(err, &rError):
 		return rError
 
+	// request deadline errors
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrRequestTimeout
+
 	// api auth errors
 	case errors.Is(err, apiauth.ErrNotAuthenticated):
 		return ErrUnauthorized
+	case errors.Is(err, apiauth.ErrNotVisible):
+		return ErrNotFound
 	case errors.Is(err, apiauth.ErrNotAuthorized):
 		return ErrForbidden
 
@@ -17,6 +17,7 @@ package usererror
 import (
 	"fmt"
 	"net/http"
+	"time"
 )
 
 var (
@@ -35,12 +36,26 @@ var (
 	// ErrForbidden is returned when the acting principal is not authorized.
 	ErrForbidden = New(http.StatusForbidden, "Forbidden")
 
+	// ErrMaintenanceMode is returned for write requests while the system is in maintenance mode.
+	ErrMaintenanceMode = &Error{
+		Status:  http.StatusServiceUnavailable,
+		Code:    ErrorCodeMaintenanceMode,
+		Message: "The system is in maintenance mode, write operations are temporarily unavailable",
+	}
+
 	// ErrNotFound is returned when a resource is not found.
 	ErrNotFound = New(http.StatusNotFound, "Not Found")
 
 	// ErrPreconditionFailed is returned when a precondition failed.
 	ErrPreconditionFailed = New(http.StatusPreconditionFailed, "Precondition failed")
 
+	// ErrResourceModified is returned when the resource was modified since the client retrieved it.
+	ErrResourceModified = New(http.StatusPreconditionFailed,
+		"The resource has been modified since it was retrieved, reload it and try again")
+
+	// ErrIfMatchRequired is returned when an update is missing the required If-Match header.
+	ErrIfMatchRequired = New(http.StatusPreconditionRequired, "The If-Match header is required")
+
 	// ErrNotMergeable is returned when a branch can't be merged.
 	ErrNotMergeable = New(http.StatusPreconditionFailed, "Branch can't be merged")
 
@@ -67,12 +82,25 @@ var (
 	// ErrDefaultBranchCantBeDeleted is returned if the user tries to delete the default branch of a repository.
 	ErrDefaultBranchCantBeDeleted = New(http.StatusBadRequest, "The default branch of a repository can't be deleted")
 
+	// ErrBranchNotMerged is returned if the user tries to delete a branch that isn't merged into the default branch.
+	ErrBranchNotMerged = New(http.StatusBadRequest,
+		"The branch isn't merged into the default branch, use force to delete it anyway")
+
+	// ErrRepositoryArchived is returned if a user tries to modify an archived repository.
+	ErrRepositoryArchived = New(http.StatusForbidden, "The repository is archived and can't be modified")
+
+	// ErrRepositoryMirror is returned if a user tries to push to a mirrored repository.
+	ErrRepositoryMirror = New(http.StatusForbidden, "The repository is a mirror and can only be updated by syncing")
+
 	// ErrPullReqRefsCantBeModified is returned if a user tries to tinker with a pull request git ref.
 	ErrPullReqRefsCantBeModified = New(http.StatusBadRequest, "The pull request git refs can't be modified")
 
 	// ErrRequestTooLarge is returned if the request it too large.
 	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "The request is too large")
 
+	// ErrRequestTimeout is returned if the request didn't complete before its deadline.
+	ErrRequestTimeout = New(http.StatusGatewayTimeout, "The request timed out")
+
 	// ErrWebhookNotRetriggerable is returned if the webhook can't be retriggered.
 	ErrWebhookNotRetriggerable = New(http.StatusMethodNotAllowed,
 		"The webhook execution is incomplete and can't be retriggered")
@@ -94,17 +122,57 @@ var (
 		"Pushing to an empty repository requires at least one branch with commits.")
 )
 
+// ErrorCodeRateLimitExceeded is the code returned in the payload of rate limited requests.
+const ErrorCodeRateLimitExceeded = "rate_limit_exceeded"
+
+// ErrorCodeAccountLocked is the code returned in the payload of logins to a locked account.
+const ErrorCodeAccountLocked = "account_locked"
+
+// ErrorCodeMaintenanceMode is the code returned in the payload of write requests blocked by the maintenance mode.
+const ErrorCodeMaintenanceMode = "maintenance_mode"
+
 // Error represents a json-encoded API error.
 type Error struct {
-	Status  int            `json:"-"`
-	Message string         `json:"message"`
-	Values  map[string]any `json:"values,omitempty"`
+	Status int `json:"-"`
+	// Code is the machine-readable code of the error, it defaults to the category.
+	Code     string         `json:"code,omitempty"`
+	Category Category       `json:"category,omitempty"`
+	Message  string         `json:"message"`
+	Values   map[string]any `json:"values,omitempty"`
+	// RequestID is the id of the request that resulted in the error, allowing to correlate it with the logs.
+	RequestID string `json:"request_id,omitempty"`
 }
 
 func (e *Error) Error() string {
 	return e.Message
 }
 
+// Classified returns the error with the category derived from its status and the code defaulting
+// to the category. The error is copied if any of them isn't set, as errors are shared.
+func (e *Error) Classified() *Error {
+	if e.Category != "" && e.Code != "" {
+		return e
+	}
+
+	classified := *e
+	if classified.Category == "" {
+		classified.Category = CategoryFromStatus(e.Status)
+	}
+	if classified.Code == "" {
+		classified.Code = string(classified.Category)
+	}
+
+	return &classified
+}
+
+// WithRequestID returns a copy of the error with the request id set, as errors are shared.
+func (e *Error) WithRequestID(requestID string) *Error {
+	withID := *e
+	withID.RequestID = requestID
+
+	return &withID
+}
+
 // New returns a new user facing error.
 func New(status int, message string) *Error {
 	return &Error{Status: status, Message: message}
@@ -170,7 +238,31 @@ func ConflictWithPayload(message string, values ...map[string]any) *Error {
 	return NewWithPayload(http.StatusConflict, message, values...)
 }
 
+// TooManyRequests returns a new user facing too many requests error.
+// The payload contains the state of the limiter that rejected the request.
+func TooManyRequests(limit int, remaining int, reset time.Time) *Error {
+	err := NewWithPayload(http.StatusTooManyRequests, "Too many requests", map[string]any{
+		"code":      ErrorCodeRateLimitExceeded,
+		"limit":     limit,
+		"remaining": remaining,
+		"reset":     reset.Unix(),
+	})
+	err.Code = ErrorCodeRateLimitExceeded
+	return err
+}
+
 // Conflict returns a new user facing conflict error.
 func Conflict(message string) *Error {
 	return NewWithPayload(http.StatusConflict, message)
 }
+
+// AccountLocked returns a new user facing error for logins to an account that's locked until the provided time.
+func AccountLocked(lockedUntil time.Time) *Error {
+	err := NewWithPayload(http.StatusLocked,
+		"The account is temporarily locked due to too many failed login attempts.", map[string]any{
+			"code":         ErrorCodeAccountLocked,
+			"locked_until": lockedUntil.UnixMilli(),
+		})
+	err.Code = ErrorCodeAccountLocked
+	return err
+}
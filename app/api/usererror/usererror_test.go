@@ -14,7 +14,13 @@
 
 package usererror
 
-import "testing"
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+)
 
 func TestError(t *testing.T) {
 	got, want := ErrNotFound.Message, ErrNotFound.Message
@@ -22,3 +28,45 @@ func TestError(t *testing.T) {
 		t.Errorf("Want error string %q, got %q", got, want)
 	}
 }
+
+func TestTranslate_NotVisible(t *testing.T) {
+	if got := Translate(context.Background(), apiauth.ErrNotVisible); got.Status != http.StatusNotFound {
+		t.Errorf("Want status %d for a resource that isn't visible, got %d", http.StatusNotFound, got.Status)
+	}
+	if got := Translate(context.Background(), apiauth.ErrNotAuthorized); got.Status != http.StatusForbidden {
+		t.Errorf("Want status %d for a resource that is visible, got %d", http.StatusForbidden, got.Status)
+	}
+}
+
+func TestClassified_DoesNotModifySharedErrors(t *testing.T) {
+	classified := ErrNotFound.Classified()
+
+	if classified.Category != CategoryNotFound || classified.Code != string(CategoryNotFound) {
+		t.Errorf("Want category and code %q, got %q and %q", CategoryNotFound, classified.Category, classified.Code)
+	}
+	if ErrNotFound.Category != "" || ErrNotFound.Code != "" {
+		t.Errorf("Want shared error to stay unclassified, got %q and %q", ErrNotFound.Category, ErrNotFound.Code)
+	}
+}
+
+func TestCategoryFromStatus(t *testing.T) {
+	tests := map[int]Category{
+		http.StatusBadRequest:          CategoryValidation,
+		http.StatusUnprocessableEntity: CategoryValidation,
+		http.StatusUnauthorized:        CategoryUnauthorized,
+		http.StatusForbidden:           CategoryForbidden,
+		http.StatusNotFound:            CategoryNotFound,
+		http.StatusConflict:            CategoryConflict,
+		http.StatusLocked:              CategoryConflict,
+		http.StatusTooManyRequests:     CategoryRateLimited,
+		http.StatusServiceUnavailable:  CategoryUnavailable,
+		http.StatusInternalServerError: CategoryInternal,
+		http.StatusNotImplemented:      CategoryInternal,
+	}
+
+	for status, want := range tests {
+		if got := CategoryFromStatus(status); got != want {
+			t.Errorf("Want category %q for status %d, got %q", want, status, got)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ErrInvalidDeployKey is returned if the key isn't a deploy key (anymore) or its principal is blocked.
+var ErrInvalidDeployKey = errors.New("invalid deploy key")
+
+// DeployKeyAuthenticator authenticates git ssh connections that use a deploy key.
+// The resulting session acts on behalf of the principal that added the key,
+// but is restricted to the repository of the key (and the principal's access to it) by the DeployKeyMetadata.
+type DeployKeyAuthenticator struct {
+	deployKeyStore store.DeployKeyStore
+	principalStore store.PrincipalStore
+}
+
+func NewDeployKeyAuthenticator(
+	deployKeyStore store.DeployKeyStore,
+	principalStore store.PrincipalStore,
+) *DeployKeyAuthenticator {
+	return &DeployKeyAuthenticator{
+		deployKeyStore: deployKeyStore,
+		principalStore: principalStore,
+	}
+}
+
+// Authenticate returns the session for the provided ssh public key.
+// Revoked (deleted) deploy keys are no longer found and fail authentication.
+func (a *DeployKeyAuthenticator) Authenticate(ctx context.Context, key ssh.PublicKey) (*auth.Session, error) {
+	deployKey, err := a.deployKeyStore.FindByFingerprint(ctx, ssh.FingerprintSHA256(key))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrInvalidDeployKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find deploy key: %w", err)
+	}
+
+	principal, err := a.principalStore.Find(ctx, deployKey.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal of deploy key: %w", err)
+	}
+
+	if principal.Blocked {
+		return nil, ErrInvalidDeployKey
+	}
+
+	return &auth.Session{
+		Principal: *principal,
+		Metadata: &auth.DeployKeyMetadata{
+			DeployKeyID: deployKey.ID,
+			RepoID:      deployKey.RepoID,
+			Write:       deployKey.Write,
+		},
+	}, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// deployKeyStoreFake is an in-memory deploy key store.
+type deployKeyStoreFake struct {
+	store.DeployKeyStore
+	keys []*types.DeployKey
+}
+
+func (s *deployKeyStoreFake) FindByFingerprint(_ context.Context, fingerprint string) (*types.DeployKey, error) {
+	for _, k := range s.keys {
+		if k.Fingerprint == fingerprint {
+			return k, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func generateSSHKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to convert key: %v", err)
+	}
+
+	return key
+}
+
+func TestDeployKeyAuthenticator(t *testing.T) {
+	key := generateSSHKey(t)
+	deployKeyStore := &deployKeyStoreFake{keys: []*types.DeployKey{
+		{ID: 3, RepoID: 2, Fingerprint: ssh.FingerprintSHA256(key), CreatedBy: 1},
+	}}
+	authenticator := NewDeployKeyAuthenticator(deployKeyStore,
+		&principalStoreFake{user: &types.User{ID: 1, UID: "user"}})
+
+	session, err := authenticator.Authenticate(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Want deploy key to authenticate, got %v", err)
+	}
+	if got, want := session.Principal.ID, int64(1); got != want {
+		t.Errorf("Want principal %d, got %d", want, got)
+	}
+
+	metadata, ok := session.Metadata.(*auth.DeployKeyMetadata)
+	if !ok {
+		t.Fatalf("Want deploy key metadata, got %T", session.Metadata)
+	}
+	if metadata.RepoID != 2 || metadata.DeployKeyID != 3 || metadata.Write {
+		t.Errorf("Want read-only metadata for deploy key 3 of repo 2, got %+v", metadata)
+	}
+
+	// revoked keys no longer authenticate.
+	deployKeyStore.keys = nil
+	if _, err = authenticator.Authenticate(context.Background(), key); !errors.Is(err, ErrInvalidDeployKey) {
+		t.Errorf("Want revoked deploy key to fail authentication")
+	}
+}
+
+func TestDeployKeyAuthenticator_UnknownKey(t *testing.T) {
+	authenticator := NewDeployKeyAuthenticator(&deployKeyStoreFake{},
+		&principalStoreFake{user: &types.User{ID: 1, UID: "user"}})
+
+	if _, err := authenticator.Authenticate(context.Background(), generateSSHKey(t)); !errors.Is(err, ErrInvalidDeployKey) {
+		t.Errorf("Want unknown key to fail authentication")
+	}
+}
@@ -16,38 +16,46 @@ package authn
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
-	gojwt "github.com/golang-jwt/jwt"
+	"github.com/rs/zerolog/log"
 )
 
 var _ Authenticator = (*JWTAuthenticator)(nil)
 
+// tokenLastUsedUpdateInterval limits how often the last used time of a token is updated.
+const tokenLastUsedUpdateInterval = time.Minute
+
 // JWTAuthenticator uses the provided JWT to authenticate the caller.
 type JWTAuthenticator struct {
 	cookieName     string
 	principalStore store.PrincipalStore
 	tokenStore     store.TokenStore
+	signingKeys    *jwt.KeySet
 }
 
 func NewTokenAuthenticator(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	signingKeys *jwt.KeySet,
 	cookieName string,
 ) *JWTAuthenticator {
 	return &JWTAuthenticator{
 		cookieName:     cookieName,
 		principalStore: principalStore,
 		tokenStore:     tokenStore,
+		signingKeys:    signingKeys,
 	}
 }
 
@@ -60,34 +68,42 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 	}
 
 	var principal *types.Principal
-	var err error
 	claims := &jwt.Claims{}
-	parsed, err := gojwt.ParseWithClaims(str, claims, func(_ *gojwt.Token) (interface{}, error) {
+	err := jwt.Parse(str, claims, a.signingKeys, func(claims *jwt.Claims) (string, error) {
+		var err error
 		principal, err = a.principalStore.Find(ctx, claims.PrincipalID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get principal for token: %w", err)
+			return "", fmt.Errorf("failed to get principal for token: %w", err)
 		}
-		return []byte(principal.Salt), nil
+		return principal.Salt, nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("parsing of JWT claims failed: %w", err)
-	}
-
-	if !parsed.Valid {
-		return nil, errors.New("parsed JWT token is invalid")
+		return nil, err
 	}
 
-	if _, ok := parsed.Method.(*gojwt.SigningMethodHMAC); !ok {
-		return nil, errors.New("invalid HMAC signature for JWT")
+	// tokens signed with a key of the key set don't require the secret of the principal.
+	if principal == nil {
+		principal, err = a.principalStore.Find(ctx, claims.PrincipalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get principal for token: %w", err)
+		}
 	}
 
 	var metadata auth.Metadata
+	var impersonator *types.Principal
 	switch {
 	case claims.Token != nil:
-		metadata, err = a.metadataFromTokenClaims(ctx, principal, claims.Token)
+		var tkn *types.Token
+		metadata, tkn, err = a.metadataFromTokenClaims(ctx, principal, claims.Token)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get metadata from token claims: %w", err)
 		}
+		if tkn.Type == enum.TokenTypeImpersonation {
+			impersonator, err = a.findImpersonator(ctx, tkn, claims.Token)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get impersonator from token claims: %w", err)
+			}
+		}
 	case claims.Membership != nil:
 		metadata = a.metadataFromMembershipClaims(claims.Membership)
 	default:
@@ -95,8 +111,9 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 	}
 
 	return &auth.Session{
-		Principal: *principal,
-		Metadata:  metadata,
+		Principal:    *principal,
+		Metadata:     metadata,
+		Impersonator: impersonator,
 	}, nil
 }
 
@@ -104,23 +121,69 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 	ctx context.Context,
 	principal *types.Principal,
 	tknClaims *jwt.SubClaimsToken,
-) (auth.Metadata, error) {
+) (auth.Metadata, *types.Token, error) {
 	// ensure tkn exists
 	tkn, err := a.tokenStore.Find(ctx, tknClaims.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find token in db: %w", err)
+		return nil, nil, fmt.Errorf("failed to find token in db: %w", err)
+	}
+
+	if err = token.Validate(tkn, principal); err != nil {
+		return nil, nil, err
 	}
 
-	// protect against faked JWTs for other principals in case of single salt leak
-	if principal.ID != tkn.PrincipalID {
-		return nil, fmt.Errorf("JWT was for principal %d while db token was for principal %d",
-			principal.ID, tkn.PrincipalID)
+	// password reset tokens are only valid for resetting the password.
+	if tkn.Type == enum.TokenTypePasswordReset {
+		return nil, nil, fmt.Errorf("token %d of type %s can't be used for authentication", tkn.ID, tkn.Type)
 	}
 
+	a.updateLastUsed(ctx, tkn)
+
 	return &auth.TokenMetadata{
 		TokenType: tkn.Type,
 		TokenID:   tkn.ID,
-	}, nil
+		Scopes:    tkn.Scopes,
+	}, tkn, nil
+}
+
+// findImpersonator returns the admin that created the impersonation token.
+// The impersonation is only valid as long as the impersonator is still an admin.
+func (a *JWTAuthenticator) findImpersonator(
+	ctx context.Context,
+	tkn *types.Token,
+	tknClaims *jwt.SubClaimsToken,
+) (*types.Principal, error) {
+	if tknClaims.ImpersonatorID != tkn.CreatedBy {
+		return nil, fmt.Errorf("JWT was impersonated by principal %d while db token was created by principal %d",
+			tknClaims.ImpersonatorID, tkn.CreatedBy)
+	}
+
+	impersonator, err := a.principalStore.Find(ctx, tkn.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find impersonator: %w", err)
+	}
+
+	if !impersonator.Admin || impersonator.Blocked {
+		return nil, fmt.Errorf("impersonator %d of token %d is no longer an active admin", impersonator.ID, tkn.ID)
+	}
+
+	return impersonator, nil
+}
+
+// updateLastUsed updates the last used time of the token (best effort).
+// To avoid a db write per request, the time is only updated if it's older than tokenLastUsedUpdateInterval.
+func (a *JWTAuthenticator) updateLastUsed(ctx context.Context, tkn *types.Token) {
+	now := time.Now().UnixMilli()
+	if tkn.LastUsed != nil && now-*tkn.LastUsed < tokenLastUsedUpdateInterval.Milliseconds() {
+		return
+	}
+
+	if err := a.tokenStore.UpdateLastUsed(ctx, tkn.ID, now); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to update last used time of token %d", tkn.ID)
+		return
+	}
+
+	tkn.LastUsed = &now
 }
 
 func (a *JWTAuthenticator) metadataFromMembershipClaims(
@@ -0,0 +1,276 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// principalStoreFake is an in-memory principal store holding a single user and an optional admin.
+type principalStoreFake struct {
+	store.PrincipalStore
+	user  *types.User
+	admin *types.User
+}
+
+func (s *principalStoreFake) Find(_ context.Context, id int64) (*types.Principal, error) {
+	if s.admin != nil && id == s.admin.ID {
+		return s.admin.ToPrincipal(), nil
+	}
+	if id != s.user.ID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return s.user.ToPrincipal(), nil
+}
+
+func (s *principalStoreFake) IncrementTokenGeneration(_ context.Context, id int64) (int64, error) {
+	if id != s.user.ID {
+		return 0, gitness_store.ErrResourceNotFound
+	}
+	s.user.TokenGeneration++
+	return s.user.TokenGeneration, nil
+}
+
+// tokenStoreFake is an in-memory token store.
+type tokenStoreFake struct {
+	store.TokenStore
+	tokens []*types.Token
+
+	lastUsedUpdates int
+}
+
+func (s *tokenStoreFake) Create(_ context.Context, token *types.Token) error {
+	token.ID = int64(len(s.tokens) + 1)
+	s.tokens = append(s.tokens, token)
+	return nil
+}
+
+func (s *tokenStoreFake) Find(_ context.Context, id int64) (*types.Token, error) {
+	for _, t := range s.tokens {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *tokenStoreFake) UpdateLastUsed(_ context.Context, id int64, lastUsed int64) error {
+	for _, t := range s.tokens {
+		if t.ID == id {
+			s.lastUsedUpdates++
+			t.LastUsed = &lastUsed
+			return nil
+		}
+	}
+	return gitness_store.ErrResourceNotFound
+}
+
+// setupTokenAuthenticator returns an authenticator that verifies the tokens of the returned issuer.
+func setupTokenAuthenticator(
+	principalStore store.PrincipalStore,
+	tokenStore store.TokenStore,
+) (*JWTAuthenticator, *token.Issuer) {
+	keySet := jwt.NewKeySet(0)
+	return NewTokenAuthenticator(principalStore, tokenStore, keySet, "token"),
+		token.NewIssuer(tokenStore, keySet, jwt.GenerateForToken)
+}
+
+func authenticateWithToken(t *testing.T, authenticator *JWTAuthenticator, jwt string) error {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/user", nil)
+	r.Header.Set(request.HeaderAuthorization, "Bearer "+jwt)
+
+	_, err := authenticator.Authenticate(r)
+	return err
+}
+
+func TestAuthenticate_RevokedTokenGeneration(t *testing.T) {
+	ctx := context.Background()
+	principalStore := &principalStoreFake{user: &types.User{ID: 1, UID: "user", Salt: "user-salt"}}
+	tokenStore := &tokenStoreFake{}
+	authenticator, issuer := setupTokenAuthenticator(principalStore, tokenStore)
+
+	_, oldJWT, err := issuer.CreateUserSession(ctx, principalStore.user, "old-session", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	if err = authenticateWithToken(t, authenticator, oldJWT); err != nil {
+		t.Fatalf("Want old session to be valid before revocation, got %v", err)
+	}
+
+	if _, err = principalStore.IncrementTokenGeneration(ctx, principalStore.user.ID); err != nil {
+		t.Fatalf("failed to increment token generation: %v", err)
+	}
+
+	if err = authenticateWithToken(t, authenticator, oldJWT); err == nil {
+		t.Errorf("Want old session to be rejected after revocation, got no error")
+	}
+
+	_, newJWT, err := issuer.CreateUserSession(ctx, principalStore.user, "new-session", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	if err = authenticateWithToken(t, authenticator, newJWT); err != nil {
+		t.Errorf("Want new session to be valid after revocation, got %v", err)
+	}
+}
+
+func TestAuthenticate_ExpiredToken(t *testing.T) {
+	ctx := context.Background()
+	principalStore := &principalStoreFake{user: &types.User{ID: 1, UID: "user", Salt: "user-salt"}}
+	tokenStore := &tokenStoreFake{}
+	authenticator, issuer := setupTokenAuthenticator(principalStore, tokenStore)
+
+	session, sessionJWT, err := issuer.CreateUserSession(ctx, principalStore.user, "session", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	lifetime := time.Hour
+	pat, patJWT, err := issuer.CreatePAT(ctx, principalStore.user.ToPrincipal(), principalStore.user,
+		"pat", &lifetime, nil)
+	if err != nil {
+		t.Fatalf("failed to create pat: %v", err)
+	}
+
+	for _, test := range []struct {
+		tkn *types.Token
+		jwt string
+	}{{session, sessionJWT}, {pat, patJWT}} {
+		if err = authenticateWithToken(t, authenticator, test.jwt); err != nil {
+			t.Fatalf("Want %s token to be valid before expiry, got %v", test.tkn.Type, err)
+		}
+
+		expired := time.Now().Add(-time.Minute).UnixMilli()
+		test.tkn.ExpiresAt = &expired
+
+		if err = authenticateWithToken(t, authenticator, test.jwt); err == nil {
+			t.Errorf("Want %s token to be rejected after expiry, got no error", test.tkn.Type)
+		}
+	}
+}
+
+func TestAuthenticate_BlockedUser(t *testing.T) {
+	ctx := context.Background()
+	principalStore := &principalStoreFake{user: &types.User{ID: 1, UID: "user", Salt: "user-salt"}}
+	tokenStore := &tokenStoreFake{}
+	authenticator, issuer := setupTokenAuthenticator(principalStore, tokenStore)
+
+	_, sessionJWT, err := issuer.CreateUserSession(ctx, principalStore.user, "session", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	principalStore.user.Blocked = true
+
+	if err = authenticateWithToken(t, authenticator, sessionJWT); err == nil {
+		t.Errorf("Want session of blocked user to be rejected, got no error")
+	}
+}
+
+func TestAuthenticate_UpdatesLastUsed(t *testing.T) {
+	ctx := context.Background()
+	principalStore := &principalStoreFake{user: &types.User{ID: 1, UID: "user", Salt: "user-salt"}}
+	tokenStore := &tokenStoreFake{}
+	authenticator, issuer := setupTokenAuthenticator(principalStore, tokenStore)
+
+	session, sessionJWT, err := issuer.CreateUserSession(ctx, principalStore.user, "session", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	if session.LastUsed != nil {
+		t.Fatalf("Want new token to be unused, got last used %d", *session.LastUsed)
+	}
+
+	if err = authenticateWithToken(t, authenticator, sessionJWT); err != nil {
+		t.Fatalf("Want session to be valid, got %v", err)
+	}
+
+	if session.LastUsed == nil {
+		t.Fatalf("Want last used to be set after authentication")
+	}
+
+	// subsequent requests within the update interval don't update the token again.
+	if err = authenticateWithToken(t, authenticator, sessionJWT); err != nil {
+		t.Fatalf("Want session to be valid, got %v", err)
+	}
+
+	if tokenStore.lastUsedUpdates != 1 {
+		t.Errorf("Want 1 last used update, got %d", tokenStore.lastUsedUpdates)
+	}
+
+	stale := time.Now().Add(-2 * tokenLastUsedUpdateInterval).UnixMilli()
+	session.LastUsed = &stale
+
+	if err = authenticateWithToken(t, authenticator, sessionJWT); err != nil {
+		t.Fatalf("Want session to be valid, got %v", err)
+	}
+
+	if tokenStore.lastUsedUpdates != 2 || *session.LastUsed == stale {
+		t.Errorf("Want stale last used to be updated, got %d updates", tokenStore.lastUsedUpdates)
+	}
+}
+
+func TestAuthenticate_Impersonation(t *testing.T) {
+	ctx := context.Background()
+	principalStore := &principalStoreFake{
+		user:  &types.User{ID: 1, UID: "user", Salt: "user-salt"},
+		admin: &types.User{ID: 2, UID: "admin", Salt: "admin-salt", Admin: true},
+	}
+	tokenStore := &tokenStoreFake{}
+	authenticator, issuer := setupTokenAuthenticator(principalStore, tokenStore)
+
+	_, impersonationJWT, err := issuer.CreateImpersonation(ctx, principalStore.admin.ToPrincipal(),
+		principalStore.user, "impersonation", time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create impersonation token: %v", err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/user", nil)
+	r.Header.Set(request.HeaderAuthorization, "Bearer "+impersonationJWT)
+
+	session, err := authenticator.Authenticate(r)
+	if err != nil {
+		t.Fatalf("Want impersonation token to be valid, got %v", err)
+	}
+	if session.Principal.ID != principalStore.user.ID || session.Principal.Admin {
+		t.Errorf("Want session of non-admin user %d, got %d (admin: %t)",
+			principalStore.user.ID, session.Principal.ID, session.Principal.Admin)
+	}
+	if session.Impersonator == nil || session.Impersonator.ID != principalStore.admin.ID {
+		t.Fatalf("Want session to be impersonated by admin %d, got %v", principalStore.admin.ID, session.Impersonator)
+	}
+
+	// the impersonation ends once the impersonator is no longer an admin.
+	principalStore.admin.Admin = false
+	if err = authenticateWithToken(t, authenticator, impersonationJWT); err == nil {
+		t.Errorf("Want impersonation token of demoted admin to be rejected, got no error")
+	}
+}
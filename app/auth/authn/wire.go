@@ -15,6 +15,7 @@
 package authn
 
 import (
+	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types"
 
@@ -24,12 +25,21 @@ import (
 // WireSet provides a wire set for this package.
 var WireSet = wire.NewSet(
 	ProvideAuthenticator,
+	ProvideDeployKeyAuthenticator,
 )
 
 func ProvideAuthenticator(
 	config *types.Config,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	signingKeys *jwt.KeySet,
 ) Authenticator {
-	return NewTokenAuthenticator(principalStore, tokenStore, config.Token.CookieName)
+	return NewTokenAuthenticator(principalStore, tokenStore, signingKeys, config.Token.CookieName)
+}
+
+func ProvideDeployKeyAuthenticator(
+	deployKeyStore store.DeployKeyStore,
+	principalStore store.PrincipalStore,
+) *DeployKeyAuthenticator {
+	return NewDeployKeyAuthenticator(deployKeyStore, principalStore)
 }
@@ -0,0 +1,282 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authsource
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/dchest/uniuri"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/rs/zerolog/log"
+)
+
+var _ Source = (*LDAPSource)(nil)
+
+// DirectoryEntry contains the attributes of an authenticated directory user.
+type DirectoryEntry struct {
+	// ExternalID is the immutable id of the entry in the directory.
+	ExternalID  string
+	UID         string
+	Email       string
+	DisplayName string
+}
+
+// Directory is an abstraction of the directory the LDAP source verifies the credentials against.
+type Directory interface {
+	/*
+	 * Verifies the credentials and returns the directory entry of the user.
+	 * Returns:
+	 *		(entry, nil) 		          - the credentials are valid
+	 *		(nil, ErrInvalidCredentials)  - the user doesn't exist or the password is invalid
+	 *		(nil, err)  		          - the credentials couldn't be verified
+	 */
+	Authenticate(ctx context.Context, loginIdentifier string, password string) (*DirectoryEntry, error)
+}
+
+// LDAPSource authenticates users against a directory.
+// Users are provisioned in the principal store with their first successful login.
+type LDAPSource struct {
+	directory         Directory
+	principalStore    store.PrincipalStore
+	principalUIDCheck check.PrincipalUID
+}
+
+func NewLDAPSource(
+	directory Directory,
+	principalStore store.PrincipalStore,
+	principalUIDCheck check.PrincipalUID,
+) *LDAPSource {
+	return &LDAPSource{
+		directory:         directory,
+		principalStore:    principalStore,
+		principalUIDCheck: principalUIDCheck,
+	}
+}
+
+func (s *LDAPSource) Authenticate(
+	ctx context.Context,
+	loginIdentifier string,
+	password string,
+) (*types.User, error) {
+	entry, err := s.directory.Authenticate(ctx, loginIdentifier, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.ExternalID == "" {
+		return nil, fmt.Errorf("directory entry of %q has no external id", entry.UID)
+	}
+
+	user, err := s.principalStore.FindUserByUID(ctx, entry.UID)
+	if err == nil {
+		// only link to users that were provisioned from the same directory entry,
+		// otherwise a directory user could take over a local (or re-assigned) account with the same uid.
+		if user.AuthSource != TypeLDAP || user.ExternalID != entry.ExternalID {
+			log.Ctx(ctx).Warn().
+				Str("user_uid", user.UID).
+				Msg("ldap user matches a principal that wasn't provisioned from the same directory entry")
+			return nil, ErrInvalidCredentials
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return s.provisionUser(ctx, entry)
+}
+
+// provisionUser creates the user for the directory entry.
+// NOTE: The user doesn't get a password, so it can only login via the directory.
+func (s *LDAPSource) provisionUser(ctx context.Context, entry *DirectoryEntry) (*types.User, error) {
+	if err := s.principalUIDCheck(entry.UID); err != nil {
+		return nil, fmt.Errorf("directory user has an invalid uid: %w", err)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(entry.Email))
+	if err := check.Email(email); err != nil {
+		return nil, fmt.Errorf("directory user has an invalid email: %w", err)
+	}
+
+	displayName := strings.TrimSpace(entry.DisplayName)
+	if displayName == "" {
+		displayName = entry.UID
+	}
+	if err := check.DisplayName(displayName); err != nil {
+		return nil, fmt.Errorf("directory user has an invalid display name: %w", err)
+	}
+
+	// never link a directory user to an existing principal based on the email.
+	_, err := s.principalStore.FindByEmail(ctx, email)
+	if err == nil {
+		return nil, usererror.Conflict("A principal with the email of the directory user already exists.")
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to check email uniqueness: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	user := &types.User{
+		UID:           entry.UID,
+		DisplayName:   displayName,
+		Email:         email,
+		EmailVerified: true,
+		AuthSource:    TypeLDAP,
+		ExternalID:    entry.ExternalID,
+		Salt:          uniuri.NewLen(uniuri.UUIDLen),
+		Created:       now,
+		Updated:       now,
+	}
+
+	if err = s.principalStore.CreateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to provision user: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Str("user_uid", user.UID).
+		Msg("provisioned user from ldap directory")
+
+	return user, nil
+}
+
+// LDAPConfig contains the configuration of the LDAP server.
+type LDAPConfig struct {
+	// URL is the url of the LDAP server (e.g. ldaps://ldap.example.com:636).
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+
+	// BindDN and BindPassword are the credentials used to search the user (anonymous search if empty).
+	BindDN       string
+	BindPassword string
+
+	// BaseDN is the DN the user search starts from.
+	BaseDN string
+	// SearchFilter is the filter used to find the user, "%s" is replaced with the escaped login identifier.
+	SearchFilter string
+
+	AttributeUID         string
+	AttributeEmail       string
+	AttributeDisplayName string
+	// AttributeExternalID is the immutable attribute identifying the entry (the DN is used if empty).
+	AttributeExternalID string
+}
+
+// LDAPDirectory verifies credentials against an LDAP server.
+// The user is searched using the bind DN and authenticated by binding as the found entry.
+type LDAPDirectory struct {
+	config LDAPConfig
+}
+
+func NewLDAPDirectory(config LDAPConfig) *LDAPDirectory {
+	return &LDAPDirectory{
+		config: config,
+	}
+}
+
+func (d *LDAPDirectory) Authenticate(
+	ctx context.Context,
+	loginIdentifier string,
+	password string,
+) (*DirectoryEntry, error) {
+	// an empty password would result in an unauthenticated bind which always succeeds.
+	if loginIdentifier == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	//nolint:gosec // skipping verification has to be explicitly configured.
+	tlsConfig := &tls.Config{InsecureSkipVerify: d.config.InsecureSkipVerify}
+
+	conn, err := ldap.DialURL(d.config.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ldap server: %w", err)
+	}
+	defer conn.Close()
+
+	if d.config.StartTLS {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			return nil, fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if d.config.BindDN != "" {
+		if err = conn.Bind(d.config.BindDN, d.config.BindPassword); err != nil {
+			return nil, fmt.Errorf("failed to bind with configured bind dn: %w", err)
+		}
+	}
+
+	res, err := conn.Search(ldap.NewSearchRequest(
+		d.config.BaseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2, // only one entry is expected, the second one is to detect ambiguous filters.
+		0,
+		false,
+		strings.ReplaceAll(d.config.SearchFilter, "%s", ldap.EscapeFilter(loginIdentifier)),
+		[]string{
+			d.config.AttributeUID,
+			d.config.AttributeEmail,
+			d.config.AttributeDisplayName,
+			d.config.AttributeExternalID,
+		},
+		nil,
+	))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		log.Ctx(ctx).Warn().Msgf("ldap search filter matches multiple entries for %q", loginIdentifier)
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search ldap directory: %w", err)
+	}
+	if len(res.Entries) != 1 {
+		log.Ctx(ctx).Debug().Msgf("ldap search filter matches %d entries for %q", len(res.Entries), loginIdentifier)
+		return nil, ErrInvalidCredentials
+	}
+
+	entry := res.Entries[0]
+
+	err = conn.Bind(entry.DN, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind as user: %w", err)
+	}
+
+	// the raw value is hex encoded as some directories store binary ids (e.g. objectGUID).
+	externalID := entry.DN
+	if d.config.AttributeExternalID != "" {
+		externalID = hex.EncodeToString(entry.GetRawAttributeValue(d.config.AttributeExternalID))
+	}
+
+	return &DirectoryEntry{
+		ExternalID:  externalID,
+		UID:         entry.GetAttributeValue(d.config.AttributeUID),
+		Email:       entry.GetAttributeValue(d.config.AttributeEmail),
+		DisplayName: entry.GetAttributeValue(d.config.AttributeDisplayName),
+	}, nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authsource
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types/check"
+)
+
+func setupLDAPSource() (*LDAPSource, *principalStoreFake) {
+	directory := &directoryFake{
+		entries: map[string]*DirectoryEntry{
+			"jdoe":   {ExternalID: "1", UID: "jdoe", Email: " JDoe@Example.com ", DisplayName: "John Doe"},
+			"asmith": {ExternalID: "2", UID: "asmith", Email: "asmith@example.com", DisplayName: "Alice Smith"},
+			"taken":  {ExternalID: "3", UID: "taken", Email: "local@example.com", DisplayName: "Taken"},
+			"admin":  {ExternalID: "4", UID: "admin", Email: "admin@corp.example.com", DisplayName: "Admin"},
+			"bwayne": {ExternalID: "5", UID: "bwayne", Email: "bwayne@example.com", DisplayName: "Bruce Wayne"},
+		},
+		passwords: map[string]string{
+			"jdoe":   "secret",
+			"asmith": "secret",
+			"taken":  "secret",
+			"admin":  "secret",
+			"bwayne": "secret",
+		},
+	}
+	principalStore := &principalStoreFake{}
+	principalStore.addLDAPUser("asmith", "asmith@example.com", "2")
+	principalStore.addUser("local", "local@example.com")
+	principalStore.addUser("admin", "admin@example.com")
+	// the directory entry with the uid was replaced since the user was provisioned.
+	principalStore.addLDAPUser("bwayne", "bwayne@example.com", "42")
+
+	return NewLDAPSource(directory, principalStore, check.PrincipalUIDDefault), principalStore
+}
+
+func TestLDAPSource_ExistingUser(t *testing.T) {
+	source, principalStore := setupLDAPSource()
+
+	user, err := source.Authenticate(context.Background(), "asmith", "secret")
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if user.ID != 1 {
+		t.Errorf("Want existing user with id %d, got %d", 1, user.ID)
+	}
+	if len(principalStore.users) != 4 {
+		t.Errorf("Want no user to be provisioned, got %d users", len(principalStore.users))
+	}
+}
+
+func TestLDAPSource_DoesNotLinkUnmarkedUsers(t *testing.T) {
+	source, principalStore := setupLDAPSource()
+
+	tests := []struct {
+		name            string
+		loginIdentifier string
+	}{
+		{name: "local user with same uid", loginIdentifier: "admin"},
+		{name: "user provisioned from other entry", loginIdentifier: "bwayne"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := source.Authenticate(context.Background(), tt.loginIdentifier, "secret")
+			if !errors.Is(err, ErrInvalidCredentials) {
+				t.Errorf("Want ErrInvalidCredentials, got %v", err)
+			}
+		})
+	}
+
+	if len(principalStore.users) != 4 {
+		t.Errorf("Want no user to be provisioned, got %d users", len(principalStore.users))
+	}
+}
+
+func TestLDAPSource_InvalidCredentials(t *testing.T) {
+	source, principalStore := setupLDAPSource()
+
+	tests := []struct {
+		name            string
+		loginIdentifier string
+		password        string
+	}{
+		{name: "wrong password", loginIdentifier: "jdoe", password: "wrong"},
+		{name: "empty password", loginIdentifier: "jdoe", password: ""},
+		{name: "unknown user", loginIdentifier: "unknown", password: "secret"},
+		{name: "local only user", loginIdentifier: "local", password: "secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := source.Authenticate(context.Background(), tt.loginIdentifier, tt.password)
+			if !errors.Is(err, ErrInvalidCredentials) {
+				t.Errorf("Want ErrInvalidCredentials, got %v", err)
+			}
+		})
+	}
+
+	if len(principalStore.users) != 4 {
+		t.Errorf("Want no user to be provisioned, got %d users", len(principalStore.users))
+	}
+}
+
+func TestLDAPSource_ProvisionsUser(t *testing.T) {
+	source, principalStore := setupLDAPSource()
+
+	user, err := source.Authenticate(context.Background(), "jdoe", "secret")
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if user.UID != "jdoe" || user.Email != "jdoe@example.com" || user.DisplayName != "John Doe" {
+		t.Errorf("Want user to be provisioned from directory entry, got %+v", user)
+	}
+	if user.Admin {
+		t.Errorf("Want provisioned user to not be admin")
+	}
+	if user.Password != "" {
+		t.Errorf("Want provisioned user to not have a local password")
+	}
+	if user.AuthSource != TypeLDAP || user.ExternalID != "1" {
+		t.Errorf("Want provisioned user to be linked to the directory entry, got %q/%q", user.AuthSource, user.ExternalID)
+	}
+
+	// the second login uses the provisioned user.
+	again, err := source.Authenticate(context.Background(), "jdoe", "secret")
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if again.ID != user.ID {
+		t.Errorf("Want user %d, got %d", user.ID, again.ID)
+	}
+	if len(principalStore.users) != 5 {
+		t.Errorf("Want exactly one user to be provisioned, got %d users", len(principalStore.users))
+	}
+}
+
+func TestLDAPSource_ProvisionEmailConflict(t *testing.T) {
+	source, principalStore := setupLDAPSource()
+
+	_, err := source.Authenticate(context.Background(), "taken", "secret")
+	if status := usererror.Translate(context.Background(), err).Status; status != http.StatusConflict {
+		t.Errorf("Want status %d, got %d (%v)", http.StatusConflict, status, err)
+	}
+	if len(principalStore.users) != 4 {
+		t.Errorf("Want no user to be provisioned, got %d users", len(principalStore.users))
+	}
+}
+
+func TestLDAPDirectory_EmptyPassword(t *testing.T) {
+	// the empty password is rejected before connecting, the server doesn't have to exist.
+	directory := NewLDAPDirectory(LDAPConfig{URL: "ldap://127.0.0.1:1", BaseDN: "dc=example,dc=com"})
+
+	_, err := directory.Authenticate(context.Background(), "jdoe", "")
+	if !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Want ErrInvalidCredentials, got %v", err)
+	}
+}
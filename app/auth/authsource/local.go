@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth/password"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ Source = (*LocalSource)(nil)
+
+// LocalSource authenticates users against the password hashes stored in the principal store.
+// Hashes that weren't produced with the configured algorithm and parameters are replaced on login.
+type LocalSource struct {
+	principalStore           store.PrincipalStore
+	hasher                   password.Hasher
+	blockServiceAccountLogin bool
+}
+
+func NewLocalSource(
+	principalStore store.PrincipalStore,
+	hasher password.Hasher,
+	blockServiceAccountLogin bool,
+) *LocalSource {
+	return &LocalSource{
+		principalStore:           principalStore,
+		hasher:                   hasher,
+		blockServiceAccountLogin: blockServiceAccountLogin,
+	}
+}
+
+func (s *LocalSource) Authenticate(
+	ctx context.Context,
+	loginIdentifier string,
+	pwd string,
+) (*types.User, error) {
+	principal, err := s.principalStore.FindByUID(ctx, loginIdentifier)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		principal, err = s.principalStore.FindByEmail(ctx, loginIdentifier)
+	}
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	if principal.Type == enum.PrincipalTypeServiceAccount && s.blockServiceAccountLogin {
+		return nil, s.rejectServiceAccount(ctx, principal, pwd)
+	}
+
+	user, err := s.principalStore.FindUser(ctx, principal.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	err = s.hasher.Verify(user.Password, pwd)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).
+			Str("user_uid", user.UID).
+			Msg("invalid password")
+
+		return nil, ErrInvalidCredentials
+	}
+
+	if s.hasher.NeedsRehash(user.Password) {
+		s.rehash(ctx, user, pwd)
+	}
+
+	return user, nil
+}
+
+// rejectServiceAccount rejects the login of a service account, as they are only allowed to authenticate via tokens.
+// The password is verified first to not disclose the existence (or type) of the account to unauthenticated callers.
+func (s *LocalSource) rejectServiceAccount(ctx context.Context, principal *types.Principal, pwd string) error {
+	hash, err := s.principalStore.FindPassword(ctx, principal.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return ErrInvalidCredentials
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find password of service account: %w", err)
+	}
+
+	if hash == "" || s.hasher.Verify(hash, pwd) != nil {
+		return ErrInvalidCredentials
+	}
+
+	log.Ctx(ctx).Debug().
+		Str("principal_uid", principal.UID).
+		Msg("blocked login attempt of service account")
+
+	return ErrServiceAccountLogin
+}
+
+// rehash replaces the password hash of the user with a hash of the configured algorithm.
+// Failures are only logged, the user is authenticated regardless.
+func (s *LocalSource) rehash(ctx context.Context, user *types.User, pwd string) {
+	hash, err := s.hasher.Hash(pwd)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("user_uid", user.UID).
+			Msg("failed to rehash password")
+		return
+	}
+
+	// only the password is replaced (and only if it wasn't changed concurrently),
+	// as the user loaded for the login might already be outdated.
+	if err = s.principalStore.UpdatePassword(ctx, user.ID, user.Password, hash); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("user_uid", user.UID).
+			Msg("failed to store rehashed password")
+		return
+	}
+
+	user.Password = hash
+
+	log.Ctx(ctx).Info().
+		Str("user_uid", user.UID).
+		Str("algorithm", string(s.hasher.Algorithm())).
+		Msg("upgraded password hash")
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authsource
+
+import (
+	"context"
+	"errors"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	// TypeLocal authenticates users against the passwords stored by gitness.
+	TypeLocal = "local"
+	// TypeLDAP authenticates users against an LDAP directory.
+	TypeLDAP = "ldap"
+)
+
+var (
+	// ErrInvalidCredentials is returned if the login identifier or password are invalid.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// ErrServiceAccountLogin is returned if a service account logs in with valid credentials,
+	// as service accounts are only allowed to authenticate via their tokens.
+	ErrServiceAccountLogin = errors.New("service account login")
+)
+
+// Source is an abstraction of an entity that's responsible for authenticating users
+// logging in with their login identifier and password.
+type Source interface {
+	/*
+	 * Authenticates the user with the provided credentials.
+	 * Returns:
+	 *		(user, nil) 		          - the credentials are valid
+	 *		(nil, ErrInvalidCredentials)  - the credentials are invalid
+	 *		(nil, ErrServiceAccountLogin) - the credentials are valid, but belong to a service account
+	 *		(nil, err)  		          - the credentials couldn't be verified
+	 */
+	Authenticate(ctx context.Context, loginIdentifier string, password string) (*types.User, error)
+}
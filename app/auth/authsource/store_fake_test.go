@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authsource
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// directoryFake is an in-memory directory.
+type directoryFake struct {
+	entries   map[string]*DirectoryEntry
+	passwords map[string]string
+}
+
+func (d *directoryFake) Authenticate(
+	_ context.Context,
+	loginIdentifier string,
+	password string,
+) (*DirectoryEntry, error) {
+	entry, ok := d.entries[loginIdentifier]
+	if !ok || password == "" || d.passwords[loginIdentifier] != password {
+		return nil, ErrInvalidCredentials
+	}
+	return entry, nil
+}
+
+// principalStoreFake is an in-memory principal store that supports the operations used by the sources.
+type principalStoreFake struct {
+	store.PrincipalStore
+	users []*types.User
+}
+
+func (s *principalStoreFake) FindUserByUID(_ context.Context, uid string) (*types.User, error) {
+	for _, u := range s.users {
+		if strings.EqualFold(u.UID, uid) {
+			return u, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *principalStoreFake) FindByEmail(_ context.Context, email string) (*types.Principal, error) {
+	for _, u := range s.users {
+		if strings.EqualFold(u.Email, email) {
+			return u.ToPrincipal(), nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *principalStoreFake) CreateUser(_ context.Context, user *types.User) error {
+	user.ID = int64(len(s.users) + 1)
+	s.users = append(s.users, user)
+	return nil
+}
+
+func (s *principalStoreFake) addUser(uid string, email string) {
+	_ = s.CreateUser(context.Background(), &types.User{UID: uid, Email: email})
+}
+
+func (s *principalStoreFake) addLDAPUser(uid string, email string, externalID string) {
+	_ = s.CreateUser(context.Background(), &types.User{
+		UID:        uid,
+		Email:      email,
+		AuthSource: TypeLDAP,
+		ExternalID: externalID,
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authsource
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/auth/password"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideSource,
+)
+
+func ProvideSource(
+	config *types.Config,
+	principalStore store.PrincipalStore,
+	principalUIDCheck check.PrincipalUID,
+	hasher password.Hasher,
+) (Source, error) {
+	switch config.Auth.Source {
+	case TypeLocal:
+		return NewLocalSource(principalStore, hasher, config.Auth.BlockServiceAccountLogin), nil
+	case TypeLDAP:
+		if config.LDAP.URL == "" || config.LDAP.BaseDN == "" {
+			return nil, fmt.Errorf("ldap url and base dn are required for auth source %q", TypeLDAP)
+		}
+		directory := NewLDAPDirectory(LDAPConfig{
+			URL:                  config.LDAP.URL,
+			StartTLS:             config.LDAP.StartTLS,
+			InsecureSkipVerify:   config.LDAP.InsecureSkipVerify,
+			BindDN:               config.LDAP.BindDN,
+			BindPassword:         config.LDAP.BindPassword,
+			BaseDN:               config.LDAP.BaseDN,
+			SearchFilter:         config.LDAP.SearchFilter,
+			AttributeUID:         config.LDAP.AttributeUID,
+			AttributeEmail:       config.LDAP.AttributeEmail,
+			AttributeDisplayName: config.LDAP.AttributeDisplayName,
+			AttributeExternalID:  config.LDAP.AttributeExternalID,
+		})
+		return NewLDAPSource(directory, principalStore, principalUIDCheck), nil
+	default:
+		return nil, fmt.Errorf("unknown auth source %q", config.Auth.Source)
+	}
+}
@@ -55,4 +55,19 @@ type Authorizer interface {
 	CheckAll(ctx context.Context,
 		session *auth.Session,
 		permissionChecks ...types.PermissionCheck) (bool, error)
+
+	/*
+	 * Checks whether the principal itself, independent of any session and its metadata,
+	 * has the permission to execute the action on the resource within the scope.
+	 * Used to verify the access of principals other than the caller (e.g. reviewers).
+	 * Returns
+	 *		(true, nil)   - the action is permitted
+	 *		(false, nil)  - the action is not permitted
+	 *		(false, err)  - an error occurred while performing the permission check and the action should be denied
+	 */
+	CheckPrincipal(ctx context.Context,
+		principal *types.Principal,
+		scope *types.Scope,
+		resource *types.Resource,
+		permission enum.Permission) (bool, error)
 }
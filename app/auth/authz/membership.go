@@ -17,6 +17,7 @@ package authz
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
@@ -32,15 +33,18 @@ var _ Authorizer = (*MembershipAuthorizer)(nil)
 type MembershipAuthorizer struct {
 	permissionCache PermissionCache
 	spaceStore      store.SpaceStore
+	repoStore       store.RepoStore
 }
 
 func NewMembershipAuthorizer(
 	permissionCache PermissionCache,
 	spaceStore store.SpaceStore,
+	repoStore store.RepoStore,
 ) *MembershipAuthorizer {
 	return &MembershipAuthorizer{
 		permissionCache: permissionCache,
 		spaceStore:      spaceStore,
+		repoStore:       repoStore,
 	}
 }
 
@@ -72,11 +76,49 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
-	if session.Principal.Admin {
+	// deploy keys are restricted to their repository and the access of the principal that created them.
+	if deployKeyMetadata, ok := session.Metadata.(*auth.DeployKeyMetadata); ok {
+		return a.checkWithDeployKeyMetadata(ctx, &session.Principal, deployKeyMetadata, scope, resource, permission)
+	}
+
+	return a.check(ctx, &session.Principal, session.Metadata, scope, resource, permission)
+}
+
+func (a *MembershipAuthorizer) CheckPrincipal(
+	ctx context.Context,
+	principal *types.Principal,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	log.Ctx(ctx).Debug().Msgf(
+		"[MembershipAuthorizer] %s with id '%d' is checked for %s for %s '%s' in scope %#v",
+		principal.Type,
+		principal.ID,
+		permission,
+		resource.Type,
+		resource.Identifier,
+		scope,
+	)
+
+	return a.check(ctx, principal, nil, scope, resource, permission)
+}
+
+// check checks the permission of the principal, restricted by the provided session metadata (if any).
+func (a *MembershipAuthorizer) check(
+	ctx context.Context,
+	principal *types.Principal,
+	metadata auth.Metadata,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	if principal.Admin {
 		return true, nil // system admin can call any API
 	}
 
 	var spacePath string
+	var repoPath string
 
 	//nolint:exhaustive // we want to fail on anything else
 	switch resource.Type {
@@ -85,6 +127,9 @@ func (a *MembershipAuthorizer) Check(
 
 	case enum.ResourceTypeRepo:
 		spacePath = scope.SpacePath
+		if resource.Identifier != "" {
+			repoPath = paths.Concatenate(scope.SpacePath, resource.Identifier)
+		}
 
 	case enum.ResourceTypeServiceAccount:
 		spacePath = scope.SpacePath
@@ -103,7 +148,7 @@ func (a *MembershipAuthorizer) Check(
 
 	case enum.ResourceTypeUser:
 		// a user is allowed to view / edit themselves
-		if resource.Identifier == session.Principal.UID &&
+		if resource.Identifier == principal.UID &&
 			(permission == enum.PermissionUserView || permission == enum.PermissionUserEdit) {
 			return true, nil
 		}
@@ -120,18 +165,19 @@ func (a *MembershipAuthorizer) Check(
 	}
 
 	// ephemeral membership overrides any other space memberships of the principal
-	if membershipMetadata, ok := session.Metadata.(*auth.MembershipMetadata); ok {
+	if membershipMetadata, ok := metadata.(*auth.MembershipMetadata); ok {
 		return a.checkWithMembershipMetadata(ctx, membershipMetadata, spacePath, permission)
 	}
 
 	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
-		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
+	if metadata != nil && metadata.ImpactsAuthorization() {
+		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", metadata)
 	}
 
 	return a.permissionCache.Get(ctx, PermissionCacheKey{
-		PrincipalID: session.Principal.ID,
+		PrincipalID: principal.ID,
 		SpaceRef:    spacePath,
+		RepoRef:     repoPath,
 		Permission:  permission,
 	})
 }
@@ -179,3 +225,37 @@ func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	// access is granted by ephemeral membership
 	return true, nil
 }
+
+// checkWithDeployKeyMetadata checks access using the deploy key provided in the metadata.
+// A deploy key grants read access to its repository, and push access only if it's a write key.
+// The key acts on behalf of the principal that created it, hence it never grants more than
+// the principal is currently permitted to do on the repository.
+func (a *MembershipAuthorizer) checkWithDeployKeyMetadata(
+	ctx context.Context,
+	creator *types.Principal,
+	deployKeyMetadata *auth.DeployKeyMetadata,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	if resource.Type != enum.ResourceTypeRepo {
+		return false, nil
+	}
+
+	allowed := permission == enum.PermissionRepoView ||
+		(permission == enum.PermissionRepoPush && deployKeyMetadata.Write)
+	if !allowed {
+		return false, nil
+	}
+
+	repo, err := a.repoStore.Find(ctx, deployKeyMetadata.RepoID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo of deploy key: %w", err)
+	}
+
+	if !strings.EqualFold(repo.Path, paths.Concatenate(scope.SpacePath, resource.Identifier)) {
+		return false, nil
+	}
+
+	return a.check(ctx, creator, nil, scope, resource, permission)
+}
@@ -33,27 +33,77 @@ import (
 type PermissionCacheKey struct {
 	PrincipalID int64
 	SpaceRef    string
-	Permission  enum.Permission
+	// RepoRef is the path of the repository the permission is requested for (empty for non-repo resources).
+	RepoRef    string
+	Permission enum.Permission
 }
 type PermissionCache cache.Cache[PermissionCacheKey, bool]
 
 func NewPermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	repoStore store.RepoStore,
+	repoCollaboratorStore store.RepoCollaboratorStore,
 	cacheDuration time.Duration,
 ) PermissionCache {
 	return cache.New[PermissionCacheKey, bool](permissionCacheGetter{
-		spaceStore:      spaceStore,
-		membershipStore: membershipStore,
+		spaceStore:            spaceStore,
+		membershipStore:       membershipStore,
+		repoStore:             repoStore,
+		repoCollaboratorStore: repoCollaboratorStore,
 	}, cacheDuration)
 }
 
 type permissionCacheGetter struct {
-	spaceStore      store.SpaceStore
-	membershipStore store.MembershipStore
+	spaceStore            store.SpaceStore
+	membershipStore       store.MembershipStore
+	repoStore             store.RepoStore
+	repoCollaboratorStore store.RepoCollaboratorStore
 }
 
+// Find returns whether the principal has the requested permission.
+// Roles inherited from space memberships and the repository collaborator role are evaluated together,
+// so the effective permission is the maximum of both.
 func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey) (bool, error) {
+	allowed, err := g.checkSpaceMemberships(ctx, key)
+	if err != nil || allowed {
+		return allowed, err
+	}
+
+	if key.RepoRef == "" {
+		return false, nil
+	}
+
+	return g.checkRepoCollaborator(ctx, key)
+}
+
+// checkRepoCollaborator checks if the principal is granted the permission as a collaborator of the repository.
+func (g permissionCacheGetter) checkRepoCollaborator(ctx context.Context, key PermissionCacheKey) (bool, error) {
+	repo, err := g.repoStore.FindByRef(ctx, key.RepoRef)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo '%s': %w", key.RepoRef, err)
+	}
+
+	collaborator, err := g.repoCollaboratorStore.Find(ctx, types.RepoCollaboratorKey{
+		RepoID:      repo.ID,
+		PrincipalID: key.PrincipalID,
+	})
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo collaborator: %w", err)
+	}
+
+	return roleHasPermission(collaborator.Role, key.Permission), nil
+}
+
+// checkSpaceMemberships checks if the principal is granted the permission by a membership
+// of the space or any of its ancestors.
+func (g permissionCacheGetter) checkSpaceMemberships(ctx context.Context, key PermissionCacheKey) (bool, error) {
 	spaceRef := key.SpaceRef
 	principalID := key.PrincipalID
 
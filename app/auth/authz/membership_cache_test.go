@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// spaceStoreFake is an in-memory space store.
+type spaceStoreFake struct {
+	store.SpaceStore
+	spaces []*types.Space
+}
+
+func (s *spaceStoreFake) Find(_ context.Context, id int64) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.ID == id {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *spaceStoreFake) FindByRef(_ context.Context, ref string) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.Path == ref {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// membershipStoreFake is an in-memory membership store.
+type membershipStoreFake struct {
+	store.MembershipStore
+	memberships []*types.Membership
+}
+
+func (s *membershipStoreFake) Find(_ context.Context, key types.MembershipKey) (*types.Membership, error) {
+	for _, m := range s.memberships {
+		if m.MembershipKey == key {
+			return m, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// repoCollaboratorStoreFake is an in-memory repository collaborator store.
+type repoCollaboratorStoreFake struct {
+	store.RepoCollaboratorStore
+	collaborators []*types.RepoCollaborator
+}
+
+func (s *repoCollaboratorStoreFake) Find(
+	_ context.Context,
+	key types.RepoCollaboratorKey,
+) (*types.RepoCollaborator, error) {
+	for _, c := range s.collaborators {
+		if c.RepoCollaboratorKey == key {
+			return c, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func TestPermissionCacheGetter_EffectivePermission(t *testing.T) {
+	spaceStore := &spaceStoreFake{spaces: []*types.Space{
+		{ID: 1, Identifier: "root", Path: "root"},
+		{ID: 2, ParentID: 1, Identifier: "child", Path: "root/child"},
+	}}
+	repoStore := &repoStoreFake{repos: []*types.Repository{
+		{ID: 1, ParentID: 2, Identifier: "repo", Path: "root/child/repo"},
+		{ID: 2, ParentID: 2, Identifier: "other", Path: "root/child/other"},
+	}}
+
+	tests := []struct {
+		name               string
+		spaceRole          enum.MembershipRole
+		collaborator       enum.MembershipRole
+		collaboratorRepoID int64
+		repoRef            string
+		permission         enum.Permission
+		want               bool
+	}{
+		{name: "space reader can view", spaceRole: enum.MembershipRoleReader,
+			repoRef: "root/child/repo", permission: enum.PermissionRepoView, want: true},
+		{name: "space reader can't push", spaceRole: enum.MembershipRoleReader,
+			repoRef: "root/child/repo", permission: enum.PermissionRepoPush, want: false},
+		{name: "collaborator role extends space role", spaceRole: enum.MembershipRoleReader,
+			collaborator: enum.MembershipRoleContributor,
+			repoRef:      "root/child/repo", permission: enum.PermissionRepoPush, want: true},
+		{name: "collaborator role doesn't exceed itself", spaceRole: enum.MembershipRoleReader,
+			collaborator: enum.MembershipRoleContributor,
+			repoRef:      "root/child/repo", permission: enum.PermissionRepoEdit, want: false},
+		{name: "lower collaborator role doesn't restrict space role", spaceRole: enum.MembershipRoleSpaceOwner,
+			collaborator: enum.MembershipRoleReader,
+			repoRef:      "root/child/repo", permission: enum.PermissionRepoEdit, want: true},
+		{name: "collaborator without space membership", collaborator: enum.MembershipRoleContributor,
+			repoRef: "root/child/repo", permission: enum.PermissionRepoPush, want: true},
+		{name: "collaborator of other repo", collaborator: enum.MembershipRoleSpaceOwner, collaboratorRepoID: 2,
+			repoRef: "root/child/repo", permission: enum.PermissionRepoView, want: false},
+		{name: "collaborator role doesn't apply to space", collaborator: enum.MembershipRoleSpaceOwner,
+			permission: enum.PermissionSpaceView, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			membershipStore := &membershipStoreFake{}
+			if test.spaceRole != "" {
+				membershipStore.memberships = append(membershipStore.memberships, &types.Membership{
+					MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: 1},
+					Role:          test.spaceRole,
+				})
+			}
+
+			collaboratorStore := &repoCollaboratorStoreFake{}
+			if test.collaborator != "" {
+				repoID := test.collaboratorRepoID
+				if repoID == 0 {
+					repoID = 1
+				}
+				collaboratorStore.collaborators = append(collaboratorStore.collaborators, &types.RepoCollaborator{
+					RepoCollaboratorKey: types.RepoCollaboratorKey{RepoID: repoID, PrincipalID: 1},
+					Role:                test.collaborator,
+				})
+			}
+
+			getter := permissionCacheGetter{
+				spaceStore:            spaceStore,
+				membershipStore:       membershipStore,
+				repoStore:             repoStore,
+				repoCollaboratorStore: collaboratorStore,
+			}
+
+			got, err := getter.Find(context.Background(), PermissionCacheKey{
+				PrincipalID: 1,
+				SpaceRef:    "root/child",
+				RepoRef:     test.repoRef,
+				Permission:  test.permission,
+			})
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+			if got != test.want {
+				t.Errorf("Want authorized %t, got %t", test.want, got)
+			}
+		})
+	}
+}
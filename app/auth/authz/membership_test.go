@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// repoStoreFake is an in-memory repo store (the shared fake depends on this package, so it can't be used here).
+type repoStoreFake struct {
+	store.RepoStore
+	repos []*types.Repository
+}
+
+func (s *repoStoreFake) Find(_ context.Context, id int64) (*types.Repository, error) {
+	for _, r := range s.repos {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *repoStoreFake) FindByRef(_ context.Context, ref string) (*types.Repository, error) {
+	for _, r := range s.repos {
+		if r.Path == ref {
+			return r, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// permissionCacheFake grants all permissions, except the denied ones.
+type permissionCacheFake struct {
+	PermissionCache
+	denied map[PermissionCacheKey]bool
+}
+
+func (c permissionCacheFake) Get(_ context.Context, key PermissionCacheKey) (bool, error) {
+	return !c.denied[key], nil
+}
+
+func TestCheck_DeployKey(t *testing.T) {
+	authorizer := NewMembershipAuthorizer(permissionCacheFake{}, nil, &repoStoreFake{repos: []*types.Repository{
+		{ID: 1, Identifier: "repo", Path: "space/repo"},
+		{ID: 2, Identifier: "other", Path: "space/other"},
+	}})
+
+	repoResource := &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"}
+	otherResource := &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "other"}
+	spaceResource := &types.Resource{Type: enum.ResourceTypeSpace, Identifier: "space"}
+	scope := &types.Scope{SpacePath: "space"}
+
+	tests := []struct {
+		name       string
+		write      bool
+		resource   *types.Resource
+		permission enum.Permission
+		want       bool
+	}{
+		{name: "read key can view its repo", resource: repoResource,
+			permission: enum.PermissionRepoView, want: true},
+		{name: "read key can't push", resource: repoResource,
+			permission: enum.PermissionRepoPush, want: false},
+		{name: "write key can push", write: true, resource: repoResource,
+			permission: enum.PermissionRepoPush, want: true},
+		{name: "write key can't edit repo", write: true, resource: repoResource,
+			permission: enum.PermissionRepoEdit, want: false},
+		{name: "key can't view other repo", write: true, resource: otherResource,
+			permission: enum.PermissionRepoView, want: false},
+		{name: "key can't push to other repo", write: true, resource: otherResource,
+			permission: enum.PermissionRepoPush, want: false},
+		{name: "key can't view space", write: true, resource: spaceResource,
+			permission: enum.PermissionSpaceView, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the principal that added the key is admin, which mustn't widen the access of the key.
+			session := &auth.Session{
+				Principal: types.Principal{ID: 1, Admin: true},
+				Metadata:  &auth.DeployKeyMetadata{DeployKeyID: 1, RepoID: 1, Write: test.write},
+			}
+
+			got, err := authorizer.Check(context.Background(), session, scope, test.resource, test.permission)
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+			if got != test.want {
+				t.Errorf("Want authorized %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCheck_DeployKeyCreatorAccess(t *testing.T) {
+	creator := types.Principal{ID: 2, UID: "creator"}
+	authorizer := NewMembershipAuthorizer(
+		permissionCacheFake{denied: map[PermissionCacheKey]bool{
+			// the creator lost push access to the repository after adding the key.
+			{PrincipalID: creator.ID, SpaceRef: "space", RepoRef: "space/repo", Permission: enum.PermissionRepoPush}: true,
+		}},
+		nil,
+		&repoStoreFake{repos: []*types.Repository{{ID: 1, Identifier: "repo", Path: "space/repo"}}},
+	)
+
+	session := &auth.Session{
+		Principal: creator,
+		Metadata:  &auth.DeployKeyMetadata{DeployKeyID: 1, RepoID: 1, Write: true},
+	}
+	resource := &types.Resource{Type: enum.ResourceTypeRepo, Identifier: "repo"}
+	scope := &types.Scope{SpacePath: "space"}
+
+	tests := []struct {
+		permission enum.Permission
+		want       bool
+	}{
+		{permission: enum.PermissionRepoView, want: true},
+		{permission: enum.PermissionRepoPush, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.permission), func(t *testing.T) {
+			got, err := authorizer.Check(context.Background(), session, scope, resource, test.permission)
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+			if got != test.want {
+				t.Errorf("Want authorized %t, got %t", test.want, got)
+			}
+		})
+	}
+}
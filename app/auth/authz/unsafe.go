@@ -50,6 +50,21 @@ func (a *UnsafeAuthorizer) Check(ctx context.Context, session *auth.Session,
 
 	return true, nil
 }
+func (a *UnsafeAuthorizer) CheckPrincipal(ctx context.Context, principal *types.Principal,
+	scope *types.Scope, resource *types.Resource, permission enum.Permission) (bool, error) {
+	log.Ctx(ctx).Info().Msgf(
+		"[Authz] %s with id '%d' is checked for %s for %s '%s' in scope %#v",
+		principal.Type,
+		principal.ID,
+		permission,
+		resource.Type,
+		resource.Identifier,
+		scope,
+	)
+
+	return true, nil
+}
+
 func (a *UnsafeAuthorizer) CheckAll(ctx context.Context, session *auth.Session,
 	permissionChecks ...types.PermissionCheck) (bool, error) {
 	for i := range permissionChecks {
@@ -31,12 +31,35 @@ func (m *EmptyMetadata) ImpactsAuthorization() bool {
 type TokenMetadata struct {
 	TokenType enum.TokenType
 	TokenID   int64
+	Scopes    []enum.TokenScope
 }
 
 func (m *TokenMetadata) ImpactsAuthorization() bool {
 	return false
 }
 
+// IsScoped returns true if the token is restricted to the scopes it was granted.
+// NOTE: Tokens without any scopes (e.g. created before scopes were introduced) aren't restricted.
+func (m *TokenMetadata) IsScoped() bool {
+	return len(m.Scopes) > 0
+}
+
+// HasScope returns true if the token was granted the provided scope.
+// NOTE: Tokens without any scopes aren't restricted.
+func (m *TokenMetadata) HasScope(scope enum.TokenScope) bool {
+	if !m.IsScoped() {
+		return true
+	}
+
+	for _, granted := range m.Scopes {
+		if granted.Includes(scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MembershipMetadata contains information about an ephemeral membership grant.
 type MembershipMetadata struct {
 	SpaceID int64
@@ -46,3 +69,36 @@ type MembershipMetadata struct {
 func (m *MembershipMetadata) ImpactsAuthorization() bool {
 	return true
 }
+
+// DeployKeyMetadata contains information about the deploy key that was used during auth.
+// Deploy keys only grant access to the repository they belong to.
+type DeployKeyMetadata struct {
+	DeployKeyID int64
+	RepoID      int64
+	Write       bool
+}
+
+func (m *DeployKeyMetadata) ImpactsAuthorization() bool {
+	return true
+}
+
+// CanGrantScopes returns true if a token with the provided scopes can be created by the session.
+// Scoped sessions can only create tokens with a subset of their own scopes and no unscoped tokens.
+func CanGrantScopes(session *Session, scopes []enum.TokenScope) bool {
+	tokenMetadata, ok := session.Metadata.(*TokenMetadata)
+	if !ok || !tokenMetadata.IsScoped() {
+		return true
+	}
+
+	if len(scopes) == 0 {
+		return false
+	}
+
+	for _, scope := range scopes {
+		if !tokenMetadata.HasScope(scope) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// ErrInvalidToken is returned if the provider didn't return a valid ID token.
+var ErrInvalidToken = errors.New("invalid id token")
+
+// AuthSource is the auth source of users that are provisioned by or linked to the identity provider.
+const AuthSource = "oidc"
+
+// Claims contains the verified claims of an ID token.
+type Claims struct {
+	Issuer            string `json:"iss"`
+	Subject           string `json:"sub"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Nonce             string `json:"nonce"`
+}
+
+// ExternalID returns the id of the user at the identity provider.
+// NOTE: The subject is only unique per issuer, so both are part of the id.
+func (c *Claims) ExternalID() string {
+	return c.Issuer + "#" + c.Subject
+}
+
+// Provider is an abstraction of an OpenID Connect identity provider.
+type Provider interface {
+	// AuthCodeURL returns the url of the login page of the provider.
+	AuthCodeURL(ctx context.Context, state string, nonce string) (string, error)
+
+	// Exchange exchanges the authorization code for the verified claims of the ID token.
+	// Returns ErrInvalidToken if the ID token is missing or can't be verified.
+	Exchange(ctx context.Context, code string) (*Claims, error)
+}
+
+// Config contains the configuration of the identity provider.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+var _ Provider = (*IdentityProvider)(nil)
+
+// IdentityProvider is the Provider implementation for OpenID Connect compliant identity providers.
+// The provider metadata is discovered with the first use, to not block the startup if the provider isn't reachable.
+type IdentityProvider struct {
+	config Config
+
+	mx           sync.Mutex
+	oauth2Config *oauth2.Config
+	verifier     *gooidc.IDTokenVerifier
+}
+
+func NewIdentityProvider(config Config) *IdentityProvider {
+	return &IdentityProvider{
+		config: config,
+	}
+}
+
+func (p *IdentityProvider) AuthCodeURL(_ context.Context, state string, nonce string) (string, error) {
+	oauth2Config, _, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	return oauth2Config.AuthCodeURL(state, gooidc.Nonce(nonce)), nil
+}
+
+func (p *IdentityProvider) Exchange(ctx context.Context, code string) (*Claims, error) {
+	oauth2Config, verifier, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauth2Config.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, ErrInvalidToken
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	claims := &Claims{}
+	if err = idToken.Claims(claims); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse claims: %w", ErrInvalidToken, err)
+	}
+
+	return claims, nil
+}
+
+func (p *IdentityProvider) discover() (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.oauth2Config != nil {
+		return p.oauth2Config, p.verifier, nil
+	}
+
+	// the provider keeps using the context for fetching the signing keys - don't tie it to the request.
+	provider, err := gooidc.NewProvider(context.Background(), p.config.Issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover oidc provider %q: %w", p.config.Issuer, err)
+	}
+
+	p.oauth2Config = &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       p.config.Scopes,
+	}
+	p.verifier = provider.Verifier(&gooidc.Config{ClientID: p.config.ClientID})
+
+	return p.oauth2Config, p.verifier, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+
+	// argon2idMaxMemory, argon2idMaxIterations and argon2idMaxKeyLength bound the cost of a single
+	// verification, as the parameters of a hash are taken from the (untrusted) hash itself.
+	argon2idMaxMemory     = 4 * 1024 * 1024 // 4 GiB
+	argon2idMaxIterations = 64
+	argon2idMaxKeyLength  = 128
+)
+
+// ErrInvalidArgon2idParams is returned if argon2id parameters are out of the supported range.
+var ErrInvalidArgon2idParams = errors.New("invalid argon2id parameters")
+
+var _ Hasher = (*Argon2id)(nil)
+
+// Argon2idParams are the cost parameters of argon2id.
+type Argon2idParams struct {
+	// Memory is the amount of memory used in KiB.
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// Argon2id hashes passwords using argon2id.
+// Hashes are encoded in the PHC string format, e.g. "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>".
+type Argon2id struct {
+	params Argon2idParams
+}
+
+// Validate returns ErrInvalidArgon2idParams if the parameters can't be used to compute a hash
+// or exceed the supported maximum cost.
+func (p Argon2idParams) Validate() error {
+	if p.Parallelism < 1 {
+		return fmt.Errorf("%w: parallelism has to be at least 1", ErrInvalidArgon2idParams)
+	}
+	if p.Iterations < 1 || p.Iterations > argon2idMaxIterations {
+		return fmt.Errorf("%w: iterations have to be between 1 and %d",
+			ErrInvalidArgon2idParams, argon2idMaxIterations)
+	}
+	if p.Memory < 8*uint32(p.Parallelism) || p.Memory > argon2idMaxMemory {
+		return fmt.Errorf("%w: memory has to be between %d KiB (8 KiB per lane) and %d KiB",
+			ErrInvalidArgon2idParams, 8*uint32(p.Parallelism), argon2idMaxMemory)
+	}
+
+	return nil
+}
+
+func NewArgon2id(params Argon2idParams) (*Argon2id, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Argon2id{
+		params: params,
+	}, nil
+}
+
+func (a *Argon2id) Algorithm() Algorithm {
+	return AlgorithmArgon2id
+}
+
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt,
+		a.params.Iterations, a.params.Memory, a.params.Parallelism, argon2idKeyLength)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		AlgorithmArgon2id, argon2.Version,
+		a.params.Memory, a.params.Iterations, a.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a *Argon2id) Verify(hash string, password string) error {
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // the key length is bounded by decodeArgon2id.
+	actual := argon2.IDKey([]byte(password), salt,
+		params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return ErrMismatch
+	}
+
+	return nil
+}
+
+func (a *Argon2id) NeedsRehash(hash string) bool {
+	params, _, _, err := decodeArgon2id(hash)
+	return err != nil || params != a.params
+}
+
+func decodeArgon2id(hash string) (Argon2idParams, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=65536,t=3,p=4", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != string(AlgorithmArgon2id) {
+		return Argon2idParams{}, nil, nil, errors.New("invalid argon2id hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return Argon2idParams{}, nil, nil, fmt.Errorf("invalid argon2id version: %w", err)
+	}
+	if version != argon2.Version {
+		return Argon2idParams{}, nil, nil, fmt.Errorf("unsupported argon2id version %d", version)
+	}
+
+	var params Argon2idParams
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return Argon2idParams{}, nil, nil, fmt.Errorf("invalid argon2id parameters: %w", err)
+	}
+	if err = params.Validate(); err != nil {
+		return Argon2idParams{}, nil, nil, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2idParams{}, nil, nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 || len(key) > argon2idMaxKeyLength {
+		return Argon2idParams{}, nil, nil, errors.New("invalid argon2id key")
+	}
+
+	return params, salt, key, nil
+}
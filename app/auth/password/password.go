@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Algorithm is the algorithm a password hash was produced with.
+type Algorithm string
+
+const (
+	AlgorithmBcrypt   Algorithm = "bcrypt"
+	AlgorithmArgon2id Algorithm = "argon2id"
+)
+
+var (
+	// ErrMismatch is returned if the password doesn't match the hash.
+	ErrMismatch = errors.New("password doesn't match the hash")
+
+	// ErrUnknownAlgorithm is returned if the algorithm of a hash isn't supported.
+	ErrUnknownAlgorithm = errors.New("unknown password hash algorithm")
+)
+
+// Hasher hashes passwords and verifies passwords against hashes.
+// Hashes are self-describing, they encode the algorithm and parameters that produced them.
+type Hasher interface {
+	// Algorithm returns the algorithm new hashes are produced with.
+	Algorithm() Algorithm
+
+	// Hash returns the encoded hash of the password.
+	Hash(password string) (string, error)
+
+	// Verify returns ErrMismatch if the password doesn't match the encoded hash.
+	Verify(hash string, password string) error
+
+	// NeedsRehash returns true if the hash wasn't produced with the current algorithm and parameters.
+	NeedsRehash(hash string) bool
+}
+
+// AlgorithmOf returns the algorithm the encoded hash was produced with.
+func AlgorithmOf(hash string) (Algorithm, error) {
+	switch {
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return AlgorithmBcrypt, nil
+	case strings.HasPrefix(hash, "$"+string(AlgorithmArgon2id)+"$"):
+		return AlgorithmArgon2id, nil
+	default:
+		return "", ErrUnknownAlgorithm
+	}
+}
+
+var _ Hasher = (*MultiHasher)(nil)
+
+// MultiHasher produces new hashes with a default hasher, but verifies hashes of all known algorithms.
+// This allows existing hashes to be upgraded to the default algorithm over time.
+type MultiHasher struct {
+	defaultHasher Hasher
+	hashers       map[Algorithm]Hasher
+}
+
+// NewMultiHasher returns a new MultiHasher using the hasher of the default algorithm for new hashes.
+func NewMultiHasher(defaultAlgorithm Algorithm, hashers ...Hasher) (*MultiHasher, error) {
+	m := &MultiHasher{
+		hashers: make(map[Algorithm]Hasher, len(hashers)),
+	}
+	for _, h := range hashers {
+		m.hashers[h.Algorithm()] = h
+	}
+
+	defaultHasher, ok := m.hashers[defaultAlgorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, defaultAlgorithm)
+	}
+	m.defaultHasher = defaultHasher
+
+	return m, nil
+}
+
+func (m *MultiHasher) Algorithm() Algorithm {
+	return m.defaultHasher.Algorithm()
+}
+
+func (m *MultiHasher) Hash(password string) (string, error) {
+	return m.defaultHasher.Hash(password)
+}
+
+func (m *MultiHasher) Verify(hash string, password string) error {
+	h, err := m.hasherOf(hash)
+	if err != nil {
+		return err
+	}
+
+	return h.Verify(hash, password)
+}
+
+func (m *MultiHasher) NeedsRehash(hash string) bool {
+	algorithm, err := AlgorithmOf(hash)
+	if err != nil || algorithm != m.defaultHasher.Algorithm() {
+		return true
+	}
+
+	return m.defaultHasher.NeedsRehash(hash)
+}
+
+func (m *MultiHasher) hasherOf(hash string) (Hasher, error) {
+	algorithm, err := AlgorithmOf(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	h, ok := m.hashers[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, algorithm)
+	}
+
+	return h, nil
+}
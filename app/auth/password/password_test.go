@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// legacyBcryptHash is a bcrypt hash (cost 4) of "correct horse" as stored by previous versions.
+const legacyBcryptHash = "$2a$04$j6kwjfNwaasT4Kcekf9LWOFBl/FdO6.mX3ufjmZJO1AJpcOdqgF7W"
+
+var testArgon2idParams = Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1}
+
+func newTestHasher(t *testing.T, defaultAlgorithm Algorithm) *MultiHasher {
+	t.Helper()
+
+	argon2id, err := NewArgon2id(testArgon2idParams)
+	if err != nil {
+		t.Fatalf("failed to create argon2id hasher: %v", err)
+	}
+
+	h, err := NewMultiHasher(defaultAlgorithm, NewBcrypt(bcrypt.MinCost), argon2id)
+	if err != nil {
+		t.Fatalf("failed to create hasher: %v", err)
+	}
+
+	return h
+}
+
+func TestAlgorithmOf(t *testing.T) {
+	tests := []struct {
+		hash string
+		want Algorithm
+		err  error
+	}{
+		{hash: legacyBcryptHash, want: AlgorithmBcrypt},
+		{hash: "$2b$10$abc", want: AlgorithmBcrypt},
+		{hash: "$argon2id$v=19$m=64,t=1,p=1$c2FsdA$a2V5", want: AlgorithmArgon2id},
+		{hash: "$argon2i$v=19$m=64,t=1,p=1$c2FsdA$a2V5", err: ErrUnknownAlgorithm},
+		{hash: "plain", err: ErrUnknownAlgorithm},
+	}
+
+	for _, test := range tests {
+		got, err := AlgorithmOf(test.hash)
+		if !errors.Is(err, test.err) {
+			t.Errorf("Want error %v for %q, got %v", test.err, test.hash, err)
+		}
+		if got != test.want {
+			t.Errorf("Want algorithm %q for %q, got %q", test.want, test.hash, got)
+		}
+	}
+}
+
+func TestMultiHasher_VerifiesLegacyBcrypt(t *testing.T) {
+	h := newTestHasher(t, AlgorithmArgon2id)
+
+	if err := h.Verify(legacyBcryptHash, "correct horse"); err != nil {
+		t.Errorf("Want legacy bcrypt hash to verify, got %v", err)
+	}
+	if err := h.Verify(legacyBcryptHash, "wrong horse"); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Want ErrMismatch, got %v", err)
+	}
+	if !h.NeedsRehash(legacyBcryptHash) {
+		t.Errorf("Want legacy bcrypt hash to need a rehash")
+	}
+}
+
+func TestMultiHasher_UpgradesToArgon2id(t *testing.T) {
+	h := newTestHasher(t, AlgorithmArgon2id)
+
+	hash, err := h.Hash("correct horse")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("Want hash to encode algorithm and parameters, got %q", hash)
+	}
+	if err = h.Verify(hash, "correct horse"); err != nil {
+		t.Errorf("Want argon2id hash to verify, got %v", err)
+	}
+	if err = h.Verify(hash, "wrong horse"); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Want ErrMismatch, got %v", err)
+	}
+	if h.NeedsRehash(hash) {
+		t.Errorf("Want hash of the default algorithm to not need a rehash")
+	}
+
+	stronger, err := NewArgon2id(Argon2idParams{Memory: 128, Iterations: 1, Parallelism: 1})
+	if err != nil {
+		t.Fatalf("failed to create argon2id hasher: %v", err)
+	}
+	if !stronger.NeedsRehash(hash) {
+		t.Errorf("Want hash with outdated parameters to need a rehash")
+	}
+}
+
+func TestBcrypt_NeedsRehashOnCostChange(t *testing.T) {
+	if NewBcrypt(bcrypt.MinCost).NeedsRehash(legacyBcryptHash) {
+		t.Errorf("Want hash with matching cost to not need a rehash")
+	}
+	if !NewBcrypt(bcrypt.DefaultCost).NeedsRehash(legacyBcryptHash) {
+		t.Errorf("Want hash with different cost to need a rehash")
+	}
+}
+
+func TestNewMultiHasher_UnknownDefault(t *testing.T) {
+	_, err := NewMultiHasher("scrypt", NewBcrypt(bcrypt.MinCost))
+	if !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("Want ErrUnknownAlgorithm, got %v", err)
+	}
+}
+
+func TestNewArgon2id_InvalidParams(t *testing.T) {
+	tests := []Argon2idParams{
+		{Memory: 64, Iterations: 0, Parallelism: 1},
+		{Memory: 64, Iterations: 1, Parallelism: 0},
+		{Memory: 64, Iterations: argon2idMaxIterations + 1, Parallelism: 1},
+		{Memory: 16, Iterations: 1, Parallelism: 4},
+		{Memory: argon2idMaxMemory + 1, Iterations: 1, Parallelism: 1},
+	}
+
+	for _, params := range tests {
+		if _, err := NewArgon2id(params); !errors.Is(err, ErrInvalidArgon2idParams) {
+			t.Errorf("Want ErrInvalidArgon2idParams for %+v, got %v", params, err)
+		}
+	}
+}
+
+func TestArgon2id_VerifyRejectsInvalidParams(t *testing.T) {
+	h := newTestHasher(t, AlgorithmArgon2id)
+
+	hashes := []string{
+		"$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHRzYWx0$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=0$c2FsdHNhbHRzYWx0$a2V5",
+		"$argon2id$v=19$m=4294967295,t=1,p=1$c2FsdHNhbHRzYWx0$a2V5",
+		"$argon2id$v=19$m=64,t=4294967295,p=1$c2FsdHNhbHRzYWx0$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0$" + strings.Repeat("a", 512),
+	}
+
+	for _, hash := range hashes {
+		err := h.Verify(hash, "correct horse")
+		if err == nil || errors.Is(err, ErrMismatch) {
+			t.Errorf("Want invalid hash error for %q, got %v", hash, err)
+		}
+		if !h.NeedsRehash(hash) {
+			t.Errorf("Want invalid hash %q to need a rehash", hash)
+		}
+	}
+}
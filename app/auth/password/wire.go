@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package password
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideHasher,
+)
+
+// ProvideHasher provides a hasher that creates hashes with the configured algorithm
+// and verifies the hashes of all supported algorithms.
+func ProvideHasher(config *types.Config) (Hasher, error) {
+	argon2id, err := NewArgon2id(Argon2idParams{
+		Memory:      config.Auth.PasswordHash.Argon2id.Memory,
+		Iterations:  config.Auth.PasswordHash.Argon2id.Iterations,
+		Parallelism: config.Auth.PasswordHash.Argon2id.Parallelism,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create argon2id hasher: %w", err)
+	}
+
+	return NewMultiHasher(
+		Algorithm(config.Auth.PasswordHash.Algorithm),
+		NewBcrypt(config.Auth.PasswordHash.BcryptCost),
+		argon2id,
+	)
+}
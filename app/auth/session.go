@@ -25,4 +25,7 @@ type Session struct {
 
 	// Metadata contains auth related information (access grants, tokenId, sshKeyId, ...)
 	Metadata Metadata
+
+	// Impersonator is the admin acting as the principal (nil if the session isn't impersonated).
+	Impersonator *types.Principal
 }
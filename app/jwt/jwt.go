@@ -15,6 +15,7 @@
 package jwt
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/harness/gitness/types"
@@ -42,6 +43,8 @@ type Claims struct {
 type SubClaimsToken struct {
 	Type enum.TokenType `json:"typ,omitempty"`
 	ID   int64          `json:"id,omitempty"`
+	// ImpersonatorID is the id of the admin acting as the principal (only set for impersonation tokens).
+	ImpersonatorID int64 `json:"iid,omitempty"`
 }
 
 // SubClaimsMembership contains the ephemeral membership the JWT was created with.
@@ -52,12 +55,42 @@ type SubClaimsMembership struct {
 
 // GenerateForToken generates a jwt for a given token.
 func GenerateForToken(token *types.Token, secret string) (string, error) {
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claimsForToken(token))
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
+
+// GenerateForTokenWithKey generates a jwt for a given token signed with the provided signing key.
+// The id of the key is added as 'kid' header to allow verification after key rotations.
+func GenerateForTokenWithKey(token *types.Token, key SigningKey) (string, error) {
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claimsForToken(token))
+	jwtToken.Header["kid"] = key.ID
+
+	res, err := jwtToken.SignedString(key.PrivateKey)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
+
+func claimsForToken(token *types.Token) Claims {
 	var expiresAt int64
 	if token.ExpiresAt != nil {
 		expiresAt = *token.ExpiresAt
 	}
 
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+	var impersonatorID int64
+	if token.Type == enum.TokenTypeImpersonation {
+		impersonatorID = token.CreatedBy
+	}
+
+	return Claims{
 		StandardClaims: jwt.StandardClaims{
 			Issuer: issuer,
 			// times required to be in sec not millisec
@@ -66,17 +99,47 @@ func GenerateForToken(token *types.Token, secret string) (string, error) {
 		},
 		PrincipalID: token.PrincipalID,
 		Token: &SubClaimsToken{
-			Type: token.Type,
-			ID:   token.ID,
+			Type:           token.Type,
+			ID:             token.ID,
+			ImpersonatorID: impersonatorID,
 		},
-	})
+	}
+}
 
-	res, err := jwtToken.SignedString([]byte(secret))
+// Parse parses and verifies the provided jwt into claims.
+// JWTs with a 'kid' header are verified against the key set, all other JWTs are expected
+// to be HMAC signed with the secret returned by secretFn (called with the parsed claims).
+func Parse(str string, claims *Claims, keySet *KeySet, secretFn func(*Claims) (string, error)) error {
+	parsed, err := jwt.ParseWithClaims(str, claims, func(token *jwt.Token) (interface{}, error) {
+		if kid, ok := token.Header["kid"].(string); ok {
+			if _, ok = token.Method.(*jwt.SigningMethodRSA); !ok {
+				return nil, fmt.Errorf("unexpected signing method %q for signing key %q", token.Method.Alg(), kid)
+			}
+			if keySet == nil {
+				return nil, fmt.Errorf("unknown signing key %q", kid)
+			}
+			return keySet.Find(kid, time.Now())
+		}
+
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid HMAC signature for JWT")
+		}
+
+		secret, err := secretFn(claims)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(secret), nil
+	})
 	if err != nil {
-		return "", errors.Wrap(err, "Failed to sign token")
+		return fmt.Errorf("parsing of JWT claims failed: %w", err)
 	}
 
-	return res, nil
+	if !parsed.Valid {
+		return errors.New("parsed JWT token is invalid")
+	}
+
+	return nil
 }
 
 // GenerateWithMembership generates a jwt with the given ephemeral membership.
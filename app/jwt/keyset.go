@@ -0,0 +1,255 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwt
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// SigningKey is an asymmetric key used to sign JWTs.
+type SigningKey struct {
+	// ID is the unique identifier of the key, it's added as 'kid' header to all JWTs signed with the key.
+	ID         string
+	PrivateKey *rsa.PrivateKey
+	// Created is the time the key becomes the current key (and replaces all older keys).
+	Created time.Time
+	// Retired is the time the key got replaced by a newer key (zero for the current key).
+	Retired time.Time
+}
+
+// KeySet is a set of rotating signing keys.
+// New JWTs are signed with the current (newest) key, while retired keys remain valid
+// for verification until their grace period is over.
+type KeySet struct {
+	mx          sync.RWMutex
+	keys        []SigningKey // ordered from oldest to newest.
+	path        string
+	gracePeriod time.Duration
+}
+
+// NewKeySet returns a new key set with the provided keys.
+// The grace period should cover the max lifetime of the tokens signed with the keys,
+// a grace period of 0 keeps retired keys valid for verification as long as they are part of the set.
+func NewKeySet(gracePeriod time.Duration, keys ...SigningKey) *KeySet {
+	return &KeySet{
+		keys:        orderKeys(keys),
+		gracePeriod: gracePeriod,
+	}
+}
+
+// LoadKeySet loads all PEM encoded RSA private keys (*.pem) from the provided directory.
+// The file name (without extension) is used as key id and has to start with the creation time of the key
+// as unix timestamp (e.g. "1700000000.pem" or "1700000000-primary.pem"). The keys are ordered by creation time,
+// meaning a key is considered retired as soon as a newer key got created.
+// An empty path returns an empty key set.
+func LoadKeySet(path string, gracePeriod time.Duration) (*KeySet, error) {
+	keySet := NewKeySet(gracePeriod)
+	keySet.path = path
+
+	if err := keySet.Reload(); err != nil {
+		return nil, err
+	}
+
+	return keySet, nil
+}
+
+// Reload replaces the keys of the set with the keys currently stored in the directory the set was loaded from.
+// This allows rotating keys by adding a new key file (and removing expired ones) without a restart.
+// The keys remain unchanged in case loading fails.
+func (s *KeySet) Reload() error {
+	if s.path == "" {
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.path, "*.pem"))
+	if err != nil {
+		return fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	keys := make([]SigningKey, 0, len(files))
+	for _, file := range files {
+		id := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+
+		created, err := parseKeyCreated(id)
+		if err != nil {
+			return fmt.Errorf("invalid name of signing key %q: %w", file, err)
+		}
+
+		key, err := readPrivateKey(file)
+		if err != nil {
+			return err
+		}
+
+		keys = append(keys, SigningKey{
+			ID:         id,
+			PrivateKey: key,
+			Created:    created,
+		})
+	}
+
+	keys = orderKeys(keys)
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.keys = keys
+
+	return nil
+}
+
+// parseKeyCreated returns the creation time encoded as unix timestamp at the beginning of the key id.
+func parseKeyCreated(id string) (time.Time, error) {
+	timestamp, _, _ := strings.Cut(id, "-")
+
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("the key id has to start with the unix timestamp of the key creation: %w", err)
+	}
+
+	return time.Unix(sec, 0), nil
+}
+
+// orderKeys orders the keys from oldest to newest and sets the retirement time of all replaced keys.
+func orderKeys(keys []SigningKey) []SigningKey {
+	slices.SortStableFunc(keys, func(a, b SigningKey) bool {
+		return a.Created.Before(b.Created)
+	})
+
+	for i := range keys {
+		keys[i].Retired = time.Time{}
+		if i < len(keys)-1 {
+			keys[i].Retired = keys[i+1].Created
+		}
+	}
+
+	return keys
+}
+
+func readPrivateKey(file string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key %q: %w", file, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %q isn't PEM encoded", file)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %q: %w", file, err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %q isn't an RSA key", file)
+	}
+
+	return rsaKey, nil
+}
+
+// Current returns the key used for signing new JWTs, which is the newest key that got created before now.
+// The second return value is false in case the key set doesn't contain such a key.
+func (s *KeySet) Current(now time.Time) (SigningKey, bool) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		if !s.keys[i].Created.After(now) {
+			return s.keys[i], true
+		}
+	}
+
+	return SigningKey{}, false
+}
+
+// Find returns the public key with the provided id.
+// An error is returned in case the key is unknown or its grace period is over.
+func (s *KeySet) Find(id string, now time.Time) (*rsa.PublicKey, error) {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	for _, k := range s.keys {
+		if k.ID != id {
+			continue
+		}
+		if s.expired(k, now) {
+			return nil, fmt.Errorf("signing key %q expired", id)
+		}
+		return &k.PrivateKey.PublicKey, nil
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", id)
+}
+
+func (s *KeySet) expired(key SigningKey, now time.Time) bool {
+	return s.gracePeriod > 0 && !key.Retired.IsZero() && now.After(key.Retired.Add(s.gracePeriod))
+}
+
+// JWK is the JSON web key representation of a public RSA signing key.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS is a JSON web key set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of all keys that are valid for verification.
+func (s *KeySet) JWKS(now time.Time) JWKS {
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	out := JWKS{Keys: []JWK{}}
+	for _, k := range s.keys {
+		if s.expired(k, now) {
+			continue
+		}
+		out.Keys = append(out.Keys, JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: "RS256",
+			Kid: k.ID,
+			N:   base64.RawURLEncoding.EncodeToString(k.PrivateKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.PrivateKey.E)).Bytes()),
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,229 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func newSigningKey(t *testing.T, id string, created time.Time) SigningKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	return SigningKey{ID: id, PrivateKey: key, Created: created}
+}
+
+func generateTestToken(t *testing.T, key SigningKey) string {
+	t.Helper()
+
+	now := time.Now()
+	str, err := GenerateForTokenWithKey(&types.Token{
+		ID:          1,
+		PrincipalID: 2,
+		Type:        enum.TokenTypeSession,
+		IssuedAt:    now.UnixMilli(),
+		ExpiresAt:   ptrInt64(now.Add(time.Hour).UnixMilli()),
+	}, key)
+	if err != nil {
+		t.Fatalf("failed to generate token: %s", err)
+	}
+
+	return str
+}
+
+func ptrInt64(v int64) *int64 {
+	return &v
+}
+
+func noSecret(*Claims) (string, error) {
+	return "", nil
+}
+
+func TestParse_RotatedKey(t *testing.T) {
+	const gracePeriod = 2 * time.Hour
+
+	now := time.Now()
+	oldKey := newSigningKey(t, "old", now.Add(-5*time.Hour))
+	str := generateTestToken(t, oldKey)
+
+	// rotated out an hour ago, the grace period isn't over yet.
+	keySet := NewKeySet(gracePeriod, newSigningKey(t, "new", now.Add(-time.Hour)), oldKey)
+
+	current, _ := keySet.Current(now)
+	if current.ID != "new" {
+		t.Fatalf("Want current key to be %q, got %q", "new", current.ID)
+	}
+
+	claims := &Claims{}
+	if err := Parse(str, claims, keySet, noSecret); err != nil {
+		t.Fatalf("Want token of rotated out key to be valid, got %s", err)
+	}
+	if claims.PrincipalID != 2 || claims.Token == nil || claims.Token.ID != 1 {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+
+	// tokens of the new key are valid as well.
+	if err := Parse(generateTestToken(t, current), &Claims{}, keySet, noSecret); err != nil {
+		t.Errorf("Want token of current key to be valid, got %s", err)
+	}
+}
+
+func TestParse_ExpiredKey(t *testing.T) {
+	const gracePeriod = 2 * time.Hour
+
+	now := time.Now()
+	oldKey := newSigningKey(t, "old", now.Add(-5*time.Hour))
+	str := generateTestToken(t, oldKey)
+
+	// rotated out three hours ago, the grace period is over.
+	keySet := NewKeySet(gracePeriod, oldKey, newSigningKey(t, "new", now.Add(-3*time.Hour)))
+
+	if err := Parse(str, &Claims{}, keySet, noSecret); err == nil {
+		t.Errorf("Want token of expired key to be rejected, got no error")
+	}
+
+	jwks := keySet.JWKS(time.Now())
+	if len(jwks.Keys) != 1 || jwks.Keys[0].Kid != "new" {
+		t.Errorf("Want only the new key to be published, got %+v", jwks.Keys)
+	}
+}
+
+func TestParse_UnknownKey(t *testing.T) {
+	keySet := NewKeySet(time.Hour, newSigningKey(t, "known", time.Now()))
+	str := generateTestToken(t, newSigningKey(t, "unknown", time.Now()))
+
+	if err := Parse(str, &Claims{}, keySet, noSecret); err == nil {
+		t.Errorf("Want token of unknown key to be rejected, got no error")
+	}
+}
+
+func TestParse_Secret(t *testing.T) {
+	str, err := GenerateForToken(&types.Token{ID: 1, PrincipalID: 2, Type: enum.TokenTypePAT}, "salt")
+	if err != nil {
+		t.Fatalf("failed to generate token: %s", err)
+	}
+
+	secretFn := func(secret string) func(*Claims) (string, error) {
+		return func(*Claims) (string, error) { return secret, nil }
+	}
+
+	keySet := NewKeySet(time.Hour, newSigningKey(t, "key", time.Now()))
+	if err = Parse(str, &Claims{}, keySet, secretFn("salt")); err != nil {
+		t.Errorf("Want token signed with the secret to be valid, got %s", err)
+	}
+	if err = Parse(str, &Claims{}, keySet, secretFn("other")); err == nil {
+		t.Errorf("Want token signed with another secret to be rejected, got no error")
+	}
+}
+
+func writeSigningKey(t *testing.T, dir string, key SigningKey) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key.PrivateKey)})
+	if err := os.WriteFile(filepath.Join(dir, key.ID+".pem"), data, 0o600); err != nil {
+		t.Fatalf("failed to write key: %s", err)
+	}
+}
+
+func keyID(created time.Time, name string) string {
+	return strconv.FormatInt(created.Unix(), 10) + "-" + name
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+
+	now := time.Now()
+	first := newSigningKey(t, keyID(now.Add(-2*time.Hour), "first"), time.Time{})
+	second := newSigningKey(t, keyID(now.Add(-time.Hour), "second"), time.Time{})
+	upcoming := newSigningKey(t, keyID(now.Add(time.Hour), "upcoming"), time.Time{})
+	// the files are written in reverse order to ensure the keys aren't ordered by modification time.
+	for _, key := range []SigningKey{upcoming, second, first} {
+		writeSigningKey(t, dir, key)
+	}
+
+	keySet, err := LoadKeySet(dir, 30*time.Minute)
+	if err != nil {
+		t.Fatalf("failed to load key set: %s", err)
+	}
+
+	// the upcoming key is published, but not used for signing before it was created.
+	current, ok := keySet.Current(now)
+	if !ok || current.ID != second.ID {
+		t.Fatalf("Want current key to be %q, got %q", second.ID, current.ID)
+	}
+	if _, err = keySet.Find(upcoming.ID, now); err != nil {
+		t.Errorf("Want upcoming key to be valid for verification, got %s", err)
+	}
+
+	// the first key got retired an hour ago, which is past the grace period.
+	if _, err = keySet.Find(first.ID, now); err == nil {
+		t.Errorf("Want first key to be expired, got no error")
+	}
+	if _, err = keySet.Find(first.ID, now.Add(-45*time.Minute)); err != nil {
+		t.Errorf("Want first key to be valid within the grace period, got %s", err)
+	}
+}
+
+func TestLoadKeySet_InvalidName(t *testing.T) {
+	dir := t.TempDir()
+	writeSigningKey(t, dir, newSigningKey(t, "primary", time.Time{}))
+
+	if _, err := LoadKeySet(dir, time.Hour); err == nil {
+		t.Errorf("Want key without creation time to be rejected, got no error")
+	}
+}
+
+func TestKeySet_Reload(t *testing.T) {
+	dir := t.TempDir()
+
+	now := time.Now()
+	oldKey := newSigningKey(t, keyID(now.Add(-time.Hour), "old"), time.Time{})
+	writeSigningKey(t, dir, oldKey)
+
+	keySet, err := LoadKeySet(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("failed to load key set: %s", err)
+	}
+
+	newKey := newSigningKey(t, keyID(now, "new"), time.Time{})
+	writeSigningKey(t, dir, newKey)
+
+	if err = keySet.Reload(); err != nil {
+		t.Fatalf("failed to reload key set: %s", err)
+	}
+
+	current, ok := keySet.Current(now)
+	if !ok || current.ID != newKey.ID {
+		t.Fatalf("Want current key to be %q after reload, got %q", newKey.ID, current.ID)
+	}
+	if _, err = keySet.Find(oldKey.ID, now); err != nil {
+		t.Errorf("Want rotated out key to be valid within the grace period, got %s", err)
+	}
+}
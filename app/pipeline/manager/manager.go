@@ -30,6 +30,7 @@ import (
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/livelog"
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -142,6 +143,7 @@ type Manager struct {
 	Repos     store.RepoStore
 	Scheduler scheduler.Scheduler
 	Secrets   store.SecretStore
+	Encrypter encrypt.Encrypter
 	// Status  store.StatusService
 	Stages store.StageStore
 	Steps  store.StepStore
@@ -167,6 +169,7 @@ func New(
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
+	encrypter encrypt.Encrypter,
 ) *Manager {
 	return &Manager{
 		Config:           config,
@@ -182,6 +185,7 @@ func New(
 		Repos:            repoStore,
 		Scheduler:        scheduler,
 		Secrets:          secretStore,
+		Encrypter:        encrypter,
 		Stages:           stageStore,
 		Steps:            stepStore,
 		Users:            userStore,
@@ -314,9 +318,7 @@ func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext,
 		Str("repo", repo.GetGitUID()).
 		Logger()
 
-	// TODO: Currently we fetch all the secrets from the same space.
-	// This logic can be updated when needed.
-	secrets, err := m.Secrets.ListAll(noContext, repo.ParentID)
+	secrets, err := m.resolveSecrets(noContext, repo)
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: cannot list secrets")
 		return nil, err
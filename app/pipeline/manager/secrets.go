@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// resolveSecrets returns the decrypted secrets available to executions of the repository.
+// Secrets of the repository take precedence over secrets of its parent space with the same identifier.
+// NOTE: the decrypted values are only handed to the runner, which masks them in the step logs.
+func (m *Manager) resolveSecrets(ctx context.Context, repo *types.Repository) ([]*types.Secret, error) {
+	spaceSecrets, err := m.Secrets.ListAll(ctx, repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets of space: %w", err)
+	}
+
+	repoSecrets, err := m.Secrets.ListAllForRepo(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets of repo: %w", err)
+	}
+
+	overridden := make(map[string]struct{}, len(repoSecrets))
+	for _, secret := range repoSecrets {
+		overridden[secret.Identifier] = struct{}{}
+	}
+
+	secrets := make([]*types.Secret, 0, len(spaceSecrets)+len(repoSecrets))
+	for _, secret := range spaceSecrets {
+		if _, ok := overridden[secret.Identifier]; !ok {
+			secrets = append(secrets, secret)
+		}
+	}
+	secrets = append(secrets, repoSecrets...)
+
+	for i, secret := range secrets {
+		plaintext, err := m.Encrypter.Decrypt([]byte(secret.Data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt secret %q: %w", secret.Identifier, err)
+		}
+
+		decrypted := *secret
+		decrypted.Data = plaintext
+		secrets[i] = &decrypted
+	}
+
+	return secrets, nil
+}
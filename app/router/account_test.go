@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-chi/chi"
+)
+
+func TestSetupAccount_PasswordResetLimitSeparateFromLogin(t *testing.T) {
+	config := &types.Config{}
+	config.RateLimit.Login = 1
+	config.RateLimit.PasswordReset = 2
+	config.RateLimit.Window = time.Minute
+
+	r := chi.NewRouter()
+	setupAccount(r, &user.Controller{}, nil, config)
+
+	post := func(path string) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}")))
+		return w.Code
+	}
+
+	// exhaust the password reset budget of the client.
+	for i := 0; i < 2; i++ {
+		if code := post("/password-reset"); code == http.StatusTooManyRequests {
+			t.Fatalf("Want password reset request %d to be allowed, got status %d", i, code)
+		}
+	}
+	if code := post("/password-reset"); code != http.StatusTooManyRequests {
+		t.Errorf("Want status %d once the password reset budget is spent, got %d", http.StatusTooManyRequests, code)
+	}
+
+	// the login budget is untouched by the password reset requests.
+	if code := post("/password-reset/confirm"); code == http.StatusTooManyRequests {
+		t.Errorf("Want login budget to be unaffected by password reset requests, got status %d", code)
+	}
+	if code := post("/password-reset/confirm"); code != http.StatusTooManyRequests {
+		t.Errorf("Want status %d once the login budget is spent, got %d", http.StatusTooManyRequests, code)
+	}
+}
+
+func TestSetupAccount_PasswordResetLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	config := &types.Config{}
+	config.RateLimit.PasswordReset = 1
+	config.RateLimit.Window = time.Minute
+
+	r := chi.NewRouter()
+	setupAccount(r, &user.Controller{}, nil, config)
+
+	post := func(forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/password-reset", strings.NewReader("{}"))
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		req.Header.Set("X-Real-IP", forwardedFor)
+		req.Header.Set("True-Client-IP", forwardedFor)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := post("10.0.0.1"); code == http.StatusTooManyRequests {
+		t.Fatalf("Want first password reset request to be allowed, got status %d", code)
+	}
+	if code := post("10.0.0.2"); code != http.StatusTooManyRequests {
+		t.Errorf("Want status %d for a request with a spoofed client IP, got %d", http.StatusTooManyRequests, code)
+	}
+}
@@ -18,11 +18,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/harness/gitness/app/api/controller/check"
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
+	"github.com/harness/gitness/app/api/controller/globalsearch"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/pipeline"
@@ -45,6 +47,7 @@ import (
 	handlerconnector "github.com/harness/gitness/app/api/handler/connector"
 	handlerexecution "github.com/harness/gitness/app/api/handler/execution"
 	handlergithook "github.com/harness/gitness/app/api/handler/githook"
+	handlerglobalsearch "github.com/harness/gitness/app/api/handler/globalsearch"
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
@@ -66,14 +69,23 @@ import (
 	handlerwebhook "github.com/harness/gitness/app/api/handler/webhook"
 	"github.com/harness/gitness/app/api/middleware/address"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
+	"github.com/harness/gitness/app/api/middleware/bodylimit"
 	"github.com/harness/gitness/app/api/middleware/encode"
+	middlewareflag "github.com/harness/gitness/app/api/middleware/featureflag"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	middlewaremaintenance "github.com/harness/gitness/app/api/middleware/maintenance"
 	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
+	"github.com/harness/gitness/app/api/middleware/ratelimit"
+	"github.com/harness/gitness/app/api/middleware/timeout"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/featureflag"
+	"github.com/harness/gitness/app/services/maintenance"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
+	gitnesshttp "github.com/harness/gitness/http"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -121,6 +133,11 @@ func NewAPIHandler(
 	sysCtrl *system.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	globalSearchCtrl *globalsearch.Controller,
+	readiness *gitnesshttp.Readiness,
+	flags *featureflag.Service,
+	maintenanceSvc *maintenance.Service,
+	auditService audit.Service,
 ) APIHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -130,7 +147,7 @@ func NewAPIHandler(
 	r.Use(middleware.Recoverer)
 
 	// configure logging middleware.
-	r.Use(hlog.URLHandler("http.url"))
+	r.Use(logging.HLogURLHandler("http.url"))
 	r.Use(hlog.MethodHandler("http.method"))
 	r.Use(logging.HLogRequestIDHandler())
 	r.Use(logging.HLogAccessLogHandler())
@@ -139,22 +156,97 @@ func NewAPIHandler(
 	// configure cors middleware
 	r.Use(corsHandler(config))
 
-	// for now always attempt auth - enforced per operation.
-	r.Use(middlewareauthn.Attempt(authenticator))
-
 	r.Use(audit.Middleware())
 
+	// for now always attempt auth - enforced per operation.
+	r.Use(middlewareauthn.Attempt(authenticator, auditService))
+	if !config.AnonymousAccessEnabled {
+		r.Use(middlewareauthn.DisableAnonymousAccess)
+	}
+
+	// configure rate limiting middleware (disabled if limit isn't set).
+	if config.RateLimit.Global > 0 {
+		r.Use(ratelimit.Global(ratelimit.NewFixedWindow(config.RateLimit.Global, config.RateLimit.Window)))
+	}
+	if config.RateLimit.Principal > 0 {
+		r.Use(ratelimit.PerPrincipal(
+			ratelimit.NewFixedWindow(config.RateLimit.Principal, config.RateLimit.Window),
+			config.RateLimit.TrustedProxies,
+		))
+	}
+
+	// configure request timeout middleware (the deadline can be overridden per route).
+	r.Use(timeout.Timeout(config.Timeout.Request))
+
+	// configure request body limit middleware (the limit can be overridden per route).
+	r.Use(bodylimit.Limit(config.BodyLimit.Request))
+
 	r.Route("/v1", func(r chi.Router) {
 		setupRoutesV1(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl, pipelineCtrl,
 			connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 			webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, uploadCtrl,
-			searchCtrl)
+			searchCtrl, globalSearchCtrl, readiness, flags, maintenanceSvc)
 	})
 
 	// wrap router in terminatedPath encoder.
 	return encode.TerminatedPathBefore(terminatedPathPrefixesAPI, r)
 }
 
+// routeScopes are the token scopes required to read and write a route.
+type routeScopes struct {
+	read  enum.TokenScope
+	write enum.TokenScope
+}
+
+// apiRouteScopes maps the top level routes of the api to the token scopes required to access them.
+// Scoped tokens are denied access to all routes that aren't listed (e.g. user, tokens, service accounts).
+var apiRouteScopes = map[string]routeScopes{
+	"spaces":     {read: enum.TokenScopeRepoRead, write: enum.TokenScopeRepoWrite},
+	"repos":      {read: enum.TokenScopeRepoRead, write: enum.TokenScopeRepoWrite},
+	"connectors": {read: enum.TokenScopeRepoRead, write: enum.TokenScopeRepoWrite},
+	"templates":  {read: enum.TokenScopeRepoRead, write: enum.TokenScopeRepoWrite},
+	"secrets":    {read: enum.TokenScopeRepoRead, write: enum.TokenScopeRepoWrite},
+	"plugins":    {read: enum.TokenScopeRepoRead, write: enum.TokenScopeRepoWrite},
+	"resources":  {read: enum.TokenScopeRepoRead, write: enum.TokenScopeRepoRead},
+	// search only reads, even though the keyword search is a POST request.
+	"search": {read: enum.TokenScopeRepoRead, write: enum.TokenScopeRepoRead},
+	"admin":  {read: enum.TokenScopeUserAdmin, write: enum.TokenScopeUserAdmin},
+}
+
+// apiScopeForRequest returns the token scope required for the api request based on its top level route.
+func apiScopeForRequest(r *http.Request) (enum.TokenScope, bool) {
+	routePath := r.URL.Path
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		routePath = rctx.RoutePath
+	}
+
+	segment, _, _ := strings.Cut(strings.TrimPrefix(routePath, "/"), "/")
+	scopes, ok := apiRouteScopes[segment]
+	if !ok {
+		return "", false
+	}
+
+	return middlewareauthz.ScopeForMethod(r.Method, scopes.read, scopes.write), true
+}
+
+// scopeLimiters returns the rate limiters of the token scopes (scopes without a configured limit aren't limited).
+func scopeLimiters(config *types.Config) map[enum.TokenScope]ratelimit.Limiter {
+	limits := map[enum.TokenScope]int{
+		enum.TokenScopeRepoRead:  config.RateLimit.Scope.RepoRead,
+		enum.TokenScopeRepoWrite: config.RateLimit.Scope.RepoWrite,
+		enum.TokenScopeUserAdmin: config.RateLimit.Scope.UserAdmin,
+	}
+
+	limiters := make(map[enum.TokenScope]ratelimit.Limiter)
+	for scope, limit := range limits {
+		if limit > 0 {
+			limiters[scope] = ratelimit.NewFixedWindow(limit, config.RateLimit.Window)
+		}
+	}
+
+	return limiters
+}
+
 func corsHandler(config *types.Config) func(http.Handler) http.Handler {
 	return cors.New(
 		cors.Options{
@@ -194,23 +286,66 @@ func setupRoutesV1(r chi.Router,
 	sysCtrl *system.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	globalSearchCtrl *globalsearch.Controller,
+	readiness *gitnesshttp.Readiness,
+	flags *featureflag.Service,
+	maintenanceSvc *maintenance.Service,
 ) {
+	// scoped tokens are restricted to the routes their scopes were granted for.
+	r.Use(middlewareauthz.RequireScopes(apiScopeForRequest))
+	if limiters := scopeLimiters(config); len(limiters) > 0 {
+		r.Use(ratelimit.PerScope(limiters, apiScopeForRequest))
+	}
+
+	r.Use(middlewaremaintenance.BlockWrites(maintenanceSvc, apiIsWriteRequest))
+
 	setupSpaces(r, appCtx, spaceCtrl)
-	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
-		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl)
+	setupRepos(r, config, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
+		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, secretCtrl, flags)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
-	setupUser(r, userCtrl)
+	setupUser(r, config, userCtrl)
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, sysCtrl, repoCtrl, maintenanceSvc)
 	setupAccount(r, userCtrl, sysCtrl, config)
-	setupSystem(r, config, sysCtrl)
+	setupSystem(r, config, sysCtrl, readiness, flags)
 	setupResources(r)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
+	setupGlobalSearch(r, globalSearchCtrl)
+}
+
+// maintenanceExemptRoutes are the write routes that aren't blocked in maintenance mode,
+// to ensure admins can always log in and disable it.
+var maintenanceExemptRoutes = map[string]string{
+	"/login":             http.MethodPost,
+	"/login/token":       http.MethodPost,
+	"/admin/maintenance": http.MethodPut,
+}
+
+// maintenanceExemptGitHooksPrefix is the prefix of the git hook routes, which are called by the hooks
+// of pushes that got through to git. The originating git request is already gated by the maintenance mode.
+const maintenanceExemptGitHooksPrefix = "/internal/git-hooks/"
+
+// apiIsWriteRequest returns whether the api request writes data and is blocked in maintenance mode.
+func apiIsWriteRequest(r *http.Request) bool {
+	routePath := r.URL.Path
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		routePath = rctx.RoutePath
+	}
+
+	if method, ok := maintenanceExemptRoutes[strings.TrimSuffix(routePath, "/")]; ok && method == r.Method {
+		return false
+	}
+
+	if strings.HasPrefix(routePath, maintenanceExemptGitHooksPrefix) {
+		return false
+	}
+
+	return middlewaremaintenance.IsWriteMethod(r)
 }
 
 // nolint: revive // it's the app context, it shouldn't be the first argument
@@ -228,7 +363,7 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 			r.Post("/restore", handlerspace.HandleRestore(spaceCtrl))
 			r.Post("/purge", handlerspace.HandlePurge(spaceCtrl))
 
-			r.Get("/events", handlerspace.HandleEvents(appCtx, spaceCtrl))
+			r.With(timeout.Override(0)).Get("/events", handlerspace.HandleEvents(appCtx, spaceCtrl))
 
 			r.Post("/import", handlerspace.HandleImportRepositories(spaceCtrl))
 			r.Post("/move", handlerspace.HandleMove(spaceCtrl))
@@ -254,6 +389,7 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 }
 
 func setupRepos(r chi.Router,
+	config *types.Config,
 	repoCtrl *repo.Controller,
 	repoSettingsCtrl *reposettings.Controller,
 	pipelineCtrl *pipeline.Controller,
@@ -264,11 +400,14 @@ func setupRepos(r chi.Router,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
+	secretCtrl *secret.Controller,
+	flags *featureflag.Service,
 ) {
 	r.Route("/repos", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerrepo.HandleCreate(repoCtrl))
 		r.Post("/import", handlerrepo.HandleImport(repoCtrl))
+		r.Post("/mirror", handlerrepo.HandleCreateMirror(repoCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
 			// repo level operations
 			r.Get("/", handlerrepo.HandleFind(repoCtrl))
@@ -279,12 +418,26 @@ func setupRepos(r chi.Router,
 
 			r.Get("/settings/security", handlerreposettings.HandleSecurityFind(repoSettingsCtrl))
 			r.Patch("/settings/security", handlerreposettings.HandleSecurityUpdate(repoSettingsCtrl))
+			r.Get("/settings/pullreq", handlerreposettings.HandlePullReqFind(repoSettingsCtrl))
+			r.Patch("/settings/pullreq", handlerreposettings.HandlePullReqUpdate(repoSettingsCtrl))
+			r.Get("/settings/general", handlerreposettings.HandleGeneralFind(repoSettingsCtrl))
+			r.Patch("/settings/general", handlerreposettings.HandleGeneralUpdate(repoSettingsCtrl))
 
 			r.Post("/move", handlerrepo.HandleMove(repoCtrl))
+			r.Post("/rename", handlerrepo.HandleRename(repoCtrl))
+			r.Post("/fork", handlerrepo.HandleFork(repoCtrl))
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
 
+			r.Get("/mirror", handlerrepo.HandleMirrorFind(repoCtrl))
+			r.Post("/mirror/sync", handlerrepo.HandleMirrorSync(repoCtrl))
+
+			r.Get("/stats", handlerrepo.HandleStats(repoCtrl))
+			r.Get("/languages", handlerrepo.HandleLanguages(repoCtrl))
+			r.Post("/archive", handlerrepo.HandleArchive(repoCtrl))
+			r.Post("/unarchive", handlerrepo.HandleUnarchive(repoCtrl))
+
 			r.Post("/default-branch", handlerrepo.HandleUpdateDefaultBranch(repoCtrl))
 
 			// content operations
@@ -305,17 +458,29 @@ func setupRepos(r chi.Router,
 				r.Get("/*", handlerrepo.HandleRaw(repoCtrl))
 			})
 
+			// NOTE: this allows /readme and /readme/ to both be valid (same as for content).
+			r.Route("/readme", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleReadme(repoCtrl))
+			})
+
+			r.With(timeout.Override(config.Timeout.Archive)).Get("/archive/*", handlerrepo.HandleDownloadArchive(repoCtrl))
+
+			r.Get("/resolve", handlerrepo.HandleResolveRef(repoCtrl))
+
 			// commit operations
 			r.Route("/commits", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListCommits(repoCtrl))
 
 				r.Post("/calculate-divergence", handlerrepo.HandleCalculateCommitDivergence(repoCtrl))
-				r.Post("/", handlerrepo.HandleCommitFiles(repoCtrl))
+				r.With(bodylimit.Override(config.BodyLimit.Upload)).Post("/", handlerrepo.HandleCommitFiles(repoCtrl))
 
 				// per commit operations
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 					r.Get("/", handlerrepo.HandleGetCommit(repoCtrl))
 					r.Get("/diff", handlerrepo.HandleCommitDiff(repoCtrl))
+					r.Get("/changes", handlerrepo.HandleCommitChanges(repoCtrl))
+					r.With(middlewareflag.Require(flags, featureflag.FlagRepoCherryPick)).
+						Post("/cherry-pick", handlerrepo.HandleCherryPick(repoCtrl))
 				})
 			})
 
@@ -344,13 +509,16 @@ func setupRepos(r chi.Router,
 			r.Route("/diff-stats", func(r chi.Router) {
 				r.Get("/*", handlerrepo.HandleDiffStats(repoCtrl))
 			})
+			r.Route("/compare", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleCompare(repoCtrl))
+			})
 			r.Route("/merge-check", func(r chi.Router) {
 				r.Post("/*", handlerrepo.HandleMergeCheck(repoCtrl))
 			})
 
 			r.Get("/codeowners/validate", handlerrepo.HandleCodeOwnersValidate(repoCtrl))
 
-			SetupPullReq(r, pullreqCtrl)
+			SetupPullReq(r, pullreqCtrl, flags)
 
 			SetupWebhook(r, webhookCtrl)
 
@@ -358,16 +526,24 @@ func setupRepos(r chi.Router,
 
 			SetupChecks(r, checkCtrl)
 
-			SetupUploads(r, uploadCtrl)
+			SetupUploads(r, config, uploadCtrl)
 
 			SetupRules(r, repoCtrl)
+
+			setupDeployKeys(r, repoCtrl)
+
+			setupCollaborators(r, repoCtrl)
+
+			setupRepoSecrets(r, secretCtrl)
+
+			r.Get("/activities", handlerrepo.HandleListActivities(repoCtrl))
 		})
 	})
 }
 
-func SetupUploads(r chi.Router, uploadCtrl *upload.Controller) {
+func SetupUploads(r chi.Router, config *types.Config, uploadCtrl *upload.Controller) {
 	r.Route("/uploads", func(r chi.Router) {
-		r.Post("/", handlerupload.HandleUpload(uploadCtrl))
+		r.With(bodylimit.Override(config.BodyLimit.Upload)).Post("/", handlerupload.HandleUpload(uploadCtrl))
 		r.Get("/*", handlerupload.HandleDownoad(uploadCtrl))
 	})
 }
@@ -437,6 +613,14 @@ func setupSecrets(r chi.Router, secretCtrl *secret.Controller) {
 	})
 }
 
+func setupRepoSecrets(r chi.Router, secretCtrl *secret.Controller) {
+	r.Route("/secrets", func(r chi.Router) {
+		r.Get("/", handlersecret.HandleListRepoSecrets(secretCtrl))
+		r.Post("/", handlersecret.HandleCreateRepoSecret(secretCtrl))
+		r.Delete(fmt.Sprintf("/{%s}", request.PathParamSecretIdentifier), handlersecret.HandleDeleteRepoSecret(secretCtrl))
+	})
+}
+
 func setupPlugins(r chi.Router, pluginCtrl *plugin.Controller) {
 	r.Route("/plugins", func(r chi.Router) {
 		r.Get("/", handlerplugin.HandleList(pluginCtrl))
@@ -461,7 +645,7 @@ func setupExecutions(
 					request.PathParamStepNumber,
 				), handlerlogs.HandleFind(logCtrl))
 			// TODO: Decide whether API should be /stream/logs/{}/{} or /logs/{}/{}/stream
-			r.Get(
+			r.With(timeout.Override(0)).Get(
 				fmt.Sprintf("/logs/{%s}/{%s}/stream",
 					request.PathParamStageNumber,
 					request.PathParamStepNumber,
@@ -499,10 +683,12 @@ func SetupGitHooks(r chi.Router, githookCtrl *controllergithook.Controller, git
 	})
 }
 
-func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
+func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller, flags *featureflag.Service) {
 	r.Route("/pullreq", func(r chi.Router) {
 		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
 		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
+		r.With(middlewareflag.Require(flags, featureflag.FlagPullReqMergeability)).
+			Get("/mergeability", handlerpullreq.HandleMergeability(pullreqCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqNumber), func(r chi.Router) {
 			r.Get("/", handlerpullreq.HandleFind(pullreqCtrl))
@@ -511,6 +697,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Get("/activities", handlerpullreq.HandleListActivities(pullreqCtrl))
 			r.Route("/comments", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleCommentCreate(pullreqCtrl))
+				r.Get("/files", handlerpullreq.HandleCommentListByFile(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqCommentID), func(r chi.Router) {
 					r.Patch("/", handlerpullreq.HandleCommentUpdate(pullreqCtrl))
 					r.Delete("/", handlerpullreq.HandleCommentDelete(pullreqCtrl))
@@ -553,6 +740,8 @@ func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
 			r.Get("/", handlerwebhook.HandleFind(webhookCtrl))
 			r.Patch("/", handlerwebhook.HandleUpdate(webhookCtrl))
 			r.Delete("/", handlerwebhook.HandleDelete(webhookCtrl))
+			r.Post("/ping", handlerwebhook.HandlePing(webhookCtrl))
+			r.Post("/regenerate-secret", handlerwebhook.HandleRegenerateSecret(webhookCtrl))
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutions(webhookCtrl))
@@ -574,6 +763,10 @@ func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 			r.Get("/", handlercheck.HandleCheckList(checkCtrl))
 		})
 	})
+	r.Route(fmt.Sprintf("/statuses/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
+		r.Post("/", handlercheck.HandleCommitStatusSet(checkCtrl))
+		r.Get("/", handlercheck.HandleCommitStatusCombined(checkCtrl))
+	})
 }
 
 func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
@@ -588,13 +781,38 @@ func SetupRules(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
-func setupUser(r chi.Router, userCtrl *user.Controller) {
+func setupDeployKeys(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/deploy-keys", func(r chi.Router) {
+		r.Post("/", handlerrepo.HandleCreateDeployKey(repoCtrl))
+		r.Get("/", handlerrepo.HandleListDeployKeys(repoCtrl))
+		r.Post("/authorize", handlerrepo.HandleAuthorizeDeployKey(repoCtrl))
+		r.Delete(fmt.Sprintf("/{%s}", request.PathParamDeployKeyID), handlerrepo.HandleDeleteDeployKey(repoCtrl))
+	})
+}
+
+func setupCollaborators(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/collaborators", func(r chi.Router) {
+		r.Post("/", handlerrepo.HandleCollaboratorAdd(repoCtrl))
+		r.Get("/", handlerrepo.HandleCollaboratorList(repoCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamUserUID), func(r chi.Router) {
+			r.Patch("/", handlerrepo.HandleCollaboratorUpdate(repoCtrl))
+			r.Delete("/", handlerrepo.HandleCollaboratorRemove(repoCtrl))
+		})
+	})
+}
+
+func setupUser(r chi.Router, config *types.Config, userCtrl *user.Controller) {
+	r.Get(fmt.Sprintf("/users/{%s}/avatar", request.PathParamUserUID), users.HandleAvatar(userCtrl))
+
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
 		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
 		r.Get("/", handleruser.HandleFind(userCtrl))
+		r.Get("/me", handleruser.HandleMe(userCtrl))
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
+		r.Post("/onboarding/complete", handleruser.HandleCompleteOnboarding(userCtrl))
+		r.With(bodylimit.Override(config.BodyLimit.Upload)).Put("/avatar", handleruser.HandleUpdateAvatar(userCtrl))
 
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
@@ -607,15 +825,51 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			})
 		})
 
+		// SSH PUBLIC KEYS
+		r.Route("/keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListPublicKeys(userCtrl))
+			r.Post("/", handleruser.HandleCreatePublicKey(userCtrl))
+
+			// per key operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamPublicKeyID), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeletePublicKey(userCtrl))
+			})
+		})
+
+		// EMAILS
+		r.Route("/emails", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListEmails(userCtrl))
+			r.Post("/", handleruser.HandleAddEmail(userCtrl))
+			r.Delete("/primary", handleruser.HandleRemovePrimaryEmail(userCtrl))
+
+			// per email operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamUserEmailID), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleRemoveEmail(userCtrl))
+				r.Post("/verify", handleruser.HandleVerifyEmail(userCtrl))
+				r.Post("/primary", handleruser.HandleSetPrimaryEmail(userCtrl))
+			})
+		})
+
 		// SESSION TOKENS
 		r.Route("/sessions", func(r chi.Router) {
 			r.Get("/", handleruser.HandleListTokens(userCtrl, enum.TokenTypeSession))
+			r.Post("/revoke-all", handleruser.HandleRevokeAllTokens(userCtrl))
 
 			// per token operations
 			r.Route(fmt.Sprintf("/{%s}", request.PathParamTokenIdentifier), func(r chi.Router) {
 				r.Delete("/", handleruser.HandleDeleteToken(userCtrl, enum.TokenTypeSession))
 			})
 		})
+
+		// ACTIVE TOKENS (all types)
+		r.Route("/active-tokens", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListActiveTokens(userCtrl))
+
+			// per token operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamTokenID), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleRevokeActiveToken(userCtrl))
+			})
+		})
 	})
 }
 
@@ -642,11 +896,18 @@ func setupServiceAccounts(r chi.Router, saCtrl *serviceaccount.Controller) {
 	})
 }
 
-func setupSystem(r chi.Router, config *types.Config, sysCtrl *system.Controller) {
+func setupSystem(r chi.Router,
+	config *types.Config,
+	sysCtrl *system.Controller,
+	readiness *gitnesshttp.Readiness,
+	flags *featureflag.Service,
+) {
 	r.Route("/system", func(r chi.Router) {
-		r.Get("/health", handlersystem.HandleHealth)
+		r.Get("/health", handlersystem.HandleHealth(readiness))
 		r.Get("/version", handlersystem.HandleVersion)
+		r.Get("/jwks", handlersystem.HandleJWKS(sysCtrl))
 		r.Get("/config", handlersystem.HandleGetConfig(config, sysCtrl))
+		r.Get("/feature-flags", handlersystem.HandleListFeatureFlags(flags))
 	})
 }
 
@@ -660,6 +921,8 @@ func setupResources(r chi.Router) {
 func setupPrincipals(r chi.Router, principalCtrl principal.Controller) {
 	r.Route("/principals", func(r chi.Router) {
 		r.Get("/", handlerprincipal.HandleList(principalCtrl))
+		r.Get("/whoami", handlerprincipal.HandleWhoami(principalCtrl))
+		r.Post("/users/batch", handlerprincipal.HandleFindManyUsers(principalCtrl))
 	})
 }
 
@@ -667,7 +930,16 @@ func setupKeywordSearch(r chi.Router, searchCtrl *keywordsearch.Controller) {
 	r.Post("/search", handlerkeywordsearch.HandleSearch(searchCtrl))
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupGlobalSearch(r chi.Router, globalSearchCtrl *globalsearch.Controller) {
+	r.Get("/search/global", handlerglobalsearch.HandleSearch(globalSearchCtrl))
+}
+
+func setupAdmin(r chi.Router,
+	userCtrl *user.Controller,
+	sysCtrl *system.Controller,
+	repoCtrl *repo.Controller,
+	maintenanceSvc *maintenance.Service,
+) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -679,6 +951,26 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/", users.HandleUpdate(userCtrl))
 				r.Delete("/", users.HandleDelete(userCtrl))
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
+				r.Post("/unlock", users.HandleUnlock(userCtrl))
+				r.Post("/impersonate", users.HandleImpersonate(userCtrl))
+			})
+		})
+		r.Get("/audit/verify", handlersystem.HandleVerifyAuditChain(sysCtrl))
+		r.Post("/cleanup", handlersystem.HandleRunCleanup(sysCtrl))
+		r.Get("/migrations", handlersystem.HandleGetMigrationStatus(sysCtrl))
+		r.Get("/maintenance", handlersystem.HandleGetMaintenance(maintenanceSvc))
+		r.Put("/maintenance", handlersystem.HandleUpdateMaintenance(maintenanceSvc))
+		r.Route("/operations", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListOperations(sysCtrl))
+			r.Post(fmt.Sprintf("/{%s}/kill", request.PathParamOperationID), handlersystem.HandleKillOperation(sysCtrl))
+		})
+		r.Route("/repos", func(r chi.Router) {
+			r.Get("/gc", handlerrepo.HandleGarbageCollectStatuses(repoCtrl))
+			r.Post("/gc", handlerrepo.HandleGarbageCollectAll(repoCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
+				r.Get("/gc", handlerrepo.HandleGarbageCollectStatus(repoCtrl))
+				r.Post("/gc", handlerrepo.HandleGarbageCollect(repoCtrl))
 			})
 		})
 	})
@@ -686,7 +978,30 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 
 func setupAccount(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Controller, config *types.Config) {
 	cookieName := config.Token.CookieName
-	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
+
+	r.Group(func(r chi.Router) {
+		if config.RateLimit.Login > 0 {
+			r.Use(ratelimit.PerClientIP(
+				ratelimit.NewFixedWindow(config.RateLimit.Login, config.RateLimit.Window),
+				config.RateLimit.TrustedProxies,
+			))
+		}
+		r.Post("/login", account.HandleLogin(userCtrl, cookieName))
+		r.Post("/login/token", account.HandleLoginWithToken(userCtrl, cookieName))
+		r.Post("/password-reset/confirm", account.HandleResetPassword(userCtrl))
+	})
+	// reset requests have their own budget to not consume the login budget of the client.
+	r.Group(func(r chi.Router) {
+		if config.RateLimit.PasswordReset > 0 {
+			r.Use(ratelimit.PerClientIP(
+				ratelimit.NewFixedWindow(config.RateLimit.PasswordReset, config.RateLimit.Window),
+				config.RateLimit.TrustedProxies,
+			))
+		}
+		r.Post("/password-reset", account.HandleRequestPasswordReset(userCtrl))
+	})
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
+	r.Get("/oidc/login", account.HandleOIDCLogin(userCtrl))
+	r.Get("/oidc/callback", account.HandleOIDCCallback(userCtrl, cookieName, config.URL.UI))
 	r.Post("/logout", account.HandleLogout(userCtrl, cookieName))
 }
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func setupCorsHandler() http.Handler {
+	config := &types.Config{}
+	config.Cors.AllowedOrigins = []string{"https://app.example.com"}
+	config.Cors.AllowedMethods = []string{http.MethodGet, http.MethodPost}
+	config.Cors.AllowedHeaders = []string{"Authorization", "Content-Type"}
+	config.Cors.ExposedHeaders = []string{"Link"}
+	config.Cors.AllowCredentials = true
+	config.Cors.MaxAge = 300
+
+	return corsHandler(config)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+}
+
+func TestCors_AllowedOrigin(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/v1/user", nil)
+	r.Header.Set("Origin", "https://app.example.com")
+	w := httptest.NewRecorder()
+
+	setupCorsHandler().ServeHTTP(w, r)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("Want allowed origin %q, got %q", "https://app.example.com", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Want credentials to be allowed, got %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Expose-Headers"); got != "Link" {
+		t.Errorf("Want exposed headers %q, got %q", "Link", got)
+	}
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Want request to reach the handler, got status %d", w.Code)
+	}
+}
+
+func TestCors_DisallowedOrigin(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/v1/user", nil)
+	r.Header.Set("Origin", "https://evil.example.com")
+	w := httptest.NewRecorder()
+
+	setupCorsHandler().ServeHTTP(w, r)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Want no allowed origin, got %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Want no credentials header, got %q", got)
+	}
+}
+
+func TestCors_Preflight(t *testing.T) {
+	r := httptest.NewRequest(http.MethodOptions, "/v1/user", nil)
+	r.Header.Set("Origin", "https://app.example.com")
+	r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	r.Header.Set("Access-Control-Request-Headers", "Authorization")
+	w := httptest.NewRecorder()
+
+	setupCorsHandler().ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Want preflight status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("Want allowed origin %q, got %q", "https://app.example.com", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got != http.MethodPost {
+		t.Errorf("Want allowed method %q, got %q", http.MethodPost, got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Headers"); got != "Authorization" {
+		t.Errorf("Want allowed headers %q, got %q", "Authorization", got)
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "300" {
+		t.Errorf("Want max age %q, got %q", "300", got)
+	}
+}
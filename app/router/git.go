@@ -17,16 +17,23 @@ package router
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/repo"
+	handlerlfs "github.com/harness/gitness/app/api/handler/lfs"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
 	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	middlewaremaintenance "github.com/harness/gitness/app/api/middleware/maintenance"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/services/maintenance"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/go-chi/chi"
@@ -41,9 +48,13 @@ type GitHandler interface {
 
 // NewGitHandler returns a new GitHandler.
 func NewGitHandler(
+	config *types.Config,
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
+	maintenanceSvc *maintenance.Service,
+	auditService audit.Service,
 ) GitHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -53,13 +64,18 @@ func NewGitHandler(
 	r.Use(middleware.Recoverer)
 
 	// configure logging middleware.
-	r.Use(hlog.URLHandler("http.url"))
+	r.Use(logging.HLogURLHandler("http.url"))
 	r.Use(hlog.MethodHandler("http.method"))
 	r.Use(logging.HLogRequestIDHandler())
 	r.Use(logging.HLogAccessLogHandler())
 
+	r.Use(audit.Middleware())
+
 	// for now always attempt auth - enforced per operation.
-	r.Use(middlewareauthn.Attempt(authenticator))
+	r.Use(middlewareauthn.Attempt(authenticator, auditService))
+	if !config.AnonymousAccessEnabled {
+		r.Use(middlewareauthn.DisableAnonymousAccess)
+	}
 
 	r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
 		// routes that aren't coming from git
@@ -71,6 +87,8 @@ func NewGitHandler(
 		// routes that are coming from git (where we block the usage of session tokens)
 		r.Group(func(r chi.Router) {
 			r.Use(middlewareauthz.BlockSessionToken)
+			r.Use(middlewareauthz.RequireScopes(gitScopeForRequest))
+			r.Use(middlewaremaintenance.BlockWrites(maintenanceSvc, gitIsWriteRequest))
 
 			// smart protocol
 			r.Post("/git-upload-pack", handlerrepo.HandleGitServicePack(
@@ -79,6 +97,13 @@ func NewGitHandler(
 				enum.GitServiceTypeReceivePack, repoCtrl, urlProvider))
 			r.Get("/info/refs", handlerrepo.HandleGitInfoRefs(repoCtrl, urlProvider))
 
+			// git LFS (basic transfer adapter)
+			r.Route("/info/lfs/objects", func(r chi.Router) {
+				r.Post("/batch", handlerlfs.HandleBatch(lfsCtrl, urlProvider))
+				r.Put(fmt.Sprintf("/{%s}", request.PathParamLFSObjectID), handlerlfs.HandleUpload(lfsCtrl, urlProvider))
+				r.Get(fmt.Sprintf("/{%s}", request.PathParamLFSObjectID), handlerlfs.HandleDownload(lfsCtrl, urlProvider))
+			})
+
 			// dumb protocol
 			r.Get("/HEAD", stubGitHandler())
 			r.Get("/objects/info/alternates", stubGitHandler())
@@ -95,6 +120,26 @@ func NewGitHandler(
 	return encode.GitPathBefore(r)
 }
 
+// gitScopeForRequest returns the token scope required for the git request.
+// Pushes (including LFS uploads) require the write scope, everything else only reads.
+func gitScopeForRequest(r *http.Request) (enum.TokenScope, bool) {
+	if r.Method == http.MethodPut || strings.HasSuffix(r.URL.Path, "/git-receive-pack") {
+		return enum.TokenScopeRepoWrite, true
+	}
+
+	if service, err := request.GetGitServiceTypeFromQuery(r); err == nil && service == enum.GitServiceTypeReceivePack {
+		return enum.TokenScopeRepoWrite, true
+	}
+
+	return enum.TokenScopeRepoRead, true
+}
+
+// gitIsWriteRequest returns whether the git request writes data (a push or LFS upload).
+func gitIsWriteRequest(r *http.Request) bool {
+	scope, _ := gitScopeForRequest(r)
+	return scope == enum.TokenScopeRepoWrite
+}
+
 func stubGitHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write([]byte("Seems like an asteroid destroyed the ancient git protocol"))
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+)
+
+func TestAPIIsWriteRequest(t *testing.T) {
+	var got bool
+	r := chi.NewRouter()
+	r.Route("/v1", func(r chi.Router) {
+		r.HandleFunc("/*", func(_ http.ResponseWriter, r *http.Request) {
+			got = apiIsWriteRequest(r)
+		})
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   bool
+	}{
+		{name: "read", method: http.MethodGet, path: "/v1/repos/space/repo", want: false},
+		{name: "write", method: http.MethodPost, path: "/v1/repos/space/repo/rename", want: true},
+		{name: "admin write", method: http.MethodPost, path: "/v1/admin/users", want: true},
+		{name: "user write", method: http.MethodPatch, path: "/v1/user", want: true},
+		{name: "register", method: http.MethodPost, path: "/v1/register", want: true},
+		{name: "login", method: http.MethodPost, path: "/v1/login", want: false},
+		{name: "login with token", method: http.MethodPost, path: "/v1/login/token", want: false},
+		{name: "toggle", method: http.MethodPut, path: "/v1/admin/maintenance", want: false},
+		{name: "toggle with other method", method: http.MethodDelete, path: "/v1/admin/maintenance", want: true},
+		{name: "pre-receive hook", method: http.MethodPost, path: "/v1/internal/git-hooks/pre-receive", want: false},
+		{name: "update hook", method: http.MethodPost, path: "/v1/internal/git-hooks/update", want: false},
+		{name: "post-receive hook", method: http.MethodPost, path: "/v1/internal/git-hooks/post-receive", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(test.method, test.path, nil))
+
+			if got != test.want {
+				t.Errorf("Want write %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestGitIsWriteRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   bool
+	}{
+		{name: "fetch", method: http.MethodPost, path: "/space/repo.git/git-upload-pack", want: false},
+		{name: "fetch refs", method: http.MethodGet, path: "/space/repo.git/info/refs?service=git-upload-pack",
+			want: false},
+		{name: "push", method: http.MethodPost, path: "/space/repo.git/git-receive-pack", want: true},
+		{name: "push refs", method: http.MethodGet, path: "/space/repo.git/info/refs?service=git-receive-pack",
+			want: true},
+		{name: "lfs upload", method: http.MethodPut, path: "/space/repo.git/info/lfs/objects/abc", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := gitIsWriteRequest(httptest.NewRequest(test.method, test.path, nil)); got != test.want {
+				t.Errorf("Want write %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/harness/gitness/app/api/middleware/logging"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/request"
 
@@ -69,7 +70,7 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	req = req.WithContext(ctx)
 	log.UpdateContext(func(c zerolog.Context) zerolog.Context {
 		return c.
-			Str("http.original_url", req.URL.String())
+			Str("http.original_url", logging.RedactURL(req.URL))
 	})
 
 	/*
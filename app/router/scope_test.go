@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	middlewareauthz "github.com/harness/gitness/app/api/middleware/authz"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/go-chi/chi"
+)
+
+func TestAPIScopeForRequest(t *testing.T) {
+	r := chi.NewRouter()
+	r.Route("/v1", func(r chi.Router) {
+		r.Use(middlewareauthz.RequireScopes(apiScopeForRequest))
+		r.HandleFunc("/*", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+
+	readToken := &auth.TokenMetadata{TokenType: enum.TokenTypePAT, Scopes: []enum.TokenScope{enum.TokenScopeRepoRead}}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		metadata auth.Metadata
+		want     int
+	}{
+		{name: "repo read", method: http.MethodGet, path: "/v1/repos/space/repo", metadata: readToken,
+			want: http.StatusOK},
+		{name: "repo write", method: http.MethodPost, path: "/v1/repos/space/repo/rename", metadata: readToken,
+			want: http.StatusForbidden},
+		{name: "space read", method: http.MethodGet, path: "/v1/spaces/space/repos", metadata: readToken,
+			want: http.StatusOK},
+		{name: "token creation", method: http.MethodPost, path: "/v1/user/tokens", metadata: readToken,
+			want: http.StatusForbidden},
+		{name: "unmapped read", method: http.MethodGet, path: "/v1/service-accounts/sa", metadata: readToken,
+			want: http.StatusForbidden},
+		{name: "admin", method: http.MethodGet, path: "/v1/admin/users", metadata: readToken,
+			want: http.StatusForbidden},
+		{name: "unscoped token", method: http.MethodPost, path: "/v1/user/tokens",
+			metadata: &auth.TokenMetadata{TokenType: enum.TokenTypePAT}, want: http.StatusOK},
+		{name: "session without token", method: http.MethodPost, path: "/v1/user/tokens",
+			metadata: &auth.EmptyMetadata{}, want: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := request.WithAuthSession(context.Background(), &auth.Session{Metadata: test.metadata})
+			req := httptest.NewRequest(test.method, test.path, nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != test.want {
+				t.Errorf("Want response code %d, got %d", test.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestGitScopeForRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		target string
+		want   enum.TokenScope
+	}{
+		{method: http.MethodGet, target: "/space/repo.git/info/refs?service=git-upload-pack",
+			want: enum.TokenScopeRepoRead},
+		{method: http.MethodPost, target: "/space/repo.git/git-upload-pack", want: enum.TokenScopeRepoRead},
+		{method: http.MethodGet, target: "/space/repo.git/info/refs?service=git-receive-pack",
+			want: enum.TokenScopeRepoWrite},
+		{method: http.MethodPost, target: "/space/repo.git/git-receive-pack", want: enum.TokenScopeRepoWrite},
+		{method: http.MethodPut, target: "/space/repo.git/info/lfs/objects/abc", want: enum.TokenScopeRepoWrite},
+	}
+
+	for _, test := range tests {
+		got, ok := gitScopeForRequest(httptest.NewRequest(test.method, test.target, nil))
+		if !ok || got != test.want {
+			t.Errorf("Want scope %q for %s %s, got %q", test.want, test.method, test.target, got)
+		}
+	}
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
+	"github.com/harness/gitness/app/api/controller/globalsearch"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
@@ -41,8 +43,12 @@ import (
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/services/featureflag"
+	"github.com/harness/gitness/app/services/maintenance"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/git"
+	gitnesshttp "github.com/harness/gitness/http"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -77,14 +83,22 @@ func ProvideRouter(
 }
 
 func ProvideGitHandler(
+	config *types.Config,
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
+	maintenanceSvc *maintenance.Service,
+	auditService audit.Service,
 ) GitHandler {
 	return NewGitHandler(
+		config,
 		urlProvider,
 		authenticator,
 		repoCtrl,
+		lfsCtrl,
+		maintenanceSvc,
+		auditService,
 	)
 }
 
@@ -114,11 +128,17 @@ func ProvideAPIHandler(
 	sysCtrl *system.Controller,
 	blobCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	globalSearchCtrl *globalsearch.Controller,
+	readiness *gitnesshttp.Readiness,
+	flags *featureflag.Service,
+	maintenanceSvc *maintenance.Service,
+	auditService audit.Service,
 ) APIHandler {
 	return NewAPIHandler(appCtx, config,
 		authenticator, repoCtrl, repoSettingsCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
 		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
-		githookCtrl, git, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl)
+		githookCtrl, git, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl,
+		globalSearchCtrl, readiness, flags, maintenanceSvc, auditService)
 }
 
 func ProvideWebHandler(config *types.Config, openapi openapi.Service) WebHandler {
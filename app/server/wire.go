@@ -23,16 +23,23 @@ import (
 )
 
 // WireSet provides a wire set for this package.
-var WireSet = wire.NewSet(ProvideServer)
+var WireSet = wire.NewSet(ProvideServer, ProvideReadiness)
+
+// ProvideReadiness provides the readiness of the server.
+func ProvideReadiness() *http.Readiness {
+	return &http.Readiness{}
+}
 
 // ProvideServer provides a server instance.
-func ProvideServer(config *types.Config, router *router.Router) *Server {
+func ProvideServer(config *types.Config, router *router.Router, readiness *http.Readiness) *Server {
 	return &Server{
 		http.NewServer(
 			http.Config{
-				Port:     config.Server.HTTP.Port,
-				Acme:     config.Server.Acme.Enabled,
-				AcmeHost: config.Server.Acme.Host,
+				Host:      config.Server.HTTP.Host,
+				Port:      config.Server.HTTP.Port,
+				Acme:      config.Server.Acme.Enabled,
+				AcmeHost:  config.Server.Acme.Host,
+				Readiness: readiness,
 			},
 			router,
 		),
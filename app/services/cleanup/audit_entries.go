@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeAuditEntries        = "gitness:cleanup:audit-entries"
+	jobCronAuditEntries        = "37 */4 * * *" // At minute 37 past every 4th hour.
+	jobMaxDurationAuditEntries = 1 * time.Minute
+)
+
+type auditEntriesCleanupJob struct {
+	retentionTime time.Duration
+
+	auditChainStore audit.ChainStore
+}
+
+func newAuditEntriesCleanupJob(
+	retentionTime time.Duration,
+	auditChainStore audit.ChainStore,
+) *auditEntriesCleanupJob {
+	return &auditEntriesCleanupJob{
+		retentionTime: retentionTime,
+
+		auditChainStore: auditChainStore,
+	}
+}
+
+// Handle purges old audit chain entries that are past the retention time.
+func (j *auditEntriesCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging audit entries older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	n, err := j.auditChainStore.DeleteOld(ctx, olderThan)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete old audit entries: %w", err)
+	}
+
+	result := "no old audit entries found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d audit entries", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
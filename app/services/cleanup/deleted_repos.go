@@ -20,8 +20,6 @@ import (
 	"math"
 	"time"
 
-	"github.com/harness/gitness/app/api/controller/repo"
-	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/types"
@@ -39,20 +37,20 @@ const (
 type deletedReposCleanupJob struct {
 	retentionTime time.Duration
 
-	repoStore store.RepoStore
-	repoCtrl  *repo.Controller
+	repoStore  store.RepoStore
+	repoPurger RepoPurger
 }
 
 func newDeletedReposCleanupJob(
 	retentionTime time.Duration,
 	repoStore store.RepoStore,
-	repoCtrl *repo.Controller,
+	repoPurger RepoPurger,
 ) *deletedReposCleanupJob {
 	return &deletedReposCleanupJob{
 		retentionTime: retentionTime,
 
-		repoStore: repoStore,
-		repoCtrl:  repoCtrl,
+		repoStore:  repoStore,
+		repoPurger: repoPurger,
 	}
 }
 
@@ -79,10 +77,9 @@ func (j *deletedReposCleanupJob) Handle(ctx context.Context, _ string, _ job.Pro
 		return "", fmt.Errorf("failed to list ready-to-delete repositories: %w", err)
 	}
 
-	session := bootstrap.NewSystemServiceSession()
 	purgedRepos := 0
 	for _, r := range toBePurgedRepos {
-		err := j.repoCtrl.PurgeNoAuth(ctx, session, r)
+		err := j.repoPurger.Purge(ctx, r)
 		if err != nil {
 			log.Warn().Err(err).Msgf("failed to purge repo uid: %s, path: %s, deleted at %d",
 				r.Identifier, r.Path, *r.Deleted)
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeIdempotencyKeys        = "gitness:cleanup:idempotency-keys"
+	jobCronIdempotencyKeys        = "33 */4 * * *" // At minute 33 past every 4th hour.
+	jobMaxDurationIdempotencyKeys = 1 * time.Minute
+)
+
+type idempotencyKeysCleanupJob struct {
+	ttl time.Duration
+
+	idempotencyKeyStore store.IdempotencyKeyStore
+}
+
+func newIdempotencyKeysCleanupJob(
+	ttl time.Duration,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+) *idempotencyKeysCleanupJob {
+	return &idempotencyKeysCleanupJob{
+		ttl: ttl,
+
+		idempotencyKeyStore: idempotencyKeyStore,
+	}
+}
+
+// Handle purges idempotency keys that are expired.
+func (j *idempotencyKeysCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	createdBefore := time.Now().Add(-j.ttl)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging idempotency keys older than %s (aka created before %s)",
+		j.ttl,
+		createdBefore.Format(time.RFC3339Nano))
+
+	n, err := j.idempotencyKeyStore.DeleteCreatedBefore(ctx, createdBefore)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	result := "no expired idempotency keys found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d idempotency keys", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
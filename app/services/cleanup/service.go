@@ -20,14 +20,28 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// runNowLockKey is the key of the distributed lock that ensures only a single on-demand cleanup runs at a time.
+	runNowLockKey    = "cleanup"
+	runNowLockExpiry = jobMaxDurationWebhookExecutions + jobMaxDurationTokens + jobMaxDurationAuditEntries
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime   time.Duration
 	DeletedRepositoriesRetentionTime time.Duration
+	IdempotencyKeyTTL                time.Duration
+
+	// AuditEntriesRetentionTime is the retention time of audit chain entries (zero disables the cleanup).
+	AuditEntriesRetentionTime time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -41,9 +55,29 @@ func (c *Config) Prepare() error {
 	if c.DeletedRepositoriesRetentionTime <= 0 {
 		return errors.New("config.DeletedRepositoriesRetentionTime has to be provided")
 	}
+
+	if c.IdempotencyKeyTTL <= 0 {
+		return errors.New("config.IdempotencyKeyTTL has to be provided")
+	}
+
+	if c.AuditEntriesRetentionTime < 0 {
+		return errors.New("config.AuditEntriesRetentionTime can't be negative")
+	}
 	return nil
 }
 
+// Cleaner runs the cleanup of expired data on demand.
+type Cleaner interface {
+	RunNow(ctx context.Context) ([]types.CleanupJobResult, error)
+}
+
+// RepoPurger purges deleted repositories on behalf of the system.
+type RepoPurger interface {
+	Purge(ctx context.Context, repo *types.Repository) error
+}
+
+var _ Cleaner = (*Service)(nil)
+
 // Service is responsible for cleaning up data in db / git / ...
 type Service struct {
 	config                Config
@@ -52,7 +86,10 @@ type Service struct {
 	webhookExecutionStore store.WebhookExecutionStore
 	tokenStore            store.TokenStore
 	repoStore             store.RepoStore
-	repoCtrl              *repo.Controller
+	repoPurger            RepoPurger
+	idempotencyKeyStore   store.IdempotencyKeyStore
+	auditChainStore       audit.ChainStore
+	mxManager             lock.MutexManager
 }
 
 func NewService(
@@ -62,7 +99,10 @@ func NewService(
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
-	repoCtrl *repo.Controller,
+	repoPurger RepoPurger,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	auditChainStore audit.ChainStore,
+	mxManager lock.MutexManager,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -76,7 +116,10 @@ func NewService(
 		webhookExecutionStore: webhookExecutionStore,
 		tokenStore:            tokenStore,
 		repoStore:             repoStore,
-		repoCtrl:              repoCtrl,
+		repoPurger:            repoPurger,
+		idempotencyKeyStore:   idempotencyKeyStore,
+		auditChainStore:       auditChainStore,
+		mxManager:             mxManager,
 	}, nil
 }
 
@@ -126,6 +169,30 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to schedule deleted repo cleanup job: %w", err)
 	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeIdempotencyKeys,
+		jobTypeIdempotencyKeys,
+		jobCronIdempotencyKeys,
+		jobMaxDurationIdempotencyKeys,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule idempotency key cleanup job: %w", err)
+	}
+
+	if s.config.AuditEntriesRetentionTime > 0 {
+		err = s.scheduler.AddRecurring(
+			ctx,
+			jobTypeAuditEntries,
+			jobTypeAuditEntries,
+			jobCronAuditEntries,
+			jobMaxDurationAuditEntries,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to schedule audit entries cleanup job: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -155,10 +222,89 @@ func (s *Service) registerJobHandlers() error {
 		newDeletedReposCleanupJob(
 			s.config.DeletedRepositoriesRetentionTime,
 			s.repoStore,
-			s.repoCtrl,
+			s.repoPurger,
 		),
 	); err != nil {
 		return fmt.Errorf("failed to register job handler for deleted repos cleanup: %w", err)
 	}
+
+	if err := s.executor.Register(
+		jobTypeIdempotencyKeys,
+		newIdempotencyKeysCleanupJob(
+			s.config.IdempotencyKeyTTL,
+			s.idempotencyKeyStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for idempotency key cleanup: %w", err)
+	}
+
+	if s.config.AuditEntriesRetentionTime > 0 {
+		if err := s.executor.Register(
+			jobTypeAuditEntries,
+			newAuditEntriesCleanupJob(
+				s.config.AuditEntriesRetentionTime,
+				s.auditChainStore,
+			),
+		); err != nil {
+			return fmt.Errorf("failed to register job handler for audit entries cleanup: %w", err)
+		}
+	}
 	return nil
 }
+
+// onDemandJob is a cleanup job that can be run on demand via RunNow.
+type onDemandJob struct {
+	jobType string
+	handler job.Handler
+}
+
+// RunNow synchronously runs the expired token, webhook execution and audit entry cleanups.
+// A distributed lock guarantees that only a single on-demand cleanup is running across all replicas.
+func (s *Service) RunNow(ctx context.Context) ([]types.CleanupJobResult, error) {
+	mutex, err := s.mxManager.NewMutex(
+		runNowLockKey,
+		lock.WithExpiry(runNowLockExpiry),
+		lock.WithTries(1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cleanup mutex: %w", err)
+	}
+
+	if err = mutex.Lock(ctx); err != nil {
+		return nil, fmt.Errorf("failed to acquire cleanup lock: %w", err)
+	}
+	defer func() {
+		if errUnlock := mutex.Unlock(context.Background()); errUnlock != nil {
+			log.Ctx(ctx).Warn().Err(errUnlock).Msg("failed to release cleanup lock")
+		}
+	}()
+
+	jobs := []onDemandJob{
+		{jobTypeTokens, newTokensCleanupJob(s.tokenStore)},
+		{
+			jobTypeWebhookExecutions,
+			newWebhookExecutionsCleanupJob(s.config.WebhookExecutionsRetentionTime, s.webhookExecutionStore),
+		},
+	}
+	if s.config.AuditEntriesRetentionTime > 0 {
+		jobs = append(jobs, onDemandJob{
+			jobTypeAuditEntries,
+			newAuditEntriesCleanupJob(s.config.AuditEntriesRetentionTime, s.auditChainStore),
+		})
+	}
+
+	results := make([]types.CleanupJobResult, len(jobs))
+	for i, j := range jobs {
+		result, err := j.handler.Handle(ctx, "", func(int, string) error { return nil })
+		if err != nil {
+			return nil, fmt.Errorf("failed to run cleanup job %q: %w", j.jobType, err)
+		}
+
+		results[i] = types.CleanupJobResult{
+			Job:    j.jobType,
+			Result: result,
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types/enum"
+)
+
+type tokenStoreFake struct {
+	store.TokenStore
+	deleted int64
+}
+
+func (s *tokenStoreFake) DeleteExpiredBefore(context.Context, time.Time, []enum.TokenType) (int64, error) {
+	return s.deleted, nil
+}
+
+type webhookExecutionStoreFake struct {
+	store.WebhookExecutionStore
+	olderThan time.Time
+}
+
+func (s *webhookExecutionStoreFake) DeleteOld(_ context.Context, olderThan time.Time) (int64, error) {
+	s.olderThan = olderThan
+	return 0, nil
+}
+
+type auditChainStoreFake struct {
+	audit.ChainStore
+	olderThan time.Time
+}
+
+func (s *auditChainStoreFake) DeleteOld(_ context.Context, olderThan time.Time) (int64, error) {
+	s.olderThan = olderThan
+	return 5, nil
+}
+
+func newRunNowService(
+	auditRetention time.Duration,
+	mxManager lock.MutexManager,
+) (*Service, *webhookExecutionStoreFake, *auditChainStoreFake) {
+	webhookExecutionStore := &webhookExecutionStoreFake{}
+	auditChainStore := &auditChainStoreFake{}
+	return &Service{
+		config: Config{
+			WebhookExecutionsRetentionTime: 24 * time.Hour,
+			AuditEntriesRetentionTime:      auditRetention,
+		},
+		tokenStore:            &tokenStoreFake{deleted: 2},
+		webhookExecutionStore: webhookExecutionStore,
+		auditChainStore:       auditChainStore,
+		mxManager:             mxManager,
+	}, webhookExecutionStore, auditChainStore
+}
+
+func newInMemoryLock() lock.MutexManager {
+	return lock.NewInMemory(lock.Config{
+		App:        "gitness",
+		Expiry:     time.Minute,
+		Tries:      1,
+		RetryDelay: 10 * time.Millisecond,
+	})
+}
+
+func TestRunNow(t *testing.T) {
+	svc, _, auditChainStore := newRunNowService(30*24*time.Hour, newInMemoryLock())
+
+	start := time.Now()
+	results, err := svc.RunNow(context.Background())
+	if err != nil {
+		t.Fatalf("failed to run cleanup: %s", err)
+	}
+
+	want := map[string]string{
+		jobTypeTokens:            "deleted 2 tokens",
+		jobTypeWebhookExecutions: "no old webhook executions found",
+		jobTypeAuditEntries:      "deleted 5 audit entries",
+	}
+	if len(results) != len(want) {
+		t.Fatalf("Want %d results, got %d", len(want), len(results))
+	}
+	for _, result := range results {
+		if result.Result != want[result.Job] {
+			t.Errorf("Want result %q for job %q, got %q", want[result.Job], result.Job, result.Result)
+		}
+	}
+
+	auditCutoff := auditChainStore.olderThan
+	if auditCutoff.Before(start.Add(-30*24*time.Hour)) || auditCutoff.After(time.Now().Add(-30*24*time.Hour)) {
+		t.Errorf("Want audit cutoff of 30 days ago, got %s", auditCutoff)
+	}
+}
+
+func TestRunNow_AuditRetentionDisabled(t *testing.T) {
+	svc, _, auditChainStore := newRunNowService(0, newInMemoryLock())
+
+	results, err := svc.RunNow(context.Background())
+	if err != nil {
+		t.Fatalf("failed to run cleanup: %s", err)
+	}
+
+	for _, result := range results {
+		if result.Job == jobTypeAuditEntries {
+			t.Errorf("Want no audit entries cleanup if retention is disabled")
+		}
+	}
+	if !auditChainStore.olderThan.IsZero() {
+		t.Errorf("Want audit entries untouched if retention is disabled")
+	}
+}
+
+func TestRunNow_Locked(t *testing.T) {
+	mxManager := newInMemoryLock()
+	svc, webhookExecutionStore, _ := newRunNowService(0, mxManager)
+
+	// simulate a cleanup that is already running on another replica
+	mutex, err := mxManager.NewMutex(runNowLockKey)
+	if err != nil {
+		t.Fatalf("failed to create mutex: %s", err)
+	}
+	if err = mutex.Lock(context.Background()); err != nil {
+		t.Fatalf("failed to lock mutex: %s", err)
+	}
+	defer func() { _ = mutex.Unlock(context.Background()) }()
+
+	_, err = svc.RunNow(context.Background())
+
+	var lockErr *lock.Error
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("Want lock error, got %v", err)
+	}
+	if !webhookExecutionStore.olderThan.IsZero() {
+		t.Errorf("Want no cleanup while the lock is held")
+	}
+}
@@ -15,15 +15,17 @@
 package cleanup
 
 import (
-	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/lock"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	ProvideService,
+	ProvideCleaner,
 )
 
 func ProvideService(
@@ -33,7 +35,10 @@ func ProvideService(
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
 	repoStore store.RepoStore,
-	repoCtrl *repo.Controller,
+	repoPurger RepoPurger,
+	idempotencyKeyStore store.IdempotencyKeyStore,
+	auditChainStore audit.ChainStore,
+	mxManager lock.MutexManager,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -42,6 +47,14 @@ func ProvideService(
 		webhookExecutionStore,
 		tokenStore,
 		repoStore,
-		repoCtrl,
+		repoPurger,
+		idempotencyKeyStore,
+		auditChainStore,
+		mxManager,
 	)
 }
+
+// ProvideCleaner provides the cleanup service as on-demand cleaner.
+func ProvideCleaner(svc *Service) Cleaner {
+	return svc
+}
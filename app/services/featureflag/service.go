@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflag
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+// Flag is the name of a feature flag.
+type Flag string
+
+const (
+	// FlagRepoCherryPick gates the endpoint cherry-picking a commit onto a branch.
+	FlagRepoCherryPick Flag = "repo_cherry_pick"
+	// FlagPullReqMergeability gates the mergeability preflight of branches before opening a pull request.
+	FlagPullReqMergeability Flag = "pullreq_mergeability"
+)
+
+// flagDefaults contains all known flags and their state if they aren't configured.
+var flagDefaults = map[Flag]bool{
+	FlagRepoCherryPick:      true,
+	FlagPullReqMergeability: true,
+}
+
+// Service resolves the state of feature flags for the whole instance and for individual users.
+type Service struct {
+	instance map[Flag]bool
+	users    map[Flag]map[string]bool
+}
+
+// NewService returns a new feature flag service.
+// An error is returned if the provided state references an unknown flag.
+func NewService(enabled []string, disabled []string, users map[string]string) (*Service, error) {
+	s := &Service{
+		instance: make(map[Flag]bool, len(flagDefaults)),
+		users:    make(map[Flag]map[string]bool),
+	}
+
+	for flag, value := range flagDefaults {
+		s.instance[flag] = value
+	}
+
+	for _, name := range enabled {
+		flag, err := parseFlag(name)
+		if err != nil {
+			return nil, err
+		}
+		s.instance[flag] = true
+	}
+
+	for _, name := range disabled {
+		flag, err := parseFlag(name)
+		if err != nil {
+			return nil, err
+		}
+		s.instance[flag] = false
+	}
+
+	for name, uids := range users {
+		flag, err := parseFlag(name)
+		if err != nil {
+			return nil, err
+		}
+
+		overrides := make(map[string]bool)
+		for _, uid := range strings.Split(uids, ";") {
+			uid = strings.TrimSpace(uid)
+			value := !strings.HasPrefix(uid, "!")
+			uid = strings.TrimPrefix(uid, "!")
+			if uid == "" {
+				continue
+			}
+
+			overrides[strings.ToLower(uid)] = value
+		}
+
+		s.users[flag] = overrides
+	}
+
+	return s, nil
+}
+
+// IsEnabled returns whether the flag is enabled for the principal of the session (nil for anonymous requests).
+func (s *Service) IsEnabled(flag Flag, session *auth.Session) bool {
+	if session != nil {
+		if value, ok := s.users[flag][strings.ToLower(session.Principal.UID)]; ok {
+			return value
+		}
+	}
+
+	return s.instance[flag]
+}
+
+// List returns the state of all known flags for the principal of the session (nil for anonymous requests).
+func (s *Service) List(session *auth.Session) []types.FeatureFlag {
+	flags := make([]types.FeatureFlag, 0, len(flagDefaults))
+	for flag := range flagDefaults {
+		flags = append(flags, types.FeatureFlag{
+			Name:    string(flag),
+			Enabled: s.IsEnabled(flag, session),
+		})
+	}
+
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Name < flags[j].Name
+	})
+
+	return flags
+}
+
+func parseFlag(name string) (Flag, error) {
+	flag := Flag(strings.TrimSpace(name))
+	if _, ok := flagDefaults[flag]; !ok {
+		return "", fmt.Errorf("unknown feature flag '%s'", name)
+	}
+
+	return flag, nil
+}
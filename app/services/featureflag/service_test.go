@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package featureflag
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+)
+
+func TestService_IsEnabled(t *testing.T) {
+	alice := &auth.Session{Principal: types.Principal{UID: "Alice"}}
+	bob := &auth.Session{Principal: types.Principal{UID: "bob"}}
+
+	tests := []struct {
+		name     string
+		enabled  []string
+		disabled []string
+		users    map[string]string
+		session  *auth.Session
+		want     bool
+	}{
+		{name: "default", session: alice, want: true},
+		{name: "default anonymous", want: true},
+		{name: "disabled for instance", disabled: []string{string(FlagRepoCherryPick)}, session: alice, want: false},
+		{name: "enabled for user", disabled: []string{string(FlagRepoCherryPick)},
+			users: map[string]string{string(FlagRepoCherryPick): "alice"}, session: alice, want: true},
+		{name: "enabled for other user", disabled: []string{string(FlagRepoCherryPick)},
+			users: map[string]string{string(FlagRepoCherryPick): "alice"}, session: bob, want: false},
+		{name: "enabled for user anonymous", disabled: []string{string(FlagRepoCherryPick)},
+			users: map[string]string{string(FlagRepoCherryPick): "alice"}, want: false},
+		{name: "disabled for user", enabled: []string{string(FlagRepoCherryPick)},
+			users: map[string]string{string(FlagRepoCherryPick): "bob;!alice"}, session: alice, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := NewService(test.enabled, test.disabled, test.users)
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+
+			if got := s.IsEnabled(FlagRepoCherryPick, test.session); got != test.want {
+				t.Errorf("Want enabled %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestService_UnknownFlag(t *testing.T) {
+	if _, err := NewService([]string{"unknown"}, nil, nil); err == nil {
+		t.Errorf("Want error for unknown enabled flag, got nil")
+	}
+	if _, err := NewService(nil, nil, map[string]string{"unknown": "alice"}); err == nil {
+		t.Errorf("Want error for unknown user flag, got nil")
+	}
+}
+
+func TestService_List(t *testing.T) {
+	s, err := NewService(nil, []string{string(FlagPullReqMergeability)}, nil)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	want := []types.FeatureFlag{
+		{Name: string(FlagPullReqMergeability), Enabled: false},
+		{Name: string(FlagRepoCherryPick), Enabled: true},
+	}
+
+	got := s.List(nil)
+	if len(got) != len(want) {
+		t.Fatalf("Want %d flags, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Want flag %+v, got %+v", want[i], got[i])
+		}
+	}
+}
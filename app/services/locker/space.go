@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package locker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const namespaceSpace = "space"
+
+// LockSpacePaths locks the paths of the direct children of a space (spaces and repos).
+// It serializes operations that rename or move resources within the space, to avoid
+// concurrent requests from corrupting the paths.
+func (l Locker) LockSpacePaths(
+	ctx context.Context,
+	spaceID int64,
+	expiry time.Duration,
+) (func(), error) {
+	key := strconv.FormatInt(spaceID, 10) + "/paths"
+
+	unlockFn, err := l.lock(ctx, namespaceSpace, key, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock paths of space %d: %w", spaceID, err)
+	}
+
+	return unlockFn, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// refreshInterval is the max duration the persisted state is cached for,
+// which is how long it takes until a toggle is picked up by other instances.
+const refreshInterval = 5 * time.Second
+
+var errEnabledByConfig = usererror.Conflict(
+	"The maintenance mode is enabled by the server configuration and can't be disabled at runtime.")
+
+// Service holds the state of the maintenance mode, which blocks write requests while enabled.
+// The state is persisted as system setting to be shared by all instances and survive restarts.
+type Service struct {
+	settings    *settings.Service
+	forced      bool
+	retryAfter  time.Duration
+	adminBypass bool
+
+	mx       sync.Mutex
+	enabled  bool
+	loadedAt time.Time
+}
+
+// NewService returns a new maintenance mode service.
+// If forced is true, the maintenance mode is enabled regardless of the persisted state.
+func NewService(
+	settingsSvc *settings.Service,
+	forced bool,
+	retryAfter time.Duration,
+	adminBypass bool,
+) *Service {
+	return &Service{
+		settings:    settingsSvc,
+		forced:      forced,
+		retryAfter:  retryAfter,
+		adminBypass: adminBypass,
+	}
+}
+
+// Enabled returns whether the maintenance mode is enabled.
+// If the persisted state can't be loaded, the last known state is returned and cached like a loaded state,
+// to not query a failing database on every request.
+func (s *Service) Enabled(ctx context.Context) bool {
+	if s.forced {
+		return true
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if time.Since(s.loadedAt) < refreshInterval {
+		return s.enabled
+	}
+
+	enabled := settings.DefaultMaintenanceEnabled
+	if _, err := s.settings.SystemGet(ctx, settings.KeyMaintenanceEnabled, &enabled); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to load maintenance mode, using last known state")
+		s.loadedAt = time.Now()
+		return s.enabled
+	}
+
+	s.enabled = enabled
+	s.loadedAt = time.Now()
+
+	return s.enabled
+}
+
+// SetEnabled enables or disables the maintenance mode.
+func (s *Service) SetEnabled(ctx context.Context, enabled bool) (types.MaintenanceMode, error) {
+	if s.forced && !enabled {
+		return types.MaintenanceMode{}, errEnabledByConfig
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if err := s.settings.SystemSet(ctx, settings.KeyMaintenanceEnabled, enabled); err != nil {
+		return types.MaintenanceMode{}, err
+	}
+
+	if s.enabled != enabled {
+		log.Ctx(ctx).Info().Bool("enabled", enabled).Msg("maintenance mode toggled")
+	}
+
+	s.enabled = enabled
+	s.loadedAt = time.Now()
+
+	return s.state(s.forced || s.enabled), nil
+}
+
+// RetryAfter returns the duration clients are advised to wait before retrying blocked requests.
+func (s *Service) RetryAfter() time.Duration {
+	return s.retryAfter
+}
+
+// State returns the current state of the maintenance mode.
+func (s *Service) State(ctx context.Context) types.MaintenanceMode {
+	return s.state(s.Enabled(ctx))
+}
+
+func (s *Service) state(enabled bool) types.MaintenanceMode {
+	return types.MaintenanceMode{
+		Enabled:     enabled,
+		RetryAfter:  int64(s.retryAfter / time.Second),
+		AdminBypass: s.adminBypass,
+	}
+}
+
+// AllowsWrite returns whether a write request is allowed for the principal of the session
+// (nil for anonymous requests). Writes are only allowed outside of maintenance mode
+// or for admins if they are allowed to bypass it.
+func (s *Service) AllowsWrite(ctx context.Context, session *auth.Session) bool {
+	if !s.Enabled(ctx) {
+		return true
+	}
+
+	return s.adminBypass && session != nil && session.Principal.Admin
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/git/api"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	errRemoteNotAllowed   = usererror.BadRequest("The remote repository URL is not allowed.")
+	errRemoteUnresolvable = usererror.BadRequest("The host of the remote repository URL can't be resolved.")
+
+	errUnspecifiedNotAllowed = errors.New("unspecified addresses are not allowed")
+	errLoopbackNotAllowed    = errors.New("loopback addresses are not allowed")
+	errPrivateNotAllowed     = errors.New("private network addresses are not allowed")
+)
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Resolver resolves the IP addresses of a host.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// checkRemote verifies that the remote URL is an http(s) URL that doesn't resolve to
+// loopback or private network addresses (unless allowed by the webhook network policy).
+// It returns the options git has to access the remote with, which pin the host to the verified address
+// and prevent redirects or other protocols - otherwise git could still be sent to a disallowed address.
+// IMPORTANT: It's called before every clone and sync, as the DNS records of the host can change any time.
+func (s *Service) checkRemote(ctx context.Context, remoteURL string) (api.RemoteOptions, error) {
+	u, err := url.Parse(remoteURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return api.RemoteOptions{}, errRemoteNotAllowed
+	}
+
+	host := u.Hostname()
+
+	addrs, err := s.resolve(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		log.Ctx(ctx).Debug().Err(err).Msgf("failed to resolve remote host %q", host)
+		return api.RemoteOptions{}, errRemoteUnresolvable
+	}
+
+	for _, addr := range addrs {
+		if err = s.checkRemoteIP(addr.IP); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("remote host %q resolves to %s", host, addr.IP)
+			return api.RemoteOptions{}, errRemoteNotAllowed
+		}
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = defaultPorts[u.Scheme]
+	}
+
+	address := addrs[0].IP.String()
+	if addrs[0].IP.To4() == nil {
+		address = "[" + address + "]"
+	}
+
+	return api.RemoteOptions{
+		Protocols:        []string{u.Scheme},
+		DisableRedirects: true,
+		Resolve:          []string{host + ":" + port + ":" + address},
+	}, nil
+}
+
+func (s *Service) resolve(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IPAddr{{IP: ip}}, nil
+	}
+
+	return s.resolver.LookupIPAddr(ctx, host)
+}
+
+func (s *Service) checkRemoteIP(ip net.IP) error {
+	if ip.IsUnspecified() {
+		return errUnspecifiedNotAllowed
+	}
+
+	if !s.allowLoopback && ip.IsLoopback() {
+		return errLoopbackNotAllowed
+	}
+
+	// link local addresses are treated as private network (e.g. the instance metadata service of cloud providers).
+	if !s.allowPrivateNetwork && (ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast()) {
+		return errPrivateNotAllowed
+	}
+
+	return nil
+}
@@ -0,0 +1,426 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/operation"
+	"github.com/harness/gitness/app/store"
+	gitnessurl "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/lock"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType       = "repository-mirror-sync"
+	lockNamespace = "repo"
+
+	// syncTimeout is the max duration of the sync of a single mirrored repository.
+	syncTimeout = 15 * time.Minute
+)
+
+// refSpecs are the references synced from the remote repository.
+var refSpecs = []string{"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"}
+
+// Credentials are the optional credentials used to access the remote repository.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Service clones repositories from external git URLs and keeps the mirrored repositories in sync.
+type Service struct {
+	enabled     bool
+	cron        string
+	maxDur      time.Duration
+	urlProvider gitnessurl.Provider
+	git         git.Interface
+	repoStore   store.RepoStore
+	mirrorStore store.RepoMirrorStore
+	encrypter   encrypt.Encrypter
+	scheduler   *job.Scheduler
+	mtxManager  lock.MutexManager
+	operations  *operation.Registry
+
+	resolver            Resolver
+	allowLoopback       bool
+	allowPrivateNetwork bool
+}
+
+var _ job.Handler = (*Service)(nil)
+
+// Register registers the recurring job that syncs all mirrored repositories.
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for repository mirror sync: %w", err)
+	}
+
+	return nil
+}
+
+// Handle syncs all mirrored repositories. Repositories that are already being synced are skipped.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	mirrors, err := s.mirrorStore.List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repository mirrors: %w", err)
+	}
+
+	for _, mirror := range mirrors {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		log := log.Ctx(ctx).With().Int64("repo_id", mirror.RepoID).Logger()
+
+		repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue // the repository has been deleted
+		}
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to find mirrored repository")
+			continue
+		}
+
+		// failed syncs are logged and recorded with the mirror by the sync itself.
+		if _, err = s.Sync(ctx, &systemPrincipal, repo); err != nil {
+			log.Warn().Err(err).Msg("skipping sync of mirrored repository")
+		}
+	}
+
+	return "", nil
+}
+
+// Clone creates a new git repository with the content of the remote repository.
+// It returns the git UID and the default branch of the new repository.
+func (s *Service) Clone(
+	ctx context.Context,
+	principal *types.Principal,
+	remoteURL string,
+	credentials Credentials,
+) (string, string, error) {
+	remoteOpts, err := s.checkRemote(ctx, remoteURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	gitUID, err := git.NewRepositoryUID()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create new git uid: %w", err)
+	}
+
+	writeParams, err := s.createWriteParams(ctx, principal, 0, gitUID)
+	if err != nil {
+		return "", "", err
+	}
+
+	source, err := sourceURL(remoteURL, credentials)
+	if err != nil {
+		return "", "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+
+	out, err := s.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams:       writeParams,
+		Source:            source,
+		CreateIfNotExists: true,
+		RefSpecs:          refSpecs,
+		RemoteOptions:     remoteOpts,
+	})
+	if err != nil {
+		if errDel := s.git.DeleteRepository(context.Background(), &git.DeleteRepositoryParams{
+			WriteParams: writeParams,
+		}); errDel != nil {
+			log.Ctx(ctx).Debug().Err(errDel).Msg("failed to delete git repository after failed clone")
+		}
+
+		// the output of git isn't returned to the user as it could leak details of the remote host.
+		log.Ctx(ctx).Warn().Msgf("failed to clone remote repository: %s", redact(err.Error(), credentials))
+
+		return "", "", usererror.BadRequest("Failed to clone the remote repository.")
+	}
+
+	return gitUID, out.DefaultBranch, nil
+}
+
+// Create stores the mirror configuration of the repository. The credentials are stored encrypted.
+func (s *Service) Create(
+	ctx context.Context,
+	repoID int64,
+	principalID int64,
+	remoteURL string,
+	credentials Credentials,
+) (*types.RepositoryMirror, error) {
+	encrypted, err := s.encryptCredentials(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	mirror := &types.RepositoryMirror{
+		RepoID:        repoID,
+		RemoteURL:     remoteURL,
+		Credentials:   encrypted,
+		CreatedBy:     principalID,
+		Created:       now,
+		Updated:       now,
+		LastSynced:    now,
+		LastSyncError: "",
+	}
+
+	if err = s.mirrorStore.Create(ctx, mirror); err != nil {
+		return nil, fmt.Errorf("failed to create repository mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// Find returns the mirror configuration of the repository.
+func (s *Service) Find(ctx context.Context, repoID int64) (*types.RepositoryMirror, error) {
+	mirror, err := s.mirrorStore.Find(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// Sync fetches the updates of the remote repository into the mirrored repository.
+// A failed sync isn't returned as error, it's recorded in the LastSyncError of the returned mirror.
+// An error is returned if the repository is already being synced.
+func (s *Service) Sync(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+) (*types.RepositoryMirror, error) {
+	unlock, err := s.lock(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	mirror, err := s.Find(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.decryptCredentials(mirror.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := sourceURL(mirror.RemoteURL, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	writeParams, err := s.createWriteParams(ctx, principal, repo.ID, repo.GitUID)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteOpts, err := s.checkRemote(ctx, mirror.RemoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	syncCtx, done := s.operations.Start(ctx, enum.OperationTypeMirrorSync, operation.RepoTarget(repo.ID))
+	defer done()
+
+	syncCtx, cancel := context.WithTimeout(syncCtx, syncTimeout)
+	defer cancel()
+
+	out, errSync := s.git.SyncRepository(syncCtx, &git.SyncRepositoryParams{
+		WriteParams:   writeParams,
+		Source:        source,
+		RefSpecs:      refSpecs,
+		RemoteOptions: remoteOpts,
+	})
+
+	now := time.Now().UnixMilli()
+	mirror.Updated = now
+	if errSync != nil {
+		// the output of git isn't stored as it's visible to all readers of the repository.
+		log.Ctx(ctx).Warn().Int64("repo_id", repo.ID).
+			Msgf("failed to sync mirrored repository: %s", redact(errSync.Error(), credentials))
+		mirror.LastSyncError = "Failed to fetch from the remote repository."
+	} else {
+		mirror.LastSynced = now
+		mirror.LastSyncError = ""
+	}
+
+	if err = s.mirrorStore.Update(ctx, mirror); err != nil {
+		return nil, fmt.Errorf("failed to update repository mirror: %w", err)
+	}
+
+	if errSync != nil || out.DefaultBranch == "" ||
+		(out.DefaultBranch == repo.DefaultBranch && !repo.IsEmpty) {
+		return mirror, nil
+	}
+
+	_, err = s.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+		r.DefaultBranch = out.DefaultBranch
+		r.IsEmpty = false
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update mirrored repository: %w", err)
+	}
+
+	return mirror, nil
+}
+
+func (s *Service) lock(ctx context.Context, repoID int64) (func(), error) {
+	mutex, err := s.mtxManager.NewMutex(
+		strconv.FormatInt(repoID, 10)+"/mirror",
+		lock.WithNamespace(lockNamespace),
+		lock.WithExpiry(syncTimeout+time.Minute),
+		lock.WithTries(1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mirror sync mutex: %w", err)
+	}
+
+	if err = mutex.Lock(ctx); err != nil {
+		return nil, fmt.Errorf("failed to acquire mirror sync lock: %w", err)
+	}
+
+	return func() {
+		if errUnlock := mutex.Unlock(context.Background()); errUnlock != nil {
+			log.Ctx(ctx).Warn().Err(errUnlock).Msg("failed to release mirror sync lock")
+		}
+	}, nil
+}
+
+func (s *Service) createWriteParams(
+	ctx context.Context,
+	principal *types.Principal,
+	repoID int64,
+	gitUID string,
+) (git.WriteParams, error) {
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(),
+		repoID,
+		principal.ID,
+		repoID == 0,
+		true,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: gitUID,
+		EnvVars: envVars,
+	}, nil
+}
+
+func (s *Service) encryptCredentials(credentials Credentials) (string, error) {
+	data, err := json.Marshal(credentials)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal mirror credentials: %w", err)
+	}
+
+	encrypted, err := s.encrypter.Encrypt(string(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt mirror credentials: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+func (s *Service) decryptCredentials(data string) (Credentials, error) {
+	encrypted, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to base64 decode mirror credentials: %w", err)
+	}
+
+	decrypted, err := s.encrypter.Decrypt(encrypted)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to decrypt mirror credentials: %w", err)
+	}
+
+	var credentials Credentials
+	if err = json.Unmarshal([]byte(decrypted), &credentials); err != nil {
+		return Credentials{}, fmt.Errorf("failed to unmarshal mirror credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// sourceURL returns the remote URL with the credentials embedded.
+func sourceURL(remoteURL string, credentials Credentials) (string, error) {
+	if credentials.Username == "" && credentials.Password == "" {
+		return remoteURL, nil
+	}
+
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse remote URL: %w", err)
+	}
+
+	u.User = url.UserPassword(credentials.Username, credentials.Password)
+
+	return u.String(), nil
+}
+
+// redact removes the password from the message, in case it was echoed back by git.
+func redact(msg string, credentials Credentials) string {
+	if credentials.Password == "" {
+		return msg
+	}
+
+	return strings.NewReplacer(
+		credentials.Password, "*****",
+		url.QueryEscape(credentials.Password), "*****",
+		url.PathEscape(credentials.Password), "*****",
+	).Replace(msg)
+}
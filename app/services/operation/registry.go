@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Registry keeps track of the long-running operations that are active on this instance
+// and allows them to be canceled on demand.
+// NOTE: Only operations of the local instance are known to the registry.
+type Registry struct {
+	mx         sync.Mutex
+	lastID     int64
+	operations map[int64]entry
+}
+
+type entry struct {
+	operation types.Operation
+	cancel    context.CancelFunc
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		operations: map[int64]entry{},
+	}
+}
+
+// RepoTarget returns the operation target of the repository.
+func RepoTarget(repoID int64) string {
+	return "repo:" + strconv.FormatInt(repoID, 10)
+}
+
+// Start registers a new operation and returns a context that is canceled once the operation is killed.
+// The returned function must be called when the operation completes, it removes the operation from the registry.
+func (r *Registry) Start(
+	ctx context.Context,
+	opType enum.OperationType,
+	target string,
+) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	r.mx.Lock()
+	r.lastID++
+	id := r.lastID
+	r.operations[id] = entry{
+		operation: types.Operation{
+			ID:      id,
+			Type:    opType,
+			Target:  target,
+			Started: time.Now().UnixMilli(),
+		},
+		cancel: cancel,
+	}
+	r.mx.Unlock()
+
+	return ctx, func() {
+		r.mx.Lock()
+		delete(r.operations, id)
+		r.mx.Unlock()
+
+		cancel()
+	}
+}
+
+// List returns all active operations, ordered by ID.
+func (r *Registry) List() []types.Operation {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	operations := make([]types.Operation, 0, len(r.operations))
+	for _, e := range r.operations {
+		operations = append(operations, e.operation)
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].ID < operations[j].ID
+	})
+
+	return operations
+}
+
+// Kill cancels the context of the operation. It returns false if there is no active operation with the ID.
+func (r *Registry) Kill(id int64) bool {
+	r.mx.Lock()
+	e, ok := r.operations[id]
+	r.mx.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	e.cancel()
+
+	return true
+}
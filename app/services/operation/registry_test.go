@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRegistry_List(t *testing.T) {
+	r := NewRegistry()
+
+	_, doneGC := r.Start(context.Background(), enum.OperationTypeGitGC, RepoTarget(1))
+	defer doneGC()
+	_, doneSync := r.Start(context.Background(), enum.OperationTypeMirrorSync, RepoTarget(2))
+	defer doneSync()
+
+	ops := r.List()
+	if len(ops) != 2 {
+		t.Fatalf("Want 2 operations, got %d", len(ops))
+	}
+	if ops[0].Type != enum.OperationTypeGitGC || ops[0].Target != "repo:1" {
+		t.Errorf("Want gc of repo:1, got %s of %s", ops[0].Type, ops[0].Target)
+	}
+	if ops[1].Type != enum.OperationTypeMirrorSync || ops[1].Target != "repo:2" {
+		t.Errorf("Want mirror sync of repo:2, got %s of %s", ops[1].Type, ops[1].Target)
+	}
+	if ops[0].ID == ops[1].ID {
+		t.Errorf("Want unique operation IDs, got %d twice", ops[0].ID)
+	}
+	if ops[0].Started == 0 {
+		t.Errorf("Want start time to be set")
+	}
+}
+
+func TestRegistry_Kill(t *testing.T) {
+	r := NewRegistry()
+
+	ctx, done := r.Start(context.Background(), enum.OperationTypeArchive, RepoTarget(1))
+	defer done()
+
+	ops := r.List()
+	if len(ops) != 1 {
+		t.Fatalf("Want 1 operation, got %d", len(ops))
+	}
+
+	if !r.Kill(ops[0].ID) {
+		t.Fatalf("Want operation to be killed")
+	}
+
+	select {
+	case <-ctx.Done():
+	default:
+		t.Errorf("Want context of killed operation to be canceled")
+	}
+
+	if r.Kill(ops[0].ID + 1) {
+		t.Errorf("Want kill of unknown operation to fail")
+	}
+}
+
+func TestRegistry_Done(t *testing.T) {
+	r := NewRegistry()
+
+	ctx, done := r.Start(context.Background(), enum.OperationTypeGitGC, RepoTarget(1))
+	done()
+
+	if ops := r.List(); len(ops) != 0 {
+		t.Errorf("Want completed operation to be removed, got %v", ops)
+	}
+	if ctx.Err() == nil {
+		t.Errorf("Want context of completed operation to be canceled")
+	}
+	if r.Kill(1) {
+		t.Errorf("Want kill of completed operation to fail")
+	}
+}
@@ -79,6 +79,31 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			},
 			expOut: MergeVerifyOutput{MinimumRequiredApprovalsCount: 2},
 		},
+		{
+			name: codePullReqApprovalReqMinCount + "-with-change-request",
+			def: DefPullReq{
+				Approvals: DefApprovals{RequireMinimumCount: 2, RequireNoChangeRequest: true},
+			},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{SourceSHA: "abc"},
+				Reviewers: []*types.PullReqReviewer{
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, SHA: "abc"},
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, SHA: "abc"},
+					{
+						ReviewDecision: enum.PullReqReviewDecisionChangeReq,
+						Reviewer:       types.PrincipalInfo{DisplayName: "John"},
+						SHA:            "abc",
+					},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqApprovalReqChangeRequested},
+			expParams: [][]any{{"John"}},
+			expOut: MergeVerifyOutput{
+				MinimumRequiredApprovalsCount: 2,
+				RequiresNoChangeRequests:      true,
+			},
+		},
 		{
 			name: codePullReqApprovalReqLatestCommit + "-fail",
 			def:  DefPullReq{Approvals: DefApprovals{RequireMinimumCount: 2, RequireLatestCommit: true}},
@@ -0,0 +1,218 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/services/operation"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/contextutil"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const gcLockNamespace = "repo"
+
+// GarbageCollector runs git garbage collection of repositories in the background.
+// A distributed lock guarantees that only a single garbage collection per repository is running across all replicas.
+// NOTE: The status of a garbage collection is only known to the replica that started it.
+type GarbageCollector struct {
+	ctx        context.Context
+	maxDur     time.Duration
+	git        git.Interface
+	repoStore  store.RepoStore
+	mtxManager lock.MutexManager
+	operations *operation.Registry
+
+	mx       sync.RWMutex
+	statuses map[int64]types.RepositoryGCStatus
+}
+
+func NewGarbageCollector(
+	ctx context.Context,
+	maxDur time.Duration,
+	git git.Interface,
+	repoStore store.RepoStore,
+	mtxManager lock.MutexManager,
+	operations *operation.Registry,
+) *GarbageCollector {
+	return &GarbageCollector{
+		ctx:        ctx,
+		maxDur:     maxDur,
+		git:        git,
+		repoStore:  repoStore,
+		mtxManager: mtxManager,
+		operations: operations,
+		statuses:   map[int64]types.RepositoryGCStatus{},
+	}
+}
+
+// Run starts the garbage collection of the repository in the background.
+// An error is returned if a garbage collection of the repository is already running.
+func (c *GarbageCollector) Run(ctx context.Context, repoID int64, gitUID string) (types.RepositoryGCStatus, error) {
+	unlock, err := c.lock(ctx, repoID)
+	if err != nil {
+		return types.RepositoryGCStatus{}, err
+	}
+
+	status := c.start(repoID)
+
+	ctx = contextutil.WithNewValues(c.ctx, ctx)
+	go func() {
+		defer unlock()
+		c.gc(ctx, repoID, gitUID)
+	}()
+
+	return status, nil
+}
+
+// RunAll starts the garbage collection of all active repositories in the background and returns their number.
+// The repositories are processed one after another, repositories with a running garbage collection are skipped.
+func (c *GarbageCollector) RunAll(ctx context.Context) (int, error) {
+	sizeInfos, err := c.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	ctx = contextutil.WithNewValues(c.ctx, ctx)
+	go func() {
+		for _, sizeInfo := range sizeInfos {
+			if ctx.Err() != nil {
+				return
+			}
+
+			unlock, err := c.lock(ctx, sizeInfo.ID)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("repo_id", sizeInfo.ID).
+					Msg("skipping garbage collection of repository")
+				continue
+			}
+
+			c.start(sizeInfo.ID)
+			c.gc(ctx, sizeInfo.ID, sizeInfo.GitUID)
+			unlock()
+		}
+	}()
+
+	return len(sizeInfos), nil
+}
+
+// Status returns the status of the latest garbage collection of the repository.
+func (c *GarbageCollector) Status(repoID int64) (types.RepositoryGCStatus, bool) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	status, ok := c.statuses[repoID]
+	return status, ok
+}
+
+// Statuses returns the status of the latest garbage collection of all repositories, ordered by repository ID.
+func (c *GarbageCollector) Statuses() []types.RepositoryGCStatus {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+
+	statuses := make([]types.RepositoryGCStatus, 0, len(c.statuses))
+	for _, status := range c.statuses {
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].RepoID < statuses[j].RepoID
+	})
+
+	return statuses
+}
+
+func (c *GarbageCollector) lock(ctx context.Context, repoID int64) (func(), error) {
+	mutex, err := c.mtxManager.NewMutex(
+		strconv.FormatInt(repoID, 10)+"/gc",
+		lock.WithNamespace(gcLockNamespace),
+		lock.WithExpiry(c.maxDur),
+		lock.WithTries(1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create garbage collection mutex: %w", err)
+	}
+
+	if err = mutex.Lock(ctx); err != nil {
+		return nil, fmt.Errorf("failed to acquire garbage collection lock: %w", err)
+	}
+
+	return func() {
+		if errUnlock := mutex.Unlock(context.Background()); errUnlock != nil {
+			log.Ctx(ctx).Warn().Err(errUnlock).Msg("failed to release garbage collection lock")
+		}
+	}, nil
+}
+
+func (c *GarbageCollector) start(repoID int64) types.RepositoryGCStatus {
+	status := types.RepositoryGCStatus{
+		RepoID:  repoID,
+		State:   enum.JobStateRunning,
+		Started: time.Now().UnixMilli(),
+	}
+
+	c.mx.Lock()
+	c.statuses[repoID] = status
+	c.mx.Unlock()
+
+	return status
+}
+
+func (c *GarbageCollector) gc(ctx context.Context, repoID int64, gitUID string) {
+	ctx, done := c.operations.Start(ctx, enum.OperationTypeGitGC, operation.RepoTarget(repoID))
+	defer done()
+
+	ctx, cancel := context.WithTimeout(ctx, c.maxDur)
+	defer cancel()
+
+	err := c.git.GarbageCollect(ctx, &git.GarbageCollectParams{
+		ReadParams: git.ReadParams{RepoUID: gitUID},
+	})
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	status := c.statuses[repoID]
+	status.Finished = time.Now().UnixMilli()
+
+	switch {
+	case err == nil:
+		status.State = enum.JobStateFinished
+	case ctx.Err() != nil:
+		status.State = enum.JobStateCanceled
+		status.Error = ctx.Err().Error()
+	default:
+		status.State = enum.JobStateFailed
+		status.Error = err.Error()
+	}
+
+	c.statuses[repoID] = status
+
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).Msg("failed to garbage collect repository")
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/services/operation"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// blockingGit blocks garbage collections until released or canceled.
+type blockingGit struct {
+	git.Interface
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingGit() *blockingGit {
+	return &blockingGit{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+}
+
+func (g *blockingGit) GarbageCollect(ctx context.Context, _ *git.GarbageCollectParams) error {
+	g.started <- struct{}{}
+	select {
+	case <-g.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newInMemoryLock() lock.MutexManager {
+	return lock.NewInMemory(lock.Config{
+		App:        "gitness",
+		Expiry:     time.Minute,
+		Tries:      1,
+		RetryDelay: 10 * time.Millisecond,
+	})
+}
+
+// waitForGCState waits until the garbage collection of the repo reaches the state.
+func waitForGCState(t *testing.T, c *GarbageCollector, repoID int64, state enum.JobState) types.RepositoryGCStatus {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if status, ok := c.Status(repoID); ok && status.State == state {
+			return status
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	status, _ := c.Status(repoID)
+	t.Fatalf("Want gc state %q, got %q", state, status.State)
+	return status
+}
+
+func TestGarbageCollector_RejectsConcurrentRun(t *testing.T) {
+	g := newBlockingGit()
+	c := NewGarbageCollector(context.Background(), time.Minute, g, nil, newInMemoryLock(), operation.NewRegistry())
+
+	status, err := c.Run(context.Background(), 1, "repo1")
+	if err != nil {
+		t.Fatalf("Want gc to start, got %v", err)
+	}
+	if status.State != enum.JobStateRunning {
+		t.Errorf("Want state %q, got %q", enum.JobStateRunning, status.State)
+	}
+	<-g.started
+
+	_, err = c.Run(context.Background(), 1, "repo1")
+	var lockErr *lock.Error
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("Want concurrent gc to be rejected with lock error, got %v", err)
+	}
+
+	close(g.release)
+	status = waitForGCState(t, c, 1, enum.JobStateFinished)
+	if status.Finished < status.Started {
+		t.Errorf("Want finished (%d) not before started (%d)", status.Finished, status.Started)
+	}
+
+	// the lock is released once the gc is done.
+	if _, err = c.Run(context.Background(), 1, "repo1"); err != nil {
+		t.Fatalf("Want gc to start again after completion, got %v", err)
+	}
+	<-g.started
+	waitForGCState(t, c, 1, enum.JobStateFinished)
+}
+
+func TestGarbageCollector_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	g := newBlockingGit()
+	c := NewGarbageCollector(ctx, time.Minute, g, nil, newInMemoryLock(), operation.NewRegistry())
+
+	if _, err := c.Run(context.Background(), 1, "repo1"); err != nil {
+		t.Fatalf("Want gc to start, got %v", err)
+	}
+	<-g.started
+
+	cancel()
+
+	status := waitForGCState(t, c, 1, enum.JobStateCanceled)
+	if status.Error == "" {
+		t.Errorf("Want error of canceled gc to be reported")
+	}
+}
+
+func TestGarbageCollector_Run(t *testing.T) {
+	const fixtureRepoID = 42
+
+	g := setupFixtureGit(t)
+	c := NewGarbageCollector(context.Background(), time.Minute, g, nil, newInMemoryLock(), operation.NewRegistry())
+
+	if _, err := c.Run(context.Background(), fixtureRepoID, fixtureRepoUID); err != nil {
+		t.Fatalf("Want gc to start, got %v", err)
+	}
+
+	status := waitForGCState(t, c, fixtureRepoID, enum.JobStateFinished)
+	if status.Error != "" {
+		t.Errorf("Want no error, got %q", status.Error)
+	}
+
+	statuses := c.Statuses()
+	if len(statuses) != 1 || statuses[0].RepoID != fixtureRepoID {
+		t.Errorf("Want status of fixture repo to be listed, got %v", statuses)
+	}
+}
+
+func TestGarbageCollector_Killed(t *testing.T) {
+	g := newBlockingGit()
+	operations := operation.NewRegistry()
+	c := NewGarbageCollector(context.Background(), time.Minute, g, nil, newInMemoryLock(), operations)
+
+	if _, err := c.Run(context.Background(), 1, "repo1"); err != nil {
+		t.Fatalf("Want gc to start, got %v", err)
+	}
+	<-g.started
+
+	ops := operations.List()
+	if len(ops) != 1 || ops[0].Type != enum.OperationTypeGitGC || ops[0].Target != operation.RepoTarget(1) {
+		t.Fatalf("Want running gc to be listed as operation, got %v", ops)
+	}
+
+	if !operations.Kill(ops[0].ID) {
+		t.Fatalf("Want gc operation to be killed")
+	}
+
+	waitForGCState(t, c, 1, enum.JobStateCanceled)
+	if ops = operations.List(); len(ops) != 0 {
+		t.Errorf("Want killed gc to be removed from operations, got %v", ops)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// languagesRulesFileMaxSize is the max number of bytes read from the languages rules file.
+const languagesRulesFileMaxSize = 64 * 1024
+
+// LanguageAnalyzer computes the breakdown of the files of repositories by language.
+// The breakdown only depends on the commit, hence the results are cached per commit SHA.
+type LanguageAnalyzer struct {
+	cache cache.Cache[languagesKey, *types.RepositoryLanguages]
+}
+
+func NewLanguageAnalyzer(git git.Interface, cacheDuration time.Duration, rulesFilePath string) *LanguageAnalyzer {
+	return &LanguageAnalyzer{
+		cache: cache.New[languagesKey, *types.RepositoryLanguages](
+			languagesGetter{git: git, rulesFilePath: rulesFilePath}, cacheDuration),
+	}
+}
+
+// Analyze returns the (possibly cached) language breakdown of the repository at the provided commit.
+func (a *LanguageAnalyzer) Analyze(
+	ctx context.Context,
+	gitUID string,
+	commitSHA string,
+) (*types.RepositoryLanguages, error) {
+	return a.cache.Get(ctx, languagesKey{gitUID: gitUID, commitSHA: commitSHA})
+}
+
+type languagesKey struct {
+	gitUID    string
+	commitSHA string
+}
+
+// languagesGetter computes the language breakdown of a commit using git.
+type languagesGetter struct {
+	git           git.Interface
+	rulesFilePath string
+}
+
+func (g languagesGetter) Find(ctx context.Context, key languagesKey) (*types.RepositoryLanguages, error) {
+	readParams := git.ReadParams{RepoUID: key.gitUID}
+
+	out, err := g.git.ListFileSizes(ctx, &git.ListFileSizesParams{
+		ReadParams: readParams,
+		GitREF:     key.commitSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	var excluded []string
+	for _, file := range out.Files {
+		if file.Path != g.rulesFilePath {
+			continue
+		}
+
+		excluded, err = g.readRules(ctx, readParams, file.SHA.String())
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	return languagesBreakdown(key.commitSHA, out.Files, excluded), nil
+}
+
+// readRules reads the paths patterns excluded from the breakdown from the rules file.
+// The file contains a glob pattern per line, empty lines and lines starting with '#' are ignored.
+// Patterns ending with '/' exclude the whole directory.
+func (g languagesGetter) readRules(ctx context.Context, readParams git.ReadParams, blobSHA string) ([]string, error) {
+	blob, err := g.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        blobSHA,
+		SizeLimit:  languagesRulesFileMaxSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read languages rules file: %w", err)
+	}
+	defer blob.Content.Close()
+
+	var patterns []string
+
+	scanner := bufio.NewScanner(blob.Content)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pattern := strings.TrimPrefix(line, "/")
+		if strings.HasSuffix(pattern, "/") {
+			pattern += "**"
+		}
+
+		if !doublestar.ValidatePattern(pattern) {
+			continue
+		}
+
+		patterns = append(patterns, pattern)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read languages rules file: %w", err)
+	}
+
+	return patterns, nil
+}
+
+func languagesBreakdown(commitSHA string, files []git.FileSize, excluded []string) *types.RepositoryLanguages {
+	bytesByLanguage := map[string]int64{}
+	var total int64
+
+	for _, file := range files {
+		language := fileLanguage(file.Path)
+		if language == "" || isExcluded(file.Path, excluded) {
+			continue
+		}
+
+		bytesByLanguage[language] += file.Size
+		total += file.Size
+	}
+
+	languages := make([]types.RepositoryLanguage, 0, len(bytesByLanguage))
+	for language, size := range bytesByLanguage {
+		var percentage float64
+		if total > 0 {
+			percentage = math.Round(float64(size)*1000/float64(total)) / 10
+		}
+
+		languages = append(languages, types.RepositoryLanguage{
+			Language:   language,
+			Bytes:      size,
+			Percentage: percentage,
+		})
+	}
+
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].Bytes != languages[j].Bytes {
+			return languages[i].Bytes > languages[j].Bytes
+		}
+		return languages[i].Language < languages[j].Language
+	})
+
+	return &types.RepositoryLanguages{
+		CommitSHA:  commitSHA,
+		TotalBytes: total,
+		Languages:  languages,
+	}
+}
+
+func isExcluded(filePath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, filePath); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fileLanguage returns the language of the file based on its name or extension.
+// An empty string is returned for files of unknown language.
+func fileLanguage(filePath string) string {
+	name := path.Base(filePath)
+	if language, ok := languagesByFileName[name]; ok {
+		return language
+	}
+
+	return languagesByExtension[strings.ToLower(path.Ext(name))]
+}
+
+var languagesByFileName = map[string]string{
+	"Dockerfile":  "Dockerfile",
+	"Makefile":    "Makefile",
+	"GNUmakefile": "Makefile",
+	"Jenkinsfile": "Groovy",
+	"Rakefile":    "Ruby",
+	"Gemfile":     "Ruby",
+}
+
+var languagesByExtension = map[string]string{
+	".go":     "Go",
+	".js":     "JavaScript",
+	".mjs":    "JavaScript",
+	".cjs":    "JavaScript",
+	".jsx":    "JavaScript",
+	".ts":     "TypeScript",
+	".tsx":    "TypeScript",
+	".py":     "Python",
+	".java":   "Java",
+	".kt":     "Kotlin",
+	".kts":    "Kotlin",
+	".scala":  "Scala",
+	".groovy": "Groovy",
+	".c":      "C",
+	".h":      "C",
+	".cc":     "C++",
+	".cpp":    "C++",
+	".cxx":    "C++",
+	".hpp":    "C++",
+	".cs":     "C#",
+	".m":      "Objective-C",
+	".swift":  "Swift",
+	".rs":     "Rust",
+	".rb":     "Ruby",
+	".php":    "PHP",
+	".pl":     "Perl",
+	".lua":    "Lua",
+	".r":      "R",
+	".dart":   "Dart",
+	".sh":     "Shell",
+	".bash":   "Shell",
+	".zsh":    "Shell",
+	".ps1":    "PowerShell",
+	".bat":    "Batchfile",
+	".sql":    "SQL",
+	".html":   "HTML",
+	".htm":    "HTML",
+	".css":    "CSS",
+	".scss":   "SCSS",
+	".less":   "Less",
+	".vue":    "Vue",
+	".proto":  "Protocol Buffer",
+	".tf":     "HCL",
+	".hcl":    "HCL",
+	".md":     "Markdown",
+	".yaml":   "YAML",
+	".yml":    "YAML",
+	".json":   "JSON",
+	".toml":   "TOML",
+	".xml":    "XML",
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+// headSHA returns the SHA of the head commit of the fixture repository.
+func headSHA(t *testing.T, g git.Interface) string {
+	t.Helper()
+
+	out, err := g.GetCommit(context.Background(), &git.GetCommitParams{
+		ReadParams: git.ReadParams{RepoUID: fixtureRepoUID},
+		Revision:   "HEAD",
+	})
+	if err != nil {
+		t.Fatalf("failed to get head commit: %s", err)
+	}
+
+	return out.Commit.SHA.String()
+}
+
+func TestLanguageAnalyzer_Analyze(t *testing.T) {
+	g := setupFixtureGitWithFiles(t, map[string]string{
+		"main.go":                   strings.Repeat("g", 600),
+		"cmd/tool/tool.go":          strings.Repeat("g", 150),
+		"web/app.ts":                strings.Repeat("t", 200),
+		"scripts/build.sh":          strings.Repeat("s", 50),
+		"Dockerfile":                strings.Repeat("d", 100),
+		"LICENSE":                   strings.Repeat("l", 1000),
+		"vendor/lib/lib.go":         strings.Repeat("v", 5000),
+		"web/dist/bundle.js":        strings.Repeat("b", 5000),
+		"api/service.pb.go":         strings.Repeat("p", 5000),
+		".harness/languages-ignore": "# generated and vendored code\nvendor/\n/web/dist/\n\n**/*.pb.go\n",
+	})
+	commitSHA := headSHA(t, g)
+
+	analyzer := NewLanguageAnalyzer(g, time.Minute, ".harness/languages-ignore")
+
+	languages, err := analyzer.Analyze(context.Background(), fixtureRepoUID, commitSHA)
+	if err != nil {
+		t.Fatalf("failed to analyze languages: %s", err)
+	}
+
+	want := []types.RepositoryLanguage{
+		{Language: "Go", Bytes: 750, Percentage: 68.2},
+		{Language: "TypeScript", Bytes: 200, Percentage: 18.2},
+		{Language: "Dockerfile", Bytes: 100, Percentage: 9.1},
+		{Language: "Shell", Bytes: 50, Percentage: 4.5},
+	}
+	if !slices.Equal(languages.Languages, want) {
+		t.Errorf("Want languages %v, got %v", want, languages.Languages)
+	}
+	if languages.TotalBytes != 1100 {
+		t.Errorf("Want total bytes 1100, got %d", languages.TotalBytes)
+	}
+	if languages.CommitSHA != commitSHA {
+		t.Errorf("Want commit sha %q, got %q", commitSHA, languages.CommitSHA)
+	}
+
+	again, err := analyzer.Analyze(context.Background(), fixtureRepoUID, commitSHA)
+	if err != nil {
+		t.Fatalf("failed to analyze languages: %s", err)
+	}
+
+	if g.listCalls != 1 {
+		t.Errorf("Want languages to be computed once per commit, got %d computations", g.listCalls)
+	}
+	if again != languages {
+		t.Errorf("Want cached languages to be returned")
+	}
+}
+
+func TestLanguageAnalyzer_NoRulesFile(t *testing.T) {
+	g := setupFixtureGitWithFiles(t, map[string]string{
+		"main.go":           strings.Repeat("g", 100),
+		"vendor/lib/lib.go": strings.Repeat("v", 300),
+		"README.md":         strings.Repeat("m", 100),
+	})
+
+	analyzer := NewLanguageAnalyzer(g, time.Minute, ".harness/languages-ignore")
+
+	languages, err := analyzer.Analyze(context.Background(), fixtureRepoUID, headSHA(t, g))
+	if err != nil {
+		t.Fatalf("failed to analyze languages: %s", err)
+	}
+
+	want := []types.RepositoryLanguage{
+		{Language: "Go", Bytes: 400, Percentage: 80},
+		{Language: "Markdown", Bytes: 100, Percentage: 20},
+	}
+	if !slices.Equal(languages.Languages, want) {
+		t.Errorf("Want languages %v, got %v", want, languages.Languages)
+	}
+}
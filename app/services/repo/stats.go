@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/cache"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// StatsReporter reports the on-disk size and object statistics of repositories.
+// Computing the statistics is expensive, hence the results are cached per repository.
+type StatsReporter struct {
+	cache cache.Cache[string, *types.RepositoryStats]
+}
+
+func NewStatsReporter(git git.Interface, cacheDuration time.Duration) *StatsReporter {
+	return &StatsReporter{
+		cache: cache.New[string, *types.RepositoryStats](statsGetter{git: git}, cacheDuration),
+	}
+}
+
+// Report returns the (possibly cached) statistics of the repository with the provided git UID.
+func (r *StatsReporter) Report(ctx context.Context, gitUID string) (*types.RepositoryStats, error) {
+	return r.cache.Get(ctx, gitUID)
+}
+
+// statsGetter computes the repository statistics using git.
+type statsGetter struct {
+	git git.Interface
+}
+
+func (g statsGetter) Find(ctx context.Context, gitUID string) (*types.RepositoryStats, error) {
+	out, err := g.git.GetRepositoryStats(ctx, &git.GetRepositoryStatsParams{
+		ReadParams: git.ReadParams{RepoUID: gitUID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository stats: %w", err)
+	}
+
+	stats := &types.RepositoryStats{
+		DiskSize:    out.DiskSize,
+		ObjectCount: out.ObjectCount,
+		Computed:    time.Now().UnixMilli(),
+	}
+
+	if !out.LargestBlobSHA.IsEmpty() {
+		stats.LargestBlob = &types.BlobSize{
+			SHA:  out.LargestBlobSHA.String(),
+			Size: out.LargestBlobSize,
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	gittypes "github.com/harness/gitness/git/types"
+)
+
+const fixtureRepoUID = "fixture1234"
+
+// countingGit counts the calls to GetRepositoryStats and ListFileSizes of the wrapped git.Interface.
+type countingGit struct {
+	git.Interface
+	calls     int
+	listCalls int
+}
+
+func (g *countingGit) GetRepositoryStats(
+	ctx context.Context,
+	params *git.GetRepositoryStatsParams,
+) (*git.GetRepositoryStatsOutput, error) {
+	g.calls++
+	return g.Interface.GetRepositoryStats(ctx, params)
+}
+
+func (g *countingGit) ListFileSizes(
+	ctx context.Context,
+	params *git.ListFileSizesParams,
+) (*git.ListFileSizesOutput, error) {
+	g.listCalls++
+	return g.Interface.ListFileSizes(ctx, params)
+}
+
+// setupFixtureGit creates a git service with a small fixture repository.
+func setupFixtureGit(t *testing.T) *countingGit {
+	t.Helper()
+
+	return setupFixtureGitWithFiles(t, map[string]string{
+		"file.txt": strings.Repeat("fixture content\n", 50),
+	})
+}
+
+// setupFixtureGitWithFiles creates a git service with a fixture repository containing the provided files.
+func setupFixtureGitWithFiles(t *testing.T, files map[string]string) *countingGit {
+	t.Helper()
+
+	root := t.TempDir()
+	repoPath := filepath.Join(root, "repos", fixtureRepoUID[0:2], fixtureRepoUID[2:4], fixtureRepoUID[4:]+".git")
+	if err := os.MkdirAll(repoPath, 0o700); err != nil {
+		t.Fatalf("failed to create repo dir: %s", err)
+	}
+
+	for name, content := range files {
+		filePath := filepath.Join(repoPath, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o700); err != nil {
+			t.Fatalf("failed to create dir: %s", err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@gitness.io", "commit", "--quiet", "-m", "initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("failed to run git %v: %s: %s", args, err, out)
+		}
+	}
+
+	config := gittypes.Config{Root: root}
+	adapter, err := api.New(config, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git adapter: %s", err)
+	}
+
+	gitService, err := git.New(config, adapter, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create git service: %s", err)
+	}
+
+	return &countingGit{Interface: gitService}
+}
+
+func TestStatsReporter_Report(t *testing.T) {
+	gitCounter := setupFixtureGit(t)
+	reporter := NewStatsReporter(gitCounter, time.Minute)
+
+	stats, err := reporter.Report(context.Background(), fixtureRepoUID)
+	if err != nil {
+		t.Fatalf("failed to report stats: %s", err)
+	}
+
+	if stats.DiskSize <= 0 {
+		t.Errorf("Want positive disk size, got %d", stats.DiskSize)
+	}
+	if stats.ObjectCount <= 0 {
+		t.Errorf("Want positive object count, got %d", stats.ObjectCount)
+	}
+	if stats.LargestBlob == nil {
+		t.Fatalf("Want largest blob to be set")
+	}
+	if want := int64(len("fixture content\n") * 50); stats.LargestBlob.Size != want {
+		t.Errorf("Want largest blob size %d, got %d", want, stats.LargestBlob.Size)
+	}
+
+	again, err := reporter.Report(context.Background(), fixtureRepoUID)
+	if err != nil {
+		t.Fatalf("failed to report stats: %s", err)
+	}
+
+	if gitCounter.calls != 1 {
+		t.Errorf("Want stats to be computed once, got %d", gitCounter.calls)
+	}
+	if again != stats {
+		t.Errorf("Want cached stats to be returned")
+	}
+}
+
+func TestStatsReporter_ReportExpired(t *testing.T) {
+	gitCounter := setupFixtureGit(t)
+	reporter := NewStatsReporter(gitCounter, time.Millisecond)
+
+	if _, err := reporter.Report(context.Background(), fixtureRepoUID); err != nil {
+		t.Fatalf("failed to report stats: %s", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := reporter.Report(context.Background(), fixtureRepoUID); err != nil {
+		t.Fatalf("failed to report stats: %s", err)
+	}
+
+	if gitCounter.calls != 2 {
+		t.Errorf("Want stats to be recomputed after the interval, got %d computations", gitCounter.calls)
+	}
+}
@@ -19,11 +19,13 @@ import (
 
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -32,6 +34,9 @@ import (
 var WireSet = wire.NewSet(
 	ProvideCalculator,
 	ProvideService,
+	ProvideStatsReporter,
+	ProvideLanguageAnalyzer,
+	ProvideGarbageCollector,
 )
 
 func ProvideCalculator(
@@ -59,6 +64,25 @@ func ProvideCalculator(
 	return job, nil
 }
 
+func ProvideStatsReporter(config *types.Config, git git.Interface) *StatsReporter {
+	return NewStatsReporter(git, config.RepoSize.StatsCacheDuration)
+}
+
+func ProvideLanguageAnalyzer(config *types.Config, git git.Interface) *LanguageAnalyzer {
+	return NewLanguageAnalyzer(git, config.RepoLanguages.CacheDuration, config.RepoLanguages.RulesFilePath)
+}
+
+func ProvideGarbageCollector(
+	ctx context.Context,
+	config *types.Config,
+	git git.Interface,
+	repoStore store.RepoStore,
+	mtxManager lock.MutexManager,
+	operations *operation.Registry,
+) *GarbageCollector {
+	return NewGarbageCollector(ctx, config.RepoGC.MaxDuration, git, repoStore, mtxManager, operations)
+}
+
 func ProvideService(ctx context.Context,
 	config *types.Config,
 	repoEvReporter *repoevents.Reporter,
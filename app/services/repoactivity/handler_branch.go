@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoactivity
+
+import (
+	"context"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.record(ctx, event.ID, event.Timestamp.UnixMilli(),
+		event.Payload.RepoID, event.Payload.PrincipalID, enum.RepoActivityTypeBranchCreated,
+		types.RepoActivityBranchPayload{
+			Ref:    event.Payload.Ref,
+			NewSHA: event.Payload.SHA,
+		})
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.record(ctx, event.ID, event.Timestamp.UnixMilli(),
+		event.Payload.RepoID, event.Payload.PrincipalID, enum.RepoActivityTypeBranchUpdated,
+		types.RepoActivityBranchPayload{
+			Ref:    event.Payload.Ref,
+			OldSHA: event.Payload.OldSHA,
+			NewSHA: event.Payload.NewSHA,
+			Forced: event.Payload.Forced,
+		})
+}
+
+func (s *Service) handleEventBranchDeleted(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload]) error {
+	return s.record(ctx, event.ID, event.Timestamp.UnixMilli(),
+		event.Payload.RepoID, event.Payload.PrincipalID, enum.RepoActivityTypeBranchDeleted,
+		types.RepoActivityBranchPayload{
+			Ref:    event.Payload.Ref,
+			OldSHA: event.Payload.SHA,
+		})
+}
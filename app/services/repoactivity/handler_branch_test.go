@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoactivity
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestHandleEventBranchUpdated(t *testing.T) {
+	activityStore := &repoActivityStoreFake{}
+	s := &Service{activityStore: activityStore}
+
+	now := time.Now()
+	event := &events.Event[*gitevents.BranchUpdatedPayload]{
+		ID:        "1-0",
+		Timestamp: now,
+		Payload: &gitevents.BranchUpdatedPayload{
+			RepoID:      7,
+			PrincipalID: 3,
+			Ref:         "refs/heads/main",
+			OldSHA:      "aaaa",
+			NewSHA:      "bbbb",
+		},
+	}
+
+	// the second call simulates the redelivery of the same event
+	for i := 0; i < 2; i++ {
+		if err := s.handleEventBranchUpdated(context.Background(), event); err != nil {
+			t.Fatalf("Want no error, got %v", err)
+		}
+	}
+
+	if len(activityStore.activities) != 1 {
+		t.Fatalf("Want 1 activity, got %d", len(activityStore.activities))
+	}
+
+	act := activityStore.activities[0]
+	if act.RepoID != 7 || act.PrincipalID != 3 {
+		t.Errorf("Want repo 7 and principal 3, got repo %d and principal %d", act.RepoID, act.PrincipalID)
+	}
+	if act.Type != enum.RepoActivityTypeBranchUpdated {
+		t.Errorf("Want type %s, got %s", enum.RepoActivityTypeBranchUpdated, act.Type)
+	}
+	if act.Created != now.UnixMilli() {
+		t.Errorf("Want created %d, got %d", now.UnixMilli(), act.Created)
+	}
+
+	payload := types.RepoActivityBranchPayload{}
+	if err := json.Unmarshal(act.Payload, &payload); err != nil {
+		t.Fatalf("Want valid payload, got %v", err)
+	}
+	if payload.Ref != "refs/heads/main" || payload.OldSHA != "aaaa" || payload.NewSHA != "bbbb" {
+		t.Errorf("Want payload with ref and shas of the push, got %+v", payload)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoactivity
+
+import (
+	"context"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (s *Service) handleEventPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	return s.recordPullReq(ctx, event.ID, event.Timestamp.UnixMilli(), &event.Payload.Base,
+		enum.RepoActivityTypePullReqCreated, "")
+}
+
+func (s *Service) handleEventPullReqClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload]) error {
+	return s.recordPullReq(ctx, event.ID, event.Timestamp.UnixMilli(), &event.Payload.Base,
+		enum.RepoActivityTypePullReqClosed, "")
+}
+
+func (s *Service) handleEventPullReqReopened(ctx context.Context,
+	event *events.Event[*pullreqevents.ReopenedPayload]) error {
+	return s.recordPullReq(ctx, event.ID, event.Timestamp.UnixMilli(), &event.Payload.Base,
+		enum.RepoActivityTypePullReqReopened, "")
+}
+
+func (s *Service) handleEventPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload]) error {
+	return s.recordPullReq(ctx, event.ID, event.Timestamp.UnixMilli(), &event.Payload.Base,
+		enum.RepoActivityTypePullReqMerged, event.Payload.MergeSHA)
+}
+
+// recordPullReq records the pull request activity in the feed of the target repository.
+func (s *Service) recordPullReq(
+	ctx context.Context,
+	eventID string,
+	created int64,
+	base *pullreqevents.Base,
+	activityType enum.RepoActivityType,
+	mergeSHA string,
+) error {
+	return s.record(ctx, eventID, created, base.TargetRepoID, base.PrincipalID, activityType,
+		types.RepoActivityPullReqPayload{
+			PullReqID: base.PullReqID,
+			Number:    base.Number,
+			MergeSHA:  mergeSHA,
+		})
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoactivity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// record stores a single activity for the provided event.
+// Redelivered events are recognized by their event ID and aren't recorded twice.
+func (s *Service) record(
+	ctx context.Context,
+	eventID string,
+	created int64,
+	repoID int64,
+	principalID int64,
+	activityType enum.RepoActivityType,
+	payload any,
+) error {
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal repo activity payload: %w", err)
+	}
+
+	act := &types.RepoActivity{
+		RepoID:      repoID,
+		Type:        activityType,
+		EventID:     eventID,
+		PrincipalID: principalID,
+		Created:     created,
+		Payload:     rawPayload,
+	}
+
+	err = s.activityStore.Create(ctx, act)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		log.Ctx(ctx).Debug().Msgf("repo activity for event %s is already recorded", eventID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create repo activity: %w", err)
+	}
+
+	return nil
+}
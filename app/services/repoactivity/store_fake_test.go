@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repoactivity
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type repoActivityStoreFake struct {
+	store.RepoActivityStore
+	activities []*types.RepoActivity
+}
+
+func (s *repoActivityStoreFake) Create(_ context.Context, act *types.RepoActivity) error {
+	for _, existing := range s.activities {
+		if existing.RepoID == act.RepoID && existing.EventID == act.EventID {
+			return gitness_store.ErrDuplicate
+		}
+	}
+
+	act.ID = int64(len(s.activities) + 1)
+	s.activities = append(s.activities, act)
+
+	return nil
+}
@@ -14,6 +14,8 @@
 
 package settings
 
+import "github.com/harness/gitness/types/enum"
+
 type Key string
 
 var (
@@ -21,3 +23,36 @@ var (
 	KeySecretScanningEnabled     Key = "secret_scanning_enabled"
 	DefaultSecretScanningEnabled     = false
 )
+
+var (
+	// KeyDefaultReviewerIDs [[]int64] contains the IDs of principals that are added as reviewers
+	// to every new pull request of the repo.
+	KeyDefaultReviewerIDs     Key = "default_reviewer_ids"
+	DefaultDefaultReviewerIDs     = []int64{}
+
+	// KeyDefaultReviewerRole [enum.MembershipRole] if set, all members of the repo's parent space
+	// with the given role are added as reviewers to every new pull request of the repo.
+	KeyDefaultReviewerRole     Key = "default_reviewer_role"
+	DefaultDefaultReviewerRole     = enum.MembershipRole("")
+
+	// KeyDefaultMergeMethod [enum.MergeMethod] is the merge method used when a merge request doesn't specify one.
+	KeyDefaultMergeMethod     Key = "default_merge_method"
+	DefaultDefaultMergeMethod     = enum.MergeMethod("")
+
+	// KeyAllowedMergeMethods [[]enum.MergeMethod] restricts the merge methods that can be used to merge
+	// pull requests of the repo (empty means all methods are allowed).
+	KeyAllowedMergeMethods     Key = "allowed_merge_methods"
+	DefaultAllowedMergeMethods     = []enum.MergeMethod{}
+)
+
+var (
+	// KeyMaxFileSize [int64] is the maximum size in bytes of any file pushed to the repo (0 means unlimited).
+	KeyMaxFileSize     Key = "max_file_size"
+	DefaultMaxFileSize     = int64(0)
+)
+
+var (
+	// KeyMaintenanceEnabled [bool] enables the maintenance mode, which blocks write requests.
+	KeyMaintenanceEnabled     Key = "maintenance_enabled"
+	DefaultMaintenanceEnabled     = false
+)
@@ -0,0 +1,260 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/testing/fake"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	prEventSecret    = "pr-secret"
+	prEventSourceSHA = "1111111111111111111111111111111111111111"
+)
+
+// pullReqStoreFake serves a single pull request.
+type pullReqStoreFake struct {
+	store.PullReqStore
+	pr *types.PullReq
+}
+
+func (f pullReqStoreFake) Find(context.Context, int64) (*types.PullReq, error) {
+	return f.pr, nil
+}
+
+// principalStoreFake serves a single principal.
+type principalStoreFake struct {
+	store.PrincipalStore
+	principal *types.Principal
+}
+
+func (f principalStoreFake) Find(context.Context, int64) (*types.Principal, error) {
+	return f.principal, nil
+}
+
+// gitFake serves a single commit.
+type gitFake struct {
+	git.Interface
+}
+
+func (gitFake) GetCommit(_ context.Context, params *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	return &git.GetCommitOutput{
+		Commit: git.Commit{SHA: sha.Must(params.Revision), Title: "add feature", Message: "add feature"},
+	}, nil
+}
+
+// prDelivery is a webhook request received by the test server.
+type prDelivery struct {
+	trigger   string
+	signature string
+	body      []byte
+}
+
+// setupPullReqEventService returns a service with a pull request and a webhook registered for the triggers
+// that delivers to a test server.
+func setupPullReqEventService(
+	t *testing.T,
+	pr *types.PullReq,
+	triggers ...enum.WebhookTrigger,
+) (*Service, *[]prDelivery) {
+	t.Helper()
+
+	deliveries := &[]prDelivery{}
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*deliveries = append(*deliveries, prDelivery{
+			trigger:   r.Header.Get("X-Gitness-Trigger"),
+			signature: r.Header.Get("X-Gitness-Signature"),
+			body:      body,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	webhook := &types.Webhook{ID: 1, Identifier: "hook", URL: server.URL, Secret: prEventSecret,
+		Enabled: true, Triggers: triggers}
+
+	s := setupPingService(t)
+	s.webhookStore = webhookStoreFake{webhooks: map[int64]*types.Webhook{webhook.ID: webhook}}
+	s.webhookExecutionStore = &webhookExecutionStoreFake{}
+	s.repoStore = fake.NewRepoStore(&types.Repository{ID: 1, Identifier: "repo", Path: "space/repo"})
+	s.pullreqStore = pullReqStoreFake{pr: pr}
+	s.principalStore = principalStoreFake{principal: &types.Principal{ID: 7, UID: "jane", Type: enum.PrincipalTypeUser}}
+	s.git = gitFake{}
+
+	return s, deliveries
+}
+
+func newTestPullReq() *types.PullReq {
+	return &types.PullReq{ID: 11, Number: 3, Title: "Add feature", State: enum.PullReqStateOpen,
+		SourceRepoID: 1, SourceBranch: "feature", TargetRepoID: 1, TargetBranch: "main"}
+}
+
+// decodeDelivery verifies the signature of the single delivery and decodes its body.
+func decodeDelivery(t *testing.T, deliveries []prDelivery, wantTrigger enum.WebhookTrigger, payload any) {
+	t.Helper()
+
+	if len(deliveries) != 1 {
+		t.Fatalf("Want 1 delivery, got %d", len(deliveries))
+	}
+	delivery := deliveries[0]
+
+	if delivery.trigger != string(wantTrigger) {
+		t.Errorf("Want trigger header %q, got %q", wantTrigger, delivery.trigger)
+	}
+
+	mac := hmac.New(sha256.New, []byte(prEventSecret))
+	_, _ = mac.Write(delivery.body)
+	if want := hex.EncodeToString(mac.Sum(nil)); delivery.signature != want {
+		t.Errorf("Want signature %q, got %q", want, delivery.signature)
+	}
+
+	if err := json.Unmarshal(delivery.body, payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+}
+
+func TestHandleEventPullReqCreated(t *testing.T) {
+	s, deliveries := setupPullReqEventService(t, newTestPullReq(), enum.WebhookTriggerPullReqCreated)
+
+	err := s.handleEventPullReqCreated(context.Background(), &events.Event[*pullreqevents.CreatedPayload]{
+		ID: "event-created",
+		Payload: &pullreqevents.CreatedPayload{
+			Base:         pullreqevents.Base{PullReqID: 11, SourceRepoID: 1, TargetRepoID: 1, PrincipalID: 7, Number: 3},
+			SourceBranch: "feature",
+			TargetBranch: "main",
+			SourceSHA:    prEventSourceSHA,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	payload := PullReqCreatedPayload{}
+	decodeDelivery(t, *deliveries, enum.WebhookTriggerPullReqCreated, &payload)
+
+	if payload.Trigger != enum.WebhookTriggerPullReqCreated {
+		t.Errorf("Want trigger %q, got %q", enum.WebhookTriggerPullReqCreated, payload.Trigger)
+	}
+	if payload.Principal.UID != "jane" {
+		t.Errorf("Want principal %q, got %q", "jane", payload.Principal.UID)
+	}
+	if payload.PullReq.Number != 3 || payload.PullReq.Title != "Add feature" ||
+		payload.PullReq.State != enum.PullReqStateOpen {
+		t.Errorf("Unexpected pull request %+v", payload.PullReq)
+	}
+	if payload.Ref.Name != "refs/heads/feature" || payload.TargetRef.Name != "refs/heads/main" {
+		t.Errorf("Want refs feature -> main, got %q -> %q", payload.Ref.Name, payload.TargetRef.Name)
+	}
+	if payload.SHA != prEventSourceSHA || payload.HeadCommit == nil || payload.HeadCommit.Message != "add feature" {
+		t.Errorf("Want head commit %s, got %q %+v", prEventSourceSHA, payload.SHA, payload.HeadCommit)
+	}
+}
+
+func TestHandleEventPullReqMerged(t *testing.T) {
+	pr := newTestPullReq()
+	pr.State = enum.PullReqStateMerged
+	method := enum.MergeMethodSquash
+	pr.MergeMethod = &method
+
+	s, deliveries := setupPullReqEventService(t, pr, enum.WebhookTriggerPullReqMerged)
+
+	err := s.handleEventPullReqMerged(context.Background(), &events.Event[*pullreqevents.MergedPayload]{
+		ID: "event-merged",
+		Payload: &pullreqevents.MergedPayload{
+			Base:        pullreqevents.Base{PullReqID: 11, SourceRepoID: 1, TargetRepoID: 1, PrincipalID: 7, Number: 3},
+			MergeMethod: enum.MergeMethodSquash,
+			SourceSHA:   prEventSourceSHA,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	payload := PullReqClosedPayload{}
+	decodeDelivery(t, *deliveries, enum.WebhookTriggerPullReqMerged, &payload)
+
+	if payload.Trigger != enum.WebhookTriggerPullReqMerged {
+		t.Errorf("Want trigger %q, got %q", enum.WebhookTriggerPullReqMerged, payload.Trigger)
+	}
+	if payload.Principal.UID != "jane" {
+		t.Errorf("Want principal %q, got %q", "jane", payload.Principal.UID)
+	}
+	if payload.PullReq.State != enum.PullReqStateMerged || payload.PullReq.MergeStrategy == nil ||
+		*payload.PullReq.MergeStrategy != enum.MergeMethodSquash {
+		t.Errorf("Unexpected pull request %+v", payload.PullReq)
+	}
+}
+
+func TestHandleEventPullReqUpdated(t *testing.T) {
+	s, deliveries := setupPullReqEventService(t, newTestPullReq(), enum.WebhookTriggerPullReqUpdated)
+
+	err := s.handleEventPullReqUpdated(context.Background(), &events.Event[*pullreqevents.UpdatedPayload]{
+		ID: "event-updated",
+		Payload: &pullreqevents.UpdatedPayload{
+			Base:           pullreqevents.Base{PullReqID: 11, SourceRepoID: 1, TargetRepoID: 1, PrincipalID: 7, Number: 3},
+			OldTitle:       "WIP",
+			NewTitle:       "Add feature",
+			OldDescription: "details",
+			NewDescription: "details",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	payload := PullReqUpdatedPayload{}
+	decodeDelivery(t, *deliveries, enum.WebhookTriggerPullReqUpdated, &payload)
+
+	want := PullReqUpdateSegment{TitleChanged: true, TitleOld: "WIP", TitleNew: "Add feature",
+		DescriptionOld: "details", DescriptionNew: "details"}
+	if payload.PullReqUpdateSegment != want {
+		t.Errorf("Want update %+v, got %+v", want, payload.PullReqUpdateSegment)
+	}
+}
+
+func TestHandleEventPullReq_TriggerFilter(t *testing.T) {
+	s, deliveries := setupPullReqEventService(t, newTestPullReq(), enum.WebhookTriggerPullReqMerged)
+
+	err := s.handleEventPullReqCreated(context.Background(), &events.Event[*pullreqevents.CreatedPayload]{
+		ID: "event-created",
+		Payload: &pullreqevents.CreatedPayload{
+			Base:      pullreqevents.Base{PullReqID: 11, SourceRepoID: 1, TargetRepoID: 1, PrincipalID: 7, Number: 3},
+			SourceSHA: prEventSourceSHA,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if len(*deliveries) != 0 {
+		t.Errorf("Want no delivery to webhook not registered for the trigger, got %d", len(*deliveries))
+	}
+}
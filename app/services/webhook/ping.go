@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PingPayload describes the payload of the ping webhook trigger.
+type PingPayload struct {
+	BaseSegment
+	Webhook HookInfo `json:"webhook"`
+}
+
+// HookInfo describes the webhook related info for a webhook payload.
+type HookInfo struct {
+	ID         int64  `json:"id"`
+	Identifier string `json:"identifier"`
+	URL        string `json:"url"`
+}
+
+// PingWebhook sends a synthetic ping event to the webhook and returns the (not persisted) execution.
+// The request is signed and time limited the same way as for any other trigger.
+func (s *Service) PingWebhook(
+	ctx context.Context,
+	webhook *types.Webhook,
+	repo *types.Repository,
+	principal *types.Principal,
+) (*types.WebhookExecution, error) {
+	execution := types.WebhookExecution{
+		WebhookID:   webhook.ID,
+		TriggerType: enum.WebhookTriggerPing,
+		Result:      enum.WebhookExecutionResultFatalError,
+		Error:       "An unknown error occurred",
+	}
+
+	body := &PingPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerPing,
+			Repo:      repositoryInfoFrom(repo, s.urlProvider),
+			Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+		},
+		Webhook: HookInfo{
+			ID:         webhook.ID,
+			Identifier: webhook.Identifier,
+			URL:        webhook.URL,
+		},
+	}
+
+	start := time.Now()
+	err := s.sendWebhook(ctx, &execution, webhook, enum.WebhookTriggerPing, body)
+	execution.Duration = int64(time.Since(start))
+	execution.Created = time.Now().UnixMilli()
+
+	return &execution, err
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// urlProviderFake generates static urls.
+type urlProviderFake struct {
+	url.Provider
+}
+
+func (urlProviderFake) GenerateGITCloneURL(repoPath string) string {
+	return "http://localhost/git/" + repoPath + ".git"
+}
+
+func (urlProviderFake) GenerateUIPRURL(repoPath string, prID int64) string {
+	return "http://localhost/" + repoPath + "/pulls/" + strconv.FormatInt(prID, 10)
+}
+
+func setupPingService(t *testing.T) *Service {
+	t.Helper()
+
+	encrypter, err := encrypt.ProvideEncrypter(&types.Config{})
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	return &Service{
+		urlProvider:      urlProviderFake{},
+		encrypter:        encrypter,
+		secureHTTPClient: http.DefaultClient,
+		retry:            &retryPolicy{maxAttempts: 1},
+		health:           newHealthTracker(realClock{}),
+		config: Config{
+			UserAgentIdentity: "Gitness",
+			HeaderIdentity:    "Gitness",
+		},
+	}
+}
+
+func TestPingWebhook_Success(t *testing.T) {
+	const secret = "ping-secret"
+
+	var gotTrigger, gotSignature string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTrigger = r.Header.Get("X-Gitness-Trigger")
+		gotSignature = r.Header.Get("X-Gitness-Signature")
+		gotBody, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte("pong"))
+	}))
+	defer server.Close()
+
+	s := setupPingService(t)
+	webhook := &types.Webhook{ID: 1, Identifier: "hook", URL: server.URL, Secret: secret, Enabled: true}
+	repo := &types.Repository{ID: 1, Identifier: "repo", Path: "space/repo"}
+	principal := &types.Principal{ID: 1, UID: "user"}
+
+	execution, err := s.PingWebhook(context.Background(), webhook, repo, principal)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if execution.Result != enum.WebhookExecutionResultSuccess {
+		t.Errorf("Want result %s, got %s", enum.WebhookExecutionResultSuccess, execution.Result)
+	}
+	if execution.Response.StatusCode != http.StatusOK || execution.Response.Body != "pong" {
+		t.Errorf("Want response 200 'pong', got %d %q", execution.Response.StatusCode, execution.Response.Body)
+	}
+	if execution.Duration <= 0 {
+		t.Errorf("Want positive duration, got %d", execution.Duration)
+	}
+
+	if gotTrigger != string(enum.WebhookTriggerPing) {
+		t.Errorf("Want trigger header %q, got %q", enum.WebhookTriggerPing, gotTrigger)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(gotBody)
+	if want := hex.EncodeToString(mac.Sum(nil)); gotSignature != want {
+		t.Errorf("Want signature %q, got %q", want, gotSignature)
+	}
+
+	payload := PingPayload{}
+	if err = json.Unmarshal(gotBody, &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if payload.Trigger != enum.WebhookTriggerPing || payload.Webhook.Identifier != "hook" ||
+		payload.Repo.Path != "space/repo" {
+		t.Errorf("Unexpected ping payload %+v", payload)
+	}
+}
+
+func TestPingWebhook_Timeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	s := setupPingService(t)
+	webhook := &types.Webhook{ID: 1, Identifier: "hook", URL: server.URL, Enabled: true}
+	repo := &types.Repository{ID: 1, Identifier: "repo", Path: "space/repo"}
+	principal := &types.Principal{ID: 1, UID: "user"}
+
+	// the parent deadline is shorter than the webhook time limit and takes precedence.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	execution, err := s.PingWebhook(ctx, webhook, repo, principal)
+	if err == nil {
+		t.Fatalf("Want timeout error, got none")
+	}
+
+	if execution.Result != enum.WebhookExecutionResultFatalError {
+		t.Errorf("Want result %s, got %s", enum.WebhookExecutionResultFatalError, execution.Result)
+	}
+	if !strings.Contains(execution.Error, "time limit") {
+		t.Errorf("Want time limit error, got %q", execution.Error)
+	}
+}
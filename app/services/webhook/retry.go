@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// retryMaxAttempts defines how often a webhook is executed for a single trigger before giving up.
+	retryMaxAttempts = 3
+
+	// retryBaseDelay and retryMaxDelay define the capped exponential backoff between attempts.
+	retryBaseDelay = 1 * time.Second
+	retryMaxDelay  = 10 * time.Second
+
+	// healthFailureThreshold defines the number of consecutive failed triggers after which
+	// a webhook is temporarily disabled.
+	healthFailureThreshold = 5
+
+	// healthCooldown defines how long a webhook stays disabled before it's tried again.
+	healthCooldown = 5 * time.Minute
+)
+
+// clock abstracts time to allow testing the retry schedule without waiting.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// retryPolicy retries retriable webhook executions using capped exponential backoff with jitter.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	clock       clock
+	// jitter returns a random number in [0, n).
+	jitter func(n int64) int64
+}
+
+func newRetryPolicy(clock clock) *retryPolicy {
+	return &retryPolicy{
+		maxAttempts: retryMaxAttempts,
+		baseDelay:   retryBaseDelay,
+		maxDelay:    retryMaxDelay,
+		clock:       clock,
+		//nolint:gosec // jitter doesn't need a cryptographically secure source.
+		jitter: rand.Int63n,
+	}
+}
+
+// delay returns the delay before the provided attempt (starting at 2, as the first attempt isn't delayed).
+// The delay is randomized within the upper half of the capped exponential backoff
+// to spread out retries of webhooks that failed at the same time.
+func (p *retryPolicy) delay(attempt int) time.Duration {
+	backoff := p.maxDelay
+	if shift := attempt - 2; shift < 32 {
+		if d := p.baseDelay << shift; d > 0 && d < p.maxDelay {
+			backoff = d
+		}
+	}
+
+	half := backoff / 2
+	return half + time.Duration(p.jitter(int64(backoff-half)+1))
+}
+
+// wait blocks for the delay of the provided attempt, or until the context is done.
+func (p *retryPolicy) wait(ctx context.Context, attempt int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.clock.After(p.delay(attempt)):
+		return nil
+	}
+}
+
+// healthTracker temporarily disables webhooks after sustained failures,
+// and re-enables them after a later successful execution.
+// NOTE: the state is kept in memory and isn't shared between instances.
+type healthTracker struct {
+	threshold int
+	cooldown  time.Duration
+	clock     clock
+
+	mx       sync.Mutex
+	failures map[int64]int
+	disabled map[int64]time.Time
+}
+
+func newHealthTracker(clock clock) *healthTracker {
+	return &healthTracker{
+		threshold: healthFailureThreshold,
+		cooldown:  healthCooldown,
+		clock:     clock,
+		failures:  make(map[int64]int),
+		disabled:  make(map[int64]time.Time),
+	}
+}
+
+// Allowed returns false while the webhook is temporarily disabled.
+// Once the cooldown passed, the webhook is allowed again to probe whether it recovered.
+func (h *healthTracker) Allowed(webhookID int64) bool {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	until, ok := h.disabled[webhookID]
+	return !ok || !h.clock.Now().Before(until)
+}
+
+// Disabled returns true if the webhook got temporarily disabled (even if its cooldown passed already).
+func (h *healthTracker) Disabled(webhookID int64) bool {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	_, ok := h.disabled[webhookID]
+	return ok
+}
+
+// Report records the final result of a webhook trigger.
+func (h *healthTracker) Report(ctx context.Context, webhookID int64, result enum.WebhookExecutionResult) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	if result == enum.WebhookExecutionResultSuccess {
+		if _, ok := h.disabled[webhookID]; ok {
+			log.Ctx(ctx).Info().Msgf("webhook %d succeeded and got re-enabled", webhookID)
+		}
+		delete(h.failures, webhookID)
+		delete(h.disabled, webhookID)
+		return
+	}
+
+	h.failures[webhookID]++
+	if h.failures[webhookID] < h.threshold {
+		return
+	}
+
+	until := h.clock.Now().Add(h.cooldown)
+	if _, ok := h.disabled[webhookID]; !ok {
+		log.Ctx(ctx).Warn().Msgf("webhook %d failed %d times in a row and got disabled until %s",
+			webhookID, h.failures[webhookID], until.Format(time.RFC3339))
+	}
+	h.disabled[webhookID] = until
+}
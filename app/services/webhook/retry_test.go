@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// clockFake advances time instantly and records all requested delays.
+type clockFake struct {
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *clockFake) Now() time.Time {
+	return c.now
+}
+
+func (c *clockFake) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	c.now = c.now.Add(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{attempt: 2, wantMin: 500 * time.Millisecond, wantMax: 1 * time.Second},
+		{attempt: 3, wantMin: 1 * time.Second, wantMax: 2 * time.Second},
+		{attempt: 4, wantMin: 2 * time.Second, wantMax: 4 * time.Second},
+		{attempt: 5, wantMin: 4 * time.Second, wantMax: 8 * time.Second},
+		{attempt: 6, wantMin: 5 * time.Second, wantMax: 10 * time.Second},
+		{attempt: 100, wantMin: 5 * time.Second, wantMax: 10 * time.Second},
+	}
+
+	for _, test := range tests {
+		p := newRetryPolicy(&clockFake{})
+
+		// the extremes of the jitter have to hit the bounds exactly.
+		p.jitter = func(int64) int64 { return 0 }
+		if got := p.delay(test.attempt); got != test.wantMin {
+			t.Errorf("attempt %d: Want min delay %s, got %s", test.attempt, test.wantMin, got)
+		}
+		p.jitter = func(n int64) int64 { return n - 1 }
+		if got := p.delay(test.attempt); got != test.wantMax {
+			t.Errorf("attempt %d: Want max delay %s, got %s", test.attempt, test.wantMax, got)
+		}
+
+		// random jitter has to stay within the bounds.
+		p = newRetryPolicy(&clockFake{})
+		for i := 0; i < 100; i++ {
+			if got := p.delay(test.attempt); got < test.wantMin || got > test.wantMax {
+				t.Fatalf("attempt %d: Want delay within [%s, %s], got %s",
+					test.attempt, test.wantMin, test.wantMax, got)
+			}
+		}
+	}
+}
+
+func TestExecuteWebhookWithRetry(t *testing.T) {
+	deliveries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		deliveries++
+		if deliveries < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	webhook := &types.Webhook{ID: 1, URL: server.URL, Enabled: true}
+	clock := &clockFake{now: time.Now()}
+
+	s := setupPingService(t)
+	s.webhookStore = webhookStoreFake{webhooks: map[int64]*types.Webhook{webhook.ID: webhook}}
+	executionStore := &webhookExecutionStoreFake{}
+	s.webhookExecutionStore = executionStore
+	s.retry = newRetryPolicy(clock)
+
+	results, err := s.triggerWebhooks(context.Background(), []*types.Webhook{webhook},
+		"trigger", enum.WebhookTriggerBranchUpdated, &ReferencePayload{})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if results[0].Execution.Result != enum.WebhookExecutionResultSuccess {
+		t.Errorf("Want result %s, got %s", enum.WebhookExecutionResultSuccess, results[0].Execution.Result)
+	}
+	if deliveries != 3 || len(executionStore.executions) != 3 {
+		t.Errorf("Want 3 deliveries and executions, got %d and %d", deliveries, len(executionStore.executions))
+	}
+
+	if len(clock.delays) != 2 {
+		t.Fatalf("Want 2 delays, got %v", clock.delays)
+	}
+	for i, delay := range clock.delays {
+		backoff := retryBaseDelay << i
+		if delay < backoff/2 || delay > backoff {
+			t.Errorf("Want delay %d within [%s, %s], got %s", i, backoff/2, backoff, delay)
+		}
+	}
+}
+
+func TestExecuteWebhookWithRetry_FatalNotRetried(t *testing.T) {
+	deliveries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		deliveries++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	webhook := &types.Webhook{ID: 1, URL: server.URL, Enabled: true}
+	clock := &clockFake{now: time.Now()}
+
+	s := setupPingService(t)
+	s.webhookStore = webhookStoreFake{webhooks: map[int64]*types.Webhook{webhook.ID: webhook}}
+	s.webhookExecutionStore = &webhookExecutionStoreFake{}
+	s.retry = newRetryPolicy(clock)
+
+	results, err := s.triggerWebhooks(context.Background(), []*types.Webhook{webhook},
+		"trigger", enum.WebhookTriggerBranchUpdated, &ReferencePayload{})
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if results[0].Execution.Result != enum.WebhookExecutionResultFatalError {
+		t.Errorf("Want result %s, got %s", enum.WebhookExecutionResultFatalError, results[0].Execution.Result)
+	}
+	if deliveries != 1 || len(clock.delays) != 0 {
+		t.Errorf("Want a single delivery without delay, got %d deliveries and delays %v", deliveries, clock.delays)
+	}
+}
+
+func TestHealthTracker(t *testing.T) {
+	ctx := context.Background()
+	clock := &clockFake{now: time.Now()}
+	h := newHealthTracker(clock)
+
+	// failures below the threshold keep the webhook enabled.
+	for i := 1; i < healthFailureThreshold; i++ {
+		h.Report(ctx, 1, enum.WebhookExecutionResultRetriableError)
+	}
+	if !h.Allowed(1) || h.Disabled(1) {
+		t.Fatalf("Want webhook enabled below the failure threshold")
+	}
+
+	// reaching the threshold disables the webhook for the cooldown.
+	h.Report(ctx, 1, enum.WebhookExecutionResultFatalError)
+	if h.Allowed(1) || !h.Disabled(1) {
+		t.Fatalf("Want webhook disabled after %d failures", healthFailureThreshold)
+	}
+	if !h.Allowed(2) {
+		t.Errorf("Want other webhooks to be unaffected")
+	}
+
+	// after the cooldown a single probe is allowed, failing it disables the webhook again.
+	clock.now = clock.now.Add(healthCooldown)
+	if !h.Allowed(1) {
+		t.Fatalf("Want webhook allowed after the cooldown")
+	}
+	h.Report(ctx, 1, enum.WebhookExecutionResultRetriableError)
+	if h.Allowed(1) {
+		t.Fatalf("Want webhook disabled after failed probe")
+	}
+
+	// a later success re-enables the webhook and resets the failure count.
+	clock.now = clock.now.Add(healthCooldown)
+	h.Report(ctx, 1, enum.WebhookExecutionResultSuccess)
+	if !h.Allowed(1) || h.Disabled(1) {
+		t.Fatalf("Want webhook re-enabled after success")
+	}
+	h.Report(ctx, 1, enum.WebhookExecutionResultRetriableError)
+	if !h.Allowed(1) {
+		t.Errorf("Want failure count to be reset after success")
+	}
+}
+
+func TestTriggerWebhooks_SkipsDisabled(t *testing.T) {
+	deliveries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		deliveries++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	webhook := &types.Webhook{ID: 1, URL: server.URL, Enabled: true}
+	clock := &clockFake{now: time.Now()}
+
+	s := setupPingService(t)
+	s.webhookStore = webhookStoreFake{webhooks: map[int64]*types.Webhook{webhook.ID: webhook}}
+	s.webhookExecutionStore = &webhookExecutionStoreFake{}
+	s.health = newHealthTracker(clock)
+
+	for i := 0; i <= healthFailureThreshold; i++ {
+		results, err := s.triggerWebhooks(context.Background(), []*types.Webhook{webhook},
+			"trigger", enum.WebhookTriggerBranchUpdated, &ReferencePayload{})
+		if err != nil {
+			t.Fatalf("Want no error, got %v", err)
+		}
+
+		if wantSkipped := i == healthFailureThreshold; results[0].Skipped() != wantSkipped {
+			t.Errorf("trigger %d: Want skipped %t, got %t", i, wantSkipped, results[0].Skipped())
+		}
+	}
+
+	if deliveries != healthFailureThreshold {
+		t.Errorf("Want %d deliveries, got %d", healthFailureThreshold, deliveries)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// webhookSecretLength is the number of random bytes of a generated webhook secret.
+const webhookSecretLength = 32
+
+// RegenerateSecret replaces the secret of the webhook with a newly generated random secret.
+// The new secret is used to sign all subsequent deliveries, while the signatures of past executions stay as they are.
+// The plain secret is returned as it can't be retrieved again later.
+func (s *Service) RegenerateSecret(
+	ctx context.Context,
+	webhook *types.Webhook,
+) (*types.Webhook, string, error) {
+	b := make([]byte, webhookSecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	secret := hex.EncodeToString(b)
+
+	encryptedSecret, err := s.encrypter.Encrypt(secret)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	webhook, err = s.webhookStore.UpdateOptLock(ctx, webhook, func(hook *types.Webhook) error {
+		hook.Secret = string(encryptedSecret)
+		return nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to update webhook secret: %w", err)
+	}
+
+	return webhook, secret, nil
+}
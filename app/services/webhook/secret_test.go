@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRegenerateSecret(t *testing.T) {
+	const oldSecret = "old-secret"
+
+	var gotSignature string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get("X-Gitness-Signature")
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	s := setupPingService(t)
+	s.webhookStore = webhookStoreFake{}
+	s.webhookExecutionStore = &webhookExecutionStoreFake{}
+
+	encryptedOldSecret, err := s.encrypter.Encrypt(oldSecret)
+	if err != nil {
+		t.Fatalf("failed to encrypt secret: %v", err)
+	}
+	webhook := &types.Webhook{ID: 1, URL: server.URL, Enabled: true, Secret: string(encryptedOldSecret)}
+
+	webhook, newSecret, err := s.RegenerateSecret(context.Background(), webhook)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if newSecret == "" || newSecret == oldSecret {
+		t.Fatalf("Want a new non-empty secret, got %q", newSecret)
+	}
+	if len(newSecret) != 2*webhookSecretLength {
+		t.Errorf("Want secret of length %d, got %d", 2*webhookSecretLength, len(newSecret))
+	}
+
+	body := &ReferencePayload{
+		ReferenceSegment: ReferenceSegment{Ref: ReferenceInfo{Name: "refs/heads/main"}},
+	}
+	_, err = s.triggerWebhooks(context.Background(), []*types.Webhook{webhook},
+		"trigger", enum.WebhookTriggerBranchUpdated, body)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write(gotBody)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	if gotSignature != sign(newSecret) {
+		t.Errorf("Want delivery signed with the new secret, got signature %q", gotSignature)
+	}
+	if gotSignature == sign(oldSecret) {
+		t.Errorf("Want old secret to no longer verify the delivery")
+	}
+}
@@ -92,6 +92,9 @@ type Service struct {
 	secureHTTPClientInternal   *http.Client
 	insecureHTTPClientInternal *http.Client
 
+	retry  *retryPolicy
+	health *healthTracker
+
 	config Config
 }
 
@@ -130,6 +133,9 @@ func NewService(
 		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
 		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
 
+		retry:  newRetryPolicy(realClock{}),
+		health: newHealthTracker(realClock{}),
+
 		config: config,
 	}
 
@@ -171,6 +177,7 @@ func NewService(
 			// register events
 			_ = r.RegisterCreated(service.handleEventPullReqCreated)
 			_ = r.RegisterReopened(service.handleEventPullReqReopened)
+			_ = r.RegisterUpdated(service.handleEventPullReqUpdated)
 			_ = r.RegisterBranchUpdated(service.handleEventPullReqBranchUpdated)
 			_ = r.RegisterClosed(service.handleEventPullReqClosed)
 			_ = r.RegisterCommentCreated(service.handleEventPullReqComment)
@@ -26,6 +26,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/harness/gitness/store"
@@ -33,6 +34,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 	"github.com/harness/gitness/version"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/rs/zerolog/log"
 )
 
@@ -133,13 +135,69 @@ func (s *Service) triggerWebhooks(ctx context.Context, webhooks []*types.Webhook
 			continue
 		}
 
+		// check if the branch of a branch trigger matches the webhook (empty pattern => all branches)
+		if !branchPatternMatches(webhook, triggerType, body) {
+			continue
+		}
+
+		// check if webhook got temporarily disabled due to sustained failures
+		if !s.health.Allowed(webhook.ID) {
+			continue
+		}
+
 		// execute trigger and store output in result
-		results[i].Execution, results[i].Err = s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+		results[i].Execution, results[i].Err = s.executeWebhookWithRetry(ctx, webhook, triggerID, triggerType, body)
+		s.health.Report(ctx, webhook.ID, results[i].Execution.Result)
 	}
 
 	return results, nil
 }
 
+// executeWebhookWithRetry executes the webhook and retries retriable failures with backoff.
+// Every attempt is stored as separate execution, the last execution is returned.
+func (s *Service) executeWebhookWithRetry(ctx context.Context, webhook *types.Webhook, triggerID string,
+	triggerType enum.WebhookTrigger, body any) (*types.WebhookExecution, error) {
+	execution, err := s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+
+	for attempt := 2; attempt <= s.retry.maxAttempts; attempt++ {
+		if execution.Result != enum.WebhookExecutionResultRetriableError {
+			break
+		}
+
+		if waitErr := s.retry.wait(ctx, attempt); waitErr != nil {
+			break
+		}
+
+		execution, err = s.executeWebhook(ctx, webhook, triggerID, triggerType, body, nil)
+	}
+
+	return execution, err
+}
+
+// branchPatternMatches returns true if the branch of a branch trigger matches the branch pattern of the webhook.
+// Non-branch triggers and webhooks without a branch pattern always match.
+func branchPatternMatches(webhook *types.Webhook, triggerType enum.WebhookTrigger, body any) bool {
+	if webhook.BranchPattern == "" {
+		return true
+	}
+
+	if triggerType != enum.WebhookTriggerBranchCreated &&
+		triggerType != enum.WebhookTriggerBranchUpdated &&
+		triggerType != enum.WebhookTriggerBranchDeleted {
+		return true
+	}
+
+	payload, ok := body.(*ReferencePayload)
+	if !ok {
+		return true
+	}
+
+	branch := strings.TrimPrefix(payload.Ref.Name, gitReferenceNamePrefixBranch)
+	match, _ := doublestar.Match(webhook.BranchPattern, branch)
+
+	return match
+}
+
 func (s *Service) RetriggerWebhookExecution(ctx context.Context, webhookExecutionID int64) (*TriggerResult, error) {
 	// find execution
 	webhookExecution, err := s.webhookExecutionStore.Find(ctx, webhookExecutionID)
@@ -177,7 +235,6 @@ func (s *Service) RetriggerWebhookExecution(ctx context.Context, webhookExecutio
 	}, nil
 }
 
-//nolint:gocognit // refactor into smaller chunks if necessary.
 func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, triggerID string,
 	triggerType enum.WebhookTrigger, body any, rerunOfID *int64) (*types.WebhookExecution, error) {
 	// build execution entry on the fly (save no matter what)
@@ -217,14 +274,24 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		}
 	}(ctx, time.Now())
 
+	err := s.sendWebhook(ctx, &execution, webhook, triggerType, body)
+
+	return &execution, err
+}
+
+// sendWebhook sends the webhook request and records the request and response details in the execution.
+//
+//nolint:gocognit // refactor into smaller chunks if necessary.
+func (s *Service) sendWebhook(ctx context.Context, execution *types.WebhookExecution, webhook *types.Webhook,
+	triggerType enum.WebhookTrigger, body any) error {
 	// derive context with time limit
 	ctx, cancel := context.WithTimeout(ctx, webhookTimeLimit)
 	defer cancel()
 
 	// create request from webhook and body
-	req, err := s.prepareHTTPRequest(ctx, &execution, triggerType, webhook, body)
+	req, err := s.prepareHTTPRequest(ctx, execution, triggerType, webhook, body)
 	if err != nil {
-		return &execution, err
+		return err
 	}
 
 	// Execute HTTP Request (insecure if requested)
@@ -258,26 +325,24 @@ func (s *Service) executeWebhook(ctx context.Context, webhook *types.Webhook, tr
 		tErr := fmt.Errorf("request exceeded time limit of %s", webhookTimeLimit)
 		execution.Error = tErr.Error()
 		execution.Result = enum.WebhookExecutionResultFatalError
-		return &execution, tErr
+		return tErr
 
 	case errors.As(err, &dnsError) && dnsError.IsNotFound:
 		// this error is assumed unrecoverable - mark status accordingly and fail execution
 		execution.Error = fmt.Sprintf("host '%s' was not found", dnsError.Name)
 		execution.Result = enum.WebhookExecutionResultFatalError
-		return &execution, fmt.Errorf("failed to resolve host name '%s': %w", dnsError.Name, err)
+		return fmt.Errorf("failed to resolve host name '%s': %w", dnsError.Name, err)
 
 	case err != nil:
 		// for all other errors we don't retry - protect the system. User can retrigger manually (if body was set)
 		tErr := fmt.Errorf("an error occurred while sending the request: %w", err)
 		execution.Error = tErr.Error()
 		execution.Result = enum.WebhookExecutionResultFatalError
-		return &execution, tErr
+		return tErr
 	}
 
 	// handle response
-	err = handleWebhookResponse(&execution, resp)
-
-	return &execution, err
+	return handleWebhookResponse(execution, resp)
 }
 
 // prepareHTTPRequest prepares a new http.Request object for the webhook using the provided body as request body.
@@ -0,0 +1,217 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// webhookStoreFake ignores updates of the latest execution result.
+type webhookStoreFake struct {
+	store.WebhookStore
+
+	webhooks map[int64]*types.Webhook
+}
+
+func (s webhookStoreFake) Find(_ context.Context, id int64) (*types.Webhook, error) {
+	hook, ok := s.webhooks[id]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return hook, nil
+}
+
+func (s webhookStoreFake) List(
+	context.Context,
+	enum.WebhookParent,
+	int64,
+	*types.WebhookFilter,
+) ([]*types.Webhook, error) {
+	webhooks := make([]*types.Webhook, 0, len(s.webhooks))
+	for _, hook := range s.webhooks {
+		webhooks = append(webhooks, hook)
+	}
+	return webhooks, nil
+}
+
+func (webhookStoreFake) UpdateOptLock(_ context.Context, hook *types.Webhook,
+	mutateFn func(hook *types.Webhook) error) (*types.Webhook, error) {
+	return hook, mutateFn(hook)
+}
+
+// webhookExecutionStoreFake is an in-memory webhook execution store.
+type webhookExecutionStoreFake struct {
+	store.WebhookExecutionStore
+
+	executions []*types.WebhookExecution
+}
+
+func (s *webhookExecutionStoreFake) Create(_ context.Context, execution *types.WebhookExecution) error {
+	s.executions = append(s.executions, execution)
+	execution.ID = int64(len(s.executions))
+	return nil
+}
+
+func (s *webhookExecutionStoreFake) Find(_ context.Context, id int64) (*types.WebhookExecution, error) {
+	for _, execution := range s.executions {
+		if execution.ID == id {
+			return execution, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *webhookExecutionStoreFake) ListForTrigger(context.Context, string) ([]*types.WebhookExecution, error) {
+	return nil, nil
+}
+
+func TestTriggerWebhooks_BranchPattern(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern       string
+		triggerType   enum.WebhookTrigger
+		ref           string
+		wantDelivered bool
+	}{
+		{name: "empty pattern matches all branches", pattern: "",
+			triggerType: enum.WebhookTriggerBranchUpdated, ref: "refs/heads/feature/x", wantDelivered: true},
+		{name: "matching branch", pattern: "release/*",
+			triggerType: enum.WebhookTriggerBranchUpdated, ref: "refs/heads/release/1.0", wantDelivered: true},
+		{name: "non-matching branch", pattern: "release/*",
+			triggerType: enum.WebhookTriggerBranchUpdated, ref: "refs/heads/main", wantDelivered: false},
+		{name: "non-matching created branch", pattern: "main",
+			triggerType: enum.WebhookTriggerBranchCreated, ref: "refs/heads/feature", wantDelivered: false},
+		{name: "pattern ignored for tags", pattern: "main",
+			triggerType: enum.WebhookTriggerTagCreated, ref: "refs/tags/v1.0", wantDelivered: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deliveries := 0
+			server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				deliveries++
+			}))
+			defer server.Close()
+
+			s := setupPingService(t)
+			s.webhookStore = webhookStoreFake{}
+			executionStore := &webhookExecutionStoreFake{}
+			s.webhookExecutionStore = executionStore
+
+			webhook := &types.Webhook{ID: 1, URL: server.URL, Enabled: true, BranchPattern: test.pattern}
+			body := &ReferencePayload{
+				ReferenceSegment: ReferenceSegment{Ref: ReferenceInfo{Name: test.ref}},
+			}
+
+			results, err := s.triggerWebhooks(context.Background(), []*types.Webhook{webhook},
+				"trigger", test.triggerType, body)
+			if err != nil {
+				t.Fatalf("Want no error, got %v", err)
+			}
+
+			if results[0].Skipped() == test.wantDelivered {
+				t.Errorf("Want skipped %t, got %t", !test.wantDelivered, results[0].Skipped())
+			}
+
+			wantDeliveries := 0
+			if test.wantDelivered {
+				wantDeliveries = 1
+			}
+			if deliveries != wantDeliveries {
+				t.Errorf("Want %d deliveries, got %d", wantDeliveries, deliveries)
+			}
+			if len(executionStore.executions) != wantDeliveries {
+				t.Errorf("Want %d executions, got %d", wantDeliveries, len(executionStore.executions))
+			}
+		})
+	}
+}
+
+func TestRetriggerWebhookExecution(t *testing.T) {
+	var gotBodies, gotSignatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBodies = append(gotBodies, string(body))
+		gotSignatures = append(gotSignatures, r.Header.Get("X-Gitness-Signature"))
+
+		// fail the original delivery, accept the replay.
+		if len(gotBodies) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	webhook := &types.Webhook{ID: 1, URL: server.URL, Secret: "old-secret", Enabled: true}
+
+	s := setupPingService(t)
+	s.webhookStore = webhookStoreFake{webhooks: map[int64]*types.Webhook{webhook.ID: webhook}}
+	executionStore := &webhookExecutionStoreFake{}
+	s.webhookExecutionStore = executionStore
+
+	body := &ReferencePayload{
+		ReferenceSegment: ReferenceSegment{Ref: ReferenceInfo{Name: "refs/heads/main"}},
+	}
+	_, err := s.triggerWebhooks(context.Background(), []*types.Webhook{webhook},
+		"trigger", enum.WebhookTriggerBranchUpdated, body)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	original := executionStore.executions[0]
+	if original.Result != enum.WebhookExecutionResultRetriableError {
+		t.Fatalf("Want original result %s, got %s", enum.WebhookExecutionResultRetriableError, original.Result)
+	}
+
+	// replays are signed with the current secret of the webhook.
+	webhook.Secret = "new-secret"
+
+	result, err := s.RetriggerWebhookExecution(context.Background(), original.ID)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if result.Err != nil {
+		t.Fatalf("Want no execution error, got %v", result.Err)
+	}
+
+	replay := result.Execution
+	if replay.Result != enum.WebhookExecutionResultSuccess {
+		t.Errorf("Want replay result %s, got %s", enum.WebhookExecutionResultSuccess, replay.Result)
+	}
+	if replay.RetriggerOf == nil || *replay.RetriggerOf != original.ID {
+		t.Errorf("Want replay to reference execution %d, got %v", original.ID, replay.RetriggerOf)
+	}
+	if replay.TriggerID != original.TriggerID {
+		t.Errorf("Want trigger id %q, got %q", original.TriggerID, replay.TriggerID)
+	}
+	if len(executionStore.executions) != 2 {
+		t.Errorf("Want 2 stored executions, got %d", len(executionStore.executions))
+	}
+
+	if len(gotBodies) != 2 || gotBodies[1] != gotBodies[0] {
+		t.Fatalf("Want original payload to be resent, got %q", gotBodies)
+	}
+	if want, _ := generateHMACSHA256([]byte(gotBodies[1]), []byte("new-secret")); gotSignatures[1] != want {
+		t.Errorf("Want signature %q, got %q", want, gotSignatures[1])
+	}
+}
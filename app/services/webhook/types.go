@@ -74,6 +74,16 @@ type PullReqSegment struct {
 	PullReq PullReqInfo `json:"pull_req"`
 }
 
+// PullReqUpdateSegment contains details of the pull req title and description update for webhooks.
+type PullReqUpdateSegment struct {
+	TitleChanged       bool   `json:"title_changed"`
+	TitleOld           string `json:"title_old"`
+	TitleNew           string `json:"title_new"`
+	DescriptionChanged bool   `json:"description_changed"`
+	DescriptionOld     string `json:"description_old"`
+	DescriptionNew     string `json:"description_new"`
+}
+
 // PullReqCommentSegment contains details for all pull req comment related payloads for webhooks.
 type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
@@ -18,9 +18,11 @@ import (
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repoactivity"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
@@ -43,6 +45,8 @@ type Services struct {
 	Cleanup            *cleanup.Service
 	Notification       *notification.Service
 	Keywordsearch      *keywordsearch.Service
+	RepoActivity       *repoactivity.Service
+	Mirror             *mirror.Service
 }
 
 func ProvideServices(
@@ -56,6 +60,8 @@ func ProvideServices(
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	repoActivitySvc *repoactivity.Service,
+	mirrorSvc *mirror.Service,
 ) Services {
 	return Services{
 		Webhook:            webhooksSvc,
@@ -68,5 +74,7 @@ func ProvideServices(
 		Cleanup:            cleanupSvc,
 		Notification:       notificationSvc,
 		Keywordsearch:      keywordsearchSvc,
+		RepoActivity:       repoActivitySvc,
+		Mirror:             mirrorSvc,
 	}
 }
@@ -43,6 +43,9 @@ type (
 		// FindByEmail finds the principal by email.
 		FindByEmail(ctx context.Context, email string) (*types.Principal, error)
 
+		// FindPassword finds the password hash of the principal, independent of its type.
+		FindPassword(ctx context.Context, id int64) (string, error)
+
 		/*
 		 * USER RELATED OPERATIONS.
 		 */
@@ -59,12 +62,33 @@ type (
 		// FindUserByEmail finds the user by email.
 		FindUserByEmail(ctx context.Context, email string) (*types.User, error)
 
+		// FindUserByExternalID finds the user provisioned by the auth source with the provided external id.
+		FindUserByExternalID(ctx context.Context, authSource string, externalID string) (*types.User, error)
+
 		// CreateUser saves the user details.
 		CreateUser(ctx context.Context, user *types.User) error
 
 		// UpdateUser updates an existing user.
 		UpdateUser(ctx context.Context, user *types.User) error
 
+		// UpdateUserIfUnmodified updates an existing user if its version still matches the provided version.
+		// It returns store.ErrVersionConflict if the user was modified in the meantime.
+		UpdateUserIfUnmodified(ctx context.Context, user *types.User, version int64) error
+
+		// IncrementTokenGeneration increases the token generation of the user, which invalidates all its tokens.
+		// It returns the new token generation.
+		IncrementTokenGeneration(ctx context.Context, id int64) (int64, error)
+
+		// IncrementFailedLogins increases the number of failed logins of the user and returns the new count.
+		IncrementFailedLogins(ctx context.Context, id int64) (int, error)
+
+		// UpdateLockedUntil locks the user until the provided time (0 unlocks the user) and resets its failed logins.
+		UpdateLockedUntil(ctx context.Context, id int64, lockedUntil int64) error
+
+		// UpdatePassword replaces the password hash of the user if it still matches the current hash.
+		// It returns store.ErrVersionConflict if the password was changed in the meantime.
+		UpdatePassword(ctx context.Context, id int64, currentHash string, hash string) error
+
 		// DeleteUser deletes the user.
 		DeleteUser(ctx context.Context, id int64) error
 
@@ -194,6 +218,10 @@ type (
 
 		// List returns a list of child spaces in a space.
 		List(ctx context.Context, id int64, opts *types.SpaceFilter) ([]*types.Space, error)
+
+		// Search returns up to limit active spaces of all levels whose identifier contains the query,
+		// shorter identifiers first, skipping the first offset matches.
+		Search(ctx context.Context, query string, offset int, limit int) ([]*types.Space, error)
 	}
 
 	// RepoStore defines the repository data storage.
@@ -239,6 +267,10 @@ type (
 		// List returns a list of repos in a space. With "DeletedBeforeOrAt" filter, lists deleted repos.
 		List(ctx context.Context, parentID int64, opts *types.RepoFilter) ([]*types.Repository, error)
 
+		// Search returns up to limit active repos of all spaces whose identifier contains the query,
+		// shorter identifiers first, skipping the first offset matches.
+		Search(ctx context.Context, query string, offset int, limit int) ([]*types.Repository, error)
+
 		// ListSizeInfos returns a list of all active repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
 	}
@@ -302,6 +334,7 @@ type (
 		Create(ctx context.Context, token *types.Token) error
 
 		// Delete deletes the token with the given id.
+		// Returns ErrResourceNotFound if the token doesn't exist (anymore).
 		Delete(ctx context.Context, id int64) error
 
 		// DeleteExpiredBefore deletes all tokens that expired before the provided time.
@@ -313,6 +346,163 @@ type (
 
 		// Count returns a count of tokens of a specifc type for a specific principal.
 		Count(ctx context.Context, principalID int64, tokenType enum.TokenType) (int64, error)
+
+		// ListActive returns all tokens of a principal that can still be used for authentication,
+		// i.e. tokens of the provided generation or newer that aren't expired and aren't password reset tokens.
+		ListActive(ctx context.Context, principalID int64, generation int64, now time.Time) ([]*types.Token, error)
+
+		// UpdateLastUsed sets the time at which the token was last used.
+		UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error
+	}
+
+	// UserEmailStore defines the secondary user email data storage.
+	UserEmailStore interface {
+		// Find finds the user email by id.
+		Find(ctx context.Context, id int64) (*types.UserEmail, error)
+
+		// FindByEmail finds the user email by its (case-insensitive) address.
+		FindByEmail(ctx context.Context, email string) (*types.UserEmail, error)
+
+		// Create saves the user email.
+		Create(ctx context.Context, email *types.UserEmail) error
+
+		// Update updates the user email.
+		Update(ctx context.Context, email *types.UserEmail) error
+
+		// Delete deletes the user email with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all secondary emails of a user.
+		List(ctx context.Context, principalID int64) ([]*types.UserEmail, error)
+
+		// MapPrincipalIDs maps the provided emails (lower-cased) to the ids of the users owning them.
+		// Primary emails of users and verified secondary emails are taken into account.
+		MapPrincipalIDs(ctx context.Context, emails []string) (map[string]int64, error)
+	}
+
+	// RepoCollaboratorStore defines the repository collaborator data storage.
+	RepoCollaboratorStore interface {
+		// Find finds the collaborator by repo id and principal id.
+		Find(ctx context.Context, key types.RepoCollaboratorKey) (*types.RepoCollaborator, error)
+
+		// FindUser finds the collaborator by repo id and principal id and adds the principal infos.
+		FindUser(ctx context.Context, key types.RepoCollaboratorKey) (*types.RepoCollaboratorUser, error)
+
+		// Create saves the repository collaborator.
+		Create(ctx context.Context, collaborator *types.RepoCollaborator) error
+
+		// Update updates the role of the repository collaborator.
+		Update(ctx context.Context, collaborator *types.RepoCollaborator) error
+
+		// Delete deletes the repository collaborator.
+		Delete(ctx context.Context, key types.RepoCollaboratorKey) error
+
+		// CountByRole returns the number of collaborators of the repository with the provided role.
+		CountByRole(ctx context.Context, repoID int64, role enum.MembershipRole) (int64, error)
+
+		// ListUsers returns all collaborators of the repository, ordered by their display name.
+		ListUsers(ctx context.Context, repoID int64) ([]types.RepoCollaboratorUser, error)
+	}
+
+	// RepoRedirectStore defines the storage of previous repository paths.
+	RepoRedirectStore interface {
+		// Upsert creates or replaces the redirect for the space and identifier.
+		Upsert(ctx context.Context, redirect *types.RepoRedirect) error
+
+		// Delete deletes the redirect for the space and identifier, if there is one.
+		Delete(ctx context.Context, spaceID int64, identifier string) error
+	}
+
+	// RepoMirrorStore defines the repository mirror data storage.
+	RepoMirrorStore interface {
+		// Find finds the mirror of the repository.
+		Find(ctx context.Context, repoID int64) (*types.RepositoryMirror, error)
+
+		// Create saves the repository mirror.
+		Create(ctx context.Context, mirror *types.RepositoryMirror) error
+
+		// Update updates the repository mirror.
+		Update(ctx context.Context, mirror *types.RepositoryMirror) error
+
+		// List returns all repository mirrors.
+		List(ctx context.Context) ([]*types.RepositoryMirror, error)
+	}
+
+	// PublicKeyStore defines the ssh public key data storage.
+	PublicKeyStore interface {
+		// Find finds the public key by id.
+		Find(ctx context.Context, id int64) (*types.PublicKey, error)
+
+		// FindByFingerprint finds the public key by its fingerprint.
+		FindByFingerprint(ctx context.Context, fingerprint string) (*types.PublicKey, error)
+
+		// Create saves the public key.
+		Create(ctx context.Context, key *types.PublicKey) error
+
+		// Delete deletes the public key with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all public keys of a principal.
+		List(ctx context.Context, principalID int64) ([]*types.PublicKey, error)
+	}
+
+	// DeployKeyStore defines the deploy key data storage.
+	DeployKeyStore interface {
+		// Find finds the deploy key by id.
+		Find(ctx context.Context, id int64) (*types.DeployKey, error)
+
+		// FindByFingerprint finds the deploy key by its fingerprint.
+		FindByFingerprint(ctx context.Context, fingerprint string) (*types.DeployKey, error)
+
+		// Create saves the deploy key.
+		Create(ctx context.Context, key *types.DeployKey) error
+
+		// Delete deletes the deploy key with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all deploy keys of a repository.
+		List(ctx context.Context, repoID int64) ([]*types.DeployKey, error)
+	}
+
+	// RepoActivityStore defines the repository activity feed data storage.
+	RepoActivityStore interface {
+		// Create saves the repository activity.
+		// Returns store.ErrDuplicate if an activity was already recorded for the same event.
+		Create(ctx context.Context, act *types.RepoActivity) error
+
+		// Count returns the number of repository activities matching the filter.
+		Count(ctx context.Context, repoID int64, filter *types.RepoActivityFilter) (int64, error)
+
+		// List returns a list of repository activities, newest first.
+		List(ctx context.Context, repoID int64, filter *types.RepoActivityFilter) ([]*types.RepoActivity, error)
+	}
+
+	// LFSObjectStore defines the git LFS object data storage.
+	LFSObjectStore interface {
+		// Find finds the LFS object of a repository by its oid.
+		Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error)
+
+		// FindMany finds the LFS objects of a repository with the provided oids.
+		FindMany(ctx context.Context, repoID int64, oids []string) ([]*types.LFSObject, error)
+
+		// Create saves the LFS object.
+		Create(ctx context.Context, obj *types.LFSObject) error
+	}
+
+	// IdempotencyKeyStore defines the idempotency key data storage.
+	IdempotencyKeyStore interface {
+		// Find finds the idempotency key of a principal for a specific resource type.
+		Find(ctx context.Context, principalID int64, resourceType enum.ResourceType,
+			key string) (*types.IdempotencyKey, error)
+
+		// Create saves the idempotency key.
+		Create(ctx context.Context, key *types.IdempotencyKey) error
+
+		// Delete deletes the idempotency key with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// DeleteCreatedBefore deletes all idempotency keys created before the provided time.
+		DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error)
 	}
 
 	// PullReqStore defines the pull request data storage.
@@ -531,6 +721,11 @@ type (
 
 		// ListForTrigger lists the webhook executions for a given trigger id.
 		ListForTrigger(ctx context.Context, triggerID string) ([]*types.WebhookExecution, error)
+
+		// MapDeliveryStats returns the statistics of the executions of the webhooks created since the provided time.
+		// Webhooks without executions are omitted.
+		MapDeliveryStats(ctx context.Context, webhookIDs []int64,
+			since time.Time) (map[int64]types.WebhookDeliveryStats, error)
 	}
 
 	CheckStore interface {
@@ -621,6 +816,18 @@ type (
 
 		// ListAll lists all the secrets in a given space.
 		ListAll(ctx context.Context, parentID int64) ([]*types.Secret, error)
+
+		// FindByRepoIdentifier returns a secret of a repository given its identifier.
+		FindByRepoIdentifier(ctx context.Context, repoID int64, identifier string) (*types.Secret, error)
+
+		// CountForRepo counts the secrets of a repository matching the given filter.
+		CountForRepo(ctx context.Context, repoID int64, filter types.ListQueryFilter) (int64, error)
+
+		// ListForRepo lists the secrets of a repository.
+		ListForRepo(ctx context.Context, repoID int64, filter types.ListQueryFilter) ([]*types.Secret, error)
+
+		// ListAllForRepo lists all the secrets of a repository.
+		ListAllForRepo(ctx context.Context, repoID int64) ([]*types.Secret, error)
 	}
 
 	ExecutionStore interface {
@@ -0,0 +1,240 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/harness/gitness/audit"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ audit.ChainStore = (*AuditChainStore)(nil)
+
+// NewAuditChainStore returns a new AuditChainStore.
+func NewAuditChainStore(db *sqlx.DB) *AuditChainStore {
+	return &AuditChainStore{db}
+}
+
+// AuditChainStore implements an audit.ChainStore backed by a relational database.
+type AuditChainStore struct {
+	db *sqlx.DB
+}
+
+// auditChainEntry is an internal representation used to store audit chain entries in the database.
+type auditChainEntry struct {
+	Stream   string `db:"audit_chain_stream"`
+	Sequence int64  `db:"audit_chain_sequence"`
+	Payload  string `db:"audit_chain_payload"`
+	PrevHash string `db:"audit_chain_prev_hash"`
+	Hash     string `db:"audit_chain_hash"`
+	Created  int64  `db:"audit_chain_created"`
+}
+
+// auditChainCheckpoint is an internal representation used to store audit chain checkpoints in the database.
+type auditChainCheckpoint struct {
+	Stream   string `db:"audit_chain_checkpoint_stream"`
+	Sequence int64  `db:"audit_chain_checkpoint_sequence"`
+	Hash     string `db:"audit_chain_checkpoint_hash"`
+}
+
+const (
+	auditChainColumns = `
+		 audit_chain_stream
+		,audit_chain_sequence
+		,audit_chain_payload
+		,audit_chain_prev_hash
+		,audit_chain_hash
+		,audit_chain_created`
+
+	auditChainSelectBase = `
+	SELECT` + auditChainColumns + `
+	FROM audit_chain_entries`
+)
+
+// Last returns the last entry of the stream.
+func (s *AuditChainStore) Last(ctx context.Context, stream string) (*audit.ChainEntry, error) {
+	const sqlQuery = auditChainSelectBase + `
+	WHERE audit_chain_stream = $1
+	ORDER BY audit_chain_sequence DESC
+	LIMIT 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &auditChainEntry{}
+	if err := db.GetContext(ctx, dst, sqlQuery, stream); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find last audit chain entry")
+	}
+
+	return mapToAuditChainEntry(dst), nil
+}
+
+// Create saves the audit chain entry.
+func (s *AuditChainStore) Create(ctx context.Context, entry *audit.ChainEntry) error {
+	const sqlQuery = `
+	INSERT INTO audit_chain_entries (` + auditChainColumns + `
+	) values (
+		 :audit_chain_stream
+		,:audit_chain_sequence
+		,:audit_chain_payload
+		,:audit_chain_prev_hash
+		,:audit_chain_hash
+		,:audit_chain_created
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalAuditChainEntry(entry))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind audit chain entry object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// List returns all entries of the stream ordered by sequence.
+func (s *AuditChainStore) List(ctx context.Context, stream string) ([]*audit.ChainEntry, error) {
+	const sqlQuery = auditChainSelectBase + `
+	WHERE audit_chain_stream = $1
+	ORDER BY audit_chain_sequence ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*auditChainEntry{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, stream); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list audit chain entries")
+	}
+
+	entries := make([]*audit.ChainEntry, len(dst))
+	for i := range dst {
+		entries[i] = mapToAuditChainEntry(dst[i])
+	}
+
+	return entries, nil
+}
+
+// Checkpoint returns the checkpoint of the stream.
+func (s *AuditChainStore) Checkpoint(ctx context.Context, stream string) (*audit.ChainCheckpoint, error) {
+	const sqlQuery = `
+	SELECT
+		 audit_chain_checkpoint_stream
+		,audit_chain_checkpoint_sequence
+		,audit_chain_checkpoint_hash
+	FROM audit_chain_checkpoints
+	WHERE audit_chain_checkpoint_stream = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &auditChainCheckpoint{}
+	if err := db.GetContext(ctx, dst, sqlQuery, stream); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find audit chain checkpoint")
+	}
+
+	return &audit.ChainCheckpoint{
+		Stream:   dst.Stream,
+		Sequence: dst.Sequence,
+		Hash:     dst.Hash,
+	}, nil
+}
+
+// DeleteOld removes the oldest entries of each stream that were created before the provided time.
+// Only a prefix of each stream is removed and the last entry of each stream is always kept to allow
+// extending the chain. The checkpoint of the stream is moved to the last removed entry first,
+// which allows verifying the remaining chain.
+func (s *AuditChainStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	// the last prunable entry of a stream is the one right before the first entry that's not old enough
+	// (or before the last entry, in case all entries are old enough).
+	const sqlQueryCheckpoint = `
+	INSERT INTO audit_chain_checkpoints (
+		 audit_chain_checkpoint_stream
+		,audit_chain_checkpoint_sequence
+		,audit_chain_checkpoint_hash
+	)
+	SELECT
+		 e.audit_chain_stream
+		,e.audit_chain_sequence
+		,e.audit_chain_hash
+	FROM audit_chain_entries e
+	WHERE e.audit_chain_sequence = (
+		SELECT COALESCE(
+			MIN(CASE WHEN n.audit_chain_created >= $1 THEN n.audit_chain_sequence END),
+			MAX(n.audit_chain_sequence)
+		) - 1
+		FROM audit_chain_entries n
+		WHERE n.audit_chain_stream = e.audit_chain_stream
+	)
+	ON CONFLICT (audit_chain_checkpoint_stream) DO UPDATE
+	SET
+		 audit_chain_checkpoint_sequence = EXCLUDED.audit_chain_checkpoint_sequence
+		,audit_chain_checkpoint_hash = EXCLUDED.audit_chain_checkpoint_hash
+	WHERE EXCLUDED.audit_chain_checkpoint_sequence > audit_chain_checkpoints.audit_chain_checkpoint_sequence`
+
+	const sqlQueryDelete = `
+	DELETE FROM audit_chain_entries
+	WHERE audit_chain_sequence <= (
+		SELECT c.audit_chain_checkpoint_sequence
+		FROM audit_chain_checkpoints c
+		WHERE c.audit_chain_checkpoint_stream = audit_chain_entries.audit_chain_stream
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQueryCheckpoint, olderThan.UnixMilli()); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to update audit chain checkpoints")
+	}
+
+	result, err := db.ExecContext(ctx, sqlQueryDelete)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete old audit chain entries")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted audit chain entries")
+	}
+
+	return n, nil
+}
+
+func mapToAuditChainEntry(in *auditChainEntry) *audit.ChainEntry {
+	return &audit.ChainEntry{
+		Stream:   in.Stream,
+		Sequence: in.Sequence,
+		Payload:  json.RawMessage(in.Payload),
+		PrevHash: in.PrevHash,
+		Hash:     in.Hash,
+		Created:  in.Created,
+	}
+}
+
+func mapToInternalAuditChainEntry(in *audit.ChainEntry) *auditChainEntry {
+	return &auditChainEntry{
+		Stream:   in.Stream,
+		Sequence: in.Sequence,
+		Payload:  string(in.Payload),
+		PrevHash: in.PrevHash,
+		Hash:     in.Hash,
+		Created:  in.Created,
+	}
+}
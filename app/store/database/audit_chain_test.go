@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/audit"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+func TestDatabase_AuditChainDeleteOld(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	store := database.NewAuditChainStore(db)
+	ctx := context.Background()
+
+	cutoff := time.Now().Add(-24 * time.Hour)
+	create := func(stream string, seq int64, created time.Time) {
+		entry := &audit.ChainEntry{
+			Stream:   stream,
+			Sequence: seq,
+			Payload:  json.RawMessage(`{}`),
+			Hash:     fmt.Sprintf("%s-hash-%d", stream, seq),
+			Created:  created.UnixMilli(),
+		}
+		if err := store.Create(ctx, entry); err != nil {
+			t.Fatalf("failed to create audit chain entry %v", err)
+		}
+	}
+
+	// stream "a": old, exactly at cutoff, recent
+	create("a", 1, cutoff.Add(-time.Hour))
+	create("a", 2, cutoff)
+	create("a", 3, time.Now())
+	// stream "b": only old entries, the last one has to be kept
+	create("b", 1, cutoff.Add(-2*time.Hour))
+	create("b", 2, cutoff.Add(-time.Hour))
+
+	n, err := store.DeleteOld(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("failed to delete old audit chain entries %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Want 2 deleted entries, got %d", n)
+	}
+
+	for stream, want := range map[string]int64{"a": 1, "b": 1} {
+		checkpoint, err := store.Checkpoint(ctx, stream)
+		if err != nil {
+			t.Fatalf("failed to find audit chain checkpoint %v", err)
+		}
+		if checkpoint.Sequence != want || checkpoint.Hash != fmt.Sprintf("%s-hash-%d", stream, want) {
+			t.Errorf("Want checkpoint at sequence %d for stream %q, got %d (%s)",
+				want, stream, checkpoint.Sequence, checkpoint.Hash)
+		}
+	}
+
+	for stream, want := range map[string][]int64{"a": {2, 3}, "b": {2}} {
+		entries, err := store.List(ctx, stream)
+		if err != nil {
+			t.Fatalf("failed to list audit chain entries %v", err)
+		}
+		if len(entries) != len(want) {
+			t.Fatalf("Want %d entries for stream %q, got %d", len(want), stream, len(entries))
+		}
+		for i := range want {
+			if entries[i].Sequence != want[i] {
+				t.Errorf("Want sequence %d for stream %q, got %d", want[i], stream, entries[i].Sequence)
+			}
+		}
+	}
+}
+
+func TestDatabase_AuditChainDeleteOldPrefixOnly(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	store := database.NewAuditChainStore(db)
+	ctx := context.Background()
+
+	cutoff := time.Now().Add(-24 * time.Hour)
+	for seq, created := range []time.Time{cutoff.Add(-time.Hour), time.Now(), cutoff.Add(-time.Hour), time.Now()} {
+		entry := &audit.ChainEntry{
+			Stream:   "a",
+			Sequence: int64(seq + 1),
+			Payload:  json.RawMessage(`{}`),
+			Hash:     fmt.Sprintf("hash-%d", seq+1),
+			Created:  created.UnixMilli(),
+		}
+		if err := store.Create(ctx, entry); err != nil {
+			t.Fatalf("failed to create audit chain entry %v", err)
+		}
+	}
+
+	// the old entry 3 is kept, as removing it would leave a gap in the chain.
+	n, err := store.DeleteOld(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("failed to delete old audit chain entries %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Want 1 deleted entry, got %d", n)
+	}
+
+	checkpoint, err := store.Checkpoint(ctx, "a")
+	if err != nil {
+		t.Fatalf("failed to find audit chain checkpoint %v", err)
+	}
+	if checkpoint.Sequence != 1 || checkpoint.Hash != "hash-1" {
+		t.Errorf("Want checkpoint at sequence 1, got %d (%s)", checkpoint.Sequence, checkpoint.Hash)
+	}
+
+	// streams that weren't pruned don't have a checkpoint.
+	_, err = store.Checkpoint(ctx, "b")
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("Want %v, got %v", gitness_store.ErrResourceNotFound, err)
+	}
+}
+
+func TestDatabase_AuditChainDuplicateSequence(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	store := database.NewAuditChainStore(db)
+	ctx := context.Background()
+
+	entry := &audit.ChainEntry{
+		Stream:   "a",
+		Sequence: 1,
+		Payload:  json.RawMessage(`{}`),
+		Hash:     "hash",
+		Created:  time.Now().UnixMilli(),
+	}
+	if err := store.Create(ctx, entry); err != nil {
+		t.Fatalf("failed to create audit chain entry %v", err)
+	}
+
+	err := store.Create(ctx, entry)
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("Want %v, got %v", gitness_store.ErrDuplicate, err)
+	}
+}
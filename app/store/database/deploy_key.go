@@ -0,0 +1,195 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.DeployKeyStore = (*DeployKeyStore)(nil)
+
+// NewDeployKeyStore returns a new DeployKeyStore.
+func NewDeployKeyStore(db *sqlx.DB) *DeployKeyStore {
+	return &DeployKeyStore{db}
+}
+
+// DeployKeyStore implements a DeployKeyStore backed by a relational database.
+type DeployKeyStore struct {
+	db *sqlx.DB
+}
+
+// deployKey is an internal representation used to store deploy key data in the database.
+type deployKey struct {
+	ID          int64  `db:"deploy_key_id"`
+	RepoID      int64  `db:"deploy_key_repo_id"`
+	Fingerprint string `db:"deploy_key_fingerprint"`
+	Content     string `db:"deploy_key_content"`
+	Label       string `db:"deploy_key_label"`
+	Write       bool   `db:"deploy_key_write"`
+	CreatedBy   int64  `db:"deploy_key_created_by"`
+	Created     int64  `db:"deploy_key_created"`
+}
+
+// Find finds the deploy key by id.
+func (s *DeployKeyStore) Find(ctx context.Context, id int64) (*types.DeployKey, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(deployKey)
+	if err := db.GetContext(ctx, dst, deployKeySelectByID, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find deploy key")
+	}
+
+	return mapToDeployKey(dst), nil
+}
+
+// FindByFingerprint finds the deploy key by its fingerprint.
+func (s *DeployKeyStore) FindByFingerprint(ctx context.Context, fingerprint string) (*types.DeployKey, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(deployKey)
+	if err := db.GetContext(ctx, dst, deployKeySelectByFingerprint, fingerprint); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find deploy key by fingerprint")
+	}
+
+	return mapToDeployKey(dst), nil
+}
+
+// Create saves the deploy key.
+func (s *DeployKeyStore) Create(ctx context.Context, key *types.DeployKey) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(deployKeyInsert, mapToInternalDeployKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind deploy key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the deploy key with the given id.
+func (s *DeployKeyStore) Delete(ctx context.Context, id int64) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, deployKeyDelete, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List returns all deploy keys of a repository.
+func (s *DeployKeyStore) List(ctx context.Context, repoID int64) ([]*types.DeployKey, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*deployKey{}
+	if err := db.SelectContext(ctx, &dst, deployKeySelectForRepoID, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing deploy key list query")
+	}
+
+	res := make([]*types.DeployKey, len(dst))
+	for i := range dst {
+		res[i] = mapToDeployKey(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToDeployKey(k *deployKey) *types.DeployKey {
+	return &types.DeployKey{
+		ID:          k.ID,
+		RepoID:      k.RepoID,
+		Fingerprint: k.Fingerprint,
+		Content:     k.Content,
+		Label:       k.Label,
+		Write:       k.Write,
+		CreatedBy:   k.CreatedBy,
+		Created:     k.Created,
+	}
+}
+
+func mapToInternalDeployKey(k *types.DeployKey) *deployKey {
+	return &deployKey{
+		ID:          k.ID,
+		RepoID:      k.RepoID,
+		Fingerprint: k.Fingerprint,
+		Content:     k.Content,
+		Label:       k.Label,
+		Write:       k.Write,
+		CreatedBy:   k.CreatedBy,
+		Created:     k.Created,
+	}
+}
+
+const deployKeySelectBase = `
+SELECT
+deploy_key_id
+,deploy_key_repo_id
+,deploy_key_fingerprint
+,deploy_key_content
+,deploy_key_label
+,deploy_key_write
+,deploy_key_created_by
+,deploy_key_created
+FROM deploy_keys
+`
+
+const deployKeySelectByID = deployKeySelectBase + `
+WHERE deploy_key_id = $1
+`
+
+const deployKeySelectByFingerprint = deployKeySelectBase + `
+WHERE deploy_key_fingerprint = $1
+`
+
+const deployKeySelectForRepoID = deployKeySelectBase + `
+WHERE deploy_key_repo_id = $1
+ORDER BY deploy_key_created DESC
+`
+
+const deployKeyDelete = `
+DELETE FROM deploy_keys
+WHERE deploy_key_id = $1
+`
+
+const deployKeyInsert = `
+INSERT INTO deploy_keys (
+	deploy_key_repo_id
+	,deploy_key_fingerprint
+	,deploy_key_content
+	,deploy_key_label
+	,deploy_key_write
+	,deploy_key_created_by
+	,deploy_key_created
+) values (
+	:deploy_key_repo_id
+	,:deploy_key_fingerprint
+	,:deploy_key_content
+	,:deploy_key_label
+	,:deploy_key_write
+	,:deploy_key_created_by
+	,:deploy_key_created
+) RETURNING deploy_key_id
+`
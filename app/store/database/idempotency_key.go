@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.IdempotencyKeyStore = (*IdempotencyKeyStore)(nil)
+
+// NewIdempotencyKeyStore returns a new IdempotencyKeyStore.
+func NewIdempotencyKeyStore(db *sqlx.DB) *IdempotencyKeyStore {
+	return &IdempotencyKeyStore{db}
+}
+
+// IdempotencyKeyStore implements a IdempotencyKeyStore backed by a relational database.
+type IdempotencyKeyStore struct {
+	db *sqlx.DB
+}
+
+// idempotencyKey is an internal representation used to store idempotency key data in the database.
+type idempotencyKey struct {
+	ID           int64             `db:"idempotency_key_id"`
+	PrincipalID  int64             `db:"idempotency_key_principal_id"`
+	ResourceType enum.ResourceType `db:"idempotency_key_resource_type"`
+	Value        string            `db:"idempotency_key_value"`
+	RequestHash  string            `db:"idempotency_key_request_hash"`
+	ResourceID   int64             `db:"idempotency_key_resource_id"`
+	Created      int64             `db:"idempotency_key_created"`
+}
+
+// Find finds the idempotency key of a principal for a specific resource type.
+func (s *IdempotencyKeyStore) Find(
+	ctx context.Context,
+	principalID int64,
+	resourceType enum.ResourceType,
+	key string,
+) (*types.IdempotencyKey, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(idempotencyKey)
+	if err := db.GetContext(ctx, dst, idempotencyKeySelectByValue, principalID, resourceType, key); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find idempotency key")
+	}
+
+	return mapToIdempotencyKey(dst), nil
+}
+
+// Create saves the idempotency key.
+func (s *IdempotencyKeyStore) Create(ctx context.Context, key *types.IdempotencyKey) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(idempotencyKeyInsert, mapToInternalIdempotencyKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind idempotency key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the idempotency key with the given id.
+func (s *IdempotencyKeyStore) Delete(ctx context.Context, id int64) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, idempotencyKeyDelete, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// DeleteCreatedBefore deletes all idempotency keys created before the provided time.
+func (s *IdempotencyKeyStore) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, idempotencyKeyDeleteCreatedBefore, before.UnixMilli())
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to delete idempotency keys")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted idempotency keys")
+	}
+
+	return n, nil
+}
+
+func mapToIdempotencyKey(k *idempotencyKey) *types.IdempotencyKey {
+	return &types.IdempotencyKey{
+		ID:           k.ID,
+		PrincipalID:  k.PrincipalID,
+		ResourceType: k.ResourceType,
+		Key:          k.Value,
+		RequestHash:  k.RequestHash,
+		ResourceID:   k.ResourceID,
+		Created:      k.Created,
+	}
+}
+
+func mapToInternalIdempotencyKey(k *types.IdempotencyKey) *idempotencyKey {
+	return &idempotencyKey{
+		ID:           k.ID,
+		PrincipalID:  k.PrincipalID,
+		ResourceType: k.ResourceType,
+		Value:        k.Key,
+		RequestHash:  k.RequestHash,
+		ResourceID:   k.ResourceID,
+		Created:      k.Created,
+	}
+}
+
+const idempotencyKeySelectByValue = `
+SELECT
+idempotency_key_id
+,idempotency_key_principal_id
+,idempotency_key_resource_type
+,idempotency_key_value
+,idempotency_key_request_hash
+,idempotency_key_resource_id
+,idempotency_key_created
+FROM idempotency_keys
+WHERE idempotency_key_principal_id = $1
+	AND idempotency_key_resource_type = $2
+	AND idempotency_key_value = $3
+`
+
+const idempotencyKeyDelete = `
+DELETE FROM idempotency_keys
+WHERE idempotency_key_id = $1
+`
+
+const idempotencyKeyDeleteCreatedBefore = `
+DELETE FROM idempotency_keys
+WHERE idempotency_key_created < $1
+`
+
+const idempotencyKeyInsert = `
+INSERT INTO idempotency_keys (
+	idempotency_key_principal_id
+	,idempotency_key_resource_type
+	,idempotency_key_value
+	,idempotency_key_request_hash
+	,idempotency_key_resource_id
+	,idempotency_key_created
+) values (
+	:idempotency_key_principal_id
+	,:idempotency_key_resource_type
+	,:idempotency_key_value
+	,:idempotency_key_request_hash
+	,:idempotency_key_resource_id
+	,:idempotency_key_created
+) RETURNING idempotency_key_id
+`
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_IdempotencyKey(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+	keyStore := database.NewIdempotencyKeyStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	now := time.Now()
+	oldKey := &types.IdempotencyKey{
+		PrincipalID:  userID,
+		ResourceType: enum.ResourceTypeRepo,
+		Key:          "old",
+		ResourceID:   1,
+		Created:      now.Add(-48 * time.Hour).UnixMilli(),
+	}
+	newKey := &types.IdempotencyKey{
+		PrincipalID:  userID,
+		ResourceType: enum.ResourceTypeRepo,
+		Key:          "new",
+		RequestHash:  "hash",
+		ResourceID:   2,
+		Created:      now.UnixMilli(),
+	}
+	for _, key := range []*types.IdempotencyKey{oldKey, newKey} {
+		if err := keyStore.Create(ctx, key); err != nil {
+			t.Fatalf("failed to create idempotency key %v", err)
+		}
+	}
+
+	err := keyStore.Create(ctx, &types.IdempotencyKey{
+		PrincipalID:  userID,
+		ResourceType: enum.ResourceTypeRepo,
+		Key:          "new",
+		ResourceID:   3,
+		Created:      now.UnixMilli(),
+	})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("err = %v, want %v", err, gitness_store.ErrDuplicate)
+	}
+
+	key, err := keyStore.Find(ctx, userID, enum.ResourceTypeRepo, "new")
+	if err != nil {
+		t.Fatalf("failed to find idempotency key %v", err)
+	}
+	if key.ResourceID != 2 {
+		t.Errorf("key.ResourceID = %d, want %d", key.ResourceID, 2)
+	}
+	if key.RequestHash != "hash" {
+		t.Errorf("key.RequestHash = %q, want %q", key.RequestHash, "hash")
+	}
+
+	_, err = keyStore.Find(ctx, userID, enum.ResourceTypeUser, "new")
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("err = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+
+	n, err := keyStore.DeleteCreatedBefore(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to delete idempotency keys %v", err)
+	}
+	if n != 1 {
+		t.Errorf("n = %d, want %d", n, 1)
+	}
+
+	_, err = keyStore.Find(ctx, userID, enum.ResourceTypeRepo, "old")
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("err = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+}
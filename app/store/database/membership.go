@@ -283,7 +283,14 @@ func applyMembershipUserFilter(
 ) squirrel.SelectBuilder {
 	if opts.Query != "" {
 		searchTerm := "%%" + strings.ToLower(opts.Query) + "%%"
-		stmt = stmt.Where("LOWER(principal_display_name) LIKE ?", searchTerm)
+		stmt = stmt.Where(squirrel.Or{
+			squirrel.Expr("LOWER(principal_display_name) LIKE ?", searchTerm),
+			squirrel.Expr("LOWER(principal_email) LIKE ?", searchTerm),
+		})
+	}
+
+	if opts.Role != "" {
+		stmt = stmt.Where("membership_role = ?", opts.Role)
 	}
 
 	return stmt
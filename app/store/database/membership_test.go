@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_MembershipListUsers(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	membershipStore := database.NewMembershipStore(db, pCache, spacePathStore, spaceStore)
+
+	members := []struct {
+		displayName string
+		email       string
+		role        enum.MembershipRole
+	}{
+		{displayName: "Alice", email: "alice@example.com", role: enum.MembershipRoleSpaceOwner},
+		{displayName: "Bob", email: "bob@example.com", role: enum.MembershipRoleReader},
+		{displayName: "Carol", email: "carol@corp.example.com", role: enum.MembershipRoleSpaceOwner},
+	}
+
+	for _, m := range members {
+		user := &types.User{UID: m.displayName, DisplayName: m.displayName, Email: m.email}
+		if err := principalStore.CreateUser(ctx, user); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+
+		if err := membershipStore.Create(ctx, &types.Membership{
+			MembershipKey: types.MembershipKey{SpaceID: 1, PrincipalID: user.ID},
+			CreatedBy:     userID,
+			Role:          m.role,
+		}); err != nil {
+			t.Fatalf("failed to create membership: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter types.MembershipUserFilter
+		want   []string
+	}{
+		{
+			name: "all",
+			want: []string{"Alice", "Bob", "Carol"},
+		},
+		{
+			name:   "admin-role",
+			filter: types.MembershipUserFilter{Role: enum.MembershipRoleSpaceOwner},
+			want:   []string{"Alice", "Carol"},
+		},
+		{
+			name:   "search-email",
+			filter: types.MembershipUserFilter{ListQueryFilter: types.ListQueryFilter{Query: "CORP"}},
+			want:   []string{"Carol"},
+		},
+		{
+			name: "paginated",
+			filter: types.MembershipUserFilter{
+				ListQueryFilter: types.ListQueryFilter{Pagination: types.Pagination{Page: 2, Size: 2}},
+			},
+			want: []string{"Carol"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := test.filter
+			filter.Sort = enum.MembershipUserSortName
+
+			list, err := membershipStore.ListUsers(ctx, 1, filter)
+			if err != nil {
+				t.Fatalf("failed to list members: %v", err)
+			}
+
+			got := make([]string, len(list))
+			for i, m := range list {
+				got[i] = m.Principal.DisplayName
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("Want members %v, got %v", test.want, got)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("Want members %v, got %v", test.want, got)
+					break
+				}
+			}
+
+			count, err := membershipStore.CountUsers(ctx, 1, filter)
+			if err != nil {
+				t.Fatalf("failed to count members: %v", err)
+			}
+			if filter.Page == 0 && count != int64(len(test.want)) {
+				t.Errorf("Want count %d, got %d", len(test.want), count)
+			}
+		})
+	}
+}
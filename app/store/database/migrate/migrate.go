@@ -20,6 +20,10 @@ import (
 	"embed"
 	"fmt"
 	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/types"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/maragudk/migrate"
@@ -40,15 +44,98 @@ const (
 
 	sqliteDriverName = "sqlite3"
 	sqliteSourceDir  = "sqlite"
+
+	upSuffix = ".up.sql"
 )
 
-// Migrate performs the database migration.
+// Migrate performs the database migration by applying all pending migrations in order.
+// Applying the migrations is idempotent, migrations that have been applied already are skipped.
+// In case a migration fails, the returned error states the failed migration and the current version.
 func Migrate(ctx context.Context, db *sqlx.DB) error {
 	opts, err := getMigrator(db)
 	if err != nil {
 		return fmt.Errorf("failed to get migrator: %w", err)
 	}
-	return migrate.New(opts).MigrateUp(ctx)
+
+	if err = migrate.New(opts).MigrateUp(ctx); err != nil {
+		// each migration is applied in its own transaction, the version is the last successful migration.
+		status, statusErr := Status(ctx, db)
+		if statusErr != nil || len(status.Pending) == 0 {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+
+		return fmt.Errorf("failed to apply migration %q (database is at version %q): %w",
+			status.Pending[0], status.Current, err)
+	}
+
+	return nil
+}
+
+// Status returns the current version of the database and the migrations that haven't been applied yet.
+func Status(ctx context.Context, db *sqlx.DB) (*types.MigrationStatus, error) {
+	opts, err := getMigrator(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migrator: %w", err)
+	}
+
+	versions, err := listVersions(opts.FS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	current, err := Current(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &types.MigrationStatus{
+		Current: current,
+		Pending: []string{},
+	}
+
+	if len(versions) > 0 {
+		status.Latest = versions[len(versions)-1]
+	}
+
+	for _, version := range versions {
+		if version > current {
+			status.Pending = append(status.Pending, version)
+		}
+	}
+
+	return status, nil
+}
+
+// listVersions returns the versions of all available migrations in the order they are applied.
+func listVersions(folder fs.FS) ([]string, error) {
+	names, err := fs.Glob(folder, "*"+upSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, len(names))
+	for i, name := range names {
+		versions[i] = strings.TrimSuffix(name, upSuffix)
+	}
+
+	sort.Strings(versions)
+
+	return versions, nil
+}
+
+// Reporter reports the migration status of the database.
+type Reporter struct {
+	db *sqlx.DB
+}
+
+// NewReporter returns a new migration status reporter of the database.
+func NewReporter(db *sqlx.DB) *Reporter {
+	return &Reporter{db: db}
+}
+
+// Status returns the current version of the database and the migrations that haven't been applied yet.
+func (r *Reporter) Status(ctx context.Context) (*types.MigrationStatus, error) {
+	return Status(ctx, r.db)
 }
 
 // To performs the database migration to the specific version.
@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/store/database"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/xid"
+)
+
+func setupFreshDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s.db?mode=memory&cache=shared", xid.New().String())
+	db, err := database.Connect(context.Background(), sqliteDriverName, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func TestMigrate_FreshDB(t *testing.T) {
+	ctx := context.Background()
+	db := setupFreshDB(t)
+
+	status, err := Status(ctx, db)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if status.Current != "" {
+		t.Errorf("Want no current version, got %q", status.Current)
+	}
+	if status.Latest == "" || len(status.Pending) == 0 || status.Pending[len(status.Pending)-1] != status.Latest {
+		t.Fatalf("Want all migrations up to %q pending, got %v", status.Latest, status.Pending)
+	}
+
+	// applying the migrations twice must be a no-op the second time.
+	for i := 0; i < 2; i++ {
+		if err = Migrate(ctx, db); err != nil {
+			t.Fatalf("Want no error applying migrations (run %d), got %v", i+1, err)
+		}
+	}
+
+	status, err = Status(ctx, db)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if status.Current != status.Latest {
+		t.Errorf("Want current version %q, got %q", status.Latest, status.Current)
+	}
+	if len(status.Pending) != 0 {
+		t.Errorf("Want no pending migrations, got %v", status.Pending)
+	}
+}
+
+func TestStatus_Pending(t *testing.T) {
+	ctx := context.Background()
+	db := setupFreshDB(t)
+
+	opts, err := getMigrator(db)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	versions, err := listVersions(opts.FS)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if len(versions) < 2 {
+		t.Fatalf("Want at least two migrations, got %d", len(versions))
+	}
+
+	previous := versions[len(versions)-2]
+	if err = To(ctx, db, previous); err != nil {
+		t.Fatalf("Want no error migrating to %q, got %v", previous, err)
+	}
+
+	status, err := NewReporter(db).Status(ctx)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+	if status.Current != previous {
+		t.Errorf("Want current version %q, got %q", previous, status.Current)
+	}
+	if len(status.Pending) != 1 || status.Pending[0] != status.Latest {
+		t.Errorf("Want pending migration %q, got %v", status.Latest, status.Pending)
+	}
+}
@@ -0,0 +1,9 @@
+CREATE TABLE audit_chain_entries (
+ audit_chain_stream TEXT NOT NULL
+,audit_chain_sequence BIGINT NOT NULL
+,audit_chain_payload TEXT NOT NULL
+,audit_chain_prev_hash TEXT NOT NULL
+,audit_chain_hash TEXT NOT NULL
+,audit_chain_created BIGINT NOT NULL
+,PRIMARY KEY (audit_chain_stream, audit_chain_sequence)
+);
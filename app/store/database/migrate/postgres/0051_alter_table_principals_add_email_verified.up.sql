@@ -0,0 +1 @@
+ALTER TABLE principals ADD COLUMN principal_user_email_verified BOOLEAN NOT NULL DEFAULT FALSE;
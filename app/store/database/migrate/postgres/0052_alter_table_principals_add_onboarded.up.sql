@@ -0,0 +1,4 @@
+ALTER TABLE principals ADD COLUMN principal_user_onboarded BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- existing users don't need to be onboarded
+UPDATE principals SET principal_user_onboarded = TRUE WHERE principal_type = 'user';
@@ -0,0 +1 @@
+ALTER TABLE spaces ADD COLUMN space_default_branch TEXT NOT NULL DEFAULT '';
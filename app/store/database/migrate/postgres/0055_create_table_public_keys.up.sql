@@ -0,0 +1,16 @@
+CREATE TABLE public_keys (
+ public_key_id             SERIAL PRIMARY KEY
+,public_key_principal_id   INTEGER NOT NULL
+,public_key_fingerprint    TEXT NOT NULL
+,public_key_content        TEXT NOT NULL
+,public_key_label          TEXT NOT NULL
+,public_key_created        BIGINT NOT NULL
+,UNIQUE(public_key_fingerprint)
+
+,CONSTRAINT fk_public_key_principal_id FOREIGN KEY (public_key_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX public_keys_principal_id ON public_keys(public_key_principal_id);
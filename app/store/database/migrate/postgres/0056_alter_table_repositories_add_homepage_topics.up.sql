@@ -0,0 +1,2 @@
+ALTER TABLE repositories ADD COLUMN repo_homepage TEXT NOT NULL DEFAULT '';
+ALTER TABLE repositories ADD COLUMN repo_topics TEXT NOT NULL DEFAULT '';
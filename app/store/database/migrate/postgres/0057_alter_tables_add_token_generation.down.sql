@@ -0,0 +1,2 @@
+ALTER TABLE tokens DROP COLUMN token_generation;
+ALTER TABLE principals DROP COLUMN principal_token_generation;
@@ -0,0 +1 @@
+ALTER TABLE repositories ADD COLUMN repo_is_template BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,22 @@
+CREATE TABLE deploy_keys (
+ deploy_key_id            SERIAL PRIMARY KEY
+,deploy_key_repo_id       INTEGER NOT NULL
+,deploy_key_fingerprint   TEXT NOT NULL
+,deploy_key_content       TEXT NOT NULL
+,deploy_key_label         TEXT NOT NULL
+,deploy_key_write         BOOLEAN NOT NULL
+,deploy_key_created_by    INTEGER NOT NULL
+,deploy_key_created       BIGINT NOT NULL
+,UNIQUE(deploy_key_fingerprint)
+
+,CONSTRAINT fk_deploy_key_repo_id FOREIGN KEY (deploy_key_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_deploy_key_created_by FOREIGN KEY (deploy_key_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX deploy_keys_repo_id ON deploy_keys(deploy_key_repo_id);
@@ -0,0 +1 @@
+ALTER TABLE webhooks DROP COLUMN webhook_branch_pattern;
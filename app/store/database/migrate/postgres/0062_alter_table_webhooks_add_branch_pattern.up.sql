@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_branch_pattern TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,21 @@
+CREATE TABLE repo_activities (
+ repo_activity_id            SERIAL PRIMARY KEY
+,repo_activity_repo_id       INTEGER NOT NULL
+,repo_activity_type          TEXT NOT NULL
+,repo_activity_event_id      TEXT NOT NULL
+,repo_activity_principal_id  INTEGER NOT NULL
+,repo_activity_created       BIGINT NOT NULL
+,repo_activity_payload       TEXT NOT NULL
+,UNIQUE(repo_activity_repo_id, repo_activity_event_id)
+
+,CONSTRAINT fk_repo_activity_repo_id FOREIGN KEY (repo_activity_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_activity_principal_id FOREIGN KEY (repo_activity_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX repo_activities_repo_id_created ON repo_activities(repo_activity_repo_id, repo_activity_created);
@@ -0,0 +1,2 @@
+ALTER TABLE principals ADD COLUMN principal_user_failed_logins INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE principals ADD COLUMN principal_user_locked_until BIGINT NOT NULL DEFAULT 0;
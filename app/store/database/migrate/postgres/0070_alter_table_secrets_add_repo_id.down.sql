@@ -0,0 +1,13 @@
+DELETE FROM secrets WHERE secret_repo_id IS NOT NULL;
+
+DROP INDEX secrets_repo_id_uid;
+DROP INDEX secrets_space_id_uid;
+
+ALTER TABLE secrets
+    ADD CONSTRAINT secrets_secret_space_id_secret_uid_key UNIQUE (secret_space_id, secret_uid);
+
+ALTER TABLE secrets
+    DROP CONSTRAINT fk_secrets_repo_id;
+
+ALTER TABLE secrets
+    DROP COLUMN secret_repo_id;
@@ -0,0 +1,19 @@
+ALTER TABLE secrets
+    ADD COLUMN secret_repo_id INTEGER;
+
+ALTER TABLE secrets
+    ADD CONSTRAINT fk_secrets_repo_id FOREIGN KEY (secret_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE;
+
+ALTER TABLE secrets
+    DROP CONSTRAINT secrets_secret_space_id_secret_uid_key;
+
+CREATE UNIQUE INDEX secrets_space_id_uid
+    ON secrets (secret_space_id, secret_uid)
+    WHERE secret_repo_id IS NULL;
+
+CREATE UNIQUE INDEX secrets_repo_id_uid
+    ON secrets (secret_repo_id, secret_uid)
+    WHERE secret_repo_id IS NOT NULL;
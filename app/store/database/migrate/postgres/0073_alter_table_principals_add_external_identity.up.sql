@@ -0,0 +1,2 @@
+ALTER TABLE principals ADD COLUMN principal_user_auth_source TEXT NOT NULL DEFAULT '';
+ALTER TABLE principals ADD COLUMN principal_user_external_id TEXT NOT NULL DEFAULT '';
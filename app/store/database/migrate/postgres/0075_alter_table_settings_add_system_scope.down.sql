@@ -0,0 +1 @@
+DROP INDEX settings_system_key;
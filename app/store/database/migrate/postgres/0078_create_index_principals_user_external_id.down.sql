@@ -0,0 +1 @@
+DROP INDEX principals_user_auth_source_external_id;
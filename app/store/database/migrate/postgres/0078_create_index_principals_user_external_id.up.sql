@@ -0,0 +1,3 @@
+CREATE UNIQUE INDEX principals_user_auth_source_external_id
+ON principals(principal_user_auth_source, principal_user_external_id)
+WHERE principal_user_external_id <> '';
@@ -0,0 +1 @@
+ALTER TABLE principals ADD COLUMN principal_version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE tokens ADD COLUMN token_scopes TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE principals ADD COLUMN principal_token_generation INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tokens ADD COLUMN token_generation INTEGER NOT NULL DEFAULT 0;
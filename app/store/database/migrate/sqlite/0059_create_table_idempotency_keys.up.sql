@@ -0,0 +1,16 @@
+CREATE TABLE idempotency_keys (
+ idempotency_key_id             INTEGER PRIMARY KEY AUTOINCREMENT
+,idempotency_key_principal_id   INTEGER NOT NULL
+,idempotency_key_resource_type  TEXT NOT NULL
+,idempotency_key_value          TEXT NOT NULL
+,idempotency_key_resource_id    BIGINT NOT NULL
+,idempotency_key_created        BIGINT NOT NULL
+,UNIQUE(idempotency_key_principal_id, idempotency_key_resource_type, idempotency_key_value)
+
+,CONSTRAINT fk_idempotency_key_principal_id FOREIGN KEY (idempotency_key_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idempotency_keys_created ON idempotency_keys(idempotency_key_created);
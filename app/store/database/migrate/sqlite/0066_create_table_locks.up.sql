@@ -0,0 +1,5 @@
+CREATE TABLE locks (
+ lock_key      TEXT PRIMARY KEY
+,lock_token    TEXT NOT NULL
+,lock_expires  BIGINT NOT NULL
+);
@@ -0,0 +1,17 @@
+CREATE TABLE user_emails (
+ user_email_id                  INTEGER PRIMARY KEY AUTOINCREMENT
+,user_email_principal_id        INTEGER NOT NULL
+,user_email_address             TEXT NOT NULL
+,user_email_verified            BOOLEAN NOT NULL
+,user_email_verification_token  TEXT NOT NULL
+,user_email_created             BIGINT NOT NULL
+,user_email_updated             BIGINT NOT NULL
+
+,CONSTRAINT fk_user_email_principal_id FOREIGN KEY (user_email_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX user_emails_address ON user_emails(LOWER(user_email_address));
+CREATE INDEX user_emails_principal_id ON user_emails(user_email_principal_id);
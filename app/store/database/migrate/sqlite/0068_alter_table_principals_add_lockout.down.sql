@@ -0,0 +1,2 @@
+ALTER TABLE principals DROP COLUMN principal_user_locked_until;
+ALTER TABLE principals DROP COLUMN principal_user_failed_logins;
@@ -0,0 +1,19 @@
+CREATE TABLE repository_mirrors (
+ mirror_repo_id          INTEGER PRIMARY KEY
+,mirror_remote_url       TEXT NOT NULL
+,mirror_credentials      TEXT NOT NULL
+,mirror_created_by       INTEGER NOT NULL
+,mirror_created          BIGINT NOT NULL
+,mirror_updated          BIGINT NOT NULL
+,mirror_last_synced      BIGINT NOT NULL
+,mirror_last_sync_error  TEXT NOT NULL
+
+,CONSTRAINT fk_mirror_repo_id FOREIGN KEY (mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_mirror_created_by FOREIGN KEY (mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
@@ -0,0 +1,66 @@
+CREATE TABLE secrets_new (
+    secret_id INTEGER PRIMARY KEY AUTOINCREMENT
+    ,secret_uid TEXT NOT NULL
+    ,secret_space_id INTEGER NOT NULL
+    ,secret_repo_id INTEGER
+    ,secret_description TEXT NOT NULL
+    ,secret_data BLOB NOT NULL
+    ,secret_created INTEGER NOT NULL
+    ,secret_updated INTEGER NOT NULL
+    ,secret_version INTEGER NOT NULL
+    ,secret_created_by INTEGER NOT NULL
+
+    -- Foreign key to spaces table
+    ,CONSTRAINT fk_secrets_space_id FOREIGN KEY (secret_space_id)
+        REFERENCES spaces (space_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+
+    -- Foreign key to repositories table
+    ,CONSTRAINT fk_secrets_repo_id FOREIGN KEY (secret_repo_id)
+        REFERENCES repositories (repo_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE
+
+    -- Foreign key to principals table
+    ,CONSTRAINT fk_secrets_created_by FOREIGN KEY (secret_created_by)
+        REFERENCES principals (principal_id) MATCH SIMPLE
+        ON UPDATE NO ACTION
+        ON DELETE NO ACTION
+);
+
+INSERT INTO secrets_new(
+ secret_id
+,secret_uid
+,secret_space_id
+,secret_description
+,secret_data
+,secret_created
+,secret_updated
+,secret_version
+,secret_created_by
+)
+SELECT
+ secret_id
+,secret_uid
+,secret_space_id
+,secret_description
+,secret_data
+,secret_created
+,secret_updated
+,secret_version
+,secret_created_by
+FROM secrets;
+
+DROP TABLE secrets;
+
+ALTER TABLE secrets_new
+    RENAME TO secrets;
+
+CREATE UNIQUE INDEX secrets_space_id_uid
+    ON secrets (secret_space_id, secret_uid)
+    WHERE secret_repo_id IS NULL;
+
+CREATE UNIQUE INDEX secrets_repo_id_uid
+    ON secrets (secret_repo_id, secret_uid)
+    WHERE secret_repo_id IS NOT NULL;
@@ -0,0 +1,22 @@
+CREATE TABLE repository_collaborators (
+ collaborator_repo_id       INTEGER NOT NULL
+,collaborator_principal_id  INTEGER NOT NULL
+,collaborator_created_by    INTEGER NOT NULL
+,collaborator_created       BIGINT NOT NULL
+,collaborator_updated       BIGINT NOT NULL
+,collaborator_role          TEXT NOT NULL
+
+,CONSTRAINT pk_repository_collaborators PRIMARY KEY (collaborator_repo_id, collaborator_principal_id)
+,CONSTRAINT fk_collaborator_repo_id FOREIGN KEY (collaborator_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_collaborator_principal_id FOREIGN KEY (collaborator_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_collaborator_created_by FOREIGN KEY (collaborator_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
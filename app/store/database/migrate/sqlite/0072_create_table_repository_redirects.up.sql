@@ -0,0 +1,20 @@
+CREATE TABLE repository_redirects (
+ redirect_space_id  INTEGER NOT NULL
+,redirect_uid       TEXT NOT NULL
+,redirect_repo_id   INTEGER NOT NULL
+,redirect_created   BIGINT NOT NULL
+,redirect_expires   BIGINT NOT NULL
+
+,CONSTRAINT pk_repository_redirects PRIMARY KEY (redirect_space_id, redirect_uid)
+,CONSTRAINT fk_redirect_space_id FOREIGN KEY (redirect_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_redirect_repo_id FOREIGN KEY (redirect_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX repository_redirects_repo_id
+    ON repository_redirects(redirect_repo_id);
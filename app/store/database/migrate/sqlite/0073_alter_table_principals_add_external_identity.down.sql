@@ -0,0 +1,2 @@
+ALTER TABLE principals DROP COLUMN principal_user_external_id;
+ALTER TABLE principals DROP COLUMN principal_user_auth_source;
@@ -0,0 +1 @@
+ALTER TABLE idempotency_keys ADD COLUMN idempotency_key_request_hash TEXT NOT NULL DEFAULT '';
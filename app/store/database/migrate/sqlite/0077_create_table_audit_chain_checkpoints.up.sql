@@ -0,0 +1,23 @@
+CREATE TABLE audit_chain_checkpoints (
+ audit_chain_checkpoint_stream TEXT PRIMARY KEY
+,audit_chain_checkpoint_sequence INTEGER NOT NULL
+,audit_chain_checkpoint_hash TEXT NOT NULL
+);
+
+-- streams that were pruned already are anchored on their current first entry.
+INSERT INTO audit_chain_checkpoints (
+ audit_chain_checkpoint_stream
+,audit_chain_checkpoint_sequence
+,audit_chain_checkpoint_hash
+)
+SELECT
+ e.audit_chain_stream
+,e.audit_chain_sequence - 1
+,e.audit_chain_prev_hash
+FROM audit_chain_entries e
+WHERE e.audit_chain_sequence > 1
+  AND e.audit_chain_sequence = (
+    SELECT MIN(f.audit_chain_sequence)
+    FROM audit_chain_entries f
+    WHERE f.audit_chain_stream = e.audit_chain_stream
+  );
@@ -70,7 +70,8 @@ const principalCommonColumns = `
 // principalColumns defines the column that are used only in a principal itself
 // (for explicit principals the type is implicit, only the generic principal struct stores it explicitly).
 const principalColumns = principalCommonColumns + `
-	,principal_type`
+	,principal_type
+	,principal_token_generation`
 
 //nolint:goconst
 const principalSelectBase = `
@@ -16,8 +16,10 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database"
@@ -25,6 +27,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
@@ -37,7 +40,16 @@ type user struct {
 }
 
 const userColumns = principalCommonColumns + `
-	,principal_user_password`
+	,principal_user_password
+	,principal_user_email_verified
+	,principal_user_onboarded
+	,principal_user_avatar
+	,principal_token_generation
+	,principal_version
+	,principal_user_failed_logins
+	,principal_user_locked_until
+	,principal_user_auth_source
+	,principal_user_external_id`
 
 const userSelectBase = `
 	SELECT` + userColumns + `
@@ -96,6 +108,29 @@ func (s *PrincipalStore) FindUserByEmail(ctx context.Context, email string) (*ty
 	return s.mapDBUser(dst), nil
 }
 
+// FindUserByExternalID finds the user provisioned by the auth source with the provided external id.
+func (s *PrincipalStore) FindUserByExternalID(
+	ctx context.Context,
+	authSource string,
+	externalID string,
+) (*types.User, error) {
+	const sqlQuery = userSelectBase + `
+		WHERE principal_type = 'user' AND principal_user_auth_source = $1 AND principal_user_external_id = $2`
+
+	if externalID == "" {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(user)
+	if err := db.GetContext(ctx, dst, sqlQuery, authSource, externalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select by external id query failed")
+	}
+
+	return s.mapDBUser(dst), nil
+}
+
 // CreateUser saves the user details.
 func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error {
 	const sqlQuery = `
@@ -111,6 +146,11 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 			,principal_created
 			,principal_updated
 			,principal_user_password
+			,principal_user_email_verified
+			,principal_user_onboarded
+			,principal_user_avatar
+			,principal_user_auth_source
+			,principal_user_external_id
 		) values (
 			'user'
 			,:principal_uid
@@ -123,6 +163,11 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 			,:principal_created
 			,:principal_updated
 			,:principal_user_password
+			,:principal_user_email_verified
+			,:principal_user_onboarded
+			,:principal_user_avatar
+			,:principal_user_auth_source
+			,:principal_user_external_id
 		) RETURNING principal_id`
 
 	dbUser, err := s.mapToDBUser(user)
@@ -144,9 +189,8 @@ func (s *PrincipalStore) CreateUser(ctx context.Context, user *types.User) error
 	return nil
 }
 
-// UpdateUser updates an existing user.
-func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error {
-	const sqlQuery = `
+// userUpdateQuery updates all mutable columns of a user.
+const userUpdateQuery = `
 		UPDATE principals
 		SET
 			principal_email     	  = :principal_email
@@ -155,9 +199,20 @@ func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error
 			,principal_blocked        = :principal_blocked
 			,principal_salt           = :principal_salt
 			,principal_updated        = :principal_updated
+			,principal_version        = principal_version + 1
 			,principal_user_password  = :principal_user_password
+			,principal_user_email_verified = :principal_user_email_verified
+			,principal_user_onboarded = :principal_user_onboarded
+			,principal_user_avatar = :principal_user_avatar
+			,principal_user_auth_source = :principal_user_auth_source
+			,principal_user_external_id = :principal_user_external_id
 		WHERE principal_type = 'user' AND principal_id = :principal_id`
 
+// UpdateUser updates an existing user.
+func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error {
+	const sqlQuery = userUpdateQuery + `
+		RETURNING principal_version`
+
 	dbUser, err := s.mapToDBUser(user)
 	if err != nil {
 		return fmt.Errorf("failed to map db user: %w", err)
@@ -170,11 +225,46 @@ func (s *PrincipalStore) UpdateUser(ctx context.Context, user *types.User) error
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user object")
 	}
 
-	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&user.Version); err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Update query failed")
 	}
 
-	return err
+	return nil
+}
+
+// UpdateUserIfUnmodified updates an existing user if its version still matches the provided version.
+// It returns store.ErrVersionConflict if the user was modified in the meantime.
+func (s *PrincipalStore) UpdateUserIfUnmodified(ctx context.Context, usr *types.User, version int64) error {
+	const sqlQuery = userUpdateQuery + ` AND principal_version = :principal_last_version
+		RETURNING principal_version`
+
+	dbUser, err := s.mapToDBUser(usr)
+	if err != nil {
+		return fmt.Errorf("failed to map db user: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, struct {
+		user
+		LastVersion int64 `db:"principal_last_version"`
+	}{
+		user:        *dbUser,
+		LastVersion: version,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user object")
+	}
+
+	err = db.QueryRowContext(ctx, query, arg...).Scan(&usr.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return gitness_store.ErrVersionConflict
+	}
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update query failed")
+	}
+
+	return nil
 }
 
 // DeleteUser deletes the user.
@@ -192,6 +282,107 @@ func (s *PrincipalStore) DeleteUser(ctx context.Context, id int64) error {
 	return nil
 }
 
+// IncrementTokenGeneration increases the token generation of the user, which invalidates all its tokens.
+func (s *PrincipalStore) IncrementTokenGeneration(ctx context.Context, id int64) (int64, error) {
+	const sqlQuery = `
+		UPDATE principals
+		SET
+			principal_token_generation = principal_token_generation + 1
+			,principal_updated = $1
+			,principal_version = principal_version + 1
+		WHERE principal_type = 'user' AND principal_id = $2
+		RETURNING principal_token_generation`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var generation int64
+	if err := db.QueryRowContext(ctx, sqlQuery, time.Now().UnixMilli(), id).Scan(&generation); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to increment token generation")
+	}
+
+	return generation, nil
+}
+
+// IncrementFailedLogins increases the number of failed logins of the user and returns the new count.
+func (s *PrincipalStore) IncrementFailedLogins(ctx context.Context, id int64) (int, error) {
+	const sqlQuery = `
+		UPDATE principals
+		SET principal_user_failed_logins = principal_user_failed_logins + 1
+		WHERE principal_type = 'user' AND principal_id = $1
+		RETURNING principal_user_failed_logins`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var failedLogins int
+	if err := db.QueryRowContext(ctx, sqlQuery, id).Scan(&failedLogins); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to increment failed logins")
+	}
+
+	return failedLogins, nil
+}
+
+// UpdateLockedUntil locks the user until the provided time (0 unlocks the user) and resets its failed logins.
+func (s *PrincipalStore) UpdateLockedUntil(ctx context.Context, id int64, lockedUntil int64) error {
+	const sqlQuery = `
+		UPDATE principals
+		SET
+			principal_user_locked_until = $1
+			,principal_user_failed_logins = 0
+		WHERE principal_type = 'user' AND principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, lockedUntil, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update locked until")
+	}
+
+	return nil
+}
+
+// UpdatePassword replaces the password hash of the user if it still matches the current hash.
+// It returns store.ErrVersionConflict if the password was changed in the meantime.
+func (s *PrincipalStore) UpdatePassword(ctx context.Context, id int64, currentHash string, hash string) error {
+	const sqlQuery = `
+		UPDATE principals
+		SET principal_user_password = $1
+		WHERE principal_type = 'user' AND principal_id = $2 AND principal_user_password = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, hash, id, currentHash)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update password")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	return nil
+}
+
+// FindPassword finds the password hash of the principal, independent of its type.
+func (s *PrincipalStore) FindPassword(ctx context.Context, id int64) (string, error) {
+	const sqlQuery = `
+		SELECT COALESCE(principal_user_password, '')
+		FROM principals
+		WHERE principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var hash string
+	if err := db.GetContext(ctx, &hash, sqlQuery, id); err != nil {
+		return "", database.ProcessSQLErrorf(ctx, err, "Select password by id query failed")
+	}
+
+	return hash, nil
+}
+
 // ListUsers returns a list of users.
 func (s *PrincipalStore) ListUsers(ctx context.Context, opts *types.UserFilter) ([]*types.User, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -201,6 +392,7 @@ func (s *PrincipalStore) ListUsers(ctx context.Context, opts *types.UserFilter)
 		Select(userColumns).
 		From("principals").
 		Where("principal_type = 'user'")
+	stmt = s.applyCreatedFilter(stmt, opts.CreatedFilter)
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
 
@@ -227,12 +419,12 @@ func (s *PrincipalStore) ListUsers(ctx context.Context, opts *types.UserFilter)
 		stmt = stmt.OrderBy("principal_admin " + order.String())
 	}
 
-	sql, _, err := stmt.ToSql()
+	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to convert query to sql")
 	}
 
-	if err = db.SelectContext(ctx, &dst, sql); err != nil {
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
 	}
 
@@ -250,6 +442,8 @@ func (s *PrincipalStore) CountUsers(ctx context.Context, opts *types.UserFilter)
 		stmt = stmt.Where("principal_admin = ?", opts.Admin)
 	}
 
+	stmt = s.applyCreatedFilter(stmt, opts.CreatedFilter)
+
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return 0, errors.Wrap(err, "Failed to convert query to sql")
@@ -266,6 +460,21 @@ func (s *PrincipalStore) CountUsers(ctx context.Context, opts *types.UserFilter)
 	return count, nil
 }
 
+func (*PrincipalStore) applyCreatedFilter(
+	stmt squirrel.SelectBuilder,
+	filter types.CreatedFilter,
+) squirrel.SelectBuilder {
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("principal_created > ?", filter.CreatedGt)
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("principal_created < ?", filter.CreatedLt)
+	}
+
+	return stmt
+}
+
 func (s *PrincipalStore) mapDBUser(dbUser *user) *types.User {
 	return &dbUser.User
 }
@@ -0,0 +1,366 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_ListUsersCreatedRange(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	for i := int64(1); i <= 5; i++ {
+		uid := "user_" + strconv.FormatInt(i, 10)
+		if err := principalStore.CreateUser(ctx, &types.User{
+			ID:      i,
+			UID:     uid,
+			Email:   uid + "@example.com",
+			Created: i * 1000,
+		}); err != nil {
+			t.Fatalf("failed to create user %v", err)
+		}
+	}
+
+	filter := &types.UserFilter{
+		Sort:          enum.UserAttrCreated,
+		Order:         enum.OrderAsc,
+		CreatedFilter: types.CreatedFilter{CreatedGt: 1000, CreatedLt: 5000},
+	}
+
+	users, err := principalStore.ListUsers(ctx, filter)
+	if err != nil {
+		t.Fatalf("failed to list users %v", err)
+	}
+
+	count, err := principalStore.CountUsers(ctx, filter)
+	if err != nil {
+		t.Fatalf("failed to count users %v", err)
+	}
+
+	wantIDs := []int64{2, 3, 4}
+	if len(users) != len(wantIDs) || count != int64(len(wantIDs)) {
+		t.Fatalf("len(users) = %d, count = %d, want %d", len(users), count, len(wantIDs))
+	}
+
+	for i, u := range users {
+		if u.ID != wantIDs[i] {
+			t.Errorf("users[%d].ID = %d, want %d", i, u.ID, wantIDs[i])
+		}
+	}
+}
+
+func TestDatabase_IncrementTokenGeneration(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	for want := int64(1); want <= 2; want++ {
+		generation, err := principalStore.IncrementTokenGeneration(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to increment token generation %v", err)
+		}
+		if generation != want {
+			t.Errorf("generation = %d, want %d", generation, want)
+		}
+	}
+
+	principal, err := principalStore.Find(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to find principal %v", err)
+	}
+	if principal.TokenGeneration != 2 {
+		t.Errorf("principal.TokenGeneration = %d, want %d", principal.TokenGeneration, 2)
+	}
+}
+
+func TestDatabase_FailedLoginsAndLockout(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	for want := 1; want <= 2; want++ {
+		failedLogins, err := principalStore.IncrementFailedLogins(ctx, userID)
+		if err != nil {
+			t.Fatalf("failed to increment failed logins %v", err)
+		}
+		if failedLogins != want {
+			t.Errorf("failedLogins = %d, want %d", failedLogins, want)
+		}
+	}
+
+	if err := principalStore.UpdateLockedUntil(ctx, userID, 5000); err != nil {
+		t.Fatalf("failed to update locked until %v", err)
+	}
+
+	user, err := principalStore.FindUser(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to find user %v", err)
+	}
+	if user.LockedUntil != 5000 {
+		t.Errorf("user.LockedUntil = %d, want %d", user.LockedUntil, 5000)
+	}
+	if user.FailedLogins != 0 {
+		t.Errorf("user.FailedLogins = %d, want %d", user.FailedLogins, 0)
+	}
+}
+
+func TestDatabase_UserEmailCaseInsensitive(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	if err := principalStore.CreateUser(ctx, &types.User{
+		ID:    1,
+		UID:   "octocat",
+		Email: "Octocat@example.com",
+	}); err != nil {
+		t.Fatalf("failed to create user %v", err)
+	}
+
+	err := principalStore.CreateUser(ctx, &types.User{
+		ID:    2,
+		UID:   "octocat2",
+		Email: "octocat@example.com",
+	})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("err = %v, want %v", err, gitness_store.ErrDuplicate)
+	}
+
+	user, err := principalStore.FindUserByEmail(ctx, "OCTOCAT@example.com")
+	if err != nil {
+		t.Fatalf("failed to find user by email %v", err)
+	}
+	if user.ID != 1 {
+		t.Errorf("user.ID = %d, want %d", user.ID, 1)
+	}
+}
+
+func TestDatabase_UserExternalIdentity(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	if err := principalStore.CreateUser(ctx, &types.User{
+		UID:        "jdoe",
+		Email:      "jdoe@example.com",
+		AuthSource: "ldap",
+		ExternalID: "c0ffee",
+	}); err != nil {
+		t.Fatalf("failed to create user %v", err)
+	}
+
+	user, err := principalStore.FindUserByUID(ctx, "jdoe")
+	if err != nil {
+		t.Fatalf("failed to find user %v", err)
+	}
+	if user.AuthSource != "ldap" || user.ExternalID != "c0ffee" {
+		t.Errorf("external identity = %q/%q, want %q/%q", user.AuthSource, user.ExternalID, "ldap", "c0ffee")
+	}
+
+	found, err := principalStore.FindUserByExternalID(ctx, "ldap", "c0ffee")
+	if err != nil {
+		t.Fatalf("failed to find user by external id %v", err)
+	}
+	if found.ID != user.ID {
+		t.Errorf("user id = %d, want %d", found.ID, user.ID)
+	}
+
+	_, err = principalStore.FindUserByExternalID(ctx, "oidc", "c0ffee")
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("err = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+
+	// local users are linked to an external identity via update.
+	if err = principalStore.CreateUser(ctx, &types.User{UID: "local", Email: "local@example.com"}); err != nil {
+		t.Fatalf("failed to create user %v", err)
+	}
+	local, err := principalStore.FindUserByUID(ctx, "local")
+	if err != nil {
+		t.Fatalf("failed to find user %v", err)
+	}
+	if _, err = principalStore.FindUserByExternalID(ctx, "", ""); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("err = %v, want %v", err, gitness_store.ErrResourceNotFound)
+	}
+
+	local.AuthSource = "oidc"
+	local.ExternalID = "issuer#subject"
+	if err = principalStore.UpdateUser(ctx, local); err != nil {
+		t.Fatalf("failed to update user %v", err)
+	}
+	if found, err = principalStore.FindUserByExternalID(ctx, "oidc", "issuer#subject"); err != nil {
+		t.Fatalf("failed to find linked user %v", err)
+	} else if found.ID != local.ID {
+		t.Errorf("user id = %d, want %d", found.ID, local.ID)
+	}
+
+	// an external identity can only be linked to a single user.
+	user.AuthSource = "oidc"
+	user.ExternalID = "issuer#subject"
+	if err = principalStore.UpdateUser(ctx, user); !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("err = %v, want %v", err, gitness_store.ErrDuplicate)
+	}
+}
+
+func TestDatabase_UpdatePassword(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	if err := principalStore.CreateUser(ctx, &types.User{
+		UID:         "jdoe",
+		Email:       "jdoe@example.com",
+		DisplayName: "John Doe",
+		Password:    "old",
+	}); err != nil {
+		t.Fatalf("failed to create user %v", err)
+	}
+
+	user, err := principalStore.FindUserByUID(ctx, "jdoe")
+	if err != nil {
+		t.Fatalf("failed to find user %v", err)
+	}
+
+	err = principalStore.UpdatePassword(ctx, user.ID, "stale", "new")
+	if !errors.Is(err, gitness_store.ErrVersionConflict) {
+		t.Errorf("err = %v, want %v", err, gitness_store.ErrVersionConflict)
+	}
+
+	if err = principalStore.UpdatePassword(ctx, user.ID, "old", "new"); err != nil {
+		t.Fatalf("failed to update password %v", err)
+	}
+
+	user, err = principalStore.FindUserByUID(ctx, "jdoe")
+	if err != nil {
+		t.Fatalf("failed to find user %v", err)
+	}
+	if user.Password != "new" {
+		t.Errorf("user.Password = %q, want %q", user.Password, "new")
+	}
+	if user.DisplayName != "John Doe" {
+		t.Errorf("user.DisplayName = %q, want %q", user.DisplayName, "John Doe")
+	}
+}
+
+func TestDatabase_UpdateUserIfUnmodified(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	if err := principalStore.CreateUser(ctx, &types.User{
+		UID:         "jdoe",
+		Email:       "jdoe@example.com",
+		DisplayName: "John Doe",
+		Updated:     1,
+	}); err != nil {
+		t.Fatalf("failed to create user %v", err)
+	}
+
+	// concurrent updates that are based on the same version of the user, only one of them may succeed.
+	const updates = 4
+	users := make([]*types.User, updates)
+	for i := range users {
+		user, err := principalStore.FindUserByUID(ctx, "jdoe")
+		if err != nil {
+			t.Fatalf("failed to find user %v", err)
+		}
+		users[i] = user
+	}
+
+	errs := make(chan error, updates)
+	var wg sync.WaitGroup
+	for i, user := range users {
+		wg.Add(1)
+		go func(i int, user *types.User) {
+			defer wg.Done()
+
+			version := user.Version
+			user.DisplayName = "Update " + strconv.Itoa(i)
+			// all updates happen at the same time, only the version tells them apart.
+			user.Updated = 2
+			errs <- principalStore.UpdateUserIfUnmodified(ctx, user, version)
+		}(i, user)
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, gitness_store.ErrVersionConflict):
+			t.Errorf("err = %v, want nil or %v", err, gitness_store.ErrVersionConflict)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("succeeded updates = %d, want 1", succeeded)
+	}
+
+	user, err := principalStore.FindUserByUID(ctx, "jdoe")
+	if err != nil {
+		t.Fatalf("failed to find user %v", err)
+	}
+
+	if user.Version != 1 {
+		t.Errorf("version = %d, want 1", user.Version)
+	}
+
+	err = principalStore.UpdateUserIfUnmodified(ctx, user, 0)
+	if !errors.Is(err, gitness_store.ErrVersionConflict) {
+		t.Errorf("err = %v, want %v", err, gitness_store.ErrVersionConflict)
+	}
+
+	if err = principalStore.UpdateUserIfUnmodified(ctx, user, user.Version); err != nil {
+		t.Fatalf("failed to update user %v", err)
+	}
+	if user.Version != 2 {
+		t.Errorf("version = %d, want 2", user.Version)
+	}
+}
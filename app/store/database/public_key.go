@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PublicKeyStore = (*PublicKeyStore)(nil)
+
+// NewPublicKeyStore returns a new PublicKeyStore.
+func NewPublicKeyStore(db *sqlx.DB) *PublicKeyStore {
+	return &PublicKeyStore{db}
+}
+
+// PublicKeyStore implements a PublicKeyStore backed by a relational database.
+type PublicKeyStore struct {
+	db *sqlx.DB
+}
+
+// publicKey is an internal representation used to store public key data in the database.
+type publicKey struct {
+	ID          int64  `db:"public_key_id"`
+	PrincipalID int64  `db:"public_key_principal_id"`
+	Fingerprint string `db:"public_key_fingerprint"`
+	Content     string `db:"public_key_content"`
+	Label       string `db:"public_key_label"`
+	Created     int64  `db:"public_key_created"`
+}
+
+// Find finds the public key by id.
+func (s *PublicKeyStore) Find(ctx context.Context, id int64) (*types.PublicKey, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(publicKey)
+	if err := db.GetContext(ctx, dst, publicKeySelectByID, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find public key")
+	}
+
+	return mapToPublicKey(dst), nil
+}
+
+// FindByFingerprint finds the public key by its fingerprint.
+func (s *PublicKeyStore) FindByFingerprint(ctx context.Context, fingerprint string) (*types.PublicKey, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(publicKey)
+	if err := db.GetContext(ctx, dst, publicKeySelectByFingerprint, fingerprint); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find public key by fingerprint")
+	}
+
+	return mapToPublicKey(dst), nil
+}
+
+// Create saves the public key.
+func (s *PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(publicKeyInsert, mapToInternalPublicKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind public key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the public key with the given id.
+func (s *PublicKeyStore) Delete(ctx context.Context, id int64) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, publicKeyDelete, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List returns all public keys of a principal.
+func (s *PublicKeyStore) List(ctx context.Context, principalID int64) ([]*types.PublicKey, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*publicKey{}
+	if err := db.SelectContext(ctx, &dst, publicKeySelectForPrincipalID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing public key list query")
+	}
+
+	res := make([]*types.PublicKey, len(dst))
+	for i := range dst {
+		res[i] = mapToPublicKey(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToPublicKey(k *publicKey) *types.PublicKey {
+	return &types.PublicKey{
+		ID:          k.ID,
+		PrincipalID: k.PrincipalID,
+		Fingerprint: k.Fingerprint,
+		Content:     k.Content,
+		Label:       k.Label,
+		Created:     k.Created,
+	}
+}
+
+func mapToInternalPublicKey(k *types.PublicKey) *publicKey {
+	return &publicKey{
+		ID:          k.ID,
+		PrincipalID: k.PrincipalID,
+		Fingerprint: k.Fingerprint,
+		Content:     k.Content,
+		Label:       k.Label,
+		Created:     k.Created,
+	}
+}
+
+const publicKeySelectBase = `
+SELECT
+public_key_id
+,public_key_principal_id
+,public_key_fingerprint
+,public_key_content
+,public_key_label
+,public_key_created
+FROM public_keys
+`
+
+const publicKeySelectByID = publicKeySelectBase + `
+WHERE public_key_id = $1
+`
+
+const publicKeySelectByFingerprint = publicKeySelectBase + `
+WHERE public_key_fingerprint = $1
+`
+
+const publicKeySelectForPrincipalID = publicKeySelectBase + `
+WHERE public_key_principal_id = $1
+ORDER BY public_key_created DESC
+`
+
+const publicKeyDelete = `
+DELETE FROM public_keys
+WHERE public_key_id = $1
+`
+
+const publicKeyInsert = `
+INSERT INTO public_keys (
+	public_key_principal_id
+	,public_key_fingerprint
+	,public_key_content
+	,public_key_label
+	,public_key_created
+) values (
+	:public_key_principal_id
+	,:public_key_fingerprint
+	,:public_key_content
+	,:public_key_label
+	,:public_key_created
+) RETURNING public_key_id
+`
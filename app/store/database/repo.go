@@ -67,7 +67,10 @@ type repository struct {
 	ParentID    int64    `db:"repo_parent_id"`
 	Identifier  string   `db:"repo_uid"`
 	Description string   `db:"repo_description"`
+	Homepage    string   `db:"repo_homepage"`
+	Topics      string   `db:"repo_topics"`
 	IsPublic    bool     `db:"repo_is_public"`
+	IsTemplate  bool     `db:"repo_is_template"`
 	CreatedBy   int64    `db:"repo_created_by"`
 	Created     int64    `db:"repo_created"`
 	Updated     int64    `db:"repo_updated"`
@@ -89,6 +92,7 @@ type repository struct {
 
 	Importing bool `db:"repo_importing"`
 	IsEmpty   bool `db:"repo_is_empty"`
+	Archived  bool `db:"repo_archived"`
 }
 
 const (
@@ -98,7 +102,10 @@ const (
 		,repo_parent_id
 		,repo_uid
 		,repo_description
+		,repo_homepage
+		,repo_topics
 		,repo_is_public
+		,repo_is_template
 		,repo_created_by
 		,repo_created
 		,repo_updated
@@ -115,7 +122,8 @@ const (
 		,repo_num_open_pulls
 		,repo_num_merged_pulls
 		,repo_importing
-		,repo_is_empty`
+		,repo_is_empty
+		,repo_archived`
 )
 
 // Find finds the repo by id.
@@ -196,11 +204,48 @@ func (s *RepoStore) findByRef(ctx context.Context, repoRef string, deletedAt *in
 			return nil, fmt.Errorf("failed to get space path: %w", err)
 		}
 
-		return s.findByIdentifier(ctx, pathObject.SpaceID, repoIdentifier, deletedAt)
+		repo, err := s.findByIdentifier(ctx, pathObject.SpaceID, repoIdentifier, deletedAt)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) && deletedAt == nil {
+			// the repository might have been renamed - fall back to the previous paths.
+			if redirected, errRedirect := s.findByRedirect(ctx, pathObject.SpaceID, repoIdentifier); errRedirect == nil {
+				return redirected, nil
+			}
+		}
+
+		return repo, err
 	}
 	return s.find(ctx, id, deletedAt)
 }
 
+// findByRedirect finds the active repository that used to have the provided identifier within the space.
+func (s *RepoStore) findByRedirect(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repository_redirects").
+		InnerJoin("repositories ON repo_id = redirect_repo_id").
+		Where("redirect_space_id = ? AND redirect_uid = ?", spaceID, strings.ToLower(identifier)).
+		Where("redirect_expires > ?", time.Now().UnixMilli()).
+		Where("repo_deleted IS NULL")
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(repository)
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repo by redirect")
+	}
+
+	return s.mapToRepo(ctx, dst)
+}
+
 // FindByRef finds the repo using the repoRef as either the id or the repo path.
 func (s *RepoStore) FindByRef(ctx context.Context, repoRef string) (*types.Repository, error) {
 	return s.findByRef(ctx, repoRef, nil)
@@ -223,7 +268,10 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,repo_parent_id
 			,repo_uid
 			,repo_description
+			,repo_homepage
+			,repo_topics
 			,repo_is_public
+			,repo_is_template
 			,repo_created_by
 			,repo_created
 			,repo_updated
@@ -241,12 +289,16 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,repo_num_merged_pulls
 			,repo_importing
 			,repo_is_empty
+			,repo_archived
 		) values (
 			:repo_version
 			,:repo_parent_id
 			,:repo_uid
 			,:repo_description
+			,:repo_homepage
+			,:repo_topics
 			,:repo_is_public
+			,:repo_is_template
 			,:repo_created_by
 			,:repo_created
 			,:repo_updated
@@ -264,6 +316,7 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,:repo_num_merged_pulls
 			,:repo_importing
 			,:repo_is_empty
+			,:repo_archived
 		) RETURNING repo_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -298,7 +351,10 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 			,repo_uid = :repo_uid
 			,repo_git_uid = :repo_git_uid
 			,repo_description = :repo_description
+			,repo_homepage = :repo_homepage
+			,repo_topics = :repo_topics
 			,repo_is_public = :repo_is_public
+			,repo_is_template = :repo_is_template
 			,repo_default_branch = :repo_default_branch
 			,repo_pullreq_seq = :repo_pullreq_seq
 			,repo_num_forks = :repo_num_forks
@@ -308,6 +364,7 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 			,repo_num_merged_pulls = :repo_num_merged_pulls
 			,repo_importing = :repo_importing
 			,repo_is_empty = :repo_is_empty
+			,repo_archived = :repo_archived
 		WHERE repo_id = :repo_id AND repo_version = :repo_version - 1`
 
 	dbRepo := mapToInternalRepo(repo)
@@ -608,6 +665,38 @@ FROM SpaceHierarchy h1;`
 	return numRepos, nil
 }
 
+// Search returns up to limit active repos of all spaces whose identifier contains the query,
+// shorter identifiers first.
+func (s *RepoStore) Search(
+	ctx context.Context,
+	query string,
+	offset int,
+	limit int,
+) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories")
+
+	stmt = applyQueryFilter(stmt, &types.RepoFilter{Query: query})
+	stmt = stmt.OrderBy("LENGTH(repo_uid) ASC", "LOWER(repo_uid) ASC", "repo_id ASC")
+	stmt = stmt.Limit(uint64(limit))
+	stmt = stmt.Offset(uint64(offset))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing search query")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
 // List returns a list of active repos in a space.
 // With "DeletedBeforeOrAt" filter, lists deleted repos by opts.DeletedBeforeOrAt.
 func (s *RepoStore) List(
@@ -734,7 +823,10 @@ func (s *RepoStore) mapToRepo(
 		ParentID:       in.ParentID,
 		Identifier:     in.Identifier,
 		Description:    in.Description,
+		Homepage:       in.Homepage,
+		Topics:         topicsFromString(in.Topics),
 		IsPublic:       in.IsPublic,
+		IsTemplate:     in.IsTemplate,
 		Created:        in.Created,
 		CreatedBy:      in.CreatedBy,
 		Updated:        in.Updated,
@@ -752,6 +844,7 @@ func (s *RepoStore) mapToRepo(
 		NumMergedPulls: in.NumMergedPulls,
 		Importing:      in.Importing,
 		IsEmpty:        in.IsEmpty,
+		Archived:       in.Archived,
 		// Path: is set below
 	}
 
@@ -818,7 +911,10 @@ func mapToInternalRepo(in *types.Repository) *repository {
 		ParentID:       in.ParentID,
 		Identifier:     in.Identifier,
 		Description:    in.Description,
+		Homepage:       in.Homepage,
+		Topics:         topicsToString(in.Topics),
 		IsPublic:       in.IsPublic,
+		IsTemplate:     in.IsTemplate,
 		Created:        in.Created,
 		CreatedBy:      in.CreatedBy,
 		Updated:        in.Updated,
@@ -836,7 +932,24 @@ func mapToInternalRepo(in *types.Repository) *repository {
 		NumMergedPulls: in.NumMergedPulls,
 		Importing:      in.Importing,
 		IsEmpty:        in.IsEmpty,
+		Archived:       in.Archived,
+	}
+}
+
+// topicsSeparator defines the character that's used to join topics for storing them in the DB
+// ASSUMPTION: topics are validated by check.RepoTopics and don't contain ",".
+const topicsSeparator = ","
+
+func topicsFromString(topicsString string) []string {
+	if topicsString == "" {
+		return []string{}
 	}
+
+	return strings.Split(topicsString, topicsSeparator)
+}
+
+func topicsToString(topics []string) string {
+	return strings.Join(topics, topicsSeparator)
 }
 
 func applyQueryFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squirrel.SelectBuilder {
@@ -851,10 +964,39 @@ func applyQueryFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squ
 	} else {
 		stmt = stmt.Where("repo_deleted IS NULL")
 	}
+	if filter.Archived != nil {
+		stmt = stmt.Where("repo_archived = ?", *filter.Archived)
+	}
+	if len(filter.Topics) > 0 {
+		stmt = stmt.Where(topicsCondition(filter.Topics, filter.TopicsMatchAny))
+	}
 	return stmt
 }
 
+// topicsCondition matches repositories tagged with all (or any) of the topics.
+// Surrounding the stored topics with separators ensures only whole topics are matched.
+// ASSUMPTION: topics are validated by check.RepoTopics and don't contain any LIKE wildcards.
+func topicsCondition(topics []string, matchAny bool) squirrel.Sqlizer {
+	conditions := make([]squirrel.Sqlizer, len(topics))
+	for i, topic := range topics {
+		conditions[i] = squirrel.Expr(
+			"('"+topicsSeparator+"' || repo_topics || '"+topicsSeparator+"') LIKE ?",
+			"%"+topicsSeparator+topic+topicsSeparator+"%",
+		)
+	}
+
+	if matchAny {
+		return squirrel.Or(conditions)
+	}
+
+	return squirrel.And(conditions)
+}
+
 func applySortFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squirrel.SelectBuilder {
+	if filter.Cursor != nil {
+		return applyCursorFilter(stmt, filter)
+	}
+
 	stmt = stmt.Limit(database.Limit(filter.Size))
 	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
 
@@ -875,3 +1017,19 @@ func applySortFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squi
 
 	return stmt
 }
+
+// applyCursorFilter applies keyset pagination ordered by creation time and id,
+// returning only repositories located after the cursor.
+func applyCursorFilter(stmt squirrel.SelectBuilder, filter *types.RepoFilter) squirrel.SelectBuilder {
+	stmt = stmt.Where(squirrel.Or{
+		squirrel.Gt{"repo_created": filter.Cursor.Created},
+		squirrel.And{
+			squirrel.Eq{"repo_created": filter.Cursor.Created},
+			squirrel.Gt{"repo_id": filter.Cursor.ID},
+		},
+	})
+
+	return stmt.
+		OrderBy("repo_created asc", "repo_id asc").
+		Limit(database.Limit(filter.Size))
+}
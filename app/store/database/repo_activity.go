@@ -0,0 +1,236 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.RepoActivityStore = (*RepoActivityStore)(nil)
+
+// NewRepoActivityStore returns a new RepoActivityStore.
+func NewRepoActivityStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *RepoActivityStore {
+	return &RepoActivityStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// RepoActivityStore implements store.RepoActivityStore backed by a relational database.
+type RepoActivityStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+// repoActivity is an internal representation used to store repository activity data in the database.
+type repoActivity struct {
+	ID          int64                 `db:"repo_activity_id"`
+	RepoID      int64                 `db:"repo_activity_repo_id"`
+	Type        enum.RepoActivityType `db:"repo_activity_type"`
+	EventID     string                `db:"repo_activity_event_id"`
+	PrincipalID int64                 `db:"repo_activity_principal_id"`
+	Created     int64                 `db:"repo_activity_created"`
+	Payload     string                `db:"repo_activity_payload"`
+}
+
+const (
+	repoActivityColumns = `
+		 repo_activity_id
+		,repo_activity_repo_id
+		,repo_activity_type
+		,repo_activity_event_id
+		,repo_activity_principal_id
+		,repo_activity_created
+		,repo_activity_payload`
+)
+
+// Create saves the repository activity. If an activity for the same event was already stored,
+// the function returns store.ErrDuplicate.
+func (s *RepoActivityStore) Create(ctx context.Context, act *types.RepoActivity) error {
+	const sqlQuery = `
+	INSERT INTO repo_activities (
+		 repo_activity_repo_id
+		,repo_activity_type
+		,repo_activity_event_id
+		,repo_activity_principal_id
+		,repo_activity_created
+		,repo_activity_payload
+	) values (
+		 :repo_activity_repo_id
+		,:repo_activity_type
+		,:repo_activity_event_id
+		,:repo_activity_principal_id
+		,:repo_activity_created
+		,:repo_activity_payload
+	) RETURNING repo_activity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalRepoActivity(act))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repository activity object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&act.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert repository activity")
+	}
+
+	return nil
+}
+
+// Count returns the number of repository activities matching the filter.
+func (s *RepoActivityStore) Count(ctx context.Context,
+	repoID int64,
+	filter *types.RepoActivityFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("repo_activities").
+		Where("repo_activity_repo_id = ?", repoID)
+
+	stmt = applyRepoActivityFilter(filter, stmt)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List returns a list of repository activities, newest first.
+func (s *RepoActivityStore) List(ctx context.Context,
+	repoID int64,
+	filter *types.RepoActivityFilter,
+) ([]*types.RepoActivity, error) {
+	stmt := database.Builder.
+		Select(repoActivityColumns).
+		From("repo_activities").
+		Where("repo_activity_repo_id = ?", repoID)
+
+	stmt = applyRepoActivityFilter(filter, stmt)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("repo_activity_created desc", "repo_activity_id desc")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert repository activity query to sql")
+	}
+
+	dst := make([]*repoActivity, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing repository activity list query")
+	}
+
+	return s.mapSliceRepoActivity(ctx, dst)
+}
+
+func applyRepoActivityFilter(
+	filter *types.RepoActivityFilter,
+	stmt squirrel.SelectBuilder,
+) squirrel.SelectBuilder {
+	if len(filter.Types) == 1 {
+		stmt = stmt.Where("repo_activity_type = ?", filter.Types[0])
+	} else if len(filter.Types) > 1 {
+		stmt = stmt.Where(squirrel.Eq{"repo_activity_type": filter.Types})
+	}
+
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("repo_activity_created > ?", filter.CreatedGt)
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("repo_activity_created < ?", filter.CreatedLt)
+	}
+
+	return stmt
+}
+
+func (s *RepoActivityStore) mapSliceRepoActivity(
+	ctx context.Context,
+	activities []*repoActivity,
+) ([]*types.RepoActivity, error) {
+	ids := make([]int64, len(activities))
+	for i, act := range activities {
+		ids[i] = act.PrincipalID
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load repository activity principal infos: %w", err)
+	}
+
+	m := make([]*types.RepoActivity, len(activities))
+	for i, act := range activities {
+		m[i] = mapRepoActivity(act)
+		m[i].Principal = infoMap[act.PrincipalID]
+	}
+
+	return m, nil
+}
+
+func mapRepoActivity(act *repoActivity) *types.RepoActivity {
+	return &types.RepoActivity{
+		ID:          act.ID,
+		RepoID:      act.RepoID,
+		Type:        act.Type,
+		EventID:     act.EventID,
+		PrincipalID: act.PrincipalID,
+		Created:     act.Created,
+		Payload:     json.RawMessage(act.Payload),
+	}
+}
+
+func mapInternalRepoActivity(act *types.RepoActivity) *repoActivity {
+	payload := string(act.Payload)
+	if payload == "" {
+		payload = "{}"
+	}
+
+	return &repoActivity{
+		ID:          act.ID,
+		RepoID:      act.RepoID,
+		Type:        act.Type,
+		EventID:     act.EventID,
+		PrincipalID: act.PrincipalID,
+		Created:     act.Created,
+		Payload:     payload,
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRepoActivityStore_ListFilteredByType(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	activityStore := database.NewRepoActivityStore(db,
+		cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db)))
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	activities := []*types.RepoActivity{
+		{EventID: "e1", Type: enum.RepoActivityTypeBranchUpdated, Created: 1000},
+		{EventID: "e2", Type: enum.RepoActivityTypePullReqCreated, Created: 2000},
+		{EventID: "e3", Type: enum.RepoActivityTypeBranchUpdated, Created: 3000},
+	}
+	for _, act := range activities {
+		act.RepoID = 1
+		act.PrincipalID = userID
+		if err := activityStore.Create(ctx, act); err != nil {
+			t.Fatalf("failed to create repo activity: %v", err)
+		}
+	}
+
+	err := activityStore.Create(ctx, &types.RepoActivity{
+		RepoID: 1, PrincipalID: userID, EventID: "e1", Type: enum.RepoActivityTypeBranchUpdated, Created: 1000,
+	})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("Want ErrDuplicate for redelivered event, got %v", err)
+	}
+
+	filter := &types.RepoActivityFilter{
+		Page:  1,
+		Size:  10,
+		Types: []enum.RepoActivityType{enum.RepoActivityTypeBranchUpdated},
+	}
+
+	list, err := activityStore.List(ctx, 1, filter)
+	if err != nil {
+		t.Fatalf("failed to list repo activities: %v", err)
+	}
+
+	if len(list) != 2 {
+		t.Fatalf("Want 2 activities, got %d", len(list))
+	}
+	if list[0].EventID != "e3" || list[1].EventID != "e1" {
+		t.Errorf("Want activities e3, e1 (newest first), got %s, %s", list[0].EventID, list[1].EventID)
+	}
+	if list[0].Principal == nil || list[0].Principal.ID != userID {
+		t.Errorf("Want principal info of user %d, got %v", userID, list[0].Principal)
+	}
+
+	count, err := activityStore.Count(ctx, 1, filter)
+	if err != nil {
+		t.Fatalf("failed to count repo activities: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Want count 2, got %d", count)
+	}
+
+	filter.CreatedGt = 1000
+	list, err = activityStore.List(ctx, 1, filter)
+	if err != nil {
+		t.Fatalf("failed to list repo activities: %v", err)
+	}
+	if len(list) != 1 || list[0].EventID != "e3" {
+		t.Errorf("Want only activity e3 created after 1000, got %d activities", len(list))
+	}
+}
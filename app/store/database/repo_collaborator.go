@@ -0,0 +1,300 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoCollaboratorStore = (*RepoCollaboratorStore)(nil)
+
+// NewRepoCollaboratorStore returns a new RepoCollaboratorStore.
+func NewRepoCollaboratorStore(
+	db *sqlx.DB,
+	pCache store.PrincipalInfoCache,
+) *RepoCollaboratorStore {
+	return &RepoCollaboratorStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// RepoCollaboratorStore implements store.RepoCollaboratorStore backed by a relational database.
+type RepoCollaboratorStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type repoCollaborator struct {
+	RepoID      int64 `db:"collaborator_repo_id"`
+	PrincipalID int64 `db:"collaborator_principal_id"`
+
+	CreatedBy int64 `db:"collaborator_created_by"`
+	Created   int64 `db:"collaborator_created"`
+	Updated   int64 `db:"collaborator_updated"`
+
+	Role enum.MembershipRole `db:"collaborator_role"`
+}
+
+type repoCollaboratorPrincipal struct {
+	repoCollaborator
+	principalInfo
+}
+
+const (
+	repoCollaboratorColumns = `
+		 collaborator_repo_id
+		,collaborator_principal_id
+		,collaborator_created_by
+		,collaborator_created
+		,collaborator_updated
+		,collaborator_role`
+
+	repoCollaboratorSelectBase = `
+	SELECT` + repoCollaboratorColumns + `
+	FROM repository_collaborators`
+)
+
+// Find finds the collaborator by repo id and principal id.
+func (s *RepoCollaboratorStore) Find(
+	ctx context.Context,
+	key types.RepoCollaboratorKey,
+) (*types.RepoCollaborator, error) {
+	const sqlQuery = repoCollaboratorSelectBase + `
+	WHERE collaborator_repo_id = $1 AND collaborator_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoCollaborator{}
+	if err := db.GetContext(ctx, dst, sqlQuery, key.RepoID, key.PrincipalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repository collaborator")
+	}
+
+	result := mapToRepoCollaborator(dst)
+
+	return &result, nil
+}
+
+// FindUser finds the collaborator by repo id and principal id and adds the principal infos.
+func (s *RepoCollaboratorStore) FindUser(
+	ctx context.Context,
+	key types.RepoCollaboratorKey,
+) (*types.RepoCollaboratorUser, error) {
+	c, err := s.Find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, []int64{c.CreatedBy, c.PrincipalID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load repository collaborator principal infos: %w", err)
+	}
+
+	result := &types.RepoCollaboratorUser{RepoCollaborator: *c}
+
+	user, ok := infoMap[c.PrincipalID]
+	if !ok {
+		return nil, fmt.Errorf("failed to find repository collaborator principal info")
+	}
+	result.Principal = *user
+
+	if addedBy, found := infoMap[c.CreatedBy]; found {
+		result.AddedBy = *addedBy
+	}
+
+	return result, nil
+}
+
+// Create creates a new repository collaborator.
+func (s *RepoCollaboratorStore) Create(ctx context.Context, collaborator *types.RepoCollaborator) error {
+	const sqlQuery = `
+	INSERT INTO repository_collaborators (
+		 collaborator_repo_id
+		,collaborator_principal_id
+		,collaborator_created_by
+		,collaborator_created
+		,collaborator_updated
+		,collaborator_role
+	) values (
+		 :collaborator_repo_id
+		,:collaborator_principal_id
+		,:collaborator_created_by
+		,:collaborator_created
+		,:collaborator_updated
+		,:collaborator_role
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoCollaborator(collaborator))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repository collaborator object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert repository collaborator")
+	}
+
+	return nil
+}
+
+// Update updates the role of a repository collaborator.
+func (s *RepoCollaboratorStore) Update(ctx context.Context, collaborator *types.RepoCollaborator) error {
+	const sqlQuery = `
+	UPDATE repository_collaborators
+	SET
+		 collaborator_updated = :collaborator_updated
+		,collaborator_role = :collaborator_role
+	WHERE collaborator_repo_id = :collaborator_repo_id AND
+	      collaborator_principal_id = :collaborator_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbCollaborator := mapToInternalRepoCollaborator(collaborator)
+	dbCollaborator.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbCollaborator)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repository collaborator object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update repository collaborator role")
+	}
+
+	collaborator.Updated = dbCollaborator.Updated
+
+	return nil
+}
+
+// Delete deletes the repository collaborator.
+func (s *RepoCollaboratorStore) Delete(ctx context.Context, key types.RepoCollaboratorKey) error {
+	const sqlQuery = `
+	DELETE from repository_collaborators
+	WHERE collaborator_repo_id = $1 AND
+	      collaborator_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, key.RepoID, key.PrincipalID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "delete repository collaborator query failed")
+	}
+	return nil
+}
+
+// CountByRole returns the number of collaborators of the repository with the provided role.
+func (s *RepoCollaboratorStore) CountByRole(
+	ctx context.Context,
+	repoID int64,
+	role enum.MembershipRole,
+) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM repository_collaborators
+	WHERE collaborator_repo_id = $1 AND collaborator_role = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, repoID, role).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing repository collaborator count query")
+	}
+
+	return count, nil
+}
+
+// ListUsers returns all collaborators of the repository, ordered by their display name.
+func (s *RepoCollaboratorStore) ListUsers(ctx context.Context, repoID int64) ([]types.RepoCollaboratorUser, error) {
+	const columns = repoCollaboratorColumns + "," + principalInfoCommonColumns
+	stmt := database.Builder.
+		Select(columns).
+		From("repository_collaborators").
+		InnerJoin("principals ON collaborator_principal_id = principal_id").
+		Where("collaborator_repo_id = ?", repoID).
+		OrderBy("principal_display_name ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert repository collaborator list query to sql: %w", err)
+	}
+
+	dst := make([]*repoCollaboratorPrincipal, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing repository collaborator list query")
+	}
+
+	// collect all principal IDs
+	ids := make([]int64, 0, len(dst))
+	for _, c := range dst {
+		ids = append(ids, c.repoCollaborator.CreatedBy)
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load repository collaborator principal infos: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	res := make([]types.RepoCollaboratorUser, len(dst))
+	for i := range dst {
+		c := dst[i]
+		res[i].RepoCollaborator = mapToRepoCollaborator(&c.repoCollaborator)
+		res[i].Principal = mapToPrincipalInfo(&c.principalInfo)
+		if addedBy, ok := infoMap[c.repoCollaborator.CreatedBy]; ok {
+			res[i].AddedBy = *addedBy
+		}
+	}
+
+	return res, nil
+}
+
+func mapToRepoCollaborator(c *repoCollaborator) types.RepoCollaborator {
+	return types.RepoCollaborator{
+		RepoCollaboratorKey: types.RepoCollaboratorKey{
+			RepoID:      c.RepoID,
+			PrincipalID: c.PrincipalID,
+		},
+		CreatedBy: c.CreatedBy,
+		Created:   c.Created,
+		Updated:   c.Updated,
+		Role:      c.Role,
+	}
+}
+
+func mapToInternalRepoCollaborator(c *types.RepoCollaborator) repoCollaborator {
+	return repoCollaborator{
+		RepoID:      c.RepoID,
+		PrincipalID: c.PrincipalID,
+		CreatedBy:   c.CreatedBy,
+		Created:     c.Created,
+		Updated:     c.Updated,
+		Role:        c.Role,
+	}
+}
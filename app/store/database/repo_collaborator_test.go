@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_RepoCollaborator(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	pCache := cache.ProvidePrincipalInfoCache(database.NewPrincipalInfoView(db))
+	collaboratorStore := database.NewRepoCollaboratorStore(db, pCache)
+
+	users := make([]*types.User, 0, 2)
+	for _, name := range []string{"Bob", "Alice"} {
+		user := &types.User{UID: name, DisplayName: name, Email: name + "@example.com"}
+		if err := principalStore.CreateUser(ctx, user); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+		users = append(users, user)
+
+		if err := collaboratorStore.Create(ctx, &types.RepoCollaborator{
+			RepoCollaboratorKey: types.RepoCollaboratorKey{RepoID: 1, PrincipalID: user.ID},
+			CreatedBy:           userID,
+			Role:                enum.MembershipRoleSpaceOwner,
+		}); err != nil {
+			t.Fatalf("failed to create collaborator: %v", err)
+		}
+	}
+
+	bobKey := types.RepoCollaboratorKey{RepoID: 1, PrincipalID: users[0].ID}
+
+	err := collaboratorStore.Create(ctx, &types.RepoCollaborator{RepoCollaboratorKey: bobKey})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("Want duplicate error, got %v", err)
+	}
+
+	if err = collaboratorStore.Update(ctx, &types.RepoCollaborator{
+		RepoCollaboratorKey: bobKey,
+		Role:                enum.MembershipRoleContributor,
+	}); err != nil {
+		t.Fatalf("failed to update collaborator: %v", err)
+	}
+
+	count, err := collaboratorStore.CountByRole(ctx, 1, enum.MembershipRoleSpaceOwner)
+	if err != nil {
+		t.Fatalf("failed to count collaborators: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Want 1 admin collaborator, got %d", count)
+	}
+
+	list, err := collaboratorStore.ListUsers(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to list collaborators: %v", err)
+	}
+	if len(list) != 2 || list[0].Principal.UID != "Alice" || list[1].Principal.UID != "Bob" {
+		t.Fatalf("Want collaborators [Alice Bob], got %+v", list)
+	}
+	if list[1].Role != enum.MembershipRoleContributor {
+		t.Errorf("Want role '%s', got '%s'", enum.MembershipRoleContributor, list[1].Role)
+	}
+	if list[1].AddedBy.ID != userID {
+		t.Errorf("Want added by %d, got %d", userID, list[1].AddedBy.ID)
+	}
+
+	if err = collaboratorStore.Delete(ctx, bobKey); err != nil {
+		t.Fatalf("failed to delete collaborator: %v", err)
+	}
+
+	if _, err = collaboratorStore.Find(ctx, bobKey); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Errorf("Want not found error, got %v", err)
+	}
+}
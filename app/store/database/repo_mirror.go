@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoMirrorStore = (*RepoMirrorStore)(nil)
+
+// NewRepoMirrorStore returns a new RepoMirrorStore.
+func NewRepoMirrorStore(db *sqlx.DB) *RepoMirrorStore {
+	return &RepoMirrorStore{db}
+}
+
+// RepoMirrorStore implements a RepoMirrorStore backed by a relational database.
+type RepoMirrorStore struct {
+	db *sqlx.DB
+}
+
+// repoMirror is an internal representation used to store repository mirror data in the database.
+type repoMirror struct {
+	RepoID        int64  `db:"mirror_repo_id"`
+	RemoteURL     string `db:"mirror_remote_url"`
+	Credentials   string `db:"mirror_credentials"`
+	CreatedBy     int64  `db:"mirror_created_by"`
+	Created       int64  `db:"mirror_created"`
+	Updated       int64  `db:"mirror_updated"`
+	LastSynced    int64  `db:"mirror_last_synced"`
+	LastSyncError string `db:"mirror_last_sync_error"`
+}
+
+// Find finds the mirror of the repository.
+func (s *RepoMirrorStore) Find(ctx context.Context, repoID int64) (*types.RepositoryMirror, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(repoMirror)
+	if err := db.GetContext(ctx, dst, repoMirrorSelectByRepoID, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find repository mirror")
+	}
+
+	return mapToRepoMirror(dst), nil
+}
+
+// Create saves the repository mirror.
+func (s *RepoMirrorStore) Create(ctx context.Context, mirror *types.RepositoryMirror) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(repoMirrorInsert, mapToInternalRepoMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repository mirror object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the repository mirror.
+func (s *RepoMirrorStore) Update(ctx context.Context, mirror *types.RepositoryMirror) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(repoMirrorUpdate, mapToInternalRepoMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repository mirror object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update query failed")
+	}
+
+	return nil
+}
+
+// List returns all repository mirrors.
+func (s *RepoMirrorStore) List(ctx context.Context) ([]*types.RepositoryMirror, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repoMirror{}
+	if err := db.SelectContext(ctx, &dst, repoMirrorSelectAll); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing repository mirror list query")
+	}
+
+	res := make([]*types.RepositoryMirror, len(dst))
+	for i := range dst {
+		res[i] = mapToRepoMirror(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToRepoMirror(m *repoMirror) *types.RepositoryMirror {
+	return &types.RepositoryMirror{
+		RepoID:        m.RepoID,
+		RemoteURL:     m.RemoteURL,
+		Credentials:   m.Credentials,
+		CreatedBy:     m.CreatedBy,
+		Created:       m.Created,
+		Updated:       m.Updated,
+		LastSynced:    m.LastSynced,
+		LastSyncError: m.LastSyncError,
+	}
+}
+
+func mapToInternalRepoMirror(m *types.RepositoryMirror) *repoMirror {
+	return &repoMirror{
+		RepoID:        m.RepoID,
+		RemoteURL:     m.RemoteURL,
+		Credentials:   m.Credentials,
+		CreatedBy:     m.CreatedBy,
+		Created:       m.Created,
+		Updated:       m.Updated,
+		LastSynced:    m.LastSynced,
+		LastSyncError: m.LastSyncError,
+	}
+}
+
+const repoMirrorSelectBase = `
+SELECT
+mirror_repo_id
+,mirror_remote_url
+,mirror_credentials
+,mirror_created_by
+,mirror_created
+,mirror_updated
+,mirror_last_synced
+,mirror_last_sync_error
+FROM repository_mirrors
+`
+
+const repoMirrorSelectByRepoID = repoMirrorSelectBase + `
+WHERE mirror_repo_id = $1
+`
+
+const repoMirrorSelectAll = repoMirrorSelectBase + `
+ORDER BY mirror_repo_id ASC
+`
+
+const repoMirrorInsert = `
+INSERT INTO repository_mirrors (
+	mirror_repo_id
+	,mirror_remote_url
+	,mirror_credentials
+	,mirror_created_by
+	,mirror_created
+	,mirror_updated
+	,mirror_last_synced
+	,mirror_last_sync_error
+) values (
+	:mirror_repo_id
+	,:mirror_remote_url
+	,:mirror_credentials
+	,:mirror_created_by
+	,:mirror_created
+	,:mirror_updated
+	,:mirror_last_synced
+	,:mirror_last_sync_error
+)
+`
+
+const repoMirrorUpdate = `
+UPDATE repository_mirrors
+SET
+	mirror_remote_url = :mirror_remote_url
+	,mirror_credentials = :mirror_credentials
+	,mirror_updated = :mirror_updated
+	,mirror_last_synced = :mirror_last_synced
+	,mirror_last_sync_error = :mirror_last_sync_error
+WHERE mirror_repo_id = :mirror_repo_id
+`
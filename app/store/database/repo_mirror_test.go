@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_RepoMirror(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	mirrorStore := database.NewRepoMirrorStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 1, 0)
+
+	if _, err := mirrorStore.Find(ctx, 1); !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		t.Fatalf("Want not found error for a repo that isn't a mirror, got %v", err)
+	}
+
+	mirror := &types.RepositoryMirror{
+		RepoID:      1,
+		RemoteURL:   "https://example.com/repo.git",
+		Credentials: "encrypted",
+		CreatedBy:   userID,
+		Created:     1,
+		Updated:     1,
+		LastSynced:  1,
+	}
+	if err := mirrorStore.Create(ctx, mirror); err != nil {
+		t.Fatalf("failed to create mirror: %v", err)
+	}
+
+	mirror.Updated = 2
+	mirror.LastSyncError = "remote not reachable"
+	if err := mirrorStore.Update(ctx, mirror); err != nil {
+		t.Fatalf("failed to update mirror: %v", err)
+	}
+
+	found, err := mirrorStore.Find(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to find mirror: %v", err)
+	}
+	if *found != *mirror {
+		t.Errorf("Want mirror %+v, got %+v", *mirror, *found)
+	}
+
+	list, err := mirrorStore.List(ctx)
+	if err != nil {
+		t.Fatalf("failed to list mirrors: %v", err)
+	}
+	if len(list) != 1 || list[0].RepoID != 1 {
+		t.Errorf("Want only the mirror of repo 1, got %v", list)
+	}
+}
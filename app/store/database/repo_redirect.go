@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoRedirectStore = (*RepoRedirectStore)(nil)
+
+// NewRepoRedirectStore returns a new RepoRedirectStore.
+func NewRepoRedirectStore(db *sqlx.DB) *RepoRedirectStore {
+	return &RepoRedirectStore{
+		db: db,
+	}
+}
+
+// RepoRedirectStore implements store.RepoRedirectStore backed by a relational database.
+type RepoRedirectStore struct {
+	db *sqlx.DB
+}
+
+type repoRedirect struct {
+	SpaceID    int64  `db:"redirect_space_id"`
+	Identifier string `db:"redirect_uid"`
+	RepoID     int64  `db:"redirect_repo_id"`
+	Created    int64  `db:"redirect_created"`
+	Expires    int64  `db:"redirect_expires"`
+}
+
+// Upsert creates or replaces the redirect for the space and identifier.
+func (s *RepoRedirectStore) Upsert(ctx context.Context, redirect *types.RepoRedirect) error {
+	const sqlQuery = `
+	INSERT INTO repository_redirects (
+		 redirect_space_id
+		,redirect_uid
+		,redirect_repo_id
+		,redirect_created
+		,redirect_expires
+	) values (
+		 :redirect_space_id
+		,:redirect_uid
+		,:redirect_repo_id
+		,:redirect_created
+		,:redirect_expires
+	)
+	ON CONFLICT (redirect_space_id, redirect_uid) DO UPDATE
+	SET
+		 redirect_repo_id = EXCLUDED.redirect_repo_id
+		,redirect_created = EXCLUDED.redirect_created
+		,redirect_expires = EXCLUDED.redirect_expires`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, repoRedirect{
+		SpaceID:    redirect.SpaceID,
+		Identifier: strings.ToLower(redirect.Identifier),
+		RepoID:     redirect.RepoID,
+		Created:    redirect.Created,
+		Expires:    redirect.Expires,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind repository redirect object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert repository redirect")
+	}
+
+	return nil
+}
+
+// Delete deletes the redirect for the space and identifier, if there is one.
+func (s *RepoRedirectStore) Delete(ctx context.Context, spaceID int64, identifier string) error {
+	const sqlQuery = `
+	DELETE FROM repository_redirects
+	WHERE redirect_space_id = $1 AND redirect_uid = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, spaceID, strings.ToLower(identifier)); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete repository redirect")
+	}
+
+	return nil
+}
@@ -17,11 +17,16 @@ package database_test
 import (
 	"context"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -150,6 +155,200 @@ func TestDatabase_List(t *testing.T) {
 	}
 }
 
+func TestDatabase_ListCursor(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	for i := int64(1); i <= numTestRepos; i++ {
+		identifier := "repo_" + strconv.FormatInt(i, 10)
+		repo := types.Repository{Identifier: identifier, ParentID: 1, GitUID: identifier, Created: 1000 + i}
+		if err := repoStore.Create(ctx, &repo); err != nil {
+			t.Fatalf("failed to create repo %v", err)
+		}
+	}
+
+	const pageSize = 3
+
+	var offsetIDs []int64
+	for page := 1; ; page++ {
+		repos, err := repoStore.List(ctx, 1, &types.RepoFilter{
+			Page:  page,
+			Size:  pageSize,
+			Sort:  enum.RepoAttrCreated,
+			Order: enum.OrderAsc,
+		})
+		if err != nil {
+			t.Fatalf("failed to list repos with offset %v", err)
+		}
+		for _, repo := range repos {
+			offsetIDs = append(offsetIDs, repo.ID)
+		}
+		if len(repos) < pageSize {
+			break
+		}
+	}
+
+	var cursorIDs []int64
+	cursor := types.Cursor{}
+	for {
+		repos, err := repoStore.List(ctx, 1, &types.RepoFilter{
+			Size:   pageSize,
+			Cursor: &cursor,
+		})
+		if err != nil {
+			t.Fatalf("failed to list repos with cursor %v", err)
+		}
+		for _, repo := range repos {
+			cursorIDs = append(cursorIDs, repo.ID)
+		}
+		if len(repos) < pageSize {
+			break
+		}
+
+		// round trip the cursor the same way a client would.
+		last := repos[len(repos)-1]
+		cursor, err = types.DecodeCursor(types.Cursor{Created: last.Created, ID: last.ID}.Encode())
+		if err != nil {
+			t.Fatalf("failed to decode cursor %v", err)
+		}
+	}
+
+	if len(offsetIDs) != numTestRepos {
+		t.Fatalf("offset count = %v, want %v", len(offsetIDs), numTestRepos)
+	}
+	if len(cursorIDs) != len(offsetIDs) {
+		t.Fatalf("cursor count = %v, want %v", len(cursorIDs), len(offsetIDs))
+	}
+	for i := range offsetIDs {
+		if cursorIDs[i] != offsetIDs[i] {
+			t.Errorf("cursor repo at %d = %v, want %v", i, cursorIDs[i], offsetIDs[i])
+		}
+	}
+}
+
+func TestDatabase_ListArchived(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	const numArchived = 3
+	for i := int64(1); i <= numTestRepos; i++ {
+		identifier := "repo_" + strconv.FormatInt(i, 10)
+		repo := types.Repository{Identifier: identifier, ParentID: 1, GitUID: identifier, Archived: i <= numArchived}
+		if err := repoStore.Create(ctx, &repo); err != nil {
+			t.Fatalf("failed to create repo %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		archived *bool
+		want     int
+	}{
+		{name: "no filter", archived: nil, want: numTestRepos},
+		{name: "archived only", archived: ptr.Bool(true), want: numArchived},
+		{name: "active only", archived: ptr.Bool(false), want: numTestRepos - numArchived},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos, err := repoStore.List(ctx, 1, &types.RepoFilter{Size: numTestRepos, Archived: tt.archived})
+			if err != nil {
+				t.Fatalf("failed to list repos %v", err)
+			}
+			if len(repos) != tt.want {
+				t.Errorf("count = %v, want %v", len(repos), tt.want)
+			}
+			for _, repo := range repos {
+				if tt.archived != nil && repo.Archived != *tt.archived {
+					t.Errorf("repo %s archived = %v, want %v", repo.Identifier, repo.Archived, *tt.archived)
+				}
+			}
+		})
+	}
+}
+
+func TestDatabase_ListTopics(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	repoTopics := map[string][]string{
+		"backend":  {"go", "api"},
+		"frontend": {"typescript", "ui"},
+		"gateway":  {"go", "ui"},
+		"golang":   {"golang"},
+		"untagged": nil,
+	}
+	for identifier, topics := range repoTopics {
+		repo := types.Repository{Identifier: identifier, ParentID: 1, GitUID: identifier, Topics: topics}
+		if err := repoStore.Create(ctx, &repo); err != nil {
+			t.Fatalf("failed to create repo %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		topics   []string
+		matchAny bool
+		want     []string
+	}{
+		{name: "single topic", topics: []string{"go"}, want: []string{"backend", "gateway"}},
+		{name: "multiple topics all", topics: []string{"go", "ui"}, want: []string{"gateway"}},
+		{name: "multiple topics any", topics: []string{"api", "ui"}, matchAny: true,
+			want: []string{"backend", "frontend", "gateway"}},
+		{name: "unknown topic", topics: []string{"rust"}, want: []string{}},
+		{name: "unknown topic any", topics: []string{"rust", "golang"}, matchAny: true, want: []string{"golang"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := &types.RepoFilter{
+				Size:           len(repoTopics),
+				Order:          enum.OrderAsc,
+				Topics:         tt.topics,
+				TopicsMatchAny: tt.matchAny,
+			}
+
+			repos, err := repoStore.List(ctx, 1, filter)
+			if err != nil {
+				t.Fatalf("failed to list repos %v", err)
+			}
+			count, err := repoStore.Count(ctx, 1, filter)
+			if err != nil {
+				t.Fatalf("failed to count repos %v", err)
+			}
+
+			identifiers := make([]string, len(repos))
+			for i, repo := range repos {
+				identifiers[i] = repo.Identifier
+			}
+			if !slices.Equal(identifiers, tt.want) || count != int64(len(tt.want)) {
+				t.Errorf("repos = %v (count %d), want %v", identifiers, count, tt.want)
+			}
+		})
+	}
+}
+
 func TestDatabase_ListAll(t *testing.T) {
 	db, teardown := setupDB(t)
 	defer teardown()
@@ -177,6 +376,66 @@ func TestDatabase_ListAll(t *testing.T) {
 	}
 }
 
+func TestDatabase_Search(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	numSpaces := createNestedSpaces(ctx, t, spaceStore, spacePathStore)
+	var numRepos int64
+	for i := 1; i <= numSpaces; i++ {
+		numRepos += createRepos(ctx, t, repoStore, numRepos, numTestRepos/2, int64(i))
+	}
+
+	// repos are spread across all spaces, e.g. "repo_1", "repo_10", "repo_11", ...
+	var wantCount int
+	for i := int64(0); i < numRepos; i++ {
+		if strings.Contains("repo_"+strconv.FormatInt(i, 10), "repo_1") {
+			wantCount++
+		}
+	}
+
+	repos, err := repoStore.Search(ctx, "REPO_1", 0, int(numRepos))
+	if err != nil {
+		t.Fatalf("failed to search repos %v", err)
+	}
+	if len(repos) != wantCount {
+		t.Fatalf("count = %v, want %v", len(repos), wantCount)
+	}
+	if repos[0].Identifier != "repo_1" {
+		t.Errorf("first result = %q, want %q", repos[0].Identifier, "repo_1")
+	}
+	for _, repo := range repos {
+		if repo.Path == "" {
+			t.Errorf("path of repo %q not set", repo.Identifier)
+		}
+	}
+
+	repos, err = repoStore.Search(ctx, "repo_1", 0, 2)
+	if err != nil {
+		t.Fatalf("failed to search repos %v", err)
+	}
+	if len(repos) != 2 {
+		t.Errorf("count = %v, want %v", len(repos), 2)
+	}
+
+	repos, err = repoStore.Search(ctx, "repo_1", 1, wantCount)
+	if err != nil {
+		t.Fatalf("failed to search repos %v", err)
+	}
+	if len(repos) != wantCount-1 {
+		t.Errorf("count = %v, want %v", len(repos), wantCount-1)
+	}
+	if len(repos) > 0 && repos[0].Identifier == "repo_1" {
+		t.Errorf("first result = %q, want it to be skipped", repos[0].Identifier)
+	}
+}
+
 func createRepo(
 	ctx context.Context,
 	t *testing.T,
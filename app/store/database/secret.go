@@ -42,6 +42,7 @@ const (
 	secret_id,
 	secret_description,
 	secret_space_id,
+	secret_repo_id,
 	secret_created_by,
 	secret_uid,
 	secret_data,
@@ -78,7 +79,7 @@ func (s *secretStore) Find(ctx context.Context, id int64) (*types.Secret, error)
 // FindByIdentifier returns a secret in a given space with a given identifier.
 func (s *secretStore) FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.Secret, error) {
 	const findQueryStmt = secretQueryBase + `
-		WHERE secret_space_id = $1 AND secret_uid = $2`
+		WHERE secret_space_id = $1 AND secret_repo_id IS NULL AND secret_uid = $2`
 	db := dbtx.GetAccessor(ctx, s.db)
 
 	dst := new(types.Secret)
@@ -95,6 +96,7 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 	INSERT INTO secrets (
 		secret_description,
 		secret_space_id,
+		secret_repo_id,
 		secret_created_by,
 		secret_uid,
 		secret_data,
@@ -104,6 +106,7 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 	) VALUES (
 		:secret_description,
 		:secret_space_id,
+		:secret_repo_id,
 		:secret_created_by,
 		:secret_uid,
 		:secret_data,
@@ -200,7 +203,8 @@ func (s *secretStore) List(ctx context.Context, parentID int64, filter types.Lis
 	stmt := database.Builder.
 		Select(secretColumns).
 		From("secrets").
-		Where("secret_space_id = ?", fmt.Sprint(parentID))
+		Where("secret_space_id = ?", fmt.Sprint(parentID)).
+		Where("secret_repo_id IS NULL")
 
 	if filter.Query != "" {
 		stmt = stmt.Where("LOWER(secret_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
@@ -229,7 +233,8 @@ func (s *secretStore) ListAll(ctx context.Context, parentID int64) ([]*types.Sec
 	stmt := database.Builder.
 		Select(secretColumns).
 		From("secrets").
-		Where("secret_space_id = ?", fmt.Sprint(parentID))
+		Where("secret_space_id = ?", fmt.Sprint(parentID)).
+		Where("secret_repo_id IS NULL")
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -267,7 +272,7 @@ func (s *secretStore) DeleteByIdentifier(ctx context.Context, spaceID int64, ide
 	//nolint:gosec // wrong flagging
 	const secretDeleteStmt = `
 	DELETE FROM secrets
-	WHERE secret_space_id = $1 AND secret_uid = $2`
+	WHERE secret_space_id = $1 AND secret_repo_id IS NULL AND secret_uid = $2`
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -283,7 +288,8 @@ func (s *secretStore) Count(ctx context.Context, parentID int64, filter types.Li
 	stmt := database.Builder.
 		Select("count(*)").
 		From("secrets").
-		Where("secret_space_id = ?", parentID)
+		Where("secret_space_id = ?", parentID).
+		Where("secret_repo_id IS NULL")
 
 	if filter.Query != "" {
 		stmt = stmt.Where("LOWER(secret_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
@@ -303,3 +309,102 @@ func (s *secretStore) Count(ctx context.Context, parentID int64, filter types.Li
 	}
 	return count, nil
 }
+
+// FindByRepoIdentifier returns a secret of a repository with a given identifier.
+func (s *secretStore) FindByRepoIdentifier(
+	ctx context.Context,
+	repoID int64,
+	identifier string,
+) (*types.Secret, error) {
+	const findQueryStmt = secretQueryBase + `
+		WHERE secret_repo_id = $1 AND secret_uid = $2`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(types.Secret)
+	if err := db.GetContext(ctx, dst, findQueryStmt, repoID, identifier); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find secret")
+	}
+	return dst, nil
+}
+
+// CountForRepo counts the secrets of a repository.
+func (s *secretStore) CountForRepo(ctx context.Context, repoID int64, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("secrets").
+		Where("secret_repo_id = ?", repoID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(secret_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed executing count query")
+	}
+	return count, nil
+}
+
+// ListForRepo lists the secrets of a repository.
+func (s *secretStore) ListForRepo(
+	ctx context.Context,
+	repoID int64,
+	filter types.ListQueryFilter,
+) ([]*types.Secret, error) {
+	stmt := database.Builder.
+		Select(secretColumns).
+		From("secrets").
+		Where("secret_repo_id = ?", repoID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(secret_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	stmt = stmt.OrderBy("secret_uid")
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*types.Secret{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	return dst, nil
+}
+
+// ListAllForRepo lists all the secrets of a repository.
+func (s *secretStore) ListAllForRepo(ctx context.Context, repoID int64) ([]*types.Secret, error) {
+	stmt := database.Builder.
+		Select(secretColumns).
+		From("secrets").
+		Where("secret_repo_id = ?", repoID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*types.Secret{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing custom list query")
+	}
+
+	return dst, nil
+}
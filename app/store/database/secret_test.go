@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_RepoSecrets(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+	secretStore := database.NewSecretStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 1, 0)
+
+	// the same identifier can be used by the space and each of its repos.
+	repo1, repo2 := int64(1), int64(2)
+	for _, secret := range []*types.Secret{
+		{SpaceID: 1, Identifier: "TOKEN", Data: "space", CreatedBy: userID},
+		{SpaceID: 1, RepoID: &repo1, Identifier: "TOKEN", Data: "repo1", CreatedBy: userID},
+		{SpaceID: 1, RepoID: &repo2, Identifier: "TOKEN", Data: "repo2", CreatedBy: userID},
+	} {
+		if err := secretStore.Create(ctx, secret); err != nil {
+			t.Fatalf("failed to create secret: %v", err)
+		}
+	}
+
+	if err := secretStore.Create(ctx, &types.Secret{SpaceID: 1, RepoID: &repo1, Identifier: "TOKEN",
+		CreatedBy: userID}); err == nil {
+		t.Errorf("Want error for duplicate repo secret, got none")
+	}
+
+	spaceSecret, err := secretStore.FindByIdentifier(ctx, 1, "TOKEN")
+	if err != nil {
+		t.Fatalf("failed to find space secret: %v", err)
+	}
+	if spaceSecret.Data != "space" || spaceSecret.RepoID != nil {
+		t.Errorf("Want space secret, got %+v", spaceSecret)
+	}
+
+	repoSecret, err := secretStore.FindByRepoIdentifier(ctx, 1, "TOKEN")
+	if err != nil {
+		t.Fatalf("failed to find repo secret: %v", err)
+	}
+	if repoSecret.Data != "repo1" || repoSecret.RepoID == nil || *repoSecret.RepoID != 1 {
+		t.Errorf("Want secret of repo 1, got %+v", repoSecret)
+	}
+
+	spaceSecrets, err := secretStore.ListAll(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to list space secrets: %v", err)
+	}
+	if len(spaceSecrets) != 1 || spaceSecrets[0].Data != "space" {
+		t.Errorf("Want only the space secret, got %v", spaceSecrets)
+	}
+
+	count, err := secretStore.CountForRepo(ctx, 2, types.ListQueryFilter{})
+	if err != nil {
+		t.Fatalf("failed to count repo secrets: %v", err)
+	}
+	repoSecrets, err := secretStore.ListForRepo(ctx, 2, types.ListQueryFilter{})
+	if err != nil {
+		t.Fatalf("failed to list repo secrets: %v", err)
+	}
+	if count != 1 || len(repoSecrets) != 1 || repoSecrets[0].Data != "repo2" {
+		t.Errorf("Want only the secret of repo 2, got %d %v", count, repoSecrets)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_SettingsSystemScope(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	settingsStore := database.NewSettingsStore(db)
+
+	ctx := context.Background()
+
+	for _, value := range []string{"true", "false"} {
+		if err := settingsStore.Upsert(ctx, enum.SettingsScopeSystem, 0, "maintenance_enabled",
+			json.RawMessage(value)); err != nil {
+			t.Fatalf("failed to upsert system setting %v", err)
+		}
+	}
+
+	value, err := settingsStore.Find(ctx, enum.SettingsScopeSystem, 0, "MAINTENANCE_ENABLED")
+	if err != nil {
+		t.Fatalf("failed to find system setting %v", err)
+	}
+	if string(value) != "false" {
+		t.Errorf("value = %s, want %s", value, "false")
+	}
+
+	values, err := settingsStore.FindMany(ctx, enum.SettingsScopeSystem, 0, "maintenance_enabled")
+	if err != nil {
+		t.Fatalf("failed to find system settings %v", err)
+	}
+	if len(values) != 1 {
+		t.Errorf("len(values) = %d, want %d", len(values), 1)
+	}
+}
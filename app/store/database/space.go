@@ -62,14 +62,15 @@ type space struct {
 	ID      int64 `db:"space_id"`
 	Version int64 `db:"space_version"`
 	// IMPORTANT: We need to make parentID optional for spaces to allow it to be a foreign key.
-	ParentID    null.Int `db:"space_parent_id"`
-	Identifier  string   `db:"space_uid"`
-	Description string   `db:"space_description"`
-	IsPublic    bool     `db:"space_is_public"`
-	CreatedBy   int64    `db:"space_created_by"`
-	Created     int64    `db:"space_created"`
-	Updated     int64    `db:"space_updated"`
-	Deleted     null.Int `db:"space_deleted"`
+	ParentID      null.Int `db:"space_parent_id"`
+	Identifier    string   `db:"space_uid"`
+	Description   string   `db:"space_description"`
+	IsPublic      bool     `db:"space_is_public"`
+	DefaultBranch string   `db:"space_default_branch"`
+	CreatedBy     int64    `db:"space_created_by"`
+	Created       int64    `db:"space_created"`
+	Updated       int64    `db:"space_updated"`
+	Deleted       null.Int `db:"space_deleted"`
 }
 
 const (
@@ -80,6 +81,7 @@ const (
 		,space_uid
 		,space_description
 		,space_is_public
+		,space_default_branch
 		,space_created_by
 		,space_created
 		,space_updated
@@ -234,6 +236,7 @@ func (s *SpaceStore) Create(ctx context.Context, space *types.Space) error {
 			,space_uid
 			,space_description
 			,space_is_public
+			,space_default_branch
 			,space_created_by
 			,space_created
 			,space_updated
@@ -244,6 +247,7 @@ func (s *SpaceStore) Create(ctx context.Context, space *types.Space) error {
 			,:space_uid
 			,:space_description
 			,:space_is_public
+			,:space_default_branch
 			,:space_created_by
 			,:space_created
 			,:space_updated
@@ -279,6 +283,7 @@ func (s *SpaceStore) Update(ctx context.Context, space *types.Space) error {
 			,space_uid			= :space_uid
 			,space_description	= :space_description
 			,space_is_public	= :space_is_public
+			,space_default_branch	= :space_default_branch
 			,space_deleted 		= :space_deleted
 		WHERE space_id = :space_id AND space_version = :space_version - 1`
 
@@ -559,6 +564,38 @@ func (s *SpaceStore) countAll(
 	return count, nil
 }
 
+// Search returns up to limit active spaces of all levels whose identifier contains the query,
+// shorter identifiers first.
+func (s *SpaceStore) Search(
+	ctx context.Context,
+	query string,
+	offset int,
+	limit int,
+) ([]*types.Space, error) {
+	stmt := database.Builder.
+		Select(spaceColumns).
+		From("spaces")
+
+	stmt = s.applyQueryFilter(stmt, &types.SpaceFilter{Query: query})
+	stmt = stmt.OrderBy("LENGTH(space_uid) ASC", "LOWER(space_uid) ASC", "space_id ASC")
+	stmt = stmt.Limit(uint64(limit))
+	stmt = stmt.Offset(uint64(offset))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*space
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing search query")
+	}
+
+	return s.mapToSpaces(ctx, s.db, dst)
+}
+
 // List returns a list of spaces under the parent space.
 func (s *SpaceStore) List(
 	ctx context.Context,
@@ -718,15 +755,16 @@ func mapToSpace(
 ) (*types.Space, error) {
 	var err error
 	res := &types.Space{
-		ID:          in.ID,
-		Version:     in.Version,
-		Identifier:  in.Identifier,
-		Description: in.Description,
-		IsPublic:    in.IsPublic,
-		Created:     in.Created,
-		CreatedBy:   in.CreatedBy,
-		Updated:     in.Updated,
-		Deleted:     in.Deleted.Ptr(),
+		ID:            in.ID,
+		Version:       in.Version,
+		Identifier:    in.Identifier,
+		Description:   in.Description,
+		IsPublic:      in.IsPublic,
+		DefaultBranch: in.DefaultBranch,
+		Created:       in.Created,
+		CreatedBy:     in.CreatedBy,
+		Updated:       in.Updated,
+		Deleted:       in.Deleted.Ptr(),
 	}
 
 	// Only overwrite ParentID if it's not a root space
@@ -779,15 +817,16 @@ func (s *SpaceStore) mapToSpaces(
 
 func mapToInternalSpace(s *types.Space) *space {
 	res := &space{
-		ID:          s.ID,
-		Version:     s.Version,
-		Identifier:  s.Identifier,
-		Description: s.Description,
-		IsPublic:    s.IsPublic,
-		Created:     s.Created,
-		CreatedBy:   s.CreatedBy,
-		Updated:     s.Updated,
-		Deleted:     null.IntFromPtr(s.Deleted),
+		ID:            s.ID,
+		Version:       s.Version,
+		Identifier:    s.Identifier,
+		Description:   s.Description,
+		IsPublic:      s.IsPublic,
+		DefaultBranch: s.DefaultBranch,
+		Created:       s.Created,
+		CreatedBy:     s.CreatedBy,
+		Updated:       s.Updated,
+		Deleted:       null.IntFromPtr(s.Deleted),
 	}
 
 	// Only overwrite ParentID if it's not a root space
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -42,23 +43,37 @@ type TokenStore struct {
 	db *sqlx.DB
 }
 
+// token is an internal representation used to store token data in the database.
+type token struct {
+	ID          int64          `db:"token_id"`
+	PrincipalID int64          `db:"token_principal_id"`
+	Type        enum.TokenType `db:"token_type"`
+	Identifier  string         `db:"token_uid"`
+	ExpiresAt   *int64         `db:"token_expires_at"`
+	IssuedAt    int64          `db:"token_issued_at"`
+	CreatedBy   int64          `db:"token_created_by"`
+	Generation  int64          `db:"token_generation"`
+	Scopes      string         `db:"token_scopes"`
+	LastUsed    *int64         `db:"token_last_used"`
+}
+
 // Find finds the token by id.
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token")
 	}
 
-	return dst, nil
+	return mapToToken(dst), nil
 }
 
 // FindByIdentifier finds the token by principalId and token identifier.
 func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, identifier string) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(
 		ctx,
 		dst,
@@ -69,14 +84,14 @@ func (s *TokenStore) FindByIdentifier(ctx context.Context, principalID int64, id
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find token by identifier")
 	}
 
-	return dst, nil
+	return mapToToken(dst), nil
 }
 
 // Create saves the token details.
 func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(tokenInsert, token)
+	query, arg, err := db.BindNamed(tokenInsert, mapToInternalToken(token))
 	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "Failed to bind token object")
 	}
@@ -92,10 +107,20 @@ func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 func (s *TokenStore) Delete(ctx context.Context, id int64) error {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	if _, err := db.ExecContext(ctx, tokenDelete, id); err != nil {
+	result, err := db.ExecContext(ctx, tokenDelete, id)
+	if err != nil {
 		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
 	}
 
+	n, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted tokens")
+	}
+
+	if n != 1 {
+		return gitness_store.ErrResourceNotFound
+	}
+
 	return nil
 }
 
@@ -153,7 +178,7 @@ func (s *TokenStore) List(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) ([]*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Token{}
+	dst := []*token{}
 
 	// TODO: custom filters / sorting for tokens.
 
@@ -161,7 +186,100 @@ func (s *TokenStore) List(ctx context.Context,
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing token list query")
 	}
-	return dst, nil
+	return mapToTokens(dst), nil
+}
+
+// ListActive returns all tokens of a principal that can still be used for authentication,
+// i.e. tokens that aren't expired, aren't revoked and aren't password reset tokens.
+func (s *TokenStore) ListActive(ctx context.Context,
+	principalID int64, generation int64, now time.Time) ([]*types.Token, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*token{}
+
+	err := db.SelectContext(ctx, &dst, tokenSelectActiveForPrincipalID,
+		principalID, generation, now.UnixMilli(), enum.TokenTypePasswordReset)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing active token list query")
+	}
+	return mapToTokens(dst), nil
+}
+
+// UpdateLastUsed sets the time at which the token was last used.
+func (s *TokenStore) UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, tokenUpdateLastUsed, lastUsed, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update last used time of token")
+	}
+
+	return nil
+}
+
+func mapToToken(t *token) *types.Token {
+	return &types.Token{
+		ID:          t.ID,
+		PrincipalID: t.PrincipalID,
+		Type:        t.Type,
+		Identifier:  t.Identifier,
+		ExpiresAt:   t.ExpiresAt,
+		IssuedAt:    t.IssuedAt,
+		CreatedBy:   t.CreatedBy,
+		Generation:  t.Generation,
+		Scopes:      tokenScopesFromString(t.Scopes),
+		LastUsed:    t.LastUsed,
+	}
+}
+
+func mapToTokens(tokens []*token) []*types.Token {
+	res := make([]*types.Token, len(tokens))
+	for i := range tokens {
+		res[i] = mapToToken(tokens[i])
+	}
+	return res
+}
+
+func mapToInternalToken(t *types.Token) *token {
+	return &token{
+		ID:          t.ID,
+		PrincipalID: t.PrincipalID,
+		Type:        t.Type,
+		Identifier:  t.Identifier,
+		ExpiresAt:   t.ExpiresAt,
+		IssuedAt:    t.IssuedAt,
+		CreatedBy:   t.CreatedBy,
+		Generation:  t.Generation,
+		Scopes:      tokenScopesToString(t.Scopes),
+		LastUsed:    t.LastUsed,
+	}
+}
+
+// tokenScopesSeparator defines the character that's used to join token scopes for storing them in the DB
+// ASSUMPTION: scopes are defined in an enum and don't contain ",".
+const tokenScopesSeparator = ","
+
+func tokenScopesFromString(scopesString string) []enum.TokenScope {
+	if scopesString == "" {
+		return nil
+	}
+
+	rawScopes := strings.Split(scopesString, tokenScopesSeparator)
+
+	scopes := make([]enum.TokenScope, len(rawScopes))
+	for i, rawScope := range rawScopes {
+		scopes[i] = enum.TokenScope(rawScope)
+	}
+
+	return scopes
+}
+
+func tokenScopesToString(scopes []enum.TokenScope) string {
+	rawScopes := make([]string, len(scopes))
+	for i := range scopes {
+		rawScopes[i] = string(scopes[i])
+	}
+
+	return strings.Join(rawScopes, tokenScopesSeparator)
 }
 
 const tokenSelectBase = `
@@ -173,6 +291,9 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_generation
+,token_scopes
+,token_last_used
 FROM tokens
 ` //#nosec G101
 
@@ -181,6 +302,14 @@ WHERE token_principal_id = $1 AND token_type = $2
 ORDER BY token_issued_at DESC
 ` //#nosec G101
 
+const tokenSelectActiveForPrincipalID = tokenSelectBase + `
+WHERE token_principal_id = $1
+AND token_generation >= $2
+AND (token_expires_at IS NULL OR token_expires_at >= $3)
+AND token_type <> $4
+ORDER BY token_issued_at DESC
+` //#nosec G101
+
 const tokenCountForPrincipalIDOfType = `
 SELECT count(*)
 FROM tokens
@@ -200,6 +329,12 @@ DELETE FROM tokens
 WHERE token_id = $1
 `
 
+const tokenUpdateLastUsed = `
+UPDATE tokens
+SET token_last_used = $1
+WHERE token_id = $2
+` //#nosec G101
+
 const tokenInsert = `
 INSERT INTO tokens (
 	token_type
@@ -208,6 +343,8 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_generation
+	,token_scopes
 ) values (
 	:token_type
 	,:token_uid
@@ -215,5 +352,7 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_generation
+	,:token_scopes
 ) RETURNING token_id
 `
@@ -0,0 +1,286 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserEmailStore = (*UserEmailStore)(nil)
+
+// NewUserEmailStore returns a new UserEmailStore.
+func NewUserEmailStore(db *sqlx.DB) *UserEmailStore {
+	return &UserEmailStore{db}
+}
+
+// UserEmailStore implements a UserEmailStore backed by a relational database.
+type UserEmailStore struct {
+	db *sqlx.DB
+}
+
+// userEmail is an internal representation used to store user email data in the database.
+type userEmail struct {
+	ID                int64  `db:"user_email_id"`
+	PrincipalID       int64  `db:"user_email_principal_id"`
+	Email             string `db:"user_email_address"`
+	Verified          bool   `db:"user_email_verified"`
+	VerificationToken string `db:"user_email_verification_token"`
+	Created           int64  `db:"user_email_created"`
+	Updated           int64  `db:"user_email_updated"`
+}
+
+// Find finds the user email by id.
+func (s *UserEmailStore) Find(ctx context.Context, id int64) (*types.UserEmail, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(userEmail)
+	if err := db.GetContext(ctx, dst, userEmailSelectByID, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user email")
+	}
+
+	return mapToUserEmail(dst), nil
+}
+
+// FindByEmail finds the user email by its (case-insensitive) address.
+func (s *UserEmailStore) FindByEmail(ctx context.Context, email string) (*types.UserEmail, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(userEmail)
+	if err := db.GetContext(ctx, dst, userEmailSelectByAddress, strings.ToLower(email)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find user email by address")
+	}
+
+	return mapToUserEmail(dst), nil
+}
+
+// Create saves the user email.
+func (s *UserEmailStore) Create(ctx context.Context, email *types.UserEmail) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(userEmailInsert, mapToInternalUserEmail(email))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user email object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&email.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the user email.
+func (s *UserEmailStore) Update(ctx context.Context, email *types.UserEmail) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(userEmailUpdate, mapToInternalUserEmail(email))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind user email object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Update query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the user email with the given id.
+func (s *UserEmailStore) Delete(ctx context.Context, id int64) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, userEmailDelete, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "The delete query failed")
+	}
+
+	return nil
+}
+
+// List returns all secondary emails of a user.
+func (s *UserEmailStore) List(ctx context.Context, principalID int64) ([]*types.UserEmail, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*userEmail{}
+	if err := db.SelectContext(ctx, &dst, userEmailSelectForPrincipalID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing user email list query")
+	}
+
+	res := make([]*types.UserEmail, len(dst))
+	for i := range dst {
+		res[i] = mapToUserEmail(dst[i])
+	}
+
+	return res, nil
+}
+
+// MapPrincipalIDs maps the provided emails (lower-cased) to the ids of the users owning them.
+// Primary emails of users and verified secondary emails are taken into account.
+func (s *UserEmailStore) MapPrincipalIDs(ctx context.Context, emails []string) (map[string]int64, error) {
+	lowerEmails := make([]string, len(emails))
+	for i := range emails {
+		lowerEmails[i] = strings.ToLower(emails[i])
+	}
+
+	primaryStmt := database.Builder.
+		Select("LOWER(principal_email)", "principal_id").
+		From("principals").
+		Where("principal_type = 'user'").
+		Where(squirrel.Eq{"LOWER(principal_email)": lowerEmails})
+
+	secondaryStmt := database.Builder.
+		Select("LOWER(user_email_address)", "user_email_principal_id").
+		From("user_emails").
+		Where("user_email_verified").
+		Where(squirrel.Eq{"LOWER(user_email_address)": lowerEmails})
+
+	result := make(map[string]int64, len(emails))
+	for _, stmt := range []squirrel.SelectBuilder{primaryStmt, secondaryStmt} {
+		if err := s.mapPrincipalIDs(ctx, stmt, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// mapPrincipalIDs adds the email to principal id mappings returned by the statement to the result.
+func (s *UserEmailStore) mapPrincipalIDs(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+	result map[string]int64,
+) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	sqlQuery, params, err := stmt.ToSql()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to generate map principal ids query")
+	}
+
+	rows, err := db.QueryContext(ctx, sqlQuery, params...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed executing map principal ids query")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var (
+			email       string
+			principalID int64
+		)
+		if err = rows.Scan(&email, &principalID); err != nil {
+			return database.ProcessSQLErrorf(ctx, err, "Failed to scan principal id")
+		}
+		result[email] = principalID
+	}
+
+	if err = rows.Err(); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed reading map principal ids rows")
+	}
+
+	return nil
+}
+
+func mapToUserEmail(e *userEmail) *types.UserEmail {
+	return &types.UserEmail{
+		ID:                e.ID,
+		PrincipalID:       e.PrincipalID,
+		Email:             e.Email,
+		Verified:          e.Verified,
+		VerificationToken: e.VerificationToken,
+		Created:           e.Created,
+		Updated:           e.Updated,
+	}
+}
+
+func mapToInternalUserEmail(e *types.UserEmail) *userEmail {
+	return &userEmail{
+		ID:                e.ID,
+		PrincipalID:       e.PrincipalID,
+		Email:             e.Email,
+		Verified:          e.Verified,
+		VerificationToken: e.VerificationToken,
+		Created:           e.Created,
+		Updated:           e.Updated,
+	}
+}
+
+const userEmailSelectBase = `
+SELECT
+user_email_id
+,user_email_principal_id
+,user_email_address
+,user_email_verified
+,user_email_verification_token
+,user_email_created
+,user_email_updated
+FROM user_emails
+`
+
+const userEmailSelectByID = userEmailSelectBase + `
+WHERE user_email_id = $1
+`
+
+const userEmailSelectByAddress = userEmailSelectBase + `
+WHERE LOWER(user_email_address) = $1
+`
+
+const userEmailSelectForPrincipalID = userEmailSelectBase + `
+WHERE user_email_principal_id = $1
+ORDER BY user_email_created ASC, user_email_id ASC
+`
+
+const userEmailDelete = `
+DELETE FROM user_emails
+WHERE user_email_id = $1
+`
+
+const userEmailInsert = `
+INSERT INTO user_emails (
+	user_email_principal_id
+	,user_email_address
+	,user_email_verified
+	,user_email_verification_token
+	,user_email_created
+	,user_email_updated
+) values (
+	:user_email_principal_id
+	,:user_email_address
+	,:user_email_verified
+	,:user_email_verification_token
+	,:user_email_created
+	,:user_email_updated
+) RETURNING user_email_id
+`
+
+const userEmailUpdate = `
+UPDATE user_emails
+SET
+	user_email_address = :user_email_address
+	,user_email_verified = :user_email_verified
+	,user_email_verification_token = :user_email_verification_token
+	,user_email_updated = :user_email_updated
+WHERE user_email_id = :user_email_id
+`
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestDatabase_UserEmails(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+	emailStore := database.NewUserEmailStore(db)
+
+	ctx := context.Background()
+
+	for _, u := range []*types.User{
+		{ID: 1, UID: "user_1", Email: "user_1@example.com"},
+		{ID: 2, UID: "user_2", Email: "user_2@example.com"},
+	} {
+		if err := principalStore.CreateUser(ctx, u); err != nil {
+			t.Fatalf("failed to create user %v", err)
+		}
+	}
+
+	verified := &types.UserEmail{PrincipalID: 1, Email: "Work@example.com", Verified: true, Created: 1}
+	unverified := &types.UserEmail{PrincipalID: 2, Email: "other@example.com", Created: 2}
+	for _, e := range []*types.UserEmail{verified, unverified} {
+		if err := emailStore.Create(ctx, e); err != nil {
+			t.Fatalf("failed to create user email %v", err)
+		}
+	}
+
+	err := emailStore.Create(ctx, &types.UserEmail{PrincipalID: 2, Email: "WORK@example.com"})
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("Want duplicate error for case-insensitive duplicate email, got %v", err)
+	}
+
+	found, err := emailStore.FindByEmail(ctx, "work@EXAMPLE.com")
+	if err != nil || found.ID != verified.ID {
+		t.Fatalf("Want email %d to be found, got %v (%v)", verified.ID, found, err)
+	}
+
+	ids, err := emailStore.MapPrincipalIDs(ctx, []string{
+		"USER_2@example.com", "work@example.com", "other@example.com", "unknown@example.com"})
+	if err != nil {
+		t.Fatalf("failed to map principal ids %v", err)
+	}
+
+	want := map[string]int64{"user_2@example.com": 2, "work@example.com": 1}
+	if len(ids) != len(want) {
+		t.Fatalf("Want %v, got %v", want, ids)
+	}
+	for email, id := range want {
+		if ids[email] != id {
+			t.Errorf("Want email %q to map to %d, got %d", email, id, ids[email])
+		}
+	}
+}
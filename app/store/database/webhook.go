@@ -27,6 +27,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
@@ -66,6 +67,7 @@ type webhook struct {
 	Enabled               bool        `db:"webhook_enabled"`
 	Insecure              bool        `db:"webhook_insecure"`
 	Triggers              string      `db:"webhook_triggers"`
+	BranchPattern         string      `db:"webhook_branch_pattern"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
 }
 
@@ -86,6 +88,7 @@ const (
 		,webhook_enabled
 		,webhook_insecure
 		,webhook_triggers
+		,webhook_branch_pattern
 		,webhook_latest_execution_result
 		,webhook_internal`
 
@@ -172,6 +175,7 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_enabled
 			,webhook_insecure
 			,webhook_triggers
+			,webhook_branch_pattern
 			,webhook_latest_execution_result
 			,webhook_internal
 		) values (
@@ -188,6 +192,7 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_enabled
 			,:webhook_insecure
 			,:webhook_triggers
+			,:webhook_branch_pattern
 			,:webhook_latest_execution_result
 			,:webhook_internal
 		) RETURNING webhook_id`
@@ -226,6 +231,7 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_enabled = :webhook_enabled
 			,webhook_insecure = :webhook_insecure
 			,webhook_triggers = :webhook_triggers
+			,webhook_branch_pattern = :webhook_branch_pattern
 			,webhook_latest_execution_result = :webhook_latest_execution_result
 			,webhook_internal = :webhook_internal
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
@@ -357,9 +363,7 @@ func (s *WebhookStore) Count(ctx context.Context, parentType enum.WebhookParent,
 		return 0, fmt.Errorf("webhook parent type '%s' is not supported", parentType)
 	}
 
-	if opts.Query != "" {
-		stmt = stmt.Where("LOWER(webhook_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
-	}
+	stmt = applyWebhookFilter(stmt, opts)
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -393,13 +397,7 @@ func (s *WebhookStore) List(ctx context.Context, parentType enum.WebhookParent,
 		return nil, fmt.Errorf("webhook parent type '%s' is not supported", parentType)
 	}
 
-	if opts.Query != "" {
-		stmt = stmt.Where("LOWER(webhook_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
-	}
-
-	if opts.SkipInternal {
-		stmt = stmt.Where("webhook_internal != ?", true)
-	}
+	stmt = applyWebhookFilter(stmt, opts)
 
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
@@ -445,6 +443,31 @@ func (s *WebhookStore) List(ctx context.Context, parentType enum.WebhookParent,
 	return res, nil
 }
 
+func applyWebhookFilter(stmt squirrel.SelectBuilder, opts *types.WebhookFilter) squirrel.SelectBuilder {
+	if opts.Query != "" {
+		stmt = stmt.Where("LOWER(webhook_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
+	}
+
+	if opts.SkipInternal {
+		stmt = stmt.Where("webhook_internal != ?", true)
+	}
+
+	if opts.Enabled != nil {
+		stmt = stmt.Where("webhook_enabled = ?", *opts.Enabled)
+	}
+
+	if opts.Trigger != "" {
+		// webhooks without triggers are subscribed to all triggers.
+		stmt = stmt.Where(squirrel.Or{
+			squirrel.Eq{"webhook_triggers": ""},
+			squirrel.Expr("'"+triggersSeparator+"' || webhook_triggers || '"+triggersSeparator+"' LIKE ?",
+				"%"+triggersSeparator+string(opts.Trigger)+triggersSeparator+"%"),
+		})
+	}
+
+	return stmt
+}
+
 func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 	res := &types.Webhook{
 		ID:         hook.ID,
@@ -461,6 +484,7 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
 		Triggers:              triggersFromString(hook.Triggers),
+		BranchPattern:         hook.BranchPattern,
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Internal:              hook.Internal,
 	}
@@ -497,6 +521,7 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
 		Triggers:              triggersToString(hook.Triggers),
+		BranchPattern:         hook.BranchPattern,
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Internal:              hook.Internal,
 	}
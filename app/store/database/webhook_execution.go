@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 )
@@ -285,3 +286,62 @@ func mapToWebhookExecutions(executions []*webhookExecution) []*types.WebhookExec
 
 	return m
 }
+
+// MapDeliveryStats returns the statistics of the executions of the webhooks created since the provided time.
+// Webhooks without executions are omitted.
+func (s *WebhookExecutionStore) MapDeliveryStats(
+	ctx context.Context,
+	webhookIDs []int64,
+	since time.Time,
+) (map[int64]types.WebhookDeliveryStats, error) {
+	if len(webhookIDs) == 0 {
+		return map[int64]types.WebhookDeliveryStats{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("webhook_execution_webhook_id", "count(*)").
+		Column(squirrel.Expr("sum(case when webhook_execution_result = ? then 1 else 0 end)",
+			enum.WebhookExecutionResultSuccess)).
+		From("webhook_executions").
+		Where(squirrel.Eq{"webhook_execution_webhook_id": webhookIDs}).
+		Where("webhook_execution_created >= ?", since.UnixMilli()).
+		GroupBy("webhook_execution_webhook_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to query webhook delivery stats")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[int64]types.WebhookDeliveryStats, len(webhookIDs))
+	for rows.Next() {
+		var (
+			webhookID int64
+			stats     types.WebhookDeliveryStats
+		)
+		if err = rows.Scan(&webhookID, &stats.Total, &stats.Succeeded); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan webhook delivery stats")
+		}
+
+		if stats.Total > 0 {
+			stats.SuccessRate = float64(stats.Succeeded) / float64(stats.Total)
+		}
+
+		result[webhookID] = stats
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read webhook delivery stats")
+	}
+
+	return result, nil
+}
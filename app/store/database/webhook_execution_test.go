@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_WebhookExecutions(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	webhookStore := database.NewWebhookStore(db)
+	executionStore := database.NewWebhookExecutionStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	hook := &types.Webhook{
+		ParentType: enum.WebhookParentSpace,
+		ParentID:   1,
+		CreatedBy:  userID,
+		Identifier: "hook",
+		URL:        "http://localhost/hook",
+		Enabled:    true,
+	}
+	if err := webhookStore.Create(ctx, hook); err != nil {
+		t.Fatalf("failed to create webhook %v", err)
+	}
+
+	now := time.Now()
+	old := &types.WebhookExecution{
+		WebhookID:   hook.ID,
+		TriggerType: enum.WebhookTriggerBranchCreated,
+		Result:      enum.WebhookExecutionResultRetriableError,
+		Created:     now.Add(-48 * time.Hour).UnixMilli(),
+		Request:     types.WebhookExecutionRequest{URL: hook.URL, Headers: "X-Old: 1", Body: "{}"},
+	}
+	recent := &types.WebhookExecution{
+		WebhookID:     hook.ID,
+		Retriggerable: true,
+		TriggerType:   enum.WebhookTriggerBranchUpdated,
+		Result:        enum.WebhookExecutionResultSuccess,
+		Created:       now.UnixMilli(),
+		Request:       types.WebhookExecutionRequest{URL: hook.URL, Headers: "X-New: 1", Body: `{"ref":"main"}`},
+		Response:      types.WebhookExecutionResponse{StatusCode: 200, Status: "200 OK", Body: "ok"},
+	}
+	for _, e := range []*types.WebhookExecution{old, recent} {
+		if err := executionStore.Create(ctx, e); err != nil {
+			t.Fatalf("failed to create webhook execution %v", err)
+		}
+	}
+
+	executions, err := executionStore.ListForWebhook(ctx, hook.ID, &types.WebhookExecutionFilter{Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("failed to list webhook executions %v", err)
+	}
+	if len(executions) != 2 {
+		t.Fatalf("Want 2 executions, got %d", len(executions))
+	}
+	if executions[0].ID != recent.ID || executions[1].ID != old.ID {
+		t.Errorf("Want newest execution first, got %d, %d", executions[0].ID, executions[1].ID)
+	}
+	if got := executions[0]; got.Request != recent.Request || got.Response != recent.Response ||
+		got.Result != recent.Result || !got.Retriggerable || got.Created != recent.Created {
+		t.Errorf("Want execution %+v, got %+v", recent, got)
+	}
+
+	n, err := executionStore.DeleteOld(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to delete old webhook executions %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Want 1 deleted execution, got %d", n)
+	}
+
+	executions, err = executionStore.ListForWebhook(ctx, hook.ID, &types.WebhookExecutionFilter{Page: 1, Size: 10})
+	if err != nil {
+		t.Fatalf("failed to list webhook executions %v", err)
+	}
+	if len(executions) != 1 || executions[0].ID != recent.ID {
+		t.Errorf("Want only execution %d to remain, got %v", recent.ID, executions)
+	}
+}
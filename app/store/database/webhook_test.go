@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDatabase_WebhookListFilter(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	webhookStore := database.NewWebhookStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	hooks := []*types.Webhook{
+		{Identifier: "branches", Enabled: true, Triggers: []enum.WebhookTrigger{
+			enum.WebhookTriggerBranchCreated, enum.WebhookTriggerBranchUpdated}},
+		{Identifier: "pullreqs", Enabled: true, Triggers: []enum.WebhookTrigger{enum.WebhookTriggerPullReqCreated}},
+		{Identifier: "disabled", Enabled: false, Triggers: []enum.WebhookTrigger{enum.WebhookTriggerBranchCreated}},
+		{Identifier: "all", Enabled: true},
+	}
+	for _, hook := range hooks {
+		hook.ParentType = enum.WebhookParentSpace
+		hook.ParentID = 1
+		hook.CreatedBy = userID
+		hook.URL = "http://localhost/" + hook.Identifier
+		if err := webhookStore.Create(ctx, hook); err != nil {
+			t.Fatalf("failed to create webhook %v", err)
+		}
+	}
+
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name   string
+		filter types.WebhookFilter
+		want   []string
+	}{
+		{name: "no filter", want: []string{"branches", "pullreqs", "disabled", "all"}},
+		{name: "enabled", filter: types.WebhookFilter{Enabled: &enabled}, want: []string{"branches", "pullreqs", "all"}},
+		{name: "disabled", filter: types.WebhookFilter{Enabled: &disabled}, want: []string{"disabled"}},
+		{name: "trigger", filter: types.WebhookFilter{Trigger: enum.WebhookTriggerBranchUpdated},
+			want: []string{"branches", "all"}},
+		{name: "enabled trigger", filter: types.WebhookFilter{Enabled: &enabled, Trigger: enum.WebhookTriggerBranchCreated},
+			want: []string{"branches", "all"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter := test.filter
+			filter.Page = 1
+			filter.Size = 10
+			filter.Sort = enum.WebhookAttrID
+			filter.Order = enum.OrderAsc
+
+			list, err := webhookStore.List(ctx, enum.WebhookParentSpace, 1, &filter)
+			if err != nil {
+				t.Fatalf("failed to list webhooks %v", err)
+			}
+
+			got := make([]string, len(list))
+			for i, hook := range list {
+				got[i] = hook.Identifier
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("Want webhooks %v, got %v", test.want, got)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("Want webhooks %v, got %v", test.want, got)
+					break
+				}
+			}
+
+			count, err := webhookStore.Count(ctx, enum.WebhookParentSpace, 1, &filter)
+			if err != nil {
+				t.Fatalf("failed to count webhooks %v", err)
+			}
+			if count != int64(len(test.want)) {
+				t.Errorf("Want count %d, got %d", len(test.want), count)
+			}
+		})
+	}
+}
+
+func TestDatabase_WebhookDeliveryStats(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+	webhookStore := database.NewWebhookStore(db)
+	executionStore := database.NewWebhookExecutionStore(db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+
+	hook := &types.Webhook{
+		ParentType: enum.WebhookParentSpace,
+		ParentID:   1,
+		CreatedBy:  userID,
+		Identifier: "hook",
+		URL:        "http://localhost/hook",
+		Enabled:    true,
+	}
+	if err := webhookStore.Create(ctx, hook); err != nil {
+		t.Fatalf("failed to create webhook %v", err)
+	}
+
+	now := time.Now()
+	for _, execution := range []*types.WebhookExecution{
+		{Result: enum.WebhookExecutionResultSuccess, Created: now.UnixMilli()},
+		{Result: enum.WebhookExecutionResultSuccess, Created: now.UnixMilli()},
+		{Result: enum.WebhookExecutionResultSuccess, Created: now.UnixMilli()},
+		{Result: enum.WebhookExecutionResultFatalError, Created: now.UnixMilli()},
+		{Result: enum.WebhookExecutionResultFatalError, Created: now.Add(-48 * time.Hour).UnixMilli()},
+	} {
+		execution.WebhookID = hook.ID
+		execution.TriggerType = enum.WebhookTriggerBranchCreated
+		if err := executionStore.Create(ctx, execution); err != nil {
+			t.Fatalf("failed to create webhook execution %v", err)
+		}
+	}
+
+	stats, err := executionStore.MapDeliveryStats(ctx, []int64{hook.ID, hook.ID + 1}, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to get webhook delivery stats %v", err)
+	}
+
+	want := types.WebhookDeliveryStats{Total: 4, Succeeded: 3, SuccessRate: 0.75}
+	if got := stats[hook.ID]; got != want {
+		t.Errorf("Want stats %+v, got %+v", want, got)
+	}
+	if _, ok := stats[hook.ID+1]; ok {
+		t.Errorf("Want no stats for webhook without executions")
+	}
+}
@@ -17,8 +17,10 @@ package database
 import (
 	"context"
 
+	"github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/database/migrate"
+	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/store/database"
 
@@ -44,6 +46,15 @@ var WireSet = wire.NewSet(
 	ProvideRepoGitInfoView,
 	ProvideMembershipStore,
 	ProvideTokenStore,
+	ProvidePublicKeyStore,
+	ProvideUserEmailStore,
+	ProvideRepoMirrorStore,
+	ProvideRepoCollaboratorStore,
+	ProvideRepoRedirectStore,
+	ProvideDeployKeyStore,
+	ProvideRepoActivityStore,
+	ProvideLFSObjectStore,
+	ProvideIdempotencyKeyStore,
 	ProvidePullReqStore,
 	ProvidePullReqActivityStore,
 	ProvideCodeCommentView,
@@ -58,6 +69,8 @@ var WireSet = wire.NewSet(
 	ProvideTemplateStore,
 	ProvideTriggerStore,
 	ProvidePluginStore,
+	ProvideAuditChainStore,
+	ProvideMigrationReporter,
 )
 
 // migrator is helper function to set up the database by performing automated
@@ -76,6 +89,11 @@ func ProvideDatabase(ctx context.Context, config database.Config) (*sqlx.DB, err
 	)
 }
 
+// ProvideMigrationReporter provides the reporter of the database migration status.
+func ProvideMigrationReporter(db *sqlx.DB) system.MigrationReporter {
+	return migrate.NewReporter(db)
+}
+
 // ProvidePrincipalStore provides a principal store.
 func ProvidePrincipalStore(db *sqlx.DB, uidTransformation store.PrincipalUIDTransformation) store.PrincipalStore {
 	return NewPrincipalStore(db, uidTransformation)
@@ -190,6 +208,38 @@ func ProvideTokenStore(db *sqlx.DB) store.TokenStore {
 	return NewTokenStore(db)
 }
 
+// ProvideUserEmailStore provides a user email store.
+func ProvideUserEmailStore(db *sqlx.DB) store.UserEmailStore {
+	return NewUserEmailStore(db)
+}
+
+// ProvidePublicKeyStore provides a public key store.
+func ProvidePublicKeyStore(db *sqlx.DB) store.PublicKeyStore {
+	return NewPublicKeyStore(db)
+}
+
+// ProvideDeployKeyStore provides a deploy key store.
+func ProvideDeployKeyStore(db *sqlx.DB) store.DeployKeyStore {
+	return NewDeployKeyStore(db)
+}
+
+// ProvideRepoActivityStore provides a repository activity store.
+func ProvideRepoActivityStore(db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.RepoActivityStore {
+	return NewRepoActivityStore(db, principalInfoCache)
+}
+
+// ProvideLFSObjectStore provides an LFS object store.
+func ProvideLFSObjectStore(db *sqlx.DB) store.LFSObjectStore {
+	return NewLFSObjectStore(db)
+}
+
+// ProvideIdempotencyKeyStore provides an idempotency key store.
+func ProvideIdempotencyKeyStore(db *sqlx.DB) store.IdempotencyKeyStore {
+	return NewIdempotencyKeyStore(db)
+}
+
 // ProvidePullReqStore provides a pull request store.
 func ProvidePullReqStore(db *sqlx.DB,
 	principalInfoCache store.PrincipalInfoCache,
@@ -247,3 +297,26 @@ func ProvideCheckStore(db *sqlx.DB,
 func ProvideSettingsStore(db *sqlx.DB) store.SettingsStore {
 	return NewSettingsStore(db)
 }
+
+// ProvideAuditChainStore provides an audit chain store.
+func ProvideAuditChainStore(db *sqlx.DB) audit.ChainStore {
+	return NewAuditChainStore(db)
+}
+
+// ProvideRepoCollaboratorStore provides a repository collaborator store.
+func ProvideRepoCollaboratorStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.RepoCollaboratorStore {
+	return NewRepoCollaboratorStore(db, principalInfoCache)
+}
+
+// ProvideRepoRedirectStore provides a repository redirect store.
+func ProvideRepoRedirectStore(db *sqlx.DB) store.RepoRedirectStore {
+	return NewRepoRedirectStore(db)
+}
+
+// ProvideRepoMirrorStore provides a repository mirror store.
+func ProvideRepoMirrorStore(db *sqlx.DB) store.RepoMirrorStore {
+	return NewRepoMirrorStore(db)
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var _ authz.Authorizer = (*Authorizer)(nil)
+
+// DenyFunc returns true if the permission on the resource is denied to the principal.
+type DenyFunc func(principal *types.Principal, scope *types.Scope, resource *types.Resource,
+	permission enum.Permission) bool
+
+// Authorizer permits every action, unless it's denied by Deny (if set).
+// All permission checks are recorded.
+type Authorizer struct {
+	Deny DenyFunc
+
+	mx     sync.Mutex
+	checks []types.PermissionCheck
+}
+
+func (a *Authorizer) Check(
+	ctx context.Context,
+	session *auth.Session,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	return a.CheckPrincipal(ctx, &session.Principal, scope, resource, permission)
+}
+
+func (a *Authorizer) CheckAll(
+	ctx context.Context,
+	session *auth.Session,
+	permissionChecks ...types.PermissionCheck,
+) (bool, error) {
+	for i := range permissionChecks {
+		p := permissionChecks[i]
+		if ok, err := a.Check(ctx, session, &p.Scope, &p.Resource, p.Permission); !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (a *Authorizer) CheckPrincipal(
+	_ context.Context,
+	principal *types.Principal,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	a.mx.Lock()
+	a.checks = append(a.checks, types.PermissionCheck{Scope: *scope, Resource: *resource, Permission: permission})
+	a.mx.Unlock()
+
+	if a.Deny != nil && a.Deny(principal, scope, resource, permission) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Checks returns all permission checks in the order they were made.
+func (a *Authorizer) Checks() []types.PermissionCheck {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+	return append([]types.PermissionCheck(nil), a.checks...)
+}
+
+// DenyAll denies every permission.
+func DenyAll(*types.Principal, *types.Scope, *types.Resource, enum.Permission) bool {
+	return true
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// RepoStore is an in-memory repo store, repositories are found by their id or their path.
+// Stored repositories are only modified by updates, callers always get a copy.
+type RepoStore struct {
+	store.RepoStore
+	Repos []*types.Repository
+}
+
+func NewRepoStore(repos ...*types.Repository) *RepoStore {
+	return &RepoStore{Repos: repos}
+}
+
+func (s *RepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	for _, r := range s.Repos {
+		if r.ID == id {
+			repo := *r
+			return &repo, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// FindByRef finds the repository by its path, or by its id if the ref is numeric.
+func (s *RepoStore) FindByRef(ctx context.Context, ref string) (*types.Repository, error) {
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		return s.Find(ctx, id)
+	}
+	for _, r := range s.Repos {
+		if strings.EqualFold(r.Path, ref) {
+			repo := *r
+			return &repo, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *RepoStore) UpdateOptLock(
+	_ context.Context,
+	repo *types.Repository,
+	mutateFn func(repository *types.Repository) error,
+) (*types.Repository, error) {
+	for i, r := range s.Repos {
+		if r.ID != repo.ID {
+			continue
+		}
+		updated := r.Clone()
+		if err := mutateFn(&updated); err != nil {
+			return nil, err
+		}
+		s.Repos[i] = &updated
+		result := updated
+		return &result, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// UnsafeDeterministicAckPhrase has to be configured to enable deterministic token issuance.
+const UnsafeDeterministicAckPhrase = "I understand that all issued tokens are predictable"
+
+// Generator generates the jwt of a stored token, signed with the provided secret.
+type Generator func(token *types.Token, secret string) (string, error)
+
+// DeterministicGenerator generates a jwt that only depends on the token type, id, principal and secret.
+// The issuance and expiration times are left out of the jwt, the expiration is enforced via the stored token.
+func DeterministicGenerator(token *types.Token, secret string) (string, error) {
+	stripped := *token
+	stripped.IssuedAt = 0
+	stripped.ExpiresAt = nil
+
+	return jwt.GenerateForToken(&stripped, secret)
+}
+
+// ReloadSigningKeys periodically reloads the signing keys from disk until the context is canceled.
+// This allows rotating the signing keys without a restart.
+func ReloadSigningKeys(ctx context.Context, keySet *jwt.KeySet, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := keySet.Reload(); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to reload token signing keys")
+			}
+		}
+	}
+}
+
+// newGenerator returns the generator for issuing tokens based on the provided config.
+// Deterministic issuance fails unless it's explicitly acknowledged and gitness only listens on loopback.
+func newGenerator(config *types.Config) (Generator, error) {
+	if !config.Token.UnsafeDeterministic {
+		return jwt.GenerateForToken, nil
+	}
+
+	if config.Token.UnsafeDeterministicAck != UnsafeDeterministicAckPhrase {
+		return nil, fmt.Errorf("deterministic token issuance requires the acknowledgement %q",
+			UnsafeDeterministicAckPhrase)
+	}
+
+	if err := checkLoopback(config.Server.HTTP.Host); err != nil {
+		return nil, fmt.Errorf("deterministic token issuance is only allowed on loopback: %w", err)
+	}
+
+	log.Warn().Msg("UNSAFE: deterministic token issuance is enabled - never use this setting in production!")
+
+	return DeterministicGenerator, nil
+}
+
+// checkLoopback ensures the server only binds to a loopback address.
+// The base url isn't relevant, it doesn't restrict the interfaces the server listens on.
+func checkLoopback(host string) error {
+	if host == "" {
+		return errors.New("the server listens on all interfaces")
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+
+	return fmt.Errorf("the server binds to %q, which isn't a loopback address", host)
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// tokenStoreFake assigns sequential ids to created tokens.
+type tokenStoreFake struct {
+	store.TokenStore
+	lastID int64
+}
+
+func (s *tokenStoreFake) Create(_ context.Context, token *types.Token) error {
+	s.lastID++
+	token.ID = s.lastID
+	return nil
+}
+
+func deterministicConfig() *types.Config {
+	config := &types.Config{}
+	config.Server.HTTP.Host = "127.0.0.1"
+	config.Token.UnsafeDeterministic = true
+	config.Token.UnsafeDeterministicAck = UnsafeDeterministicAckPhrase
+	return config
+}
+
+func setupIssuer(t *testing.T, config *types.Config) *Issuer {
+	t.Helper()
+
+	keySet, err := ProvideSigningKeys(config)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	issuer, err := ProvideIssuer(config, &tokenStoreFake{}, keySet)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	return issuer
+}
+
+func TestProvideIssuer_Deterministic(t *testing.T) {
+	issuer := setupIssuer(t, deterministicConfig())
+
+	user := &types.User{ID: 1, UID: "user", Salt: "user-salt"}
+	lifetime := time.Hour
+
+	const want = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+		"eyJpc3MiOiJHaXRuZXNzIiwicGlkIjoxLCJ0a24iOnsidHlwIjoicGF0IiwiaWQiOjF9fQ." +
+		"-KVzFhTKw1bFX1-IvNyv_IMDbhJgdOzx3_YE4_89KEo"
+
+	_, jwtToken, err := issuer.CreatePAT(context.Background(), user.ToPrincipal(), user,
+		"pat", &lifetime, nil)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if jwtToken != want {
+		t.Errorf("Want token %q, got %q", want, jwtToken)
+	}
+}
+
+func TestProvideIssuer_Default(t *testing.T) {
+	issuer := setupIssuer(t, &types.Config{})
+
+	user := &types.User{ID: 1, UID: "user", Salt: "user-salt"}
+
+	tkn, jwtToken, err := issuer.CreateUserSession(context.Background(), user, "session", time.Hour)
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	deterministic, _ := DeterministicGenerator(tkn, user.Salt)
+	if jwtToken == deterministic {
+		t.Errorf("Want default token issuance to include issuance and expiration times")
+	}
+	if tkn.Type != enum.TokenTypeSession {
+		t.Errorf("Want token type %q, got %q", enum.TokenTypeSession, tkn.Type)
+	}
+}
+
+func TestProvideIssuer_Guards(t *testing.T) {
+	missingAck := deterministicConfig()
+	missingAck.Token.UnsafeDeterministicAck = "yes"
+
+	// the default base url is localhost, but the server listens on all interfaces.
+	allInterfaces := deterministicConfig()
+	allInterfaces.URL.Base = "http://localhost:3000"
+	allInterfaces.Server.HTTP.Host = ""
+
+	remote := deterministicConfig()
+	remote.Server.HTTP.Host = "10.0.0.1"
+
+	hostname := deterministicConfig()
+	hostname.Server.HTTP.Host = "gitness.example.com"
+
+	for name, config := range map[string]*types.Config{
+		"missing ack":    missingAck,
+		"all interfaces": allInterfaces,
+		"remote":         remote,
+		"hostname":       hostname,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ProvideIssuer(config, &tokenStoreFake{}, jwt.NewKeySet(0)); err == nil {
+				t.Errorf("Want deterministic issuance to be rejected")
+			}
+		})
+	}
+}
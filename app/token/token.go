@@ -27,76 +27,184 @@ import (
 	"github.com/gotidy/ptr"
 )
 
-const (
-	// userSessionTokenLifeTime is the duration a login / register token is valid.
-	// NOTE: Users can list / delete session tokens via rest API if they want to cleanup earlier.
-	userSessionTokenLifeTime time.Duration = 30 * 24 * time.Hour // 30 days.
-)
+// Issuer issues tokens and stores them in the token store.
+type Issuer struct {
+	tokenStore store.TokenStore
+	keySet     *jwt.KeySet
+	generate   Generator
+}
+
+// NewIssuer returns a new issuer that signs tokens with the current key of the key set,
+// or with the provided generator in case the key set doesn't contain a current key.
+func NewIssuer(tokenStore store.TokenStore, keySet *jwt.KeySet, generate Generator) *Issuer {
+	return &Issuer{
+		tokenStore: tokenStore,
+		keySet:     keySet,
+		generate:   generate,
+	}
+}
+
+// SigningKeys returns the key set used for signing and verifying the issued tokens.
+func (i *Issuer) SigningKeys() *jwt.KeySet {
+	return i.keySet
+}
 
-func CreateUserSession(
+func (i *Issuer) CreateUserSession(
 	ctx context.Context,
-	tokenStore store.TokenStore,
 	user *types.User,
 	identifier string,
+	lifetime time.Duration,
 ) (*types.Token, string, error) {
 	principal := user.ToPrincipal()
-	return create(
+	return i.create(
 		ctx,
-		tokenStore,
 		enum.TokenTypeSession,
 		principal,
 		principal,
 		identifier,
-		ptr.Duration(userSessionTokenLifeTime),
+		ptr.Duration(lifetime),
+		nil,
+	)
+}
+
+// CreateUserSessionFromToken creates a new session for the user that's derived from an existing token.
+// The session inherits the scopes of the token and doesn't outlive it.
+func (i *Issuer) CreateUserSessionFromToken(
+	ctx context.Context,
+	user *types.User,
+	identifier string,
+	lifetime time.Duration,
+	source *types.Token,
+) (*types.Token, string, error) {
+	if source.ExpiresAt != nil {
+		if remaining := time.Until(time.UnixMilli(*source.ExpiresAt)); remaining < lifetime {
+			lifetime = remaining
+		}
+	}
+
+	principal := user.ToPrincipal()
+	return i.create(
+		ctx,
+		enum.TokenTypeSession,
+		principal,
+		principal,
+		identifier,
+		ptr.Duration(lifetime),
+		source.Scopes,
+	)
+}
+
+// CreatePasswordReset creates a new short-lived token that allows the user to reset the password.
+func (i *Issuer) CreatePasswordReset(
+	ctx context.Context,
+	user *types.User,
+	identifier string,
+	lifetime time.Duration,
+) (*types.Token, string, error) {
+	principal := user.ToPrincipal()
+	return i.create(
+		ctx,
+		enum.TokenTypePasswordReset,
+		principal,
+		principal,
+		identifier,
+		ptr.Duration(lifetime),
+		nil,
 	)
 }
 
-func CreatePAT(
+// CreateImpersonation creates a new short-lived token that allows the admin to act as the user.
+func (i *Issuer) CreateImpersonation(
+	ctx context.Context,
+	admin *types.Principal,
+	user *types.User,
+	identifier string,
+	lifetime time.Duration,
+) (*types.Token, string, error) {
+	return i.create(
+		ctx,
+		enum.TokenTypeImpersonation,
+		admin,
+		user.ToPrincipal(),
+		identifier,
+		ptr.Duration(lifetime),
+		nil,
+	)
+}
+
+func (i *Issuer) CreatePAT(
 	ctx context.Context,
-	tokenStore store.TokenStore,
 	createdBy *types.Principal,
 	createdFor *types.User,
 	identifier string,
 	lifetime *time.Duration,
+	scopes []enum.TokenScope,
 ) (*types.Token, string, error) {
-	return create(
+	return i.create(
 		ctx,
-		tokenStore,
 		enum.TokenTypePAT,
 		createdBy,
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		scopes,
 	)
 }
 
-func CreateSAT(
+func (i *Issuer) CreateSAT(
 	ctx context.Context,
-	tokenStore store.TokenStore,
 	createdBy *types.Principal,
 	createdFor *types.ServiceAccount,
 	identifier string,
 	lifetime *time.Duration,
+	scopes []enum.TokenScope,
 ) (*types.Token, string, error) {
-	return create(
+	return i.create(
 		ctx,
-		tokenStore,
 		enum.TokenTypeSAT,
 		createdBy,
 		createdFor.ToPrincipal(),
 		identifier,
 		lifetime,
+		scopes,
 	)
 }
 
-func create(
+// Validate returns an error in case the stored token can't be used (anymore) by the principal it was issued for.
+// The type of the token has to be checked by the caller.
+func Validate(tkn *types.Token, principal *types.Principal) error {
+	// protect against faked JWTs for other principals in case of single salt leak
+	if principal.ID != tkn.PrincipalID {
+		return fmt.Errorf("JWT was for principal %d while db token was for principal %d",
+			principal.ID, tkn.PrincipalID)
+	}
+
+	// enforce the expiration of the stored token as the jwt isn't guaranteed to contain it.
+	if tkn.ExpiresAt != nil && *tkn.ExpiresAt < time.Now().UnixMilli() {
+		return fmt.Errorf("token %d expired", tkn.ID)
+	}
+
+	// tokens issued before the principal revoked all its tokens are no longer valid.
+	if tkn.Generation < principal.TokenGeneration {
+		return fmt.Errorf("token %d was revoked (generation %d < %d)",
+			tkn.ID, tkn.Generation, principal.TokenGeneration)
+	}
+
+	if principal.Blocked {
+		return fmt.Errorf("principal %d of token %d is blocked", principal.ID, tkn.ID)
+	}
+
+	return nil
+}
+
+func (i *Issuer) create(
 	ctx context.Context,
-	tokenStore store.TokenStore,
 	tokenType enum.TokenType,
 	createdBy *types.Principal,
 	createdFor *types.Principal,
 	identifier string,
 	lifetime *time.Duration,
+	scopes []enum.TokenScope,
 ) (*types.Token, string, error) {
 	issuedAt := time.Now()
 
@@ -113,15 +221,22 @@ func create(
 		IssuedAt:    issuedAt.UnixMilli(),
 		ExpiresAt:   expiresAt,
 		CreatedBy:   createdBy.ID,
+		Generation:  createdFor.TokenGeneration,
+		Scopes:      scopes,
 	}
 
-	err := tokenStore.Create(ctx, &token)
+	err := i.tokenStore.Create(ctx, &token)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to store token in db: %w", err)
 	}
 
 	// create jwt token.
-	jwtToken, err := jwt.GenerateForToken(&token, createdFor.Salt)
+	var jwtToken string
+	if key, ok := i.keySet.Current(issuedAt); ok {
+		jwtToken, err = jwt.GenerateForTokenWithKey(&token, key)
+	} else {
+		jwtToken, err = i.generate(&token, createdFor.Salt)
+	}
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to create jwt token: %w", err)
 	}
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideSigningKeys,
+	ProvideIssuer,
+)
+
+// ProvideSigningKeys loads the key set used for signing and verifying tokens
+// (empty unless signing keys are configured).
+func ProvideSigningKeys(config *types.Config) (*jwt.KeySet, error) {
+	if config.Token.UnsafeDeterministic {
+		// signatures of the key set would differ between key rotations.
+		return jwt.NewKeySet(0), nil
+	}
+
+	keySet, err := jwt.LoadKeySet(config.Token.SigningKeysPath, config.Token.SigningKeysGracePeriod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load token signing keys: %w", err)
+	}
+
+	return keySet, nil
+}
+
+// ProvideIssuer provides the token issuer, it fails if deterministic issuance is enabled unsafely.
+func ProvideIssuer(
+	config *types.Config,
+	tokenStore store.TokenStore,
+	keySet *jwt.KeySet,
+) (*Issuer, error) {
+	generate, err := newGenerator(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIssuer(tokenStore, keySet, generate), nil
+}
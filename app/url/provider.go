@@ -48,6 +48,10 @@ type Provider interface {
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GenerateGITCloneURL(repoPath string) string
 
+	// GenerateGITCloneSSHURL generates the public git clone URL via SSH for the provided repo path.
+	// NOTE: url is empty in case no external SSH frontend is configured.
+	GenerateGITCloneSSHURL(repoPath string) string
+
 	// GenerateUIRepoURL returns the url for the UI screen of a repository.
 	GenerateUIRepoURL(repoPath string) string
 
@@ -68,6 +72,10 @@ type Provider interface {
 
 	// GetAPIProto returns the proto for the API hostname
 	GetAPIProto() string
+
+	// GenerateAPIRepoURL returns the public API url for the provided sub path of a repository
+	// (e.g. "raw/docs/logo.png").
+	GenerateAPIRepoURL(repoPath string, subPath string) string
 }
 
 // Provider provides the URLs of the gitness system.
@@ -87,6 +95,9 @@ type provider struct {
 	// NOTE: we store it as url.URL so we can derive clone URLS without errors.
 	gitURL *url.URL
 
+	// gitSSHURL stores the URL of the external git ssh frontend (nil if not configured).
+	gitSSHURL *url.URL
+
 	// uiURL stores the raw URL to the ui endpoints.
 	uiURL *url.URL
 }
@@ -96,6 +107,7 @@ func NewProvider(
 	containerURLRaw string,
 	apiURLRaw string,
 	gitURLRaw,
+	gitSSHURLRaw,
 	uiURLRaw string,
 ) (Provider, error) {
 	// remove trailing '/' to make usage easier
@@ -125,6 +137,14 @@ func NewProvider(
 		return nil, fmt.Errorf("provided gitURLRaw '%s' is invalid: %w", gitURLRaw, err)
 	}
 
+	var gitSSHURL *url.URL
+	if gitSSHURLRaw != "" {
+		gitSSHURL, err = url.Parse(strings.TrimRight(gitSSHURLRaw, "/"))
+		if err != nil {
+			return nil, fmt.Errorf("provided gitSSHURLRaw '%s' is invalid: %w", gitSSHURLRaw, err)
+		}
+	}
+
 	uiURL, err := url.Parse(uiURLRaw)
 	if err != nil {
 		return nil, fmt.Errorf("provided uiURLRaw '%s' is invalid: %w", uiURLRaw, err)
@@ -135,6 +155,7 @@ func NewProvider(
 		containerURL: containerURL,
 		apiURL:       apiURL,
 		gitURL:       gitURL,
+		gitSSHURL:    gitSSHURL,
 		uiURL:        uiURL,
 	}, nil
 }
@@ -161,6 +182,19 @@ func (p *provider) GenerateGITCloneURL(repoPath string) string {
 	return p.gitURL.JoinPath(repoPath).String()
 }
 
+func (p *provider) GenerateGITCloneSSHURL(repoPath string) string {
+	if p.gitSSHURL == nil {
+		return ""
+	}
+
+	repoPath = path.Clean(repoPath)
+	if !strings.HasSuffix(repoPath, GITSuffix) {
+		repoPath += GITSuffix
+	}
+
+	return p.gitSSHURL.JoinPath(repoPath).String()
+}
+
 func (p *provider) GenerateUIBuildURL(repoPath, pipelineIdentifier string, seqNumber int64) string {
 	return p.uiURL.JoinPath(repoPath, "pipelines",
 		pipelineIdentifier, "execution", strconv.Itoa(int(seqNumber))).String()
@@ -189,3 +223,13 @@ func (p *provider) GetGITHostname() string {
 func (p *provider) GetAPIProto() string {
 	return p.apiURL.Scheme
 }
+
+func (p *provider) GenerateAPIRepoURL(repoPath string, subPath string) string {
+	segments := strings.Split(strings.Trim(subPath, "/"), "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+
+	// the repo path is escaped as a single path segment, as expected by the api router.
+	return p.apiURL.String() + "/v1/repos/" + url.PathEscape(repoPath) + "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package url
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestProvideURLProvider_CloneURLs(t *testing.T) {
+	tests := []struct {
+		name        string
+		sshFrontend string
+		wantSSH     string
+	}{
+		{
+			name:        "ssh-frontend",
+			sshFrontend: "ssh://git@ssh.example.com:2222/",
+			wantSSH:     "ssh://git@ssh.example.com:2222/space/repo.git",
+		},
+		{
+			name:        "no-ssh-frontend",
+			sshFrontend: "",
+			wantSSH:     "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &types.Config{}
+			config.URL.Internal = "http://localhost:3000"
+			config.URL.Container = "http://host.docker.internal:3000"
+			config.URL.API = "https://gitness.example.com/api"
+			config.URL.Git = "https://git.example.com/"
+			config.URL.GitSSHFrontend = test.sshFrontend
+			config.URL.UI = "https://gitness.example.com"
+
+			provider, err := ProvideURLProvider(config)
+			if err != nil {
+				t.Fatalf("failed to create url provider: %s", err)
+			}
+
+			if got, want := provider.GenerateGITCloneURL("space/repo"), "https://git.example.com/space/repo.git"; got != want {
+				t.Errorf("Want clone url %q, got %q", want, got)
+			}
+			if got := provider.GenerateGITCloneSSHURL("space/repo"); got != test.wantSSH {
+				t.Errorf("Want ssh clone url %q, got %q", test.wantSSH, got)
+			}
+		})
+	}
+}
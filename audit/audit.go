@@ -36,11 +36,18 @@ const (
 	ActionCreated Action = "created"
 	ActionUpdated Action = "updated" // update default branch, switching default branch, updating description
 	ActionDeleted Action = "deleted"
+	// ActionImpersonated is recorded when an admin starts acting as another user.
+	ActionImpersonated Action = "impersonated"
+	// ActionActedAs is recorded for every request an admin makes while acting as another user.
+	ActionActedAs Action = "acted_as"
 )
 
+// RootSpacePath is the space path events are recorded under that aren't scoped to a space (e.g. users).
+const RootSpacePath = "/"
+
 func (a Action) Validate() error {
 	switch a {
-	case ActionCreated, ActionUpdated, ActionDeleted:
+	case ActionCreated, ActionUpdated, ActionDeleted, ActionImpersonated, ActionActedAs:
 		return nil
 	default:
 		return ErrActionUndefined
@@ -52,11 +59,13 @@ type ResourceType string
 const (
 	ResourceTypeRepository ResourceType = "repository"
 	ResourceTypeBranchRule ResourceType = "branch_rule"
+	ResourceTypeDeployKey  ResourceType = "deploy_key"
+	ResourceTypeUser       ResourceType = "user"
 )
 
 func (a ResourceType) Validate() error {
 	switch a {
-	case ResourceTypeRepository, ResourceTypeBranchRule:
+	case ResourceTypeRepository, ResourceTypeBranchRule, ResourceTypeDeployKey, ResourceTypeUser:
 		return nil
 	default:
 		return ErrResourceTypeUndefined
@@ -93,9 +102,10 @@ type DiffObject struct {
 type Event struct {
 	ID            string
 	Timestamp     int64
-	Action        Action          // example: ActionCreated
-	User          types.Principal // example: Admin
-	SpacePath     string          // example: /root/projects
+	Action        Action           // example: ActionCreated
+	User          types.Principal  // example: Admin
+	Impersonator  *types.Principal // example: Admin acting as User (nil if not impersonated)
+	SpacePath     string           // example: /root/projects
 	Resource      Resource
 	DiffObject    DiffObject
 	ClientIP      string
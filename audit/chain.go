@@ -0,0 +1,287 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// ChainEntry is a single persisted entry of a hash-chained audit stream.
+// Every entry contains the hash of the previous entry of the same stream.
+type ChainEntry struct {
+	Stream   string
+	Sequence int64
+	Payload  json.RawMessage
+	PrevHash string
+	Hash     string
+	Created  int64
+}
+
+// ChainCheckpoint anchors a stream whose oldest entries were pruned.
+// It contains the sequence and hash of the last pruned entry.
+type ChainCheckpoint struct {
+	Stream   string
+	Sequence int64
+	Hash     string
+}
+
+// ChainStore defines the storage of hash-chained audit entries.
+type ChainStore interface {
+	// Last returns the last entry of the stream or store.ErrResourceNotFound if the stream is empty.
+	Last(ctx context.Context, stream string) (*ChainEntry, error)
+
+	// Create stores a new entry or returns store.ErrDuplicate if the sequence already exists in the stream.
+	Create(ctx context.Context, entry *ChainEntry) error
+
+	// List returns all entries of the stream ordered by sequence.
+	List(ctx context.Context, stream string) ([]*ChainEntry, error)
+
+	// Checkpoint returns the checkpoint of the stream or store.ErrResourceNotFound if it was never pruned.
+	Checkpoint(ctx context.Context, stream string) (*ChainCheckpoint, error)
+
+	// DeleteOld removes the oldest entries of each stream that were created before the provided time,
+	// except the last entry of each stream. Before deleting entries the checkpoint of the stream is updated.
+	DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+}
+
+// ChainVerificationError is returned if the chain of a stream is broken.
+type ChainVerificationError struct {
+	Sequence int64
+	Reason   string
+}
+
+func (e *ChainVerificationError) Error() string {
+	return fmt.Sprintf("audit chain broken at sequence %d: %s", e.Sequence, e.Reason)
+}
+
+// ChainVerification contains the result of a stream verification.
+type ChainVerification struct {
+	Stream   string `json:"stream"`
+	Entries  int    `json:"entries"`
+	Valid    bool   `json:"valid"`
+	Sequence int64  `json:"sequence,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// ComputeHash returns the hash of an entry based on its content and the hash of the previous entry.
+func ComputeHash(stream string, sequence int64, prevHash string, payload []byte) string {
+	h := sha256.New()
+	h.Write([]byte(stream))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(sequence, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(prevHash))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyChain verifies that the provided entries (ordered by sequence) form an unbroken chain.
+// It detects altered entries (hash mismatch) as well as missing entries (sequence gap or link mismatch).
+// The chain starts at the first entry of the stream, or right after the checkpoint in case the stream was pruned.
+func VerifyChain(checkpoint *ChainCheckpoint, entries []*ChainEntry) error {
+	var (
+		lastSeq  int64
+		prevHash string
+	)
+	if checkpoint != nil {
+		lastSeq = checkpoint.Sequence
+		prevHash = checkpoint.Hash
+	}
+
+	for _, entry := range entries {
+		// entries covered by the checkpoint might not have been deleted yet.
+		if entry.Sequence < lastSeq {
+			continue
+		}
+		if entry.Sequence == lastSeq {
+			if entry.Hash != prevHash {
+				return &ChainVerificationError{
+					Sequence: entry.Sequence,
+					Reason:   "entry hash doesn't match the hash of the checkpoint",
+				}
+			}
+			continue
+		}
+
+		expectedSeq := lastSeq + 1
+		if entry.Sequence != expectedSeq {
+			return &ChainVerificationError{
+				Sequence: expectedSeq,
+				Reason:   fmt.Sprintf("entry is missing, found sequence %d instead", entry.Sequence),
+			}
+		}
+
+		if entry.PrevHash != prevHash {
+			return &ChainVerificationError{
+				Sequence: entry.Sequence,
+				Reason:   "previous hash doesn't match the hash of the previous entry",
+			}
+		}
+
+		if ComputeHash(entry.Stream, entry.Sequence, entry.PrevHash, entry.Payload) != entry.Hash {
+			return &ChainVerificationError{
+				Sequence: entry.Sequence,
+				Reason:   "entry hash doesn't match the entry content",
+			}
+		}
+
+		lastSeq = entry.Sequence
+		prevHash = entry.Hash
+	}
+
+	return nil
+}
+
+// chainAppendMaxAttempts defines how often appending an entry is retried in case
+// another writer extended the stream concurrently.
+const chainAppendMaxAttempts = 5
+
+// ChainService is an audit service that persists all events as a hash chain per space path.
+type ChainService struct {
+	store ChainStore
+}
+
+func NewChainService(store ChainStore) *ChainService {
+	return &ChainService{
+		store: store,
+	}
+}
+
+func (s *ChainService) Log(
+	ctx context.Context,
+	user types.Principal,
+	resource Resource,
+	action Action,
+	spacePath string,
+	options ...Option,
+) error {
+	event := Event{
+		Timestamp: time.Now().UnixMilli(),
+		Action:    action,
+		User:      user,
+		SpacePath: spacePath,
+		Resource:  resource,
+	}
+
+	if impersonator, ok := GetImpersonator(ctx); ok {
+		event.Impersonator = &impersonator
+	}
+
+	for _, opt := range options {
+		opt.Apply(&event)
+	}
+
+	if err := event.Validate(); err != nil {
+		return fmt.Errorf("invalid audit event: %w", err)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+
+	// The store guarantees uniqueness of the sequence per stream, so concurrent writers
+	// (even across instances) can't fork the chain - the loser re-reads the head and retries.
+	for attempt := 1; ; attempt++ {
+		err = s.append(ctx, spacePath, payload, event.Timestamp)
+		if !errors.Is(err, gitness_store.ErrDuplicate) {
+			return err
+		}
+		if attempt >= chainAppendMaxAttempts {
+			return fmt.Errorf("failed to store audit entry after %d attempts: %w", attempt, err)
+		}
+	}
+}
+
+// append adds a new entry on top of the current head of the stream.
+func (s *ChainService) append(ctx context.Context, stream string, payload []byte, created int64) error {
+	var (
+		sequence int64 = 1
+		prevHash string
+	)
+
+	last, err := s.store.Last(ctx, stream)
+	switch {
+	case errors.Is(err, gitness_store.ErrResourceNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to find last audit entry: %w", err)
+	default:
+		sequence = last.Sequence + 1
+		prevHash = last.Hash
+	}
+
+	entry := &ChainEntry{
+		Stream:   stream,
+		Sequence: sequence,
+		Payload:  payload,
+		PrevHash: prevHash,
+		Hash:     ComputeHash(stream, sequence, prevHash, payload),
+		Created:  created,
+	}
+
+	if err = s.store.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to store audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// Verify verifies the hash chain of the provided stream.
+func (s *ChainService) Verify(ctx context.Context, stream string) (*ChainVerification, error) {
+	entries, err := s.store.List(ctx, stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	// read the checkpoint after the entries, a concurrent pruning then only moves the checkpoint
+	// into the listed entries, which is covered by the verification.
+	checkpoint, err := s.store.Checkpoint(ctx, stream)
+	switch {
+	case errors.Is(err, gitness_store.ErrResourceNotFound):
+		checkpoint = nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to find audit checkpoint: %w", err)
+	}
+
+	result := &ChainVerification{
+		Stream:  stream,
+		Entries: len(entries),
+		Valid:   true,
+	}
+
+	var verr *ChainVerificationError
+	err = VerifyChain(checkpoint, entries)
+	switch {
+	case errors.As(err, &verr):
+		result.Valid = false
+		result.Sequence = verr.Sequence
+		result.Reason = verr.Reason
+	case err != nil:
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,341 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+const testStream = "space"
+
+type chainStoreFake struct {
+	entries     map[string][]*ChainEntry
+	checkpoints map[string]*ChainCheckpoint
+}
+
+func newChainStoreFake() *chainStoreFake {
+	return &chainStoreFake{
+		entries:     map[string][]*ChainEntry{},
+		checkpoints: map[string]*ChainCheckpoint{},
+	}
+}
+
+func (s *chainStoreFake) Last(_ context.Context, stream string) (*ChainEntry, error) {
+	entries := s.entries[stream]
+	if len(entries) == 0 {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return entries[len(entries)-1], nil
+}
+
+func (s *chainStoreFake) Create(_ context.Context, entry *ChainEntry) error {
+	for _, e := range s.entries[entry.Stream] {
+		if e.Sequence == entry.Sequence {
+			return gitness_store.ErrDuplicate
+		}
+	}
+	s.entries[entry.Stream] = append(s.entries[entry.Stream], entry)
+	return nil
+}
+
+// racingChainStoreFake simulates another writer that extends the stream
+// between reading the head and storing the new entry.
+type racingChainStoreFake struct {
+	*chainStoreFake
+	races int
+}
+
+func (s *racingChainStoreFake) Create(ctx context.Context, entry *ChainEntry) error {
+	if s.races > 0 {
+		s.races--
+		payload := []byte(`{"Action":"other"}`)
+		prevHash := ""
+		if last, err := s.chainStoreFake.Last(ctx, entry.Stream); err == nil {
+			prevHash = last.Hash
+		}
+		_ = s.chainStoreFake.Create(ctx, &ChainEntry{
+			Stream:   entry.Stream,
+			Sequence: entry.Sequence,
+			Payload:  payload,
+			PrevHash: prevHash,
+			Hash:     ComputeHash(entry.Stream, entry.Sequence, prevHash, payload),
+		})
+	}
+	return s.chainStoreFake.Create(ctx, entry)
+}
+
+func (s *chainStoreFake) List(_ context.Context, stream string) ([]*ChainEntry, error) {
+	return s.entries[stream], nil
+}
+
+func (s *chainStoreFake) Checkpoint(_ context.Context, stream string) (*ChainCheckpoint, error) {
+	checkpoint, ok := s.checkpoints[stream]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return checkpoint, nil
+}
+
+func (s *chainStoreFake) DeleteOld(_ context.Context, olderThan time.Time) (int64, error) {
+	var n int64
+	for stream, entries := range s.entries {
+		i := 0
+		for i < len(entries)-1 && entries[i].Created < olderThan.UnixMilli() {
+			i++
+		}
+		if i == 0 {
+			continue
+		}
+
+		last := entries[i-1]
+		s.checkpoints[stream] = &ChainCheckpoint{Stream: stream, Sequence: last.Sequence, Hash: last.Hash}
+		s.entries[stream] = entries[i:]
+		n += int64(i)
+	}
+	return n, nil
+}
+
+func setupChain(t *testing.T) (*ChainService, *chainStoreFake) {
+	t.Helper()
+
+	store := newChainStoreFake()
+	service := NewChainService(store)
+	user := types.Principal{ID: 1, UID: "admin"}
+
+	for i := 0; i < 3; i++ {
+		err := service.Log(context.Background(), user, NewResource(ResourceTypeRepository, "repo"),
+			ActionUpdated, testStream, WithData("index", string(rune('a'+i))))
+		if err != nil {
+			t.Fatalf("failed to log audit event: %s", err)
+		}
+	}
+
+	return service, store
+}
+
+func TestChainService_VerifyValid(t *testing.T) {
+	service, store := setupChain(t)
+
+	// entries of another stream must not affect the chain
+	err := service.Log(context.Background(), types.Principal{ID: 1, UID: "admin"},
+		NewResource(ResourceTypeRepository, "repo"), ActionCreated, "other")
+	if err != nil {
+		t.Fatalf("failed to log audit event: %s", err)
+	}
+
+	entries := store.entries[testStream]
+	if entries[0].PrevHash != "" {
+		t.Errorf("Want empty previous hash for the first entry, got %q", entries[0].PrevHash)
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].PrevHash != entries[i-1].Hash {
+			t.Errorf("Want entry %d to reference the previous entry hash", i)
+		}
+	}
+
+	result, err := service.Verify(context.Background(), testStream)
+	if err != nil {
+		t.Fatalf("failed to verify: %s", err)
+	}
+	if !result.Valid {
+		t.Errorf("Want valid chain, got invalid at %d: %s", result.Sequence, result.Reason)
+	}
+	if result.Entries != 3 {
+		t.Errorf("Want 3 entries, got %d", result.Entries)
+	}
+}
+
+func TestChainService_VerifyMutated(t *testing.T) {
+	service, store := setupChain(t)
+
+	store.entries[testStream][1].Payload = []byte(`{"Action":"deleted"}`)
+
+	result, err := service.Verify(context.Background(), testStream)
+	if err != nil {
+		t.Fatalf("failed to verify: %s", err)
+	}
+	if result.Valid {
+		t.Errorf("Want invalid chain for mutated entry")
+	}
+	if result.Sequence != 2 {
+		t.Errorf("Want chain broken at sequence 2, got %d", result.Sequence)
+	}
+}
+
+func TestChainService_VerifyRehashed(t *testing.T) {
+	service, store := setupChain(t)
+
+	// recomputing the hash of the mutated entry must still break the link to the next entry
+	entry := store.entries[testStream][1]
+	entry.Payload = []byte(`{"Action":"deleted"}`)
+	entry.Hash = ComputeHash(entry.Stream, entry.Sequence, entry.PrevHash, entry.Payload)
+
+	result, err := service.Verify(context.Background(), testStream)
+	if err != nil {
+		t.Fatalf("failed to verify: %s", err)
+	}
+	if result.Valid {
+		t.Errorf("Want invalid chain for rehashed entry")
+	}
+	if result.Sequence != 3 {
+		t.Errorf("Want chain broken at sequence 3, got %d", result.Sequence)
+	}
+}
+
+func TestChainService_VerifyMissing(t *testing.T) {
+	service, store := setupChain(t)
+
+	entries := store.entries[testStream]
+	store.entries[testStream] = []*ChainEntry{entries[0], entries[2]}
+
+	result, err := service.Verify(context.Background(), testStream)
+	if err != nil {
+		t.Fatalf("failed to verify: %s", err)
+	}
+	if result.Valid {
+		t.Errorf("Want invalid chain for missing entry")
+	}
+	if result.Sequence != 2 {
+		t.Errorf("Want chain broken at sequence 2, got %d", result.Sequence)
+	}
+}
+
+func TestChainService_VerifyPruned(t *testing.T) {
+	service, store := setupChain(t)
+
+	// prune the first entry as the retention cleanup would
+	store.entries[testStream][0].Created = time.Now().Add(-time.Hour).UnixMilli()
+	n, err := store.DeleteOld(context.Background(), time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("failed to delete old entries: %s", err)
+	}
+	if n != 1 {
+		t.Fatalf("Want 1 deleted entry, got %d", n)
+	}
+
+	result, err := service.Verify(context.Background(), testStream)
+	if err != nil {
+		t.Fatalf("failed to verify: %s", err)
+	}
+	if !result.Valid {
+		t.Errorf("Want valid chain after pruning, got invalid at %d: %s", result.Sequence, result.Reason)
+	}
+}
+
+func TestChainService_VerifyPrunedWithoutCheckpoint(t *testing.T) {
+	service, store := setupChain(t)
+
+	// deleting the oldest entries without moving the checkpoint must be detected.
+	store.entries[testStream] = store.entries[testStream][1:]
+
+	result, err := service.Verify(context.Background(), testStream)
+	if err != nil {
+		t.Fatalf("failed to verify: %s", err)
+	}
+	if result.Valid {
+		t.Errorf("Want invalid chain for pruned entries without checkpoint")
+	}
+	if result.Sequence != 1 {
+		t.Errorf("Want chain broken at sequence 1, got %d", result.Sequence)
+	}
+}
+
+func TestChainService_VerifyPrunedBeyondCheckpoint(t *testing.T) {
+	service, store := setupChain(t)
+
+	// prune the first entry as the retention cleanup would, then delete another one.
+	store.entries[testStream][0].Created = time.Now().Add(-time.Hour).UnixMilli()
+	if _, err := store.DeleteOld(context.Background(), time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("failed to delete old entries: %s", err)
+	}
+	store.entries[testStream] = store.entries[testStream][1:]
+
+	result, err := service.Verify(context.Background(), testStream)
+	if err != nil {
+		t.Fatalf("failed to verify: %s", err)
+	}
+	if result.Valid {
+		t.Errorf("Want invalid chain for entries deleted beyond the checkpoint")
+	}
+	if result.Sequence != 2 {
+		t.Errorf("Want chain broken at sequence 2, got %d", result.Sequence)
+	}
+}
+
+func TestChainService_LogImpersonated(t *testing.T) {
+	store := newChainStoreFake()
+	service := NewChainService(store)
+
+	admin := types.Principal{ID: 1, UID: "admin", Admin: true}
+	user := types.Principal{ID: 2, UID: "user"}
+	ctx := ContextWithImpersonator(context.Background(), admin)
+
+	err := service.Log(ctx, user, NewResource(ResourceTypeRepository, "repo"), ActionUpdated, testStream)
+	if err != nil {
+		t.Fatalf("failed to log audit event: %s", err)
+	}
+
+	var event Event
+	if err = json.Unmarshal(store.entries[testStream][0].Payload, &event); err != nil {
+		t.Fatalf("failed to unmarshal audit event: %s", err)
+	}
+	if event.User.UID != user.UID {
+		t.Errorf("Want user %q, got %q", user.UID, event.User.UID)
+	}
+	if event.Impersonator == nil || event.Impersonator.UID != admin.UID {
+		t.Errorf("Want impersonator %q, got %v", admin.UID, event.Impersonator)
+	}
+}
+
+func TestChainService_LogConcurrentWriter(t *testing.T) {
+	store := &racingChainStoreFake{chainStoreFake: newChainStoreFake(), races: 2}
+	service := NewChainService(store)
+
+	err := service.Log(context.Background(), types.Principal{ID: 1, UID: "admin"},
+		NewResource(ResourceTypeRepository, "repo"), ActionUpdated, testStream)
+	if err != nil {
+		t.Fatalf("failed to log audit event: %s", err)
+	}
+
+	if len(store.entries[testStream]) != 3 {
+		t.Fatalf("Want 3 entries, got %d", len(store.entries[testStream]))
+	}
+
+	result, err := service.Verify(context.Background(), testStream)
+	if err != nil {
+		t.Fatalf("failed to verify: %s", err)
+	}
+	if !result.Valid {
+		t.Errorf("Want valid chain, got invalid at %d: %s", result.Sequence, result.Reason)
+	}
+}
+
+func TestChainService_LogConcurrentWriterGivesUp(t *testing.T) {
+	store := &racingChainStoreFake{chainStoreFake: newChainStoreFake(), races: chainAppendMaxAttempts}
+	service := NewChainService(store)
+
+	err := service.Log(context.Background(), types.Principal{ID: 1, UID: "admin"},
+		NewResource(ResourceTypeRepository, "repo"), ActionUpdated, testStream)
+	if !errors.Is(err, gitness_store.ErrDuplicate) {
+		t.Errorf("Want %v, got %v", gitness_store.ErrDuplicate, err)
+	}
+}
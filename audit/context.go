@@ -14,7 +14,11 @@
 
 package audit
 
-import "context"
+import (
+	"context"
+
+	"github.com/harness/gitness/types"
+)
 
 type key int
 
@@ -22,6 +26,7 @@ const (
 	realIPKey key = iota
 	requestID
 	requestMethod
+	impersonatorKey
 )
 
 // GetRealIP returns IP address from context.
@@ -53,3 +58,14 @@ func GetRequestMethod(ctx context.Context) string {
 
 	return method
 }
+
+// ContextWithImpersonator returns a copy of the context with the admin acting as the user of the request.
+func ContextWithImpersonator(ctx context.Context, impersonator types.Principal) context.Context {
+	return context.WithValue(ctx, impersonatorKey, impersonator)
+}
+
+// GetImpersonator returns the admin acting as the user of the request from context.
+func GetImpersonator(ctx context.Context) (types.Principal, bool) {
+	impersonator, ok := ctx.Value(impersonatorKey).(types.Principal)
+	return impersonator, ok
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+var _ Service = (*ImpersonationRecorder)(nil)
+
+// ImpersonationRecorder records every event of a request made by an admin acting as another user
+// in the application log, independent of whether (and how) the wrapped service persists events.
+// Events of impersonations are additionally persisted with the provided impersonations service,
+// which is required if the wrapped service doesn't persist events (nil otherwise).
+type ImpersonationRecorder struct {
+	service        Service
+	impersonations Service
+}
+
+func NewImpersonationRecorder(service Service, impersonations Service) *ImpersonationRecorder {
+	return &ImpersonationRecorder{
+		service:        service,
+		impersonations: impersonations,
+	}
+}
+
+func (r *ImpersonationRecorder) Log(
+	ctx context.Context,
+	user types.Principal,
+	resource Resource,
+	action Action,
+	spacePath string,
+	options ...Option,
+) error {
+	impersonator, impersonated := GetImpersonator(ctx)
+	if impersonated {
+		log.Ctx(ctx).Info().
+			Str("audit.impersonator_uid", impersonator.UID).
+			Str("audit.user_uid", user.UID).
+			Str("audit.resource_type", string(resource.Type)).
+			Str("audit.resource_identifier", resource.Identifier).
+			Str("audit.action", string(action)).
+			Str("audit.space_path", spacePath).
+			Msg("admin acting as user")
+	}
+
+	if r.impersonations != nil && (impersonated || action == ActionImpersonated) {
+		if err := r.impersonations.Log(ctx, user, resource, action, spacePath, options...); err != nil {
+			return fmt.Errorf("failed to persist impersonation event: %w", err)
+		}
+	}
+
+	return r.service.Log(ctx, user, resource, action, spacePath, options...)
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestImpersonationRecorder_PersistsImpersonations(t *testing.T) {
+	store := newChainStoreFake()
+	service := NewImpersonationRecorder(New(), NewChainService(store))
+
+	admin := types.Principal{ID: 1, UID: "admin", Admin: true}
+	user := types.Principal{ID: 2, UID: "user"}
+	resource := NewResource(ResourceTypeUser, user.UID)
+
+	// regular events aren't persisted by the noop service.
+	err := service.Log(context.Background(), user, resource, ActionUpdated, RootSpacePath)
+	if err != nil {
+		t.Fatalf("failed to log audit event: %s", err)
+	}
+	if n := len(store.entries[RootSpacePath]); n != 0 {
+		t.Fatalf("Want regular event to not be persisted, got %d entries", n)
+	}
+
+	// the mint of an impersonation token is persisted.
+	err = service.Log(context.Background(), admin, resource, ActionImpersonated, RootSpacePath)
+	if err != nil {
+		t.Fatalf("failed to log audit event: %s", err)
+	}
+
+	// every event of an impersonated request is persisted.
+	ctx := ContextWithImpersonator(context.Background(), admin)
+	err = service.Log(ctx, user, resource, ActionActedAs, RootSpacePath)
+	if err != nil {
+		t.Fatalf("failed to log audit event: %s", err)
+	}
+
+	if n := len(store.entries[RootSpacePath]); n != 2 {
+		t.Errorf("Want impersonation events to be persisted, got %d entries", n)
+	}
+}
@@ -14,12 +14,26 @@
 
 package audit
 
-import "github.com/google/wire"
+import (
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
 
 var WireSet = wire.NewSet(
+	ProvideChainService,
 	ProvideAuditService,
 )
 
-func ProvideAuditService() Service {
-	return New()
+func ProvideChainService(store ChainStore) *ChainService {
+	return NewChainService(store)
+}
+
+// ProvideAuditService provides the audit service. Events of impersonations are always recorded
+// in the application log and persisted as hash chain, even if other events aren't persisted.
+func ProvideAuditService(config *types.Config, chainService *ChainService) Service {
+	if config.Audit.HashChain {
+		return NewImpersonationRecorder(chainService, nil)
+	}
+	return NewImpersonationRecorder(New(), chainService)
 }
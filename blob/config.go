@@ -21,6 +21,7 @@ type Provider string
 const (
 	ProviderGCS        Provider = "gcs"
 	ProviderFileSystem Provider = "filesystem"
+	ProviderS3         Provider = "s3"
 )
 
 type Config struct {
@@ -29,4 +30,18 @@ type Config struct {
 	KeyPath               string
 	TargetPrincipal       string
 	ImpersonationLifetime time.Duration
+
+	// S3 contains the configuration of S3 compatible blob stores.
+	S3 S3Config
+}
+
+type S3Config struct {
+	// Endpoint is the url of the S3 compatible service (empty for AWS S3).
+	Endpoint string
+	// Region is the region of the bucket.
+	Region string
+	// PathStyle forces path style addressing of the bucket (required by most S3 compatible services).
+	PathStyle bool
+	// SignedURLExpiry is the validity of signed URLs (signed URLs are disabled if zero).
+	SignedURLExpiry time.Duration
 }
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+)
+
+// testStoreRoundTrip verifies the full lifecycle of a file in the provided store.
+func testStoreRoundTrip(t *testing.T, store Store) {
+	t.Helper()
+
+	ctx := context.Background()
+	const filePath = "dir/sub/file.bin"
+
+	data := make([]byte, 256<<10)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatalf("failed to generate data: %v", err)
+	}
+
+	if _, err := store.Stat(ctx, filePath); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Want error %v for missing file, got %v", ErrNotFound, err)
+	}
+	if _, err := store.Download(ctx, filePath); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Want error %v for missing file, got %v", ErrNotFound, err)
+	}
+
+	// the file is provided as a stream that can't be rewound or measured upfront.
+	if err := store.Upload(ctx, io.MultiReader(bytes.NewReader(data)), filePath); err != nil {
+		t.Fatalf("failed to upload file: %v", err)
+	}
+
+	info, err := store.Stat(ctx, filePath)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if info.Size != int64(len(data)) {
+		t.Errorf("Want size %d, got %d", len(data), info.Size)
+	}
+
+	rc, err := store.Download(ctx, filePath)
+	if err != nil {
+		t.Fatalf("failed to download file: %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	_ = rc.Close()
+	if err != nil {
+		t.Fatalf("failed to read downloaded file: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Want downloaded file to match uploaded file")
+	}
+
+	if err = store.Delete(ctx, filePath); err != nil {
+		t.Fatalf("failed to delete file: %v", err)
+	}
+	if _, err = store.Stat(ctx, filePath); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Want error %v for deleted file, got %v", ErrNotFound, err)
+	}
+	if err = store.Delete(ctx, filePath); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Want error %v for deleting a missing file, got %v", ErrNotFound, err)
+	}
+}
+
+func TestFileSystemStore(t *testing.T) {
+	store, err := NewFileSystemStore(Config{Provider: ProviderFileSystem, Bucket: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	testStoreRoundTrip(t, store)
+
+	if _, err = store.GetSignedURL(context.Background(), "file"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Want error %v, got %v", ErrNotSupported, err)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 )
 
 var (
@@ -25,13 +26,30 @@ var (
 	ErrNotSupported = errors.New("not supported")
 )
 
+// FileInfo contains the metadata of a file in the blob store.
+type FileInfo struct {
+	Size     int64
+	Modified time.Time
+}
+
+// Store is the interface of the blob storage backends.
+// Files are always streamed to and from the backend and never loaded into memory as a whole.
 type Store interface {
-	// Upload uploads a file to the blob store.
+	// Upload uploads a file to the blob store, overwriting an existing file with the same path.
 	Upload(ctx context.Context, file io.Reader, filePath string) error
 
 	// GetSignedURL returns the URL for a file in the blob store.
 	GetSignedURL(ctx context.Context, filePath string) (string, error)
 
 	// Download returns a reader for a file in the blob store.
+	// ErrNotFound is returned if the file doesn't exist.
 	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// Stat returns the metadata of a file in the blob store.
+	// ErrNotFound is returned if the file doesn't exist.
+	Stat(ctx context.Context, filePath string) (*FileInfo, error)
+
+	// Delete deletes a file from the blob store.
+	// ErrNotFound is returned if the file doesn't exist.
+	Delete(ctx context.Context, filePath string) error
 }
@@ -0,0 +1,156 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3Store is a blob store backed by AWS S3 or any S3 compatible service.
+// Credentials are resolved using the default AWS credential chain (e.g. AWS_ACCESS_KEY_ID).
+type S3Store struct {
+	config  Config
+	client  *s3.S3
+	session *session.Session
+}
+
+func NewS3Store(cfg Config) (Store, error) {
+	awsConfig := &aws.Config{
+		S3ForcePathStyle: aws.Bool(cfg.S3.PathStyle),
+	}
+	if cfg.S3.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.S3.Endpoint)
+		awsConfig.DisableSSL = aws.Bool(!strings.HasPrefix(cfg.S3.Endpoint, "https://"))
+	}
+	if cfg.S3.Region != "" {
+		awsConfig.Region = aws.String(cfg.S3.Region)
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 session: %w", err)
+	}
+
+	return &S3Store{
+		config:  cfg,
+		client:  s3.New(sess),
+		session: sess,
+	}, nil
+}
+
+func (c *S3Store) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	// the uploader streams the file in parts, so it never has to be loaded into memory as a whole.
+	uploader := s3manager.NewUploader(c.session)
+	_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		ACL:    aws.String(s3.ObjectCannedACLPrivate),
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+		Body:   file,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write file to S3: %w", err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) GetSignedURL(_ context.Context, filePath string) (string, error) {
+	if c.config.S3.SignedURLExpiry <= 0 {
+		return "", ErrNotSupported
+	}
+
+	req, _ := c.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+
+	signedURL, err := req.Presign(c.config.S3.SignedURLExpiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to create signed URL for file: %s %w", filePath, err)
+	}
+
+	return signedURL, nil
+}
+
+func (c *S3Store) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	out, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if isS3NotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+
+	return out.Body, nil
+}
+
+func (c *S3Store) Stat(ctx context.Context, filePath string) (*FileInfo, error) {
+	out, err := c.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if isS3NotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attributes of file: %s from bucket: %s %w",
+			filePath, c.config.Bucket, err)
+	}
+
+	return &FileInfo{
+		Size:     aws.Int64Value(out.ContentLength),
+		Modified: aws.TimeValue(out.LastModified),
+	}, nil
+}
+
+func (c *S3Store) Delete(ctx context.Context, filePath string) error {
+	// S3 doesn't fail the deletion of a missing object, hence the explicit check.
+	if _, err := c.Stat(ctx, filePath); err != nil {
+		return err
+	}
+
+	_, err := c.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.config.Bucket),
+		Key:    aws.String(filePath),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+
+	return nil
+}
+
+func isS3NotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) {
+		return reqErr.StatusCode() == http.StatusNotFound
+	}
+
+	return false
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// s3Fake is a minimal in-memory S3 compatible server supporting path style object requests.
+type s3Fake struct {
+	mx      sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+func (f *s3Fake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket+"/")
+	if !ok {
+		http.Error(w, "unknown bucket", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		f.objects[key] = data
+		w.WriteHeader(http.StatusOK)
+
+	case http.MethodGet, http.MethodHead:
+		data, exists := f.objects[key]
+		if !exists {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				_, _ = io.WriteString(w, "<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>")
+			}
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "unsupported method", http.StatusMethodNotAllowed)
+	}
+}
+
+func setupS3Store(t *testing.T, signedURLExpiry time.Duration) Store {
+	t.Helper()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-key")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	server := httptest.NewServer(&s3Fake{bucket: "blobs", objects: map[string][]byte{}})
+	t.Cleanup(server.Close)
+
+	store, err := NewS3Store(Config{
+		Provider: ProviderS3,
+		Bucket:   "blobs",
+		S3: S3Config{
+			Endpoint:        server.URL,
+			Region:          "us-east-1",
+			PathStyle:       true,
+			SignedURLExpiry: signedURLExpiry,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	return store
+}
+
+func TestS3Store(t *testing.T) {
+	testStoreRoundTrip(t, setupS3Store(t, 0))
+}
+
+func TestS3Store_GetSignedURL(t *testing.T) {
+	ctx := context.Background()
+
+	if _, err := setupS3Store(t, 0).GetSignedURL(ctx, "file"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Want error %v without signed url expiry, got %v", ErrNotSupported, err)
+	}
+
+	signedURL, err := setupS3Store(t, time.Hour).GetSignedURL(ctx, "dir/file")
+	if err != nil {
+		t.Fatalf("failed to get signed url: %v", err)
+	}
+	if !strings.Contains(signedURL, "/blobs/dir/file?") || !strings.Contains(signedURL, "X-Amz-Signature=") {
+		t.Errorf("Want signed url for the file, got %q", signedURL)
+	}
+}
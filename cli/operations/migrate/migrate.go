@@ -32,6 +32,7 @@ import (
 func Register(app *kingpin.Application) {
 	cmd := app.Command("migrate", "database migration tool")
 	registerCurrent(cmd)
+	registerStatus(cmd)
 	registerTo(cmd)
 }
 
@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/repoactivity"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/blob"
@@ -37,7 +38,6 @@ import (
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/types"
 
-	"github.com/kelseyhightower/envconfig"
 	"golang.org/x/text/runes"
 	"golang.org/x/text/transform"
 	"golang.org/x/text/unicode/norm"
@@ -54,11 +54,16 @@ const (
 // host environment.
 func LoadConfig() (*types.Config, error) {
 	config := new(types.Config)
-	err := envconfig.Process("", config)
+	err := processEnv(config)
 	if err != nil {
 		return nil, err
 	}
 
+	err = validateCors(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cors config: %w", err)
+	}
+
 	config.InstanceID, err = getSanitizedMachineName()
 	if err != nil {
 		return nil, fmt.Errorf("unable to ensure that instance ID is set in config: %w", err)
@@ -98,6 +103,21 @@ func LoadConfig() (*types.Config, error) {
 	return config, nil
 }
 
+// validateCors ensures credentialed cross-origin requests are only allowed for exact origins.
+func validateCors(config *types.Config) error {
+	if !config.Cors.AllowCredentials {
+		return nil
+	}
+
+	for _, origin := range config.Cors.AllowedOrigins {
+		if strings.Contains(origin, "*") {
+			return fmt.Errorf("wildcard origin %q can't be used when credentials are allowed", origin)
+		}
+	}
+
+	return nil
+}
+
 //nolint:gocognit // refactor if required
 func backfillURLs(config *types.Config) error {
 	// default base url
@@ -257,6 +277,12 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 		KeyPath:               config.BlobStore.KeyPath,
 		TargetPrincipal:       config.BlobStore.TargetPrincipal,
 		ImpersonationLifetime: config.BlobStore.ImpersonationLifetime,
+		S3: blob.S3Config{
+			Endpoint:        config.BlobStore.S3.Endpoint,
+			Region:          config.BlobStore.S3.Region,
+			PathStyle:       config.BlobStore.S3.PathStyle,
+			SignedURLExpiry: config.BlobStore.S3.SignedURLExpiry,
+		},
 	}, nil
 }
 
@@ -345,6 +371,8 @@ func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime:   config.Webhook.RetentionTime,
 		DeletedRepositoriesRetentionTime: config.Repos.DeletedRetentionTime,
+		IdempotencyKeyTTL:                config.Idempotency.KeyTTL,
+		AuditEntriesRetentionTime:        config.Audit.RetentionTime,
 	}
 }
 
@@ -364,6 +392,15 @@ func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 	}
 }
 
+// ProvideRepoActivityConfig loads the repo activity service config from the main config.
+func ProvideRepoActivityConfig(config *types.Config) repoactivity.Config {
+	return repoactivity.Config{
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.RepoActivity.Concurrency,
+		MaxRetries:      config.RepoActivity.MaxRetries,
+	}
+}
+
 func ProvideJobsConfig(config *types.Config) job.Config {
 	return job.Config{
 		InstanceID:                  config.InstanceID,
@@ -216,3 +216,79 @@ func TestBackfillURLsCustom(t *testing.T) {
 	require.Equal(t, "https://Git:443/Git/p", config.URL.Git)
 	require.Equal(t, "http://UI:80/UI/p", config.URL.UI)
 }
+
+func TestValidateCors(t *testing.T) {
+	config := &types.Config{}
+	config.Cors.AllowedOrigins = []string{"*"}
+
+	require.NoError(t, validateCors(config))
+
+	config.Cors.AllowCredentials = true
+	require.ErrorContains(t, validateCors(config), "wildcard origin \"*\" can't be used when credentials are allowed")
+
+	config.Cors.AllowedOrigins = []string{"https://*.example.com"}
+	require.Error(t, validateCors(config))
+
+	config.Cors.AllowedOrigins = []string{"https://app.example.com"}
+	require.NoError(t, validateCors(config))
+}
+
+type testEnvConfig struct {
+	Name    string `envconfig:"GITNESS_TEST_NAME" required:"true"`
+	Port    int    `envconfig:"GITNESS_TEST_PORT" default:"3000"`
+	Enabled bool   `envconfig:"GITNESS_TEST_ENABLED" default:"true"`
+	URL     struct {
+		Base string `envconfig:"GITNESS_TEST_URL_BASE"`
+	}
+}
+
+func TestProcessEnvExpansion(t *testing.T) {
+	t.Setenv("GITNESS_TEST_HOST", "gitness.example.com")
+	t.Setenv("GITNESS_TEST_NAME", "gitness")
+	t.Setenv("GITNESS_TEST_URL_BASE", "https://${GITNESS_TEST_HOST}:${GITNESS_TEST_PORT_EXTERNAL}/base")
+	t.Setenv("GITNESS_TEST_PORT_EXTERNAL", "8443")
+
+	config := &testEnvConfig{}
+	err := processEnv(config)
+	require.NoError(t, err)
+
+	require.Equal(t, "gitness", config.Name)
+	require.Equal(t, "https://gitness.example.com:8443/base", config.URL.Base)
+	require.Equal(t, 3000, config.Port)
+	require.True(t, config.Enabled)
+}
+
+func TestProcessEnvMissingRequired(t *testing.T) {
+	config := &testEnvConfig{}
+	err := processEnv(config)
+	require.ErrorContains(t, err, "required key GITNESS_TEST_NAME missing value")
+}
+
+func TestProcessEnvUnsetReference(t *testing.T) {
+	t.Setenv("GITNESS_TEST_NAME", "gitness")
+	t.Setenv("GITNESS_TEST_URL_BASE", "https://${GITNESS_TEST_UNSET_HOST}")
+
+	config := &testEnvConfig{}
+	err := processEnv(config)
+	require.ErrorContains(t, err, "GITNESS_TEST_URL_BASE references unset environment variables [GITNESS_TEST_UNSET_HOST]")
+}
+
+func TestProcessEnvTypeMismatch(t *testing.T) {
+	t.Setenv("GITNESS_TEST_NAME", "gitness")
+	t.Setenv("GITNESS_TEST_PORT", "abc")
+
+	config := &testEnvConfig{}
+	err := processEnv(config)
+	require.ErrorContains(t, err, "assigning GITNESS_TEST_PORT to Port")
+}
+
+func TestProcessEnvAggregatesErrors(t *testing.T) {
+	t.Setenv("GITNESS_TEST_PORT", "abc")
+	t.Setenv("GITNESS_TEST_ENABLED", "maybe")
+
+	config := &testEnvConfig{}
+	err := processEnv(config)
+	require.ErrorContains(t, err, "required key GITNESS_TEST_NAME missing value")
+	require.ErrorContains(t, err, "assigning GITNESS_TEST_PORT to Port")
+	require.ErrorContains(t, err, "assigning GITNESS_TEST_ENABLED to Enabled")
+}
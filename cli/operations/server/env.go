@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// envReferenceRegex matches ${VAR} references in environment variable values.
+var envReferenceRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// envField describes a single field of a config struct that's loaded from an environment variable.
+type envField struct {
+	name string
+	key  string
+	typ  reflect.Type
+	tag  reflect.StructTag
+}
+
+// processEnv populates the provided config struct from environment variables.
+// Before the config is loaded, all ${VAR} references in the values of the config's environment
+// variables are expanded (and updated in place in the process environment).
+// In contrast to envconfig.Process, processEnv doesn't stop at the first problem, but returns
+// an aggregated error listing every missing required or invalid setting.
+func processEnv(spec interface{}) error {
+	specType := reflect.TypeOf(spec)
+	if specType.Kind() != reflect.Pointer || specType.Elem().Kind() != reflect.Struct {
+		return errors.New("config spec must be a pointer to a struct")
+	}
+
+	var errs []error
+	for _, field := range getEnvFields(specType.Elem()) {
+		if err := expandEnvVar(field.key); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// validate each field in isolation so that all invalid fields get reported.
+		fieldSpec := reflect.New(reflect.StructOf([]reflect.StructField{{
+			Name: field.name,
+			Type: field.typ,
+			Tag:  field.tag,
+		}}))
+		if err := envconfig.Process("", fieldSpec.Interface()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+
+	return envconfig.Process("", spec)
+}
+
+// getEnvFields returns all fields of the struct type (including fields of nested structs)
+// that are loaded from an environment variable.
+func getEnvFields(typ reflect.Type) []envField {
+	var fields []envField
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key, ok := field.Tag.Lookup("envconfig")
+		if !ok {
+			if field.Type.Kind() == reflect.Struct {
+				fields = append(fields, getEnvFields(field.Type)...)
+			}
+			continue
+		}
+
+		fields = append(fields, envField{
+			name: field.Name,
+			key:  key,
+			typ:  field.Type,
+			tag:  field.Tag,
+		})
+	}
+
+	return fields
+}
+
+// expandEnvVar replaces all ${VAR} references in the value of the environment variable with the given key.
+// An error is returned if any of the referenced environment variables isn't set.
+func expandEnvVar(key string) error {
+	value, ok := os.LookupEnv(key)
+	if !ok || !envReferenceRegex.MatchString(value) {
+		return nil
+	}
+
+	var missing []string
+	expanded := envReferenceRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envReferenceRegex.FindStringSubmatch(ref)[1]
+		v, set := os.LookupEnv(name)
+		if !set {
+			missing = append(missing, name)
+		}
+		return v
+	})
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%s references unset environment variables %v", key, missing)
+	}
+
+	if err := os.Setenv(key, expanded); err != nil {
+		return fmt.Errorf("failed to set expanded value of %s: %w", key, err)
+	}
+
+	return nil
+}
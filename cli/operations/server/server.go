@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/pipeline/logger"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/profiler"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/version"
@@ -100,6 +101,11 @@ func (c *command) run(*kingpin.ParseContext) error {
 			}
 		}
 
+		if err := system.services.Mirror.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register repository mirror sync")
+			return err
+		}
+
 		if err := system.services.Cleanup.Register(gCtx); err != nil {
 			log.Error().Err(err).Msg("failed to register cleanup service")
 			return err
@@ -108,6 +114,11 @@ func (c *command) run(*kingpin.ParseContext) error {
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
+	g.Go(func() error {
+		token.ReloadSigningKeys(gCtx, system.signingKeys, config.Token.SigningKeysReloadInterval)
+		return nil
+	})
+
 	// start server
 	gHTTP, shutdownHTTP := system.server.ListenAndServe()
 	g.Go(gHTTP.Wait)
@@ -144,7 +155,8 @@ func (c *command) run(*kingpin.ParseContext) error {
 	stop()
 	log.Info().Msg("shutting down gracefully (press Ctrl+C again to force)")
 
-	// shutdown servers gracefully
+	// shutdown servers gracefully (the health endpoint reports not ready right away, in-flight requests
+	// get until the end of the grace period to complete before they are canceled).
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.GracefulShutdownTime)
 	defer cancel()
 
@@ -157,6 +169,11 @@ func (c *command) run(*kingpin.ParseContext) error {
 	log.Info().Msg("wait for subroutines to complete")
 	err = g.Wait()
 
+	// close the store only once nothing can access it anymore.
+	if cErr := system.db.Close(); cErr != nil {
+		log.Err(cErr).Msg("failed to close database connection")
+	}
+
 	return err
 }
 
@@ -16,11 +16,13 @@ package server
 
 import (
 	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/pipeline/resolver"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
 
 	"github.com/drone/runner-go/poller"
+	"github.com/jmoiron/sqlx"
 )
 
 // System stores high level System sub-routines.
@@ -30,16 +32,20 @@ type System struct {
 	resolverManager *resolver.Manager
 	poller          *poller.Poller
 	services        services.Services
+	db              *sqlx.DB
+	signingKeys     *jwt.KeySet
 }
 
 // NewSystem returns a new system structure.
 func NewSystem(bootstrap bootstrap.Bootstrap, server *server.Server, poller *poller.Poller,
-	resolverManager *resolver.Manager, services services.Services) *System {
+	resolverManager *resolver.Manager, services services.Services, db *sqlx.DB, signingKeys *jwt.KeySet) *System {
 	return &System{
 		bootstrap:       bootstrap,
 		server:          server,
 		poller:          poller,
 		resolverManager: resolverManager,
 		services:        services,
+		db:              db,
+		signingKeys:     signingKeys,
 	}
 }
@@ -76,6 +76,17 @@ func (c *HTTPClient) Login(ctx context.Context, input *user.LoginInput) (*types.
 	return out, err
 }
 
+// LoginWithToken exchanges a personal access token for a session and returns a JWT token.
+func (c *HTTPClient) LoginWithToken(
+	ctx context.Context,
+	input *user.LoginWithTokenInput,
+) (*types.TokenResponse, error) {
+	out := new(types.TokenResponse)
+	uri := fmt.Sprintf("%s/api/v1/login/token", c.base)
+	err := c.post(ctx, uri, true, input, out)
+	return out, err
+}
+
 // Register registers a new  user and returns a JWT token.
 func (c *HTTPClient) Register(ctx context.Context, input *user.RegisterInput) (*types.TokenResponse, error) {
 	out := new(types.TokenResponse)
@@ -96,6 +107,22 @@ func (c *HTTPClient) Self(ctx context.Context) (*types.User, error) {
 	return out, err
 }
 
+// Me returns the currently authenticated user together with its
+// effective access in the provided space and repository (both optional).
+func (c *HTTPClient) Me(ctx context.Context, spaceRef string, repoRef string) (*types.UserMe, error) {
+	out := new(types.UserMe)
+	params := url.Values{}
+	if spaceRef != "" {
+		params.Set("space_ref", spaceRef)
+	}
+	if repoRef != "" {
+		params.Set("repo_ref", repoRef)
+	}
+	uri := fmt.Sprintf("%s/api/v1/user/me?%s", c.base, params.Encode())
+	err := c.get(ctx, uri, out)
+	return out, err
+}
+
 // UserCreatePAT creates a new PAT for the user.
 func (c *HTTPClient) UserCreatePAT(ctx context.Context, in user.CreateTokenInput) (*types.TokenResponse, error) {
 	out := new(types.TokenResponse)
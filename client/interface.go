@@ -26,12 +26,19 @@ type Client interface {
 	// Login authenticates the user and returns a JWT token.
 	Login(ctx context.Context, input *user.LoginInput) (*types.TokenResponse, error)
 
+	// LoginWithToken exchanges a personal access token for a session and returns a JWT token.
+	LoginWithToken(ctx context.Context, input *user.LoginWithTokenInput) (*types.TokenResponse, error)
+
 	// Register registers a new  user and returns a JWT token.
 	Register(ctx context.Context, input *user.RegisterInput) (*types.TokenResponse, error)
 
 	// Self returns the currently authenticated user.
 	Self(ctx context.Context) (*types.User, error)
 
+	// Me returns the currently authenticated user together with its
+	// effective access in the provided space and repository.
+	Me(ctx context.Context, spaceRef string, repoRef string) (*types.UserMe, error)
+
 	// User returns a user by ID or email.
 	User(ctx context.Context, key string) (*types.User, error)
 
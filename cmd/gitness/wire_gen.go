@@ -13,7 +13,9 @@ import (
 	"github.com/harness/gitness/app/api/controller/connector"
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
+	"github.com/harness/gitness/app/api/controller/globalsearch"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/pipeline"
@@ -34,7 +36,10 @@ import (
 	webhook2 "github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/openapi"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/auth/authsource"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/auth/password"
 	"github.com/harness/gitness/app/bootstrap"
 	events4 "github.com/harness/gitness/app/events/git"
 	events3 "github.com/harness/gitness/app/events/pullreq"
@@ -55,15 +60,20 @@ import (
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/featureflag"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/maintenance"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/operation"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
 	repo2 "github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/repoactivity"
 	"github.com/harness/gitness/app/services/settings"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -73,6 +83,7 @@ import (
 	"github.com/harness/gitness/app/store/cache"
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/audit"
 	"github.com/harness/gitness/blob"
@@ -113,20 +124,54 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalInfoView := database.ProvidePrincipalInfoView(db)
 	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore, spaceStore)
-	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore)
-	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore)
+	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore)
+	repoCollaboratorStore := database.ProvideRepoCollaboratorStore(db, principalInfoCache)
+	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore, repoStore, repoCollaboratorStore)
+	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore, repoStore)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
+	hasher, err := password.ProvideHasher(config)
+	if err != nil {
+		return nil, err
+	}
+	source, err := authsource.ProvideSource(config, principalStore, principalUID, hasher)
+	if err != nil {
+		return nil, err
+	}
+	provider := oidc.ProvideProvider(config)
 	tokenStore := database.ProvideTokenStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore)
+	publicKeyStore := database.ProvidePublicKeyStore(db)
+	userEmailStore := database.ProvideUserEmailStore(db)
+	deployKeyStore := database.ProvideDeployKeyStore(db)
+	idempotencyKeyStore := database.ProvideIdempotencyKeyStore(db)
+	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	if err != nil {
+		return nil, err
+	}
+	mailerMailer := mailer.ProvideMailClient(config)
+	chainStore := database.ProvideAuditChainStore(db)
+	chainService := audit.ProvideChainService(chainStore)
+	auditService := audit.ProvideAuditService(config, chainService)
+	keySet, err := token.ProvideSigningKeys(config)
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := token.ProvideIssuer(config, tokenStore, keySet)
+	if err != nil {
+		return nil, err
+	}
+	controller := user.ProvideController(config, transactor, principalUID, authorizer, source, hasher, provider, principalStore, tokenStore, issuer, publicKeyStore, userEmailStore, deployKeyStore, membershipStore, spaceStore, repoStore, idempotencyKeyStore, blobStore, mailerMailer, auditService)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
-	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
-	provider, err := url.ProvideURLProvider(config)
+	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore, keySet)
+	urlProvider, err := url.ProvideURLProvider(config)
 	if err != nil {
 		return nil, err
 	}
-	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore, spaceStore)
 	pipelineStore := database.ProvidePipelineStore(db)
 	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
 	settingsStore := database.ProvideSettingsStore(db)
@@ -164,7 +209,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
 	executor := job.ProvideExecutor(jobStore, pubSub)
 	lockConfig := server.ProvideLockConfig(config)
-	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient)
+	mutexManager := lock.ProvideMutexManager(lockConfig, universalClient, db)
 	jobConfig := server.ProvideJobsConfig(config)
 	jobScheduler, err := job.ProvideScheduler(jobStore, executor, mutexManager, pubSub, jobConfig)
 	if err != nil {
@@ -173,7 +218,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	streamer := sse.ProvideEventsStreaming(pubSub)
 	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
-	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer)
+	repository, err := importer.ProvideRepoImporter(config, urlProvider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer)
 	if err != nil {
 		return nil, err
 	}
@@ -194,11 +239,22 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	lockerLocker := locker.ProvideLocker(mutexManager)
-	auditService := audit.ProvideAuditService()
 	repoIdentifier := check.ProvideRepoIdentifierCheck()
 	repoCheck := repo.ProvideRepoCheck()
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck)
-	reposettingsController := reposettings.ProvideController(authorizer, repoStore, settingsService)
+	statsReporter := repo2.ProvideStatsReporter(config, gitInterface)
+	languageAnalyzer := repo2.ProvideLanguageAnalyzer(config, gitInterface)
+	repoActivityStore := database.ProvideRepoActivityStore(db, principalInfoCache)
+	registry := operation.ProvideRegistry()
+	garbageCollector := repo2.ProvideGarbageCollector(ctx, config, gitInterface, repoStore, mutexManager, registry)
+	repoMirrorStore := database.ProvideRepoMirrorStore(db)
+	mirrorService, err := mirror.ProvideService(config, urlProvider, gitInterface, repoStore, repoMirrorStore, encrypter, jobScheduler, executor, mutexManager, registry)
+	if err != nil {
+		return nil, err
+	}
+	repoRedirectStore := database.ProvideRepoRedirectStore(db)
+	deployKeyAuthenticator := authn.ProvideDeployKeyAuthenticator(deployKeyStore, principalStore)
+	repoController := repo.ProvideController(config, transactor, urlProvider, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, settingsService, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, statsReporter, languageAnalyzer, idempotencyKeyStore, deployKeyStore, publicKeyStore, deployKeyAuthenticator, repoActivityStore, userEmailStore, garbageCollector, mirrorService, registry, membershipStore, repoCollaboratorStore, repoRedirectStore)
+	reposettingsController := reposettings.ProvideController(authorizer, repoStore, principalStore, settingsService)
 	executionStore := database.ProvideExecutionStore(db)
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
 	stageStore := database.ProvideStageStore(db)
@@ -213,7 +269,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	converterService := converter.ProvideService(fileService)
 	templateStore := database.ProvideTemplateStore(db)
 	pluginStore := database.ProvidePluginStore(db)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, provider, templateStore, pluginStore)
+	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, converterService, schedulerScheduler, repoStore, urlProvider, templateStore, pluginStore)
 	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
 	logStore := logs.ProvideLogStore(db, config)
 	logStream := livelog.ProvideLogStream()
@@ -221,13 +277,13 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
 	secretStore := database.ProvideSecretStore(db)
 	connectorStore := database.ProvideConnectorStore(db)
-	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
+	exporterRepository, err := exporter.ProvideSpaceExporter(urlProvider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter, auditService)
+	spaceController := space.ProvideController(config, transactor, urlProvider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter, auditService, lockerLocker)
 	pipelineController := pipeline.ProvideController(repoStore, triggerStore, authorizer, pipelineStore)
-	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore)
+	secretController := secret.ProvideController(encrypter, secretStore, authorizer, spaceStore, repoStore)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoStore)
 	connectorController := connector.ProvideController(connectorStore, authorizer, spaceStore)
 	templateController := template.ProvideController(templateStore, authorizer, spaceStore)
@@ -253,15 +309,15 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	repoGitInfoView := database.ProvideRepoGitInfoView(db)
 	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, urlProvider, streamer)
 	if err != nil {
 		return nil, err
 	}
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, migrator, pullreqService, protectionManager, streamer, codeownersService, lockerLocker)
+	pullreqController := pullreq2.ProvideController(transactor, urlProvider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, migrator, pullreqService, protectionManager, streamer, codeownersService, lockerLocker, settingsService)
 	webhookConfig := server.ProvideWebhookConfig(config)
 	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter)
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, urlProvider, principalStore, gitInterface, encrypter)
 	if err != nil {
 		return nil, err
 	}
@@ -282,31 +338,41 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, reporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender)
-	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
-	principalController := principal.ProvideController(principalStore)
+	scripts := githook.ProvideScripts(config)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoMirrorStore, reporter2, reporter, gitInterface, pullReqStore, urlProvider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, scripts)
+	serviceaccountController := serviceaccount.NewController(config, principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore, issuer)
+	principalController := principal.ProvideController(config, principalStore, principalInfoCache)
 	v := check2.ProvideCheckSanitizers()
 	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
-	systemController := system.NewController(principalStore, config)
-	blobConfig, err := server.ProvideBlobStoreConfig(config)
-	if err != nil {
-		return nil, err
-	}
-	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	cleanupConfig := server.ProvideCleanupConfig(config)
+	repoPurger := repo.ProvideRepoPurger(repoController)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoPurger, idempotencyKeyStore, chainStore, mutexManager)
 	if err != nil {
 		return nil, err
 	}
+	cleaner := cleanup.ProvideCleaner(cleanupService)
+	migrationReporter := database.ProvideMigrationReporter(db)
+	systemController := system.NewController(principalStore, config, chainService, cleaner, registry, migrationReporter, keySet)
 	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
 	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
 	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
-	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController)
-	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController)
+	globalsearchController := globalsearch.ProvideController(authorizer, repoStore, spaceStore, principalStore)
+	readiness := server2.ProvideReadiness()
+	featureflagService, err := featureflag.ProvideService(config)
+	if err != nil {
+		return nil, err
+	}
+	maintenanceService := maintenance.ProvideService(config, settingsService)
+	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController, globalsearchController, readiness, featureflagService, maintenanceService, auditService)
+	lfsObjectStore := database.ProvideLFSObjectStore(db)
+	lfsController := lfs.ProvideController(authorizer, repoStore, lfsObjectStore, blobStore, urlProvider)
+	gitHandler := router.ProvideGitHandler(config, urlProvider, authenticator, repoController, lfsController, maintenanceService, auditService)
 	openapiService := openapi.ProvideOpenAPIService()
 	webHandler := router.ProvideWebHandler(config, openapiService)
-	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, provider)
-	serverServer := server2.ProvideServer(config, routerRouter)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore)
-	client := manager.ProvideExecutionClient(executionManager, provider, config)
+	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, urlProvider)
+	serverServer := server2.ProvideServer(config, routerRouter, readiness)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, urlProvider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, encrypter)
+	client := manager.ProvideExecutionClient(executionManager, urlProvider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
 	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
 	if err != nil {
@@ -330,19 +396,13 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	repoService, err := repo2.ProvideService(ctx, config, reporter, readerFactory2, repoStore, provider, gitInterface, lockerLocker)
-	if err != nil {
-		return nil, err
-	}
-	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, repoStore, repoController)
+	repoService, err := repo2.ProvideService(ctx, config, reporter, readerFactory2, repoStore, urlProvider, gitInterface, lockerLocker)
 	if err != nil {
 		return nil, err
 	}
-	mailerMailer := mailer.ProvideMailClient(config)
 	notificationClient := notification.ProvideMailClient(mailerMailer)
 	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, urlProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -351,7 +411,12 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService)
-	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, poller, resolverManager, servicesServices)
+	repoactivityConfig := server.ProvideRepoActivityConfig(config)
+	repoactivityService, err := repoactivity.ProvideService(ctx, repoactivityConfig, readerFactory, eventsReaderFactory, repoActivityStore)
+	if err != nil {
+		return nil, err
+	}
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, repoactivityService, mirrorService)
+	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, poller, resolverManager, servicesServices, db, keySet)
 	return serverSystem, nil
 }
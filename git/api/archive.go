@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+)
+
+// ArchiveFormat defines the format of a repository archive.
+type ArchiveFormat string
+
+const (
+	ArchiveFormatZip   ArchiveFormat = "zip"
+	ArchiveFormatTarGz ArchiveFormat = "tar.gz"
+)
+
+// ArchiveFormats lists all supported archive formats.
+var ArchiveFormats = []ArchiveFormat{ArchiveFormatZip, ArchiveFormatTarGz}
+
+// ParseArchiveFormat parses the archive format from a string.
+func ParseArchiveFormat(s string) (ArchiveFormat, bool) {
+	for _, format := range ArchiveFormats {
+		if strings.EqualFold(s, string(format)) {
+			return format, true
+		}
+	}
+
+	return "", false
+}
+
+// Extension returns the file extension of the archive format, including the leading dot.
+func (f ArchiveFormat) Extension() string {
+	return "." + string(f)
+}
+
+// ContentType returns the http content type of the archive format.
+func (f ArchiveFormat) ContentType() string {
+	switch f {
+	case ArchiveFormatZip:
+		return "application/zip"
+	case ArchiveFormatTarGz:
+		return "application/gzip"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Archive streams an archive of the tree of the provided revision to the writer.
+// All files in the archive are placed under the provided prefix directory.
+func (g *Git) Archive(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+	format ArchiveFormat,
+	prefix string,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if rev == "" {
+		return errors.InvalidArgument("git revision cannot be empty")
+	}
+	if _, ok := ParseArchiveFormat(string(format)); !ok {
+		return errors.InvalidArgument("unsupported archive format %q", format)
+	}
+
+	cmd := command.New("archive",
+		command.WithFlag("--format="+string(format)),
+	)
+	if prefix != "" {
+		cmd.Add(command.WithFlag("--prefix=" + strings.TrimSuffix(prefix, "/") + "/"))
+	}
+	cmd.Add(command.WithArg(rev))
+
+	if err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(w),
+	); err != nil {
+		return processGitErrorf(err, "failed to archive revision %q", rev)
+	}
+
+	return nil
+}
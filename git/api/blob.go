@@ -15,11 +15,15 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
 	"github.com/harness/gitness/git/sha"
 )
 
@@ -98,3 +102,91 @@ func (l limitReaderCloser) Close() error {
 	l.stop()
 	return nil
 }
+
+// FileInfo contains the details of a blob at a specific path.
+type FileInfo struct {
+	SHA  sha.SHA
+	Path string
+	Size int64
+}
+
+// FindOversizeFiles returns all blobs that are reachable from the provided revisions,
+// but not from any existing reference, and are larger than the provided size limit.
+func (g *Git) FindOversizeFiles(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	revs []string,
+	sizeLimit int64,
+) ([]FileInfo, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	// list all objects that are new (not reachable from existing references) together with their paths.
+	cmd := command.New("rev-list",
+		command.WithFlag("--objects"),
+		command.WithArg(revs...),
+		command.WithArg("--not", "--all"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	objects := &bytes.Buffer{}
+	if err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(objects)); err != nil {
+		return nil, fmt.Errorf("failed to list new objects: %w", err)
+	}
+
+	paths := map[string]string{}
+	shas := &bytes.Buffer{}
+	for _, line := range strings.Split(objects.String(), "\n") {
+		// commits are listed without a path, and so is the root tree (with an empty path).
+		objectSHA, path, ok := strings.Cut(line, " ")
+		if !ok || path == "" {
+			continue
+		}
+		paths[objectSHA] = path
+		shas.WriteString(objectSHA + "\n")
+	}
+
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	cmd = command.New("cat-file",
+		command.WithFlag("--batch-check=%(objectname) %(objecttype) %(objectsize)"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	infos := &bytes.Buffer{}
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdin(shas), command.WithStdout(infos))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object sizes: %w", err)
+	}
+
+	var files []FileInfo
+	for _, line := range strings.Split(strings.TrimSpace(infos.String()), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[1] != string(GitObjectTypeBlob) {
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse size of object %s: %w", fields[0], err)
+		}
+		if size <= sizeLimit {
+			continue
+		}
+
+		objectSHA, err := sha.New(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse object sha: %w", err)
+		}
+
+		files = append(files, FileInfo{
+			SHA:  objectSHA,
+			Path: paths[fields[0]],
+			Size: size,
+		})
+	}
+
+	return files, nil
+}
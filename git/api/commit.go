@@ -70,6 +70,7 @@ type CommitFilter struct {
 	Since     int64
 	Until     int64
 	Committer string
+	Author    string
 }
 
 // CommitDivergenceRequest contains the refs for which the converging commits should be counted.
@@ -174,6 +175,9 @@ func (g *Git) listCommitSHAs(
 	if filter.Committer != "" {
 		cmd.Add(command.WithFlag("--committer", filter.Committer))
 	}
+	if filter.Author != "" {
+		cmd.Add(command.WithFlag("--author", filter.Author))
+	}
 	output := &bytes.Buffer{}
 	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
 	if err != nil {
@@ -523,6 +527,36 @@ func (g *Git) GetCommit(
 	return getCommit(ctx, repoPath, rev, "")
 }
 
+// GetCommitSignatures returns the signatures of the provided commits (nil for unsigned commits).
+func (g *Git) GetCommitSignatures(
+	ctx context.Context,
+	repoPath string,
+	commitSHAs []sha.SHA,
+) ([]*CommitGPGSignature, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	wr, rd, cancel := CatFileBatch(ctx, repoPath, nil)
+	defer cancel()
+
+	signatures := make([]*CommitGPGSignature, len(commitSHAs))
+	for i, commitSHA := range commitSHAs {
+		if _, err := wr.Write([]byte(commitSHA.String() + "\n")); err != nil {
+			return nil, fmt.Errorf("failed to write commit sha to cat-file: %w", err)
+		}
+
+		commit, err := getCommitFromBatchReader(ctx, repoPath, rd, commitSHA.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit '%s': %w", commitSHA, err)
+		}
+
+		signatures[i] = commit.Signature
+	}
+
+	return signatures, nil
+}
+
 func (g *Git) GetFullCommitID(
 	ctx context.Context,
 	repoPath string,
@@ -904,6 +938,9 @@ readLoop:
 				_, _ = signatureSB.Write(data)
 				_ = signatureSB.WriteByte('\n')
 				pgpsig = true
+			default:
+				// other headers (e.g. encoding or mergetag) are part of the signed payload.
+				_, _ = payloadSB.Write(line)
 			}
 		} else {
 			_, _ = messageSB.Write(line)
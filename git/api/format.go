@@ -37,5 +37,7 @@ const (
 	fmtBody    = "%B"
 
 	fmtFieldObjectType = "%(objecttype)"
+	fmtFieldObjectName = "%(objectname)"
+	fmtFieldObjectSize = "%(objectsize)"
 	fmtFieldPath       = "%(path)"
 )
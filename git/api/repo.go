@@ -15,9 +15,11 @@
 package api
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strconv"
@@ -25,6 +27,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
 
 	"github.com/rs/zerolog/log"
 )
@@ -150,11 +153,38 @@ func (g *Git) GetDefaultBranch(
 	return output.String(), nil
 }
 
+// RemoteOptions restricts how git accesses a remote repository (e.g. a remote provided by a user).
+type RemoteOptions struct {
+	// Protocols are the only transport protocols git is allowed to use (all protocols are allowed if empty).
+	Protocols []string
+	// DisableRedirects prevents git from following http redirects of the remote.
+	DisableRedirects bool
+	// Resolve pins the addresses of hosts in the format "host:port:address" (see curl's --resolve),
+	// to ensure git connects to the addresses that were verified by the caller.
+	Resolve []string
+}
+
+func (o RemoteOptions) apply(cmd *command.Command) {
+	if len(o.Protocols) > 0 {
+		cmd.Add(command.WithConfig("protocol.allow", "never"))
+		for _, protocol := range o.Protocols {
+			cmd.Add(command.WithConfig("protocol."+protocol+".allow", "always"))
+		}
+	}
+	if o.DisableRedirects {
+		cmd.Add(command.WithConfig("http.followRedirects", "false"))
+	}
+	for _, resolve := range o.Resolve {
+		cmd.Add(command.WithConfig("http.curloptResolve", resolve))
+	}
+}
+
 // GetRemoteDefaultBranch retrieves the default branch of a remote repository.
 // If the repo doesn't have a default branch, types.ErrNoDefaultBranch is returned.
 func (g *Git) GetRemoteDefaultBranch(
 	ctx context.Context,
 	remoteURL string,
+	remoteOpts RemoteOptions,
 ) (string, error) {
 	cmd := command.New("ls-remote",
 		command.WithConfig("credential.helper", ""),
@@ -163,6 +193,7 @@ func (g *Git) GetRemoteDefaultBranch(
 		command.WithArg(remoteURL),
 		command.WithArg("HEAD"),
 	)
+	remoteOpts.apply(cmd)
 	output := &bytes.Buffer{}
 	if err := cmd.Run(ctx, command.WithStdout(output)); err != nil {
 		return "", processGitErrorf(err, "failed to ls remote repo")
@@ -233,6 +264,7 @@ func (g *Git) Sync(
 	repoPath string,
 	source string,
 	refSpecs []string,
+	remoteOpts RemoteOptions,
 ) error {
 	if repoPath == "" {
 		return ErrRepositoryPathEmpty
@@ -254,6 +286,7 @@ func (g *Git) Sync(
 		command.WithArg(source),
 		command.WithArg(refSpecs...),
 	)
+	remoteOpts.apply(cmd)
 
 	err := cmd.Run(ctx, command.WithDir(repoPath))
 	if err != nil {
@@ -425,6 +458,101 @@ func (g *Git) CountObjects(ctx context.Context, repoPath string) (ObjectCount, e
 	return objectCount, nil
 }
 
+// GarbageCollect runs git gc to pack loose objects and prune unreachable objects of the repository.
+func (g *Git) GarbageCollect(ctx context.Context, repoPath string) error {
+	var errbuf strings.Builder
+	cmd := command.New("gc", command.WithFlag("--quiet"))
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStderr(&errbuf),
+	)
+	if err != nil {
+		if errbuf.Len() > 0 {
+			err = fmt.Errorf("%w\ncmd error output: %s", err, errbuf.String())
+		}
+		return processGitErrorf(err, "failed to run git gc")
+	}
+
+	return nil
+}
+
+// BlobSize contains the SHA and the size of a blob object.
+type BlobSize struct {
+	SHA  sha.SHA
+	Size int64
+}
+
+// LargestBlob returns the largest blob object stored in the repository.
+// If the repository doesn't contain any blobs, an empty BlobSize is returned.
+func (g *Git) LargestBlob(ctx context.Context, repoPath string) (BlobSize, error) {
+	cmd := command.New("cat-file",
+		command.WithFlag("--batch-all-objects"),
+		command.WithFlag("--batch-check=%(objecttype) %(objectname) %(objectsize)"),
+	)
+
+	// the output contains a line per object of the repository, so it's streamed instead of buffered.
+	pipeRead, pipeWrite := io.Pipe()
+	defer func() {
+		// stops the command in case parsing failed before the whole output was read.
+		_ = pipeRead.Close()
+	}()
+
+	go func() {
+		var err error
+
+		defer func() {
+			// If running of the command below fails, make the pipe reader also fail with the same error.
+			_ = pipeWrite.CloseWithError(err)
+		}()
+
+		if err = cmd.Run(ctx,
+			command.WithDir(repoPath),
+			command.WithStdout(pipeWrite),
+		); err != nil {
+			err = processGitErrorf(err, "failed to list objects")
+		}
+	}()
+
+	return parseLargestBlob(pipeRead)
+}
+
+// parseLargestBlob returns the largest blob of the batch check output, keeping only the current max in memory.
+func parseLargestBlob(r io.Reader) (BlobSize, error) {
+	largest := BlobSize{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[0] != string(GitObjectTypeBlob) {
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return BlobSize{}, fmt.Errorf("failed to parse size of object %s: %w", fields[1], err)
+		}
+
+		if size <= largest.Size && !largest.SHA.IsEmpty() {
+			continue
+		}
+
+		objectSHA, err := sha.New(fields[1])
+		if err != nil {
+			return BlobSize{}, fmt.Errorf("failed to parse object sha: %w", err)
+		}
+
+		largest = BlobSize{
+			SHA:  objectSHA,
+			Size: size,
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return BlobSize{}, err
+	}
+
+	return largest, nil
+}
+
 func parseGitCountObjectsOutput(ctx context.Context, output string) ObjectCount {
 	info := ObjectCount{}
 
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestParseLargestBlob(t *testing.T) {
+	const output = `commit 1111111111111111111111111111111111111111 250
+blob 2222222222222222222222222222222222222222 10
+tree 3333333333333333333333333333333333333333 900
+blob 4444444444444444444444444444444444444444 42
+blob 5555555555555555555555555555555555555555 42
+`
+
+	largest, err := parseLargestBlob(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("Want no error, got %v", err)
+	}
+
+	if want := "4444444444444444444444444444444444444444"; largest.SHA.String() != want || largest.Size != 42 {
+		t.Errorf("Want largest blob %s with size 42, got %s with size %d", want, largest.SHA, largest.Size)
+	}
+}
+
+func TestParseLargestBlob_ReadError(t *testing.T) {
+	errRead := errors.New("git failed")
+
+	_, err := parseLargestBlob(iotest.ErrReader(errRead))
+	if !errors.Is(err, errRead) {
+		t.Errorf("Want read error %v, got %v", errRead, err)
+	}
+}
@@ -40,3 +40,31 @@ func (g *Git) ResolveRev(ctx context.Context,
 	}
 	return sha.New(output.String())
 }
+
+// ResolveCommitSHA resolves the provided revision to the sha of the commit it points to.
+// In case the revision is an abbreviated sha that matches more than one object,
+// an InvalidArgument error is returned.
+func (g *Git) ResolveCommitSHA(ctx context.Context,
+	repoPath string,
+	rev string,
+) (sha.SHA, error) {
+	if repoPath == "" {
+		return sha.None, ErrRepositoryPathEmpty
+	}
+	cmd := command.New("rev-parse",
+		command.WithFlag("--verify"),
+		command.WithArg(rev+"^{commit}"),
+	)
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx, command.WithDir(repoPath), command.WithStdout(output))
+	if err != nil {
+		if strings.Contains(err.Error(), "is ambiguous") {
+			return sha.None, errors.InvalidArgument("short sha %q is ambiguous", rev)
+		}
+		if strings.Contains(err.Error(), "Needed a single revision") {
+			return sha.None, errors.NotFound("revision %q not found", rev)
+		}
+		return sha.None, fmt.Errorf("failed to resolve commit sha: %w", err)
+	}
+	return sha.New(output.String())
+}
@@ -384,3 +384,89 @@ func (g *Git) ListPaths(
 
 	return files, dirs, nil
 }
+
+// FileSize is a file of a repository together with the size of its blob.
+type FileSize struct {
+	Path string
+	SHA  sha.SHA
+	Size int64
+}
+
+// ListFileSizes lists all the files in a repo recursively together with their blob size.
+// Submodules are not included.
+func (g *Git) ListFileSizes(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+) ([]FileSize, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	cmd := command.New("ls-tree",
+		command.WithConfig("core.quotePath", "false"), // force printing of path in custom format without quoting
+		command.WithFlag("-z"),
+		command.WithFlag("-r"),
+		command.WithFlag("--full-name"),
+		command.WithFlag("--format="+fmtFieldObjectType+fmtZero+fmtFieldObjectName+fmtZero+
+			fmtFieldObjectSize+fmtZero+fmtFieldPath),
+		command.WithArg(rev+"^{commit}"), //nolint:goconst enforce commit revs for now (keep it simple)
+	)
+
+	output := &bytes.Buffer{}
+	err := cmd.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdout(output),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "expected commit type") {
+			return nil, errors.InvalidArgument("revision %q does not point to a commit", rev)
+		}
+		if strings.Contains(err.Error(), "fatal: Not a valid object name") {
+			return nil, errors.NotFound("revision %q not found", rev)
+		}
+		return nil, fmt.Errorf("failed to run git ls-tree: %w", err)
+	}
+
+	var files []FileSize
+
+	scanner := bufio.NewScanner(output)
+	scanner.Split(parser.ScanZeroSeparated)
+	for scanner.Scan() {
+		objectType := scanner.Text()
+
+		// custom format guarantees the object name, size and path in the next scans
+		var fields [3]string
+		for i := range fields {
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("unexpected output from ls-tree: %w", scanner.Err())
+			}
+			fields[i] = scanner.Text()
+		}
+
+		if !strings.EqualFold(objectType, string(GitObjectTypeBlob)) {
+			continue
+		}
+
+		objectSHA, err := sha.New(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse object name from ls-tree output: %w", err)
+		}
+
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse object size from ls-tree output: %w", err)
+		}
+
+		files = append(files, FileSize{
+			Path: fields[2],
+			SHA:  objectSHA,
+			Size: size,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading ls-tree output: %w", err)
+	}
+
+	return files, nil
+}
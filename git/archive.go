@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+)
+
+type ArchiveParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA)
+	GitREF string
+	Format api.ArchiveFormat
+	// Prefix is the directory all files of the archive are placed in.
+	Prefix string
+}
+
+func (p *ArchiveParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.GitREF == "" {
+		return errors.InvalidArgument("git ref cannot be empty")
+	}
+
+	return nil
+}
+
+// Archive streams an archive of the repository at the provided git ref to the writer.
+func (s *Service) Archive(ctx context.Context, w io.Writer, params *ArchiveParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	return s.git.Archive(ctx, repoPath, params.GitREF, params.Format, params.Prefix, w)
+}
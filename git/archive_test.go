@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestService_Archive(t *testing.T) {
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupFixtureRepo(t, reposRoot, repoUID)
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	for _, format := range api.ArchiveFormats {
+		t.Run(string(format), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := s.Archive(context.Background(), buf, &ArchiveParams{
+				ReadParams: ReadParams{RepoUID: repoUID},
+				GitREF:     "HEAD",
+				Format:     format,
+				Prefix:     "fixture",
+			})
+			if err != nil {
+				t.Fatalf("failed to archive repository: %s", err)
+			}
+
+			if buf.Len() == 0 {
+				t.Fatalf("Want non-empty archive")
+			}
+
+			switch format {
+			case api.ArchiveFormatZip:
+				r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+				if err != nil {
+					t.Fatalf("failed to read zip archive: %s", err)
+				}
+				var names []string
+				for _, f := range r.File {
+					names = append(names, f.Name)
+				}
+				if !slices.Contains(names, "fixture/small.txt") {
+					t.Errorf("Want archive to contain fixture/small.txt, got %v", names)
+				}
+			case api.ArchiveFormatTarGz:
+				if _, err := gzip.NewReader(buf); err != nil {
+					t.Fatalf("failed to read gzip archive: %s", err)
+				}
+			}
+		})
+	}
+}
+
+func TestService_ArchiveUnknownRef(t *testing.T) {
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupFixtureRepo(t, reposRoot, repoUID)
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	err := s.Archive(context.Background(), &bytes.Buffer{}, &ArchiveParams{
+		ReadParams: ReadParams{RepoUID: repoUID},
+		GitREF:     "unknown",
+		Format:     api.ArchiveFormatZip,
+	})
+	if err == nil {
+		t.Errorf("Want archiving an unknown ref to fail")
+	}
+}
@@ -16,8 +16,10 @@ package git
 
 import (
 	"context"
+	"fmt"
 	"io"
 
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/sha"
 )
 
@@ -63,3 +65,66 @@ func (s *Service) GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobO
 		Content:     reader.Content,
 	}, nil
 }
+
+type FindOversizeFilesParams struct {
+	ReadParams
+	// Revs are the revisions whose new objects (not reachable from any existing reference) are checked.
+	Revs      []string
+	SizeLimit int64
+}
+
+func (p *FindOversizeFilesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+	if len(p.Revs) == 0 {
+		return errors.InvalidArgument("at least one revision has to be provided")
+	}
+	if p.SizeLimit < 0 {
+		return errors.InvalidArgument("size limit can't be negative")
+	}
+	return nil
+}
+
+type FindOversizeFilesOutput struct {
+	FileInfos []FileInfo
+}
+
+type FileInfo struct {
+	SHA  sha.SHA
+	Path string
+	Size int64
+}
+
+// FindOversizeFiles returns all new files (not yet reachable from any reference) that exceed the size limit.
+func (s *Service) FindOversizeFiles(
+	ctx context.Context,
+	params *FindOversizeFilesParams,
+) (*FindOversizeFilesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	files, err := s.git.FindOversizeFiles(ctx, repoPath, params.AlternateObjectDirs, params.Revs, params.SizeLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find oversize files: %w", err)
+	}
+
+	fileInfos := make([]FileInfo, len(files))
+	for i, file := range files {
+		fileInfos[i] = FileInfo{
+			SHA:  file.SHA,
+			Path: file.Path,
+			Size: file.Size,
+		}
+	}
+
+	return &FindOversizeFilesOutput{
+		FileInfos: fileInfos,
+	}, nil
+}
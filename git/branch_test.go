@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+)
+
+// setupBranchFixtureRepo extends the fixture repository with the following branches,
+// each with a distinct committer date:
+// b-trunk: two commits on top of the initial commit
+// b-feature: one commit on top of the first trunk commit
+// b-merged: pointing to the initial commit (committed at the time of the test run).
+func setupBranchFixtureRepo(t *testing.T, reposRoot string, repoUID string) {
+	t.Helper()
+
+	setupFixtureRepo(t, reposRoot, repoUID)
+	repoPath := getFullPathForRepo(reposRoot, repoUID)
+
+	commit := func(name, content, date string) {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err)
+		}
+		for _, args := range [][]string{
+			{"add", name},
+			{"-c", "user.name=test", "-c", "user.email=test@gitness.io", "commit", "--quiet", "-m", name},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repoPath
+			cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE="+date, "GIT_AUTHOR_DATE="+date)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("failed to run git %v: %s: %s", args, err, out)
+			}
+		}
+	}
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("failed to run git %v: %s: %s", args, err, out)
+		}
+	}
+
+	git("branch", "b-merged")
+	git("checkout", "--quiet", "-b", "b-trunk")
+	commit("one.txt", "one\n", "2023-01-01T10:00:00Z")
+
+	git("checkout", "--quiet", "-b", "b-feature")
+	commit("feature.txt", "feature\n", "2023-01-03T10:00:00Z")
+
+	git("checkout", "--quiet", "b-trunk")
+	commit("two.txt", "two\n", "2023-01-02T10:00:00Z")
+}
+
+func TestService_ListBranchesSort(t *testing.T) {
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupBranchFixtureRepo(t, reposRoot, repoUID)
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	tests := []struct {
+		name  string
+		sort  BranchSortOption
+		order SortOrder
+		want  []string
+	}{
+		{
+			name:  "name-asc",
+			sort:  BranchSortOptionName,
+			order: SortOrderAsc,
+			want:  []string{"b-feature", "b-merged", "b-trunk"},
+		},
+		{
+			name:  "date-desc",
+			sort:  BranchSortOptionDate,
+			order: SortOrderDesc,
+			want:  []string{"b-merged", "b-feature", "b-trunk"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := s.ListBranches(context.Background(), &ListBranchesParams{
+				ReadParams: ReadParams{RepoUID: repoUID},
+				Query:      "b-", // skip the initial branch as its name depends on the git config
+				Sort:       test.sort,
+				Order:      test.order,
+			})
+			if err != nil {
+				t.Fatalf("failed to list branches: %s", err)
+			}
+
+			names := make([]string, len(out.Branches))
+			for i := range out.Branches {
+				names[i] = out.Branches[i].Name
+			}
+			if len(names) != len(test.want) {
+				t.Fatalf("Want branches %v, got %v", test.want, names)
+			}
+			for i := range names {
+				if names[i] != test.want[i] {
+					t.Errorf("Want branches %v, got %v", test.want, names)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestService_GetCommitDivergencesBranches(t *testing.T) {
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupBranchFixtureRepo(t, reposRoot, repoUID)
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	out, err := s.GetCommitDivergences(context.Background(), &GetCommitDivergencesParams{
+		ReadParams: ReadParams{RepoUID: repoUID},
+		Requests: []CommitDivergenceRequest{
+			{From: "b-feature", To: "b-trunk"},
+			{From: "b-merged", To: "b-trunk"},
+			{From: "b-trunk", To: "b-trunk"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to get divergences: %s", err)
+	}
+
+	want := []api.CommitDivergence{
+		{Ahead: 1, Behind: 1},
+		{Ahead: 0, Behind: 2},
+		{Ahead: 0, Behind: 0},
+	}
+	if len(out.Divergences) != len(want) {
+		t.Fatalf("Want %d divergences, got %d", len(want), len(out.Divergences))
+	}
+	for i := range want {
+		if out.Divergences[i] != want[i] {
+			t.Errorf("Want divergence %d to be %+v, got %+v", i, want[i], out.Divergences[i])
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CherryPickParams is input structure object for the cherry-pick operation.
+type CherryPickParams struct {
+	WriteParams
+
+	// CommitSHA is the commit whose changes are applied on top of the branch.
+	CommitSHA sha.SHA
+	// Branch is the name of the branch the commit is cherry-picked onto.
+	Branch string
+
+	// Committer overwrites the git committer used for committing the changes
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the changes
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+}
+
+func (p *CherryPickParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.CommitSHA.IsEmpty() {
+		return errors.InvalidArgument("commit sha is mandatory")
+	}
+
+	if p.Branch == "" {
+		return errors.InvalidArgument("branch is mandatory")
+	}
+
+	return nil
+}
+
+// errCherryPickConflict is used to abort the cherry-pick without updating the branch in case of conflicts.
+var errCherryPickConflict = errors.New("cherry-pick resulted in conflicts")
+
+// CherryPickOutput is result object of the cherry-pick operation.
+type CherryPickOutput struct {
+	// BranchSHA is the sha of the latest commit on the branch before the cherry-pick.
+	BranchSHA sha.SHA
+	// CommitSHA is the sha of the newly created commit (empty in case of conflicts).
+	CommitSHA sha.SHA
+	// ConflictFiles contains the files that prevented the commit from being applied cleanly.
+	ConflictFiles []string
+}
+
+// CherryPick applies the changes introduced by a commit on top of the provided branch, creating a new commit.
+// The original author and message of the commit are preserved.
+// In case the changes can't be applied cleanly, the branch isn't updated and the conflicting files are returned.
+func (s *Service) CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error) {
+	if err := params.Validate(); err != nil {
+		return CherryPickOutput{}, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	branchRef := api.GetReferenceFromBranchName(params.Branch)
+
+	branchSHA, err := s.git.GetFullCommitID(ctx, repoPath, branchRef)
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to get branch commit SHA: %w", err)
+	}
+
+	commit, err := s.git.GetCommit(ctx, repoPath, params.CommitSHA.String())
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to get commit to cherry-pick: %w", err)
+	}
+
+	if len(commit.ParentSHAs) != 1 {
+		return CherryPickOutput{}, errors.InvalidArgument(
+			"Only commits with exactly one parent can be cherry-picked.")
+	}
+
+	committer := api.Signature{Identity: api.Identity(params.Actor), When: time.Now().UTC()}
+	if params.Committer != nil {
+		committer.Identity = api.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	message := commit.Title
+	if commit.Message != "" {
+		message += "\n\n" + commit.Message
+	}
+	message += fmt.Sprintf("\n\n(cherry picked from commit %s)", commit.SHA)
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath, branchRef)
+	if err != nil {
+		return CherryPickOutput{}, errors.Internal(err, "failed to create ref updater object")
+	}
+
+	if err := refUpdater.InitOld(ctx, branchSHA); err != nil {
+		return CherryPickOutput{}, errors.Internal(err, "failed to set old reference value for ref updater")
+	}
+
+	var newSHA sha.SHA
+	var conflicts []string
+
+	err = sharedrepo.Run(ctx, refUpdater, s.tmpDir, repoPath, func(r *sharedrepo.SharedRepo) error {
+		var treeSHA sha.SHA
+
+		// use the parent of the commit as merge base to only apply the changes introduced by the commit.
+		treeSHA, conflicts, err = r.MergeTree(ctx, commit.ParentSHAs[0], branchSHA, commit.SHA)
+		if err != nil {
+			return fmt.Errorf("merge tree failed: %w", err)
+		}
+
+		if len(conflicts) > 0 {
+			return errCherryPickConflict
+		}
+
+		branchTreeSHA, err := r.GetTreeSHA(ctx, branchSHA.String())
+		if err != nil {
+			return fmt.Errorf("failed to get tree sha for branch: %w", err)
+		}
+
+		if treeSHA.Equal(branchTreeSHA) {
+			return errors.InvalidArgument("The changes of the commit are already present on the branch.")
+		}
+
+		newSHA, err = r.CommitTree(ctx, &commit.Author, &committer, treeSHA, message, false, branchSHA)
+		if err != nil {
+			return fmt.Errorf("commit tree failed: %w", err)
+		}
+
+		if err := refUpdater.InitNew(ctx, newSHA); err != nil {
+			return fmt.Errorf("refUpdater.InitNew failed: %w", err)
+		}
+
+		return nil
+	})
+	if errors.Is(err, errCherryPickConflict) {
+		return CherryPickOutput{
+			BranchSHA:     branchSHA,
+			CommitSHA:     sha.None,
+			ConflictFiles: conflicts,
+		}, nil
+	}
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to cherry-pick commit %s onto branch %q: %w",
+			params.CommitSHA, params.Branch, err)
+	}
+
+	log.Ctx(ctx).Debug().
+		Str("repo_uid", params.RepoUID).
+		Str("branch", params.Branch).
+		Msgf("cherry-picked commit %s as %s", params.CommitSHA, newSHA)
+
+	return CherryPickOutput{
+		BranchSHA: branchSHA,
+		CommitSHA: newSHA,
+	}, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/sha"
+)
+
+// skipWithoutMergeTreeMergeBase skips the test if the installed git doesn't support
+// `git merge-tree --merge-base` (introduced in git 2.40), which the cherry-pick relies on.
+func skipWithoutMergeTreeMergeBase(t *testing.T, repoPath string) {
+	t.Helper()
+
+	cmd := exec.Command("git", "merge-tree", "--write-tree", "--merge-base=HEAD", "HEAD", "HEAD")
+	cmd.Dir = repoPath
+	if out, err := cmd.CombinedOutput(); err != nil && strings.Contains(string(out), "unknown option") {
+		t.Skip("git merge-tree --merge-base is not supported by the installed git version")
+	}
+}
+
+// setupCherryPickService creates a bare copy of the compare fixture repository,
+// with an additional commit on the base branch that conflicts with the first commit of the feature branch.
+func setupCherryPickService(t *testing.T) (*Service, WriteParams, string) {
+	t.Helper()
+
+	const repoUID = "cherrypick1234"
+	reposRoot := t.TempDir()
+
+	workRoot := t.TempDir()
+	setupCompareFixtureRepo(t, workRoot, repoUID)
+	workPath := getFullPathForRepo(workRoot, repoUID)
+
+	runGit(t, workPath, "checkout", "--quiet", "-b", "conflicting", "base")
+	if err := os.WriteFile(filepath.Join(workPath, "small.txt"), []byte("small\nconflict\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err)
+	}
+	runGit(t, workPath, "-c", "user.name=test", "-c", "user.email=test@gitness.io",
+		"commit", "--quiet", "-am", "conflicting change")
+
+	// ref updates operate on bare repositories, so clone the fixture into the repos root.
+	repoPath := getFullPathForRepo(reposRoot, repoUID)
+	cmd := exec.Command("git", "clone", "--quiet", "--bare", "--no-local", workPath, repoPath)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to clone fixture repo: %s: %s", err, out)
+	}
+
+	skipWithoutMergeTreeMergeBase(t, repoPath)
+
+	s := &Service{
+		reposRoot:         reposRoot,
+		tmpDir:            t.TempDir(),
+		git:               &api.Git{},
+		hookClientFactory: noopHookClientFactory{},
+	}
+
+	return s, WriteParams{
+		Actor:   Identity{Name: "releaser", Email: "releaser@gitness.io"},
+		RepoUID: repoUID,
+	}, repoPath
+}
+
+func TestService_CherryPick(t *testing.T) {
+	s, writeParams, repoPath := setupCherryPickService(t)
+	ctx := context.Background()
+
+	commitSHA := sha.Must(runGit(t, repoPath, "rev-parse", "feature~1"))
+	baseSHA := sha.Must(runGit(t, repoPath, "rev-parse", "base"))
+
+	out, err := s.CherryPick(ctx, &CherryPickParams{
+		WriteParams: writeParams,
+		CommitSHA:   commitSHA,
+		Branch:      "base",
+	})
+	if err != nil {
+		t.Fatalf("failed to cherry-pick: %s", err)
+	}
+
+	if len(out.ConflictFiles) != 0 {
+		t.Fatalf("Want no conflicts, got %v", out.ConflictFiles)
+	}
+	if !out.BranchSHA.Equal(baseSHA) {
+		t.Errorf("Want branch sha %s, got %s", baseSHA, out.BranchSHA)
+	}
+	if out.CommitSHA.IsEmpty() || out.CommitSHA.Equal(commitSHA) {
+		t.Fatalf("Want a new commit, got %s", out.CommitSHA)
+	}
+
+	if head := runGit(t, repoPath, "rev-parse", "base"); head != out.CommitSHA.String() {
+		t.Errorf("Want branch to point to %s, got %s", out.CommitSHA, head)
+	}
+	if parent := runGit(t, repoPath, "rev-parse", "base~1"); parent != baseSHA.String() {
+		t.Errorf("Want parent %s, got %s", baseSHA, parent)
+	}
+	if content := runGit(t, repoPath, "show", "base:small.txt"); content != "small\nchanged" {
+		t.Errorf("Want cherry-picked content, got %q", content)
+	}
+	if content := runGit(t, repoPath, "show", "base:large.txt"); content != "replaced" {
+		t.Errorf("Want existing branch content to be kept, got %q", content)
+	}
+
+	message := runGit(t, repoPath, "log", "-1", "--format=%B", "base")
+	if !strings.HasPrefix(message, "change small") || !strings.Contains(message, commitSHA.String()) {
+		t.Errorf("Want original message with reference to cherry-picked commit, got %q", message)
+	}
+	if author := runGit(t, repoPath, "log", "-1", "--format=%an|%cn", "base"); author != "test|releaser" {
+		t.Errorf("Want original author and actor as committer, got %q", author)
+	}
+}
+
+func TestService_CherryPick_Conflict(t *testing.T) {
+	s, writeParams, repoPath := setupCherryPickService(t)
+	ctx := context.Background()
+
+	commitSHA := sha.Must(runGit(t, repoPath, "rev-parse", "feature~1"))
+	branchSHA := runGit(t, repoPath, "rev-parse", "conflicting")
+
+	out, err := s.CherryPick(ctx, &CherryPickParams{
+		WriteParams: writeParams,
+		CommitSHA:   commitSHA,
+		Branch:      "conflicting",
+	})
+	if err != nil {
+		t.Fatalf("failed to cherry-pick: %s", err)
+	}
+
+	if !out.CommitSHA.IsEmpty() {
+		t.Errorf("Want no commit to be created, got %s", out.CommitSHA)
+	}
+	if strings.Join(out.ConflictFiles, ",") != "small.txt" {
+		t.Errorf("Want conflict in small.txt, got %v", out.ConflictFiles)
+	}
+	if head := runGit(t, repoPath, "rev-parse", "conflicting"); head != branchSHA {
+		t.Errorf("Want branch to stay on %s, got %s", branchSHA, head)
+	}
+}
@@ -29,6 +29,7 @@ const (
 	GitTraceSetup       = "GIT_TRACE_SETUP"
 	GitExecPath         = "GIT_EXEC_PATH" // tells Git where to find its binaries.
 
+	GitDir                 = "GIT_DIR"
 	GitObjectDir           = "GIT_OBJECT_DIRECTORY"
 	GitAlternateObjectDirs = "GIT_ALTERNATE_OBJECT_DIRECTORIES"
 )
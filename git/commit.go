@@ -86,6 +86,58 @@ func (s *Service) GetCommit(ctx context.Context, params *GetCommitParams) (*GetC
 	}, nil
 }
 
+type GetCommitSignaturesParams struct {
+	ReadParams
+	CommitSHAs []sha.SHA
+}
+
+// CommitSignature contains the signature of a commit together with the payload it signs.
+type CommitSignature struct {
+	CommitSHA sha.SHA
+	// Signature is the armored signature of the commit (e.g. PGP or SSH signature).
+	Signature string
+	// Payload is the raw commit object without the signature header.
+	Payload string
+}
+
+type GetCommitSignaturesOutput struct {
+	// Signatures contains the signatures of the signed commits only.
+	Signatures []CommitSignature
+}
+
+// GetCommitSignatures returns the signatures of the provided commits.
+func (s *Service) GetCommitSignatures(
+	ctx context.Context,
+	params *GetCommitSignaturesParams,
+) (*GetCommitSignaturesOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	signatures, err := s.git.GetCommitSignatures(ctx, repoPath, params.CommitSHAs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &GetCommitSignaturesOutput{
+		Signatures: make([]CommitSignature, 0, len(signatures)),
+	}
+	for i, signature := range signatures {
+		if signature == nil {
+			continue
+		}
+
+		out.Signatures = append(out.Signatures, CommitSignature{
+			CommitSHA: params.CommitSHAs[i],
+			Signature: signature.Signature,
+			Payload:   signature.Payload,
+		})
+	}
+
+	return out, nil
+}
+
 type ListCommitsParams struct {
 	ReadParams
 	// GitREF is a git reference (branch / tag / commit SHA)
@@ -106,6 +158,9 @@ type ListCommitsParams struct {
 	// Committer allows to filter for commits based on the committer - Optional, ignored if string is empty.
 	Committer string
 
+	// Author allows to filter for commits based on the author name or email - Optional, ignored if string is empty.
+	Author string
+
 	// IncludeStats allows to include information about inserted, deletions and status for changed files.
 	IncludeStats bool
 }
@@ -151,6 +206,7 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 			Since:     params.Since,
 			Until:     params.Until,
 			Committer: params.Committer,
+			Author:    params.Author,
 		},
 	)
 	if err != nil {
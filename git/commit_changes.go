@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+)
+
+type CommitChangesParams struct {
+	ReadParams
+	// Revision is the commit for which the changes are returned.
+	Revision string
+	// Parent is the 1-based number of the parent the commit is diffed against (as in <rev>^<n>).
+	// Defaults to the first parent if not provided.
+	Parent int
+}
+
+func (p *CommitChangesParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Revision == "" {
+		return errors.InvalidArgument("revision cannot be empty")
+	}
+	if p.Parent < 0 {
+		return errors.InvalidArgument("parent cannot be negative")
+	}
+
+	return nil
+}
+
+type CommitChangesOutput struct {
+	SHA        sha.SHA
+	ParentSHAs []sha.SHA
+	// BaseSHA is the parent the commit got diffed against, sha.None in case of a root commit.
+	BaseSHA sha.SHA
+	// Files are the files changed by the commit including their patches.
+	// Binary files are flagged and don't contain the binary content.
+	Files []FileDiff
+}
+
+// CommitChanges returns the changed files of a commit compared to one of its parents.
+func (s *Service) CommitChanges(ctx context.Context, params *CommitChangesParams) (*CommitChangesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	commit, err := s.git.GetCommit(ctx, repoPath, params.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	parent := params.Parent
+	if parent == 0 {
+		parent = 1
+	}
+
+	// root commits are diffed against the empty tree
+	baseSHA, baseRef := sha.None, sha.EmptyTree
+	if len(commit.ParentSHAs) > 0 || parent > 1 {
+		if parent > len(commit.ParentSHAs) {
+			return nil, errors.InvalidArgument("commit %s has no parent %d", commit.SHA, parent)
+		}
+		baseSHA = commit.ParentSHAs[parent-1]
+		baseRef = baseSHA.String()
+	}
+
+	files, err := s.compareFiles(ctx, &DiffParams{
+		ReadParams:   params.ReadParams,
+		BaseRef:      baseRef,
+		HeadRef:      commit.SHA.String(),
+		IncludePatch: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommitChangesOutput{
+		SHA:        commit.SHA,
+		ParentSHAs: commit.ParentSHAs,
+		BaseSHA:    baseSHA,
+		Files:      files,
+	}, nil
+}
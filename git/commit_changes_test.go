@@ -0,0 +1,205 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+)
+
+const commitChangesRepoUID = "commits1234"
+
+// setupCommitChangesFixtureRepo extends the compare fixture repository with
+// a merge of the feature branch into the base branch followed by a binary file change on base.
+func setupCommitChangesFixtureRepo(t *testing.T) (*Service, string) {
+	t.Helper()
+
+	reposRoot := t.TempDir()
+	setupCompareFixtureRepo(t, reposRoot, commitChangesRepoUID)
+	repoPath := getFullPathForRepo(reposRoot, commitChangesRepoUID)
+
+	identity := []string{"-c", "user.name=test", "-c", "user.email=test@gitness.io"}
+	runGit(t, repoPath, "checkout", "--quiet", "base")
+	runGit(t, repoPath, append(identity, "merge", "--quiet", "--no-ff", "-m", "merge feature", "feature")...)
+
+	binary := []byte{0x00, 0x01, 0x02, 0xff, 0x00, 'b', 'i', 'n'}
+	if err := os.WriteFile(filepath.Join(repoPath, "image.bin"), binary, 0o600); err != nil {
+		t.Fatalf("failed to write file: %s", err)
+	}
+	runGit(t, repoPath, "add", "image.bin")
+	runGit(t, repoPath, append(identity, "commit", "--quiet", "-m", "add binary")...)
+
+	return &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}, repoPath
+}
+
+func TestService_CommitChanges(t *testing.T) {
+	s, repoPath := setupCommitChangesFixtureRepo(t)
+
+	revParse := func(rev string) sha.SHA {
+		return sha.Must(runGit(t, repoPath, "rev-parse", rev))
+	}
+
+	tests := []struct {
+		name    string
+		rev     string
+		parent  int
+		parents []sha.SHA
+		base    sha.SHA
+		files   []string
+	}{
+		{
+			name:    "normal commit",
+			rev:     "feature~1",
+			parents: []sha.SHA{revParse("feature~2")},
+			base:    revParse("feature~2"),
+			files:   []string{"small.txt"},
+		},
+		{
+			name:    "merge commit against first parent",
+			rev:     "base~1",
+			parents: []sha.SHA{revParse("base~2"), revParse("feature")},
+			base:    revParse("base~2"),
+			files:   []string{"new.txt", "small.txt"},
+		},
+		{
+			name:    "merge commit against second parent",
+			rev:     "base~1",
+			parent:  2,
+			parents: []sha.SHA{revParse("base~2"), revParse("feature")},
+			base:    revParse("feature"),
+			files:   []string{"large.txt"},
+		},
+		{
+			name:    "root commit",
+			rev:     "feature~2",
+			parents: nil,
+			base:    sha.None,
+			files:   []string{"large.txt", "small.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := s.CommitChanges(context.Background(), &CommitChangesParams{
+				ReadParams: ReadParams{RepoUID: commitChangesRepoUID},
+				Revision:   tt.rev,
+				Parent:     tt.parent,
+			})
+			if err != nil {
+				t.Fatalf("failed to get commit changes: %s", err)
+			}
+
+			if !out.SHA.Equal(revParse(tt.rev)) {
+				t.Errorf("Want sha %s, got %s", revParse(tt.rev), out.SHA)
+			}
+			if len(out.ParentSHAs) != len(tt.parents) {
+				t.Fatalf("Want parents %v, got %v", tt.parents, out.ParentSHAs)
+			}
+			for i := range tt.parents {
+				if !out.ParentSHAs[i].Equal(tt.parents[i]) {
+					t.Errorf("Want parent %d to be %s, got %s", i+1, tt.parents[i], out.ParentSHAs[i])
+				}
+			}
+			if !out.BaseSHA.Equal(tt.base) {
+				t.Errorf("Want base %s, got %s", tt.base, out.BaseSHA)
+			}
+
+			paths := make([]string, len(out.Files))
+			for i, file := range out.Files {
+				paths[i] = file.Path
+				if len(file.Patch) == 0 {
+					t.Errorf("Want patch for file %s", file.Path)
+				}
+			}
+			if strings.Join(paths, ",") != strings.Join(tt.files, ",") {
+				t.Errorf("Want files %v, got %v", tt.files, paths)
+			}
+		})
+	}
+}
+
+func TestService_CommitChangesPatchAndStats(t *testing.T) {
+	s, _ := setupCommitChangesFixtureRepo(t)
+
+	out, err := s.CommitChanges(context.Background(), &CommitChangesParams{
+		ReadParams: ReadParams{RepoUID: commitChangesRepoUID},
+		Revision:   "feature~1",
+	})
+	if err != nil {
+		t.Fatalf("failed to get commit changes: %s", err)
+	}
+	if len(out.Files) != 1 {
+		t.Fatalf("Want 1 file, got %d", len(out.Files))
+	}
+
+	// small.txt got changed from "small" (without trailing newline) to "small\nchanged\n"
+	file := out.Files[0]
+	if file.Status != enum.FileDiffStatusModified || file.Additions != 2 || file.Deletions != 1 || file.IsBinary {
+		t.Errorf("Want modified text file with 2 additions and 1 deletion, got %+v", file)
+	}
+	if !bytes.Contains(file.Patch, []byte("-small")) || !bytes.Contains(file.Patch, []byte("+changed")) {
+		t.Errorf("Want unified diff patch, got %q", file.Patch)
+	}
+}
+
+func TestService_CommitChangesBinary(t *testing.T) {
+	s, _ := setupCommitChangesFixtureRepo(t)
+
+	out, err := s.CommitChanges(context.Background(), &CommitChangesParams{
+		ReadParams: ReadParams{RepoUID: commitChangesRepoUID},
+		Revision:   "base",
+	})
+	if err != nil {
+		t.Fatalf("failed to get commit changes: %s", err)
+	}
+	if len(out.Files) != 1 {
+		t.Fatalf("Want 1 file, got %d", len(out.Files))
+	}
+
+	file := out.Files[0]
+	if file.Path != "image.bin" || !file.IsBinary || file.Status != enum.FileDiffStatusAdded {
+		t.Errorf("Want added binary file image.bin, got %+v", file)
+	}
+	if bytes.Contains(file.Patch, []byte{0x00}) {
+		t.Errorf("Want binary content to be omitted from the patch, got %q", file.Patch)
+	}
+}
+
+func TestService_CommitChangesInvalidParent(t *testing.T) {
+	s, _ := setupCommitChangesFixtureRepo(t)
+
+	for _, rev := range []string{"base~1", "feature~2"} {
+		_, err := s.CommitChanges(context.Background(), &CommitChangesParams{
+			ReadParams: ReadParams{RepoUID: commitChangesRepoUID},
+			Revision:   rev,
+			Parent:     3,
+		})
+		if !errors.IsInvalidArgument(err) {
+			t.Errorf("Want invalid argument error for %s, got %v", rev, err)
+		}
+	}
+}
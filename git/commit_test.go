@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/api"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestService_ListCommitsPagination(t *testing.T) {
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupCompareFixtureRepo(t, reposRoot, repoUID)
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	seen := map[string]int{}
+	var titles []string
+	for page := int32(1); page <= 2; page++ {
+		out, err := s.ListCommits(context.Background(), &ListCommitsParams{
+			ReadParams: ReadParams{RepoUID: repoUID},
+			GitREF:     "feature",
+			Page:       page,
+			Limit:      2,
+		})
+		if err != nil {
+			t.Fatalf("failed to list commits of page %d: %s", page, err)
+		}
+
+		for _, commit := range out.Commits {
+			if prev, ok := seen[commit.SHA.String()]; ok {
+				t.Errorf("Want disjoint pages, commit %s listed on page %d and %d", commit.SHA, prev, page)
+			}
+			seen[commit.SHA.String()] = int(page)
+			titles = append(titles, commit.Title)
+
+			if commit.Author.Identity.Name != "test" || commit.Committer.Identity.Email != "test@gitness.io" {
+				t.Errorf("Want author and committer to be set, got %+v and %+v", commit.Author, commit.Committer)
+			}
+		}
+	}
+
+	want := []string{"add new", "change small", "initial commit"}
+	if len(titles) != len(want) {
+		t.Fatalf("Want commits %v, got %v", want, titles)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Errorf("Want commit %d to be %q, got %q", i, want[i], titles[i])
+		}
+	}
+}
+
+func TestService_ListCommitsPathFilter(t *testing.T) {
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupCompareFixtureRepo(t, reposRoot, repoUID)
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	tests := map[string][]string{
+		"":          {"add new", "change small", "initial commit"},
+		"small.txt": {"change small", "initial commit"},
+		"new.txt":   {"add new"},
+	}
+
+	for path, want := range tests {
+		out, err := s.ListCommits(context.Background(), &ListCommitsParams{
+			ReadParams: ReadParams{RepoUID: repoUID},
+			GitREF:     "feature",
+			Page:       1,
+			Limit:      10,
+			Path:       path,
+		})
+		if err != nil {
+			t.Fatalf("failed to list commits for path %q: %s", path, err)
+		}
+
+		if len(out.Commits) != len(want) {
+			t.Errorf("Want %d commits for path %q, got %d", len(want), path, len(out.Commits))
+			continue
+		}
+		for i := range want {
+			if out.Commits[i].Title != want[i] {
+				t.Errorf("Want commit %d for path %q to be %q, got %q", i, path, want[i], out.Commits[i].Title)
+			}
+		}
+	}
+}
+
+// setupAuthorFixtureRepo creates a repo with commits of different authors at different dates on "main".
+func setupAuthorFixtureRepo(t *testing.T, reposRoot string, repoUID string) {
+	t.Helper()
+
+	repoPath := getFullPathForRepo(reposRoot, repoUID)
+	if err := os.MkdirAll(repoPath, 0o700); err != nil {
+		t.Fatalf("failed to create repo dir: %s", err)
+	}
+	runGit(t, repoPath, "init", "--quiet", "--initial-branch", "main")
+
+	commits := []struct {
+		title  string
+		author string
+		file   string
+		date   string
+	}{
+		{title: "alice a", author: "alice <alice@gitness.io>", file: "a.txt", date: "2023-01-01T12:00:00Z"},
+		{title: "bob b", author: "bob <bob@gitness.io>", file: "b.txt", date: "2023-02-01T12:00:00Z"},
+		{title: "alice b", author: "alice <alice@gitness.io>", file: "b.txt", date: "2023-03-01T12:00:00Z"},
+		{title: "bob a", author: "bob <bob@gitness.io>", file: "a.txt", date: "2023-04-01T12:00:00Z"},
+	}
+	for _, c := range commits {
+		if err := os.WriteFile(filepath.Join(repoPath, c.file), []byte(c.title), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err)
+		}
+		runGit(t, repoPath, "add", ".")
+
+		cmd := exec.Command("git", "-c", "user.name=test", "-c", "user.email=test@gitness.io",
+			"commit", "--quiet", "--author", c.author, "-m", c.title)
+		cmd.Dir = repoPath
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+c.date, "GIT_COMMITTER_DATE="+c.date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("failed to commit %q: %s: %s", c.title, err, out)
+		}
+	}
+}
+
+func TestService_ListCommitsAuthorAndDateFilter(t *testing.T) {
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupAuthorFixtureRepo(t, reposRoot, repoUID)
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	unix := func(date string) int64 {
+		ts, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			t.Fatalf("failed to parse date: %s", err)
+		}
+		return ts.Unix()
+	}
+
+	tests := []struct {
+		name   string
+		params ListCommitsParams
+		want   []string
+	}{
+		{
+			name:   "author name",
+			params: ListCommitsParams{Author: "alice"},
+			want:   []string{"alice b", "alice a"},
+		},
+		{
+			name:   "author email",
+			params: ListCommitsParams{Author: "bob@gitness.io"},
+			want:   []string{"bob a", "bob b"},
+		},
+		{
+			name:   "date range",
+			params: ListCommitsParams{Since: unix("2023-01-15T00:00:00Z"), Until: unix("2023-03-15T00:00:00Z")},
+			want:   []string{"alice b", "bob b"},
+		},
+		{
+			name:   "author and path",
+			params: ListCommitsParams{Author: "alice", Path: "b.txt"},
+			want:   []string{"alice b"},
+		},
+		{
+			name:   "author and date range",
+			params: ListCommitsParams{Author: "bob", Since: unix("2023-03-01T00:00:00Z")},
+			want:   []string{"bob a"},
+		},
+		{
+			name:   "author paginated",
+			params: ListCommitsParams{Author: "alice", Page: 2, Limit: 1},
+			want:   []string{"alice a"},
+		},
+		{
+			name:   "unknown author",
+			params: ListCommitsParams{Author: "carol"},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			params.ReadParams = ReadParams{RepoUID: repoUID}
+			params.GitREF = "main"
+			if params.Page == 0 {
+				params.Page, params.Limit = 1, 10
+			}
+
+			out, err := s.ListCommits(context.Background(), &params)
+			if err != nil {
+				t.Fatalf("failed to list commits: %s", err)
+			}
+
+			titles := make([]string, len(out.Commits))
+			for i := range out.Commits {
+				titles[i] = out.Commits[i].Title
+			}
+			if !slices.Equal(titles, tt.want) {
+				t.Errorf("Want commits %v, got %v", tt.want, titles)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/sha"
+)
+
+type CompareParams struct {
+	ReadParams
+	BaseRef string
+	HeadRef string
+}
+
+func (p *CompareParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.BaseRef == "" {
+		return errors.InvalidArgument("base ref cannot be empty")
+	}
+	if p.HeadRef == "" {
+		return errors.InvalidArgument("head ref cannot be empty")
+	}
+
+	return nil
+}
+
+type CompareOutput struct {
+	BaseSHA sha.SHA
+	HeadSHA sha.SHA
+	// MergeBaseSHA is sha.None in case the refs don't have a common ancestor.
+	MergeBaseSHA sha.SHA
+	// Ahead is the count of commits the head ref is ahead of the base ref.
+	Ahead int32
+	// Behind is the count of commits the head ref is behind the base ref.
+	Behind int32
+	// Files are the files changed on the head ref since the merge base (patches aren't included).
+	// In case the refs don't have a common ancestor, the trees of both refs are compared directly.
+	Files []FileDiff
+}
+
+// Compare returns the commit divergence and changed files between two refs.
+func (s *Service) Compare(ctx context.Context, params *CompareParams) (*CompareOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	baseCommit, err := s.git.GetCommit(ctx, repoPath, params.BaseRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base commit: %w", err)
+	}
+
+	headCommit, err := s.git.GetCommit(ctx, repoPath, params.HeadRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head commit: %w", err)
+	}
+
+	mergeBaseSHA, _, err := s.git.GetMergeBase(ctx, repoPath, "", baseCommit.SHA.String(), headCommit.SHA.String())
+	if cmdErr := command.AsError(err); cmdErr != nil && cmdErr.IsExitCode(1) && len(cmdErr.StdErr) == 0 {
+		// git merge-base exits with 1 without any error output if the commits have no common ancestor.
+		mergeBaseSHA, err = sha.None, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge base: %w", err)
+	}
+
+	divergences, err := s.git.GetCommitDivergences(ctx, repoPath, []api.CommitDivergenceRequest{
+		{From: headCommit.SHA.String(), To: baseCommit.SHA.String()},
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit divergence: %w", err)
+	}
+
+	files, err := s.compareFiles(ctx, &DiffParams{
+		ReadParams: params.ReadParams,
+		BaseRef:    baseCommit.SHA.String(),
+		HeadRef:    headCommit.SHA.String(),
+		MergeBase:  !mergeBaseSHA.IsEmpty(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompareOutput{
+		BaseSHA:      baseCommit.SHA,
+		HeadSHA:      headCommit.SHA,
+		MergeBaseSHA: mergeBaseSHA,
+		Ahead:        divergences[0].Ahead,
+		Behind:       divergences[0].Behind,
+		Files:        files,
+	}, nil
+}
+
+func (s *Service) compareFiles(ctx context.Context, params *DiffParams) ([]FileDiff, error) {
+	reader := NewStreamReader(s.Diff(ctx, params))
+
+	files := make([]FileDiff, 0, 16)
+	for {
+		file, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read diff: %w", err)
+		}
+
+		files = append(files, *file)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+)
+
+// setupCompareFixtureRepo extends the fixture repository with the following branches:
+// base: one commit on top of the initial commit changing large.txt
+// feature: two commits on top of the initial commit changing small.txt and adding new.txt
+// unrelated: a single commit without any common history.
+func setupCompareFixtureRepo(t *testing.T, reposRoot string, repoUID string) {
+	t.Helper()
+
+	setupFixtureRepo(t, reposRoot, repoUID)
+	repoPath := getFullPathForRepo(reposRoot, repoUID)
+
+	writeFile := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err)
+		}
+	}
+	git := func(args ...string) {
+		args = append([]string{"-c", "user.name=test", "-c", "user.email=test@gitness.io"}, args...)
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("failed to run git %v: %s: %s", args, err, out)
+		}
+	}
+
+	git("branch", "base")
+
+	git("checkout", "--quiet", "-b", "feature")
+	writeFile("small.txt", "small\nchanged\n")
+	git("commit", "--quiet", "-am", "change small")
+	writeFile("new.txt", "new\n")
+	git("add", "new.txt")
+	git("commit", "--quiet", "-m", "add new")
+
+	git("checkout", "--quiet", "base")
+	writeFile("large.txt", "replaced\n")
+	git("commit", "--quiet", "-am", "replace large")
+
+	git("checkout", "--quiet", "--orphan", "unrelated")
+	git("rm", "--quiet", "-rf", ".")
+	writeFile("other.txt", "other\n")
+	git("add", "other.txt")
+	git("commit", "--quiet", "-m", "unrelated")
+}
+
+func TestService_Compare(t *testing.T) {
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupCompareFixtureRepo(t, reposRoot, repoUID)
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	out, err := s.Compare(context.Background(), &CompareParams{
+		ReadParams: ReadParams{RepoUID: repoUID},
+		BaseRef:    "base",
+		HeadRef:    "feature",
+	})
+	if err != nil {
+		t.Fatalf("failed to compare refs: %s", err)
+	}
+
+	if out.Ahead != 2 || out.Behind != 1 {
+		t.Errorf("Want ahead 2 and behind 1, got ahead %d and behind %d", out.Ahead, out.Behind)
+	}
+	if out.MergeBaseSHA.IsEmpty() {
+		t.Errorf("Want merge base to be set")
+	}
+
+	// changes on the base branch aren't part of the comparison.
+	want := map[string][2]int64{
+		"small.txt": {2, 1},
+		"new.txt":   {1, 0},
+	}
+	if len(out.Files) != len(want) {
+		t.Fatalf("Want %d changed files, got %+v", len(want), out.Files)
+	}
+	for _, file := range out.Files {
+		stats, ok := want[file.Path]
+		if !ok {
+			t.Errorf("Unexpected changed file %q", file.Path)
+			continue
+		}
+		if file.Additions != stats[0] || file.Deletions != stats[1] {
+			t.Errorf("Want %s to have +%d -%d, got +%d -%d",
+				file.Path, stats[0], stats[1], file.Additions, file.Deletions)
+		}
+	}
+}
+
+func TestService_CompareUnrelated(t *testing.T) {
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupCompareFixtureRepo(t, reposRoot, repoUID)
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	out, err := s.Compare(context.Background(), &CompareParams{
+		ReadParams: ReadParams{RepoUID: repoUID},
+		BaseRef:    "base",
+		HeadRef:    "unrelated",
+	})
+	if err != nil {
+		t.Fatalf("failed to compare refs: %s", err)
+	}
+
+	if out.Ahead != 1 || out.Behind != 2 {
+		t.Errorf("Want ahead 1 and behind 2, got ahead %d and behind %d", out.Ahead, out.Behind)
+	}
+	if !out.MergeBaseSHA.IsEmpty() {
+		t.Errorf("Want no merge base, got %s", out.MergeBaseSHA)
+	}
+	// large.txt and small.txt are deleted, other.txt is added.
+	if len(out.Files) != 3 {
+		t.Errorf("Want 3 changed files, got %+v", out.Files)
+	}
+}
@@ -27,8 +27,10 @@ type Interface interface {
 	GetTreeNode(ctx context.Context, params *GetTreeNodeParams) (*GetTreeNodeOutput, error)
 	ListTreeNodes(ctx context.Context, params *ListTreeNodeParams) (*ListTreeNodeOutput, error)
 	ListPaths(ctx context.Context, params *ListPathsParams) (*ListPathsOutput, error)
+	ListFileSizes(ctx context.Context, params *ListFileSizesParams) (*ListFileSizesOutput, error)
 	GetSubmodule(ctx context.Context, params *GetSubmoduleParams) (*GetSubmoduleOutput, error)
 	GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobOutput, error)
+	FindOversizeFiles(ctx context.Context, params *FindOversizeFilesParams) (*FindOversizeFilesOutput, error)
 	CreateBranch(ctx context.Context, params *CreateBranchParams) (*CreateBranchOutput, error)
 	CreateCommitTag(ctx context.Context, params *CreateCommitTagParams) (*CreateCommitTagOutput, error)
 	DeleteTag(ctx context.Context, params *DeleteTagParams) error
@@ -37,9 +39,12 @@ type Interface interface {
 	ListBranches(ctx context.Context, params *ListBranchesParams) (*ListBranchesOutput, error)
 	UpdateDefaultBranch(ctx context.Context, params *UpdateDefaultBranchParams) error
 	GetRef(ctx context.Context, params GetRefParams) (GetRefResponse, error)
+	ResolveRef(ctx context.Context, params ResolveRefParams) (ResolveRefOutput, error)
 	PathsDetails(ctx context.Context, params PathsDetailsParams) (PathsDetailsOutput, error)
 
 	GetRepositorySize(ctx context.Context, params *GetRepositorySizeParams) (*GetRepositorySizeOutput, error)
+	GetRepositoryStats(ctx context.Context, params *GetRepositoryStatsParams) (*GetRepositoryStatsOutput, error)
+	GarbageCollect(ctx context.Context, params *GarbageCollectParams) error
 	// UpdateRef creates, updates or deletes a git ref. If the OldValue is defined it must match the reference value
 	// prior to the call. To remove a ref use the zero ref as the NewValue. To require the creation of a new one and
 	// not update of an exiting one, set the zero ref as the OldValue.
@@ -53,6 +58,7 @@ type Interface interface {
 	 * Commits service
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
+	GetCommitSignatures(ctx context.Context, params *GetCommitSignaturesParams) (*GetCommitSignaturesOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
@@ -65,6 +71,7 @@ type Interface interface {
 	 */
 	GetInfoRefs(ctx context.Context, w io.Writer, params *InfoRefsParams) error
 	ServicePack(ctx context.Context, w io.Writer, params *ServicePackParams) error
+	Archive(ctx context.Context, w io.Writer, params *ArchiveParams) error
 
 	/*
 	 * Diff services
@@ -75,6 +82,8 @@ type Interface interface {
 	CommitDiff(ctx context.Context, params *GetCommitParams, w io.Writer) error
 	DiffShortStat(ctx context.Context, params *DiffParams) (DiffShortStatOutput, error)
 	DiffStats(ctx context.Context, params *DiffParams) (DiffStatsOutput, error)
+	Compare(ctx context.Context, params *CompareParams) (*CompareOutput, error)
+	CommitChanges(ctx context.Context, params *CommitChangesParams) (*CommitChangesOutput, error)
 
 	GetDiffHunkHeaders(ctx context.Context, params GetDiffHunkHeadersParams) (GetDiffHunkHeadersOutput, error)
 	DiffCut(ctx context.Context, params *DiffCutParams) (DiffCutOutput, error)
@@ -83,6 +92,7 @@ type Interface interface {
 	 * Merge services
 	 */
 	Merge(ctx context.Context, in *MergeParams) (MergeOutput, error)
+	CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error)
 
 	/*
 	 * Blame services
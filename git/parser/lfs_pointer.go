@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+)
+
+const (
+	// LFSPointerMaxSize is the maximum size of a git LFS pointer file.
+	LFSPointerMaxSize = 1024
+
+	lfsPointerVersionPrefix = "version https://git-lfs.github.com/spec/v1"
+)
+
+var (
+	regExpLFSPointerOID  = regexp.MustCompile(`(?m)^oid sha256:([0-9a-f]{64})$`)
+	regExpLFSPointerSize = regexp.MustCompile(`(?m)^size (\d+)$`)
+)
+
+// LFSPointer is the parsed content of a git LFS pointer file.
+type LFSPointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// ParseLFSPointer parses the content of a blob as git LFS pointer file.
+// It returns false if the content isn't a valid pointer.
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md
+func ParseLFSPointer(content []byte) (LFSPointer, bool) {
+	if len(content) > LFSPointerMaxSize {
+		return LFSPointer{}, false
+	}
+
+	if !bytes.HasPrefix(content, []byte(lfsPointerVersionPrefix+"\n")) {
+		return LFSPointer{}, false
+	}
+
+	oid := regExpLFSPointerOID.FindSubmatch(content)
+	if oid == nil {
+		return LFSPointer{}, false
+	}
+
+	size := regExpLFSPointerSize.FindSubmatch(content)
+	if size == nil {
+		return LFSPointer{}, false
+	}
+
+	sizeValue, err := strconv.ParseInt(string(size[1]), 10, 64)
+	if err != nil {
+		return LFSPointer{}, false
+	}
+
+	return LFSPointer{
+		OID:  string(oid[1]),
+		Size: sizeValue,
+	}, true
+}
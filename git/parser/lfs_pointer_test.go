@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"testing"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	const oid = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+
+	tests := []struct {
+		name    string
+		content string
+		want    LFSPointer
+		wantOK  bool
+	}{
+		{
+			name:    "valid pointer",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 12345\n",
+			want:    LFSPointer{OID: oid, Size: 12345},
+			wantOK:  true,
+		},
+		{
+			name:    "regular file",
+			content: "just some content\n",
+		},
+		{
+			name:    "missing size",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\n",
+		},
+		{
+			name:    "unsupported hash",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha1:" + oid[:40] + "\nsize 1\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := ParseLFSPointer([]byte(test.content))
+			if ok != test.wantOK {
+				t.Fatalf("Want ok=%t, got ok=%t", test.wantOK, ok)
+			}
+			if got != test.want {
+				t.Errorf("Want %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
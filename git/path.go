@@ -34,3 +34,8 @@ func getFullPathForRepo(reposRoot, uid string) string {
 		fmt.Sprintf("%s.%s", uid[4:], gitRepoSuffix), // remainder with .git
 	)
 }
+
+// GetRepoPath returns the full path of a repo given the git root dir (see Config.Root) and the uid of the repo.
+func GetRepoPath(gitRoot, uid string) string {
+	return getFullPathForRepo(filepath.Join(gitRoot, repoSubdirName), uid)
+}
@@ -69,6 +69,94 @@ func (s *Service) GetRef(ctx context.Context, params GetRefParams) (GetRefRespon
 	return GetRefResponse{SHA: refSHA}, nil
 }
 
+type ResolveRefParams struct {
+	ReadParams
+	// Ref is either a branch name, a tag name, a full reference or a (short) commit sha.
+	Ref string
+}
+
+func (p *ResolveRefParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+	if p.Ref == "" {
+		return errors.InvalidArgument("ref cannot be empty")
+	}
+	if strings.HasPrefix(p.Ref, "-") {
+		return errors.InvalidArgument("ref cannot start with '-'")
+	}
+	return nil
+}
+
+type ResolveRefOutput struct {
+	// SHA is the sha of the commit the ref points to.
+	SHA sha.SHA
+	// Type is the type of the matched reference.
+	// In case the ref was resolved as a commit sha, the type is enum.RefTypeUndefined.
+	Type enum.RefType
+	// Name is the name of the matched branch or tag (empty for commit shas).
+	Name string
+}
+
+// ResolveRef resolves the provided ref to the sha of the commit it points to.
+// Branches take precedence over tags, and tags over (short) commit shas.
+func (s *Service) ResolveRef(ctx context.Context, params ResolveRefParams) (ResolveRefOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ResolveRefOutput{}, err
+	}
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	candidates := []struct {
+		prefix  string
+		refType enum.RefType
+	}{
+		{prefix: api.BranchPrefix, refType: enum.RefTypeBranch},
+		{prefix: api.TagPrefix, refType: enum.RefTypeTag},
+	}
+
+	// a fully qualified reference only matches its own type.
+	for _, c := range candidates {
+		if name, ok := strings.CutPrefix(params.Ref, c.prefix); ok {
+			commitSHA, err := s.git.ResolveCommitSHA(ctx, repoPath, params.Ref)
+			if err != nil {
+				return ResolveRefOutput{}, err
+			}
+			return ResolveRefOutput{SHA: commitSHA, Type: c.refType, Name: name}, nil
+		}
+	}
+
+	for _, c := range candidates {
+		commitSHA, err := s.git.ResolveCommitSHA(ctx, repoPath, c.prefix+params.Ref)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return ResolveRefOutput{}, err
+		}
+		return ResolveRefOutput{SHA: commitSHA, Type: c.refType, Name: params.Ref}, nil
+	}
+
+	ref := strings.ToLower(params.Ref)
+	if len(ref) < minShortSHALength || !matchCommitSHA.MatchString(ref) {
+		return ResolveRefOutput{}, errors.NotFound("ref %q not found", params.Ref)
+	}
+
+	commitSHA, err := s.git.ResolveCommitSHA(ctx, repoPath, ref)
+	if err != nil {
+		return ResolveRefOutput{}, err
+	}
+
+	// rev-parse also resolves other kinds of references (e.g. remotes) - only accept actual sha matches.
+	if !strings.HasPrefix(commitSHA.String(), ref) {
+		return ResolveRefOutput{}, errors.NotFound("ref %q not found", params.Ref)
+	}
+
+	return ResolveRefOutput{SHA: commitSHA}, nil
+}
+
 type UpdateRefParams struct {
 	WriteParams
 	Type enum.RefType
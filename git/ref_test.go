@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/enum"
+)
+
+func setupResolveRefService(t *testing.T) (*Service, ReadParams, string) {
+	t.Helper()
+
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupFixtureRepo(t, reposRoot, repoUID)
+
+	repoPath := getFullPathForRepo(reposRoot, repoUID)
+	runGit(t, repoPath, "branch", "feature")
+	runGit(t, repoPath, "-c", "user.name=test", "-c", "user.email=test@gitness.io",
+		"tag", "-a", "v1.0.0", "-m", "first release")
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	return s, ReadParams{RepoUID: repoUID}, repoPath
+}
+
+func TestService_ResolveRef(t *testing.T) {
+	s, readParams, repoPath := setupResolveRefService(t)
+	ctx := context.Background()
+
+	headSHA := runGit(t, repoPath, "rev-parse", "HEAD")
+
+	tests := []struct {
+		name     string
+		ref      string
+		wantType enum.RefType
+		wantName string
+	}{
+		{name: "branch", ref: "feature", wantType: enum.RefTypeBranch, wantName: "feature"},
+		{name: "full branch ref", ref: "refs/heads/feature", wantType: enum.RefTypeBranch, wantName: "feature"},
+		{name: "annotated tag", ref: "v1.0.0", wantType: enum.RefTypeTag, wantName: "v1.0.0"},
+		{name: "full sha", ref: headSHA, wantType: enum.RefTypeUndefined},
+		{name: "short sha", ref: headSHA[:7], wantType: enum.RefTypeUndefined},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := s.ResolveRef(ctx, ResolveRefParams{ReadParams: readParams, Ref: test.ref})
+			if err != nil {
+				t.Fatalf("failed to resolve ref: %s", err)
+			}
+
+			if out.SHA.String() != headSHA {
+				t.Errorf("Want sha %s, got %s", headSHA, out.SHA)
+			}
+			if out.Type != test.wantType {
+				t.Errorf("Want type %q, got %q", test.wantType, out.Type)
+			}
+			if out.Name != test.wantName {
+				t.Errorf("Want name %q, got %q", test.wantName, out.Name)
+			}
+		})
+	}
+}
+
+func TestService_ResolveRef_Unknown(t *testing.T) {
+	s, readParams, _ := setupResolveRefService(t)
+	ctx := context.Background()
+
+	for _, ref := range []string{"unknown", "refs/heads/unknown", "deadbeef"} {
+		_, err := s.ResolveRef(ctx, ResolveRefParams{ReadParams: readParams, Ref: ref})
+		if !errors.IsNotFound(err) {
+			t.Errorf("Want not found error for %q, got %v", ref, err)
+		}
+	}
+}
+
+func TestService_ResolveRef_AmbiguousShortSHA(t *testing.T) {
+	s, readParams, repoPath := setupResolveRefService(t)
+	ctx := context.Background()
+
+	// create commits until two of them share the same minimum length prefix.
+	tree := runGit(t, repoPath, "write-tree")
+	prefix := ""
+	seen := map[string]struct{}{}
+	for i := 0; i < 5000 && prefix == ""; i++ {
+		commitSHA := runGit(t, repoPath, "-c", "user.name=test", "-c", "user.email=test@gitness.io",
+			"commit-tree", tree, "-m", fmt.Sprintf("commit %d", i))
+		if _, ok := seen[commitSHA[:minShortSHALength]]; ok {
+			prefix = commitSHA[:minShortSHALength]
+		}
+		seen[commitSHA[:minShortSHALength]] = struct{}{}
+	}
+	if prefix == "" {
+		t.Fatalf("failed to create commits with ambiguous short sha")
+	}
+
+	_, err := s.ResolveRef(ctx, ResolveRefParams{ReadParams: readParams, Ref: prefix})
+	if !errors.IsInvalidArgument(err) {
+		t.Errorf("Want invalid argument error, got %v", err)
+	}
+}
@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
 	"runtime/debug"
 	"time"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/harness/gitness/git/api"
 	"github.com/harness/gitness/git/check"
 	"github.com/harness/gitness/git/hash"
+	"github.com/harness/gitness/git/sha"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/rs/zerolog/log"
@@ -100,14 +103,39 @@ type GetRepositorySizeOutput struct {
 	Size int64
 }
 
+type GetRepositoryStatsParams struct {
+	ReadParams
+}
+
+type GetRepositoryStatsOutput struct {
+	// DiskSize is the size of all files in the git directory of the repository.
+	DiskSize int64
+	// ObjectCount is the number of loose and packed objects of the repository.
+	ObjectCount int64
+	// LargestBlobSHA is the SHA of the largest blob (empty if the repository doesn't contain blobs).
+	LargestBlobSHA sha.SHA
+	// LargestBlobSize is the size of the largest blob.
+	LargestBlobSize int64
+}
+
+type GarbageCollectParams struct {
+	ReadParams
+}
+
 type SyncRepositoryParams struct {
 	WriteParams
-	Source            string
+	Source string
+	// SourceRepoUID [OPTIONAL] syncs the repository from another repository hosted by the service (e.g. for forks).
+	// If provided, Source is ignored.
+	SourceRepoUID     string
 	CreateIfNotExists bool
 
 	// RefSpecs [OPTIONAL] allows to override the refspecs that are being synced from the remote repository.
 	// By default all references present on the remote repository will be fetched (including scm internal ones).
 	RefSpecs []string
+
+	// RemoteOptions [OPTIONAL] restricts how the remote repository is accessed.
+	RemoteOptions api.RemoteOptions
 }
 
 type SyncRepositoryOutput struct {
@@ -274,14 +302,19 @@ func (s *Service) SyncRepository(
 		}
 	}
 
+	source := params.Source
+	if params.SourceRepoUID != "" {
+		source = getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+	}
+
 	// sync repo content
-	err = s.git.Sync(ctx, repoPath, params.Source, params.RefSpecs)
+	err = s.git.Sync(ctx, repoPath, source, params.RefSpecs, params.RemoteOptions)
 	if err != nil {
 		return nil, fmt.Errorf("SyncRepository: failed to sync git repo: %w", err)
 	}
 
 	// get remote default branch
-	defaultBranch, err := s.git.GetRemoteDefaultBranch(ctx, params.Source)
+	defaultBranch, err := s.git.GetRemoteDefaultBranch(ctx, source, params.RemoteOptions)
 	if errors.Is(err, api.ErrNoDefaultBranch) {
 		return &SyncRepositoryOutput{
 			DefaultBranch: "",
@@ -514,6 +547,88 @@ func (s *Service) GetRepositorySize(
 	}, nil
 }
 
+// GetRepositoryStats walks the git directory of the repository to compute its on-disk size
+// and returns it together with the object count and the largest blob of the repository.
+func (s *Service) GetRepositoryStats(
+	ctx context.Context,
+	params *GetRepositoryStatsParams,
+) (*GetRepositoryStatsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	diskSize, err := getDirectorySize(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute disk size of repo: %w", err)
+	}
+
+	count, err := s.git.CountObjects(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count objects for repo: %w", err)
+	}
+
+	largestBlob, err := s.git.LargestBlob(ctx, repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find largest blob of repo: %w", err)
+	}
+
+	return &GetRepositoryStatsOutput{
+		DiskSize:        diskSize,
+		ObjectCount:     int64(count.Count + count.InPack),
+		LargestBlobSHA:  largestBlob.SHA,
+		LargestBlobSize: largestBlob.Size,
+	}, nil
+}
+
+// GarbageCollect packs loose objects and prunes unreachable objects of the repository.
+func (s *Service) GarbageCollect(ctx context.Context, params *GarbageCollectParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	if err := s.git.GarbageCollect(ctx, repoPath); err != nil {
+		return fmt.Errorf("failed to garbage collect repo: %w", err)
+	}
+
+	return nil
+}
+
+// getDirectorySize returns the accumulated size of all regular files in the directory tree.
+func getDirectorySize(root string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			// the file got removed in the meantime (e.g. by gc)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		size += info.Size()
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, errors.NotFound("repository not found")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
 // UpdateDefaultBranch updates the default barnch of the repo.
 func (s *Service) UpdateDefaultBranch(
 	ctx context.Context,
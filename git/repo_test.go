@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/api"
+)
+
+// setupFixtureRepo creates a small repository with two files of different size.
+func setupFixtureRepo(t *testing.T, reposRoot string, repoUID string) {
+	t.Helper()
+
+	repoPath := getFullPathForRepo(reposRoot, repoUID)
+	if err := os.MkdirAll(repoPath, 0o700); err != nil {
+		t.Fatalf("failed to create repo dir: %s", err)
+	}
+
+	files := map[string]string{
+		"small.txt": "small",
+		"large.txt": strings.Repeat("large content\n", 100),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write file: %s", err)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@gitness.io", "commit", "--quiet", "-m", "initial commit"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("failed to run git %v: %s: %s", args, err, out)
+		}
+	}
+}
+
+func TestService_GetRepositoryStats(t *testing.T) {
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+	setupFixtureRepo(t, reposRoot, repoUID)
+
+	s := &Service{
+		reposRoot: reposRoot,
+		git:       &api.Git{},
+	}
+
+	out, err := s.GetRepositoryStats(context.Background(), &GetRepositoryStatsParams{
+		ReadParams: ReadParams{RepoUID: repoUID},
+	})
+	if err != nil {
+		t.Fatalf("failed to get repository stats: %s", err)
+	}
+
+	if out.DiskSize <= 0 {
+		t.Errorf("Want positive disk size, got %d", out.DiskSize)
+	}
+	// two blobs, one tree and one commit
+	if out.ObjectCount != 4 {
+		t.Errorf("Want object count 4, got %d", out.ObjectCount)
+	}
+	if want := int64(len("large content\n") * 100); out.LargestBlobSize != want {
+		t.Errorf("Want largest blob size %d, got %d", want, out.LargestBlobSize)
+	}
+	if out.LargestBlobSHA.IsEmpty() {
+		t.Errorf("Want largest blob sha to be set")
+	}
+}
+
+func TestService_SyncRepository_FromLocalRepo(t *testing.T) {
+	const (
+		sourceUID = "fixture1234"
+		forkUID   = "fork5678"
+	)
+	reposRoot := t.TempDir()
+	setupFixtureRepo(t, reposRoot, sourceUID)
+
+	s := &Service{
+		reposRoot:   reposRoot,
+		git:         &api.Git{},
+		gitHookPath: filepath.Join(t.TempDir(), "gitness-hook"),
+	}
+
+	out, err := s.SyncRepository(context.Background(), &SyncRepositoryParams{
+		WriteParams: WriteParams{
+			RepoUID: forkUID,
+			Actor:   Identity{Name: "test", Email: "test@gitness.io"},
+		},
+		SourceRepoUID:     sourceUID,
+		CreateIfNotExists: true,
+		RefSpecs:          []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+	})
+	if err != nil {
+		t.Fatalf("failed to sync repository: %s", err)
+	}
+
+	sourceBranch := runGit(t, getFullPathForRepo(reposRoot, sourceUID), "symbolic-ref", "--short", "HEAD")
+	if out.DefaultBranch != sourceBranch {
+		t.Errorf("Want default branch %q, got %q", sourceBranch, out.DefaultBranch)
+	}
+
+	files := runGit(t, getFullPathForRepo(reposRoot, forkUID), "ls-tree", "-r", "--name-only", "HEAD")
+	if want := "large.txt\nsmall.txt"; files != want {
+		t.Errorf("Want files %q, got %q", want, files)
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("failed to run git %v: %s: %s", args, err, out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
@@ -290,12 +290,17 @@ func (s *Service) CreateCommitTag(ctx context.Context, params *CreateCommitTagPa
 			return fmt.Errorf("failed to create tag '%s': %w", tagName, err)
 		}
 
-		tag, err = s.git.GetAnnotatedTag(ctx, r.Directory(), tagName)
-		if err != nil {
-			return fmt.Errorf("failed to read annotated tag after creation: %w", err)
+		// lightweight tags point directly to the commit, annotated tags to the created tag object.
+		tagSHA := targetCommit.SHA
+		if params.Message != "" {
+			tag, err = s.git.GetAnnotatedTag(ctx, r.Directory(), tagName)
+			if err != nil {
+				return fmt.Errorf("failed to read annotated tag after creation: %w", err)
+			}
+			tagSHA = tag.Sha
 		}
 
-		if err := refUpdater.Init(ctx, sha.Nil, tag.Sha); err != nil {
+		if err := refUpdater.Init(ctx, sha.Nil, tagSHA); err != nil {
 			return fmt.Errorf("failed to init ref updater: %w", err)
 		}
 
@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+)
+
+// noopHookClientFactory creates hook clients that accept all ref updates.
+type noopHookClientFactory struct{}
+
+func (noopHookClientFactory) NewClient(map[string]string) (hook.Client, error) {
+	return hook.NewNoopClient(nil), nil
+}
+
+func setupTagService(t *testing.T) (*Service, WriteParams) {
+	t.Helper()
+
+	const repoUID = "fixture1234"
+	reposRoot := t.TempDir()
+
+	// ref updates operate on bare repositories, so clone the fixture into the repos root.
+	workRoot := t.TempDir()
+	setupFixtureRepo(t, workRoot, repoUID)
+	cmd := exec.Command("git", "clone", "--quiet", "--bare",
+		getFullPathForRepo(workRoot, repoUID), getFullPathForRepo(reposRoot, repoUID))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to clone fixture repo: %s: %s", err, out)
+	}
+
+	s := &Service{
+		reposRoot:         reposRoot,
+		tmpDir:            t.TempDir(),
+		git:               &api.Git{},
+		hookClientFactory: noopHookClientFactory{},
+	}
+
+	return s, WriteParams{
+		Actor:   Identity{Name: "test", Email: "test@gitness.io"},
+		RepoUID: repoUID,
+	}
+}
+
+func TestService_CreateAnnotatedTag(t *testing.T) {
+	s, writeParams := setupTagService(t)
+	ctx := context.Background()
+
+	out, err := s.CreateCommitTag(ctx, &CreateCommitTagParams{
+		WriteParams: writeParams,
+		Name:        "v1.0.0",
+		Target:      "HEAD",
+		Message:     "first release",
+	})
+	if err != nil {
+		t.Fatalf("failed to create tag: %s", err)
+	}
+
+	if !out.IsAnnotated {
+		t.Errorf("Want tag to be annotated")
+	}
+	if out.Message != "first release" {
+		t.Errorf("Want message %q, got %q", "first release", out.Message)
+	}
+}
+
+func TestService_ListTags(t *testing.T) {
+	s, writeParams := setupTagService(t)
+	ctx := context.Background()
+
+	for _, in := range []*CreateCommitTagParams{
+		{WriteParams: writeParams, Name: "v1.0.0", Target: "HEAD", Message: "first release"},
+		{WriteParams: writeParams, Name: "v1.0.1", Target: "HEAD"},
+	} {
+		if _, err := s.CreateCommitTag(ctx, in); err != nil {
+			t.Fatalf("failed to create tag %s: %s", in.Name, err)
+		}
+	}
+
+	out, err := s.ListCommitTags(ctx, &ListCommitTagsParams{
+		ReadParams: ReadParams{RepoUID: writeParams.RepoUID},
+		Page:       1,
+		PageSize:   1,
+	})
+	if err != nil {
+		t.Fatalf("failed to list tags: %s", err)
+	}
+
+	if len(out.Tags) != 1 || out.Tags[0].Name != "v1.0.0" {
+		t.Fatalf("Want first page to only contain v1.0.0, got %+v", out.Tags)
+	}
+
+	out, err = s.ListCommitTags(ctx, &ListCommitTagsParams{
+		ReadParams: ReadParams{RepoUID: writeParams.RepoUID},
+	})
+	if err != nil {
+		t.Fatalf("failed to list tags: %s", err)
+	}
+
+	if len(out.Tags) != 2 {
+		t.Fatalf("Want 2 tags, got %d", len(out.Tags))
+	}
+	if !out.Tags[0].IsAnnotated || out.Tags[1].IsAnnotated {
+		t.Errorf("Want only v1.0.0 to be annotated, got %+v", out.Tags)
+	}
+}
+
+func TestService_CreateTagDuplicate(t *testing.T) {
+	s, writeParams := setupTagService(t)
+	ctx := context.Background()
+
+	in := &CreateCommitTagParams{WriteParams: writeParams, Name: "v1.0.0", Target: "HEAD"}
+	if _, err := s.CreateCommitTag(ctx, in); err != nil {
+		t.Fatalf("failed to create tag: %s", err)
+	}
+
+	_, err := s.CreateCommitTag(ctx, in)
+	if errors.AsStatus(err) != errors.StatusConflict {
+		t.Errorf("Want conflict error, got %v", err)
+	}
+}
+
+func TestService_DeleteTag(t *testing.T) {
+	s, writeParams := setupTagService(t)
+	ctx := context.Background()
+
+	if _, err := s.CreateCommitTag(ctx, &CreateCommitTagParams{
+		WriteParams: writeParams,
+		Name:        "v1.0.0",
+		Target:      "HEAD",
+	}); err != nil {
+		t.Fatalf("failed to create tag: %s", err)
+	}
+
+	err := s.DeleteTag(ctx, &DeleteTagParams{WriteParams: writeParams, Name: "v1.0.0"})
+	if err != nil {
+		t.Fatalf("failed to delete tag: %s", err)
+	}
+
+	out, err := s.ListCommitTags(ctx, &ListCommitTagsParams{
+		ReadParams: ReadParams{RepoUID: writeParams.RepoUID},
+	})
+	if err != nil {
+		t.Fatalf("failed to list tags: %s", err)
+	}
+	if len(out.Tags) != 0 {
+		t.Errorf("Want no tags after delete, got %+v", out.Tags)
+	}
+}
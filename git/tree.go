@@ -17,6 +17,8 @@ package git
 import (
 	"context"
 	"fmt"
+
+	"github.com/harness/gitness/git/sha"
 )
 
 // TreeNodeType specifies the different types of nodes in a git tree.
@@ -186,6 +188,49 @@ func (s *Service) ListPaths(ctx context.Context, params *ListPathsParams) (*List
 		nil
 }
 
+type ListFileSizesParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA)
+	GitREF string
+}
+
+type ListFileSizesOutput struct {
+	Files []FileSize
+}
+
+type FileSize struct {
+	Path string
+	SHA  sha.SHA
+	Size int64
+}
+
+// ListFileSizes lists all files of the repository at the git ref together with the size of their blobs.
+func (s *Service) ListFileSizes(ctx context.Context, params *ListFileSizesParams) (*ListFileSizesOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	apiFiles, err := s.git.ListFileSizes(ctx, repoPath, params.GitREF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list file sizes: %w", err)
+	}
+
+	files := make([]FileSize, len(apiFiles))
+	for i, f := range apiFiles {
+		files[i] = FileSize{
+			Path: f.Path,
+			SHA:  f.SHA,
+			Size: f.Size,
+		}
+	}
+
+	return &ListFileSizesOutput{
+		Files: files,
+	}, nil
+}
+
 type PathsDetailsParams struct {
 	ReadParams
 	GitREF string
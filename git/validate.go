@@ -18,6 +18,9 @@ import "regexp"
 
 var matchCommitSHA = regexp.MustCompile("^[0-9a-f]+$")
 
+// minShortSHALength is the minimum length of an abbreviated commit sha accepted by git.
+const minShortSHALength = 4
+
 func ValidateCommitSHA(commitSHA string) bool {
 	if len(commitSHA) != 40 && len(commitSHA) != 64 {
 		return false
@@ -17,9 +17,11 @@ package http
 import (
 	"context"
 	"crypto/tls"
-	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/crypto/acme/autocert"
@@ -40,6 +42,26 @@ type Config struct {
 	Key               string
 	AcmeHost          string
 	ReadHeaderTimeout time.Duration
+	// Host (optional) is the host the server binds to, the server listens on all interfaces if empty.
+	Host string
+	// Readiness (optional) is marked as not ready as soon as the server starts shutting down.
+	Readiness *Readiness
+}
+
+// Readiness tracks whether the server is ready to receive traffic.
+type Readiness struct {
+	draining atomic.Bool
+}
+
+// Ready returns false once the server started shutting down.
+func (r *Readiness) Ready() bool {
+	return !r.draining.Load()
+}
+
+func (r *Readiness) drain() {
+	if r != nil {
+		r.draining.Store(true)
+	}
 }
 
 // Server is a wrapper around http.Server that exposes different async ListenAndServe methods
@@ -50,6 +72,8 @@ type Server struct {
 }
 
 // ShutdownFunction defines a function that is called to shutdown the server.
+// The server stops accepting new connections and waits for in-flight requests until the context expires,
+// after which the remaining requests are canceled.
 type ShutdownFunction func(context.Context) error
 
 func NewServer(config Config, handler http.Handler) *Server {
@@ -75,30 +99,20 @@ func (s *Server) ListenAndServe() (*errgroup.Group, ShutdownFunction) {
 
 func (s *Server) listenAndServe() (*errgroup.Group, ShutdownFunction) {
 	var g errgroup.Group
-	s1 := &http.Server{
-		Addr:              fmt.Sprintf(":%d", s.config.Port),
-		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
-		Handler:           s.handler,
-	}
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	s1 := s.newHTTPServer(baseCtx, net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port)), s.handler)
 	g.Go(func() error {
 		return s1.ListenAndServe()
 	})
 
-	return &g, s1.Shutdown
+	return &g, s.shutdownFunc(cancelRequests, s1)
 }
 
 func (s *Server) listenAndServeTLS() (*errgroup.Group, ShutdownFunction) {
 	var g errgroup.Group
-	s1 := &http.Server{
-		Addr:              ":http",
-		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
-		Handler:           http.HandlerFunc(redirect),
-	}
-	s2 := &http.Server{
-		Addr:              ":https",
-		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
-		Handler:           s.handler,
-	}
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	s1 := s.newHTTPServer(baseCtx, net.JoinHostPort(s.config.Host, "http"), http.HandlerFunc(redirect))
+	s2 := s.newHTTPServer(baseCtx, net.JoinHostPort(s.config.Host, "https"), s.handler)
 	g.Go(func() error {
 		return s1.ListenAndServe()
 	})
@@ -109,39 +123,23 @@ func (s *Server) listenAndServeTLS() (*errgroup.Group, ShutdownFunction) {
 		)
 	})
 
-	return &g, func(ctx context.Context) error {
-		var sg errgroup.Group
-		sg.Go(func() error {
-			return s1.Shutdown(ctx)
-		})
-		sg.Go(func() error {
-			return s2.Shutdown(ctx)
-		})
-		return sg.Wait()
-	}
+	return &g, s.shutdownFunc(cancelRequests, s1, s2)
 }
 
-func (s Server) listenAndServeAcme() (*errgroup.Group, ShutdownFunction) {
+func (s *Server) listenAndServeAcme() (*errgroup.Group, ShutdownFunction) {
 	var g errgroup.Group
 	m := &autocert.Manager{
 		Cache:      autocert.DirCache(".cache"),
 		Prompt:     autocert.AcceptTOS,
 		HostPolicy: autocert.HostWhitelist(s.config.AcmeHost),
 	}
-	s1 := &http.Server{
-		Addr:              ":http",
-		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
-		Handler:           m.HTTPHandler(nil),
-	}
-	s2 := &http.Server{
-		Addr:              ":https",
-		Handler:           s.handler,
-		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
-		TLSConfig: &tls.Config{
-			MinVersion:     tls.VersionTLS12,
-			GetCertificate: m.GetCertificate,
-			NextProtos:     []string{"h2", "http/1.1"},
-		},
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	s1 := s.newHTTPServer(baseCtx, net.JoinHostPort(s.config.Host, "http"), m.HTTPHandler(nil))
+	s2 := s.newHTTPServer(baseCtx, net.JoinHostPort(s.config.Host, "https"), s.handler)
+	s2.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: m.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
 	}
 	g.Go(func() error {
 		return s1.ListenAndServe()
@@ -150,15 +148,47 @@ func (s Server) listenAndServeAcme() (*errgroup.Group, ShutdownFunction) {
 		return s2.ListenAndServeTLS("", "")
 	})
 
-	return &g, func(ctx context.Context) error {
+	return &g, s.shutdownFunc(cancelRequests, s1, s2)
+}
+
+// newHTTPServer creates a new http.Server whose request contexts are derived from the provided base context.
+func (s *Server) newHTTPServer(baseCtx context.Context, addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		ReadHeaderTimeout: s.config.ReadHeaderTimeout,
+		Handler:           handler,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
+	}
+}
+
+// shutdownFunc returns a ShutdownFunction that marks the server as not ready and drains all provided servers.
+// In case the context expires before all in-flight requests completed, the remaining requests are canceled
+// and their connections closed.
+func (s *Server) shutdownFunc(cancelRequests context.CancelFunc, servers ...*http.Server) ShutdownFunction {
+	return func(ctx context.Context) error {
+		defer cancelRequests()
+
+		s.config.Readiness.drain()
+
 		var sg errgroup.Group
-		sg.Go(func() error {
-			return s1.Shutdown(ctx)
-		})
-		sg.Go(func() error {
-			return s2.Shutdown(ctx)
-		})
-		return sg.Wait()
+		for _, srv := range servers {
+			srv := srv
+			sg.Go(func() error {
+				return srv.Shutdown(ctx)
+			})
+		}
+
+		err := sg.Wait()
+		if err != nil {
+			cancelRequests()
+			for _, srv := range servers {
+				_ = srv.Close()
+			}
+		}
+
+		return err
 	}
 }
 
@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// startTestServer serves the handler on a random local port and returns its address and shutdown function.
+func startTestServer(t *testing.T, readiness *Readiness, handler http.Handler) (string, ShutdownFunction) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	s := NewServer(Config{Readiness: readiness}, handler)
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	srv := s.newHTTPServer(baseCtx, l.Addr().String(), handler)
+	go func() {
+		_ = srv.Serve(l)
+	}()
+
+	return "http://" + l.Addr().String(), s.shutdownFunc(cancelRequests, srv)
+}
+
+func TestShutdown_InFlightRequestCompletes(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		_, _ = io.WriteString(w, "done")
+	})
+
+	readiness := &Readiness{}
+	addr, shutdown := startTestServer(t, readiness, handler)
+
+	type result struct {
+		body string
+		err  error
+	}
+	inFlight := make(chan result, 1)
+	go func() {
+		resp, err := http.Get(addr) //nolint:noctx // test request
+		if err != nil {
+			inFlight <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		inFlight <- result{body: string(body), err: err}
+	}()
+	<-started
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- shutdown(context.Background())
+	}()
+
+	// readiness flips immediately, even though a request is still in flight.
+	deadline := time.Now().Add(time.Second)
+	for readiness.Ready() {
+		if time.Now().After(deadline) {
+			t.Fatal("Want server to be not ready after shutdown started")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// wait until the listener is closed and verify new requests are refused.
+	deadline = time.Now().Add(time.Second)
+	for {
+		resp, err := http.Get(addr) //nolint:noctx // test request
+		if err != nil {
+			break
+		}
+		resp.Body.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("Want new requests to be refused during shutdown")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	close(release)
+
+	res := <-inFlight
+	if res.err != nil {
+		t.Fatalf("Want in-flight request to complete, got error: %v", res.err)
+	}
+	if res.body != "done" {
+		t.Errorf("Want body %q, got %q", "done", res.body)
+	}
+
+	if err := <-shutdownErr; err != nil {
+		t.Errorf("Want shutdown to complete without error, got %v", err)
+	}
+}
+
+func TestShutdown_GracePeriodCancelsRequests(t *testing.T) {
+	started := make(chan struct{})
+	canceled := make(chan struct{})
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+		close(canceled)
+	})
+
+	addr, shutdown := startTestServer(t, nil, handler)
+
+	go func() {
+		resp, err := http.Get(addr) //nolint:noctx // test request
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Want error %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("Want request context to be canceled after grace period expired")
+	}
+}
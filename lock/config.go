@@ -21,6 +21,8 @@ type Provider string
 const (
 	MemoryProvider Provider = "inmemory"
 	RedisProvider  Provider = "redis"
+	// DatabaseProvider uses the locks table of the database (requires no additional infrastructure).
+	DatabaseProvider Provider = "database"
 )
 
 // A DelayFunc is used to decide the amount of time to wait between retries.
@@ -0,0 +1,232 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Database is a MutexManager backed by the locks table of the database.
+// It allows to serialize critical sections across multiple instances without requiring redis.
+type Database struct {
+	config Config // force value copy
+	db     *sqlx.DB
+}
+
+// NewDatabase creates a new Database mutex manager.
+func NewDatabase(config Config, db *sqlx.DB) *Database {
+	return &Database{
+		config: config,
+		db:     db,
+	}
+}
+
+// NewMutex creates a mutex for the given key. The returned mutex is not held
+// and must be acquired with a call to .Lock.
+func (d *Database) NewMutex(key string, options ...Option) (Mutex, error) {
+	var (
+		token string
+		err   error
+	)
+
+	// copy default values
+	config := d.config
+
+	// set default delayFunc
+	if config.DelayFunc == nil {
+		config.DelayFunc = func(_ int) time.Duration {
+			return config.RetryDelay
+		}
+	}
+
+	// override config with custom options
+	for _, opt := range options {
+		opt.Apply(&config)
+	}
+
+	// format key
+	key = formatKey(config.App, config.Namespace, key)
+
+	switch {
+	case config.Value != "":
+		token = config.Value
+	case config.GenValueFunc != nil:
+		token, err = config.GenValueFunc()
+	default:
+		token, err = randstr(32)
+	}
+	if err != nil {
+		return nil, NewError(ErrorKindGenerateTokenFailed, key, nil)
+	}
+
+	waitTime := config.Expiry
+	if config.TimeoutFactor > 0 {
+		waitTime = time.Duration(int64(float64(config.Expiry) * config.TimeoutFactor))
+	}
+
+	return &dbMutex{
+		provider:  d,
+		expiry:    config.Expiry,
+		waitTime:  waitTime,
+		tries:     config.Tries,
+		delayFunc: config.DelayFunc,
+		key:       key,
+		token:     token,
+	}, nil
+}
+
+// acquire takes the lock in case it isn't held or the previous holder let it expire.
+func (d *Database) acquire(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	const sqlQuery = `
+		INSERT INTO locks (lock_key, lock_token, lock_expires)
+		VALUES (?, ?, ?)
+		ON CONFLICT (lock_key) DO UPDATE
+		SET lock_token = excluded.lock_token, lock_expires = excluded.lock_expires
+		WHERE locks.lock_expires <= ?`
+
+	result, err := d.db.ExecContext(ctx, d.db.Rebind(sqlQuery),
+		key, token, now.Add(ttl).UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return false, fmt.Errorf("failed to insert lock: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get number of affected rows: %w", err)
+	}
+
+	return n == 1, nil
+}
+
+func (d *Database) release(ctx context.Context, key, token string) (bool, error) {
+	const sqlQuery = `
+		DELETE FROM locks
+		WHERE lock_key = ? AND lock_token = ?`
+
+	result, err := d.db.ExecContext(ctx, d.db.Rebind(sqlQuery), key, token)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete lock: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get number of affected rows: %w", err)
+	}
+
+	return n == 1, nil
+}
+
+type dbMutex struct {
+	mutex sync.Mutex // Used while manipulating the internal state of the lock itself
+
+	provider *Database
+
+	expiry   time.Duration
+	waitTime time.Duration
+
+	tries     int
+	delayFunc DelayFunc
+
+	key    string
+	token  string // A random string used to safely release the lock
+	isHeld bool
+}
+
+// Key returns the key to be locked.
+func (m *dbMutex) Key() string {
+	return m.key
+}
+
+// Lock acquires the lock. It fails with error if the lock is already held.
+// In case the lock is held by someone else, acquisition is retried until the configured tries
+// are exhausted (a single try fails fast), the wait time is over or the context is canceled.
+func (m *dbMutex) Lock(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.isHeld {
+		return NewError(ErrorKindLockHeld, m.key, nil)
+	}
+
+	timeout := time.NewTimer(m.waitTime)
+	defer timeout.Stop()
+
+	for attempt := 1; ; attempt++ {
+		ok, err := m.provider.acquire(ctx, m.key, m.token, m.expiry)
+		if err != nil && ctx.Err() != nil {
+			return NewError(ErrorKindContext, m.key, ctx.Err())
+		}
+		if err != nil {
+			return NewError(ErrorKindProviderError, m.key, err)
+		}
+		if ok {
+			m.isHeld = true
+			return nil
+		}
+
+		if attempt >= m.tries {
+			return NewError(ErrorKindMaxRetriesExceeded, m.key, nil)
+		}
+
+		if err = m.wait(ctx, attempt, timeout); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *dbMutex) wait(ctx context.Context, attempt int, timeout *time.Timer) error {
+	delay := time.NewTimer(m.delayFunc(attempt))
+	defer delay.Stop()
+
+	select {
+	case <-ctx.Done():
+		return NewError(ErrorKindContext, m.key, ctx.Err())
+	case <-timeout.C:
+		return NewError(ErrorKindCannotLock, m.key, nil)
+	case <-delay.C: // just wait
+		return nil
+	}
+}
+
+// Unlock releases the lock. It fails with error if the lock is not currently held.
+func (m *dbMutex) Unlock(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !m.isHeld {
+		return NewError(ErrorKindLockNotHeld, m.key, nil)
+	}
+
+	ok, err := m.provider.release(ctx, m.key, m.token)
+	if err != nil {
+		return NewError(ErrorKindProviderError, m.key, err)
+	}
+
+	m.isHeld = false
+
+	if !ok {
+		// the lock expired and got acquired by someone else in the meantime.
+		return NewError(ErrorKindLockNotHeld, m.key, nil)
+	}
+
+	return nil
+}
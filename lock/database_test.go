@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func setupDatabase(t *testing.T, config Config) *Database {
+	t.Helper()
+
+	db, err := sqlx.Connect("sqlite3", "file:"+xid.New().String()+".db?mode=memory&cache=shared")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = db.Exec(`CREATE TABLE locks (
+		lock_key TEXT PRIMARY KEY
+		,lock_token TEXT NOT NULL
+		,lock_expires BIGINT NOT NULL
+	)`)
+	require.NoError(t, err)
+
+	return NewDatabase(config, db)
+}
+
+func requireErrorKind(t *testing.T, err error, kind ErrorKind) {
+	t.Helper()
+
+	var lockErr *Error
+	require.True(t, errors.As(err, &lockErr), "expected lock error, got %v", err)
+	require.Equal(t, kind, lockErr.Kind)
+}
+
+func Test_dbMutex_FailFast(t *testing.T) {
+	manager := setupDatabase(t, Config{
+		App:       "gitness",
+		Namespace: "space",
+		Expiry:    3 * time.Second,
+		Tries:     1,
+	})
+	ctx := context.Background()
+
+	mx1, err := manager.NewMutex("key1")
+	require.NoError(t, err)
+	require.NoError(t, mx1.Lock(ctx))
+
+	mx2, err := manager.NewMutex("key1")
+	require.NoError(t, err)
+	requireErrorKind(t, mx2.Lock(ctx), ErrorKindMaxRetriesExceeded)
+
+	// a different key is independent
+	mx3, err := manager.NewMutex("key2")
+	require.NoError(t, err)
+	require.NoError(t, mx3.Lock(ctx))
+	require.NoError(t, mx3.Unlock(ctx))
+
+	require.NoError(t, mx1.Unlock(ctx))
+	require.NoError(t, mx2.Lock(ctx))
+	require.NoError(t, mx2.Unlock(ctx))
+}
+
+func Test_dbMutex_Wait(t *testing.T) {
+	manager := setupDatabase(t, Config{
+		App:        "gitness",
+		Namespace:  "space",
+		Expiry:     3 * time.Second,
+		Tries:      50,
+		RetryDelay: 50 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	mx1, err := manager.NewMutex("key1")
+	require.NoError(t, err)
+	require.NoError(t, mx1.Lock(ctx))
+
+	var unlocked time.Time
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		time.Sleep(300 * time.Millisecond)
+		unlocked = time.Now()
+		if err := mx1.Unlock(ctx); err != nil {
+			t.Errorf("failed to unlock: %v", err)
+		}
+	}()
+
+	mx2, err := manager.NewMutex("key1")
+	require.NoError(t, err)
+	require.NoError(t, mx2.Lock(ctx))
+	wg.Wait()
+	require.False(t, time.Now().Before(unlocked), "second acquirer got the lock before it was released")
+	require.NoError(t, mx2.Unlock(ctx))
+}
+
+func Test_dbMutex_ContextCanceled(t *testing.T) {
+	manager := setupDatabase(t, Config{
+		App:        "gitness",
+		Namespace:  "space",
+		Expiry:     3 * time.Second,
+		Tries:      50,
+		RetryDelay: 50 * time.Millisecond,
+	})
+
+	mx1, err := manager.NewMutex("key1")
+	require.NoError(t, err)
+	require.NoError(t, mx1.Lock(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	mx2, err := manager.NewMutex("key1")
+	require.NoError(t, err)
+	requireErrorKind(t, mx2.Lock(ctx), ErrorKindContext)
+}
+
+func Test_dbMutex_Expired(t *testing.T) {
+	manager := setupDatabase(t, Config{
+		App:       "gitness",
+		Namespace: "space",
+		Expiry:    100 * time.Millisecond,
+		Tries:     1,
+	})
+	ctx := context.Background()
+
+	mx1, err := manager.NewMutex("key1")
+	require.NoError(t, err)
+	require.NoError(t, mx1.Lock(ctx))
+
+	time.Sleep(150 * time.Millisecond)
+
+	mx2, err := manager.NewMutex("key1")
+	require.NoError(t, err)
+	require.NoError(t, mx2.Lock(ctx))
+
+	// the original holder lost the lock after it expired
+	requireErrorKind(t, mx1.Unlock(ctx), ErrorKindLockNotHeld)
+	require.NoError(t, mx2.Unlock(ctx))
+}
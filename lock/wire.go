@@ -17,18 +17,21 @@ package lock
 import (
 	"github.com/go-redis/redis/v8"
 	"github.com/google/wire"
+	"github.com/jmoiron/sqlx"
 )
 
 var WireSet = wire.NewSet(
 	ProvideMutexManager,
 )
 
-func ProvideMutexManager(config Config, client redis.UniversalClient) MutexManager {
+func ProvideMutexManager(config Config, client redis.UniversalClient, db *sqlx.DB) MutexManager {
 	switch config.Provider {
 	case MemoryProvider:
 		return NewInMemory(config)
 	case RedisProvider:
 		return NewRedis(config, client)
+	case DatabaseProvider:
+		return NewDatabase(config, db)
 	}
 	return nil
 }
@@ -17,6 +17,7 @@ package resources
 import (
 	"embed"
 	"fmt"
+	"io/fs"
 	"strings"
 )
 
@@ -42,6 +43,12 @@ func ReadLicense(name string) ([]byte, error) {
 	return content, err
 }
 
+// LicenseExists returns true if a licence with the provided name exists in license folder.
+func LicenseExists(name string) bool {
+	_, err := fs.Stat(licence, fmt.Sprintf("license/%s.txt", name))
+	return err == nil
+}
+
 // GitIgnores lists all files in gitignore folder and return file names.
 func GitIgnores() ([]string, error) {
 	entries, err := gitignore.ReadDir("gitignore")
@@ -59,3 +66,9 @@ func GitIgnores() ([]string, error) {
 func ReadGitIgnore(name string) ([]byte, error) {
 	return gitignore.ReadFile(fmt.Sprintf("gitignore/%s.gitignore", name))
 }
+
+// GitIgnoreExists returns true if a gitignore file with the provided name exists in gitignore folder.
+func GitIgnoreExists(name string) bool {
+	_, err := fs.Stat(gitignore, fmt.Sprintf("gitignore/%s.gitignore", name))
+	return err == nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const (
+	maxBranchNameLength = 250
+)
+
+var (
+	ErrBranchNameLength = &ValidationError{
+		fmt.Sprintf("Branch name has to be between 1 and %d in length.", maxBranchNameLength),
+	}
+	ErrBranchNameInvalid = &ValidationError{
+		"Branch name contains characters or sequences that aren't allowed in git references.",
+	}
+	ErrBranchPatternLength = &ValidationError{
+		fmt.Sprintf("Branch pattern can be at most %d characters long.", maxBranchNameLength),
+	}
+	ErrBranchPatternInvalid = &ValidationError{
+		"Branch pattern isn't a valid glob pattern.",
+	}
+)
+
+// BranchName checks the provided branch name and returns an error if it isn't a valid git branch name.
+// The rules follow the ones of `git check-ref-format --branch`.
+func BranchName(name string) error {
+	l := len(name)
+	if l < 1 || l > maxBranchNameLength {
+		return ErrBranchNameLength
+	}
+
+	if !isValidRefName(name) {
+		return ErrBranchNameInvalid
+	}
+
+	return nil
+}
+
+// BranchPattern checks the provided branch glob pattern and returns an error if it isn't valid.
+// An empty pattern is allowed and matches all branches.
+func BranchPattern(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+
+	if len(pattern) > maxBranchNameLength {
+		return ErrBranchPatternLength
+	}
+
+	if !doublestar.ValidatePattern(pattern) {
+		return ErrBranchPatternInvalid
+	}
+
+	return nil
+}
+
+// isValidRefName returns true if the provided name is allowed as the short name of a git reference.
+// The rules follow the ones of `git check-ref-format`.
+func isValidRefName(name string) bool {
+	if name == "@" ||
+		strings.HasPrefix(name, "-") ||
+		strings.HasPrefix(name, "/") ||
+		strings.HasSuffix(name, "/") ||
+		strings.HasSuffix(name, ".") ||
+		strings.Contains(name, "..") ||
+		strings.Contains(name, "//") ||
+		strings.Contains(name, "@{") ||
+		strings.ContainsAny(name, " ~^:?*[\\") {
+		return false
+	}
+
+	for _, r := range name {
+		if r < 32 || r == 127 {
+			return false
+		}
+	}
+
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") || strings.HasSuffix(component, ".lock") {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	minDeployKeyRSABits = 2048
+)
+
+var (
+	ErrDeployKeyRSATooShort = &ValidationError{
+		fmt.Sprintf("RSA deploy keys have to be at least %d bits long.", minDeployKeyRSABits),
+	}
+)
+
+// DeployKey checks the provided ssh public key and returns an error if it can't be used as a deploy key.
+// On top of the public key checks, deploy keys reject weak RSA keys as they are used for automation.
+func DeployKey(content string) error {
+	if err := PublicKey(content); err != nil {
+		return err
+	}
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(content))
+	if err != nil {
+		return ErrPublicKeyInvalid
+	}
+
+	cryptoKey, ok := key.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil
+	}
+
+	if rsaKey, isRSA := cryptoKey.CryptoPublicKey().(*rsa.PublicKey); isRSA && rsaKey.N.BitLen() < minDeployKeyRSABits {
+		return ErrDeployKeyRSATooShort
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	maxPublicKeyLength = 16 * 1024
+)
+
+var (
+	ErrPublicKeyLength = &ValidationError{
+		fmt.Sprintf("Public key has to be between 1 and %d in length.", maxPublicKeyLength),
+	}
+	ErrPublicKeyInvalid = &ValidationError{
+		"Public key has to be a single ssh public key in authorized_keys format without options.",
+	}
+	ErrPublicKeyTypeNotAllowed = &ValidationError{
+		"DSA public keys are not supported.",
+	}
+)
+
+// PublicKey checks the provided ssh public key and returns an error if it isn't valid.
+func PublicKey(content string) error {
+	l := len(content)
+	if l < 1 || l > maxPublicKeyLength {
+		return ErrPublicKeyLength
+	}
+
+	key, _, options, rest, err := ssh.ParseAuthorizedKey([]byte(content))
+	if err != nil || len(options) > 0 || len(strings.TrimSpace(string(rest))) > 0 {
+		return ErrPublicKeyInvalid
+	}
+
+	if key.Type() == ssh.KeyAlgoDSA {
+		return ErrPublicKeyTypeNotAllowed
+	}
+
+	return nil
+}
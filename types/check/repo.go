@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+const (
+	maxRepoHomepageLength = 1024
+
+	maxRepoTopics      = 20
+	maxRepoTopicLength = 50
+	repoTopicRegex     = "^[a-z0-9][a-z0-9-]*$"
+)
+
+var (
+	matcherRepoTopic = regexp.MustCompile(repoTopicRegex)
+
+	ErrRepoHomepageLength = &ValidationError{
+		fmt.Sprintf("Homepage can be at most %d in length.", maxRepoHomepageLength),
+	}
+	ErrRepoHomepageInvalid = &ValidationError{
+		"Homepage has to be an absolute http or https URL.",
+	}
+
+	ErrRepoTopicsCount = &ValidationError{
+		fmt.Sprintf("A repository can have at most %d topics.", maxRepoTopics),
+	}
+	ErrRepoTopicLength = &ValidationError{
+		fmt.Sprintf("Topics have to be between 1 and %d in length.", maxRepoTopicLength),
+	}
+	ErrRepoTopicInvalid = &ValidationError{
+		"Topics can only contain lowercase letters, numbers and hyphens, and have to start with a letter or number.",
+	}
+
+	ErrRepoMaxFileSizeNegative = &ValidationError{
+		"Maximum file size can't be negative.",
+	}
+)
+
+// RepoHomepage checks the provided repository homepage URL and returns an error if it isn't valid.
+// An empty homepage is valid.
+func RepoHomepage(homepage string) error {
+	if homepage == "" {
+		return nil
+	}
+
+	if len(homepage) > maxRepoHomepageLength {
+		return ErrRepoHomepageLength
+	}
+
+	u, err := url.Parse(homepage)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrRepoHomepageInvalid
+	}
+
+	return nil
+}
+
+// RepoTopics checks the provided (already normalized) repository topics and returns an error if they aren't valid.
+func RepoTopics(topics []string) error {
+	if len(topics) > maxRepoTopics {
+		return ErrRepoTopicsCount
+	}
+
+	for _, topic := range topics {
+		l := len(topic)
+		if l < 1 || l > maxRepoTopicLength {
+			return ErrRepoTopicLength
+		}
+
+		if !matcherRepoTopic.MatchString(topic) {
+			return ErrRepoTopicInvalid
+		}
+	}
+
+	return nil
+}
+
+// RepoMaxFileSize checks the provided maximum file size of a repository and returns an error if it isn't valid.
+// A size of 0 means unlimited.
+func RepoMaxFileSize(size int64) error {
+	if size < 0 {
+		return ErrRepoMaxFileSizeNegative
+	}
+
+	return nil
+}
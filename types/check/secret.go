@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	maxSecretNameLength = 100
+)
+
+var (
+	// secretNameRegex matches names that can be used as environment variables in CI.
+	secretNameRegex = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+	ErrSecretNameLength = &ValidationError{
+		fmt.Sprintf("Secret name has to be between 1 and %d in length.", maxSecretNameLength),
+	}
+	ErrSecretNameInvalid = &ValidationError{
+		"Secret name can only contain [a-zA-Z0-9_] and can't start with a digit.",
+	}
+)
+
+// SecretName checks the provided secret name and returns an error if it isn't valid.
+func SecretName(name string) error {
+	l := len(name)
+	if l < 1 || l > maxSecretNameLength {
+		return ErrSecretNameLength
+	}
+
+	if !secretNameRegex.MatchString(name) {
+		return ErrSecretNameInvalid
+	}
+
+	return nil
+}
@@ -30,7 +30,7 @@ var (
 // ServiceAccountParent verifies the remaining fields of a service account
 // that aren't inhereted from principal.
 func ServiceAccountParent(parentType enum.ParentResourceType, parentID int64) error {
-	if parentType != enum.ParentResourceTypeRepo && parentType != enum.ParentResourceTypeSpace {
+	if !parentType.Valid() {
 		return ErrServiceAccountParentTypeIsInvalid
 	}
 
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"fmt"
+)
+
+const (
+	maxTagNameLength = 250
+)
+
+var (
+	ErrTagNameLength = &ValidationError{
+		fmt.Sprintf("Tag name has to be between 1 and %d in length.", maxTagNameLength),
+	}
+	ErrTagNameInvalid = &ValidationError{
+		"Tag name contains characters or sequences that aren't allowed in git references.",
+	}
+)
+
+// TagName checks the provided tag name and returns an error if it isn't a valid git tag name.
+func TagName(name string) error {
+	l := len(name)
+	if l < 1 || l > maxTagNameLength {
+		return ErrTagNameLength
+	}
+
+	if !isValidRefName(name) {
+		return ErrTagNameInvalid
+	}
+
+	return nil
+}
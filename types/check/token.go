@@ -16,6 +16,8 @@ package check
 
 import (
 	"time"
+
+	"github.com/harness/gitness/types/enum"
 )
 
 const (
@@ -48,3 +50,14 @@ func TokenLifetime(lifetime *time.Duration, optional bool) error {
 
 	return nil
 }
+
+// TokenScopes returns an error if any of the provided scopes is unknown.
+func TokenScopes(scopes []enum.TokenScope) error {
+	for _, scope := range scopes {
+		if !scope.Valid() {
+			return NewValidationErrorf("The provided token scope '%s' is invalid.", scope)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// CommitStatus is the state reported by an external system (e.g. a CI server) for a commit.
+// It is a simplified view of a status check, identified by its context.
+type CommitStatus struct {
+	Context     string                 `json:"context"`
+	State       enum.CommitStatusState `json:"state"`
+	TargetURL   string                 `json:"target_url"`
+	Description string                 `json:"description"`
+	Created     int64                  `json:"created"`
+	Updated     int64                  `json:"updated"`
+	ReportedBy  *PrincipalInfo         `json:"reported_by,omitempty"`
+}
+
+// CombinedCommitStatus is the rollup of all commit statuses reported for a commit.
+type CombinedCommitStatus struct {
+	SHA        string                 `json:"sha"`
+	State      enum.CommitStatusState `json:"state"`
+	TotalCount int                    `json:"total_count"`
+	Statuses   []CommitStatus         `json:"statuses"`
+}
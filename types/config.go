@@ -15,6 +15,7 @@
 package types
 
 import (
+	"net/netip"
 	"time"
 
 	"github.com/harness/gitness/blob"
@@ -37,12 +38,19 @@ type Config struct {
 	// 5min should be enough for most git clones to complete.
 	GracefulShutdownTime time.Duration `envconfig:"GITNESS_GRACEFUL_SHUTDOWN_TIME" default:"300s"`
 
-	UserSignupEnabled   bool `envconfig:"GITNESS_USER_SIGNUP_ENABLED" default:"true"`
+	// UserSignupEnabled specifies whether users can register themselves.
+	// Admins can always create users, and the first user of an instance can always sign up.
+	UserSignupEnabled bool `envconfig:"GITNESS_USER_SIGNUP_ENABLED" default:"true"`
+
 	NestedSpacesEnabled bool `envconfig:"GITNESS_NESTED_SPACES_ENABLED" default:"false"`
 
 	// PublicResourceCreationEnabled specifies whether a user can create publicly accessible resources.
 	PublicResourceCreationEnabled bool `envconfig:"GITNESS_PUBLIC_RESOURCE_CREATION_ENABLED" default:"true"`
 
+	// AnonymousAccessEnabled specifies whether unauthenticated requests can read public resources.
+	// If disabled, authentication is required everywhere, regardless of the visibility of a resource.
+	AnonymousAccessEnabled bool `envconfig:"GITNESS_ANONYMOUS_ACCESS_ENABLED" default:"true"`
+
 	Profiler struct {
 		Type        string `envconfig:"GITNESS_PROFILER_TYPE"`
 		ServiceName string `envconfig:"GITNESS_PROFILER_SERVICE_NAME" default:"gitness"`
@@ -63,6 +71,11 @@ type Config struct {
 		// Value is derived from Base unless explicitly specified (e.g. http://localhost:3000/git).
 		Git string `envconfig:"GITNESS_URL_GIT"`
 
+		// GitSSHFrontend defines the URL of an external SSH frontend that serves git operations of gitness
+		// (e.g. ssh://git@ssh.example.com:2222). Gitness doesn't run an SSH server itself,
+		// SSH clone URLs are only added to repositories in case the frontend is configured.
+		GitSSHFrontend string `envconfig:"GITNESS_URL_GIT_SSH_FRONTEND"`
+
 		// API defines the external URL via which the rest API is reachable.
 		// NOTE: for routing to work properly, the request path reaching gitness has to end with `/api`
 		// (this could be after proxy path rewrite).
@@ -98,6 +111,14 @@ type Config struct {
 		TmpDir string `envconfig:"GITNESS_GIT_TMP_DIR"`
 		// HookPath points to the binary used as git server hook.
 		HookPath string `envconfig:"GITNESS_GIT_HOOK_PATH"`
+		// HookScriptsDir (optional) specifies the directory containing custom server-side hook scripts
+		// ("pre-receive" and "post-receive") that are executed for git pushes.
+		// Besides PATH, HOME and the GIT_* variables, the scripts only get GITNESS_REPO_ID, GITNESS_REPO_PATH
+		// and GITNESS_PRINCIPAL_ID as environment. GIT_DIR (and GIT_ALTERNATE_OBJECT_DIRECTORIES for the
+		// quarantined objects) allow inspecting the pushed commits with git.
+		HookScriptsDir string `envconfig:"GITNESS_GIT_HOOK_SCRIPTS_DIR"`
+		// HookScriptsTimeout is the maximum duration of a single hook script execution.
+		HookScriptsTimeout time.Duration `envconfig:"GITNESS_GIT_HOOK_SCRIPTS_TIMEOUT" default:"30s"`
 
 		// LastCommitCache holds configuration options for the last commit cache.
 		LastCommitCache struct {
@@ -119,6 +140,8 @@ type Config struct {
 	Server struct {
 		// HTTP defines the http configuration parameters
 		HTTP struct {
+			// Host is the host (or ip) the server binds to, the server listens on all interfaces if empty.
+			Host  string `envconfig:"GITNESS_HTTP_HOST"`
 			Port  int    `envconfig:"GITNESS_HTTP_PORT" default:"3000"`
 			Proto string `envconfig:"GITNESS_HTTP_PROTO" default:"http"`
 		}
@@ -171,12 +194,105 @@ type Config struct {
 		TargetPrincipal string `envconfig:"GITNESS_BLOBSTORE_TARGET_PRINCIPAL" default:""`
 
 		ImpersonationLifetime time.Duration `envconfig:"GITNESS_BLOBSTORE_IMPERSONATION_LIFETIME" default:"12h"`
+
+		// S3 defines the configuration of the s3 provider, which supports AWS S3 and S3 compatible services.
+		// The bucket name is taken from Bucket and credentials from the default AWS credential chain.
+		S3 struct {
+			Endpoint  string `envconfig:"GITNESS_BLOBSTORE_S3_ENDPOINT"`
+			Region    string `envconfig:"GITNESS_BLOBSTORE_S3_REGION"`
+			PathStyle bool   `envconfig:"GITNESS_BLOBSTORE_S3_PATH_STYLE"`
+			// SignedURLExpiry enables redirects to signed URLs for downloads (files are streamed if zero).
+			SignedURLExpiry time.Duration `envconfig:"GITNESS_BLOBSTORE_S3_SIGNED_URL_EXPIRY" default:"0"`
+		}
 	}
 
 	// Token defines token configuration parameters.
 	Token struct {
-		CookieName string        `envconfig:"GITNESS_TOKEN_COOKIE_NAME" default:"token"`
-		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
+		CookieName string `envconfig:"GITNESS_TOKEN_COOKIE_NAME" default:"token"`
+
+		// Expire is the duration a user session token (login / register) is valid.
+		// NOTE: Users can list / delete session tokens via rest API if they want to cleanup earlier.
+		Expire time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
+
+		// UnsafeDeterministic makes issued tokens predictable - ONLY meant for integration tests and demos.
+		// It's only honored if UnsafeDeterministicAck is set to the acknowledgement phrase
+		// and the server only binds to a loopback address (GITNESS_HTTP_HOST).
+		UnsafeDeterministic    bool   `envconfig:"GITNESS_TOKEN_UNSAFE_DETERMINISTIC"     default:"false"`
+		UnsafeDeterministicAck string `envconfig:"GITNESS_TOKEN_UNSAFE_DETERMINISTIC_ACK"`
+
+		// SigningKeysPath is the directory containing the PEM encoded RSA keys (<kid>.pem) used to sign tokens.
+		// The key id has to start with the unix timestamp the key becomes active (e.g. 1700000000-primary.pem).
+		// The newest active key is used for signing, older keys are only used for verification.
+		// If empty, tokens are signed with the secret of the principal they are issued for.
+		SigningKeysPath string `envconfig:"GITNESS_TOKEN_SIGNING_KEYS_PATH"`
+		// SigningKeysGracePeriod is the duration a rotated out key remains valid for verification.
+		// Tokens signed with the key (including PATs and SATs) are rejected once the grace period is over.
+		// 0 keeps rotated out keys valid until they are removed from the directory.
+		SigningKeysGracePeriod time.Duration `envconfig:"GITNESS_TOKEN_SIGNING_KEYS_GRACE_PERIOD" default:"720h"`
+		// SigningKeysReloadInterval is the interval in which the signing keys are reloaded from the directory.
+		SigningKeysReloadInterval time.Duration `envconfig:"GITNESS_TOKEN_SIGNING_KEYS_RELOAD_INTERVAL" default:"1m"`
+	}
+
+	// Auth defines authentication configuration parameters.
+	Auth struct {
+		// BlockServiceAccountLogin specifies whether service accounts are blocked from the password login flow.
+		// NOTE: Service accounts are expected to authenticate via their tokens.
+		BlockServiceAccountLogin bool `envconfig:"GITNESS_AUTH_BLOCK_SERVICE_ACCOUNT_LOGIN" default:"true"`
+
+		// CompleteOnboardingOnLogin specifies whether the onboarding of a user is completed with the first login.
+		// If disabled, the onboarding has to be completed explicitly via the api.
+		CompleteOnboardingOnLogin bool `envconfig:"GITNESS_AUTH_COMPLETE_ONBOARDING_ON_LOGIN" default:"true"`
+
+		// Source is the source users logging in with a password are authenticated against ("local" or "ldap").
+		Source string `envconfig:"GITNESS_AUTH_SOURCE" default:"local"`
+
+		// ServiceAccountTokenLifetime is the duration a service account token is valid
+		// in case no lifetime is provided during token creation (0 means the token never expires).
+		ServiceAccountTokenLifetime time.Duration `envconfig:"GITNESS_AUTH_SERVICE_ACCOUNT_TOKEN_LIFETIME" default:"0"`
+
+		// ImpersonationTokenLifetime is the duration a token is valid that allows an admin to act as another user.
+		ImpersonationTokenLifetime time.Duration `envconfig:"GITNESS_AUTH_IMPERSONATION_TOKEN_LIFETIME" default:"30m"`
+
+		Lockout struct {
+			// Threshold is the number of consecutive failed logins after which an account gets locked (0 disables it).
+			Threshold int `envconfig:"GITNESS_AUTH_LOCKOUT_THRESHOLD" default:"0"`
+			// Duration is the duration an account stays locked before it gets unlocked automatically.
+			Duration time.Duration `envconfig:"GITNESS_AUTH_LOCKOUT_DURATION" default:"15m"`
+		}
+
+		PasswordReset struct {
+			// RequireVerifiedEmail specifies whether only users with a verified email can reset their password.
+			RequireVerifiedEmail bool          `envconfig:"GITNESS_AUTH_PASSWORD_RESET_REQUIRE_VERIFIED_EMAIL" default:"false"`
+			TokenLifetime        time.Duration `envconfig:"GITNESS_AUTH_PASSWORD_RESET_TOKEN_LIFETIME"         default:"1h"`
+			// MaxOutstandingTokens is the max number of unused reset tokens per account (0 means unlimited).
+			// Older tokens beyond the limit are invalidated when a new reset is requested.
+			MaxOutstandingTokens int `envconfig:"GITNESS_AUTH_PASSWORD_RESET_MAX_OUTSTANDING_TOKENS" default:"3"`
+		}
+
+		PasswordHash struct {
+			// Algorithm is the algorithm new password hashes are created with ("bcrypt" or "argon2id").
+			// Existing hashes of other algorithms are upgraded on the next successful login.
+			Algorithm  string `envconfig:"GITNESS_AUTH_PASSWORD_HASH_ALGORITHM"   default:"bcrypt"`
+			BcryptCost int    `envconfig:"GITNESS_AUTH_PASSWORD_HASH_BCRYPT_COST" default:"10"`
+
+			Argon2id struct {
+				// Memory is the memory used by argon2id in KiB.
+				Memory      uint32 `envconfig:"GITNESS_AUTH_PASSWORD_HASH_ARGON2ID_MEMORY"      default:"65536"`
+				Iterations  uint32 `envconfig:"GITNESS_AUTH_PASSWORD_HASH_ARGON2ID_ITERATIONS"  default:"3"`
+				Parallelism uint8  `envconfig:"GITNESS_AUTH_PASSWORD_HASH_ARGON2ID_PARALLELISM" default:"4"`
+			}
+		}
+	}
+
+	// Audit defines audit log configuration parameters.
+	Audit struct {
+		// HashChain specifies whether audit entries are persisted as a hash chain (per space path),
+		// which allows to detect altered or missing entries.
+		HashChain bool `envconfig:"GITNESS_AUDIT_HASH_CHAIN" default:"false"`
+
+		// RetentionTime is the duration after which audit chain entries will be purged from the DB.
+		// The last entry of each chain is always kept. Zero disables the purging.
+		RetentionTime time.Duration `envconfig:"GITNESS_AUDIT_RETENTION_TIME" default:"0"`
 	}
 
 	Logs struct {
@@ -189,16 +305,78 @@ type Config struct {
 		}
 	}
 
-	// Cors defines http cors parameters
+	// Cors defines http cors parameters.
+	// NOTE: Credentialed requests are only allowed for exact origins - a wildcard origin can't be combined with
+	// AllowCredentials.
 	Cors struct {
 		AllowedOrigins   []string `envconfig:"GITNESS_CORS_ALLOWED_ORIGINS"   default:"*"`
 		AllowedMethods   []string `envconfig:"GITNESS_CORS_ALLOWED_METHODS"   default:"GET,POST,PATCH,PUT,DELETE,OPTIONS"`
 		AllowedHeaders   []string `envconfig:"GITNESS_CORS_ALLOWED_HEADERS"   default:"Origin,Accept,Accept-Language,Authorization,Content-Type,Content-Language,X-Requested-With,X-Request-Id"` //nolint:lll // struct tags can't be multiline
 		ExposedHeaders   []string `envconfig:"GITNESS_CORS_EXPOSED_HEADERS"   default:"Link"`
-		AllowCredentials bool     `envconfig:"GITNESS_CORS_ALLOW_CREDENTIALS" default:"true"`
+		AllowCredentials bool     `envconfig:"GITNESS_CORS_ALLOW_CREDENTIALS" default:"false"`
 		MaxAge           int      `envconfig:"GITNESS_CORS_MAX_AGE"           default:"300"`
 	}
 
+	// Timeout defines the deadlines of api requests.
+	// NOTE: A timeout of 0 disables the corresponding deadline.
+	Timeout struct {
+		Request time.Duration `envconfig:"GITNESS_TIMEOUT_REQUEST" default:"0"`
+		// Archive is the timeout of archive downloads, which can take considerably longer than other requests.
+		Archive time.Duration `envconfig:"GITNESS_TIMEOUT_ARCHIVE" default:"0"`
+	}
+
+	// BodyLimit defines the maximum size of api request bodies in bytes.
+	// NOTE: A limit of 0 disables the corresponding limit.
+	BodyLimit struct {
+		Request int64 `envconfig:"GITNESS_BODY_LIMIT_REQUEST" default:"10485760"` // 10 MiB
+		// Upload is the limit of routes uploading files (e.g. committing files or uploading avatars).
+		Upload int64 `envconfig:"GITNESS_BODY_LIMIT_UPLOAD" default:"104857600"` // 100 MiB
+	}
+
+	// RateLimit defines the parameters of the api rate limiters.
+	// NOTE: A limit of 0 disables the corresponding limiter.
+	RateLimit struct {
+		Window    time.Duration `envconfig:"GITNESS_RATE_LIMIT_WINDOW"    default:"1m"`
+		Global    int           `envconfig:"GITNESS_RATE_LIMIT_GLOBAL"    default:"0"`
+		Principal int           `envconfig:"GITNESS_RATE_LIMIT_PRINCIPAL" default:"0"`
+		Login     int           `envconfig:"GITNESS_RATE_LIMIT_LOGIN"     default:"0"`
+		// PasswordReset limits the password reset requests per client IP.
+		PasswordReset int `envconfig:"GITNESS_RATE_LIMIT_PASSWORD_RESET" default:"0"`
+		// PasswordResetAccount limits the password reset requests per account.
+		PasswordResetAccount int `envconfig:"GITNESS_RATE_LIMIT_PASSWORD_RESET_ACCOUNT" default:"0"`
+		// Scope limits the requests of scoped tokens per principal and the token scope required by the request.
+		Scope struct {
+			RepoRead  int `envconfig:"GITNESS_RATE_LIMIT_SCOPE_REPO_READ"  default:"0"`
+			RepoWrite int `envconfig:"GITNESS_RATE_LIMIT_SCOPE_REPO_WRITE" default:"0"`
+			UserAdmin int `envconfig:"GITNESS_RATE_LIMIT_SCOPE_USER_ADMIN" default:"0"`
+		}
+		// TrustedProxies are the networks (in CIDR notation) of the reverse proxies in front of gitness.
+		// The X-Forwarded-For header is only used to determine the client IP of requests sent by them.
+		TrustedProxies []netip.Prefix `envconfig:"GITNESS_RATE_LIMIT_TRUSTED_PROXIES"`
+	}
+
+	// FeatureFlags defines the state of the feature flags that gate experimental features.
+	// Flags that aren't configured fall back to their default state.
+	FeatureFlags struct {
+		// Enabled and Disabled override the default state of flags for the whole instance.
+		Enabled  []string `envconfig:"GITNESS_FEATURE_FLAGS_ENABLED"`
+		Disabled []string `envconfig:"GITNESS_FEATURE_FLAGS_DISABLED"`
+		// Users overrides the state of flags for individual users, taking precedence over the instance state.
+		// Format: "flag:uid1;!uid2,flag2:uid3" - the flag is enabled for uid1 and uid3 and disabled for uid2.
+		Users map[string]string `envconfig:"GITNESS_FEATURE_FLAGS_USERS"`
+	}
+
+	// Maintenance defines the maintenance mode, which blocks write requests (e.g. while running migrations).
+	// Admins can toggle the mode at runtime, the toggled state is persisted and shared by all instances.
+	Maintenance struct {
+		// Enabled enables the maintenance mode regardless of the toggled state.
+		Enabled bool `envconfig:"GITNESS_MAINTENANCE_ENABLED" default:"false"`
+		// RetryAfter is the duration clients are advised to wait before retrying blocked requests.
+		RetryAfter time.Duration `envconfig:"GITNESS_MAINTENANCE_RETRY_AFTER" default:"5m"`
+		// AdminBypass allows admins to perform write requests in maintenance mode.
+		AdminBypass bool `envconfig:"GITNESS_MAINTENANCE_ADMIN_BYPASS" default:"true"`
+	}
+
 	// Secure defines http security parameters.
 	Secure struct {
 		AllowedHosts          []string          `envconfig:"GITNESS_HTTP_ALLOWED_HOSTS"`
@@ -219,6 +397,12 @@ type Config struct {
 	}
 
 	Principal struct {
+		// MaxBatchSize is the max number of principals that can be requested in a single batch request.
+		MaxBatchSize int `envconfig:"GITNESS_PRINCIPAL_MAX_BATCH_SIZE" default:"100"`
+
+		// RequireIfMatch requires updates of users to provide the ETag of the user in the If-Match header.
+		RequireIfMatch bool `envconfig:"GITNESS_PRINCIPAL_REQUIRE_IF_MATCH"`
+
 		// System defines the principal information used to create the system service.
 		System struct {
 			UID         string `envconfig:"GITNESS_PRINCIPAL_SYSTEM_UID"          default:"gitness"`
@@ -324,6 +508,30 @@ type Config struct {
 		CRON        string        `envconfig:"GITNESS_REPO_SIZE_CRON" default:"0 0 * * *"`
 		MaxDuration time.Duration `envconfig:"GITNESS_REPO_SIZE_MAX_DURATION" default:"15m"`
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
+		// StatsCacheDuration is the duration for which the reported repository stats are cached.
+		StatsCacheDuration time.Duration `envconfig:"GITNESS_REPO_SIZE_STATS_CACHE_DURATION" default:"10m"`
+	}
+
+	RepoGC struct {
+		// MaxDuration is the max duration of the git garbage collection of a single repository.
+		MaxDuration time.Duration `envconfig:"GITNESS_REPO_GC_MAX_DURATION" default:"1h"`
+	}
+
+	RepoLanguages struct {
+		// CacheDuration is the duration for which the language breakdown of a commit is cached.
+		CacheDuration time.Duration `envconfig:"GITNESS_REPO_LANGUAGES_CACHE_DURATION" default:"1h"`
+		// RulesFilePath is the path of the file in the repository listing the paths excluded from the breakdown.
+		RulesFilePath string `envconfig:"GITNESS_REPO_LANGUAGES_RULES_FILE_PATH" default:".harness/languages-ignore"`
+	}
+
+	// RepoMirror configures mirrored repositories.
+	// NOTE: The remotes are subject to the loopback and private network policy of webhooks.
+	RepoMirror struct {
+		// Enabled enables the periodic sync of mirrored repositories.
+		Enabled bool   `envconfig:"GITNESS_REPO_MIRROR_ENABLED" default:"true"`
+		CRON    string `envconfig:"GITNESS_REPO_MIRROR_CRON" default:"0 * * * *"`
+		// MaxDuration is the max duration of a sync of all mirrored repositories.
+		MaxDuration time.Duration `envconfig:"GITNESS_REPO_MIRROR_MAX_DURATION" default:"30m"`
 	}
 
 	CodeOwners struct {
@@ -349,8 +557,71 @@ type Config struct {
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
 	}
 
+	RepoActivity struct {
+		Concurrency int `envconfig:"GITNESS_REPO_ACTIVITY_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_REPO_ACTIVITY_MAX_RETRIES" default:"3"`
+	}
+
 	Repos struct {
 		// DeletedRetentionTime is the duration after which deleted repositories will be purged.
 		DeletedRetentionTime time.Duration `envconfig:"GITNESS_REPOS_DELETED_RETENTION_TIME" default:"2160h"` // 90 days
+
+		// RenameRedirectTTL is the duration for which the old path of a renamed repository keeps resolving.
+		RenameRedirectTTL time.Duration `envconfig:"GITNESS_REPOS_RENAME_REDIRECT_TTL" default:"720h"` // 30 days
+	}
+
+	Idempotency struct {
+		// KeyTTL is the duration for which an idempotency key returns the originally created resource.
+		KeyTTL time.Duration `envconfig:"GITNESS_IDEMPOTENCY_KEY_TTL" default:"24h"`
+	}
+
+	// LDAP defines the LDAP server used by the "ldap" auth source.
+	LDAP struct {
+		// URL is the url of the LDAP server (e.g. ldaps://ldap.example.com:636).
+		URL                string `envconfig:"GITNESS_LDAP_URL"`
+		StartTLS           bool   `envconfig:"GITNESS_LDAP_START_TLS"`
+		InsecureSkipVerify bool   `envconfig:"GITNESS_LDAP_INSECURE_SKIP_VERIFY"`
+
+		// BindDN and BindPassword are the credentials used to search the user (anonymous search if empty).
+		BindDN       string `envconfig:"GITNESS_LDAP_BIND_DN"`
+		BindPassword string `envconfig:"GITNESS_LDAP_BIND_PASSWORD"`
+
+		// BaseDN is the DN the user search starts from.
+		BaseDN string `envconfig:"GITNESS_LDAP_BASE_DN"`
+		// SearchFilter is the filter used to find the user, "%s" is replaced with the escaped login identifier.
+		SearchFilter string `envconfig:"GITNESS_LDAP_SEARCH_FILTER" default:"(uid=%s)"`
+
+		AttributeUID         string `envconfig:"GITNESS_LDAP_ATTRIBUTE_UID"          default:"uid"`
+		AttributeEmail       string `envconfig:"GITNESS_LDAP_ATTRIBUTE_EMAIL"        default:"mail"`
+		AttributeDisplayName string `envconfig:"GITNESS_LDAP_ATTRIBUTE_DISPLAY_NAME" default:"cn"`
+		// AttributeExternalID is the immutable attribute identifying the directory entry (the DN is used if empty).
+		// Only users provisioned from an entry with the same id are linked to it on login.
+		AttributeExternalID string `envconfig:"GITNESS_LDAP_ATTRIBUTE_EXTERNAL_ID" default:"entryUUID"`
+	}
+
+	// OIDC defines the OpenID Connect identity provider users can login with (disabled if no issuer is set).
+	OIDC struct {
+		Issuer       string `envconfig:"GITNESS_OIDC_ISSUER"`
+		ClientID     string `envconfig:"GITNESS_OIDC_CLIENT_ID"`
+		ClientSecret string `envconfig:"GITNESS_OIDC_CLIENT_SECRET"`
+		// RedirectURL is the callback url registered with the provider.
+		// Value is derived from URL.API unless explicitly specified (e.g. http://localhost:3000/api/v1/oidc/callback).
+		RedirectURL string   `envconfig:"GITNESS_OIDC_REDIRECT_URL"`
+		Scopes      []string `envconfig:"GITNESS_OIDC_SCOPES"       default:"openid,profile,email"`
+
+		// AllowSignup specifies whether users that don't exist yet are provisioned with their first login.
+		AllowSignup bool `envconfig:"GITNESS_OIDC_ALLOW_SIGNUP" default:"true"`
+
+		// LinkByEmail specifies whether existing local users are linked to the identity provider
+		// based on their email with the first login. Users of other auth sources are never linked.
+		// NOTE: Only enable if the identity provider is trusted to verify the ownership of emails.
+		LinkByEmail bool `envconfig:"GITNESS_OIDC_LINK_BY_EMAIL"`
+	}
+
+	// Avatar defines the user avatar configuration parameters.
+	Avatar struct {
+		// GravatarURL is the base url of the gravatar compatible service used for users without an uploaded avatar.
+		// NOTE: Leave empty to disable the fallback (e.g. for air-gapped installations).
+		GravatarURL string `envconfig:"GITNESS_AVATAR_GRAVATAR_URL" default:"https://www.gravatar.com/avatar"`
 	}
 }
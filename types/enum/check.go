@@ -22,6 +22,11 @@ type CheckStatus string
 func (CheckStatus) Enum() []interface{}                 { return toInterfaceSlice(checkStatuses) }
 func (s CheckStatus) Sanitize() (CheckStatus, bool)     { return Sanitize(s, GetAllCheckStatuses) }
 func GetAllCheckStatuses() ([]CheckStatus, CheckStatus) { return checkStatuses, "" }
+func (s CheckStatus) Valid() bool                       { return Valid(s, GetAllCheckStatuses) }
+func (s CheckStatus) String() string                    { return string(s) }
+func ParseCheckStatus(s string) (CheckStatus, bool) {
+	return Parse(s, GetAllCheckStatuses)
+}
 
 // CheckStatus enumeration.
 const (
@@ -52,6 +57,11 @@ func (s CheckPayloadKind) Sanitize() (CheckPayloadKind, bool) {
 func GetAllCheckPayloadTypes() ([]CheckPayloadKind, CheckPayloadKind) {
 	return checkPayloadTypes, CheckPayloadKindEmpty
 }
+func (s CheckPayloadKind) Valid() bool    { return Valid(s, GetAllCheckPayloadTypes) }
+func (s CheckPayloadKind) String() string { return string(s) }
+func ParseCheckPayloadKind(s string) (CheckPayloadKind, bool) {
+	return Parse(s, GetAllCheckPayloadTypes)
+}
 
 // CheckPayloadKind enumeration.
 const (
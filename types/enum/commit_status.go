@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// CommitStatusState defines the state of a commit status.
+type CommitStatusState string
+
+func (CommitStatusState) Enum() []interface{} { return toInterfaceSlice(commitStatusStates) }
+func (s CommitStatusState) Sanitize() (CommitStatusState, bool) {
+	return Sanitize(s, GetAllCommitStatusStates)
+}
+func GetAllCommitStatusStates() ([]CommitStatusState, CommitStatusState) {
+	return commitStatusStates, ""
+}
+func (s CommitStatusState) Valid() bool    { return Valid(s, GetAllCommitStatusStates) }
+func (s CommitStatusState) String() string { return string(s) }
+func ParseCommitStatusState(s string) (CommitStatusState, bool) {
+	return Parse(s, GetAllCommitStatusStates)
+}
+
+// CommitStatusState enumeration.
+const (
+	CommitStatusStatePending CommitStatusState = "pending"
+	CommitStatusStateSuccess CommitStatusState = "success"
+	CommitStatusStateFailure CommitStatusState = "failure"
+	CommitStatusStateError   CommitStatusState = "error"
+)
+
+var commitStatusStates = sortEnum([]CommitStatusState{
+	CommitStatusStatePending,
+	CommitStatusStateSuccess,
+	CommitStatusStateFailure,
+	CommitStatusStateError,
+})
+
+// CommitStatusStateFromCheckStatus converts a status check status to a commit status state.
+// A running check is reported as pending.
+func CommitStatusStateFromCheckStatus(s CheckStatus) CommitStatusState {
+	switch s {
+	case CheckStatusSuccess:
+		return CommitStatusStateSuccess
+	case CheckStatusFailure:
+		return CommitStatusStateFailure
+	case CheckStatusError:
+		return CommitStatusStateError
+	case CheckStatusPending, CheckStatusRunning:
+		return CommitStatusStatePending
+	}
+	return CommitStatusStatePending
+}
+
+// CheckStatus converts the commit status state to a status check status.
+func (s CommitStatusState) CheckStatus() CheckStatus {
+	switch s {
+	case CommitStatusStateSuccess:
+		return CheckStatusSuccess
+	case CommitStatusStateFailure:
+		return CheckStatusFailure
+	case CommitStatusStateError:
+		return CheckStatusError
+	case CommitStatusStatePending:
+		return CheckStatusPending
+	}
+	return CheckStatusPending
+}
+
+// RollupCommitStatusStates combines the states of all commit status contexts into a single state.
+// Error takes precedence over failure, which takes precedence over pending,
+// which in turn takes precedence over success. Without any state the combined state is pending.
+func RollupCommitStatusStates(states []CommitStatusState) CommitStatusState {
+	if len(states) == 0 {
+		return CommitStatusStatePending
+	}
+
+	rollup := CommitStatusStateSuccess
+	for _, s := range states {
+		switch s {
+		case CommitStatusStateError:
+			return CommitStatusStateError
+		case CommitStatusStateFailure:
+			rollup = CommitStatusStateFailure
+		case CommitStatusStatePending:
+			if rollup != CommitStatusStateFailure {
+				rollup = CommitStatusStatePending
+			}
+		case CommitStatusStateSuccess:
+		}
+	}
+
+	return rollup
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "testing"
+
+func TestRollupCommitStatusStates(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []CommitStatusState
+		want   CommitStatusState
+	}{
+		{
+			name:   "no statuses",
+			states: nil,
+			want:   CommitStatusStatePending,
+		},
+		{
+			name:   "all success",
+			states: []CommitStatusState{CommitStatusStateSuccess, CommitStatusStateSuccess},
+			want:   CommitStatusStateSuccess,
+		},
+		{
+			name:   "pending overrides success",
+			states: []CommitStatusState{CommitStatusStateSuccess, CommitStatusStatePending, CommitStatusStateSuccess},
+			want:   CommitStatusStatePending,
+		},
+		{
+			name:   "failure overrides pending",
+			states: []CommitStatusState{CommitStatusStatePending, CommitStatusStateFailure, CommitStatusStateSuccess},
+			want:   CommitStatusStateFailure,
+		},
+		{
+			name:   "failure isn't overridden by pending",
+			states: []CommitStatusState{CommitStatusStateFailure, CommitStatusStatePending},
+			want:   CommitStatusStateFailure,
+		},
+		{
+			name:   "error overrides failure",
+			states: []CommitStatusState{CommitStatusStateFailure, CommitStatusStateError, CommitStatusStateSuccess},
+			want:   CommitStatusStateError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := RollupCommitStatusStates(test.states); got != test.want {
+				t.Errorf("Want %s, got %s", test.want, got)
+			}
+		})
+	}
+}
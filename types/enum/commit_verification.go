@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// CommitVerificationReason defines the reason behind the verification result of a commit signature.
+type CommitVerificationReason string
+
+func (CommitVerificationReason) Enum() []interface{} {
+	return toInterfaceSlice(commitVerificationReasons)
+}
+func (r CommitVerificationReason) Sanitize() (CommitVerificationReason, bool) {
+	return Sanitize(r, GetAllCommitVerificationReasons)
+}
+func GetAllCommitVerificationReasons() ([]CommitVerificationReason, CommitVerificationReason) {
+	return commitVerificationReasons, ""
+}
+func (r CommitVerificationReason) Valid() bool    { return Valid(r, GetAllCommitVerificationReasons) }
+func (r CommitVerificationReason) String() string { return string(r) }
+func ParseCommitVerificationReason(s string) (CommitVerificationReason, bool) {
+	return Parse(s, GetAllCommitVerificationReasons)
+}
+
+// CommitVerificationReason enumeration.
+const (
+	// CommitVerificationReasonUnsigned is used for commits without a signature.
+	CommitVerificationReasonUnsigned CommitVerificationReason = "unsigned"
+	// CommitVerificationReasonUnknownKey is used for commits signed by a key that isn't registered by any user.
+	CommitVerificationReasonUnknownKey CommitVerificationReason = "unknown_key"
+	// CommitVerificationReasonInvalid is used for commits with a signature that doesn't match the commit.
+	CommitVerificationReasonInvalid CommitVerificationReason = "invalid"
+	// CommitVerificationReasonEmailMismatch is used for commits with a valid signature of a registered key
+	// of a user who doesn't own the committer email.
+	CommitVerificationReasonEmailMismatch CommitVerificationReason = "email_mismatch"
+	// CommitVerificationReasonValid is used for commits with a valid signature of a registered key.
+	CommitVerificationReasonValid CommitVerificationReason = "valid"
+)
+
+var commitVerificationReasons = sortEnum([]CommitVerificationReason{
+	CommitVerificationReasonUnsigned,
+	CommitVerificationReasonUnknownKey,
+	CommitVerificationReasonInvalid,
+	CommitVerificationReasonEmailMismatch,
+	CommitVerificationReasonValid,
+})
@@ -32,6 +32,24 @@ func Sanitize[E constraints.Ordered](element E, all func() ([]E, E)) (E, bool) {
 	return defValue, false
 }
 
+// Valid reports whether the element is one of the declared values of the enumeration.
+// Unlike Sanitize, an empty element is never replaced by the default value.
+func Valid[E constraints.Ordered](element E, all func() ([]E, E)) bool {
+	allValues, _ := all()
+	_, exists := slices.BinarySearch(allValues, element)
+	return exists
+}
+
+// Parse converts the string to the enumeration and reports whether it's one of the declared values.
+func Parse[E ~string](s string, all func() ([]E, E)) (E, bool) {
+	element := E(s)
+	if !Valid(element, all) {
+		var empty E
+		return empty, false
+	}
+	return element, true
+}
+
 const (
 	id = "id"
 	// TODO [CODE-1363]: remove after identifier migration.
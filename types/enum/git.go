@@ -114,3 +114,20 @@ func ParseGitServiceType(s string) (GitServiceType, error) {
 		return GitServiceType(""), fmt.Errorf("unknown git service type provided: %q", s)
 	}
 }
+
+// GitRefType defines the type of object a git ref was resolved to.
+type GitRefType string
+
+func (GitRefType) Enum() []interface{} { return toInterfaceSlice(gitRefTypes) }
+
+const (
+	GitRefTypeBranch GitRefType = "branch"
+	GitRefTypeTag    GitRefType = "tag"
+	GitRefTypeCommit GitRefType = "commit"
+)
+
+var gitRefTypes = sortEnum([]GitRefType{
+	GitRefTypeBranch,
+	GitRefTypeTag,
+	GitRefTypeCommit,
+})
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// GlobalSearchType defines the types of resources supported by the global search.
+type GlobalSearchType string
+
+func (GlobalSearchType) Enum() []interface{} { return toInterfaceSlice(globalSearchTypes) }
+func (s GlobalSearchType) Sanitize() (GlobalSearchType, bool) {
+	return Sanitize(s, GetAllGlobalSearchTypes)
+}
+func GetAllGlobalSearchTypes() ([]GlobalSearchType, GlobalSearchType) { return globalSearchTypes, "" }
+func (s GlobalSearchType) Valid() bool                                { return Valid(s, GetAllGlobalSearchTypes) }
+func (s GlobalSearchType) String() string                             { return string(s) }
+func ParseGlobalSearchType(s string) (GlobalSearchType, bool) {
+	return Parse(s, GetAllGlobalSearchTypes)
+}
+
+const (
+	// GlobalSearchTypeRepo represents a repository.
+	GlobalSearchTypeRepo GlobalSearchType = "repository"
+	// GlobalSearchTypeSpace represents a space.
+	GlobalSearchTypeSpace GlobalSearchType = "space"
+	// GlobalSearchTypeUser represents a user.
+	GlobalSearchTypeUser GlobalSearchType = "user"
+)
+
+var globalSearchTypes = sortEnum([]GlobalSearchType{
+	GlobalSearchTypeRepo,
+	GlobalSearchTypeSpace,
+	GlobalSearchTypeUser,
+})
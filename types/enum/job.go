@@ -41,6 +41,11 @@ func (s JobState) Sanitize() (JobState, bool) {
 func GetAllJobStates() ([]JobState, JobState) {
 	return jobStates, ""
 }
+func (s JobState) Valid() bool    { return Valid(s, GetAllJobStates) }
+func (s JobState) String() string { return string(s) }
+func ParseJobState(s string) (JobState, bool) {
+	return Parse(s, GetAllJobStates)
+}
 
 // JobPriority represents priority of a background job.
 type JobPriority int
@@ -22,6 +22,11 @@ type MembershipRole string
 func (MembershipRole) Enum() []interface{}                      { return toInterfaceSlice(MembershipRoles) }
 func (m MembershipRole) Sanitize() (MembershipRole, bool)       { return Sanitize(m, GetAllMembershipRoles) }
 func GetAllMembershipRoles() ([]MembershipRole, MembershipRole) { return MembershipRoles, "" }
+func (m MembershipRole) Valid() bool                            { return Valid(m, GetAllMembershipRoles) }
+func (m MembershipRole) String() string                         { return string(m) }
+func ParseMembershipRole(s string) (MembershipRole, bool) {
+	return Parse(s, GetAllMembershipRoles)
+}
 
 var MembershipRoles = sortEnum([]MembershipRole{
 	MembershipRoleReader,
@@ -20,6 +20,11 @@ type PrincipalType string
 func (PrincipalType) Enum() []interface{}                    { return toInterfaceSlice(principalTypes) }
 func (s PrincipalType) Sanitize() (PrincipalType, bool)      { return Sanitize(s, GetAllPrincipalTypes) }
 func GetAllPrincipalTypes() ([]PrincipalType, PrincipalType) { return principalTypes, "" }
+func (s PrincipalType) Valid() bool                          { return Valid(s, GetAllPrincipalTypes) }
+func (s PrincipalType) String() string                       { return string(s) }
+func ParsePrincipalType(s string) (PrincipalType, bool) {
+	return Parse(s, GetAllPrincipalTypes)
+}
 
 const (
 	// PrincipalTypeUser represents a user.
@@ -24,6 +24,11 @@ type PullReqState string
 func (PullReqState) Enum() []interface{}                  { return toInterfaceSlice(pullReqStates) }
 func (s PullReqState) Sanitize() (PullReqState, bool)     { return Sanitize(s, GetAllPullReqStates) }
 func GetAllPullReqStates() ([]PullReqState, PullReqState) { return pullReqStates, "" }
+func (s PullReqState) Valid() bool                        { return Valid(s, GetAllPullReqStates) }
+func (s PullReqState) String() string                     { return string(s) }
+func ParsePullReqState(s string) (PullReqState, bool) {
+	return Parse(s, GetAllPullReqStates)
+}
 
 // PullReqState enumeration.
 const (
@@ -44,6 +49,11 @@ type PullReqSort string
 func (PullReqSort) Enum() []interface{}                { return toInterfaceSlice(pullReqSorts) }
 func (s PullReqSort) Sanitize() (PullReqSort, bool)    { return Sanitize(s, GetAllPullReqSorts) }
 func GetAllPullReqSorts() ([]PullReqSort, PullReqSort) { return pullReqSorts, PullReqSortNumber }
+func (s PullReqSort) Valid() bool                      { return Valid(s, GetAllPullReqSorts) }
+func (s PullReqSort) String() string                   { return string(s) }
+func ParsePullReqSort(s string) (PullReqSort, bool) {
+	return Parse(s, GetAllPullReqSorts)
+}
 
 // PullReqSort enumeration.
 const (
@@ -75,6 +85,11 @@ func (t PullReqActivityType) Sanitize() (PullReqActivityType, bool) {
 func GetAllPullReqActivityTypes() ([]PullReqActivityType, PullReqActivityType) {
 	return pullReqActivityTypes, "" // No default value
 }
+func (t PullReqActivityType) Valid() bool    { return Valid(t, GetAllPullReqActivityTypes) }
+func (t PullReqActivityType) String() string { return string(t) }
+func ParsePullReqActivityType(s string) (PullReqActivityType, bool) {
+	return Parse(s, GetAllPullReqActivityTypes)
+}
 
 // PullReqActivityType enumeration.
 const (
@@ -113,6 +128,11 @@ func (k PullReqActivityKind) Sanitize() (PullReqActivityKind, bool) {
 func GetAllPullReqActivityKinds() ([]PullReqActivityKind, PullReqActivityKind) {
 	return pullReqActivityKinds, "" // No default value
 }
+func (k PullReqActivityKind) Valid() bool    { return Valid(k, GetAllPullReqActivityKinds) }
+func (k PullReqActivityKind) String() string { return string(k) }
+func ParsePullReqActivityKind(s string) (PullReqActivityKind, bool) {
+	return Parse(s, GetAllPullReqActivityKinds)
+}
 
 // PullReqActivityKind enumeration.
 const (
@@ -139,6 +159,11 @@ func (s PullReqCommentStatus) Sanitize() (PullReqCommentStatus, bool) {
 func GetAllPullReqCommentStatuses() ([]PullReqCommentStatus, PullReqCommentStatus) {
 	return pullReqCommentStatuses, "" // No default value
 }
+func (s PullReqCommentStatus) Valid() bool    { return Valid(s, GetAllPullReqCommentStatuses) }
+func (s PullReqCommentStatus) String() string { return string(s) }
+func ParsePullReqCommentStatus(s string) (PullReqCommentStatus, bool) {
+	return Parse(s, GetAllPullReqCommentStatuses)
+}
 
 // PullReqCommentStatus enumeration.
 const (
@@ -165,6 +190,13 @@ func (decision PullReqReviewDecision) Sanitize() (PullReqReviewDecision, bool) {
 func GetAllPullReqReviewDecisions() ([]PullReqReviewDecision, PullReqReviewDecision) {
 	return pullReqReviewDecisions, "" // No default value
 }
+func (decision PullReqReviewDecision) Valid() bool {
+	return Valid(decision, GetAllPullReqReviewDecisions)
+}
+func (decision PullReqReviewDecision) String() string { return string(decision) }
+func ParsePullReqReviewDecision(s string) (PullReqReviewDecision, bool) {
+	return Parse(s, GetAllPullReqReviewDecisions)
+}
 
 // PullReqReviewDecision enumeration.
 const (
@@ -193,6 +225,13 @@ func (reviewerType PullReqReviewerType) Sanitize() (PullReqReviewerType, bool) {
 func GetAllPullReqReviewerTypes() ([]PullReqReviewerType, PullReqReviewerType) {
 	return pullReqReviewerTypes, "" // No default value
 }
+func (reviewerType PullReqReviewerType) Valid() bool {
+	return Valid(reviewerType, GetAllPullReqReviewerTypes)
+}
+func (reviewerType PullReqReviewerType) String() string { return string(reviewerType) }
+func ParsePullReqReviewerType(s string) (PullReqReviewerType, bool) {
+	return Parse(s, GetAllPullReqReviewerTypes)
+}
 
 // PullReqReviewerType enumeration.
 const (
@@ -227,6 +266,14 @@ func (m MergeMethod) Sanitize() (MergeMethod, bool) {
 	s, ok := gitenum.MergeMethod(m).Sanitize()
 	return MergeMethod(s), ok
 }
+func GetAllMergeMethods() ([]MergeMethod, MergeMethod) {
+	return MergeMethods, "" // The default value is applied by Sanitize
+}
+func (m MergeMethod) Valid() bool    { return Valid(m, GetAllMergeMethods) }
+func (m MergeMethod) String() string { return string(m) }
+func ParseMergeMethod(s string) (MergeMethod, bool) {
+	return Parse(s, GetAllMergeMethods)
+}
 
 type MergeCheckStatus string
 
@@ -238,3 +285,32 @@ const (
 	// MergeCheckStatusMergeable branch can merged cleanly into the target branch.
 	MergeCheckStatusMergeable MergeCheckStatus = "mergeable"
 )
+
+// MergeabilityStatus defines the outcome of a mergeability check between two branches.
+type MergeabilityStatus string
+
+func (MergeabilityStatus) Enum() []interface{} { return toInterfaceSlice(mergeabilityStatuses) }
+func GetAllMergeabilityStatuses() ([]MergeabilityStatus, MergeabilityStatus) {
+	return mergeabilityStatuses, "" // No default value
+}
+func (s MergeabilityStatus) Valid() bool    { return Valid(s, GetAllMergeabilityStatuses) }
+func (s MergeabilityStatus) String() string { return string(s) }
+func ParseMergeabilityStatus(s string) (MergeabilityStatus, bool) {
+	return Parse(s, GetAllMergeabilityStatuses)
+}
+
+// MergeabilityStatus enumeration.
+const (
+	// MergeabilityStatusMergeable the source branch can be merged cleanly into the target branch.
+	MergeabilityStatusMergeable MergeabilityStatus = "mergeable"
+	// MergeabilityStatusConflict the source branch conflicts with the target branch.
+	MergeabilityStatusConflict MergeabilityStatus = "conflict"
+	// MergeabilityStatusUnknown the mergeability couldn't be determined.
+	MergeabilityStatusUnknown MergeabilityStatus = "unknown"
+)
+
+var mergeabilityStatuses = sortEnum([]MergeabilityStatus{
+	MergeabilityStatusMergeable,
+	MergeabilityStatusConflict,
+	MergeabilityStatusUnknown,
+})
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RepoActivityType defines the type of an entry in the activity feed of a repository.
+type RepoActivityType string
+
+func (RepoActivityType) Enum() []interface{} { return toInterfaceSlice(repoActivityTypes) }
+func (t RepoActivityType) Sanitize() (RepoActivityType, bool) {
+	return Sanitize(t, GetAllRepoActivityTypes)
+}
+
+func GetAllRepoActivityTypes() ([]RepoActivityType, RepoActivityType) {
+	return repoActivityTypes, "" // No default value
+}
+func (t RepoActivityType) Valid() bool    { return Valid(t, GetAllRepoActivityTypes) }
+func (t RepoActivityType) String() string { return string(t) }
+func ParseRepoActivityType(s string) (RepoActivityType, bool) {
+	return Parse(s, GetAllRepoActivityTypes)
+}
+
+const (
+	// RepoActivityTypeBranchCreated is recorded when a branch gets created.
+	RepoActivityTypeBranchCreated RepoActivityType = "branch_created"
+	// RepoActivityTypeBranchUpdated is recorded when commits get pushed to a branch.
+	RepoActivityTypeBranchUpdated RepoActivityType = "branch_updated"
+	// RepoActivityTypeBranchDeleted is recorded when a branch gets deleted.
+	RepoActivityTypeBranchDeleted RepoActivityType = "branch_deleted"
+
+	// RepoActivityTypePullReqCreated is recorded when a pull request gets created.
+	RepoActivityTypePullReqCreated RepoActivityType = "pullreq_created"
+	// RepoActivityTypePullReqClosed is recorded when a pull request gets closed.
+	RepoActivityTypePullReqClosed RepoActivityType = "pullreq_closed"
+	// RepoActivityTypePullReqReopened is recorded when a pull request gets reopened.
+	RepoActivityTypePullReqReopened RepoActivityType = "pullreq_reopened"
+	// RepoActivityTypePullReqMerged is recorded when a pull request gets merged.
+	RepoActivityTypePullReqMerged RepoActivityType = "pullreq_merged"
+)
+
+var repoActivityTypes = sortEnum([]RepoActivityType{
+	RepoActivityTypeBranchCreated,
+	RepoActivityTypeBranchUpdated,
+	RepoActivityTypeBranchDeleted,
+	RepoActivityTypePullReqCreated,
+	RepoActivityTypePullReqClosed,
+	RepoActivityTypePullReqReopened,
+	RepoActivityTypePullReqMerged,
+})
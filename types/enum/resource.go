@@ -18,7 +18,7 @@ package enum
 type ParentResourceType string
 
 func (ParentResourceType) Enum() []interface{} {
-	return toInterfaceSlice(GetAllParentResourceTypes())
+	return toInterfaceSlice(parentResourceTypes)
 }
 
 var (
@@ -26,9 +26,16 @@ var (
 	ParentResourceTypeRepo  ParentResourceType = "repo"
 )
 
-func GetAllParentResourceTypes() []ParentResourceType {
-	return []ParentResourceType{
-		ParentResourceTypeSpace,
-		ParentResourceTypeRepo,
-	}
+var parentResourceTypes = sortEnum([]ParentResourceType{
+	ParentResourceTypeSpace,
+	ParentResourceTypeRepo,
+})
+
+func GetAllParentResourceTypes() ([]ParentResourceType, ParentResourceType) {
+	return parentResourceTypes, "" // No default value
+}
+func (p ParentResourceType) Valid() bool    { return Valid(p, GetAllParentResourceTypes) }
+func (p ParentResourceType) String() string { return string(p) }
+func ParseParentResourceType(s string) (ParentResourceType, bool) {
+	return Parse(s, GetAllParentResourceTypes)
 }
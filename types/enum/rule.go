@@ -41,6 +41,11 @@ func (s RuleState) Sanitize() (RuleState, bool) {
 func GetAllRuleStates() ([]RuleState, RuleState) {
 	return ruleStates, RuleStateActive
 }
+func (s RuleState) Valid() bool    { return Valid(s, GetAllRuleStates) }
+func (s RuleState) String() string { return string(s) }
+func ParseRuleState(s string) (RuleState, bool) {
+	return Parse(s, GetAllRuleStates)
+}
 
 // RuleSort contains protection rule sorting options.
 type RuleSort string
@@ -68,6 +73,11 @@ func (s RuleSort) Sanitize() (RuleSort, bool) {
 func GetAllRuleSorts() ([]RuleSort, RuleSort) {
 	return ruleSorts, RuleSortCreated
 }
+func (s RuleSort) Valid() bool    { return Valid(s, GetAllRuleSorts) }
+func (s RuleSort) String() string { return string(s) }
+func ParseRuleSort(s string) (RuleSort, bool) {
+	return Parse(s, GetAllRuleSorts)
+}
 
 // ParseRuleSortAttr parses the protection rule sorting option.
 func ParseRuleSortAttr(s string) RuleSort {
@@ -18,7 +18,7 @@ package enum
 type SettingsScope string
 
 func (SettingsScope) Enum() []interface{} {
-	return toInterfaceSlice(GetAllSettingsScopes())
+	return toInterfaceSlice(settingsScopes)
 }
 
 var (
@@ -27,11 +27,22 @@ var (
 
 	// SettingsScopeRepo defines settings stored on a repo level.
 	SettingsScopeRepo SettingsScope = "repo"
+
+	// SettingsScopeSystem defines settings stored on a system level (the scope id is ignored).
+	SettingsScopeSystem SettingsScope = "system"
 )
 
-func GetAllSettingsScopes() []SettingsScope {
-	return []SettingsScope{
-		SettingsScopeSpace,
-		SettingsScopeRepo,
-	}
+var settingsScopes = sortEnum([]SettingsScope{
+	SettingsScopeSpace,
+	SettingsScopeRepo,
+	SettingsScopeSystem,
+})
+
+func GetAllSettingsScopes() ([]SettingsScope, SettingsScope) {
+	return settingsScopes, "" // No default value
+}
+func (s SettingsScope) Valid() bool    { return Valid(s, GetAllSettingsScopes) }
+func (s SettingsScope) String() string { return string(s) }
+func ParseSettingsScope(s string) (SettingsScope, bool) {
+	return Parse(s, GetAllSettingsScopes)
 }
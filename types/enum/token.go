@@ -26,4 +26,52 @@ const (
 
 	// TokenTypeSAT is a service account access token.
 	TokenTypeSAT TokenType = "sat"
+
+	// TokenTypePasswordReset is a short-lived token used to reset the password of a user.
+	// NOTE: It can't be used to authenticate api calls.
+	TokenTypePasswordReset TokenType = "password_reset"
+
+	// TokenTypeImpersonation is a short-lived token that allows an admin to act as another user.
+	TokenTypeImpersonation TokenType = "impersonation"
 )
+
+// TokenScope represents a scope that limits what a token can be used for.
+type TokenScope string
+
+func (TokenScope) Enum() []interface{}            { return toInterfaceSlice(tokenScopes) }
+func (s TokenScope) Sanitize() (TokenScope, bool) { return Sanitize(s, GetAllTokenScopes) }
+
+func GetAllTokenScopes() ([]TokenScope, TokenScope) {
+	return tokenScopes, "" // No default value
+}
+func (s TokenScope) Valid() bool    { return Valid(s, GetAllTokenScopes) }
+func (s TokenScope) String() string { return string(s) }
+func ParseTokenScope(s string) (TokenScope, bool) {
+	return Parse(s, GetAllTokenScopes)
+}
+
+const (
+	// TokenScopeRepoRead allows read access to repositories.
+	TokenScopeRepoRead TokenScope = "repo:read"
+
+	// TokenScopeRepoWrite allows read and write access to repositories.
+	TokenScopeRepoWrite TokenScope = "repo:write"
+
+	// TokenScopeUserAdmin allows access to the user administration.
+	TokenScopeUserAdmin TokenScope = "user:admin"
+)
+
+var tokenScopes = sortEnum([]TokenScope{
+	TokenScopeRepoRead,
+	TokenScopeRepoWrite,
+	TokenScopeUserAdmin,
+})
+
+// Includes returns true if the scope includes the provided scope.
+func (s TokenScope) Includes(scope TokenScope) bool {
+	if s == scope {
+		return true
+	}
+
+	return s == TokenScopeRepoWrite && scope == TokenScopeRepoRead
+}
@@ -64,6 +64,11 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 func GetAllTriggerActions() ([]TriggerAction, TriggerAction) {
 	return triggerActions, "" // No default value
 }
+func (t TriggerAction) Valid() bool    { return Valid(t, GetAllTriggerActions) }
+func (t TriggerAction) String() string { return string(t) }
+func ParseTriggerAction(s string) (TriggerAction, bool) {
+	return Parse(s, GetAllTriggerActions)
+}
 
 var triggerActions = sortEnum([]TriggerAction{
 	TriggerActionBranchCreated,
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+type validatableEnum interface {
+	~string
+	Valid() bool
+	String() string
+}
+
+func testEnumRoundTrip[E validatableEnum](
+	t *testing.T,
+	all func() ([]E, E),
+	parse func(string) (E, bool),
+) {
+	t.Helper()
+
+	values, _ := all()
+	if len(values) == 0 {
+		t.Fatal("Want declared values, got none")
+	}
+	if !slices.IsSorted(values) {
+		t.Errorf("Want declared values to be sorted, got %v", values)
+	}
+
+	for _, value := range values {
+		if !value.Valid() {
+			t.Errorf("Want %q to be valid", value)
+		}
+		if value.String() != string(value) {
+			t.Errorf("Want string %q, got %q", string(value), value.String())
+		}
+		parsed, ok := parse(value.String())
+		if !ok || parsed != value {
+			t.Errorf("Want %q to round-trip, got %q (ok=%t)", value, parsed, ok)
+		}
+	}
+
+	for _, unknown := range []string{"not_a_declared_value", " ", "UNKNOWN_VALUE"} {
+		if E(unknown).Valid() {
+			t.Errorf("Want %q to be invalid", unknown)
+		}
+		if parsed, ok := parse(unknown); ok || parsed != "" {
+			t.Errorf("Want parsing %q to fail, got %q (ok=%t)", unknown, parsed, ok)
+		}
+	}
+}
+
+func TestEnumRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{"CheckStatus", func(t *testing.T) { testEnumRoundTrip(t, GetAllCheckStatuses, ParseCheckStatus) }},
+		{"CheckPayloadKind", func(t *testing.T) { testEnumRoundTrip(t, GetAllCheckPayloadTypes, ParseCheckPayloadKind) }},
+		{"CommitStatusState", func(t *testing.T) {
+			testEnumRoundTrip(t, GetAllCommitStatusStates, ParseCommitStatusState)
+		}},
+		{"CommitVerificationReason", func(t *testing.T) {
+			testEnumRoundTrip(t, GetAllCommitVerificationReasons, ParseCommitVerificationReason)
+		}},
+		{"GlobalSearchType", func(t *testing.T) { testEnumRoundTrip(t, GetAllGlobalSearchTypes, ParseGlobalSearchType) }},
+		{"JobState", func(t *testing.T) { testEnumRoundTrip(t, GetAllJobStates, ParseJobState) }},
+		{"MembershipRole", func(t *testing.T) { testEnumRoundTrip(t, GetAllMembershipRoles, ParseMembershipRole) }},
+		{"PrincipalType", func(t *testing.T) { testEnumRoundTrip(t, GetAllPrincipalTypes, ParsePrincipalType) }},
+		{"PullReqState", func(t *testing.T) { testEnumRoundTrip(t, GetAllPullReqStates, ParsePullReqState) }},
+		{"PullReqSort", func(t *testing.T) { testEnumRoundTrip(t, GetAllPullReqSorts, ParsePullReqSort) }},
+		{"PullReqActivityType", func(t *testing.T) {
+			testEnumRoundTrip(t, GetAllPullReqActivityTypes, ParsePullReqActivityType)
+		}},
+		{"PullReqActivityKind", func(t *testing.T) {
+			testEnumRoundTrip(t, GetAllPullReqActivityKinds, ParsePullReqActivityKind)
+		}},
+		{"PullReqCommentStatus", func(t *testing.T) {
+			testEnumRoundTrip(t, GetAllPullReqCommentStatuses, ParsePullReqCommentStatus)
+		}},
+		{"PullReqReviewDecision", func(t *testing.T) {
+			testEnumRoundTrip(t, GetAllPullReqReviewDecisions, ParsePullReqReviewDecision)
+		}},
+		{"PullReqReviewerType", func(t *testing.T) {
+			testEnumRoundTrip(t, GetAllPullReqReviewerTypes, ParsePullReqReviewerType)
+		}},
+		{"MergeMethod", func(t *testing.T) { testEnumRoundTrip(t, GetAllMergeMethods, ParseMergeMethod) }},
+		{"MergeabilityStatus", func(t *testing.T) {
+			testEnumRoundTrip(t, GetAllMergeabilityStatuses, ParseMergeabilityStatus)
+		}},
+		{"RepoActivityType", func(t *testing.T) { testEnumRoundTrip(t, GetAllRepoActivityTypes, ParseRepoActivityType) }},
+		{"RuleState", func(t *testing.T) { testEnumRoundTrip(t, GetAllRuleStates, ParseRuleState) }},
+		{"RuleSort", func(t *testing.T) { testEnumRoundTrip(t, GetAllRuleSorts, ParseRuleSort) }},
+		{"TokenScope", func(t *testing.T) { testEnumRoundTrip(t, GetAllTokenScopes, ParseTokenScope) }},
+		{"TriggerAction", func(t *testing.T) { testEnumRoundTrip(t, GetAllTriggerActions, ParseTriggerAction) }},
+		{"WebhookTrigger", func(t *testing.T) { testEnumRoundTrip(t, GetAllWebhookTriggers, ParseWebhookTrigger) }},
+		{"ParentResourceType", func(t *testing.T) {
+			testEnumRoundTrip(t, GetAllParentResourceTypes, ParseParentResourceType)
+		}},
+		{"SettingsScope", func(t *testing.T) { testEnumRoundTrip(t, GetAllSettingsScopes, ParseSettingsScope) }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
+
+func TestValid_DoesNotApplyDefault(t *testing.T) {
+	// Sanitize replaces the empty value with the default, Valid must not.
+	if sanitized, ok := RuleState("").Sanitize(); !ok || sanitized != RuleStateActive {
+		t.Errorf("Want empty rule state to be sanitized to %q, got %q", RuleStateActive, sanitized)
+	}
+	if RuleState("").Valid() {
+		t.Error("Want empty rule state to be invalid")
+	}
+}
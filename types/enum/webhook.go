@@ -130,6 +130,11 @@ func (s WebhookTrigger) Sanitize() (WebhookTrigger, bool) { return Sanitize(s, G
 func GetAllWebhookTriggers() ([]WebhookTrigger, WebhookTrigger) {
 	return webhookTriggers, "" // No default value
 }
+func (s WebhookTrigger) Valid() bool    { return Valid(s, GetAllWebhookTriggers) }
+func (s WebhookTrigger) String() string { return string(s) }
+func ParseWebhookTrigger(s string) (WebhookTrigger, bool) {
+	return Parse(s, GetAllWebhookTriggers)
+}
 
 const (
 	// WebhookTriggerBranchCreated gets triggered when a branch gets created.
@@ -150,6 +155,8 @@ const (
 	WebhookTriggerPullReqCreated WebhookTrigger = "pullreq_created"
 	// WebhookTriggerPullReqReopened gets triggered when a pull request gets reopened.
 	WebhookTriggerPullReqReopened WebhookTrigger = "pullreq_reopened"
+	// WebhookTriggerPullReqUpdated gets triggered when the title or description of a pull request gets updated.
+	WebhookTriggerPullReqUpdated WebhookTrigger = "pullreq_updated"
 	// WebhookTriggerPullReqBranchUpdated gets triggered when a pull request source branch gets updated.
 	WebhookTriggerPullReqBranchUpdated WebhookTrigger = "pullreq_branch_updated"
 	// WebhookTriggerPullReqClosed gets triggered when a pull request is closed.
@@ -158,6 +165,9 @@ const (
 	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
 	// WebhookTriggerPullReqMerged gets triggered when a pull request is merged.
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
+
+	// WebhookTriggerPing gets triggered when a ping of the webhook is requested.
+	WebhookTriggerPing WebhookTrigger = "ping"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -169,8 +179,10 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerTagDeleted,
 	WebhookTriggerPullReqCreated,
 	WebhookTriggerPullReqReopened,
+	WebhookTriggerPullReqUpdated,
 	WebhookTriggerPullReqBranchUpdated,
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerPing,
 })
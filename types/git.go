@@ -37,6 +37,7 @@ type CommitFilter struct {
 	Since        int64  `json:"since"`
 	Until        int64  `json:"until"`
 	Committer    string `json:"committer"`
+	Author       string `json:"author"`
 	IncludeStats bool   `json:"include_stats"`
 }
 
@@ -84,11 +85,26 @@ type Commit struct {
 	Author     Signature   `json:"author"`
 	Committer  Signature   `json:"committer"`
 	Stats      CommitStats `json:"stats,omitempty"`
+
+	// Verification is the verification status of the commit signature (only set for fetched and listed commits).
+	Verification *CommitVerification `json:"verification,omitempty"`
+}
+
+// CommitVerification contains the result of verifying the signature of a commit
+// against the public keys registered by the users.
+type CommitVerification struct {
+	Signed   bool                          `json:"signed"`
+	Verified bool                          `json:"verified"`
+	Signer   *PrincipalInfo                `json:"signer,omitempty"`
+	Reason   enum.CommitVerificationReason `json:"reason"`
 }
 
 type Signature struct {
 	Identity Identity  `json:"identity"`
 	When     time.Time `json:"when"`
+
+	// Principal is the user the identity email belongs to (only set for listed commits, nil if unknown).
+	Principal *PrincipalInfo `json:"principal,omitempty"`
 }
 
 type Identity struct {
@@ -108,3 +124,11 @@ type ListCommitResponse struct {
 	RenameDetails []RenameDetails `json:"rename_details"`
 	TotalCommits  int             `json:"total_commits,omitempty"`
 }
+
+// ResolvedRef is the result of resolving a git ref to the commit it points to.
+type ResolvedRef struct {
+	SHA  string          `json:"sha"`
+	Type enum.GitRefType `json:"type"`
+	// Name is the name of the matched branch or tag (empty for commits).
+	Name string `json:"name,omitempty"`
+}
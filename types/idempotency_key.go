@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// IdempotencyKey links a client provided idempotency key to the resource created by the request.
+type IdempotencyKey struct {
+	ID           int64             `json:"-"`
+	PrincipalID  int64             `json:"principal_id"`
+	ResourceType enum.ResourceType `json:"resource_type"`
+	Key          string            `json:"key"`
+	RequestHash  string            `json:"-"`
+	ResourceID   int64             `json:"resource_id"`
+	Created      int64             `json:"created"`
+}
+
+// IdempotencyRequest identifies a request that can be retried safely using an idempotency key.
+type IdempotencyRequest struct {
+	// Key is the client provided idempotency key (empty if none was provided).
+	Key string
+	// Hash is the hash of the method, url and body of the request. Retries with the same key
+	// are only accepted in case the hash matches the hash of the original request.
+	Hash string
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// MaintenanceMode describes the state of the maintenance mode.
+type MaintenanceMode struct {
+	Enabled bool `json:"enabled"`
+	// RetryAfter is the number of seconds clients are advised to wait before retrying blocked requests.
+	RetryAfter  int64 `json:"retry_after"`
+	AdminBypass bool  `json:"admin_bypass"`
+}
+
+// MaintenanceModeInput is the input for toggling the maintenance mode.
+type MaintenanceModeInput struct {
+	Enabled bool `json:"enabled"`
+}
@@ -45,6 +45,7 @@ type MembershipUser struct {
 // MembershipUserFilter holds membership user query parameters.
 type MembershipUserFilter struct {
 	ListQueryFilter
+	Role  enum.MembershipRole     `json:"role"`
 	Sort  enum.MembershipUserSort `json:"sort"`
 	Order enum.Order              `json:"order"`
 }
@@ -0,0 +1,25 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// MigrationStatus describes the migration status of the database.
+type MigrationStatus struct {
+	// Current is the version of the latest applied migration (empty if no migration has been applied).
+	Current string `json:"current"`
+	// Latest is the version of the latest available migration.
+	Latest string `json:"latest"`
+	// Pending contains the versions of the migrations that haven't been applied yet, in order.
+	Pending []string `json:"pending"`
+}
@@ -14,8 +14,59 @@
 
 package types
 
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
 // Pagination stores pagination related params.
 type Pagination struct {
 	Page int `json:"page"`
 	Size int `json:"size"`
 }
+
+// ErrInvalidCursor is returned if a cursor can't be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor stores the position of the last element of a page for keyset pagination.
+// Elements are ordered by creation time and id to guarantee a stable order under concurrent writes.
+type Cursor struct {
+	Created int64
+	ID      int64
+}
+
+// Encode returns the opaque string representation of the cursor.
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.Created, 10) + ":" + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor decodes the opaque string representation of a cursor.
+// An empty string decodes to the cursor pointing before the first element.
+func DecodeCursor(s string) (Cursor, error) {
+	if s == "" {
+		return Cursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	created, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	c := Cursor{}
+	if c.Created, err = strconv.ParseInt(created, 10, 64); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if c.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
@@ -36,6 +36,9 @@ type Principal struct {
 	// Other info
 	Created int64 `db:"principal_created"                json:"created"`
 	Updated int64 `db:"principal_updated"                json:"updated"`
+
+	// TokenGeneration is increased to invalidate all tokens issued for the principal so far.
+	TokenGeneration int64 `db:"principal_token_generation" json:"-"`
 }
 
 func (p *Principal) ToPrincipalInfo() *PrincipalInfo {
@@ -65,6 +68,25 @@ func (p *PrincipalInfo) Identifier() int64 {
 	return p.ID
 }
 
+// Whoami describes the authenticated principal, independent of whether it's a user or a service account.
+type Whoami struct {
+	ID          int64              `json:"id"`
+	UID         string             `json:"uid"`
+	DisplayName string             `json:"display_name"`
+	Email       string             `json:"email"`
+	Type        enum.PrincipalType `json:"type"`
+	Admin       bool               `json:"admin"`
+
+	// Parent is the resource a service account belongs to (nil for all other principal types).
+	Parent *WhoamiParent `json:"parent,omitempty"`
+}
+
+// WhoamiParent identifies the parent resource of a service account.
+type WhoamiParent struct {
+	Type enum.ParentResourceType `json:"type"`
+	ID   int64                   `json:"id"`
+}
+
 type PrincipalFilter struct {
 	Page  int                  `json:"page"`
 	Size  int                  `json:"size"`
@@ -76,11 +76,13 @@ func NewDiffStats(commitCount int, fileCount int) DiffStats {
 	}
 }
 
-// PullReqStats shows Diff statistics and number of conversations.
+// PullReqStats shows Diff statistics, number of conversations and the review summary.
 type PullReqStats struct {
 	DiffStats
-	Conversations   int `json:"conversations,omitempty"`
-	UnresolvedCount int `json:"unresolved_count,omitempty"`
+	Conversations    int  `json:"conversations,omitempty"`
+	UnresolvedCount  int  `json:"unresolved_count,omitempty"`
+	Approvals        int  `json:"approvals,omitempty"`
+	ChangesRequested bool `json:"changes_requested,omitempty"`
 }
 
 // PullReqFilter stores pull request query parameters.
@@ -169,3 +171,12 @@ type MergeViolations struct {
 	ConflictFiles  []string         `json:"conflict_files,omitempty"`
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
 }
+
+// PullReqMergeability is the result of checking whether a source branch can be merged into a target branch.
+type PullReqMergeability struct {
+	Status        enum.MergeabilityStatus `json:"status"`
+	SourceSHA     string                  `json:"source_sha"`
+	TargetSHA     string                  `json:"target_sha"`
+	MergeBaseSHA  string                  `json:"merge_base_sha,omitempty"`
+	ConflictFiles []string                `json:"conflict_files,omitempty"`
+}
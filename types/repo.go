@@ -23,17 +23,20 @@ import (
 // Repository represents a code repository.
 type Repository struct {
 	// TODO: int64 ID doesn't match DB
-	ID          int64  `json:"id" yaml:"id"`
-	Version     int64  `json:"-" yaml:"-"`
-	ParentID    int64  `json:"parent_id" yaml:"parent_id"`
-	Identifier  string `json:"identifier" yaml:"identifier"`
-	Path        string `json:"path" yaml:"path"`
-	Description string `json:"description" yaml:"description"`
-	IsPublic    bool   `json:"is_public" yaml:"is_public"`
-	CreatedBy   int64  `json:"created_by" yaml:"created_by"`
-	Created     int64  `json:"created" yaml:"created"`
-	Updated     int64  `json:"updated" yaml:"updated"`
-	Deleted     *int64 `json:"deleted,omitempty" yaml:"deleted"`
+	ID          int64    `json:"id" yaml:"id"`
+	Version     int64    `json:"-" yaml:"-"`
+	ParentID    int64    `json:"parent_id" yaml:"parent_id"`
+	Identifier  string   `json:"identifier" yaml:"identifier"`
+	Path        string   `json:"path" yaml:"path"`
+	Description string   `json:"description" yaml:"description"`
+	Homepage    string   `json:"homepage" yaml:"homepage"`
+	Topics      []string `json:"topics" yaml:"topics"`
+	IsPublic    bool     `json:"is_public" yaml:"is_public"`
+	IsTemplate  bool     `json:"is_template" yaml:"is_template"`
+	CreatedBy   int64    `json:"created_by" yaml:"created_by"`
+	Created     int64    `json:"created" yaml:"created"`
+	Updated     int64    `json:"updated" yaml:"updated"`
+	Deleted     *int64   `json:"deleted,omitempty" yaml:"deleted"`
 
 	Size        int64 `json:"size" yaml:"size"`
 	SizeUpdated int64 `json:"size_updated" yaml:"size_updated"`
@@ -51,9 +54,11 @@ type Repository struct {
 
 	Importing bool `json:"importing" yaml:"-"`
 	IsEmpty   bool `json:"is_empty,omitempty" yaml:"is_empty"`
+	Archived  bool `json:"archived" yaml:"archived"`
 
 	// git urls
-	GitURL string `json:"git_url" yaml:"git_url"`
+	GitURL    string `json:"git_url" yaml:"git_url"`
+	GitSSHURL string `json:"git_ssh_url,omitempty" yaml:"git_ssh_url,omitempty"`
 }
 
 // Clone makes deep copy of repository object.
@@ -64,6 +69,9 @@ func (r Repository) Clone() Repository {
 		deleted = &id
 	}
 	r.Deleted = deleted
+	if r.Topics != nil {
+		r.Topics = append([]string{}, r.Topics...)
+	}
 	return r
 }
 
@@ -87,6 +95,56 @@ type RepositorySizeInfo struct {
 	SizeUpdated int64  `json:"size_updated"`
 }
 
+// RepositoryStats contains the on-disk size and the object statistics of a repository.
+type RepositoryStats struct {
+	DiskSize    int64     `json:"disk_size"`
+	ObjectCount int64     `json:"object_count"`
+	LargestBlob *BlobSize `json:"largest_blob,omitempty"`
+	Computed    int64     `json:"computed"`
+}
+
+type BlobSize struct {
+	SHA  string `json:"sha"`
+	Size int64  `json:"size"`
+}
+
+// RepositoryGCStatus contains the status of the latest git garbage collection of a repository.
+type RepositoryGCStatus struct {
+	RepoID   int64         `json:"repo_id"`
+	State    enum.JobState `json:"state"`
+	Started  int64         `json:"started,omitempty"`
+	Finished int64         `json:"finished,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// RepositoryLanguages is the breakdown of the files of a repository at a commit by language.
+type RepositoryLanguages struct {
+	CommitSHA  string               `json:"commit_sha"`
+	TotalBytes int64                `json:"total_bytes"`
+	Languages  []RepositoryLanguage `json:"languages"`
+}
+
+// RepositoryLanguage is the share of a single language in the files of a repository.
+type RepositoryLanguage struct {
+	Language string `json:"language"`
+	Bytes    int64  `json:"bytes"`
+	// Percentage is the share of the language in the total bytes, rounded to one decimal.
+	Percentage float64 `json:"percentage"`
+}
+
+// RepositoryMirror holds the remote and the sync state of a repository mirrored from an external git URL.
+type RepositoryMirror struct {
+	RepoID    int64  `json:"repo_id"`
+	RemoteURL string `json:"remote_url"`
+	// Credentials are the encrypted credentials used to access the remote - they are never exposed.
+	Credentials   string `json:"-"`
+	CreatedBy     int64  `json:"created_by"`
+	Created       int64  `json:"created"`
+	Updated       int64  `json:"updated"`
+	LastSynced    int64  `json:"last_synced"`
+	LastSyncError string `json:"last_sync_error"`
+}
+
 func (r Repository) GetGitUID() string {
 	return r.GitUID
 }
@@ -101,6 +159,14 @@ type RepoFilter struct {
 	DeletedAt         *int64        `json:"deleted_at,omitempty"`
 	DeletedBeforeOrAt *int64        `json:"deleted_before_or_at,omitempty"`
 	Recursive         bool
+	// Archived filters the repositories by their archived state (no filter if not set).
+	Archived *bool `json:"archived,omitempty"`
+	// Topics filters the repositories by their topics (no filter if empty).
+	Topics []string `json:"topics,omitempty"`
+	// TopicsMatchAny returns repositories tagged with any of the topics instead of all of them.
+	TopicsMatchAny bool `json:"topics_match_any,omitempty"`
+	// Cursor enables keyset pagination if set (page, sort and order are ignored).
+	Cursor *Cursor `json:"-"`
 }
 
 // RepositoryGitInfo holds git info for a repository.
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoActivity represents an entry in the activity feed of a repository.
+type RepoActivity struct {
+	ID     int64                 `json:"id"`
+	RepoID int64                 `json:"repo_id"`
+	Type   enum.RepoActivityType `json:"type"`
+	// EventID is the id of the event the activity was recorded for (used to deduplicate redelivered events).
+	EventID     string          `json:"-"`
+	PrincipalID int64           `json:"-"`
+	Created     int64           `json:"created"`
+	Payload     json.RawMessage `json:"payload"`
+
+	Principal *PrincipalInfo `json:"principal,omitempty"`
+}
+
+// RepoActivityBranchPayload contains the details of a branch related repository activity.
+type RepoActivityBranchPayload struct {
+	Ref    string `json:"ref"`
+	OldSHA string `json:"old_sha,omitempty"`
+	NewSHA string `json:"new_sha,omitempty"`
+	Forced bool   `json:"forced,omitempty"`
+}
+
+// RepoActivityPullReqPayload contains the details of a pull request related repository activity.
+type RepoActivityPullReqPayload struct {
+	PullReqID int64  `json:"pullreq_id"`
+	Number    int64  `json:"number"`
+	MergeSHA  string `json:"merge_sha,omitempty"`
+}
+
+// RepoActivityFilter stores repository activity query parameters.
+type RepoActivityFilter struct {
+	Page  int                     `json:"page"`
+	Size  int                     `json:"size"`
+	Types []enum.RepoActivityType `json:"types"`
+	CreatedFilter
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoCollaboratorKey can be used as a key for finding a principal's collaborator info of a repository.
+type RepoCollaboratorKey struct {
+	RepoID      int64
+	PrincipalID int64
+}
+
+// RepoCollaborator represents a principal that's granted a role on a repository directly,
+// independent of the memberships of the spaces containing the repository.
+type RepoCollaborator struct {
+	RepoCollaboratorKey `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Role enum.MembershipRole `json:"role"`
+}
+
+// RepoCollaboratorUser adds user info to the RepoCollaborator data.
+type RepoCollaboratorUser struct {
+	RepoCollaborator
+	Principal PrincipalInfo `json:"principal"`
+	AddedBy   PrincipalInfo `json:"added_by"`
+}
@@ -0,0 +1,25 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// RepoRedirect records a previous identifier of a repository within a space.
+// Requests referencing the old path are resolved to the repository until the redirect expires.
+type RepoRedirect struct {
+	SpaceID    int64  `json:"-"`
+	Identifier string `json:"identifier"`
+	RepoID     int64  `json:"-"`
+	Created    int64  `json:"created"`
+	Expires    int64  `json:"expires"`
+}
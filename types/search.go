@@ -14,6 +14,8 @@
 
 package types
 
+import "github.com/harness/gitness/types/enum"
+
 type (
 	SearchInput struct {
 		Query string `json:"query"`
@@ -72,3 +74,22 @@ type (
 		Post  string `json:"post"`  // the string after the match within the line
 	}
 )
+
+type (
+	// GlobalSearchFilter stores the global search query parameters.
+	GlobalSearchFilter struct {
+		Query string                  `json:"query"`
+		Types []enum.GlobalSearchType `json:"types"`
+		Page  int                     `json:"page"`
+		Size  int                     `json:"size"`
+	}
+
+	// GlobalSearchResult is a single resource found by the global search.
+	// Depending on the type, exactly one of Repository, Space or User is set.
+	GlobalSearchResult struct {
+		Type       enum.GlobalSearchType `json:"type"`
+		Repository *Repository           `json:"repository,omitempty"`
+		Space      *Space                `json:"space,omitempty"`
+		User       *PrincipalInfo        `json:"user,omitempty"`
+	}
+)
@@ -20,6 +20,7 @@ type Secret struct {
 	ID          int64  `db:"secret_id"              json:"-"`
 	Description string `db:"secret_description"     json:"description"`
 	SpaceID     int64  `db:"secret_space_id"        json:"space_id"`
+	RepoID      *int64 `db:"secret_repo_id"         json:"repo_id,omitempty"`
 	CreatedBy   int64  `db:"secret_created_by"      json:"created_by"`
 	Identifier  string `db:"secret_uid"             json:"identifier"`
 	Data        string `db:"secret_data"            json:"-"`
@@ -48,6 +49,7 @@ func (s *Secret) CopyWithoutData() *Secret {
 		Description: s.Description,
 		Identifier:  s.Identifier,
 		SpaceID:     s.SpaceID,
+		RepoID:      s.RepoID,
 		Created:     s.Created,
 		Updated:     s.Updated,
 		Version:     s.Version,
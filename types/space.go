@@ -40,10 +40,12 @@ type Space struct {
 	Identifier  string `json:"identifier"`
 	Description string `json:"description"`
 	IsPublic    bool   `json:"is_public"`
-	CreatedBy   int64  `json:"created_by"`
-	Created     int64  `json:"created"`
-	Updated     int64  `json:"updated"`
-	Deleted     *int64 `json:"deleted,omitempty"`
+	// DefaultBranch is the default branch of repos created in the space (global default is used if empty).
+	DefaultBranch string `json:"default_branch,omitempty"`
+	CreatedBy     int64  `json:"created_by"`
+	Created       int64  `json:"created"`
+	Updated       int64  `json:"updated"`
+	Deleted       *int64 `json:"deleted,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
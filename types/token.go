@@ -32,6 +32,13 @@ type Token struct {
 	// IssuedAt is the unix time at which the token was issued.
 	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
 	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+	// Generation is the token generation of the principal at the time the token was issued.
+	Generation int64 `db:"token_generation"         json:"-"`
+	// Scopes optionally restricts what the token can be used for.
+	// NOTE: A token without scopes grants the full rights of its principal.
+	Scopes []enum.TokenScope `db:"-"                        json:"scopes,omitempty"`
+	// LastUsed is the unix time at which the token was last used for authentication (nil if never used).
+	LastUsed *int64 `db:"token_last_used"          json:"last_used,omitempty"`
 }
 
 // TODO [CODE-1363]: remove after identifier migration.
@@ -47,8 +54,23 @@ func (t Token) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// ActiveToken describes a token that can still be used to authenticate as its principal.
+type ActiveToken struct {
+	ID         int64          `json:"id"`
+	Type       enum.TokenType `json:"type"`
+	Identifier string         `json:"identifier"`
+	IssuedAt   int64          `json:"issued_at"`
+	LastUsed   *int64         `json:"last_used,omitempty"`
+	ExpiresAt  *int64         `json:"expires_at,omitempty"`
+	// Current is true if the token is used to authenticate the current request.
+	Current bool `json:"current"`
+}
+
 // TokenResponse is returned as part of token creation for PAT / SAT / User Session.
 type TokenResponse struct {
 	AccessToken string `json:"access_token"`
 	Token       Token  `json:"token"`
+
+	// FirstLogin is true if the user session was created for the first login of the user.
+	FirstLogin bool `json:"first_login,omitempty"`
 }
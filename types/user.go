@@ -34,7 +34,31 @@ type (
 		Updated     int64  `db:"principal_updated"        json:"updated"`
 
 		// User specific fields
-		Password string `db:"principal_user_password"    json:"-"`
+		Password      string `db:"principal_user_password"       json:"-"`
+		EmailVerified bool   `db:"principal_user_email_verified" json:"email_verified"`
+		Onboarded     bool   `db:"principal_user_onboarded"      json:"onboarded"`
+
+		// Avatar is the name of the uploaded avatar file in the blob store (empty if none was uploaded).
+		Avatar string `db:"principal_user_avatar" json:"-"`
+
+		// TokenGeneration is increased to invalidate all tokens issued for the user so far.
+		TokenGeneration int64 `db:"principal_token_generation" json:"-"`
+
+		// Version is increased with every update of the user and used for optimistic locking.
+		Version int64 `db:"principal_version" json:"-"`
+
+		// FailedLogins is the number of consecutive failed logins since the last successful login or lockout.
+		FailedLogins int `db:"principal_user_failed_logins" json:"-"`
+		// LockedUntil is the time until which the user can't login due to too many failed logins (0 if not locked).
+		LockedUntil int64 `db:"principal_user_locked_until" json:"locked_until,omitempty"`
+
+		// AuthSource is the external auth source the user was provisioned by (empty for local users).
+		AuthSource string `db:"principal_user_auth_source" json:"-"`
+		// ExternalID is the id of the user in the external auth source it was provisioned by.
+		ExternalID string `db:"principal_user_external_id" json:"-"`
+
+		// SecondaryEmails are the additional email addresses of the user (only populated for the user itself).
+		SecondaryEmails []*UserEmail `db:"-" json:"secondary_emails,omitempty"`
 	}
 
 	// UserInput store user account details used to
@@ -53,6 +77,7 @@ type (
 		Sort  enum.UserAttr `json:"sort"`
 		Order enum.Order    `json:"order"`
 		Admin bool          `json:"admin"`
+		CreatedFilter
 	}
 )
 
@@ -68,9 +93,28 @@ func (u *User) ToPrincipal() *Principal {
 		Salt:        u.Salt,
 		Created:     u.Created,
 		Updated:     u.Updated,
+
+		TokenGeneration: u.TokenGeneration,
 	}
 }
 
 func (u *User) ToPrincipalInfo() *PrincipalInfo {
 	return u.ToPrincipal().ToPrincipalInfo()
 }
+
+// UserMe contains the authenticated user together with its effective access
+// in the requested space and repository.
+type UserMe struct {
+	User  *User       `json:"user"`
+	Admin bool        `json:"admin"`
+	Space *UserAccess `json:"space,omitempty"`
+	Repo  *UserAccess `json:"repository,omitempty"`
+}
+
+// UserAccess describes the effective access of a user on a space or repository.
+type UserAccess struct {
+	Path string `json:"path"`
+	// Role is the membership role inherited from the closest space on the path (empty if none).
+	Role        enum.MembershipRole `json:"role,omitempty"`
+	Permissions []enum.Permission   `json:"permissions"`
+}
@@ -0,0 +1,27 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// UserEmail represents a secondary email address of a user.
+type UserEmail struct {
+	ID          int64  `json:"id"`
+	PrincipalID int64  `json:"-"`
+	Email       string `json:"email"`
+	Verified    bool   `json:"verified"`
+	// VerificationToken is the hash of the token required to verify the email (empty once verified).
+	VerificationToken string `json:"-"`
+	Created           int64  `json:"created"`
+	Updated           int64  `json:"updated"`
+}
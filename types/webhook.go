@@ -41,7 +41,19 @@ type Webhook struct {
 	Enabled               bool                         `json:"enabled"`
 	Insecure              bool                         `json:"insecure"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers"`
+	BranchPattern         string                       `json:"branch_pattern"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty"`
+
+	// RecentDeliveries are the statistics of the recent executions (only set for listed webhooks).
+	RecentDeliveries *WebhookDeliveryStats `json:"recent_deliveries,omitempty"`
+}
+
+// WebhookDeliveryStats contains the statistics of the executions of a webhook.
+type WebhookDeliveryStats struct {
+	Total     int64 `json:"total"`
+	Succeeded int64 `json:"succeeded"`
+	// SuccessRate is the ratio of succeeded executions (0 if there weren't any executions).
+	SuccessRate float64 `json:"success_rate"`
 }
 
 // MarshalJSON overrides the default json marshaling for `Webhook` allowing us to inject the `HasSecret` field.
@@ -105,6 +117,11 @@ type WebhookFilter struct {
 	Sort         enum.WebhookAttr `json:"sort"`
 	Order        enum.Order       `json:"order"`
 	SkipInternal bool             `json:"-"`
+	// Enabled filters the webhooks by whether they are enabled (nil for all webhooks).
+	Enabled *bool `json:"enabled"`
+	// Trigger filters the webhooks by the trigger they are subscribed to,
+	// webhooks without triggers are subscribed to all triggers.
+	Trigger enum.WebhookTrigger `json:"trigger"`
 }
 
 // WebhookExecutionFilter stores WebhookExecution query parameters for listing.
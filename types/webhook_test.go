@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWebhook_MarshalJSONOmitsSecret(t *testing.T) {
+	const secret = "very-secret-value"
+	hooks := []*Webhook{
+		{Identifier: "hook", Secret: secret, RecentDeliveries: &WebhookDeliveryStats{Total: 1, Succeeded: 1}},
+	}
+
+	raw, err := json.Marshal(hooks)
+	if err != nil {
+		t.Fatalf("failed to marshal webhooks: %v", err)
+	}
+
+	if strings.Contains(string(raw), secret) {
+		t.Errorf("Want secret to be omitted, got %s", raw)
+	}
+
+	var fields []map[string]any
+	if err = json.Unmarshal(raw, &fields); err != nil {
+		t.Fatalf("failed to unmarshal webhooks: %v", err)
+	}
+	if _, ok := fields[0]["secret"]; ok {
+		t.Errorf("Want no secret field, got %v", fields[0])
+	}
+	if got := fields[0]["has_secret"]; got != true {
+		t.Errorf("Want has_secret true, got %v", got)
+	}
+	if _, ok := fields[0]["recent_deliveries"]; !ok {
+		t.Errorf("Want recent deliveries, got %v", fields[0])
+	}
+}